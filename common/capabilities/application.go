@@ -30,6 +30,12 @@ const (
 
 	// ApplicationResourcesTreeExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
+
+	// ApplicationConfidentialFabToken is the capabilities string for FabToken transactions whose quantities are hidden behind commitments.
+	ApplicationConfidentialFabToken = "V1_4_2_CONFIDENTIAL_FABTOKEN"
+
+	// ApplicationPvtDataPurge is the capabilities string for the purge of private data keys, along with their history, by chaincodes.
+	ApplicationPvtDataPurge = "V1_4_2_PVTDATA_PURGE"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -40,6 +46,8 @@ type ApplicationProvider struct {
 	v13                    bool
 	v142                   bool
 	v11PvtDataExperimental bool
+	confidentialFabToken   bool
+	pvtDataPurge           bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -51,6 +59,8 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v142 = capabilities[ApplicationV1_4_2]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.confidentialFabToken = capabilities[ApplicationConfidentialFabToken]
+	_, ap.pvtDataPurge = capabilities[ApplicationPvtDataPurge]
 	return ap
 }
 
@@ -119,6 +129,18 @@ func (ap *ApplicationProvider) FabToken() bool {
 	return false
 }
 
+// ConfidentialFabToken returns true if this channel processes FabToken
+// transactions with the confidential token management system
+func (ap *ApplicationProvider) ConfidentialFabToken() bool {
+	return ap.confidentialFabToken
+}
+
+// PvtDataPurge returns true if chaincodes may purge private data keys, i.e., if the
+// purge marker of the hashed writes is honoured when a transaction gets committed
+func (ap *ApplicationProvider) PvtDataPurge() bool {
+	return ap.pvtDataPurge
+}
+
 // StorePvtDataOfInvalidTx returns true if the peer needs to store
 // the pvtData of invalid transactions.
 func (ap *ApplicationProvider) StorePvtDataOfInvalidTx() bool {
@@ -141,6 +163,10 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationResourcesTreeExperimental:
 		return true
+	case ApplicationConfidentialFabToken:
+		return true
+	case ApplicationPvtDataPurge:
+		return true
 	default:
 		return false
 	}
@@ -84,6 +84,28 @@ func TestFabToken(t *testing.T) {
 	assert.False(t, ap.FabToken())
 }
 
+func TestConfidentialFabToken(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.ConfidentialFabToken())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationConfidentialFabToken: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ConfidentialFabToken())
+}
+
+func TestPvtDataPurge(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.PvtDataPurge())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationPvtDataPurge: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.PvtDataPurge())
+}
+
 func TestHasCapability(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.True(t, ap.HasCapability(ApplicationV1_1))
@@ -91,5 +113,7 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationConfidentialFabToken))
+	assert.True(t, ap.HasCapability(ApplicationPvtDataPurge))
 	assert.False(t, ap.HasCapability("default"))
 }
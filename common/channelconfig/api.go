@@ -184,6 +184,13 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// ConfidentialFabToken returns true if this channel processes FabToken
+	// transactions with the confidential token management system
+	ConfidentialFabToken() bool
+
+	// PvtDataPurge returns true if chaincodes may purge private data keys along with their history
+	PvtDataPurge() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -41,12 +41,23 @@ var (
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
 )
 
+// TxIDsSnapshotFileName is the name of the snapshot file that contains the IDs of the transactions in a block store
+const TxIDsSnapshotFileName = "txids.data"
+
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
+	// BootstrapFromSnapshot prepares a new block store whose lowest block is lastBlock, using the transaction IDs
+	// in the snapshot directory snapshotDir for detecting duplicate transactions. lastConfigBlock, if not nil,
+	// is the last config block below lastBlock and remains retrievable by number. The block store is then
+	// opened with OpenBlockStore
+	BootstrapFromSnapshot(ledgerid string, snapshotDir string, lastBlock *common.Block, lastConfigBlock *common.Block) error
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// Remove removes the blocks and the index of the block store for the given ledgerid.
+	// The block store is expected to have been shut down
+	Remove(ledgerid string) error
 	Close()
 }
 
@@ -63,5 +74,11 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// Prune removes the blocks below retainFromBlockNum that can be removed without removing
+	// retainFromBlockNum itself; the blocks and transactions removed are no longer retrievable
+	Prune(retainFromBlockNum uint64) error
+	// ExportTxIds writes the IDs of all the transactions in the block store to a snapshot file in the
+	// directory dir, and returns the hash of the file keyed by file name
+	ExportTxIds(dir string) (map[string][]byte, error)
 	Shutdown()
 }
@@ -87,7 +87,13 @@ func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
 		return -1, err
 	}
 
-	beginFile := 0
+	beginFile, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return -1, err
+	}
+	if beginFile < 0 {
+		beginFile = 0
+	}
 	endFile := cpInfo.latestFileChunkSuffixNum
 
 	for endFile != beginFile {
@@ -151,6 +157,32 @@ func retrieveLastFileSuffix(rootDir string) (int, error) {
 	return biggestFileNum, err
 }
 
+// retrieveFirstFileSuffix returns the lowest suffix of the block files in rootDir, which is
+// greater than zero only if the block store has been pruned, or -1 if there is no block file
+func retrieveFirstFileSuffix(rootDir string) (int, error) {
+	logger.Debugf("retrieveFirstFileSuffix()")
+	smallestFileNum := -1
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		fileNum, err := strconv.Atoi(strings.TrimPrefix(name, blockfilePrefix))
+		if err != nil {
+			return -1, err
+		}
+		if smallestFileNum == -1 || fileNum < smallestFileNum {
+			smallestFileNum = fileNum
+		}
+	}
+	logger.Debugf("retrieveFirstFileSuffix() - smallestFileNum = %d", smallestFileNum)
+	return smallestFileNum, nil
+}
+
 func isBlockFileName(name string) bool {
 	return strings.HasPrefix(name, blockfilePrefix)
 }
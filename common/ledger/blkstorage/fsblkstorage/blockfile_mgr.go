@@ -45,6 +45,11 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	pruneInfo         atomic.Value
+	pruneLock         sync.Mutex
+	// bootstrapConfigBlock is the last config block below the lowest block of a block store
+	// bootstrapped from a snapshot
+	bootstrapConfigBlock *common.Block
 }
 
 /*
@@ -138,6 +143,31 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("error in block index: %s", err))
 	}
 
+	// Load the prune info and complete the removal of the pruned block files, if a crash interrupted it
+	pruneInfo, err := mgr.loadPruneInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get prune info from db: %s", err))
+	}
+	if pruneInfo == nil {
+		if pruneInfo, err = constructPruneInfoFromBlockFiles(rootDir); err != nil {
+			panic(fmt.Sprintf("Could not build prune info from block files: %s", err))
+		}
+	}
+	mgr.pruneInfo.Store(pruneInfo)
+	firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		panic(fmt.Sprintf("Could not retrieve the first block file: %s", err))
+	}
+	if firstFileNum >= 0 && firstFileNum < pruneInfo.firstFileNum {
+		if err = mgr.removePrunedFiles(firstFileNum, pruneInfo.firstFileNum); err != nil {
+			panic(fmt.Sprintf("Could not remove pruned block files: %s", err))
+		}
+	}
+
+	if mgr.bootstrapConfigBlock, err = mgr.loadBootstrapConfigBlock(); err != nil {
+		panic(fmt.Sprintf("Could not load the bootstrap config block: %s", err))
+	}
+
 	// Update the manager with the checkpoint info and the file writer
 	mgr.cpInfo = cpInfo
 	mgr.currentFileWriter = currentFileWriter
@@ -363,6 +393,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 		startingBlockNum = lastBlockIndexed + 1
 	} else {
 		logger.Debugf("No block indexed, Last block present in block files=[%d]", mgr.cpInfo.lastBlockNumber)
+		// the blocks of the pruned files are not indexed
+		pruneInfo := mgr.getPruneInfo()
+		startFileNum = pruneInfo.firstFileNum
+		startingBlockNum = pruneInfo.firstBlockNum
 	}
 
 	logger.Infof("Start building index from block [%d] to last block [%d]", startingBlockNum, mgr.cpInfo.lastBlockNumber)
@@ -466,6 +500,12 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if err := mgr.checkNotPruned(blockNum); err != nil {
+		if configBlock := mgr.bootstrapConfigBlock; configBlock != nil && configBlock.Header.Number == blockNum {
+			return configBlock, nil
+		}
+		return nil, err
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -492,6 +532,9 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
+	if err := mgr.checkNotPruned(blockNum); err != nil {
+		return nil, err
+	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -522,6 +565,9 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
+	if err := mgr.checkNotPruned(blockNum); err != nil {
+		return nil, err
+	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
 		return nil, err
@@ -553,6 +599,9 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.checkFileNotPruned(lp.fileSuffixNum); err != nil {
+		return nil, err
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
@@ -566,6 +615,9 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.checkFileNotPruned(lp.fileSuffixNum); err != nil {
+		return nil, err
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
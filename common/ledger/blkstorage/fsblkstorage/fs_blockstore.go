@@ -102,6 +102,16 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// Prune removes the block files that only contain blocks below retainFromBlockNum
+func (store *fsBlockStore) Prune(retainFromBlockNum uint64) error {
+	return store.fileMgr.prune(retainFromBlockNum)
+}
+
+// ExportTxIds implements the function in the interface `BlockStore`
+func (store *fsBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return store.fileMgr.exportTxIds(dir)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
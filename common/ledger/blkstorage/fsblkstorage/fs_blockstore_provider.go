@@ -17,12 +17,20 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
+	"github.com/pkg/errors"
 	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
 	"github.com/tradeline-tech/fabric/common/ledger/util"
 	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
 	"github.com/tradeline-tech/fabric/common/metrics"
+	"github.com/tradeline-tech/fabric/protos/common"
 )
 
+// maxIndexRemovalBatchSize is the maximum number of index entries deleted in a single batch
+// when removing a block store
+const maxIndexRemovalBatchSize = 1000
+
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
 type FsBlockstoreProvider struct {
 	conf            *Conf
@@ -52,6 +60,14 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle, p.stats), nil
 }
 
+// BootstrapFromSnapshot implements the function in the interface `BlockStoreProvider`
+func (p *FsBlockstoreProvider) BootstrapFromSnapshot(ledgerid string, snapshotDir string,
+	lastBlock *common.Block, lastConfigBlock *common.Block) error {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	return bootstrapFromSnapshot(p.conf.getLedgerBlockDir(ledgerid), indexStoreHandle, p.indexConfig,
+		snapshotDir, lastBlock, lastConfigBlock)
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -63,6 +79,32 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove implements the function in the interface `BlockStoreProvider`. The index is removed before
+// the blocks, a removal that gets interrupted is completed by invoking Remove again
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	itr := indexStoreHandle.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(itr.Key())
+		if batch.Len() < maxIndexRemovalBatchSize {
+			continue
+		}
+		if err := indexStoreHandle.WriteBatch(batch, true); err != nil {
+			return err
+		}
+		batch = leveldbhelper.NewUpdateBatch()
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error iterating over the index of ledger [%s]", ledgerid)
+	}
+	if err := indexStoreHandle.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	return errors.Wrapf(os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)), "error removing the blocks of ledger [%s]", ledgerid)
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
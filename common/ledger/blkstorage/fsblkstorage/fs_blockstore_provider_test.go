@@ -148,6 +148,41 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestRemove(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store1, _ := provider.OpenBlockStore("ledger1")
+	store2, _ := provider.OpenBlockStore("ledger2")
+	defer store2.Shutdown()
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	for _, b := range blocks {
+		assert.NoError(t, store1.AddBlock(b))
+		assert.NoError(t, store2.AddBlock(b))
+	}
+	store1.Shutdown()
+
+	assert.NoError(t, provider.Remove("ledger1"))
+	exists, err := provider.Exists("ledger1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	storeNames, _ := provider.List()
+	assert.Equal(t, []string{"ledger2"}, storeNames)
+	checkBlocks(t, blocks, store2)
+
+	// the removed ledger starts anew when created again
+	store1, err = provider.CreateBlockStore("ledger1")
+	assert.NoError(t, err)
+	defer store1.Shutdown()
+	bcInfo, err := store1.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.Height)
+	_, err = store1.RetrieveBlockByHash(blocks[0].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/util"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	l "github.com/tradeline-tech/fabric/core/ledger"
+)
+
+var (
+	blkMgrPruneInfoKey = []byte("blkMgrPruneInfo")
+)
+
+// pruneInfo records the lowest block file, and the lowest block, retained by the block store.
+// Pruning removes whole block files, so the lowest block retained is the first block of the
+// lowest block file retained.
+type pruneInfo struct {
+	firstFileNum  int
+	firstBlockNum uint64
+}
+
+// prune removes the block files that only contain blocks below retainFromBlockNum.
+// The prune info is saved before the index entries and the block files are removed, so that
+// the blocks being removed are reported as pruned even if the peer crashes in the middle of the
+// operation; the removal of the files left behind by a crash is completed at the next start.
+//
+// The index entries keyed by block number and block hash are removed with the files. The entries
+// keyed by transaction ID are kept, so that the transaction IDs of the pruned blocks are still
+// detected as duplicates; retrieving the transactions they point to returns a PrunedErr.
+func (mgr *blockfileMgr) prune(retainFromBlockNum uint64) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if retainFromBlockNum >= bcInfo.Height {
+		return errors.Errorf("cannot prune the blocks below block number [%d]: the blockchain height is [%d]",
+			retainFromBlockNum, bcInfo.Height)
+	}
+	currentPruneInfo := mgr.getPruneInfo()
+	if retainFromBlockNum <= currentPruneInfo.firstBlockNum {
+		logger.Debugf("No block to prune below block number [%d], the lowest block retained is [%d]",
+			retainFromBlockNum, currentPruneInfo.firstBlockNum)
+		return nil
+	}
+	flp, err := mgr.index.getBlockLocByBlockNum(retainFromBlockNum)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error locating block number [%d]", retainFromBlockNum))
+	}
+	if flp.fileSuffixNum <= currentPruneInfo.firstFileNum {
+		logger.Debugf("No block file to prune below block number [%d], it is stored in the lowest block file retained [%d]",
+			retainFromBlockNum, flp.fileSuffixNum)
+		return nil
+	}
+	firstBlockNum, err := retriveFirstBlockNumFromFile(mgr.rootDir, flp.fileSuffixNum)
+	if err != nil {
+		return err
+	}
+
+	newPruneInfo := &pruneInfo{firstFileNum: flp.fileSuffixNum, firstBlockNum: firstBlockNum}
+	if err := mgr.savePruneInfo(newPruneInfo); err != nil {
+		return err
+	}
+	mgr.pruneInfo.Store(newPruneInfo)
+	logger.Infof("Pruning block files [%d] to [%d], the lowest block retained is [%d]",
+		currentPruneInfo.firstFileNum, newPruneInfo.firstFileNum-1, newPruneInfo.firstBlockNum)
+	return mgr.removePrunedFiles(currentPruneInfo.firstFileNum, newPruneInfo.firstFileNum)
+}
+
+// removePrunedFiles removes the block files in the range [startFileNum, endFileNum) that are still
+// present, along with the index entries of their blocks
+func (mgr *blockfileMgr) removePrunedFiles(startFileNum, endFileNum int) error {
+	for fileNum := startFileNum; fileNum < endFileNum; fileNum++ {
+		filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+		exists, _, err := util.FileExists(filePath)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err := mgr.removeIndexEntriesOfFile(fileNum); err != nil {
+			return err
+		}
+		logger.Debugf("Removing the pruned block file [%s]", filePath)
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "error removing the pruned block file [%s]", filePath)
+		}
+	}
+	return nil
+}
+
+func (mgr *blockfileMgr) removeIndexEntriesOfFile(fileNum int) error {
+	stream, err := newBlockfileStream(mgr.rootDir, fileNum, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		blockInfo, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrBlockHash) {
+			batch.Delete(constructBlockHashKey(blockInfo.blockHeader.Hash()))
+		}
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNum) {
+			batch.Delete(constructBlockNumKey(blockInfo.blockHeader.Number))
+		}
+		if mgr.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
+			for txIndex := range blockInfo.txOffsets {
+				batch.Delete(constructBlockNumTranNumKey(blockInfo.blockHeader.Number, uint64(txIndex)))
+			}
+		}
+	}
+	return mgr.db.WriteBatch(batch, true)
+}
+
+func (mgr *blockfileMgr) getPruneInfo() *pruneInfo {
+	return mgr.pruneInfo.Load().(*pruneInfo)
+}
+
+// checkNotPruned returns a PrunedErr if the block with the passed number has been pruned
+func (mgr *blockfileMgr) checkNotPruned(blockNum uint64) error {
+	if pi := mgr.getPruneInfo(); blockNum < pi.firstBlockNum {
+		return &l.PrunedErr{FirstBlockNum: pi.firstBlockNum}
+	}
+	return nil
+}
+
+// checkFileNotPruned returns a PrunedErr if the block file with the passed number has been pruned
+func (mgr *blockfileMgr) checkFileNotPruned(fileNum int) error {
+	if pi := mgr.getPruneInfo(); fileNum < pi.firstFileNum {
+		return &l.PrunedErr{FirstBlockNum: pi.firstBlockNum}
+	}
+	return nil
+}
+
+// loadPruneInfo loads the prune info from the db, or returns nil if the block store has never been pruned
+func (mgr *blockfileMgr) loadPruneInfo() (*pruneInfo, error) {
+	b, err := mgr.db.Get(blkMgrPruneInfoKey)
+	if b == nil || err != nil {
+		return nil, err
+	}
+	i := &pruneInfo{}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded pruneInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) savePruneInfo(i *pruneInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(blkMgrPruneInfoKey, b, true)
+}
+
+// constructPruneInfoFromBlockFiles derives the prune info from the lowest block file present,
+// for the block stores whose index has been dropped
+func constructPruneInfoFromBlockFiles(rootDir string) (*pruneInfo, error) {
+	firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if firstFileNum <= 0 {
+		return &pruneInfo{}, nil
+	}
+	firstBlockNum, err := retriveFirstBlockNumFromFile(rootDir, firstFileNum)
+	if err != nil {
+		return nil, err
+	}
+	return &pruneInfo{firstFileNum: firstFileNum, firstBlockNum: firstBlockNum}, nil
+}
+
+func (i *pruneInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstFileNum [%d]", i.firstFileNum)
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNum); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstBlockNum [%d]", i.firstBlockNum)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *pruneInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileNum = int(val)
+	if i.firstBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *pruneInfo) String() string {
+	return fmt.Sprintf("firstFileNum=[%d], firstBlockNum=[%d]", i.firstFileNum, i.firstBlockNum)
+}
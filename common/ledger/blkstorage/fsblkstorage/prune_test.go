@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/common/ledger/util"
+	l "github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+// addBlocksInFiles adds the blocks to blkfileMgr, moving to the next file every 10 blocks;
+// the block ranges in files are [(0, 10):file0, (11,20):file1, (21,30):file2, (31, 40):file3, (41,49):file4]
+func addBlocksInFiles(t *testing.T, blkfileMgr *blockfileMgr, blocks []*common.Block) {
+	for i, b := range blocks {
+		assert.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%10 == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+}
+
+func assertBlockFilesExist(t *testing.T, rootDir string, fileNums []int, expected bool) {
+	for _, fileNum := range fileNums {
+		exists, _, err := util.FileExists(deriveBlockfilePath(rootDir, fileNum))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, exists, "unexpected existence of block file [%d]", fileNum)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	addBlocksInFiles(t, blkfileMgr, blocks)
+	prunedTxID, err := utils.GetOrComputeTxIDFromEnvelope(blocks[5].Data.Data[0])
+	assert.NoError(t, err)
+
+	assert.NoError(t, blkfileMgr.prune(25))
+	assert.Equal(t, &pruneInfo{firstFileNum: 2, firstBlockNum: 21}, blkfileMgr.getPruneInfo())
+	assertBlockFilesExist(t, blkfileMgr.rootDir, []int{0, 1}, false)
+	assertBlockFilesExist(t, blkfileMgr.rootDir, []int{2, 3, 4}, true)
+
+	t.Run("pruned blocks", func(t *testing.T) {
+		_, err := blkfileMgr.retrieveBlockByNumber(20)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+		_, err = blkfileMgr.retrieveTransactionByBlockNumTranNum(20, 0)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+		_, err = blkfileMgr.retrieveBlockByHash(blocks[5].Header.Hash())
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+		itr, err := blkfileMgr.retrieveBlocks(0)
+		assert.NoError(t, err)
+		defer itr.Close()
+		_, err = itr.Next()
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+	})
+
+	t.Run("transactions of pruned blocks", func(t *testing.T) {
+		_, err := blkfileMgr.retrieveTransactionByID(prunedTxID)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+		_, err = blkfileMgr.retrieveBlockByTxID(prunedTxID)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+		_, err = blkfileMgr.retrieveTxValidationCodeByTxID(prunedTxID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("retained blocks", func(t *testing.T) {
+		blkfileMgrWrapper.testGetBlockByNumber(blocks[21:], 21, nil)
+		blkfileMgrWrapper.testGetBlockByHash(blocks[21:], nil)
+		itr, err := blkfileMgr.retrieveBlocks(21)
+		assert.NoError(t, err)
+		defer itr.Close()
+		block, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[21], block)
+	})
+
+	t.Run("nothing to prune", func(t *testing.T) {
+		assert.NoError(t, blkfileMgr.prune(15))
+		assert.NoError(t, blkfileMgr.prune(29))
+		assert.Equal(t, &pruneInfo{firstFileNum: 2, firstBlockNum: 21}, blkfileMgr.getPruneInfo())
+	})
+
+	t.Run("beyond the blockchain height", func(t *testing.T) {
+		err := blkfileMgr.prune(50)
+		assert.EqualError(t, err, "cannot prune the blocks below block number [50]: the blockchain height is [50]")
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		blkfileMgrWrapper.close()
+		env.provider.Close()
+		env = newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 0))
+		blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+		blkfileMgr = blkfileMgrWrapper.blockfileMgr
+
+		assert.Equal(t, &pruneInfo{firstFileNum: 2, firstBlockNum: 21}, blkfileMgr.getPruneInfo())
+		_, err := blkfileMgr.retrieveBlockByNumber(20)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 21}, err)
+		blkfileMgrWrapper.testGetBlockByNumber(blocks[21:], 21, nil)
+		assert.NoError(t, blkfileMgr.addBlock(testutil.ConstructBlock(t, 50, blocks[49].Header.Hash(), nil, false)))
+	})
+}
+
+func TestPruneRecovery(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	addBlocksInFiles(t, blkfileMgr, blocks)
+	rootDir := blkfileMgr.rootDir
+
+	// simulate a crash after the prune info is saved and before the pruned files are removed
+	assert.NoError(t, blkfileMgr.savePruneInfo(&pruneInfo{firstFileNum: 3, firstBlockNum: 31}))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+	assertBlockFilesExist(t, rootDir, []int{0, 1, 2}, true)
+
+	env = newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr = blkfileMgrWrapper.blockfileMgr
+	assertBlockFilesExist(t, rootDir, []int{0, 1, 2}, false)
+	_, err := blkfileMgr.retrieveBlockByHash(blocks[25].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[31:], 31, nil)
+}
+
+func TestPruneInfoFromBlockFiles(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	pi, err := constructPruneInfoFromBlockFiles(blkfileMgr.rootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &pruneInfo{}, pi)
+
+	addBlocksInFiles(t, blkfileMgr, blocks)
+	assert.NoError(t, blkfileMgr.prune(35))
+	pi, err = constructPruneInfoFromBlockFiles(blkfileMgr.rootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &pruneInfo{firstFileNum: 3, firstBlockNum: 31}, pi)
+
+	fileNum, err := binarySearchFileNumForBlock(blkfileMgr.rootDir, 45)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, fileNum)
+	fileNum, err = binarySearchFileNumForBlock(blkfileMgr.rootDir, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fileNum)
+}
+
+func TestPrunedLedgerRollbackAndReset(t *testing.T) {
+	path := testPath()
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	addBlocksInFiles(t, blkfileMgrWrapper.blockfileMgr, blocks)
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.prune(25))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	err := ValidateRollbackParams(path, "testLedger", 20)
+	assert.EqualError(t, err, "target block number [20] should not be less than the lowest block number retained after pruning [21]")
+	assert.NoError(t, ValidateRollbackParams(path, "testLedger", 21))
+
+	err = ResetBlockStore(path)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has been pruned and cannot be reset to the genesis block")
+}
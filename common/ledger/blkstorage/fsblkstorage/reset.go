@@ -12,6 +12,8 @@ import (
 	"path"
 	"strconv"
 
+	"github.com/pkg/errors"
+
 	"github.com/tradeline-tech/fabric/common/ledger/util"
 )
 
@@ -62,6 +64,13 @@ func resetToGenesisBlk(ledgerDir string) error {
 	if lastFileNum < 0 {
 		return nil
 	}
+	firstFileNum, err := retrieveFirstFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if firstFileNum > 0 {
+		return errors.Errorf("ledger [%s] has been pruned and cannot be reset to the genesis block", ledgerDir)
+	}
 	zeroFilePath, genesisBlkEndOffset, err := retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir)
 	if err != nil {
 		return err
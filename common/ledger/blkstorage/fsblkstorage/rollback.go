@@ -300,5 +300,13 @@ func validateTargetBlkNum(ledgerDir string, targetBlockNum uint64) error {
 		return errors.Errorf("target block number [%d] should be less than the biggest block number [%d]",
 			targetBlockNum, cpInfo.lastBlockNumber)
 	}
+	pruneInfo, err := constructPruneInfoFromBlockFiles(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if targetBlockNum < pruneInfo.firstBlockNum {
+		return errors.Errorf("target block number [%d] should not be less than the lowest block number retained after pruning [%d]",
+			targetBlockNum, pruneInfo.firstBlockNum)
+	}
 	return nil
 }
@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/snapshot"
+	"github.com/tradeline-tech/fabric/common/ledger/util"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/protos/common"
+)
+
+var (
+	bootstrapConfigBlockKey = []byte("bootstrapConfigBlock")
+)
+
+// maxBatchSizeForTxIDsImport is the number of index entries written to the index db in a single batch
+const maxBatchSizeForTxIDsImport = 30000
+
+// exportTxIds writes the IDs and the validation codes of all the transactions indexed by the block store
+// to a snapshot file in the directory dir. Each record of the file holds a transaction ID and its validation code
+func (mgr *blockfileMgr) exportTxIds(dir string) (map[string][]byte, error) {
+	if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) ||
+		!mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+		return nil, errors.Errorf("exporting the transaction IDs requires the attributes [%s] and [%s] to be indexed",
+			blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrTxValidationCode)
+	}
+	w, err := snapshot.CreateFile(filepath.Join(dir, blkstorage.TxIDsSnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	itr := mgr.db.GetIterator([]byte{txIDIdxKeyPrefix}, []byte{txIDIdxKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		txID := string(itr.Key()[1:])
+		code, err := mgr.index.getTxValidationCodeByTxID(txID)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error retrieving the validation code of transaction "+txID)
+		}
+		if err := w.EncodeString(txID); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeUVarint(uint64(code)); err != nil {
+			return nil, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over the transaction IDs")
+	}
+	fileHash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{blkstorage.TxIDsSnapshotFileName: fileHash}, nil
+}
+
+// bootstrapFromSnapshot prepares the block files and the index of a new block store, so that the block store
+// opens as if it had been pruned below lastBlock: lastBlock is the only block stored, in the block file [1],
+// and the transaction IDs listed in the snapshot file written by exportTxIds are indexed as belonging to the
+// pruned block file [0], so that they are detected as duplicates. The last config block, if it precedes
+// lastBlock, is kept aside in the index db to remain retrievable by number.
+func bootstrapFromSnapshot(rootDir string, db *leveldbhelper.DBHandle, indexConfig *blkstorage.IndexConfig,
+	snapshotDir string, lastBlock *common.Block, lastConfigBlock *common.Block) error {
+
+	exists, _, err := util.FileExists(rootDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("cannot bootstrap the block store from a snapshot: the directory [%s] already exists", rootDir)
+	}
+	if _, err := util.CreateDirIfMissing(rootDir); err != nil {
+		return errors.Wrapf(err, "error creating block storage root dir [%s]", rootDir)
+	}
+
+	blockBytes, blockInfo, err := serializeBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error serializing block")
+	}
+	writer, err := newBlockfileWriter(deriveBlockfilePath(rootDir, 1))
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+	if err := writer.append(proto.EncodeVarint(uint64(len(blockBytes))), false); err != nil {
+		return err
+	}
+	if err := writer.append(blockBytes, true); err != nil {
+		return err
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	pruneInfoBytes, err := (&pruneInfo{firstFileNum: 1, firstBlockNum: lastBlock.Header.Number}).marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(blkMgrPruneInfoKey, pruneInfoBytes)
+	if lastConfigBlock != nil {
+		configBlockBytes, err := proto.Marshal(lastConfigBlock)
+		if err != nil {
+			return errors.Wrap(err, "error marshaling the last config block")
+		}
+		batch.Put(bootstrapConfigBlockKey, configBlockBytes)
+	}
+	if err := db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+
+	// the transactions of lastBlock are indexed when the block store is opened
+	txIDsOfLastBlock := map[string]bool{}
+	for _, txOffset := range blockInfo.txOffsets {
+		txIDsOfLastBlock[txOffset.txID] = true
+	}
+	return importTxIds(db, indexConfig, snapshotDir, txIDsOfLastBlock)
+}
+
+func importTxIds(db *leveldbhelper.DBHandle, indexConfig *blkstorage.IndexConfig, snapshotDir string, skip map[string]bool) error {
+	r, err := snapshot.OpenFile(filepath.Join(snapshotDir, blkstorage.TxIDsSnapshotFileName))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	index, err := newBlockIndex(indexConfig, db)
+	if err != nil {
+		return err
+	}
+	prunedFileFlpBytes, err := (&fileLocPointer{fileSuffixNum: 0}).marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		hasMore, err := r.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		txID, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		code, err := r.DecodeUVarint()
+		if err != nil {
+			return err
+		}
+		if skip[txID] {
+			continue
+		}
+		if index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+			batch.Put(constructTxIDKey(txID), prunedFileFlpBytes)
+		}
+		if index.isAttributeIndexed(blkstorage.IndexableAttrBlockTxID) {
+			batch.Put(constructBlockTxIDKey(txID), prunedFileFlpBytes)
+		}
+		if index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+			batch.Put(constructTxValidationCodeIDKey(txID), []byte{byte(code)})
+		}
+		if len(batch.KVs) >= maxBatchSizeForTxIDsImport {
+			if err := db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return db.WriteBatch(batch, true)
+}
+
+// loadBootstrapConfigBlock loads the config block kept aside when the block store was bootstrapped from
+// a snapshot, or returns nil if there is none
+func (mgr *blockfileMgr) loadBootstrapConfigBlock() (*common.Block, error) {
+	b, err := mgr.db.Get(bootstrapConfigBlockKey)
+	if b == nil || err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(b, block); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the bootstrap config block")
+	}
+	return block, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
+	l "github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func TestExportTxIdsAndBootstrapFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	fileHashes, err := blkfileMgrWrapper.blockfileMgr.exportTxIds(snapshotDir)
+	assert.NoError(t, err)
+	assert.Contains(t, fileHashes, blkstorage.TxIDsSnapshotFileName)
+	blkfileMgrWrapper.close()
+
+	assert.NoError(t, env.provider.BootstrapFromSnapshot("bootstrappedLedger", snapshotDir, blocks[9], blocks[0]))
+	err = env.provider.BootstrapFromSnapshot("bootstrappedLedger", snapshotDir, blocks[9], blocks[0])
+	assert.Contains(t, err.Error(), "already exists")
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "bootstrappedLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	bcInfo := blkfileMgr.getBlockchainInfo()
+	assert.Equal(t, uint64(10), bcInfo.Height)
+	assert.Equal(t, blocks[9].Header.Hash(), bcInfo.CurrentBlockHash)
+	assert.Equal(t, &pruneInfo{firstFileNum: 1, firstBlockNum: 9}, blkfileMgr.getPruneInfo())
+
+	t.Run("retrievable blocks", func(t *testing.T) {
+		blkfileMgrWrapper.testGetBlockByNumber(blocks[9:], 9, nil)
+		blkfileMgrWrapper.testGetBlockByHash(blocks[9:], nil)
+		configBlock, err := blkfileMgr.retrieveBlockByNumber(0)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(blocks[0], configBlock))
+		_, err = blkfileMgr.retrieveBlockByNumber(5)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 9}, err)
+	})
+
+	t.Run("transactions of the snapshot", func(t *testing.T) {
+		txID, err := utils.GetOrComputeTxIDFromEnvelope(blocks[5].Data.Data[0])
+		assert.NoError(t, err)
+		validationCode, err := blkfileMgr.retrieveTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, peer.TxValidationCode_VALID, validationCode)
+		_, err = blkfileMgr.retrieveTransactionByID(txID)
+		assert.Equal(t, &l.PrunedErr{FirstBlockNum: 9}, err)
+
+		txID, err = utils.GetOrComputeTxIDFromEnvelope(blocks[9].Data.Data[0])
+		assert.NoError(t, err)
+		blkfileMgrWrapper.testGetTransactionByTxID(txID, blocks[9].Data.Data[0], nil)
+	})
+
+	t.Run("next block", func(t *testing.T) {
+		nextBlock := testutil.ConstructBlock(t, 10, blocks[9].Header.Hash(), nil, false)
+		assert.NoError(t, blkfileMgr.addBlock(nextBlock))
+		blkfileMgrWrapper.testGetBlockByNumber([]*common.Block{nextBlock}, 10, nil)
+	})
+}
+
+func TestExportTxIdsRequiresIndex(t *testing.T) {
+	env := newTestEnvSelectiveIndexing(t, NewConf(testPath(), 0),
+		[]blkstorage.IndexableAttr{blkstorage.IndexableAttrTxID}, &disabled.Provider{})
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+
+	_, err := blkfileMgrWrapper.blockfileMgr.exportTxIds(testPath())
+	assert.EqualError(t, err, "exporting the transaction IDs requires the attributes [TxID] and [TxValidationCode] to be indexed")
+}
@@ -19,6 +19,7 @@ package fileledger
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/ledger/blockledger"
 	"github.com/tradeline-tech/fabric/common/metrics/disabled"
 	genesisconfig "github.com/tradeline-tech/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/tradeline-tech/fabric/protos/common"
 )
 
 type mockBlockStoreProvider struct {
@@ -44,6 +46,11 @@ func (mbsp *mockBlockStoreProvider) OpenBlockStore(ledgerid string) (blkstorage.
 	return mbsp.blockstore, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) BootstrapFromSnapshot(ledgerid string, snapshotDir string,
+	lastBlock *cb.Block, lastConfigBlock *cb.Block) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Exists(ledgerid string) (bool, error) {
 	return mbsp.exists, mbsp.error
 }
@@ -52,6 +59,10 @@ func (mbsp *mockBlockStoreProvider) List() ([]string, error) {
 	return mbsp.list, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) Remove(ledgerid string) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Close() {
 }
 
@@ -94,3 +105,23 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(dir)
+
+	flf := New(dir, &disabled.Provider{})
+	defer flf.Close()
+	for _, chainID := range []string{"foo", "bar"} {
+		_, err = flf.GetOrCreate(chainID)
+		assert.NoError(t, err, "Error GetOrCreate chain")
+	}
+
+	assert.NoError(t, flf.Remove("foo"))
+	assert.Equal(t, []string{"bar"}, flf.ChainIDs(), "Expected the chain to be removed")
+
+	fl, err := flf.GetOrCreate("foo")
+	assert.NoError(t, err, "Error creating chain")
+	assert.Zero(t, fl.Height(), "Expected the chain to be created anew")
+}
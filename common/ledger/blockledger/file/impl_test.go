@@ -110,6 +110,14 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) Prune(retainFromBlockNum uint64) error {
+	return mbs.defaultError
+}
+
+func (mbs *mockBlockStore) ExportTxIds(dir string) (map[string][]byte, error) {
+	return nil, mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -20,6 +20,9 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove removes the ledger of the given chain, which must no longer be in use
+	Remove(chainID string) error
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
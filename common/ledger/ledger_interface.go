@@ -55,3 +55,14 @@ type QueryResult interface{}
 
 // PrunePolicy - a general interface for supporting different pruning policies
 type PrunePolicy interface{}
+
+// RetainLastBlocksPolicy is a PrunePolicy that retains the last NumBlocks blocks of the chain
+type RetainLastBlocksPolicy struct {
+	NumBlocks uint64
+}
+
+// RetainFromConfigBlockPolicy is a PrunePolicy that retains the config block with the number
+// ConfigBlockNum and all the blocks after it
+type RetainFromConfigBlockPolicy struct {
+	ConfigBlockNum uint64
+}
@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileWriter writes the records of a snapshot file. A record is a sequence of uvarints and
+// length-prefixed byte slices; the readers of the file are expected to know the layout of the records.
+// The hash of the content written is computed on the fly, so that it can be recorded in the metadata of the snapshot.
+type FileWriter struct {
+	file      *os.File
+	bufWriter *bufio.Writer
+	hasher    hash.Hash
+	varintBuf []byte
+}
+
+// CreateFile creates a new snapshot file. It is an error for the file to already exist
+func CreateFile(filePath string) (*FileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating the snapshot file: %s", filePath)
+	}
+	hasher := sha256.New()
+	return &FileWriter{
+		file:      file,
+		bufWriter: bufio.NewWriter(io.MultiWriter(file, hasher)),
+		hasher:    hasher,
+		varintBuf: make([]byte, binary.MaxVarintLen64),
+	}, nil
+}
+
+// EncodeUVarint appends a uvarint to the file
+func (w *FileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(w.varintBuf, u)
+	if _, err := w.bufWriter.Write(w.varintBuf[:n]); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeBytes appends the length of the bytes, followed by the bytes, to the file
+func (w *FileWriter) EncodeBytes(b []byte) error {
+	if err := w.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	if _, err := w.bufWriter.Write(b); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeString appends a string to the file
+func (w *FileWriter) EncodeString(s string) error {
+	return w.EncodeBytes([]byte(s))
+}
+
+// Done flushes the content of the file to the disk and returns the hash of the content
+func (w *FileWriter) Done() ([]byte, error) {
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error while flushing to the snapshot file: %s", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error while syncing the snapshot file: %s", w.file.Name())
+	}
+	return w.hasher.Sum(nil), nil
+}
+
+// Close closes the file. Close does not flush the buffered content, so Done
+// should be invoked first for the file to be complete
+func (w *FileWriter) Close() error {
+	return errors.Wrapf(w.file.Close(), "error while closing the snapshot file: %s", w.file.Name())
+}
+
+// FileReader reads the records of a snapshot file written by a FileWriter
+type FileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+}
+
+// OpenFile opens a snapshot file for reading
+func OpenFile(filePath string) (*FileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	return &FileReader{file: file, bufReader: bufio.NewReader(file)}, nil
+}
+
+// HasMore returns true if the file contains more records
+func (r *FileReader) HasMore() (bool, error) {
+	if _, err := r.bufReader.Peek(1); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return true, nil
+}
+
+// DecodeUVarint reads a uvarint from the file
+func (r *FileReader) DecodeUVarint() (uint64, error) {
+	u, err := binary.ReadUvarint(r.bufReader)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return u, nil
+}
+
+// DecodeBytes reads a length-prefixed byte slice from the file
+func (r *FileReader) DecodeBytes() ([]byte, error) {
+	size, err := r.DecodeUVarint()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.bufReader, b); err != nil {
+		return nil, errors.Wrapf(err, "error while reading from the snapshot file: %s", r.file.Name())
+	}
+	return b, nil
+}
+
+// DecodeString reads a string from the file
+func (r *FileReader) DecodeString() (string, error) {
+	b, err := r.DecodeBytes()
+	return string(b), err
+}
+
+// Close closes the file
+func (r *FileReader) Close() error {
+	return errors.Wrapf(r.file.Close(), "error while closing the snapshot file: %s", r.file.Name())
+}
+
+// FileHash computes the hash of the content of a snapshot file, for comparing it
+// against the hash recorded at the time the file was written
+func FileHash(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file: %s", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
+
+// WriteFile writes a single byte slice to a new snapshot file and returns the hash of the content
+func WriteFile(filePath string, b []byte) ([]byte, error) {
+	w, err := CreateFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	if err := w.EncodeBytes(b); err != nil {
+		return nil, err
+	}
+	return w.Done()
+}
+
+// ReadFile reads the single byte slice stored in a snapshot file by WriteFile
+func ReadFile(filePath string) ([]byte, error) {
+	r, err := OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return r.DecodeBytes()
+}
+
+// ReadVerifiedFile reads the single byte slice stored in a snapshot file by WriteFile, after checking that
+// the hash of the content of the file matches expectedHash. The content is read only once, so the
+// returned bytes are the ones that were verified
+func ReadVerifiedFile(filePath string, expectedHash []byte) ([]byte, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	hash := sha256.Sum256(content)
+	if !bytes.Equal(hash[:], expectedHash) {
+		return nil, errors.Errorf("the hash of the snapshot file [%s] does not match the expected hash", filepath.Base(filePath))
+	}
+	size, n := binary.Uvarint(content)
+	if n <= 0 || uint64(len(content)-n) != size {
+		return nil, errors.Errorf("error while reading from the snapshot file: %s", filePath)
+	}
+	return content[n:], nil
+}
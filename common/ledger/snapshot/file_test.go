@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriterAndReader(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	w, err := CreateFile(filePath)
+	require.NoError(t, err)
+	assert.NoError(t, w.EncodeString("key1"))
+	assert.NoError(t, w.EncodeBytes([]byte("value1")))
+	assert.NoError(t, w.EncodeUVarint(300))
+	assert.NoError(t, w.EncodeBytes(nil))
+	fileHash, err := w.Done()
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(content)
+	assert.Equal(t, expectedHash[:], fileHash)
+	computedHash, err := FileHash(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, fileHash, computedHash)
+
+	_, err = CreateFile(filePath)
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+
+	r, err := OpenFile(filePath)
+	require.NoError(t, err)
+	defer r.Close()
+	s, err := r.DecodeString()
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", s)
+	b, err := r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), b)
+	u, err := r.DecodeUVarint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300), u)
+	hasMore, err := r.HasMore()
+	assert.NoError(t, err)
+	assert.True(t, hasMore)
+	b, err = r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Len(t, b, 0)
+	hasMore, err = r.HasMore()
+	assert.NoError(t, err)
+	assert.False(t, hasMore)
+	_, err = r.DecodeBytes()
+	assert.Contains(t, err.Error(), "error while reading from the snapshot file")
+}
+
+func TestWriteFileAndReadFile(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	fileHash, err := WriteFile(filePath, []byte("content"))
+	assert.NoError(t, err)
+	computedHash, err := FileHash(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, fileHash, computedHash)
+	content, err := ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+	content, err = ReadVerifiedFile(filePath, fileHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+	_, err = ReadVerifiedFile(filePath, []byte("wrongHash"))
+	assert.EqualError(t, err, "the hash of the snapshot file [test.data] does not match the expected hash")
+	_, err = ReadVerifiedFile(filePath, nil)
+	assert.EqualError(t, err, "the hash of the snapshot file [test.data] does not match the expected hash")
+
+	_, err = ReadVerifiedFile(filepath.Join(testDir, "nonExisting.data"), fileHash)
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+	_, err = ReadFile(filepath.Join(testDir, "nonExisting.data"))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+	_, err = FileHash(filepath.Join(testDir, "nonExisting.data"))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+}
@@ -33,6 +33,8 @@ const (
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// Options, if not nil, are used for opening the db in place of the default goleveldb options
+	Options *opt.Options
 }
 
 // DB - a wrapper on an actual store
@@ -70,6 +72,10 @@ func (dbInst *DB) Open() {
 		return
 	}
 	dbOpts := &opt.Options{}
+	if dbInst.conf.Options != nil {
+		options := *dbInst.conf.Options
+		dbOpts = &options
+	}
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
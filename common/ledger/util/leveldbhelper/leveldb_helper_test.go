@@ -24,6 +24,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 func TestLevelDBHelperWriteWithoutOpen(t *testing.T) {
@@ -159,7 +161,7 @@ func TestFileLock(t *testing.T) {
 func TestCreateDBInEmptyDir(t *testing.T) {
 	assert.NoError(t, os.RemoveAll(testDBPath), "")
 	assert.NoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -175,7 +177,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	assert.NoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -184,3 +186,21 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestCreateDBWithOptions(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath), "")
+	defer os.RemoveAll(testDBPath)
+	options := &opt.Options{WriteBuffer: 8 * opt.MiB, Filter: filter.NewBloomFilter(10)}
+	db := CreateDB(&Conf{DBPath: testDBPath, Options: options})
+	db.Open()
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), true))
+	db.Close()
+	// the options of the configuration are not altered when opening the db
+	assert.False(t, options.ErrorIfMissing)
+
+	db.Open()
+	defer db.Close()
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+}
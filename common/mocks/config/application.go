@@ -47,6 +47,8 @@ type MockApplicationCapabilities struct {
 	KeyLevelEndorsementRv        bool
 	V1_3ValidationRv             bool
 	FabTokenRv                   bool
+	ConfidentialFabTokenRv       bool
+	PvtDataPurgeRv               bool
 	StorePvtDataOfInvalidTxRv    bool
 }
 
@@ -94,6 +96,14 @@ func (mac *MockApplicationCapabilities) FabToken() bool {
 	return mac.FabTokenRv
 }
 
+func (mac *MockApplicationCapabilities) ConfidentialFabToken() bool {
+	return mac.ConfidentialFabTokenRv
+}
+
+func (mac *MockApplicationCapabilities) PvtDataPurge() bool {
+	return mac.PvtDataPurgeRv
+}
+
 func (mac *MockApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	return mac.StorePvtDataOfInvalidTxRv
 }
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package quorum
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/msp"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+)
+
+// Size returns the number of consenters out of n consenters that make up a quorum.
+// Up to (n-1)/3 of the consenters may be faulty, and any two quorums
+// intersect in at least one correct consenter.
+func Size(n int) int {
+	f := (n - 1) / 3
+	return (n + f + 2) / 2
+}
+
+// Policy is satisfied by the valid signatures of a quorum of consenters
+// of a byzantine fault tolerant ordering service.
+type Policy struct {
+	consenters   map[string]int
+	deserializer msp.IdentityDeserializer
+}
+
+// NewPolicy returns a Policy for the consenters with the given serialized identities,
+// whose signatures are verified with the given deserializer.
+func NewPolicy(identities [][]byte, deserializer msp.IdentityDeserializer) *Policy {
+	consenters := make(map[string]int, len(identities))
+	for i, identity := range identities {
+		consenters[string(identity)] = i
+	}
+	return &Policy{
+		consenters:   consenters,
+		deserializer: deserializer,
+	}
+}
+
+// Size returns the number of consenters that make up a quorum.
+func (p *Policy) Size() int {
+	return Size(len(p.consenters))
+}
+
+// VerifySignature verifies that the given signed data was signed by one of the consenters,
+// and returns the index of that consenter among the identities of the policy.
+func (p *Policy) VerifySignature(signedData *cb.SignedData) (int, error) {
+	index, exists := p.consenters[string(signedData.Identity)]
+	if !exists {
+		return 0, errors.New("signer is not a consenter")
+	}
+	identity, err := p.deserializer.DeserializeIdentity(signedData.Identity)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed deserializing signer identity")
+	}
+	if err := identity.Verify(signedData.Data, signedData.Signature); err != nil {
+		return 0, errors.Wrap(err, "invalid signature")
+	}
+	return index, nil
+}
+
+// Evaluate returns nil if the given signature set contains the valid signatures of
+// a quorum of distinct consenters. Signatures that are invalid or that were not produced
+// by a consenter are not counted.
+func (p *Policy) Evaluate(signatureSet []*cb.SignedData) error {
+	signers := make(map[int]struct{})
+	for _, signedData := range signatureSet {
+		index, err := p.VerifySignature(signedData)
+		if err != nil {
+			continue
+		}
+		signers[index] = struct{}{}
+	}
+	if len(signers) < p.Size() {
+		return errors.Errorf("signatures of %d out of %d consenters were found, but a quorum of %d is required",
+			len(signers), len(p.consenters), p.Size())
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package quorum
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/msp"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	mb "github.com/tradeline-tech/fabric/protos/msp"
+)
+
+type mockIdentity struct {
+	msp.Identity
+	idBytes []byte
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	if !bytes.Equal(sig, append([]byte("signed by "), id.idBytes...)) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+type mockDeserializer struct {
+	fail error
+}
+
+func (md *mockDeserializer) IsWellFormed(_ *mb.SerializedIdentity) error {
+	return nil
+}
+
+func (md *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if md.fail != nil {
+		return nil, md.fail
+	}
+	return &mockIdentity{idBytes: serializedIdentity}, nil
+}
+
+func signedBy(identity string) *cb.SignedData {
+	return &cb.SignedData{
+		Identity:  []byte(identity),
+		Data:      []byte("block"),
+		Signature: []byte("signed by " + identity),
+	}
+}
+
+func TestSize(t *testing.T) {
+	for n, expected := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 4, 6: 4, 7: 5, 10: 7} {
+		assert.Equal(t, expected, Size(n), "quorum of %d consenters", n)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy := NewPolicy([][]byte{[]byte("o1"), []byte("o2"), []byte("o3"), []byte("o4")}, &mockDeserializer{})
+	assert.Equal(t, 3, policy.Size())
+
+	forged := signedBy("o3")
+	forged.Signature = []byte("signed by o1")
+
+	for _, testCase := range []struct {
+		name          string
+		signatures    []*cb.SignedData
+		expectedError string
+	}{
+		{
+			name:       "quorum",
+			signatures: []*cb.SignedData{signedBy("o1"), signedBy("o2"), signedBy("o4")},
+		},
+		{
+			name:       "all consenters",
+			signatures: []*cb.SignedData{signedBy("o1"), signedBy("o2"), signedBy("o3"), signedBy("o4")},
+		},
+		{
+			name:          "no signatures",
+			expectedError: "signatures of 0 out of 4 consenters were found, but a quorum of 3 is required",
+		},
+		{
+			name:          "duplicate signer",
+			signatures:    []*cb.SignedData{signedBy("o1"), signedBy("o2"), signedBy("o2")},
+			expectedError: "signatures of 2 out of 4 consenters were found, but a quorum of 3 is required",
+		},
+		{
+			name:          "signer is not a consenter",
+			signatures:    []*cb.SignedData{signedBy("o1"), signedBy("o2"), signedBy("o5")},
+			expectedError: "signatures of 2 out of 4 consenters were found, but a quorum of 3 is required",
+		},
+		{
+			name:          "invalid signature",
+			signatures:    []*cb.SignedData{signedBy("o1"), signedBy("o2"), forged},
+			expectedError: "signatures of 2 out of 4 consenters were found, but a quorum of 3 is required",
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			err := policy.Evaluate(testCase.signatures)
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	policy := NewPolicy([][]byte{[]byte("o1"), []byte("o2")}, &mockDeserializer{})
+	index, err := policy.VerifySignature(signedBy("o2"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = policy.VerifySignature(signedBy("o3"))
+	assert.EqualError(t, err, "signer is not a consenter")
+
+	forged := signedBy("o1")
+	forged.Signature = []byte("signed by o2")
+	_, err = policy.VerifySignature(forged)
+	assert.EqualError(t, err, "invalid signature: bad signature")
+
+	policy = NewPolicy([][]byte{[]byte("o1")}, &mockDeserializer{fail: errors.New("unknown MSP")})
+	_, err = policy.VerifySignature(signedBy("o1"))
+	assert.EqualError(t, err, "failed deserializing signer identity: unknown MSP")
+}
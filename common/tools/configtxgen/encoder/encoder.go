@@ -18,6 +18,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/util"
 	"github.com/tradeline-tech/fabric/msp"
 	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
 	"github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
 	pb "github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/utils"
@@ -224,6 +225,10 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		if consensusMetadata, err = etcdraft.Marshal(conf.EtcdRaft); err != nil {
 			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", etcdraft.TypeKey, err)
 		}
+	case bft.TypeKey:
+		if consensusMetadata, err = bft.Marshal(conf.BFT); err != nil {
+			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", bft.TypeKey, err)
+		}
 	default:
 		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
 	}
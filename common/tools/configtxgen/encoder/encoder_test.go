@@ -21,6 +21,7 @@ import (
 	cb "github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/msp"
 	ab "github.com/tradeline-tech/fabric/protos/orderer"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
 	"github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
@@ -289,6 +290,46 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is bft", func() {
+			BeforeEach(func() {
+				conf.OrdererType = "bft"
+				conf.BFT = &bft.ConfigMetadata{
+					Options: &bft.Options{
+						RequestTimeout: "5s",
+					},
+				}
+			})
+
+			It("adds the bft metadata", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(5))
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("bft"))
+				metadata := &bft.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Options.RequestTimeout).To(Equal("5s"))
+			})
+
+			Context("when the bft configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT = &bft.ConfigMetadata{
+						Consenters: []*bft.Consenter{
+							{},
+						},
+					}
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("cannot marshal metadata for orderer type bft: cannot load client cert for consenter :0: open : no such file or directory"))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
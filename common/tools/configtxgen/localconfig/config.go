@@ -19,6 +19,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/viperutil"
 	cf "github.com/tradeline-tech/fabric/core/config"
 	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
 	"github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
 )
 
@@ -163,6 +164,7 @@ type Orderer struct {
 	BatchSize     BatchSize                `yaml:"BatchSize"`
 	Kafka         Kafka                    `yaml:"Kafka"`
 	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	BFT           *bft.ConfigMetadata      `yaml:"BFT"`
 	Organizations []*Organization          `yaml:"Organizations"`
 	MaxChannels   uint64                   `yaml:"MaxChannels"`
 	Capabilities  map[string]bool          `yaml:"Capabilities"`
@@ -462,6 +464,41 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case bft.TypeKey:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", bft.TypeKey)
+		}
+		if len(ord.BFT.Consenters) == 0 {
+			logger.Panicf("%s configuration did not specify any consenter", bft.TypeKey)
+		}
+
+		for _, c := range ord.BFT.GetConsenters() {
+			switch {
+			case c.Id == 0:
+				logger.Panicf("consenter info in %s configuration did not specify ID", bft.TypeKey)
+			case c.Host == "":
+				logger.Panicf("consenter info in %s configuration did not specify host", bft.TypeKey)
+			case c.Port == 0:
+				logger.Panicf("consenter info in %s configuration did not specify port", bft.TypeKey)
+			case c.MspId == "":
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", bft.TypeKey)
+			case c.Identity == nil:
+				logger.Panicf("consenter info in %s configuration did not specify identity", bft.TypeKey)
+			case c.ClientTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", bft.TypeKey)
+			case c.ServerTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", bft.TypeKey)
+			}
+			identityPath := string(c.GetIdentity())
+			cf.TranslatePathInPlace(configDir, &identityPath)
+			c.Identity = []byte(identityPath)
+			clientCertPath := string(c.GetClientTlsCert())
+			cf.TranslatePathInPlace(configDir, &clientCertPath)
+			c.ClientTlsCert = []byte(clientCertPath)
+			serverCertPath := string(c.GetServerTlsCert())
+			cf.TranslatePathInPlace(configDir, &serverCertPath)
+			c.ServerTlsCert = []byte(serverCertPath)
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
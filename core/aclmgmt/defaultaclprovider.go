@@ -75,10 +75,13 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetHistoryForPrivateDataHash] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetHistoryForPartialCompositeKey] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Cscc_JoinChain] = ""
+	d.pResourcePolicyMap[resources.Cscc_JoinChainBySnapshot] = ""
 	d.pResourcePolicyMap[resources.Cscc_GetChannels] = ""
 
 	//c resources
@@ -97,6 +100,7 @@ func (d *defaultACLProvider) initialize() {
 	//Event resources
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Event_FilteredBlock] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Event_TokenBlock] = CHANNELREADERS
 }
 
 //this should cover an exhaustive list of everything called from the peer
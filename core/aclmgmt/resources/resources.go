@@ -23,14 +23,17 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo                     = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber                 = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash                   = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID               = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID                   = "qscc/GetBlockByTxID"
+	Qscc_GetHistoryForPrivateDataHash     = "qscc/GetHistoryForPrivateDataHash"
+	Qscc_GetHistoryForPartialCompositeKey = "qscc/GetHistoryForPartialCompositeKey"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
+	Cscc_JoinChainBySnapshot      = "cscc/JoinChainBySnapshot"
 	Cscc_GetConfigBlock           = "cscc/GetConfigBlock"
 	Cscc_GetChannels              = "cscc/GetChannels"
 	Cscc_GetConfigTree            = "cscc/GetConfigTree"
@@ -43,6 +46,7 @@ const (
 	//Events
 	Event_Block         = "event/Block"
 	Event_FilteredBlock = "event/FilteredBlock"
+	Event_TokenBlock    = "event/TokenBlock"
 
 	//Token resources
 	Token_Issue    = "token/Issue"
@@ -35,13 +35,21 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// PvtDataPurger purges the private data of the ledger of a channel
+type PvtDataPurger interface {
+	// PurgePrivateData purges the private data of the blocks below maxBlockNumToRetain
+	// and returns the lowest block number whose private data is retained
+	PurgePrivateData(channelID string, maxBlockNumToRetain uint64) (uint64, error)
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, purger PvtDataPurger) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
 		specAtStartup: flogging.Global.Spec(),
+		purger:        purger,
 	}
 	return s
 }
@@ -51,6 +59,7 @@ type ServerAdmin struct {
 	v requestValidator
 
 	specAtStartup string
+	purger        PvtDataPurger
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -141,3 +150,23 @@ func (s *ServerAdmin) SetLogSpec(ctx context.Context, env *common.Envelope) (*pb
 	}
 	return logResponse, nil
 }
+
+func (s *ServerAdmin) PurgePrivateData(ctx context.Context, env *common.Envelope) (*pb.PvtDataPurgeResponse, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetPvtDataPurgeReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if request.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel ID is empty")
+	}
+	logger.Infof("Purging the private data of channel [%s] below block [%d]", request.ChannelId, request.MaxBlockNumToRetain)
+	minBlockNum, err := s.purger.PurgePrivateData(request.ChannelId, request.MaxBlockNumToRetain)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PvtDataPurgeResponse{MinBlockNum: minBlockNum}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -36,8 +37,17 @@ func (v *mockValidator) validate(ctx context.Context, env *common.Envelope) (*pb
 	return args.Get(0).(*pb.AdminOperation), nil
 }
 
+type mockPurger struct {
+	mock.Mock
+}
+
+func (p *mockPurger) PurgePrivateData(channelID string, maxBlockNumToRetain uint64) (uint64, error) {
+	args := p.Called(channelID, maxBlockNumToRetain)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -47,7 +57,7 @@ func TestGetStatus(t *testing.T) {
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -57,10 +67,10 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
-	mv.On("validate").Return(nil, accessDenied).Times(7)
+	mv.On("validate").Return(nil, accessDenied).Times(8)
 
 	ctx := context.Background()
 	status, err := adminServer.GetStatus(ctx, nil)
@@ -86,10 +96,13 @@ func TestForbidden(t *testing.T) {
 
 	_, err = adminServer.StartServer(ctx, nil)
 	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.PurgePrivateData(ctx, nil)
+	assert.Equal(t, accessDenied, err)
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -220,3 +233,38 @@ func TestLoggingCalls(t *testing.T) {
 		}
 	}
 }
+
+func TestPurgePrivateData(t *testing.T) {
+	purger := &mockPurger{}
+	adminServer := NewAdminServer(nil, purger)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapPvtDataPurgeRequest := func(r *pb.PvtDataPurgeRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_PvtDataPurgeReq{
+				PvtDataPurgeReq: r,
+			},
+		}
+	}
+
+	mv.On("validate").Return(wrapPvtDataPurgeRequest(nil), nil).Once()
+	_, err := adminServer.PurgePrivateData(context.Background(), nil)
+	assert.EqualError(t, err, "request is nil")
+
+	mv.On("validate").Return(wrapPvtDataPurgeRequest(&pb.PvtDataPurgeRequest{MaxBlockNumToRetain: 5}), nil).Once()
+	_, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = channel ID is empty")
+
+	purger.On("PurgePrivateData", "mychannel", uint64(50)).Return(uint64(0), errors.New("the private data below block [60] has already been purged")).Once()
+	mv.On("validate").Return(wrapPvtDataPurgeRequest(&pb.PvtDataPurgeRequest{ChannelId: "mychannel", MaxBlockNumToRetain: 50}), nil).Once()
+	_, err = adminServer.PurgePrivateData(context.Background(), nil)
+	assert.EqualError(t, err, "the private data below block [60] has already been purged")
+
+	purger.On("PurgePrivateData", "mychannel", uint64(100)).Return(uint64(100), nil).Once()
+	mv.On("validate").Return(wrapPvtDataPurgeRequest(&pb.PvtDataPurgeRequest{ChannelId: "mychannel", MaxBlockNumToRetain: 100}), nil).Once()
+	resp, err := adminServer.PurgePrivateData(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), resp.MinBlockNum)
+	purger.AssertExpectations(t)
+}
@@ -87,6 +87,10 @@ func (meqe *mockExecQuerySimulator) GetHistoryForKey(namespace, query string) (c
 	return meqe.commonQuery(namespace, query)
 }
 
+func (meqe *mockExecQuerySimulator) GetHistoryForKeyWithMetadata(namespace, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return nil, fmt.Errorf("GetHistoryForKeyWithMetadata not implemented")
+}
+
 func (meqe *mockExecQuerySimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return meqe.commonQuery(namespace, query)
 }
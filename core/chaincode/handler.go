@@ -212,6 +212,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_PURGE_PRIVATE_DATA:
+		go h.HandleTransaction(msg, h.HandlePurgePrivateData)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 	case pb.ChaincodeMessage_GET_STATE:
@@ -593,6 +595,18 @@ func (h *Handler) checkMetadataCap(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
+func (h *Handler) checkPurgeCap(msg *pb.ChaincodeMessage) error {
+	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", msg.ChannelId)
+	}
+
+	if !ac.Capabilities().PvtDataPurge() {
+		return errors.New("private data purge is not enabled, channel application capability of V1_4_2_PVTDATA_PURGE is required")
+	}
+	return nil
+}
+
 func errorIfCreatorHasNoReadAccess(chaincodeName, collection string, txContext *TransactionContext) error {
 	accessAllowed, err := hasReadAccess(chaincodeName, collection, txContext)
 	if err != nil {
@@ -946,15 +960,35 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getHistoryQueryMetadataFromBytes(getHistoryForKey.Metadata)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	var historyIter commonledger.ResultsIterator
+	var totalReturnLimit int32
+	isPaginated := false
+
+	if metadata == nil {
+		totalReturnLimit = calculateTotalReturnLimit(nil)
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	} else {
+		paginationMetadata := &pb.QueryMetadata{PageSize: metadata.PageSize, Bookmark: metadata.Bookmark}
+		totalReturnLimit = calculateTotalReturnLimit(paginationMetadata)
+		historyQueryInfo := createHistoryQueryInfoFromMetadata(metadata)
+		if isMetadataSetForPagination(paginationMetadata) {
+			historyQueryInfo["limit"] = totalReturnLimit
+			historyQueryInfo["bookmark"] = metadata.Bookmark
+			isPaginated = true
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithMetadata(chaincodeName, getHistoryForKey.Key, historyQueryInfo)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -998,6 +1032,38 @@ func getQueryMetadataFromBytes(metadataBytes []byte) (*pb.QueryMetadata, error)
 	return nil, nil
 }
 
+func getHistoryQueryMetadataFromBytes(metadataBytes []byte) (*pb.HistoryQueryMetadata, error) {
+	if metadataBytes != nil {
+		metadata := &pb.HistoryQueryMetadata{}
+		err := proto.Unmarshal(metadataBytes, metadata)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal failed")
+		}
+		return metadata, nil
+	}
+	return nil, nil
+}
+
+func createHistoryQueryInfoFromMetadata(metadata *pb.HistoryQueryMetadata) map[string]interface{} {
+	historyQueryInfo := make(map[string]interface{})
+	if metadata.StartBlock > 0 {
+		historyQueryInfo["startBlock"] = metadata.StartBlock
+	}
+	if metadata.EndBlock > 0 {
+		historyQueryInfo["endBlock"] = metadata.EndBlock
+	}
+	if metadata.StartTime != nil {
+		historyQueryInfo["startTime"] = metadata.StartTime
+	}
+	if metadata.EndTime != nil {
+		historyQueryInfo["endTime"] = metadata.EndTime
+	}
+	if metadata.NewestFirst {
+		historyQueryInfo["newestFirst"] = true
+	}
+	return historyQueryInfo
+}
+
 func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLimit int32, queryType pb.ChaincodeMessage_Type) (map[string]interface{}, error) {
 	paginationInfoMap := make(map[string]interface{})
 
@@ -1141,6 +1207,33 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+func (h *Handler) HandlePurgePrivateData(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	purgePrivateState := &pb.PurgePrivateState{}
+	err := proto.Unmarshal(msg.Payload, purgePrivateState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	if !isCollectionSet(purgePrivateState.Collection) {
+		return nil, errors.New("collection must be specified to purge private data")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	if err := h.checkPurgeCap(msg); err != nil {
+		return nil, err
+	}
+
+	chaincodeName := h.ChaincodeName()
+	err = txContext.TXSimulator.PurgePrivateData(chaincodeName, purgePrivateState.Collection, purgePrivateState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -91,7 +92,7 @@ var _ = Describe("Handler", func() {
 
 		fakeApplicationConfigRetriever = &fake.ApplicationConfigRetriever{}
 		applicationCapability := &config.MockApplication{
-			CapabilitiesRv: &config.MockApplicationCapabilities{KeyLevelEndorsementRv: true},
+			CapabilitiesRv: &config.MockApplicationCapabilities{KeyLevelEndorsementRv: true, PvtDataPurgeRv: true},
 		}
 		fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
 
@@ -865,6 +866,118 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePurgePrivateData", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.PurgePrivateState
+
+		BeforeEach(func() {
+			request = &pb.PurgePrivateState{
+				Key:        "purge-key",
+				Collection: "collection-name",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PURGE_PRIVATE_DATA,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls PurgePrivateData on the transaction simulator and returns a response message", func() {
+			resp, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.PurgePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("purge-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("collection must be specified to purge private data"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when PurgePrivateData fails due to ledger error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.PurgePrivateDataReturns(errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("mango"))
+			})
+		})
+
+		Context("when called from an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when the application config does not exist", func() {
+			BeforeEach(func() {
+				fakeApplicationConfigRetriever.GetApplicationConfigReturns(nil, false)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("application config does not exist for channel-id"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the private data purge capability is not enabled", func() {
+			BeforeEach(func() {
+				applicationCapability := &config.MockApplication{
+					CapabilitiesRv: &config.MockApplicationCapabilities{PvtDataPurgeRv: false},
+				}
+				fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data purge is not enabled, channel application capability of V1_4_2_PVTDATA_PURGE is required"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -2001,6 +2114,90 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when history query metadata is provided", func() {
+			var metadata *pb.HistoryQueryMetadata
+
+			BeforeEach(func() {
+				metadata = &pb.HistoryQueryMetadata{
+					StartBlock:  2,
+					EndBlock:    8,
+					StartTime:   &timestamp.Timestamp{Seconds: 100},
+					NewestFirst: true,
+				}
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(fakeIterator, nil)
+			})
+
+			JustBeforeEach(func() {
+				request.Metadata, _ = proto.Marshal(metadata)
+				incomingMessage.Payload, _ = proto.Marshal(request)
+			})
+
+			It("calls GetHistoryForKeyWithMetadata on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+				ccname, key, historyQueryInfo := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(historyQueryInfo).To(Equal(map[string]interface{}{
+					"startBlock":  uint64(2),
+					"endBlock":    uint64(8),
+					"startTime":   &timestamp.Timestamp{Seconds: 100},
+					"newestFirst": true,
+				}))
+			})
+
+			It("builds a query response that is not paginated", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, iter, _, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(iter).To(Equal(fakeIterator))
+				Expect(isPaginated).To(BeFalse())
+			})
+
+			Context("when pagination is requested", func() {
+				BeforeEach(func() {
+					metadata.PageSize = 5
+					metadata.Bookmark = "3:0"
+				})
+
+				It("passes the limit and bookmark to the history query executor", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataCallCount()).To(Equal(1))
+					_, _, historyQueryInfo := fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataArgsForCall(0)
+					Expect(historyQueryInfo).To(HaveKeyWithValue("limit", int32(5)))
+					Expect(historyQueryInfo).To(HaveKeyWithValue("bookmark", "3:0"))
+				})
+
+				It("builds a paginated query response", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+					_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+					Expect(isPaginated).To(BeTrue())
+					Expect(totalReturnLimit).To(Equal(int32(5)))
+				})
+			})
+
+			Context("when the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithMetadataReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
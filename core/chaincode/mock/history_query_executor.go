@@ -5,6 +5,7 @@ import (
 	sync "sync"
 
 	ledger "github.com/tradeline-tech/fabric/common/ledger"
+	ledgera "github.com/tradeline-tech/fabric/core/ledger"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +23,50 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyWithMetadataStub        func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyWithMetadataMutex       sync.RWMutex
+	getHistoryForKeyWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}
+	getHistoryForKeyWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	GetHistoryForPartialCompositeKeyStub        func(string, string) (ledger.ResultsIterator, error)
+	getHistoryForPartialCompositeKeyMutex       sync.RWMutex
+	getHistoryForPartialCompositeKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getHistoryForPartialCompositeKeyReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getHistoryForPartialCompositeKeyReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	GetHistoryForPrivateDataHashStub        func(string, string, []byte) (ledger.ResultsIterator, error)
+	getHistoryForPrivateDataHashMutex       sync.RWMutex
+	getHistoryForPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	getHistoryForPrivateDataHashReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getHistoryForPrivateDataHashReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +135,216 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithMetadataReturnsOnCall[len(fake.getHistoryForKeyWithMetadataArgsForCall)]
+	fake.getHistoryForKeyWithMetadataArgsForCall = append(fake.getHistoryForKeyWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]interface{}
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyWithMetadata", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	if fake.GetHistoryForKeyWithMetadataStub != nil {
+		return fake.GetHistoryForKeyWithMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCallCount() int {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithMetadataArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataCalls(stub func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataArgsForCall(i int) (string, string, map[string]interface{}) {
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	fake.getHistoryForKeyWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyWithMetadataMutex.Lock()
+	defer fake.getHistoryForKeyWithMetadataMutex.Unlock()
+	fake.GetHistoryForKeyWithMetadataStub = nil
+	if fake.getHistoryForKeyWithMetadataReturnsOnCall == nil {
+		fake.getHistoryForKeyWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKey(arg1 string, arg2 string) (ledger.ResultsIterator, error) {
+	fake.getHistoryForPartialCompositeKeyMutex.Lock()
+	ret, specificReturn := fake.getHistoryForPartialCompositeKeyReturnsOnCall[len(fake.getHistoryForPartialCompositeKeyArgsForCall)]
+	fake.getHistoryForPartialCompositeKeyArgsForCall = append(fake.getHistoryForPartialCompositeKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetHistoryForPartialCompositeKey", []interface{}{arg1, arg2})
+	fake.getHistoryForPartialCompositeKeyMutex.Unlock()
+	if fake.GetHistoryForPartialCompositeKeyStub != nil {
+		return fake.GetHistoryForPartialCompositeKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForPartialCompositeKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKeyCallCount() int {
+	fake.getHistoryForPartialCompositeKeyMutex.RLock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.RUnlock()
+	return len(fake.getHistoryForPartialCompositeKeyArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKeyCalls(stub func(string, string) (ledger.ResultsIterator, error)) {
+	fake.getHistoryForPartialCompositeKeyMutex.Lock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.Unlock()
+	fake.GetHistoryForPartialCompositeKeyStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKeyArgsForCall(i int) (string, string) {
+	fake.getHistoryForPartialCompositeKeyMutex.RLock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.RUnlock()
+	argsForCall := fake.getHistoryForPartialCompositeKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKeyReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getHistoryForPartialCompositeKeyMutex.Lock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.Unlock()
+	fake.GetHistoryForPartialCompositeKeyStub = nil
+	fake.getHistoryForPartialCompositeKeyReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPartialCompositeKeyReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getHistoryForPartialCompositeKeyMutex.Lock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.Unlock()
+	fake.GetHistoryForPartialCompositeKeyStub = nil
+	if fake.getHistoryForPartialCompositeKeyReturnsOnCall == nil {
+		fake.getHistoryForPartialCompositeKeyReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForPartialCompositeKeyReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHash(arg1 string, arg2 string, arg3 []byte) (ledger.ResultsIterator, error) {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getHistoryForPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.getHistoryForPrivateDataHashReturnsOnCall[len(fake.getHistoryForPrivateDataHashArgsForCall)]
+	fake.getHistoryForPrivateDataHashArgsForCall = append(fake.getHistoryForPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("GetHistoryForPrivateDataHash", []interface{}{arg1, arg2, arg3Copy})
+	fake.getHistoryForPrivateDataHashMutex.Unlock()
+	if fake.GetHistoryForPrivateDataHashStub != nil {
+		return fake.GetHistoryForPrivateDataHashStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHashCallCount() int {
+	fake.getHistoryForPrivateDataHashMutex.RLock()
+	defer fake.getHistoryForPrivateDataHashMutex.RUnlock()
+	return len(fake.getHistoryForPrivateDataHashArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHashCalls(stub func(string, string, []byte) (ledger.ResultsIterator, error)) {
+	fake.getHistoryForPrivateDataHashMutex.Lock()
+	defer fake.getHistoryForPrivateDataHashMutex.Unlock()
+	fake.GetHistoryForPrivateDataHashStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHashArgsForCall(i int) (string, string, []byte) {
+	fake.getHistoryForPrivateDataHashMutex.RLock()
+	defer fake.getHistoryForPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.getHistoryForPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHashReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getHistoryForPrivateDataHashMutex.Lock()
+	defer fake.getHistoryForPrivateDataHashMutex.Unlock()
+	fake.GetHistoryForPrivateDataHashStub = nil
+	fake.getHistoryForPrivateDataHashReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForPrivateDataHashReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getHistoryForPrivateDataHashMutex.Lock()
+	defer fake.getHistoryForPrivateDataHashMutex.Unlock()
+	fake.GetHistoryForPrivateDataHashStub = nil
+	if fake.getHistoryForPrivateDataHashReturnsOnCall == nil {
+		fake.getHistoryForPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForPrivateDataHashReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithMetadataMutex.RLock()
+	defer fake.getHistoryForKeyWithMetadataMutex.RUnlock()
+	fake.getHistoryForPartialCompositeKeyMutex.RLock()
+	defer fake.getHistoryForPartialCompositeKeyMutex.RUnlock()
+	fake.getHistoryForPrivateDataHashMutex.RLock()
+	defer fake.getHistoryForPrivateDataHashMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
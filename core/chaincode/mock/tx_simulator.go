@@ -92,6 +92,22 @@ type TxSimulator struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	ExecuteQueryOnPrivateDataWithMetadataStub        func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	executeQueryOnPrivateDataWithMetadataMutex       sync.RWMutex
+	executeQueryOnPrivateDataWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}
+	executeQueryOnPrivateDataWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	executeQueryOnPrivateDataWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
 	ExecuteQueryWithMetadataStub        func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
 	executeQueryWithMetadataMutex       sync.RWMutex
 	executeQueryWithMetadataArgsForCall []struct {
@@ -294,6 +310,19 @@ type TxSimulator struct {
 		result1 *ledgera.TxSimulationResults
 		result2 error
 	}
+	PurgePrivateDataStub        func(string, string, string) error
+	purgePrivateDataMutex       sync.RWMutex
+	purgePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	purgePrivateDataReturns struct {
+		result1 error
+	}
+	purgePrivateDataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivateDataStub        func(string, string, string, []byte) error
 	setPrivateDataMutex       sync.RWMutex
 	setPrivateDataArgsForCall []struct {
@@ -775,6 +804,72 @@ func (fake *TxSimulator) ExecuteQueryOnPrivateDataReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadata(arg1 string, arg2 string, arg3 string, arg4 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	ret, specificReturn := fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall[len(fake.executeQueryOnPrivateDataWithMetadataArgsForCall)]
+	fake.executeQueryOnPrivateDataWithMetadataArgsForCall = append(fake.executeQueryOnPrivateDataWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ExecuteQueryOnPrivateDataWithMetadata", []interface{}{arg1, arg2, arg3, arg4})
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	if fake.ExecuteQueryOnPrivateDataWithMetadataStub != nil {
+		return fake.ExecuteQueryOnPrivateDataWithMetadataStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.executeQueryOnPrivateDataWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadataCallCount() int {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
+	return len(fake.executeQueryOnPrivateDataWithMetadataArgsForCall)
+}
+
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadataCalls(stub func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = stub
+}
+
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadataArgsForCall(i int) (string, string, string, map[string]interface{}) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
+	argsForCall := fake.executeQueryOnPrivateDataWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = nil
+	fake.executeQueryOnPrivateDataWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) ExecuteQueryOnPrivateDataWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = nil
+	if fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall == nil {
+		fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TxSimulator) ExecuteQueryWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
 	fake.executeQueryWithMetadataMutex.Lock()
 	ret, specificReturn := fake.executeQueryWithMetadataReturnsOnCall[len(fake.executeQueryWithMetadataArgsForCall)]
@@ -1684,6 +1779,68 @@ func (fake *TxSimulator) GetTxSimulationResultsReturnsOnCall(i int, result1 *led
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) PurgePrivateData(arg1 string, arg2 string, arg3 string) error {
+	fake.purgePrivateDataMutex.Lock()
+	ret, specificReturn := fake.purgePrivateDataReturnsOnCall[len(fake.purgePrivateDataArgsForCall)]
+	fake.purgePrivateDataArgsForCall = append(fake.purgePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("PurgePrivateData", []interface{}{arg1, arg2, arg3})
+	fake.purgePrivateDataMutex.Unlock()
+	if fake.PurgePrivateDataStub != nil {
+		return fake.PurgePrivateDataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePrivateDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *TxSimulator) PurgePrivateDataCallCount() int {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return len(fake.purgePrivateDataArgsForCall)
+}
+
+func (fake *TxSimulator) PurgePrivateDataCalls(stub func(string, string, string) error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = stub
+}
+
+func (fake *TxSimulator) PurgePrivateDataArgsForCall(i int) (string, string, string) {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	argsForCall := fake.purgePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturns(result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	fake.purgePrivateDataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturnsOnCall(i int, result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	if fake.purgePrivateDataReturnsOnCall == nil {
+		fake.purgePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgePrivateDataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TxSimulator) SetPrivateData(arg1 string, arg2 string, arg3 string, arg4 []byte) error {
 	var arg4Copy []byte
 	if arg4 != nil {
@@ -2084,6 +2241,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.executeQueryMutex.RUnlock()
 	fake.executeQueryOnPrivateDataMutex.RLock()
 	defer fake.executeQueryOnPrivateDataMutex.RUnlock()
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
 	fake.executeQueryWithMetadataMutex.RLock()
 	defer fake.executeQueryWithMetadataMutex.RUnlock()
 	fake.executeUpdateMutex.RLock()
@@ -2112,6 +2271,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.getStateRangeScanIteratorWithMetadataMutex.RUnlock()
 	fake.getTxSimulationResultsMutex.RLock()
 	defer fake.getTxSimulationResultsMutex.RUnlock()
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
 	fake.setPrivateDataMutex.RLock()
 	defer fake.setPrivateDataMutex.RUnlock()
 	fake.setPrivateDataMetadataMutex.RLock()
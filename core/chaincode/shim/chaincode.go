@@ -549,6 +549,17 @@ func (stub *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// PurgePrivateData documentation can be found in interfaces.go
+func (stub *ChaincodeStub) PurgePrivateData(collection string, key string) error {
+	if collection == "" {
+		return fmt.Errorf("collection must not be an empty string")
+	}
+	if key == "" {
+		return fmt.Errorf("key must not be an empty string")
+	}
+	return stub.handler.handlePurgeState(collection, key, stub.ChannelId, stub.TxID)
+}
+
 // GetPrivateDataByRange documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if collection == "" {
@@ -724,13 +735,66 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetHistoryForKey(key, nil, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyWithOptions documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	metadata, err := createHistoryQueryMetadata(options, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	// ignore QueryResponseMetadata as it is not applicable for a history query without pagination
+	iterator, _, err := stub.handleGetHistoryForKey(key, metadata)
+
+	return iterator, err
+}
+
+// GetHistoryForKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	metadata, err := createHistoryQueryMetadata(options, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetHistoryForKey(key, metadata)
+}
+
+func (stub *ChaincodeStub) handleGetHistoryForKey(key string, metadata []byte) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	response, err := stub.handler.handleGetHistoryForKey(key, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
+}
+
+func createHistoryQueryMetadata(options *HistoryQueryOptions, pageSize int32, bookmark string) ([]byte, error) {
+	metadata := &pb.HistoryQueryMetadata{PageSize: pageSize, Bookmark: bookmark}
+	if options != nil {
+		metadata.StartBlock = options.StartBlock
+		metadata.EndBlock = options.EndBlock
+		metadata.StartTime = options.StartTime
+		metadata.EndTime = options.EndTime
+		metadata.NewestFirst = options.NewestFirst
+	}
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadataBytes, nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
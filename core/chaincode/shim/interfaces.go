@@ -234,6 +234,24 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithOptions returns the history of key values restricted
+	// to the block range and time range given in the options, in the order
+	// requested by the options. A nil options value behaves as GetHistoryForKey.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyWithPagination returns the history of key values as
+	// GetHistoryForKeyWithOptions does, limited to at most `pageSize` results.
+	// When an empty string is passed as a value to the bookmark argument, the
+	// returned iterator can be used to fetch the first `pageSize` historic values.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the next `pageSize` historic values after the bookmark. Only the bookmark
+	// present in a prior page of results (ResponseMetadata) can be used as a value
+	// to the bookmark argument.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -266,6 +284,14 @@ type ChaincodeStubInterface interface {
 	// when the transaction is validated and successfully committed.
 	DelPrivateData(collection, key string) error
 
+	// PurgePrivateData records the specified `key` to be purged in the private
+	// writeset of the transaction. Unlike DelPrivateData, when the transaction is
+	// validated and successfully committed, not only the current value but also
+	// all the historical values of the `key` are removed from the private data
+	// stores of the peers. The hashes of the key and of the values are retained
+	// on the ledger.
+	PurgePrivateData(collection, key string) error
+
 	// SetPrivateDataValidationParameter sets the key-level endorsement policy
 	// for the private data specified by `key`.
 	SetPrivateDataValidationParameter(collection, key string, ep []byte) error
@@ -404,6 +430,17 @@ type HistoryQueryIteratorInterface interface {
 	Next() (*queryresult.KeyModification, error)
 }
 
+// HistoryQueryOptions restricts and orders the results of a history query.
+// Block and time bounds are inclusive; a zero EndBlock and nil timestamps
+// leave the corresponding end of the range unbounded.
+type HistoryQueryOptions struct {
+	StartBlock  uint64
+	EndBlock    uint64
+	StartTime   *timestamp.Timestamp
+	EndTime     *timestamp.Timestamp
+	NewestFirst bool
+}
+
 // MockQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by range query.
 // TODO: Once the execute query and history query are implemented in MockStub,
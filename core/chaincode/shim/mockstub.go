@@ -186,6 +186,15 @@ func (stub *MockStub) DelPrivateData(collection string, key string) error {
 	return errors.New("Not Implemented")
 }
 
+// PurgePrivateData removes the specified `key` from the private data of the collection.
+// The mock keeps no history, so this only removes the current value.
+func (stub *MockStub) PurgePrivateData(collection string, key string) error {
+	if m, in := stub.PvtState[collection]; in {
+		delete(m, key)
+	}
+	return nil
+}
+
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
 	return nil, errors.New("Not Implemented")
 }
@@ -305,6 +314,17 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyWithOptions is not implemented since the mock engine does not keep a history
+func (stub *MockStub) GetHistoryForKeyWithOptions(key string, options *HistoryQueryOptions) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetHistoryForKeyWithPagination is not implemented since the mock engine does not keep a history
+func (stub *MockStub) GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
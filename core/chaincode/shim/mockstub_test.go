@@ -285,6 +285,23 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+func TestMockPurgePrivateData(t *testing.T) {
+	stub := NewMockStub("purge", nil)
+	stub.MockTransactionStart("1")
+	assert.NoError(t, stub.PutPrivateData("coll", "key1", []byte("value1")))
+	assert.NoError(t, stub.PutPrivateData("coll", "key2", []byte("value2")))
+	assert.NoError(t, stub.PurgePrivateData("coll", "key1"))
+	assert.NoError(t, stub.PurgePrivateData("nonExistingColl", "key1"))
+	stub.MockTransactionEnd("1")
+
+	val, err := stub.GetPrivateData("coll", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+	val, err = stub.GetPrivateData("coll", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), val)
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -315,6 +332,8 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithOptions("k", &HistoryQueryOptions{NewestFirst: true})
+	stub.GetHistoryForKeyWithPagination("k", nil, 10, "")
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -1164,6 +1166,35 @@ func TestCC2CC(t *testing.T) {
 	processDone(t, done, false)
 }
 
+func TestCreateHistoryQueryMetadata(t *testing.T) {
+	metadataBytes, err := createHistoryQueryMetadata(nil, 0, "")
+	assert.NoError(t, err)
+	metadata := &pb.HistoryQueryMetadata{}
+	assert.NoError(t, proto.Unmarshal(metadataBytes, metadata))
+	assert.True(t, proto.Equal(&pb.HistoryQueryMetadata{}, metadata))
+
+	options := &HistoryQueryOptions{
+		StartBlock:  3,
+		EndBlock:    7,
+		StartTime:   &timestamp.Timestamp{Seconds: 10},
+		EndTime:     &timestamp.Timestamp{Seconds: 20},
+		NewestFirst: true,
+	}
+	metadataBytes, err = createHistoryQueryMetadata(options, 5, "4:1")
+	assert.NoError(t, err)
+	metadata = &pb.HistoryQueryMetadata{}
+	assert.NoError(t, proto.Unmarshal(metadataBytes, metadata))
+	assert.True(t, proto.Equal(&pb.HistoryQueryMetadata{
+		StartBlock:  3,
+		EndBlock:    7,
+		StartTime:   &timestamp.Timestamp{Seconds: 10},
+		EndTime:     &timestamp.Timestamp{Seconds: 20},
+		NewestFirst: true,
+		PageSize:    5,
+		Bookmark:    "4:1",
+	}, metadata))
+}
+
 func TestRealPeerStream(t *testing.T) {
 	viper.Set("peer.address", "127.0.0.1:12345")
 	_, err := userChaincodeStreamGetter("fake")
@@ -493,6 +493,16 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 		}
 	}
 
+	// if returned error is of type ledger.PrunedErr, the transaction is in a
+	// block that has been pruned, therefore it is a duplicate as well
+	if _, isPrunedErrType := err.(*ledger.PrunedErr); isPrunedErrType {
+		logger.Error("Duplicate transaction found in a pruned block, ", txID, ", skipping")
+		return &blockValidationResult{
+			tIdx:           tIdx,
+			validationCode: peer.TxValidationCode_DUPLICATE_TXID,
+		}
+	}
+
 	// if returned error is not of type blkstorage.NotFoundInIndexErr, it means
 	// we could not verify whether a tx with the supplied id is in the ledger
 	if _, isNotFoundInIndexErrType := err.(ledger.NotFoundInIndexErr); !isNotFoundInIndexErrType {
@@ -666,6 +676,18 @@ func (ds *dynamicCapabilities) FabToken() bool {
 	return ds.support.Capabilities().FabToken()
 }
 
+// ConfidentialFabToken returns true if fabric token transactions are processed
+// by the confidential token management system.
+func (ds *dynamicCapabilities) ConfidentialFabToken() bool {
+	return ds.support.Capabilities().ConfidentialFabToken()
+}
+
+// PvtDataPurge returns true if chaincodes may purge private data keys
+// along with their history.
+func (ds *dynamicCapabilities) PvtDataPurge() bool {
+	return ds.support.Capabilities().PvtDataPurge()
+}
+
 func (ds *dynamicCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	return ds.support.Capabilities().ForbidDuplicateTXIdInBlock()
 }
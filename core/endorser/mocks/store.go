@@ -101,6 +101,20 @@ func (_m *Store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return r0
 }
 
+// PurgeKeys provides a mock function with given fields: purges
+func (_m *Store) PurgeKeys(purges []*ledger.PvtdataKeyPurge) error {
+	ret := _m.Called(purges)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*ledger.PvtdataKeyPurge) error); ok {
+		r0 = rf(purges)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PurgeByTxids provides a mock function with given fields: txids
 func (_m *Store) PurgeByTxids(txids []string) error {
 	ret := _m.Called(txids)
@@ -58,4 +58,12 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// ConfidentialFabToken returns true if fabric token transactions are processed
+	// by the confidential token management system.
+	ConfidentialFabToken() bool
+
+	// PvtDataPurge returns true if chaincodes may purge private data keys
+	// along with their history.
+	PvtDataPurge() bool
 }
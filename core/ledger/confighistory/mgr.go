@@ -8,11 +8,13 @@ package confighistory
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/ledger/snapshot"
 	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	"github.com/tradeline-tech/fabric/protos/common"
@@ -23,12 +25,16 @@ var logger = flogging.MustGetLogger("confighistory")
 
 const (
 	collectionConfigNamespace = "lscc" // lscc namespace was introduced in version 1.2 and we continue to use this in order to be compatible with existing data
+	// SnapshotFileName is the name of the snapshot file that contains the config history
+	SnapshotFileName = "confighistory.data"
 )
 
 // Mgr should be registered as a state listener. The state listener builds the history and retriever helps in querying the history
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	ExportConfigHistory(ledgerID string, dir string) (map[string][]byte, error)
+	ImportConfigHistory(ledgerID string, dir string) error
 	Close()
 }
 
@@ -97,6 +103,81 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	return &retriever{dbHandle: m.dbProvider.getDB(ledgerID), ledgerInfoRetriever: ledgerInfoRetriever}
 }
 
+// ExportConfigHistory implements the function in the interface 'Mgr'. All the entries of the config history
+// of the ledger are written to a snapshot file in the directory dir; each record of the file holds the namespace,
+// the key, the committing block number, and the value of an entry. The returned map holds the hash of the file
+func (m *mgr) ExportConfigHistory(ledgerID string, dir string) (map[string][]byte, error) {
+	w, err := snapshot.CreateFile(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	itr := dbHandle.GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		k := decodeCompositeKey(itr.Key())
+		if err := w.EncodeString(k.ns); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeString(k.key); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeUVarint(k.blockNum); err != nil {
+			return nil, err
+		}
+		if err := w.EncodeBytes(itr.Value()); err != nil {
+			return nil, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error while iterating over the config history of ledger [%s]", ledgerID)
+	}
+	fileHash, err := w.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotFileName: fileHash}, nil
+}
+
+// ImportConfigHistory implements the function in the interface 'Mgr'. The entries of the snapshot file
+// written by ExportConfigHistory are loaded into the config history of the ledger
+func (m *mgr) ImportConfigHistory(ledgerID string, dir string) error {
+	r, err := snapshot.OpenFile(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	batch := newBatch()
+	for {
+		hasMore, err := r.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		ns, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		key, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		blockNum, err := r.DecodeUVarint()
+		if err != nil {
+			return err
+		}
+		value, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		batch.add(ns, key, blockNum, value)
+	}
+	return m.dbProvider.getDB(ledgerID).writeBatch(batch, true)
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
@@ -8,6 +8,7 @@ package confighistory
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
 	"testing"
@@ -116,6 +117,45 @@ func TestMgr(t *testing.T) {
 	})
 }
 
+func TestExportAndImportConfigHistory(t *testing.T) {
+	dbPath := "/tmp/fabric/core/ledger/confighistory"
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	env := newTestEnv(t, dbPath, mockCCInfoProvider)
+	mgr := env.mgr
+	defer env.cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	chaincodeName := "chaincode1"
+	configCommittingBlockNums := []uint64{5, 10}
+	for _, committingBlockNum := range configCommittingBlockNums {
+		collConfigPackage := sampleCollectionConfigPackage("ledger1", committingBlockNum)
+		testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(mockCCInfoProvider, chaincodeName, collConfigPackage)
+		mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:           "ledger1",
+			CommittingBlockNum: committingBlockNum},
+		)
+	}
+
+	fileHashes, err := mgr.ExportConfigHistory("ledger1", snapshotDir)
+	assert.NoError(t, err)
+	assert.Contains(t, fileHashes, SnapshotFileName)
+	assert.NoError(t, mgr.ImportConfigHistory("ledger2", snapshotDir))
+
+	dummyLedgerInfoRetriever := &dummyLedgerInfoRetriever{info: &common.BlockchainInfo{Height: 20}}
+	retriever := mgr.GetRetriever("ledger2", dummyLedgerInfoRetriever)
+	for _, committingBlockNum := range configCommittingBlockNums {
+		retrievedConfig, err := retriever.CollectionConfigAt(committingBlockNum, chaincodeName)
+		assert.NoError(t, err)
+		assert.Equal(t, sampleCollectionConfigPackage("ledger1", committingBlockNum), retrievedConfig.CollectionConfig)
+	}
+
+	fileHashes, err = mgr.ExportConfigHistory("ledger3", t.Name())
+	assert.Error(t, err)
+	assert.Nil(t, fileHashes)
+}
+
 type testEnv struct {
 	dbPath string
 	mgr    Mgr
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package customtx
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/tradeline-tech/fabric/protos/common"
@@ -33,3 +34,21 @@ func initialize(customTxProcessors Processors) {
 func GetProcessor(txType common.HeaderType) Processor {
 	return processors[txType]
 }
+
+// GetEndorserTxProcessors returns the Processors that derive ledger updates from the endorser transactions,
+// ordered by the type of their custom transactions
+func GetEndorserTxProcessors() []EndorserTxProcessor {
+	var txTypes []common.HeaderType
+	for txType, processor := range processors {
+		if _, ok := processor.(EndorserTxProcessor); ok {
+			txTypes = append(txTypes, txType)
+		}
+	}
+	sort.Slice(txTypes, func(i, j int) bool { return txTypes[i] < txTypes[j] })
+
+	var endorserTxProcessors []EndorserTxProcessor
+	for _, txType := range txTypes {
+		endorserTxProcessors = append(endorserTxProcessors, processors[txType].(EndorserTxProcessor))
+	}
+	return endorserTxProcessors
+}
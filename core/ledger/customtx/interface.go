@@ -9,6 +9,7 @@ package customtx
 import (
 	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
 )
 
 // InvalidTxError is expected to be thrown by a custom transaction processor (an implementation of interface `Processor`)
@@ -33,3 +34,21 @@ func (e *InvalidTxError) Error() string {
 type Processor interface {
 	GenerateSimulationResults(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error
 }
+
+// PositionAwareProcessor is implemented by the Processors that also need the position of the transactions
+// they process in the blockchain, for instance to index them. For such processors, the ledger invokes
+// GenerateSimulationResultsAt with the number of the block and the index of the transaction in the block.
+type PositionAwareProcessor interface {
+	Processor
+	GenerateSimulationResultsAt(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool, blockNum uint64, txNum uint64) error
+}
+
+// EndorserTxProcessor is implemented by the Processors that also derive ledger updates from the endorser transactions,
+// for instance to record in the state what a chaincode transaction expects from the custom transactions bound to it.
+// For each endorser transaction that passed the validation of its endorsements, the ledger invokes
+// GenerateEndorserTxSimulationResults with the chaincode action of the transaction. The writes generated are committed
+// along with the writes of the transaction, only if the transaction is valid. An InvalidTxError marks the transaction invalid.
+type EndorserTxProcessor interface {
+	Processor
+	GenerateEndorserTxSimulationResults(txID string, chaincodeAction *peer.ChaincodeAction, simulator ledger.TxSimulator) error
+}
@@ -37,7 +37,12 @@ type provider struct {
 
 // NewProvider instantiates a new provider
 func NewProvider() Provider {
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: getInternalBookkeeperPath()})
+	return NewProviderWithPath(getInternalBookkeeperPath())
+}
+
+// NewProviderWithPath instantiates a new provider that keeps the bookkeeping in the LevelDB at dbPath
+func NewProviderWithPath(dbPath string) Provider {
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
 	return &provider{dbProvider: dbProvider}
 }
 
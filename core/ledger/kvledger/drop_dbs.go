@@ -8,9 +8,11 @@ package kvledger
 
 import (
 	"os"
+	"sort"
 
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 )
 
@@ -24,7 +26,7 @@ func dropDBs() error {
 	// command fails before dropping the stateDB, peer cannot start with consistent data (if the
 	// user decides to start the peer without retrying the reset/rollback) as the stateDB would
 	// not be rebuilt.
-	if err := dropStateLevelDB(); err != nil {
+	if err := dropStateDBs(); err != nil {
 		return err
 	}
 	if err := dropConfigHistoryDB(); err != nil {
@@ -39,11 +41,23 @@ func dropDBs() error {
 	return nil
 }
 
-func dropStateLevelDB() error {
-	stateLeveldbPath := ledgerconfig.GetStateLevelDBPath()
-	logger.Infof("Dropping StateLevelDB at location [%s]", stateLeveldbPath)
-	err := os.RemoveAll(stateLeveldbPath)
-	return errors.Wrapf(err, "error removing the StateLevelDB located at %s", stateLeveldbPath)
+// dropStateDBs drops the data of all the embedded state databases, as the peer may have
+// used a state database other than the configured one before
+func dropStateDBs() error {
+	dataPaths := statedb.DataPaths()
+	names := make([]string, 0, len(dataPaths))
+	for name := range dataPaths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dataPath := dataPaths[name]
+		logger.Infof("Dropping state database [%s] at location [%s]", name, dataPath)
+		if err := os.RemoveAll(dataPath); err != nil {
+			return errors.Wrapf(err, "error removing the state database [%s] located at %s", name, dataPath)
+		}
+	}
+	return nil
 }
 
 func dropConfigHistoryDB() error {
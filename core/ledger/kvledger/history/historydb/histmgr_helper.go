@@ -54,6 +54,71 @@ func ConstructPartialCompositeHistoryKey(ns string, key string, endkey bool) []b
 	return compositeKey
 }
 
+// The keys of the private data hash index and the composite key index start with a nil byte
+// so that they never clash with the public history keys, which start with a non-empty namespace
+var (
+	pvtDataHashHistoryKeyPrefix  = []byte{0x00, 0x01}
+	compositeKeyHistoryKeyPrefix = []byte{0x00, 0x02}
+)
+
+//ConstructPvtDataHashHistoryKey builds the History Key of the hashed private data write
+// in the form prefix~namespace~collection~keyhash~blocknum~trannum
+func ConstructPvtDataHashHistoryKey(ns string, coll string, keyHash []byte, blocknum uint64, trannum uint64) []byte {
+	compositeKey := ConstructPartialPvtDataHashHistoryKey(ns, coll, keyHash, false)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(blocknum)...)
+	compositeKey = append(compositeKey, util.EncodeOrderPreservingVarUint64(trannum)...)
+	return compositeKey
+}
+
+//ConstructPartialPvtDataHashHistoryKey builds a partial History Key prefix~namespace~collection~keyhash~
+// for use in private data hash history range queries
+func ConstructPartialPvtDataHashHistoryKey(ns string, coll string, keyHash []byte, endkey bool) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, pvtDataHashHistoryKeyPrefix...)
+	compositeKey = append(compositeKey, []byte(ns)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, []byte(coll)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, keyHash...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	if endkey {
+		compositeKey = append(compositeKey, []byte{0xff}...)
+	}
+	return compositeKey
+}
+
+//ConstructCompositeKeyHistoryKey builds the History Key of a write to a composite key in the form
+// prefix~namespace~compositekey blocknum~trannum. As a composite key ends with a nil byte,
+// no separator is added between the composite key and the blocknum.
+// The caller stores the encoded blocknum~trannum as the value, so that the composite key can be
+// extracted from the History Key without ambiguity
+func ConstructCompositeKeyHistoryKey(ns string, compositeKey string, blocknum uint64, trannum uint64) ([]byte, []byte) {
+	blockNumTranNum := util.EncodeOrderPreservingVarUint64(blocknum)
+	blockNumTranNum = append(blockNumTranNum, util.EncodeOrderPreservingVarUint64(trannum)...)
+	historyKey := ConstructPartialCompositeKeyHistoryKey(ns, compositeKey, false)
+	historyKey = append(historyKey, blockNumTranNum...)
+	return historyKey, blockNumTranNum
+}
+
+//ConstructPartialCompositeKeyHistoryKey builds a partial History Key prefix~namespace~partialcompositekey
+// for use in range queries over the history of all composite keys sharing a prefix
+func ConstructPartialCompositeKeyHistoryKey(ns string, partialCompositeKey string, endkey bool) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, compositeKeyHistoryKeyPrefix...)
+	compositeKey = append(compositeKey, []byte(ns)...)
+	compositeKey = append(compositeKey, CompositeKeySep...)
+	compositeKey = append(compositeKey, []byte(partialCompositeKey)...)
+	if endkey {
+		compositeKey = append(compositeKey, []byte{0xff}...)
+	}
+	return compositeKey
+}
+
+//IsCompositeKey returns true if the key was created by the chaincode shim as a composite key
+func IsCompositeKey(key string) bool {
+	return len(key) > 0 && key[0] == CompositeKeySep[0]
+}
+
 //SplitCompositeHistoryKey splits the key bytes using a separator
 func SplitCompositeHistoryKey(bytesToSplit []byte, separator []byte) ([]byte, []byte) {
 	split := bytes.SplitN(bytesToSplit, separator, 2)
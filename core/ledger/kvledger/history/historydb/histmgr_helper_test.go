@@ -17,6 +17,7 @@ limitations under the License.
 package historydb
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,3 +47,37 @@ func TestSplitCompositeKey(t *testing.T) {
 	// second position should hold the extra bytes that were split off
 	assert.Equal(t, []byte("extra bytes to split"), extraBytes)
 }
+
+func TestConstructPvtDataHashHistoryKey(t *testing.T) {
+	keyHash := []byte{0x01, 0x00, 0x02}
+	compositeStartKey := ConstructPartialPvtDataHashHistoryKey("ns1", "coll1", keyHash, false)
+	compositeEndKey := ConstructPartialPvtDataHashHistoryKey("ns1", "coll1", keyHash, true)
+	assert.Equal(t, []byte("\x00\x01ns1"+strKeySep+"coll1"+strKeySep+"\x01\x00\x02"+strKeySep), compositeStartKey)
+	assert.Equal(t, append(compositeStartKey, 0xff), compositeEndKey)
+
+	compositeKey := ConstructPvtDataHashHistoryKey("ns1", "coll1", keyHash, 1, 1)
+	assert.True(t, bytes.HasPrefix(compositeKey, compositeStartKey))
+	assert.True(t, bytes.Compare(compositeKey, compositeEndKey) < 0)
+	assert.False(t, bytes.HasPrefix(compositeKey, ConstructPartialCompositeHistoryKey("ns1", "coll1", false)))
+}
+
+func TestConstructCompositeKeyHistoryKey(t *testing.T) {
+	compositeKey := "\x00asset\x00blue\x00a1\x00"
+	historyKey, blockNumTranNum := ConstructCompositeKeyHistoryKey("ns1", compositeKey, 5, 2)
+	assert.Equal(t, []byte("\x00\x02ns1"+strKeySep+compositeKey), historyKey[:len(historyKey)-len(blockNumTranNum)])
+	assert.True(t, bytes.HasSuffix(historyKey, blockNumTranNum))
+
+	partialStartKey := ConstructPartialCompositeKeyHistoryKey("ns1", "\x00asset\x00blue\x00", false)
+	partialEndKey := ConstructPartialCompositeKeyHistoryKey("ns1", "\x00asset\x00blue\x00", true)
+	assert.True(t, bytes.HasPrefix(historyKey, partialStartKey))
+	assert.True(t, bytes.Compare(historyKey, partialEndKey) < 0)
+
+	otherKey, _ := ConstructCompositeKeyHistoryKey("ns1", "\x00asset\x00bluegreen\x00a1\x00", 1, 0)
+	assert.False(t, bytes.HasPrefix(otherKey, partialStartKey))
+}
+
+func TestIsCompositeKey(t *testing.T) {
+	assert.True(t, IsCompositeKey("\x00asset\x00a1\x00"))
+	assert.False(t, IsCompositeKey("asset"))
+	assert.False(t, IsCompositeKey(""))
+}
@@ -40,3 +40,16 @@ type HistoryDB interface {
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 	Name() string
 }
+
+// FullScannable is implemented by the history databases capable of iterating over all their entries
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the entries of the db, including the savepoint, ordered by key
+	GetFullScanIterator() (FullScanIterator, error)
+}
+
+// FullScanIterator iterates over the raw entries of a history db
+type FullScanIterator interface {
+	// Next returns the key and the value of the next entry, or a nil key when all the entries have been returned
+	Next() ([]byte, []byte, error)
+	Close()
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/tradeline-tech/fabric/common/flogging"
 	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
 	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
@@ -42,7 +44,11 @@ type HistoryDBProvider struct {
 
 // NewHistoryDBProvider instantiates HistoryDBProvider
 func NewHistoryDBProvider() *HistoryDBProvider {
-	dbPath := ledgerconfig.GetHistoryLevelDBPath()
+	return NewHistoryDBProviderWithPath(ledgerconfig.GetHistoryLevelDBPath())
+}
+
+// NewHistoryDBProviderWithPath instantiates HistoryDBProvider for the LevelDB at dbPath
+func NewHistoryDBProviderWithPath(dbPath string) *HistoryDBProvider {
 	logger.Debugf("constructing HistoryDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
 	return &HistoryDBProvider{dbProvider}
@@ -150,6 +156,22 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
+
+					// writes to composite keys are also indexed so that the history of all the keys
+					// sharing a composite key prefix can be queried
+					if historydb.IsCompositeKey(writeKey) {
+						compositeKeyHistoryKey, blockNumTranNum := historydb.ConstructCompositeKeyHistoryKey(ns, writeKey, blockNo, tranNo)
+						dbBatch.Put(compositeKeyHistoryKey, blockNumTranNum)
+					}
+				}
+
+				// add a history record for each hashed write to private data
+				for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+					for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+						pvtDataHashHistoryKey := historydb.ConstructPvtDataHashHistoryKey(ns, collHashedRwSet.CollectionName,
+							kvWriteHash.KeyHash, blockNo, tranNo)
+						dbBatch.Put(pvtDataHashHistoryKey, emptyValue)
+					}
 				}
 			}
 
@@ -191,6 +213,11 @@ func (historyDB *historyDB) GetLastSavepoint() (*version.Height, error) {
 	return height, nil
 }
 
+// GetFullScanIterator implements method in FullScannable interface
+func (historyDB *historyDB) GetFullScanIterator() (historydb.FullScanIterator, error) {
+	return &fullDBScanner{historyDB.db.GetIterator(nil, nil)}, nil
+}
+
 // ShouldRecover implements method in interface kvledger.Recoverer
 func (historyDB *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error) {
 	if !ledgerconfig.IsHistoryDBEnabled() {
@@ -227,3 +254,21 @@ func (historyDB *historyDB) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtD
 	}
 	return nil
 }
+
+// fullDBScanner iterates over all the entries of a historyDB
+type fullDBScanner struct {
+	dbItr *leveldbhelper.Iterator
+}
+
+func (s *fullDBScanner) Next() ([]byte, []byte, error) {
+	if !s.dbItr.Next() {
+		return nil, nil, errors.Wrap(s.dbItr.Error(), "error while iterating over the history database")
+	}
+	key := append([]byte{}, s.dbItr.Key()...)
+	value := append([]byte{}, s.dbItr.Value()...)
+	return key, value, nil
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
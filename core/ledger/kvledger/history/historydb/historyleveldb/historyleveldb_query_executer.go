@@ -7,12 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 
 	commonledger "github.com/tradeline-tech/fabric/common/ledger"
 	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
 	"github.com/tradeline-tech/fabric/common/ledger/util"
+	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
@@ -29,33 +36,239 @@ type LevelHistoryDBQueryExecutor struct {
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	return q.GetHistoryForKeyWithMetadata(namespace, key, nil)
+}
+
+// GetHistoryForKeyWithMetadata implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 
 	if ledgerconfig.IsHistoryDBEnabled() == false {
 		return nil, errors.New("history database not enabled")
 	}
 
+	options, err := newHistoryQueryOptions(metadata)
+	if err != nil {
+		return nil, err
+	}
+
 	var compositeStartKey []byte
 	var compositeEndKey []byte
-	compositeStartKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey = compositePartialKey
 	compositeEndKey = historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
 
+	// narrow the range scan to the requested block range and to the entries after the bookmark
+	if options.startBlock > 0 {
+		compositeStartKey = historydb.ConstructCompositeHistoryKey(namespace, key, options.startBlock, 0)
+	}
+	if options.endBlock > 0 {
+		compositeEndKey = historydb.ConstructCompositeHistoryKey(namespace, key, options.endBlock+1, 0)
+	}
+	if options.bookmark != "" {
+		blockNum, tranNum, err := decodeHistoryBookmark(options.bookmark)
+		if err != nil {
+			return nil, err
+		}
+		if options.newestFirst {
+			bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, blockNum, tranNum)
+			if bytes.Compare(bookmarkKey, compositeEndKey) < 0 {
+				compositeEndKey = bookmarkKey
+			}
+		} else {
+			bookmarkKey := historydb.ConstructCompositeHistoryKey(namespace, key, blockNum, tranNum+1)
+			if bytes.Compare(bookmarkKey, compositeStartKey) > 0 {
+				compositeStartKey = bookmarkKey
+			}
+		}
+	}
+
 	// range scan to find any history records starting with namespace~key
 	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
-	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
+	return newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore, options,
+		func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error) {
+			return getKeyModificationFromTran(tranEnvelope, namespace, key)
+		}), nil
+}
+
+// GetHistoryForPrivateDataHash implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForPrivateDataHash(namespace, collection string, keyhash []byte) (commonledger.ResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+
+	// range scan to find any history records starting with prefix~namespace~collection~keyhash
+	compositeStartKey := historydb.ConstructPartialPvtDataHashHistoryKey(namespace, collection, keyhash, false)
+	compositeEndKey := historydb.ConstructPartialPvtDataHashHistoryKey(namespace, collection, keyhash, true)
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return newHistoryScanner(compositeStartKey, namespace, fmt.Sprintf("%s/%x", collection, keyhash), dbItr, q.blockStore, &historyQueryOptions{},
+		func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error) {
+			return getKeyHashModificationFromTran(tranEnvelope, namespace, collection, keyhash)
+		}), nil
+}
+
+// GetHistoryForPartialCompositeKey implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForPartialCompositeKey(namespace string, partialCompositeKey string) (commonledger.ResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+
+	if partialCompositeKey != "" && !historydb.IsCompositeKey(partialCompositeKey) {
+		return nil, errors.Errorf("invalid partial composite key [%#v], only the history of composite keys is indexed by prefix", partialCompositeKey)
+	}
+
+	// range scan to find any history records starting with prefix~namespace~partialcompositekey
+	compositeStartKey := historydb.ConstructPartialCompositeKeyHistoryKey(namespace, partialCompositeKey, false)
+	compositeEndKey := historydb.ConstructPartialCompositeKeyHistoryKey(namespace, partialCompositeKey, true)
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &compositeKeyHistoryScanner{
+		namespacePrefixLen: len(historydb.ConstructPartialCompositeKeyHistoryKey(namespace, "", false)),
+		namespace:          namespace,
+		dbItr:              dbItr,
+		blockStore:         q.blockStore,
+	}, nil
+}
+
+const (
+	optionStartBlock  = "startBlock"
+	optionEndBlock    = "endBlock"
+	optionStartTime   = "startTime"
+	optionEndTime     = "endTime"
+	optionNewestFirst = "newestFirst"
+	optionLimit       = "limit"
+	optionBookmark    = "bookmark"
+)
+
+// historyQueryOptions bounds, orders, and paginates the history of a key
+type historyQueryOptions struct {
+	startBlock  uint64
+	endBlock    uint64
+	startTime   *timestamp.Timestamp
+	endTime     *timestamp.Timestamp
+	newestFirst bool
+	limit       int32
+	bookmark    string
+}
+
+func newHistoryQueryOptions(metadata map[string]interface{}) (*historyQueryOptions, error) {
+	options := &historyQueryOptions{}
+	for key, keyVal := range metadata {
+		ok := false
+		switch key {
+		case optionStartBlock:
+			options.startBlock, ok = keyVal.(uint64)
+		case optionEndBlock:
+			options.endBlock, ok = keyVal.(uint64)
+		case optionStartTime:
+			options.startTime, ok = keyVal.(*timestamp.Timestamp)
+		case optionEndTime:
+			options.endTime, ok = keyVal.(*timestamp.Timestamp)
+		case optionNewestFirst:
+			options.newestFirst, ok = keyVal.(bool)
+		case optionLimit:
+			options.limit, ok = keyVal.(int32)
+		case optionBookmark:
+			options.bookmark, ok = keyVal.(string)
+		default:
+			return nil, errors.Errorf("invalid entry, option %s not recognized", key)
+		}
+		if !ok {
+			return nil, errors.Errorf("invalid entry, option %s has an unexpected type %T", key, keyVal)
+		}
+	}
+	if options.endBlock > 0 && options.endBlock < options.startBlock {
+		return nil, errors.Errorf("invalid block range, end block [%d] is lower than start block [%d]", options.endBlock, options.startBlock)
+	}
+	return options, nil
+}
+
+// withinTimeRange returns true if the given transaction timestamp lies in the time range of the options
+func (options *historyQueryOptions) withinTimeRange(txTimestamp *timestamp.Timestamp) bool {
+	if options.startTime != nil && compareTimestamps(txTimestamp, options.startTime) < 0 {
+		return false
+	}
+	if options.endTime != nil && compareTimestamps(txTimestamp, options.endTime) > 0 {
+		return false
+	}
+	return true
+}
+
+func compareTimestamps(t1, t2 *timestamp.Timestamp) int {
+	switch {
+	case t1.GetSeconds() != t2.GetSeconds():
+		if t1.GetSeconds() < t2.GetSeconds() {
+			return -1
+		}
+		return 1
+	case t1.GetNanos() != t2.GetNanos():
+		if t1.GetNanos() < t2.GetNanos() {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// a history bookmark is the position blockNum:tranNum of the last returned history record
+func encodeHistoryBookmark(blockNum, tranNum uint64) string {
+	return fmt.Sprintf("%d:%d", blockNum, tranNum)
+}
+
+func decodeHistoryBookmark(bookmark string) (uint64, uint64, error) {
+	parts := strings.Split(bookmark, ":")
+	if len(parts) == 2 {
+		blockNum, blockErr := strconv.ParseUint(parts[0], 10, 64)
+		tranNum, tranErr := strconv.ParseUint(parts[1], 10, 64)
+		if blockErr == nil && tranErr == nil {
+			return blockNum, tranNum, nil
+		}
+	}
+	return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
 }
 
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
-	compositePartialKey []byte //compositePartialKey includes namespace~key
-	namespace           string
-	key                 string
-	dbItr               iterator.Iterator
-	blockStore          blkstorage.BlockStore
+	compositePartialKey  []byte //compositePartialKey includes namespace~key
+	namespace            string
+	key                  string
+	dbItr                iterator.Iterator
+	blockStore           blkstorage.BlockStore
+	options              *historyQueryOptions
+	started              bool
+	totalRecordsReturned int32
+	lastBlockNum         uint64
+	lastTranNum          uint64
+	// getKeyModification finds the write of the scanned key in a transaction
+	getKeyModification func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error)
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
-	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	dbItr iterator.Iterator, blockStore blkstorage.BlockStore, options *historyQueryOptions,
+	getKeyModification func(tranEnvelope *common.Envelope) (commonledger.QueryResult, error)) *historyScanner {
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+		options:             options,
+		getKeyModification:  getKeyModification,
+	}
+}
+
+// advance moves the db iterator to the next history record as per the requested order
+func (scanner *historyScanner) advance() bool {
+	if !scanner.options.newestFirst {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
 }
 
 // Next iterates to the next key from history scanner, decodes blockNumTranNumBytes to get blockNum and tranNum,
@@ -69,8 +282,11 @@ func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 // was actually added for some other <ns, key, blockNum, tranNum>. It would cause this iterator to
 // return a history query result out of the order.
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.options.limit > 0 && scanner.totalRecordsReturned >= scanner.options.limit {
+		return nil, nil
+	}
 	for {
-		if !scanner.dbItr.Next() {
+		if !scanner.advance() {
 			return nil, nil
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
@@ -112,12 +328,17 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 				historyKey, scanner.key)
 			continue
 		}
+		if isPruned(err) {
+			logger.Debugf("Skipping history record for namespace:%s key:%s at blockNumTranNum %v:%v in a pruned block",
+				scanner.namespace, scanner.key, blockNum, tranNum)
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
 
 		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-		queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
+		queryResult, err := scanner.getKeyModification(tranEnvelope)
 		if err != nil {
 			return nil, err
 		}
@@ -130,8 +351,16 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 				historyKey, scanner.key)
 			continue
 		}
+		keyModification := queryResult.(*queryresult.KeyModification)
+		if !scanner.options.withinTimeRange(keyModification.Timestamp) {
+			logger.Debugf("Skipping history record for namespace:%s key:%s from transaction %s outside the time range",
+				scanner.namespace, scanner.key, keyModification.TxId)
+			continue
+		}
 		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
-			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+			scanner.namespace, scanner.key, keyModification.TxId)
+		scanner.totalRecordsReturned++
+		scanner.lastBlockNum, scanner.lastTranNum = blockNum, tranNum
 		return queryResult, nil
 	}
 }
@@ -140,39 +369,110 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
-// getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
-func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
-	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
-
-	// extract action from the envelope
-	payload, err := putils.GetPayload(tranEnvelope)
-	if err != nil {
-		return nil, err
+// GetBookmarkAndClose returns the position of the last returned history record. If no record
+// has been returned, the bookmark passed to the query is returned
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	if scanner.totalRecordsReturned == 0 {
+		return scanner.options.bookmark
 	}
+	return encodeHistoryBookmark(scanner.lastBlockNum, scanner.lastTranNum)
+}
 
-	tx, err := putils.GetTransaction(payload.Data)
-	if err != nil {
-		return nil, err
-	}
+// compositeKeyHistoryScanner implements ResultsIterator for iterating through the history of all the composite keys
+// sharing a prefix. The history keys are in the format of <prefix, namespace, compositeKey, blockNum, tranNum> and
+// the value holds the encoded blockNum and tranNum, which marks the end of the composite key in the history key.
+type compositeKeyHistoryScanner struct {
+	namespacePrefixLen int
+	namespace          string
+	dbItr              iterator.Iterator
+	blockStore         blkstorage.BlockStore
+}
 
-	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
-	if err != nil {
-		return nil, err
+// Next iterates to the next history record, loads the block:tran from block storage and returns
+// the modification of the composite key recorded in the history key
+func (scanner *compositeKeyHistoryScanner) Next() (commonledger.QueryResult, error) {
+	for {
+		if !scanner.dbItr.Next() {
+			return nil, nil
+		}
+		historyKey := scanner.dbItr.Key()
+		blockNumTranNumBytes := scanner.dbItr.Value()
+		if len(historyKey) < scanner.namespacePrefixLen+len(blockNumTranNumBytes) {
+			logger.Warnf("Malformed composite key history record [%#v] in namespace [%s]. Skipping", historyKey, scanner.namespace)
+			continue
+		}
+		key := string(historyKey[scanner.namespacePrefixLen : len(historyKey)-len(blockNumTranNumBytes)])
+		blockNum, tranNum, err := decodeBlockNumTranNum(blockNumTranNumBytes)
+		if err != nil {
+			logger.Warnf("Malformed composite key history record [%#v] in namespace [%s]. Skipping (decoding error: %s)",
+				historyKey, scanner.namespace, err)
+			continue
+		}
+
+		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+		if isPruned(err) {
+			logger.Debugf("Skipping history record for namespace:%s key:%#v at blockNumTranNum %v:%v in a pruned block",
+				scanner.namespace, key, blockNum, tranNum)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			logger.Warnf("Key [%#v] not found in the transaction %d:%d recorded in the history for namespace [%s]. Skipping",
+				key, blockNum, tranNum, scanner.namespace)
+			continue
+		}
+		keyModification := queryResult.(*queryresult.KeyModification)
+		keyModification.Key = key
+		logger.Debugf("Found historic key value for namespace:%s key:%#v from transaction %s",
+			scanner.namespace, key, keyModification.TxId)
+		return keyModification, nil
 	}
+}
 
-	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+func (scanner *compositeKeyHistoryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// getKeyHashModificationFromTran returns the hashed write of the private data key in the collection
+func getKeyHashModificationFromTran(tranEnvelope *common.Envelope, namespace, collection string, keyHash []byte) (commonledger.QueryResult, error) {
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
 	if err != nil {
 		return nil, err
 	}
 
-	txID := chdr.TxId
-	timestamp := chdr.Timestamp
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.KeyModification{TxId: txID, Value: kvWriteHash.ValueHash,
+						Timestamp: timestamp, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+		}
+	}
+	logger.Debugf("key hash [%x] not found in collection [%s] of namespace [%s]", keyHash, collection, namespace)
+	return nil, nil
+}
 
-	txRWSet := &rwsetutil.TxRwSet{}
+// getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
+func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
+	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
 
-	// Get the Result from the Action and then Unmarshal
-	// it into a TxReadWriteSet using custom unmarshalling
-	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+	txID, timestamp, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
 		return nil, err
 	}
 
@@ -194,6 +494,46 @@ func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string,
 	return nil, nil
 }
 
+// getTxRWSetFromTran returns the txid, the timestamp and the read-write set of the transaction
+func getTxRWSetFromTran(tranEnvelope *common.Envelope) (string, *timestamp.Timestamp, *rwsetutil.TxRwSet, error) {
+	// extract action from the envelope
+	payload, err := putils.GetPayload(tranEnvelope)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	tx, err := putils.GetTransaction(payload.Data)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+
+	// Get the Result from the Action and then Unmarshal
+	// it into a TxReadWriteSet using custom unmarshalling
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return "", nil, nil, err
+	}
+	return chdr.TxId, chdr.Timestamp, txRWSet, nil
+}
+
+// isPruned returns true if err reports that a transaction is in a block that has been pruned from
+// the block storage; the history records of pruned blocks are retained but cannot be resolved
+func isPruned(err error) bool {
+	_, ok := errors.Cause(err).(*ledger.PrunedErr)
+	return ok
+}
+
 // decodeBlockNumTranNum decodes blockNumTranNumBytes to get blockNum and tranNum.
 func decodeBlockNumTranNum(blockNumTranNumBytes []byte) (uint64, uint64, error) {
 	blockNum, blockBytesConsumed, err := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes)
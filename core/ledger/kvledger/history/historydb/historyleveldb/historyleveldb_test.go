@@ -23,6 +23,7 @@ import (
 	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/history/historydb/historyleveldb/fakes"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
@@ -521,6 +522,266 @@ func TestHistoryWithBlockNumber256(t *testing.T) {
 	assert.Equal(t, "value256", valueInBlock256)
 }
 
+func TestHistoryWithMetadata(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// add 5 blocks, each block has 1 transaction setting state for "ns1" and "key", value is "value<blockNum>"
+	for i := 1; i <= 5; i++ {
+		txid := util2.GenerateUUID()
+		simulator, _ := env.txmgr.NewTxSimulator(txid)
+		simulator.SetState("ns1", "key", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	t.Run("block-range", func(t *testing.T) {
+		vals, _ := testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"startBlock": uint64(2), "endBlock": uint64(4)})
+		assert.Equal(t, []string{"value2", "value3", "value4"}, vals)
+		vals, _ = testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"startBlock": uint64(4)})
+		assert.Equal(t, []string{"value4", "value5"}, vals)
+		vals, _ = testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"startBlock": uint64(6)})
+		assert.Empty(t, vals)
+	})
+
+	t.Run("newest-first", func(t *testing.T) {
+		vals, _ := testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"newestFirst": true})
+		assert.Equal(t, []string{"value5", "value4", "value3", "value2", "value1"}, vals)
+		vals, _ = testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"newestFirst": true, "endBlock": uint64(3)})
+		assert.Equal(t, []string{"value3", "value2", "value1"}, vals)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		for _, newestFirst := range []bool{false, true} {
+			expectedPages := [][]string{{"value1", "value2"}, {"value3", "value4"}, {"value5"}, {}}
+			if newestFirst {
+				expectedPages = [][]string{{"value5", "value4"}, {"value3", "value2"}, {"value1"}, {}}
+			}
+			bookmark := ""
+			for _, expectedPage := range expectedPages {
+				var vals []string
+				previousBookmark := bookmark
+				vals, bookmark = testutilRetrieveHistory(t, qhistory, "ns1", "key",
+					map[string]interface{}{"newestFirst": newestFirst, "limit": int32(2), "bookmark": bookmark})
+				assert.Equal(t, expectedPage, vals)
+				if len(vals) == 0 {
+					assert.Equal(t, previousBookmark, bookmark)
+				}
+			}
+		}
+	})
+
+	t.Run("time-range", func(t *testing.T) {
+		itr, err := qhistory.GetHistoryForKey("ns1", "key")
+		assert.NoError(t, err)
+		allKmods := []*queryresult.KeyModification{}
+		for {
+			kmod, _ := itr.Next()
+			if kmod == nil {
+				break
+			}
+			allKmods = append(allKmods, kmod.(*queryresult.KeyModification))
+		}
+		itr.Close()
+		assert.Len(t, allKmods, 5)
+
+		startTime, endTime := allKmods[1].Timestamp, allKmods[3].Timestamp
+		expectedVals := []string{}
+		for _, kmod := range allKmods {
+			if compareTimestamps(kmod.Timestamp, startTime) >= 0 && compareTimestamps(kmod.Timestamp, endTime) <= 0 {
+				expectedVals = append(expectedVals, string(kmod.Value))
+			}
+		}
+		vals, _ := testutilRetrieveHistory(t, qhistory, "ns1", "key", map[string]interface{}{"startTime": startTime, "endTime": endTime})
+		assert.Equal(t, expectedVals, vals)
+		assert.Contains(t, vals, "value2")
+		assert.Contains(t, vals, "value4")
+	})
+
+	t.Run("invalid-metadata", func(t *testing.T) {
+		_, err := qhistory.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"unknown": true})
+		assert.EqualError(t, err, "invalid entry, option unknown not recognized")
+		_, err = qhistory.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"startBlock": 2})
+		assert.EqualError(t, err, "invalid entry, option startBlock has an unexpected type int")
+		_, err = qhistory.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"startBlock": uint64(4), "endBlock": uint64(2)})
+		assert.EqualError(t, err, "invalid block range, end block [2] is lower than start block [4]")
+		_, err = qhistory.GetHistoryForKeyWithMetadata("ns1", "key", map[string]interface{}{"bookmark": "not-a-bookmark"})
+		assert.EqualError(t, err, "invalid bookmark [not-a-bookmark]")
+	})
+}
+
+func TestHistoryForPrivateDataHash(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(buildRWSet func(rwsetBuilder *rwsetutil.RWSetBuilder)) {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		buildRWSet(rwsetBuilder)
+		simRes, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	//block1
+	commitBlock(func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+		rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+		rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll2", "key1", []byte("value-coll2"))
+	})
+	//block2
+	commitBlock(func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+		rwsetBuilder.AddToWriteSet("ns1", "key1", []byte("public-value"))
+		rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value2"))
+	})
+	//block3
+	commitBlock(func(rwsetBuilder *rwsetutil.RWSetBuilder) {
+		rwsetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", nil)
+	})
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	itr, err := qhistory.GetHistoryForPrivateDataHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err, "Error upon GetHistoryForPrivateDataHash()")
+	kmods := []*queryresult.KeyModification{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		kmods = append(kmods, kmod.(*queryresult.KeyModification))
+	}
+	itr.Close()
+	assert.Len(t, kmods, 3)
+	assert.Equal(t, util.ComputeStringHash("value1"), kmods[0].Value)
+	assert.False(t, kmods[0].IsDelete)
+	assert.Equal(t, util.ComputeStringHash("value2"), kmods[1].Value)
+	assert.False(t, kmods[1].IsDelete)
+	assert.True(t, kmods[2].IsDelete)
+
+	// the public history of the key and the private history of other collections are indexed separately
+	testutilVerifyResults(t, qhistory, "ns1", "key1", []string{"public-value"})
+	itr, err = qhistory.GetHistoryForPrivateDataHash("ns1", "coll2", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	kmod, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value-coll2"), kmod.(*queryresult.KeyModification).Value)
+	kmod, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, kmod)
+	itr.Close()
+}
+
+func TestHistoryForPartialCompositeKey(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// composite keys as created by the chaincode shim
+	compositeKey := func(objectType string, attributes ...string) string {
+		key := "\x00" + objectType + "\x00"
+		for _, attribute := range attributes {
+			key += attribute + "\x00"
+		}
+		return key
+	}
+	blueA1 := compositeKey("asset", "blue", "a1")
+	blueA2 := compositeKey("asset", "blue", "a2")
+	blueishA3 := compositeKey("asset", "blueish", "a3")
+	ownerA1 := compositeKey("owner", "tom", "a1")
+
+	writes := []map[string]string{
+		{blueA2: "value1", blueA1: "value1", ownerA1: "value1", "asset": "value1"},
+		{blueA1: "value2", blueishA3: "value2"},
+		{blueA2: ""},
+	}
+	for _, blockWrites := range writes {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		for key, value := range blockWrites {
+			if value == "" {
+				assert.NoError(t, simulator.DeleteState("ns1", key))
+				continue
+			}
+			assert.NoError(t, simulator.SetState("ns1", key, []byte(value)))
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	retrieve := func(partialCompositeKey string) []string {
+		itr, err := qhistory.GetHistoryForPartialCompositeKey("ns1", partialCompositeKey)
+		assert.NoError(t, err, "Error upon GetHistoryForPartialCompositeKey()")
+		defer itr.Close()
+		results := []string{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				break
+			}
+			keyModification := kmod.(*queryresult.KeyModification)
+			results = append(results, fmt.Sprintf("%q=%s,%t", keyModification.Key, keyModification.Value, keyModification.IsDelete))
+		}
+		return results
+	}
+
+	assert.Equal(t, []string{
+		fmt.Sprintf("%q=value1,false", blueA1),
+		fmt.Sprintf("%q=value2,false", blueA1),
+		fmt.Sprintf("%q=value1,false", blueA2),
+		fmt.Sprintf("%q=,true", blueA2),
+	}, retrieve(compositeKey("asset", "blue")))
+	assert.Len(t, retrieve(compositeKey("asset")), 5)
+	assert.Len(t, retrieve(""), 6)
+	assert.Empty(t, retrieve(compositeKey("asset", "red")))
+
+	_, err = qhistory.GetHistoryForPartialCompositeKey("ns1", "asset")
+	assert.Error(t, err)
+}
+
 func TestName(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -602,3 +863,17 @@ func testutilVerifyWarningMsg(t *testing.T, fakeLogger *fakes.HistoryleveldbLogg
 	}
 	assert.Fail(t, fmt.Sprintf("did not find false key %s in range", falseKey))
 }
+
+func testutilRetrieveHistory(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, metadata map[string]interface{}) ([]string, string) {
+	itr, err := hqe.GetHistoryForKeyWithMetadata(ns, key, metadata)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithMetadata()")
+	retrievedVals := []string{}
+	for {
+		kmod, _ := itr.Next()
+		if kmod == nil {
+			break
+		}
+		retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+	}
+	return retrievedVals, itr.GetBookmarkAndClose()
+}
@@ -36,6 +36,9 @@ import (
 
 var logger = flogging.MustGetLogger("kvledger")
 
+// maxBlocksPerPvtDataPurge is the maximum number of blocks whose pvt data is purged from the stateDB in one batch
+const maxBlocksPerPvtDataPurge = 100
+
 // KVLedger provides an implementation of `ledger.PeerLedger`.
 // This implementation provides a key-value based data model
 type kvLedger struct {
@@ -45,8 +48,11 @@ type kvLedger struct {
 	historyDB              historydb.HistoryDB
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
+	oldBlocksPvtDataLock   sync.Mutex
 	stats                  *ledgerStats
 	commitHash             []byte
+	// pruning tracks the pruning of the block store running in the background
+	pruning sync.WaitGroup
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -339,7 +345,91 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 
 //Prune prunes the blocks/transactions that satisfy the given policy
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
-	return errors.New("not yet implemented")
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+	return l.prune(policy)
+}
+
+// prune is invoked with the blockAPIsRWLock held
+func (l *kvLedger) prune(policy commonledger.PrunePolicy) error {
+	retainFromBlockNum, err := l.retainFromBlockNum(policy)
+	if err != nil {
+		return err
+	}
+	logger.Infof("[%s] Pruning the blocks below block number [%d]", l.ledgerID, retainFromBlockNum)
+	return l.blockStore.Prune(retainFromBlockNum)
+}
+
+// pruneInBackground prunes the blocks below retainFromBlockNum without holding the blockAPIsRWLock,
+// so that removing a large number of block files does not hold up the commits and the block queries.
+// The block store serializes the prunes, and reports the blocks being removed as pruned from the start.
+func (l *kvLedger) pruneInBackground(retainFromBlockNum uint64) {
+	l.pruning.Add(1)
+	go func() {
+		defer l.pruning.Done()
+		logger.Infof("[%s] Pruning the blocks below block number [%d]", l.ledgerID, retainFromBlockNum)
+		if err := l.blockStore.Prune(retainFromBlockNum); err != nil {
+			logger.Errorf("[%s] Error while pruning the blocks below block number [%d]: %+v", l.ledgerID, retainFromBlockNum, err)
+		}
+	}()
+}
+
+// retainFromBlockNum returns the lowest block number that the passed policy retains
+func (l *kvLedger) retainFromBlockNum(policy commonledger.PrunePolicy) (uint64, error) {
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	switch p := policy.(type) {
+	case *commonledger.RetainLastBlocksPolicy:
+		if p.NumBlocks == 0 {
+			return 0, errors.New("the number of blocks to retain must be greater than zero")
+		}
+		if info.Height <= p.NumBlocks {
+			return 0, nil
+		}
+		retainFromBlockNum := info.Height - p.NumBlocks
+		// the last config block is needed to reopen the channel, hence it is always retained
+		lastConfigBlockNum, err := l.lastConfigBlockNum(info)
+		if err != nil {
+			return 0, err
+		}
+		if lastConfigBlockNum < retainFromBlockNum {
+			logger.Infof("[%s] Retaining the blocks from the last config block [%d] rather than from block [%d]",
+				l.ledgerID, lastConfigBlockNum, retainFromBlockNum)
+			retainFromBlockNum = lastConfigBlockNum
+		}
+		return retainFromBlockNum, nil
+
+	case *commonledger.RetainFromConfigBlockPolicy:
+		if p.ConfigBlockNum >= info.Height {
+			return 0, errors.Errorf("block [%d] is beyond the blockchain height [%d]", p.ConfigBlockNum, info.Height)
+		}
+		block, err := l.blockStore.RetrieveBlockByNumber(p.ConfigBlockNum)
+		if err != nil {
+			return 0, errors.WithMessage(err, fmt.Sprintf("error retrieving block [%d]", p.ConfigBlockNum))
+		}
+		if !utils.IsConfigBlock(block) {
+			return 0, errors.Errorf("block [%d] is not a config block", p.ConfigBlockNum)
+		}
+		return p.ConfigBlockNum, nil
+
+	default:
+		return 0, errors.Errorf("unsupported prune policy %T", policy)
+	}
+}
+
+// lastConfigBlockNum returns the number of the last config block, as recorded in the metadata of the last block
+func (l *kvLedger) lastConfigBlockNum(info *common.BlockchainInfo) (uint64, error) {
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(info.Height - 1)
+	if err != nil {
+		return 0, errors.WithMessage(err, fmt.Sprintf("error retrieving block [%d]", info.Height-1))
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return 0, errors.WithMessage(err, fmt.Sprintf("error reading the last config block of block [%d]", info.Height-1))
+	}
+	return lastConfigBlockNum, nil
 }
 
 // NewTxSimulator returns new `ledger.TxSimulator`
@@ -429,6 +519,18 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, co
 		}
 	}
 
+	// the block is committed at this point, a failure to prune is retried at the next interval
+	if retainLastBlocks := ledgerconfig.GetPruningRetainLastBlocks(); retainLastBlocks > 0 &&
+		(blockNo+1)%ledgerconfig.GetPruningInterval() == 0 {
+		logger.Debugf("[%s] Pruning the block store after block [%d]", l.ledgerID, blockNo)
+		retainFromBlockNum, err := l.retainFromBlockNum(&commonledger.RetainLastBlocksPolicy{NumBlocks: retainLastBlocks})
+		if err != nil {
+			logger.Errorf("[%s] Error while pruning the block store after block [%d]: %+v", l.ledgerID, blockNo, err)
+		} else {
+			l.pruneInBackground(retainFromBlockNum)
+		}
+	}
+
 	logger.Infof("[%s] Committed block [%d] with %d transaction(s) in %dms (state_validation=%dms block_and_pvtdata_commit=%dms state_commit=%dms)"+
 		" commitHash=[%x]",
 		l.ledgerID, block.Header.Number, len(block.Data.Data),
@@ -520,16 +622,65 @@ func (l *kvLedger) DoesPvtDataInfoExist(blockNum uint64) (bool, error) {
 	return l.blockStore.DoesPvtDataInfoExist(blockNum)
 }
 
-// Purge removes private read-writes set generated by endorsers at block height lesser than
+// PurgePrivateData removes private read-writes set generated by endorsers at block height lesser than
 // a given maxBlockNumToRetain. In other words, Purge only retains private read-write sets
 // that were generated at block height of maxBlockNumToRetain or higher.
+// The pvt data is first removed from the stateDB and then from the pvtdata store. As the latter
+// records the purge, a crash in between is recovered by invoking this function again.
+// The regular block commits and the commits of the pvt data of old blocks are blocked during the purge.
 func (l *kvLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
-	return errors.New("not yet implemented")
+	l.oldBlocksPvtDataLock.Lock()
+	defer l.oldBlocksPvtDataLock.Unlock()
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if maxBlockNumToRetain > info.Height {
+		return errors.Errorf("cannot purge the private data below block [%d] as the blockchain height is [%d]",
+			maxBlockNumToRetain, info.Height)
+	}
+	minRetainedBlockNum, err := l.blockStore.PrivateDataMinBlockNum()
+	if err != nil {
+		return err
+	}
+	if maxBlockNumToRetain < minRetainedBlockNum {
+		return errors.Errorf("the private data below block [%d] has already been purged", minRetainedBlockNum)
+	}
+
+	logger.Infof("[%s] Purging the private data below block number [%d]", l.ledgerID, maxBlockNumToRetain)
+	blocksPvtData := make(map[uint64][]*ledger.TxPvtData)
+	for blkNum := minRetainedBlockNum; blkNum < maxBlockNumToRetain; blkNum++ {
+		pvtData, err := l.blockStore.GetPvtDataByNum(blkNum, nil)
+		if err != nil {
+			return err
+		}
+		if len(pvtData) > 0 {
+			blocksPvtData[blkNum] = pvtData
+		}
+		if len(blocksPvtData) < maxBlocksPerPvtDataPurge {
+			continue
+		}
+		if err := l.txtmgmt.PurgePvtDataOfOldBlocks(blocksPvtData, maxBlockNumToRetain); err != nil {
+			return err
+		}
+		blocksPvtData = make(map[uint64][]*ledger.TxPvtData)
+	}
+	if err := l.txtmgmt.PurgePvtDataOfOldBlocks(blocksPvtData, maxBlockNumToRetain); err != nil {
+		return err
+	}
+	if err := l.blockStore.PurgePvtDataBelow(maxBlockNumToRetain); err != nil {
+		return err
+	}
+	logger.Infof("[%s] Purged the private data below block number [%d]", l.ledgerID, maxBlockNumToRetain)
+	return nil
 }
 
 // PrivateDataMinBlockNum returns the lowest retained endorsement block height
 func (l *kvLedger) PrivateDataMinBlockNum() (uint64, error) {
-	return 0, errors.New("not yet implemented")
+	return l.blockStore.PrivateDataMinBlockNum()
 }
 
 func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error) {
@@ -537,6 +688,17 @@ func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, e
 }
 
 func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	// the pvt data of the blocks below the lowest retained block must not be
+	// committed again. Holding the lock ensures that the lowest retained block
+	// does not change till the pvt data is committed to the stateDB
+	l.oldBlocksPvtDataLock.Lock()
+	defer l.oldBlocksPvtDataLock.Unlock()
+	minRetainedBlockNum, err := l.blockStore.PrivateDataMinBlockNum()
+	if err != nil {
+		return nil, err
+	}
+	pvtData = filterPvtDataOfPurgedBlocks(pvtData, minRetainedBlockNum)
+
 	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
 		l.ledgerID, len(pvtData))
 
@@ -559,6 +721,19 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*
 	return hashMismatches, nil
 }
 
+func filterPvtDataOfPurgedBlocks(pvtData []*ledger.BlockPvtData, minRetainedBlockNum uint64) []*ledger.BlockPvtData {
+	var retainedPvtData []*ledger.BlockPvtData
+	for _, blockPvtData := range pvtData {
+		if blockPvtData.BlockNum < minRetainedBlockNum {
+			logger.Debugf("Ignoring the pvtData of block [%d] as the private data below block [%d] has been purged",
+				blockPvtData.BlockNum, minRetainedBlockNum)
+			continue
+		}
+		retainedPvtData = append(retainedPvtData, blockPvtData)
+	}
+	return retainedPvtData
+}
+
 func (l *kvLedger) applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData map[uint64][]*ledger.TxPvtData) error {
 	logger.Debugf("[%s:] Filtering pvtData of invalidation transactions", l.ledgerID)
 	committedPvtData, err := filterPvtDataOfInvalidTx(hashVerifiedPvtData, l.blockStore)
@@ -585,6 +760,7 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	l.pruning.Wait()
 	l.blockStore.Shutdown()
 	l.txtmgmt.Shutdown()
 }
@@ -15,9 +15,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/tradeline-tech/fabric/common/flogging"
+	commonledger "github.com/tradeline-tech/fabric/common/ledger"
 	"github.com/tradeline-tech/fabric/common/ledger/testutil"
 	"github.com/tradeline-tech/fabric/common/util"
 	lgr "github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/cceventmgmt"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/tradeline-tech/fabric/core/ledger/testutil"
@@ -32,6 +34,8 @@ func TestMain(m *testing.M) {
 	flogging.ActivateSpec("lockbasedtxmgr,statevalidator,valimpl,confighistory,pvtstatepurgemgmt=debug")
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger")
 	viper.Set("ledger.history.enableHistoryDatabase", true)
+	// the state db registers for the chaincode lifecycle events as it processes the chaincode indexes
+	cceventmgmt.Initialize(nil)
 	os.Exit(m.Run())
 }
 
@@ -120,6 +124,386 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	assert.Equal(t, peer.TxValidationCode_VALID, validCode)
 }
 
+func TestKVLedgerPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	for i := 0; i < 3; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		assert.NoError(t, err)
+		simulator.SetState("ns1", "key1", []byte{byte(i)})
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}, &lgr.CommitOptions{}))
+	}
+	kvl := ledger.(*kvLedger)
+
+	tests := []struct {
+		name                       string
+		policy                     commonledger.PrunePolicy
+		expectedRetainFromBlockNum uint64
+		expectedErr                string
+	}{
+		{
+			name:                       "retain last blocks from the last config block",
+			policy:                     &commonledger.RetainLastBlocksPolicy{NumBlocks: 2},
+			expectedRetainFromBlockNum: 0,
+		},
+		{
+			name:                       "retain more blocks than the height",
+			policy:                     &commonledger.RetainLastBlocksPolicy{NumBlocks: 10},
+			expectedRetainFromBlockNum: 0,
+		},
+		{
+			name:        "retain no block",
+			policy:      &commonledger.RetainLastBlocksPolicy{},
+			expectedErr: "the number of blocks to retain must be greater than zero",
+		},
+		{
+			name:                       "retain from config block",
+			policy:                     &commonledger.RetainFromConfigBlockPolicy{ConfigBlockNum: 0},
+			expectedRetainFromBlockNum: 0,
+		},
+		{
+			name:        "retain from a block that is not a config block",
+			policy:      &commonledger.RetainFromConfigBlockPolicy{ConfigBlockNum: 1},
+			expectedErr: "block [1] is not a config block",
+		},
+		{
+			name:        "retain from a block beyond the height",
+			policy:      &commonledger.RetainFromConfigBlockPolicy{ConfigBlockNum: 4},
+			expectedErr: "block [4] is beyond the blockchain height [4]",
+		},
+		{
+			name:        "unsupported policy",
+			policy:      "all",
+			expectedErr: "unsupported prune policy string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retainFromBlockNum, err := kvl.retainFromBlockNum(tt.policy)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.EqualError(t, ledger.Prune(tt.policy), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRetainFromBlockNum, retainFromBlockNum)
+			// all the blocks are in the same block file, which is never pruned
+			assert.NoError(t, ledger.Prune(tt.policy))
+			_, err = ledger.GetBlockByNumber(0)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestKVLedgerPurgePrivateData(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	minBlockNum, err := ledger.PrivateDataMinBlockNum()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), minBlockNum)
+
+	// block 1 and 3 write key1, block 2 writes key2, and the pvt data of block 4 is missing
+	blockAndPvtData1 := prepareNextBlockForTest(t, ledger, bg, "txid-1", map[string]string{"pubkey1": "pub-value1"}, map[string]string{"key1": "value1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtData1, &lgr.CommitOptions{}))
+	blockAndPvtData2 := prepareNextBlockForTest(t, ledger, bg, "txid-2", map[string]string{"pubkey2": "pub-value2"}, map[string]string{"key2": "value2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtData2, &lgr.CommitOptions{}))
+	blockAndPvtData3 := prepareNextBlockForTest(t, ledger, bg, "txid-3", map[string]string{"pubkey3": "pub-value3"}, map[string]string{"key1": "new-value1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtData3, &lgr.CommitOptions{}))
+	blockAndPvtData4, _ := prepareNextBlockWithMissingPvtDataForTest(t, ledger, bg, "txid-4", map[string]string{"pubkey4": "pub-value4"}, map[string]string{"key4": "value4"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtData4, &lgr.CommitOptions{}))
+
+	assert.EqualError(t, ledger.PurgePrivateData(6), "cannot purge the private data below block [6] as the blockchain height is [5]")
+	assert.NoError(t, ledger.PurgePrivateData(3))
+	minBlockNum, err = ledger.PrivateDataMinBlockNum()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), minBlockNum)
+	assert.EqualError(t, ledger.PurgePrivateData(2), "the private data below block [3] has already been purged")
+
+	// the pvt data of the purged blocks is removed from the pvtdata store and the stateDB
+	pvtData, err := ledger.GetPvtDataByNum(2, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pvtData)
+	pvtData, err = ledger.GetPvtDataByNum(3, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pvtData, 1)
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	val, err := qe.GetPrivateData("ns", "coll", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new-value1"), val)
+	_, err = qe.GetPrivateData("ns", "coll", "key2")
+	assert.IsType(t, &txmgr.ErrPvtdataNotAvailable{}, err)
+	qe.Done()
+
+	// the pvt data of the purged blocks is not committed again
+	hashMismatches, err := ledger.CommitPvtDataOfOldBlocks([]*lgr.BlockPvtData{
+		{BlockNum: 2, WriteSets: blockAndPvtData2.PvtData},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, hashMismatches, 0)
+	pvtData, err = ledger.GetPvtDataByNum(2, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pvtData)
+
+	// the missing pvt data info of the purged blocks is removed as well
+	missingPvtDataInfo, err := ledger.(*kvLedger).GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(t, err)
+	assert.Len(t, missingPvtDataInfo, 1)
+	assert.NoError(t, ledger.PurgePrivateData(5))
+	missingPvtDataInfo, err = ledger.(*kvLedger).GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(t, err)
+	assert.Len(t, missingPvtDataInfo, 0)
+
+	// the ledger continues to commit blocks after the purge
+	blockAndPvtData5 := prepareNextBlockForTest(t, ledger, bg, "txid-5", map[string]string{"pubkey5": "pub-value5"}, map[string]string{"key5": "value5"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtData5, &lgr.CommitOptions{}))
+	pvtData, err = ledger.GetPvtDataByNum(5, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pvtData, 1)
+}
+
+func TestKVLedgerPruneRetainsLastConfigBlock(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// every block is stored in a block file of its own, so that any block can be pruned
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+	provider := testutilNewProvider(t)
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+
+	// blocks [1] and [2] follow the genesis block, block [3] is a config block followed by blocks [4] and [5]
+	prevHash := gb.Header.Hash()
+	for blockNum := uint64(1); blockNum <= 5; blockNum++ {
+		var block *common.Block
+		if blockNum == 3 {
+			block = proto.Clone(gb).(*common.Block)
+			block.Header.Number = blockNum
+			block.Header.PreviousHash = prevHash
+		} else {
+			simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+			assert.NoError(t, err)
+			simulator.SetState("ns1", "key1", []byte{byte(blockNum)})
+			simulator.Done()
+			simRes, err := simulator.GetTxSimulationResults()
+			assert.NoError(t, err)
+			pubSimBytes, err := simRes.GetPubSimulationBytes()
+			assert.NoError(t, err)
+			block = testutil.ConstructBlock(t, blockNum, prevHash, [][]byte{pubSimBytes}, false)
+		}
+		lastConfigBlockNum := uint64(0)
+		if blockNum >= 3 {
+			lastConfigBlockNum = 3
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = putils.MarshalOrPanic(&common.Metadata{
+			Value: putils.MarshalOrPanic(&common.LastConfig{Index: lastConfigBlockNum}),
+		})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		prevHash = block.Header.Hash()
+	}
+
+	// retaining the last block would prune the last config block, which is retained instead
+	assert.NoError(t, ledger.Prune(&commonledger.RetainLastBlocksPolicy{NumBlocks: 1}))
+	_, err = ledger.GetBlockByNumber(2)
+	assert.Equal(t, &lgr.PrunedErr{FirstBlockNum: 3}, err)
+	ledger.Close()
+	provider.Close()
+
+	// the channel can be reopened from its last config block
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	ledger, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	info, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), info.Height)
+	lastBlock, err := ledger.GetBlockByNumber(info.Height - 1)
+	assert.NoError(t, err)
+	lastConfigBlockNum, err := putils.GetLastConfigIndexFromBlock(lastBlock)
+	assert.NoError(t, err)
+	configBlock, err := ledger.GetBlockByNumber(lastConfigBlockNum)
+	assert.NoError(t, err)
+	assert.True(t, putils.IsConfigBlock(configBlock))
+}
+
+func TestKVLedgerPruningPolicyFromConfig(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// every block is stored in a block file of its own, so that any block can be pruned
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+	viper.Set("ledger.pruning.retainLastBlocks", 2)
+	defer viper.Set("ledger.pruning.retainLastBlocks", 0)
+	viper.Set("ledger.pruning.interval", 4)
+	defer viper.Set("ledger.pruning.interval", 1000)
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	// block [3] is a config block, the other blocks following the genesis block are not
+	prevHash := gb.Header.Hash()
+	commitBlock := func(blockNum uint64) {
+		var block *common.Block
+		if blockNum == 3 {
+			block = proto.Clone(gb).(*common.Block)
+			block.Header.Number = blockNum
+			block.Header.PreviousHash = prevHash
+		} else {
+			simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+			assert.NoError(t, err)
+			simulator.SetState("ns1", "key1", []byte{byte(blockNum)})
+			simulator.Done()
+			simRes, err := simulator.GetTxSimulationResults()
+			assert.NoError(t, err)
+			pubSimBytes, err := simRes.GetPubSimulationBytes()
+			assert.NoError(t, err)
+			block = testutil.ConstructBlock(t, blockNum, prevHash, [][]byte{pubSimBytes}, false)
+		}
+		lastConfigBlockNum := uint64(0)
+		if blockNum >= 3 {
+			lastConfigBlockNum = 3
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = putils.MarshalOrPanic(&common.Metadata{
+			Value: putils.MarshalOrPanic(&common.LastConfig{Index: lastConfigBlockNum}),
+		})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		prevHash = block.Header.Hash()
+	}
+
+	// no block is pruned before the height reaches the pruning interval
+	for blockNum := uint64(1); blockNum <= 2; blockNum++ {
+		commitBlock(blockNum)
+	}
+	_, err = ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+
+	// at height [4] the last [2] blocks are retained, the pruning completes in the background
+	commitBlock(3)
+	ledger.(*kvLedger).pruning.Wait()
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Equal(t, &lgr.PrunedErr{FirstBlockNum: 2}, err)
+	_, err = ledger.GetBlockByNumber(2)
+	assert.NoError(t, err)
+
+	// the block store is not pruned again before the next interval
+	for blockNum := uint64(4); blockNum <= 6; blockNum++ {
+		commitBlock(blockNum)
+	}
+	_, err = ledger.GetBlockByNumber(2)
+	assert.NoError(t, err)
+
+	// at height [8] the last config block [3] is retained along with the last [2] blocks
+	commitBlock(7)
+	ledger.(*kvLedger).pruning.Wait()
+	_, err = ledger.GetBlockByNumber(2)
+	assert.Equal(t, &lgr.PrunedErr{FirstBlockNum: 3}, err)
+	configBlock, err := ledger.GetBlockByNumber(3)
+	assert.NoError(t, err)
+	assert.True(t, putils.IsConfigBlock(configBlock))
+	info, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), info.Height)
+}
+
+func TestKVLedgerHistoryAfterPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// every block is stored in a block file of its own, so that any block can be pruned
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	// blocks [1] to [4] write key1 and a composite key, block [3] being a config block
+	compositeKey := "\x00asset\x00a1\x00"
+	prevHash := gb.Header.Hash()
+	for blockNum := uint64(1); blockNum <= 4; blockNum++ {
+		var block *common.Block
+		if blockNum == 3 {
+			block = proto.Clone(gb).(*common.Block)
+			block.Header.Number = blockNum
+			block.Header.PreviousHash = prevHash
+		} else {
+			simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+			assert.NoError(t, err)
+			simulator.SetState("ns1", "key1", []byte{byte(blockNum)})
+			simulator.SetState("ns1", compositeKey, []byte{byte(blockNum)})
+			simulator.Done()
+			simRes, err := simulator.GetTxSimulationResults()
+			assert.NoError(t, err)
+			pubSimBytes, err := simRes.GetPubSimulationBytes()
+			assert.NoError(t, err)
+			block = testutil.ConstructBlock(t, blockNum, prevHash, [][]byte{pubSimBytes}, false)
+		}
+		lastConfigBlockNum := uint64(0)
+		if blockNum >= 3 {
+			lastConfigBlockNum = 3
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = putils.MarshalOrPanic(&common.Metadata{
+			Value: putils.MarshalOrPanic(&common.LastConfig{Index: lastConfigBlockNum}),
+		})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		prevHash = block.Header.Hash()
+	}
+
+	// blocks [0] to [2] are pruned
+	assert.NoError(t, ledger.Prune(&commonledger.RetainLastBlocksPolicy{NumBlocks: 1}))
+	_, err = ledger.GetBlockByNumber(2)
+	assert.Equal(t, &lgr.PrunedErr{FirstBlockNum: 3}, err)
+
+	qhistory, err := ledger.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	retrieve := func(itr commonledger.ResultsIterator, err error) [][]byte {
+		assert.NoError(t, err)
+		defer itr.Close()
+		values := [][]byte{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				return values
+			}
+			values = append(values, kmod.(*queryresult.KeyModification).Value)
+		}
+	}
+
+	// the history records of the pruned blocks are skipped
+	assert.Equal(t, [][]byte{{4}}, retrieve(qhistory.GetHistoryForKey("ns1", "key1")))
+	assert.Equal(t, [][]byte{{4}}, retrieve(qhistory.GetHistoryForPartialCompositeKey("ns1", "\x00asset\x00")))
+}
+
 func TestAddCommitHash(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
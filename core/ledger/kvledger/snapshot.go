@@ -0,0 +1,385 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/snapshot"
+	"github.com/tradeline-tech/fabric/common/ledger/util"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
+	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/confighistory"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerstorage"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+const (
+	// SnapshotMetadataFileName is the name of the file that records the metadata of a snapshot,
+	// including the hashes of all the other files of the snapshot
+	SnapshotMetadataFileName = "_snapshot_metadata.json"
+
+	lastBlockFileName       = "last_block.data"
+	lastConfigBlockFileName = "last_config_block.data"
+)
+
+// snapshotFiles lists the files that every snapshot contains, besides the metadata file
+var snapshotFiles = []string{
+	lastBlockFileName,
+	blkstorage.TxIDsSnapshotFileName,
+	privacyenabledstate.PubStateDataFileName,
+	privacyenabledstate.PvtStateHashesFileName,
+	confighistory.SnapshotFileName,
+}
+
+// snapshotMetadata is the content of the metadata file of a snapshot. The hashes are hex encoded
+type snapshotMetadata struct {
+	ChannelName     string            `json:"channel_name"`
+	LastBlockNumber uint64            `json:"last_block_number"`
+	LastBlockHash   string            `json:"last_block_hash"`
+	FileHashes      map[string]string `json:"file_hashes"`
+}
+
+// ExportSnapshot writes a snapshot of the ledger ledgerID to the directory snapshotDir, which must not exist.
+// The snapshot is taken at the current height of the block store and contains the last block, the last config block,
+// the IDs of all the transactions, the public state, the hashes of the private state, and the config history.
+// The private data and the history of the keys are not exported. The hashes of the files of the snapshot are
+// recorded in the metadata file of the snapshot, whose own hash is returned for being compared out of band.
+// This function is expected to be invoked while the peer is stopped
+func ExportSnapshot(ledgerID string, snapshotDir string) ([]byte, error) {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+	ledgerStoreProvider := ledgerstorage.NewProvider(&disabled.Provider{})
+	defer ledgerStoreProvider.Close()
+	blockStore, err := ledgerStoreProvider.Open(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStore.Shutdown()
+
+	bookkeepingProvider := bookkeeping.NewProvider()
+	defer bookkeepingProvider.Close()
+	// the health check registry is used by the couchdb state database only
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeepingProvider, &disabled.Provider{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer vdbProvider.Close()
+	vdb, err := vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !vdb.IsFullScannable() {
+		return nil, errors.New("exporting a snapshot is not supported by the configured state database")
+	}
+
+	configHistoryMgr := confighistory.NewMgr(nil)
+	defer configHistoryMgr.Close()
+
+	if err := createSnapshotDir(snapshotDir); err != nil {
+		return nil, err
+	}
+
+	return exportSnapshot(ledgerID, snapshotDir, blockStore, vdb, configHistoryMgr)
+}
+
+func exportSnapshot(ledgerID string, snapshotDir string, blockStore *ledgerstorage.Store,
+	vdb privacyenabledstate.DB, configHistoryMgr confighistory.Mgr) ([]byte, error) {
+
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.Height == 0 {
+		return nil, errors.Errorf("cannot export a snapshot of ledger [%s]: the ledger is empty", ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	savepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil || savepoint.BlockNum != lastBlockNum {
+		return nil, errors.Errorf("cannot export a snapshot of ledger [%s]: the state database is not in sync with "+
+			"the block store [height=%d]. Start the peer for the state database to be rebuilt before exporting a snapshot",
+			ledgerID, bcInfo.Height)
+	}
+
+	lastBlock, err := blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	fileHashes := map[string][]byte{}
+	addFileHashes := func(hashes map[string][]byte, err error) error {
+		if err != nil {
+			return err
+		}
+		for f, h := range hashes {
+			fileHashes[f] = h
+		}
+		return nil
+	}
+	writeBlock := func(fileName string, block *common.Block) (map[string][]byte, error) {
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshaling block [%d]", block.Header.Number)
+		}
+		fileHash, err := snapshot.WriteFile(filepath.Join(snapshotDir, fileName), blockBytes)
+		return map[string][]byte{fileName: fileHash}, err
+	}
+
+	if err := addFileHashes(writeBlock(lastBlockFileName, lastBlock)); err != nil {
+		return nil, err
+	}
+	if lastConfigBlockNum != lastBlockNum {
+		lastConfigBlock, err := blockStore.RetrieveBlockByNumber(lastConfigBlockNum)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error retrieving the last config block")
+		}
+		if err := addFileHashes(writeBlock(lastConfigBlockFileName, lastConfigBlock)); err != nil {
+			return nil, err
+		}
+	}
+	if err := addFileHashes(blockStore.ExportTxIds(snapshotDir)); err != nil {
+		return nil, err
+	}
+	if err := addFileHashes(vdb.ExportPubStateAndPvtStateHashes(snapshotDir)); err != nil {
+		return nil, err
+	}
+	if err := addFileHashes(configHistoryMgr.ExportConfigHistory(ledgerID, snapshotDir)); err != nil {
+		return nil, err
+	}
+
+	metadata := &snapshotMetadata{
+		ChannelName:     ledgerID,
+		LastBlockNumber: lastBlockNum,
+		LastBlockHash:   hex.EncodeToString(lastBlock.Header.Hash()),
+		FileHashes:      map[string]string{},
+	}
+	for f, h := range fileHashes {
+		metadata.FileHashes[f] = hex.EncodeToString(h)
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling the snapshot metadata")
+	}
+	metadataHash, err := snapshot.WriteFile(filepath.Join(snapshotDir, SnapshotMetadataFileName), metadataBytes)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Exported a snapshot of ledger [%s] at block [%d] to [%s]", ledgerID, lastBlockNum, snapshotDir)
+	return metadataHash, nil
+}
+
+// SnapshotLedgerID returns the ID of the ledger that the snapshot in the directory snapshotDir was exported from.
+// The metadata of the snapshot is read only if its hash matches metadataHash
+func SnapshotLedgerID(snapshotDir string, metadataHash []byte) (string, error) {
+	metadata, err := readSnapshotMetadata(snapshotDir, metadataHash)
+	if err != nil {
+		return "", err
+	}
+	return metadata.ChannelName, nil
+}
+
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider.
+// The snapshot directory is not trusted: the metadata of the snapshot is verified against metadataHash, which the
+// operator obtains out of band from the exporting peer, and the hashes of the other files of the snapshot are then
+// verified against the metadata before any data is loaded. As in the case of Create, the under construction flag is set while the ledger is created; however,
+// a crash in between leaves a partially created ledger that has to be removed manually
+func (provider *Provider) CreateFromSnapshot(snapshotDir string, metadataHash []byte) (ledger.PeerLedger, error) {
+	metadata, err := readSnapshotMetadata(snapshotDir, metadataHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySnapshot(snapshotDir, metadata); err != nil {
+		return nil, err
+	}
+	lastBlock, err := readSnapshotBlock(snapshotDir, lastBlockFileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLastBlock(lastBlock, metadata); err != nil {
+		return nil, err
+	}
+	var lastConfigBlock *common.Block
+	if _, ok := metadata.FileHashes[lastConfigBlockFileName]; ok {
+		if lastConfigBlock, err = readSnapshotBlock(snapshotDir, lastConfigBlockFileName); err != nil {
+			return nil, err
+		}
+	}
+
+	ledgerID := metadata.ChannelName
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, err
+	}
+	lgr, err := provider.bootstrapFromSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock)
+	if err != nil {
+		logger.Errorf("Error creating ledger [%s] from snapshot. Unsetting under construction flag. Error: %+v", ledgerID, err)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, lastBlock), "Error while marking ledger as created")
+	logger.Infof("Created ledger [%s] from snapshot at block [%d]", ledgerID, metadata.LastBlockNumber)
+	return lgr, nil
+}
+
+func (provider *Provider) bootstrapFromSnapshot(ledgerID string, snapshotDir string,
+	lastBlock *common.Block, lastConfigBlock *common.Block) (ledger.PeerLedger, error) {
+
+	if err := provider.ledgerStoreProvider.BootstrapFromSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock); err != nil {
+		return nil, err
+	}
+	vdb, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	// the savepoint of the state database matches the one recorded when committing lastBlock
+	savepoint := version.NewHeight(lastBlock.Header.Number, uint64(len(lastBlock.Data.Data)-1))
+	if err := vdb.ImportPubStateAndPvtStateHashes(snapshotDir, savepoint); err != nil {
+		return nil, err
+	}
+	if err := provider.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return nil, err
+	}
+	// the history database starts with lastBlock, so that it is not recovered from the pruned blocks
+	if ledgerconfig.IsHistoryDBEnabled() {
+		historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if err := historyDB.Commit(lastBlock); err != nil {
+			return nil, err
+		}
+	}
+	return provider.openInternal(ledgerID)
+}
+
+func createSnapshotDir(snapshotDir string) error {
+	exists, _, err := util.FileExists(snapshotDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("the snapshot directory [%s] already exists", snapshotDir)
+	}
+	if _, err := util.CreateDirIfMissing(snapshotDir); err != nil {
+		return errors.Wrapf(err, "error creating the snapshot directory [%s]", snapshotDir)
+	}
+	return nil
+}
+
+func readSnapshotMetadata(snapshotDir string, metadataHash []byte) (*snapshotMetadata, error) {
+	if len(metadataHash) == 0 {
+		return nil, errors.New("the expected hash of the snapshot metadata is required")
+	}
+	metadataBytes, err := snapshot.ReadVerifiedFile(filepath.Join(snapshotDir, SnapshotMetadataFileName), metadataHash)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &snapshotMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the snapshot metadata")
+	}
+	if metadata.ChannelName == "" {
+		return nil, errors.New("the snapshot metadata does not contain the channel name")
+	}
+	return metadata, nil
+}
+
+// verifySnapshot checks that the snapshot contains all the expected files and that the content
+// of every file matches the hash recorded in the metadata
+func verifySnapshot(snapshotDir string, metadata *snapshotMetadata) error {
+	for _, f := range snapshotFiles {
+		if _, ok := metadata.FileHashes[f]; !ok {
+			return errors.Errorf("the snapshot metadata does not contain the hash of the file [%s]", f)
+		}
+	}
+	for f, expectedHash := range metadata.FileHashes {
+		if filepath.Base(f) != f {
+			return errors.Errorf("invalid file name [%s] in the snapshot metadata", f)
+		}
+		fileHash, err := snapshot.FileHash(filepath.Join(snapshotDir, f))
+		if err != nil {
+			return err
+		}
+		if hex.EncodeToString(fileHash) != expectedHash {
+			return errors.Errorf("the hash of the snapshot file [%s] does not match the hash recorded in the snapshot metadata", f)
+		}
+	}
+	return nil
+}
+
+func readSnapshotBlock(snapshotDir string, fileName string) (*common.Block, error) {
+	blockBytes, err := snapshot.ReadFile(filepath.Join(snapshotDir, fileName))
+	if err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the block in the snapshot file [%s]", fileName)
+	}
+	if block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return nil, errors.Errorf("the block in the snapshot file [%s] is incomplete", fileName)
+	}
+	return block, nil
+}
+
+func checkLastBlock(lastBlock *common.Block, metadata *snapshotMetadata) error {
+	if lastBlock.Header.Number != metadata.LastBlockNumber {
+		return errors.Errorf("the number of the last block [%d] does not match the snapshot metadata [%d]",
+			lastBlock.Header.Number, metadata.LastBlockNumber)
+	}
+	lastBlockHash, err := hex.DecodeString(metadata.LastBlockHash)
+	if err != nil {
+		return errors.Wrap(err, "error decoding the hash of the last block in the snapshot metadata")
+	}
+	if !bytes.Equal(lastBlock.Header.Hash(), lastBlockHash) {
+		return errors.New("the hash of the last block does not match the snapshot metadata")
+	}
+	if len(lastBlock.Data.Data) == 0 {
+		return errors.New("the last block of the snapshot contains no transactions")
+	}
+	return nil
+}
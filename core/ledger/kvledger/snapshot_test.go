@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/common/metrics"
+	"github.com/tradeline-tech/fabric/common/util"
+	lgr "github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/mock"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
+	putils "github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func TestSnapshotExportAndCreateFromSnapshot(t *testing.T) {
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsDir)
+	snapshotDir := filepath.Join(snapshotsDir, "snapshot")
+
+	// export a snapshot of a ledger at height 4
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	var blocks []*common.Block
+	for i := 0; i < 3; i++ {
+		blocks = append(blocks, commitSimulatedBlock(t, ledger, bg, fmt.Sprintf("key%d", i+1), fmt.Sprintf("value%d", i+1)))
+	}
+	ledger.Close()
+	provider.Close()
+
+	_, err = ExportSnapshot("nonExistingLedger", snapshotDir)
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+	metadataHash, err := ExportSnapshot("testLedger", snapshotDir)
+	require.NoError(t, err)
+	assert.NotNil(t, metadataHash)
+	_, err = ExportSnapshot("testLedger", snapshotDir)
+	assert.Contains(t, err.Error(), "already exists")
+	ledgerID, err := SnapshotLedgerID(snapshotDir, metadataHash)
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", ledgerID)
+
+	// create a ledger from the snapshot on another peer
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	ledger, err = provider.CreateFromSnapshot(snapshotDir, metadataHash)
+	require.NoError(t, err)
+	defer ledger.Close()
+	ids, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testLedger"}, ids)
+	_, err = provider.CreateFromSnapshot(snapshotDir, metadataHash)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+	assert.Equal(t, blocks[2].Header.Hash(), bcInfo.CurrentBlockHash)
+
+	// the last block and the last config block are retrievable, the other blocks are not
+	b, err := ledger.GetBlockByNumber(3)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[2], b))
+	b, err = ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(gb, b))
+	_, err = ledger.GetBlockByNumber(1)
+	assert.IsType(t, &lgr.PrunedErr{}, err)
+
+	// the transactions of the snapshot are known to the ledger
+	validationCode, err := ledger.GetTxValidationCodeByTxID(txIDOfBlock(t, blocks[0]))
+	assert.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, validationCode)
+
+	qe, err := ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		val, err := qe.GetState("ns1", fmt.Sprintf("key%d", i+1))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value%d", i+1)), val)
+	}
+	qe.Done()
+
+	// the ledger continues from the block following the last block of the snapshot
+	commitSimulatedBlock(t, ledger, bg, "key4", "value4")
+	bcInfo, err = ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), bcInfo.Height)
+	qe, err = ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState("ns1", "key4")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value4"), val)
+}
+
+func TestCreateFromSnapshotVerification(t *testing.T) {
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsDir)
+	snapshotDir := filepath.Join(snapshotsDir, "snapshot")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	commitSimulatedBlock(t, ledger, bg, "key1", "value1")
+	ledger.Close()
+	provider.Close()
+	metadataHash, err := ExportSnapshot("testLedger", snapshotDir)
+	require.NoError(t, err)
+
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+
+	_, err = provider.CreateFromSnapshot(filepath.Join(snapshotsDir, "nonExisting"), metadataHash)
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	// the expected hash of the metadata is required and has to match the metadata in the snapshot directory
+	_, err = provider.CreateFromSnapshot(snapshotDir, nil)
+	assert.EqualError(t, err, "the expected hash of the snapshot metadata is required")
+	_, err = provider.CreateFromSnapshot(snapshotDir, []byte("wrongHash"))
+	assert.EqualError(t, err, "the hash of the snapshot file [_snapshot_metadata.json] does not match the expected hash")
+
+	// a tampered file is detected before any data is loaded
+	pubStateFile := filepath.Join(snapshotDir, privacyenabledstate.PubStateDataFileName)
+	f, err := os.OpenFile(pubStateFile, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("tampered"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	_, err = provider.CreateFromSnapshot(snapshotDir, metadataHash)
+	assert.EqualError(t, err, "the hash of the snapshot file [public_state.data] does not match the hash recorded in the snapshot metadata")
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// a missing file is detected
+	require.NoError(t, os.Remove(pubStateFile))
+	_, err = provider.CreateFromSnapshot(snapshotDir, metadataHash)
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+}
+
+func commitSimulatedBlock(t *testing.T, ledger lgr.PeerLedger, bg *testutil.BlockGenerator, key, value string) *common.Block {
+	simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", key, []byte(value)))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+	return block
+}
+
+func txIDOfBlock(t *testing.T, block *common.Block) string {
+	env, err := putils.GetEnvelopeFromBlock(block.Data.Data[0])
+	require.NoError(t, err)
+	payload, err := putils.GetPayload(env)
+	require.NoError(t, err)
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	return chdr.TxId
+}
+
+func TestSnapshotExportWithStateDatabase(t *testing.T) {
+	snapshotsDir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsDir)
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.state.stateDatabase", "BulkLevelDB")
+	defer viper.Set("ledger.state.stateDatabase", "goleveldb")
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	commitSimulatedBlock(t, ledger, bg, "key1", "value1")
+	ledger.Close()
+	provider.Close()
+
+	// the snapshot is exported from any state database that supports full scans
+	_, err = ExportSnapshot("testLedger", filepath.Join(snapshotsDir, "snapshot"))
+	assert.NoError(t, err)
+
+	// a state database that does not support full scans is rejected before the snapshot directory is created
+	viper.Set("ledger.state.stateDatabase", "TestNotFullScannableDB")
+	_, err = ExportSnapshot("testLedger", filepath.Join(snapshotsDir, "anotherSnapshot"))
+	assert.EqualError(t, err, "exporting a snapshot is not supported by the configured state database")
+	_, err = os.Stat(filepath.Join(snapshotsDir, "anotherSnapshot"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func init() {
+	statedb.RegisterVersionedDBProviderFactory("TestNotFullScannableDB", func(metrics.Provider) (statedb.VersionedDBProvider, error) {
+		return &notFullScannableDBProvider{}, nil
+	})
+}
+
+type notFullScannableDBProvider struct{}
+
+func (p *notFullScannableDBProvider) GetDBHandle(id string) (statedb.VersionedDB, error) {
+	return &mock.VersionedDB{}, nil
+}
+
+func (p *notFullScannableDBProvider) Close() {}
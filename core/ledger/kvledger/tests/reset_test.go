@@ -187,3 +187,29 @@ func TestResetLedgerWithoutDroppingDBs(t *testing.T) {
 		"This is possible when the state database is not dropped after a ledger reset/rollback. "+
 		"The state database can safely be dropped and will be rebuilt up to block store height upon the next peer start.")
 }
+
+func TestResetAllLedgersWithBulkLevelDB(t *testing.T) {
+	env := newEnv(config{
+		"peer.fileSystemPath":        "/tmp/fabric/ledgertests",
+		"ledger.state.stateDatabase": "BulkLevelDB",
+	}, t)
+	defer env.cleanup()
+	dataHelper := newSampleDataHelper(t)
+	h := newTestHelperCreateLgr("ledger-1", t)
+	dataHelper.populateLedger(h)
+	dataHelper.verifyLedgerContent(h)
+	closeLedgerMgmt()
+	env.verifyNonEmptyDirExists(ledgerconfig.GetStateBulkLevelDBPath())
+
+	// the state of the state database in use is dropped along with the other rebuildable dbs
+	assert.NoError(t, kvledger.ResetAllKVLedgers())
+	env.verifyDirDoesNotExist(ledgerconfig.GetStateBulkLevelDBPath())
+	env.verifyRebuilableDoesNotExist(rebuildableBookkeeper | rebuildableConfigHistory | rebuildableHistoryDB | rebuildableBlockIndex)
+	initLedgerMgmt()
+	h = newTestHelperOpenLgr("ledger-1", t)
+	h.verifyLedgerHeight(1)
+	for _, b := range dataHelper.submittedData["ledger-1"].Blocks {
+		assert.NoError(t, h.lgr.CommitWithPvtData(b, &ledger.CommitOptions{}))
+	}
+	dataHelper.verifyLedgerContent(h)
+}
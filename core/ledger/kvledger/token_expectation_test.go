@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/common/util"
+	lgr "github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	lgrutil "github.com/tradeline-tech/fabric/core/ledger/util"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/token"
+	putils "github.com/tradeline-tech/fabric/protos/utils"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+	"github.com/tradeline-tech/fabric/token/transaction"
+	"github.com/tradeline-tech/fabric/token/transaction/mock"
+)
+
+func TestTokenExpectationAfterPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// every block is stored in a block file of its own, so that any block can be pruned
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+	viper.Set("ledger.pruning.retainLastBlocks", 2)
+	defer viper.Set("ledger.pruning.retainLastBlocks", 0)
+	viper.Set("ledger.pruning.interval", 4)
+	defer viper.Set("ledger.pruning.interval", 1000)
+
+	tmsManager := &mock.TMSManager{}
+	tmsManager.GetTxProcessorReturns(&plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}, nil)
+	customtx.InitializeTestEnv(customtx.Processors{
+		common.HeaderType_TOKEN_TRANSACTION: &transaction.Processor{TMSManager: tmsManager},
+	})
+	defer customtx.InitializeTestEnv(nil)
+
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	outputs := []*token.PlainOutput{{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 100}}
+	expectation := &token.TokenExpectation{
+		Expectation: &token.TokenExpectation_PlainExpectation{
+			PlainExpectation: &token.PlainExpectation{
+				Payload: &token.PlainExpectation_ImportExpectation{
+					ImportExpectation: &token.PlainTokenExpectation{Outputs: outputs},
+				},
+			},
+		},
+	}
+	lastConfig := func(block *common.Block, blockNum uint64) {
+		block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = putils.MarshalOrPanic(&common.Metadata{
+			Value: putils.MarshalOrPanic(&common.LastConfig{Index: blockNum}),
+		})
+	}
+
+	// block [1] sets the token expectation of chaincode transaction cc-tx,
+	// block [2] the one of chaincode transaction cc-tx-invalid, that is invalid
+	blk1 := testutil.NewBlock([]*common.Envelope{createEndorserTxWithExpectation(t, ledger, "cc-tx", expectation)}, 1, gb.Header.Hash())
+	lastConfig(blk1, 0)
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: blk1}, &lgr.CommitOptions{}))
+	blk2 := testutil.NewBlock([]*common.Envelope{createEndorserTxWithExpectation(t, ledger, "cc-tx-invalid", expectation)}, 2, blk1.Header.Hash())
+	lastConfig(blk2, 0)
+	lgrutil.TxValidationFlags(blk2.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]).SetFlag(0, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: blk2}, &lgr.CommitOptions{}))
+
+	// at height [4] the last config block [3] and the last [2] blocks are retained
+	blk3 := proto.Clone(gb).(*common.Block)
+	blk3.Header.Number = 3
+	blk3.Header.PreviousHash = blk2.Header.Hash()
+	lastConfig(blk3, 3)
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: blk3}, &lgr.CommitOptions{}))
+	ledger.(*kvLedger).pruning.Wait()
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Equal(t, &lgr.PrunedErr{FirstBlockNum: 2}, err)
+
+	// the token transactions bound to an unknown or invalid chaincode transaction are invalid,
+	// the one bound to the chaincode transaction in the pruned block is checked against the state
+	blk4 := testutil.NewBlock([]*common.Envelope{
+		createBoundImportTx(t, "token-tx1", "bogus-cc-tx", outputs),
+		createBoundImportTx(t, "token-tx2", "cc-tx-invalid", outputs),
+		createBoundImportTx(t, "token-tx3", "cc-tx", outputs),
+	}, 4, blk3.Header.Hash())
+	lastConfig(blk4, 3)
+	assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: blk4}, &lgr.CommitOptions{}))
+
+	blockPersisted, err := ledger.GetBlockByNumber(4)
+	assert.NoError(t, err)
+	txFilter := lgrutil.TxValidationFlags(blockPersisted.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.Equal(t, peer.TxValidationCode_INVALID_OTHER_REASON, txFilter.Flag(0))
+	assert.Equal(t, peer.TxValidationCode_INVALID_OTHER_REASON, txFilter.Flag(1))
+	assert.Equal(t, peer.TxValidationCode_VALID, txFilter.Flag(2))
+}
+
+// createEndorserTxWithExpectation creates the endorser transaction txID whose chaincode action sets expectation
+func createEndorserTxWithExpectation(t *testing.T, ledger lgr.PeerLedger, txID string, expectation *token.TokenExpectation) *common.Envelope {
+	simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+	assert.NoError(t, err)
+	simulator.SetState("ns1", "key1", []byte(txID))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	txEnv, _, err := testutil.ConstructTransaction(t, pubSimBytes, txID, false)
+	assert.NoError(t, err)
+
+	payload, err := putils.GetPayload(txEnv)
+	assert.NoError(t, err)
+	tx, err := putils.GetTransaction(payload.Data)
+	assert.NoError(t, err)
+	actionPayload, err := putils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	assert.NoError(t, err)
+	responsePayload, err := putils.GetProposalResponsePayload(actionPayload.Action.ProposalResponsePayload)
+	assert.NoError(t, err)
+	chaincodeAction, err := putils.GetChaincodeAction(responsePayload.Extension)
+	assert.NoError(t, err)
+
+	chaincodeAction.TokenExpectation = expectation
+	responsePayload.Extension = putils.MarshalOrPanic(chaincodeAction)
+	actionPayload.Action.ProposalResponsePayload = putils.MarshalOrPanic(responsePayload)
+	tx.Actions[0].Payload = putils.MarshalOrPanic(actionPayload)
+	payload.Data = putils.MarshalOrPanic(tx)
+	txEnv.Payload = putils.MarshalOrPanic(payload)
+	return txEnv
+}
+
+// createBoundImportTx creates the token transaction txID that imports outputs and is bound to the chaincode transaction ccTxID
+func createBoundImportTx(t *testing.T, txID string, ccTxID string, outputs []*token.PlainOutput) *common.Envelope {
+	ttx := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainImport{
+					PlainImport: &token.PlainImport{Outputs: outputs},
+				},
+			},
+		},
+		ChaincodeTxId: ccTxID,
+	}
+	channelHeader := putils.MakeChannelHeader(common.HeaderType_TOKEN_TRANSACTION, 0, "testLedger", 0)
+	channelHeader.TxId = txID
+	payload := &common.Payload{
+		Header: putils.MakePayloadHeader(channelHeader, &common.SignatureHeader{Creator: []byte("issuer")}),
+		Data:   putils.MarshalOrPanic(ttx),
+	}
+	return &common.Envelope{Payload: putils.MarshalOrPanic(payload)}
+}
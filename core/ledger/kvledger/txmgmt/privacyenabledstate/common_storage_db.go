@@ -20,8 +20,10 @@ import (
 	"github.com/tradeline-tech/fabric/core/ledger/cceventmgmt"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
-	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
-	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	// the state databases built into the peer register themselves when imported
+	_ "github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/statebulkleveldb"
+	_ "github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	_ "github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 )
@@ -41,16 +43,19 @@ type CommonStorageDBProvider struct {
 	bookkeepingProvider bookkeeping.Provider
 }
 
-// NewCommonStorageDBProvider constructs an instance of DBProvider
+// NewCommonStorageDBProvider constructs an instance of DBProvider over the state database configured
+// by `ledger.state.stateDatabase`, which is constructed by the factory registered for its name
+// via statedb.RegisterVersionedDBProviderFactory
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider); err != nil {
-			return nil, err
-		}
-	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+	stateDatabase := ledgerconfig.GetStateDatabase()
+	factory, ok := statedb.GetVersionedDBProviderFactory(stateDatabase)
+	if !ok {
+		return nil, errors.Errorf("unsupported state database [%s], the supported state databases are %s",
+			stateDatabase, statedb.RegisteredStateDatabases())
+	}
+	vdbProvider, err := factory(metricsProvider)
+	if err != nil {
+		return nil, err
 	}
 
 	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
@@ -63,9 +68,17 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metrics
 	return dbProvider, nil
 }
 
+// NewCommonStorageDBProviderWithVersionedDBProvider constructs an instance of DBProvider that manages
+// the databases provided by vdbProvider
+func NewCommonStorageDBProviderWithVersionedDBProvider(vdbProvider statedb.VersionedDBProvider, bookkeeperProvider bookkeeping.Provider) DBProvider {
+	return &CommonStorageDBProvider{VersionedDBProvider: vdbProvider, bookkeepingProvider: bookkeeperProvider}
+}
+
+// RegisterHealthChecker registers the state database, if it implements healthz.HealthChecker,
+// under the lower cased name of the configured state database
 func (p *CommonStorageDBProvider) RegisterHealthChecker() error {
 	if healthChecker, ok := p.VersionedDBProvider.(healthz.HealthChecker); ok {
-		return p.HealthCheckRegistry.RegisterChecker("couchdb", healthChecker)
+		return p.HealthCheckRegistry.RegisterChecker(strings.ToLower(ledgerconfig.GetStateDatabase()), healthChecker)
 	}
 	return nil
 }
@@ -105,6 +118,12 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 	return ok
 }
 
+// IsFullScannable implements corresponding function in interface DB
+func (s *CommonStorageDB) IsFullScannable() bool {
+	_, ok := s.VersionedDB.(statedb.FullScannable)
+	return ok
+}
+
 // LoadCommittedVersionsOfPubAndHashedKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
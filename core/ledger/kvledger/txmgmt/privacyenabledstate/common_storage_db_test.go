@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
 
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
@@ -30,6 +32,8 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(err).NotTo(HaveOccurred())
 	gt.Expect(fakeHealthCheckRegistry.RegisterCheckerCallCount()).To(Equal(0))
 
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	defer viper.Reset()
 	dbProvider.VersionedDBProvider = &statecouchdb.VersionedDBProvider{}
 	err = dbProvider.RegisterHealthChecker()
 	gt.Expect(err).NotTo(HaveOccurred())
@@ -39,3 +43,12 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(arg1).To(Equal("couchdb"))
 	gt.Expect(arg2).NotTo(Equal(nil))
 }
+
+func TestNewCommonStorageDBProviderUnsupportedStateDatabase(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	viper.Set("ledger.state.stateDatabase", "UnknownDB")
+	defer viper.Reset()
+
+	_, err := privacyenabledstate.NewCommonStorageDBProvider(nil, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+	gt.Expect(err).To(MatchError("unsupported state database [UnknownDB], the supported state databases are [BulkLevelDB CouchDB goleveldb]"))
+}
@@ -26,6 +26,7 @@ type DBProvider interface {
 type DB interface {
 	statedb.VersionedDB
 	IsBulkOptimizable() bool
+	IsFullScannable() bool
 	LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey, hashedKeys []*HashedCompositeKey) error
 	GetCachedKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, bool)
 	ClearCachedVersions()
@@ -40,6 +41,8 @@ type DB interface {
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error)
+	ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
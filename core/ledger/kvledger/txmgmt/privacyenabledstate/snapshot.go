@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/ledger/snapshot"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+const (
+	// PubStateDataFileName is the name of the snapshot file that contains the public state
+	PubStateDataFileName = "public_state.data"
+	// PvtStateHashesFileName is the name of the snapshot file that contains the hashes of the private state
+	PvtStateHashesFileName = "private_state_hashes.data"
+
+	// maxBatchSizeForImport is the number of snapshot entries applied to the db in a single batch
+	maxBatchSizeForImport = 10000
+)
+
+// ExportPubStateAndPvtStateHashes writes the public state and the hashes of the private state to the snapshot
+// files in the directory dir, and returns the hashes of the files keyed by file name. The private data itself
+// is not exported. The caller is expected to prevent commits to the db until the export is complete.
+//
+// Each record of the public state file holds the namespace, the key, the value, the metadata, and the version
+// of an entry. Each record of the private state hashes file holds the namespace, the collection, the key hash,
+// the value hash, the metadata, and the version of an entry.
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, errors.New("exporting a snapshot is not supported by the configured state database")
+	}
+	itr, err := fullScannable.GetFullScanIterator(isPvtDataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := snapshot.CreateFile(filepath.Join(dir, PubStateDataFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.Close()
+	pvtStateHashesWriter, err := snapshot.CreateFile(filepath.Join(dir, PvtStateHashesFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.Close()
+
+	for {
+		kv, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if kv == nil {
+			break
+		}
+		ns, coll, isHashedData := splitHashedDataNs(kv.Namespace)
+		if !isHashedData {
+			if err := encodeSnapshotEntry(pubStateWriter, []string{kv.Namespace}, []byte(kv.Key), &kv.VersionedValue); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		keyHash := []byte(kv.Key)
+		if !s.BytesKeySupported() {
+			if keyHash, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+				return nil, errors.Wrapf(err, "error decoding the key hash [%s] in namespace [%s]", kv.Key, kv.Namespace)
+			}
+		}
+		if err := encodeSnapshotEntry(pvtStateHashesWriter, []string{ns, coll}, keyHash, &kv.VersionedValue); err != nil {
+			return nil, err
+		}
+	}
+
+	pubStateHash, err := pubStateWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesHash, err := pvtStateHashesWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		PubStateDataFileName:   pubStateHash,
+		PvtStateHashesFileName: pvtStateHashesHash,
+	}, nil
+}
+
+// ImportPubStateAndPvtStateHashes loads the snapshot files written by ExportPubStateAndPvtStateHashes into the db,
+// which is expected to be empty, and records savepoint as the height of the db once all the entries are loaded
+func (s *CommonStorageDB) ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error {
+	pubStateReader, err := snapshot.OpenFile(filepath.Join(dir, PubStateDataFileName))
+	if err != nil {
+		return err
+	}
+	defer pubStateReader.Close()
+	pvtStateHashesReader, err := snapshot.OpenFile(filepath.Join(dir, PvtStateHashesFileName))
+	if err != nil {
+		return err
+	}
+	defer pvtStateHashesReader.Close()
+
+	batch := NewUpdateBatch()
+	batchSize := 0
+	applyBatchIfFull := func() error {
+		batchSize++
+		if batchSize < maxBatchSizeForImport {
+			return nil
+		}
+		// no savepoint is recorded until all the entries are loaded
+		if err := s.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+			return err
+		}
+		batch = NewUpdateBatch()
+		batchSize = 0
+		return nil
+	}
+
+	for {
+		hasMore, err := pubStateReader.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		names, key, vv, err := decodeSnapshotEntry(pubStateReader, 1)
+		if err != nil {
+			return err
+		}
+		batch.PubUpdates.PutValAndMetadata(names[0], string(key), vv.Value, vv.Metadata, vv.Version)
+		if err := applyBatchIfFull(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		hasMore, err := pvtStateHashesReader.HasMore()
+		if err != nil {
+			return err
+		}
+		if !hasMore {
+			break
+		}
+		names, keyHash, vv, err := decodeSnapshotEntry(pvtStateHashesReader, 2)
+		if err != nil {
+			return err
+		}
+		batch.HashUpdates.PutValHashAndMetadata(names[0], names[1], keyHash, vv.Value, vv.Metadata, vv.Version)
+		if err := applyBatchIfFull(); err != nil {
+			return err
+		}
+	}
+	return s.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
+func encodeSnapshotEntry(w *snapshot.FileWriter, names []string, key []byte, vv *statedb.VersionedValue) error {
+	for _, name := range names {
+		if err := w.EncodeString(name); err != nil {
+			return err
+		}
+	}
+	if err := w.EncodeBytes(key); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(vv.Value); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(vv.Metadata); err != nil {
+		return err
+	}
+	return w.EncodeBytes(vv.Version.ToBytes())
+}
+
+func decodeSnapshotEntry(r *snapshot.FileReader, numNames int) ([]string, []byte, *statedb.VersionedValue, error) {
+	names := make([]string, numNames)
+	var err error
+	for i := range names {
+		if names[i], err = r.DecodeString(); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	key, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	vv := &statedb.VersionedValue{}
+	if vv.Value, err = r.DecodeBytes(); err != nil {
+		return nil, nil, nil, err
+	}
+	if vv.Metadata, err = r.DecodeBytes(); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(vv.Metadata) == 0 {
+		vv.Metadata = nil
+	}
+	versionBytes, err := r.DecodeBytes()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if vv.Version, _, err = version.NewHeightFromBytes(versionBytes); err != nil {
+		return nil, nil, nil, err
+	}
+	return names, key, vv, nil
+}
+
+func isPvtDataNs(namespace string) bool {
+	return strings.Contains(namespace, nsJoiner+pvtDataPrefix)
+}
+
+// splitHashedDataNs returns the namespace and the collection of a namespace derived by deriveHashedDataNs
+func splitHashedDataNs(namespace string) (string, string, bool) {
+	parts := strings.SplitN(namespace, nsJoiner+hashDataPrefix, 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
+)
+
+func TestExportAndImportPubStateAndPvtStateHashes(t *testing.T) {
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := testEnv.GetDBHandle("source-db")
+	batch := NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.PubUpdates.Put("ns2", "key1", []byte("value3"), version.NewHeight(2, 1))
+	putPvtUpdates(t, batch, "ns1", "coll1", "key1", []byte("pvtValue1"), version.NewHeight(2, 2))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(2, 2)))
+
+	fileHashes, err := db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	require.NoError(t, err)
+	assert.Len(t, fileHashes, 2)
+	assert.Contains(t, fileHashes, PubStateDataFileName)
+	assert.Contains(t, fileHashes, PvtStateHashesFileName)
+	_, err = db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+
+	importedDB := testEnv.GetDBHandle("imported-db")
+	require.NoError(t, importedDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(2, 2)))
+
+	savepoint, err := importedDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 2), savepoint)
+
+	vv, err := importedDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	vv, err = importedDB.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)}, vv)
+	vv, err = importedDB.GetState("ns2", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(2, 1)}, vv)
+
+	// only the hashes of the private data are exported
+	vv, err = importedDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: util.ComputeHash([]byte("pvtValue1")), Version: version.NewHeight(2, 2)}, vv)
+	vv, err = importedDB.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
+func TestImportPubStateAndPvtStateHashesMissingFiles(t *testing.T) {
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := testEnv.GetDBHandle("imported-db")
+	err = db.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 1))
+	assert.Contains(t, err.Error(), filepath.Join(snapshotDir, PubStateDataFileName))
+}
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// StateDivergence describes an entry of a state database that differs from the expected entry.
+// A nil Expected denotes an unexpected entry and a nil Actual denotes a missing entry.
+// For the hashes of the private data, Key is the hex encoded key hash
+type StateDivergence struct {
+	Namespace  string
+	Collection string
+	Key        string
+	Expected   *statedb.VersionedValue
+	Actual     *statedb.VersionedValue
+}
+
+// CompareState compares the public state, the private state, and the hashes of the private state held by db
+// with the ones held by expectedDB, which has to support full scans, and returns the first entry that differs,
+// or nil if none differs. The savepoints of the databases are not compared.
+//
+// The JSON values held by a db that does not support any bytes as key, such as CouchDB, are compared by content,
+// as such a db does not preserve the formatting of the JSON values. If db does not support full scans, the
+// unexpected entries are only searched for in the namespaces that are present in expectedDB
+func CompareState(expectedDB, db DB) (*StateDivergence, error) {
+	expectedScannable, ok := fullScannable(expectedDB)
+	if !ok {
+		return nil, errors.New("the expected state database does not support full scans")
+	}
+	itr, err := expectedScannable.GetFullScanIterator(func(string) bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	namespaces := map[string]struct{}{}
+	for {
+		expected, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if expected == nil {
+			break
+		}
+		namespaces[expected.Namespace] = struct{}{}
+		actual, err := db.GetState(expected.Namespace, dbKey(db, expected.Namespace, expected.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !sameVersionedValue(&expected.VersionedValue, actual, !db.BytesKeySupported()) {
+			return newStateDivergence(expected.Namespace, expected.Key, &expected.VersionedValue, actual), nil
+		}
+	}
+
+	if scannable, ok := fullScannable(db); ok {
+		itr, err := scannable.GetFullScanIterator(func(string) bool { return false })
+		if err != nil {
+			return nil, err
+		}
+		defer itr.Close()
+		return findUnexpectedEntry(expectedDB, db, itr.Next)
+	}
+
+	sortedNamespaces := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		sortedNamespaces = append(sortedNamespaces, ns)
+	}
+	sort.Strings(sortedNamespaces)
+	for _, ns := range sortedNamespaces {
+		divergence, err := findUnexpectedEntryInNs(expectedDB, db, ns)
+		if err != nil || divergence != nil {
+			return divergence, err
+		}
+	}
+	return nil, nil
+}
+
+func findUnexpectedEntryInNs(expectedDB, db DB, ns string) (*StateDivergence, error) {
+	itr, err := db.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+	return findUnexpectedEntry(expectedDB, db, func() (*statedb.VersionedKV, error) {
+		result, err := itr.Next()
+		if err != nil || result == nil {
+			return nil, err
+		}
+		return result.(*statedb.VersionedKV), nil
+	})
+}
+
+// findUnexpectedEntry returns the first entry returned by next that is not present in expectedDB
+func findUnexpectedEntry(expectedDB, db DB, next func() (*statedb.VersionedKV, error)) (*StateDivergence, error) {
+	for {
+		actual, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if actual == nil {
+			return nil, nil
+		}
+		key, err := appKey(db, actual.Namespace, actual.Key)
+		if err != nil {
+			return nil, err
+		}
+		expected, err := expectedDB.GetState(actual.Namespace, dbKey(expectedDB, actual.Namespace, key))
+		if err != nil {
+			return nil, err
+		}
+		if expected == nil {
+			return newStateDivergence(actual.Namespace, key, nil, &actual.VersionedValue), nil
+		}
+	}
+}
+
+func fullScannable(db DB) (statedb.FullScannable, bool) {
+	commonStorageDB, ok := db.(*CommonStorageDB)
+	if !ok {
+		return nil, false
+	}
+	scannable, ok := commonStorageDB.VersionedDB.(statedb.FullScannable)
+	return scannable, ok
+}
+
+// dbKey returns the key under which db stores the key of the namespace ns
+func dbKey(db DB, ns, key string) string {
+	if _, _, isHashedData := splitHashedDataNs(ns); isHashedData && !db.BytesKeySupported() {
+		return base64.StdEncoding.EncodeToString([]byte(key))
+	}
+	return key
+}
+
+// appKey is the inverse of dbKey
+func appKey(db DB, ns, key string) (string, error) {
+	if _, _, isHashedData := splitHashedDataNs(ns); !isHashedData || db.BytesKeySupported() {
+		return key, nil
+	}
+	keyHash, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", errors.Wrapf(err, "error decoding the key hash [%s] in namespace [%s]", key, ns)
+	}
+	return string(keyHash), nil
+}
+
+func sameVersionedValue(expected, actual *statedb.VersionedValue, compareJSONContent bool) bool {
+	if expected == nil || actual == nil {
+		return expected == actual
+	}
+	if !bytes.Equal(expected.Metadata, actual.Metadata) || expected.Version.Compare(actual.Version) != 0 {
+		return false
+	}
+	if bytes.Equal(expected.Value, actual.Value) {
+		return true
+	}
+	if !compareJSONContent {
+		return false
+	}
+	var expectedJSON, actualJSON interface{}
+	if json.Unmarshal(expected.Value, &expectedJSON) != nil || json.Unmarshal(actual.Value, &actualJSON) != nil {
+		return false
+	}
+	return reflect.DeepEqual(expectedJSON, actualJSON)
+}
+
+func newStateDivergence(ns, key string, expected, actual *statedb.VersionedValue) *StateDivergence {
+	divergence := &StateDivergence{Namespace: ns, Key: key, Expected: expected, Actual: actual}
+	if namespace, coll, isHashedData := splitHashedDataNs(ns); isHashedData {
+		divergence.Namespace, divergence.Collection, divergence.Key = namespace, coll, hex.EncodeToString([]byte(key))
+	} else if parts := strings.SplitN(ns, nsJoiner+pvtDataPrefix, 2); len(parts) == 2 {
+		divergence.Namespace, divergence.Collection = parts[0], parts[1]
+	}
+	return divergence
+}
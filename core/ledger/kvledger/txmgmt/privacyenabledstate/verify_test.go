@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
+)
+
+func TestCompareState(t *testing.T) {
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+
+	populate := func(db DB, extraUpdates func(batch *UpdateBatch)) {
+		batch := NewUpdateBatch()
+		batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+		batch.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+		putPvtUpdates(t, batch, "ns1", "coll1", "key1", []byte("pvtValue1"), version.NewHeight(2, 1))
+		if extraUpdates != nil {
+			extraUpdates(batch)
+		}
+		require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(2, 1)))
+	}
+	expectedDB := testEnv.GetDBHandle("expected-db")
+	populate(expectedDB, nil)
+
+	t.Run("same-state", func(t *testing.T) {
+		db := testEnv.GetDBHandle("same-db")
+		populate(db, nil)
+		divergence, err := CompareState(expectedDB, db)
+		assert.NoError(t, err)
+		assert.Nil(t, divergence)
+	})
+
+	t.Run("different-value", func(t *testing.T) {
+		db := testEnv.GetDBHandle("different-value-db")
+		populate(db, func(batch *UpdateBatch) {
+			batch.PubUpdates.Put("ns1", "key1", []byte("otherValue"), version.NewHeight(1, 1))
+		})
+		divergence, err := CompareState(expectedDB, db)
+		assert.NoError(t, err)
+		assert.Equal(t, &StateDivergence{
+			Namespace: "ns1",
+			Key:       "key1",
+			Expected:  &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+			Actual:    &statedb.VersionedValue{Value: []byte("otherValue"), Version: version.NewHeight(1, 1)},
+		}, divergence)
+	})
+
+	t.Run("different-version", func(t *testing.T) {
+		db := testEnv.GetDBHandle("different-version-db")
+		populate(db, func(batch *UpdateBatch) {
+			batch.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(2, 1))
+		})
+		divergence, err := CompareState(expectedDB, db)
+		assert.NoError(t, err)
+		require.NotNil(t, divergence)
+		assert.Equal(t, "key2", divergence.Key)
+		assert.Equal(t, version.NewHeight(2, 1), divergence.Actual.Version)
+	})
+
+	t.Run("missing-hashed-entry", func(t *testing.T) {
+		db := testEnv.GetDBHandle("missing-hashed-entry-db")
+		populate(db, func(batch *UpdateBatch) {
+			batch.HashUpdates.Delete("ns1", "coll1", util.ComputeStringHash("key1"), version.NewHeight(2, 1))
+		})
+		divergence, err := CompareState(expectedDB, db)
+		assert.NoError(t, err)
+		assert.Equal(t, &StateDivergence{
+			Namespace:  "ns1",
+			Collection: "coll1",
+			Key:        hex.EncodeToString(util.ComputeStringHash("key1")),
+			Expected:   &statedb.VersionedValue{Value: util.ComputeHash([]byte("pvtValue1")), Version: version.NewHeight(2, 1)},
+		}, divergence)
+	})
+
+	t.Run("unexpected-private-entry", func(t *testing.T) {
+		db := testEnv.GetDBHandle("unexpected-private-entry-db")
+		populate(db, func(batch *UpdateBatch) {
+			batch.PvtUpdates.Put("ns1", "coll2", "key3", []byte("pvtValue3"), version.NewHeight(2, 1))
+		})
+		divergence, err := CompareState(expectedDB, db)
+		assert.NoError(t, err)
+		assert.Equal(t, &StateDivergence{
+			Namespace:  "ns1",
+			Collection: "coll2",
+			Key:        "key3",
+			Actual:     &statedb.VersionedValue{Value: []byte("pvtValue3"), Version: version.NewHeight(2, 1)},
+		}, divergence)
+	})
+}
+
+func TestSameVersionedValueComparesJSONContent(t *testing.T) {
+	expected := &statedb.VersionedValue{Value: []byte(`{"a":1,"b":"x"}`), Version: version.NewHeight(1, 1)}
+	actual := &statedb.VersionedValue{Value: []byte(`{"b": "x", "a": 1}`), Version: version.NewHeight(1, 1)}
+	assert.False(t, sameVersionedValue(expected, actual, false))
+	assert.True(t, sameVersionedValue(expected, actual, true))
+	assert.False(t, sameVersionedValue(expected, nil, true))
+	assert.True(t, sameVersionedValue(nil, nil, true))
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+// PvtdataKeyPurgesOfBlock returns the private data keys purged by the valid endorser transactions of the block.
+// The validation flags present in the block metadata are expected to be the final ones, i.e., this function
+// is meant to be invoked on a block that has gone through the ledger validation. A transaction whose results
+// cannot be parsed as a read-write set cannot carry any purge and hence, is skipped
+func PvtdataKeyPurgesOfBlock(block *common.Block) ([]*ledger.PvtdataKeyPurge, error) {
+	var purges []*ledger.PvtdataKeyPurge
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(txIndex) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		respPayload, err := utils.GetActionFromEnvelopeMsg(env)
+		if err != nil {
+			return nil, err
+		}
+		txRWSet := &TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			logger.Warningf("Skipping tx [%d] of block [%d] while looking for private data purges, as its results could not be parsed: %s",
+				txIndex, block.Header.Number, err)
+			continue
+		}
+		purges = append(purges, txRWSet.pvtdataKeyPurges(block.Header.Number, uint64(txIndex))...)
+	}
+	return purges, nil
+}
+
+// PvtdataKeyPurgesOfValidTxs returns the purges, among the given ones, that belong to a transaction which is valid as per
+// the current validation flags of the block. This allows the purges computed ahead of the ledger validation to be
+// narrowed down to the transactions that are eventually committed as valid
+func PvtdataKeyPurgesOfValidTxs(block *common.Block, purges []*ledger.PvtdataKeyPurge) []*ledger.PvtdataKeyPurge {
+	var validPurges []*ledger.PvtdataKeyPurge
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for _, purge := range purges {
+		if txsFilter.IsInvalid(int(purge.TxNum)) {
+			continue
+		}
+		validPurges = append(validPurges, purge)
+	}
+	return validPurges
+}
+
+func (txRwSet *TxRwSet) pvtdataKeyPurges(blkNum, txNum uint64) []*ledger.PvtdataKeyPurge {
+	var purges []*ledger.PvtdataKeyPurge
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if !hashedWrite.IsPurge {
+					continue
+				}
+				purges = append(purges, &ledger.PvtdataKeyPurge{
+					BlockNum:   blkNum,
+					TxNum:      txNum,
+					Namespace:  nsRwSet.NameSpace,
+					Collection: collHashedRwSet.CollectionName,
+					KeyHash:    hashedWrite.KeyHash,
+				})
+			}
+		}
+	}
+	return purges
+}
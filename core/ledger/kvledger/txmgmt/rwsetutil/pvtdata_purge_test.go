@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
+)
+
+func TestPvtdataKeyPurgesOfBlock(t *testing.T) {
+	simResultsWithPurges := func(keys ...string) []byte {
+		rwSetBuilder := NewRWSetBuilder()
+		rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key0", []byte("value0"))
+		for _, key := range keys {
+			rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", key)
+		}
+		simRes, err := rwSetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		return pubSimBytes
+	}
+
+	block := testutil.ConstructBlock(t, 5, []byte("previousHash"),
+		[][]byte{
+			simResultsWithPurges("key1"),
+			simResultsWithPurges(),
+			simResultsWithPurges("key2"),
+			simResultsWithPurges("key3"),
+		},
+		false,
+	)
+	txsFilter := util.NewTxValidationFlagsSetValue(len(block.Data.Data), peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(2, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	purges, err := PvtdataKeyPurgesOfBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]*ledger.PvtdataKeyPurge{
+			{BlockNum: 5, TxNum: 0, Namespace: "ns1", Collection: "coll1", KeyHash: util.ComputeStringHash("key1")},
+			{BlockNum: 5, TxNum: 3, Namespace: "ns1", Collection: "coll1", KeyHash: util.ComputeStringHash("key3")},
+		},
+		purges,
+	)
+
+	block.Data.Data[0] = []byte("garbage")
+	_, err = PvtdataKeyPurgesOfBlock(block)
+	assert.Error(t, err)
+}
+
+func TestPvtdataKeyPurgesOfValidTxs(t *testing.T) {
+	block := testutil.ConstructBlock(t, 5, []byte("previousHash"), [][]byte{{}, {}, {}}, false)
+	txsFilter := util.NewTxValidationFlagsSetValue(len(block.Data.Data), peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	purges := []*ledger.PvtdataKeyPurge{
+		{BlockNum: 5, TxNum: 0, Namespace: "ns1", Collection: "coll1", KeyHash: util.ComputeStringHash("key1")},
+		{BlockNum: 5, TxNum: 1, Namespace: "ns1", Collection: "coll1", KeyHash: util.ComputeStringHash("key2")},
+		{BlockNum: 5, TxNum: 2, Namespace: "ns1", Collection: "coll2", KeyHash: util.ComputeStringHash("key3")},
+	}
+	assert.Equal(t,
+		[]*ledger.PvtdataKeyPurge{purges[0], purges[2]},
+		PvtdataKeyPurgesOfValidTxs(block, purges),
+	)
+	assert.Nil(t, PvtdataKeyPurgesOfValidTxs(block, nil))
+}
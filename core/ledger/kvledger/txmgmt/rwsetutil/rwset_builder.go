@@ -112,6 +112,20 @@ func (b *RWSetBuilder) AddToHashedReadSet(ns string, coll string, key string, ve
 // AddToPvtAndHashedWriteSet adds a key and value to the private and hashed write-set
 func (b *RWSetBuilder) AddToPvtAndHashedWriteSet(ns string, coll string, key string, value []byte) {
 	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, value)
+	collHashedRwBuilder := b.getOrCreateCollHashedRwBuilder(ns, coll)
+	if existing, ok := collHashedRwBuilder.writeMap[key]; ok && existing.IsPurge {
+		// a write following a purge in the same transaction does not cancel the purge of the history
+		kvWriteHash.IsPurge = true
+	}
+	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
+	collHashedRwBuilder.writeMap[key] = kvWriteHash
+}
+
+// AddToPvtAndHashedWriteSetForPurge adds a delete of the key to the private write-set and
+// a delete marked as purge to the hashed write-set
+func (b *RWSetBuilder) AddToPvtAndHashedWriteSetForPurge(ns, coll, key string) {
+	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, nil)
+	kvWriteHash.IsPurge = true
 	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
 	b.getOrCreateCollHashedRwBuilder(ns, coll).writeMap[key] = kvWriteHash
 }
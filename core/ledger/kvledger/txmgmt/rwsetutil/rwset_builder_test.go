@@ -343,6 +343,47 @@ func TestTxSimulationResultWithMetadata(t *testing.T) {
 	assert.Equal(t, expectedPubRWSet, actualSimRes.PubSimulationResults)
 }
 
+func TestTxSimulationResultWithPvtDataPurge(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key1")
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key2")
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key2", []byte("value2"))
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key3", []byte("value3"))
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	expectedPvtNs1Coll1 := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			newKVWrite("key1", nil),
+			newKVWrite("key2", []byte("value2")),
+			newKVWrite("key3", []byte("value3")),
+		},
+	}
+	assert.Equal(t,
+		serializeTestProtoMsg(t, expectedPvtNs1Coll1),
+		actualSimRes.PvtSimulationResults.NsPvtRwset[0].CollectionPvtRwset[0].Rwset,
+	)
+
+	// a purge is recorded as a delete in the hashed write-set and is retained when the key is written again in the same transaction
+	txRwSet, err := TxRwSetFromProtoMsg(actualSimRes.PubSimulationResults)
+	assert.NoError(t, err)
+	hashedWrites := txRwSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedWrites
+	assert.Len(t, hashedWrites, 3)
+	writeHashes := map[string]*kvrwset.KVWriteHash{}
+	for _, w := range hashedWrites {
+		writeHashes[string(w.KeyHash)] = w
+	}
+	key1WriteHash := writeHashes[string(util.ComputeStringHash("key1"))]
+	assert.True(t, key1WriteHash.IsDelete)
+	assert.True(t, key1WriteHash.IsPurge)
+	key2WriteHash := writeHashes[string(util.ComputeStringHash("key2"))]
+	assert.False(t, key2WriteHash.IsDelete)
+	assert.True(t, key2WriteHash.IsPurge)
+	assert.Equal(t, util.ComputeHash([]byte("value2")), key2WriteHash.ValueHash)
+	assert.False(t, writeHashes[string(util.ComputeStringHash("key3"))].IsPurge)
+}
+
 func constructTestPvtKVReadHash(t *testing.T, key string, version *version.Height) *kvrwset.KVReadHash {
 	kvReadHash := newPvtKVReadHash(key, version)
 	return kvReadHash
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commontests
+
+import (
+	"testing"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// conformanceTests are the tests that every state database is expected to pass,
+// regardless of the query language it supports
+var conformanceTests = []struct {
+	name string
+	test func(t *testing.T, dbProvider statedb.VersionedDBProvider)
+}{
+	{"GetStateMultipleKeys", TestGetStateMultipleKeys},
+	{"BasicRW", TestBasicRW},
+	{"MultiDBBasicRW", TestMultiDBBasicRW},
+	{"Deletes", TestDeletes},
+	{"Iterator", TestIterator},
+	{"GetVersion", TestGetVersion},
+	{"ValueAndMetadataWrites", TestValueAndMetadataWrites},
+	{"PaginatedRangeQuery", TestPaginatedRangeQuery},
+	{"ApplyUpdatesWithNilHeight", TestApplyUpdatesWithNilHeight},
+}
+
+// RunConformanceSuite runs, as subtests of t, the tests that a state database has to pass for being
+// registered via statedb.RegisterVersionedDBProviderFactory. Each test is given the provider returned by
+// newProvider, which is expected to manage empty databases, and cleanup is invoked once the test is done.
+// The tests of the query language of a state database, such as TestQuery, are not part of the suite
+func RunConformanceSuite(t *testing.T, newProvider func(t *testing.T) statedb.VersionedDBProvider, cleanup func()) {
+	for _, conformanceTest := range conformanceTests {
+		test := conformanceTest.test
+		t.Run(conformanceTest.name, func(t *testing.T) {
+			defer cleanup()
+			test(t, newProvider(t))
+		})
+	}
+}
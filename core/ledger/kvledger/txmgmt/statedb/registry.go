@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tradeline-tech/fabric/common/metrics"
+)
+
+// VersionedDBProviderFactory constructs the VersionedDBProvider of a state database
+type VersionedDBProviderFactory func(metricsProvider metrics.Provider) (VersionedDBProvider, error)
+
+var registry = struct {
+	mutex     sync.RWMutex
+	factories map[string]VersionedDBProviderFactory
+	dataPaths map[string]func() string
+}{
+	factories: map[string]VersionedDBProviderFactory{},
+	dataPaths: map[string]func() string{},
+}
+
+// RegisterVersionedDBProviderFactory makes the state database name, as configured by
+// `ledger.state.stateDatabase`, available through factory. It is expected to be invoked
+// from the init function of the package implementing the state database, and panics
+// if name is empty or already registered
+func RegisterVersionedDBProviderFactory(name string, factory VersionedDBProviderFactory) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if name == "" || factory == nil {
+		panic("a state database requires a name and a factory")
+	}
+	if _, ok := registry.factories[name]; ok {
+		panic(fmt.Sprintf("state database [%s] is already registered", name))
+	}
+	registry.factories[name] = factory
+}
+
+// GetVersionedDBProviderFactory returns the factory registered for the state database name
+func GetVersionedDBProviderFactory(name string) (VersionedDBProviderFactory, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	factory, ok := registry.factories[name]
+	return factory, ok
+}
+
+// RegisterDataPath records dataPath as the function returning the local directory that holds the data of the
+// state database name. It is expected to be invoked, along with RegisterVersionedDBProviderFactory, by the
+// state databases that are embedded in the peer, so that their data can be dropped without opening them.
+// It panics if name is empty or its data path is already registered
+func RegisterDataPath(name string, dataPath func() string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if name == "" || dataPath == nil {
+		panic("a state database data path requires a name and a path")
+	}
+	if _, ok := registry.dataPaths[name]; ok {
+		panic(fmt.Sprintf("the data path of state database [%s] is already registered", name))
+	}
+	registry.dataPaths[name] = dataPath
+}
+
+// DataPaths returns the local directories that hold the data of the registered state databases, keyed by the
+// name of the state database. The directories are returned whether or not the state database is configured,
+// as the peer may have used a different state database in the past
+func DataPaths() map[string]string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	dataPaths := make(map[string]string, len(registry.dataPaths))
+	for name, dataPath := range registry.dataPaths {
+		dataPaths[name] = dataPath()
+	}
+	return dataPaths
+}
+
+// RegisteredStateDatabases returns the sorted names of the registered state databases
+func RegisteredStateDatabases() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/common/metrics"
+)
+
+func TestRegisterVersionedDBProviderFactory(t *testing.T) {
+	_, ok := GetVersionedDBProviderFactory("testDB")
+	assert.False(t, ok)
+
+	factory := func(metrics.Provider) (VersionedDBProvider, error) { return nil, nil }
+	RegisterVersionedDBProviderFactory("testDB", factory)
+	RegisterVersionedDBProviderFactory("anotherTestDB", factory)
+	defer func() {
+		registry.mutex.Lock()
+		delete(registry.factories, "testDB")
+		delete(registry.factories, "anotherTestDB")
+		registry.mutex.Unlock()
+	}()
+
+	registeredFactory, ok := GetVersionedDBProviderFactory("testDB")
+	require.True(t, ok)
+	assert.NotNil(t, registeredFactory)
+	assert.Equal(t, []string{"anotherTestDB", "testDB"}, RegisteredStateDatabases())
+
+	assert.PanicsWithValue(t, "state database [testDB] is already registered", func() {
+		RegisterVersionedDBProviderFactory("testDB", factory)
+	})
+	assert.Panics(t, func() { RegisterVersionedDBProviderFactory("", factory) })
+	assert.Panics(t, func() { RegisterVersionedDBProviderFactory("yetAnotherTestDB", nil) })
+}
+
+func TestRegisterDataPath(t *testing.T) {
+	RegisterDataPath("testDB", func() string { return "/test/path" })
+	defer func() {
+		registry.mutex.Lock()
+		delete(registry.dataPaths, "testDB")
+		registry.mutex.Unlock()
+	}()
+
+	assert.Equal(t, map[string]string{"testDB": "/test/path"}, DataPaths())
+	assert.PanicsWithValue(t, "the data path of state database [testDB] is already registered", func() {
+		RegisterDataPath("testDB", func() string { return "/another/path" })
+	})
+	assert.Panics(t, func() { RegisterDataPath("", func() string { return "/test/path" }) })
+	assert.Panics(t, func() { RegisterDataPath("anotherTestDB", nil) })
+}
@@ -0,0 +1,410 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package statebulkleveldb provides an embedded state database for peers that commit large
+// blocks and serve heavy range queries. Unlike stateleveldb, which keeps the state of all the
+// channels in a single LevelDB, it maintains a LevelDB per channel under its own directory and
+// opens it with options favouring large batched writes and sequential reads over a small memory
+// footprint. It also implements the bulk loading of the committed versions, so that the validation
+// of a block reads the versions of all the keys of the block in a single pass over the db.
+// The state is not shared with stateleveldb, so switching between the two state databases
+// requires the state to be rebuilt from the blocks.
+package statebulkleveldb
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/common/metrics"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
+)
+
+var logger = flogging.MustGetLogger("statebulkleveldb")
+
+// the keys of the state are prefixed so that they never collide with the savepoint
+var dataKeyPrefix = []byte{'d'}
+var compositeKeySep = []byte{0x00}
+var lastKeyIndicator = byte(0x01)
+var savePointKey = []byte{'s'}
+
+// StateDatabaseName is the value of `ledger.state.stateDatabase` that selects this state database
+const StateDatabaseName = "BulkLevelDB"
+
+func init() {
+	statedb.RegisterVersionedDBProviderFactory(StateDatabaseName, func(metrics.Provider) (statedb.VersionedDBProvider, error) {
+		return NewVersionedDBProvider(), nil
+	})
+	statedb.RegisterDataPath(StateDatabaseName, ledgerconfig.GetStateBulkLevelDBPath)
+}
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	dbPath    string
+	databases map[string]*versionedDB
+	mux       sync.Mutex
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider() *VersionedDBProvider {
+	return NewVersionedDBProviderWithPath(ledgerconfig.GetStateBulkLevelDBPath())
+}
+
+// NewVersionedDBProviderWithPath instantiates VersionedDBProvider for the LevelDBs under dbPath
+func NewVersionedDBProviderWithPath(dbPath string) *VersionedDBProvider {
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	return &VersionedDBProvider{dbPath: dbPath, databases: make(map[string]*versionedDB)}
+}
+
+// GetDBHandle gets the handle to a named database, opening its LevelDB on the first invocation
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+
+	vdb, ok := provider.databases[dbName]
+	if !ok {
+		db := leveldbhelper.CreateDB(&leveldbhelper.Conf{
+			DBPath:  filepath.Join(provider.dbPath, dbName),
+			Options: Options(),
+		})
+		db.Open()
+		vdb = newVersionedDB(db, dbName)
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Close closes the LevelDBs of all the databases
+func (provider *VersionedDBProvider) Close() {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	for dbName, vdb := range provider.databases {
+		vdb.db.Close()
+		delete(provider.databases, dbName)
+	}
+}
+
+// Options returns the goleveldb options used for opening the LevelDBs
+func Options() *opt.Options {
+	return &opt.Options{
+		// a large memtable absorbs the update batch of a block in a single flush and
+		// the level-0 triggers let the flushes pile up before writes are throttled
+		WriteBuffer:            64 * opt.MiB,
+		CompactionL0Trigger:    8,
+		WriteL0SlowdownTrigger: 16,
+		WriteL0PauseTrigger:    24,
+		// larger tables and blocks mean fewer files and fewer block reads per range scan
+		CompactionTableSize: 8 * opt.MiB,
+		CompactionTotalSize: 64 * opt.MiB,
+		BlockSize:           32 * opt.KiB,
+		BlockCacheCapacity:  64 * opt.MiB,
+		// bloom filters let the point lookups made during simulation skip the tables
+		// that do not hold the key
+		Filter: filter.NewBloomFilter(10),
+	}
+}
+
+// versionedDB implements VersionedDB interface
+type versionedDB struct {
+	db     *leveldbhelper.DB
+	dbName string
+	// versionCache holds the versions loaded by LoadCommittedVersions, keyed by namespace and key.
+	// A nil version denotes a key that is not present in the db
+	versionCache     map[string]map[string]*version.Height
+	versionCacheLock sync.RWMutex
+}
+
+func newVersionedDB(db *leveldbhelper.DB, dbName string) *versionedDB {
+	return &versionedDB{
+		db:           db,
+		dbName:       dbName,
+		versionCache: make(map[string]map[string]*version.Height),
+	}
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because the db is opened by the provider
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because the db is closed by the provider
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	dbVal, err := vdb.db.Get(constructCompositeKey(namespace, key))
+	if err != nil {
+		return nil, err
+	}
+	if dbVal == nil {
+		return nil, nil
+	}
+	return decodeValue(dbVal)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	if ver, ok := vdb.GetCachedVersion(namespace, key); ok {
+		return ver, nil
+	}
+	// the version of a key is not cached during simulation
+	dbVal, err := vdb.db.Get(constructCompositeKey(namespace, key))
+	if err != nil || dbVal == nil {
+		return nil, err
+	}
+	return decodeVersion(dbVal)
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// LoadCommittedVersions implements method in BulkOptimizable interface. The keys are looked up in
+// their order in the db, so that a single iterator visits the tables of the db at most once
+func (vdb *versionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) error {
+	dbKeys := make([][]byte, len(keys))
+	for i, compositeKey := range keys {
+		dbKeys[i] = constructCompositeKey(compositeKey.Namespace, compositeKey.Key)
+	}
+	sort.Slice(dbKeys, func(i, j int) bool { return bytes.Compare(dbKeys[i], dbKeys[j]) < 0 })
+
+	versionCache := make(map[string]map[string]*version.Height)
+	dbItr := vdb.db.GetIterator(nil, nil)
+	defer dbItr.Release()
+	for _, dbKey := range dbKeys {
+		ns, key := splitCompositeKey(dbKey)
+		var ver *version.Height
+		if dbItr.Seek(dbKey) && bytes.Equal(dbItr.Key(), dbKey) {
+			var err error
+			if ver, err = decodeVersion(dbItr.Value()); err != nil {
+				return err
+			}
+		}
+		if versionCache[ns] == nil {
+			versionCache[ns] = make(map[string]*version.Height)
+		}
+		versionCache[ns][key] = ver
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrap(err, "error while loading the committed versions")
+	}
+
+	vdb.versionCacheLock.Lock()
+	defer vdb.versionCacheLock.Unlock()
+	vdb.versionCache = versionCache
+	return nil
+}
+
+// GetCachedVersion implements method in BulkOptimizable interface
+func (vdb *versionedDB) GetCachedVersion(namespace, key string) (*version.Height, bool) {
+	vdb.versionCacheLock.RLock()
+	defer vdb.versionCacheLock.RUnlock()
+	ver, ok := vdb.versionCache[namespace][key]
+	return ver, ok
+}
+
+// ClearCachedVersions implements method in BulkOptimizable interface
+func (vdb *versionedDB) ClearCachedVersions() {
+	vdb.versionCacheLock.Lock()
+	defer vdb.versionCacheLock.Unlock()
+	vdb.versionCache = make(map[string]map[string]*version.Height)
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+const optionLimit = "limit"
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit := int32(0)
+	if metadata != nil {
+		if err := statedb.ValidateRangeMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+	}
+
+	compositeStartKey := constructCompositeKey(namespace, startKey)
+	compositeEndKey := constructCompositeKey(namespace, endKey)
+	if endKey == "" {
+		compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	}
+	dbItr := vdb.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &kvScanner{namespace: namespace, dbItr: dbItr, requestedLimit: requestedLimit}, nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return nil, errors.New("ExecuteQuery not supported for BulkLevelDB")
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	return nil, errors.New("ExecuteQueryWithMetadata not supported for BulkLevelDB")
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	dbBatch := &leveldb.Batch{}
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for k, vv := range batch.GetUpdates(ns) {
+			compositeKey := constructCompositeKey(ns, k)
+			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
+			if vv.Value == nil {
+				dbBatch.Delete(compositeKey)
+				continue
+			}
+			dbBatch.Put(compositeKey, encodeValue(vv))
+		}
+	}
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks,
+	// for which the pvtstore maintains the savepoint
+	if height != nil {
+		dbBatch.Put(savePointKey, height.ToBytes())
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	versionBytes, err := vdb.db.Get(savePointKey)
+	if err != nil {
+		return nil, err
+	}
+	if versionBytes == nil {
+		return nil, nil
+	}
+	height, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
+	return height, nil
+}
+
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, error) {
+	dataKeysEnd := []byte{dataKeyPrefix[0] + 1}
+	return &fullDBScanner{vdb.db.GetIterator(dataKeyPrefix, dataKeysEnd), skipNamespace}, nil
+}
+
+func constructCompositeKey(ns string, key string) []byte {
+	compositeKey := make([]byte, 0, len(dataKeyPrefix)+len(ns)+len(compositeKeySep)+len(key))
+	compositeKey = append(compositeKey, dataKeyPrefix...)
+	compositeKey = append(compositeKey, ns...)
+	compositeKey = append(compositeKey, compositeKeySep...)
+	return append(compositeKey, key...)
+}
+
+func splitCompositeKey(compositeKey []byte) (string, string) {
+	split := bytes.SplitN(compositeKey[len(dataKeyPrefix):], compositeKeySep, 2)
+	return string(split[0]), string(split[1])
+}
+
+type kvScanner struct {
+	namespace            string
+	dbItr                iterator.Iterator
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	if !scanner.dbItr.Next() {
+		return nil, errors.Wrap(scanner.dbItr.Error(), "error while scanning the range")
+	}
+	_, key := splitCompositeKey(scanner.dbItr.Key())
+	vv, err := decodeValue(scanner.dbItr.Value())
+	if err != nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+		VersionedValue: *vv}, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if scanner.dbItr.Next() {
+		_, retval = splitCompositeKey(scanner.dbItr.Key())
+	}
+	scanner.Close()
+	return retval
+}
+
+// fullDBScanner iterates over all the keys of a versionedDB
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (*statedb.VersionedKV, error) {
+	for s.dbItr.Next() {
+		ns, key := splitCompositeKey(s.dbItr.Key())
+		if s.skipNamespace(ns) {
+			continue
+		}
+		vv, err := decodeValue(s.dbItr.Value())
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "error while iterating over the state database")
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebulkleveldb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/statebulkleveldb")
+	os.Exit(m.Run())
+}
+
+func newTestProvider(t *testing.T) (*VersionedDBProvider, func()) {
+	require.NoError(t, os.RemoveAll(ledgerconfig.GetStateBulkLevelDBPath()))
+	dbProvider := NewVersionedDBProvider()
+	return dbProvider, func() {
+		dbProvider.Close()
+		os.RemoveAll(ledgerconfig.GetStateBulkLevelDBPath())
+	}
+}
+
+func TestConformance(t *testing.T) {
+	var cleanup func()
+	commontests.RunConformanceSuite(t,
+		func(t *testing.T) statedb.VersionedDBProvider {
+			var dbProvider *VersionedDBProvider
+			dbProvider, cleanup = newTestProvider(t)
+			return dbProvider
+		},
+		func() { cleanup() },
+	)
+}
+
+func TestRegistration(t *testing.T) {
+	require.NoError(t, os.RemoveAll(ledgerconfig.GetStateBulkLevelDBPath()))
+	defer os.RemoveAll(ledgerconfig.GetStateBulkLevelDBPath())
+	factory, ok := statedb.GetVersionedDBProviderFactory(StateDatabaseName)
+	require.True(t, ok)
+	dbProvider, err := factory(&disabled.Provider{})
+	require.NoError(t, err)
+	dbProvider.Close()
+	assert.Equal(t, ledgerconfig.GetStateBulkLevelDBPath(), statedb.DataPaths()[StateDatabaseName])
+}
+
+func TestDBPerChannel(t *testing.T) {
+	dbProvider, cleanup := newTestProvider(t)
+	defer cleanup()
+
+	for _, dbName := range []string{"ch1", "ch2"} {
+		db, err := dbProvider.GetDBHandle(dbName)
+		require.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns", "key", []byte("value-"+dbName), version.NewHeight(1, 1))
+		require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+		_, err = os.Stat(filepath.Join(ledgerconfig.GetStateBulkLevelDBPath(), dbName))
+		assert.NoError(t, err)
+	}
+
+	// the dbs are reopened from their own directories
+	dbProvider.Close()
+	dbProvider = NewVersionedDBProvider()
+	defer dbProvider.Close()
+	for _, dbName := range []string{"ch1", "ch2"} {
+		db, err := dbProvider.GetDBHandle(dbName)
+		require.NoError(t, err)
+		vv, err := db.GetState("ns", "key")
+		require.NoError(t, err)
+		assert.Equal(t, &statedb.VersionedValue{Value: []byte("value-" + dbName), Version: version.NewHeight(1, 1)}, vv)
+		savepoint, err := db.GetLatestSavePoint()
+		require.NoError(t, err)
+		assert.Equal(t, version.NewHeight(1, 1), savepoint)
+	}
+}
+
+func TestLoadCommittedVersions(t *testing.T) {
+	dbProvider, cleanup := newTestProvider(t)
+	defer cleanup()
+	db, err := dbProvider.GetDBHandle("testloadcommittedversions")
+	require.NoError(t, err)
+	bulkDB := db.(statedb.BulkOptimizable)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+
+	require.NoError(t, bulkDB.LoadCommittedVersions([]*statedb.CompositeKey{
+		{Namespace: "ns2", Key: "key1"},
+		{Namespace: "ns1", Key: "key3"},
+		{Namespace: "ns1", Key: "key1"},
+	}))
+	ver, ok := bulkDB.GetCachedVersion("ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+	ver, ok = bulkDB.GetCachedVersion("ns2", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, version.NewHeight(1, 3), ver)
+	// a loaded key that is not present in the db is cached with a nil version
+	ver, ok = bulkDB.GetCachedVersion("ns1", "key3")
+	assert.True(t, ok)
+	assert.Nil(t, ver)
+	_, ok = bulkDB.GetCachedVersion("ns1", "key2")
+	assert.False(t, ok)
+
+	// the cached version is returned in place of the committed one until the cache is cleared
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value4"), version.NewHeight(2, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	ver, err = db.GetVersion("ns1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+	bulkDB.ClearCachedVersions()
+	ver, err = db.GetVersion("ns1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 1), ver)
+}
+
+func TestFullScan(t *testing.T) {
+	dbProvider, cleanup := newTestProvider(t)
+	defer cleanup()
+	db, err := dbProvider.GetDBHandle("testfullscan")
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns2", "key1", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns3", "key1", []byte("value3"), version.NewHeight(1, 3))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(func(ns string) bool { return ns == "ns3" })
+	require.NoError(t, err)
+	defer itr.Close()
+	var kvs []*statedb.VersionedKV
+	for {
+		kv, err := itr.Next()
+		require.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		kvs = append(kvs, kv)
+	}
+	assert.Equal(t, []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+		},
+	}, kvs)
+}
+
+func TestExecuteQueryNotSupported(t *testing.T) {
+	dbProvider, cleanup := newTestProvider(t)
+	defer cleanup()
+	db, err := dbProvider.GetDBHandle("testquery")
+	require.NoError(t, err)
+
+	_, err = db.ExecuteQuery("ns", `{"selector":{"owner":"jerry"}}`)
+	assert.EqualError(t, err, "ExecuteQuery not supported for BulkLevelDB")
+	_, err = db.ExecuteQueryWithMetadata("ns", `{"selector":{"owner":"jerry"}}`, nil)
+	assert.EqualError(t, err, "ExecuteQueryWithMetadata not supported for BulkLevelDB")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebulkleveldb
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// encodeValue encodes the version, followed by the length-prefixed metadata and the value. As the version
+// comes first, LoadCommittedVersions decodes it without decoding the rest of the entry
+func encodeValue(v *statedb.VersionedValue) []byte {
+	encodedVersion := v.Version.ToBytes()
+	encodedValue := make([]byte, len(encodedVersion), len(encodedVersion)+binary.MaxVarintLen64+len(v.Metadata)+len(v.Value))
+	copy(encodedValue, encodedVersion)
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	encodedValue = append(encodedValue, lenBuf[:binary.PutUvarint(lenBuf, uint64(len(v.Metadata)))]...)
+	encodedValue = append(encodedValue, v.Metadata...)
+	return append(encodedValue, v.Value...)
+}
+
+// decodeValue decodes an entry encoded by encodeValue. The returned value does not share the memory of
+// encodedValue, which may be reused by the iterator it was read from
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	ver, n, err := version.NewHeightFromBytes(encodedValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the version of the value")
+	}
+	rest := encodedValue[n:]
+	metadataLen, n := binary.Uvarint(rest)
+	if n <= 0 || uint64(len(rest)-n) < metadataLen {
+		return nil, errors.New("error decoding the metadata of the value")
+	}
+	rest = rest[n:]
+	var metadata []byte
+	if metadataLen > 0 {
+		metadata = append([]byte{}, rest[:metadataLen]...)
+	}
+	value := append([]byte{}, rest[metadataLen:]...)
+	return &statedb.VersionedValue{Value: value, Metadata: metadata, Version: ver}, nil
+}
+
+// decodeVersion decodes the version of an entry encoded by encodeValue
+func decodeVersion(encodedValue []byte) (*version.Height, error) {
+	ver, _, err := version.NewHeightFromBytes(encodedValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the version of the value")
+	}
+	return ver, nil
+}
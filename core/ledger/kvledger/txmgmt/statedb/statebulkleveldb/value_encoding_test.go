@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebulkleveldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+func TestEncodeDecodeValue(t *testing.T) {
+	testdata := []*statedb.VersionedValue{
+		{Value: []byte("value0"), Version: version.NewHeight(0, 0)},
+		{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 2)},
+		{Value: []byte{}, Metadata: []byte("metadata2"), Version: version.NewHeight(1000, 2000)},
+		{Value: []byte{}, Version: version.NewHeight(3, 4)},
+	}
+	for _, vv := range testdata {
+		encodedValue := encodeValue(vv)
+		decodedValue, err := decodeValue(encodedValue)
+		require.NoError(t, err)
+		assert.Equal(t, vv, decodedValue)
+		decodedVersion, err := decodeVersion(encodedValue)
+		require.NoError(t, err)
+		assert.Equal(t, vv.Version, decodedVersion)
+	}
+}
+
+func TestDecodeValueErrors(t *testing.T) {
+	encodedValue := encodeValue(&statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 2)})
+	encodedVersionLen := len(version.NewHeight(1, 2).ToBytes())
+
+	_, err := decodeValue(encodedValue[:encodedVersionLen])
+	assert.EqualError(t, err, "error decoding the metadata of the value")
+	_, err = decodeValue(encodedValue[:encodedVersionLen+3])
+	assert.EqualError(t, err, "error decoding the metadata of the value")
+	_, err = decodeVersion([]byte{0xff})
+	assert.Contains(t, err.Error(), "error decoding the version of the value")
+}
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"github.com/tradeline-tech/fabric/common/metrics"
+)
+
+var (
+	stateCacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "couchdb",
+		Subsystem:    "",
+		Name:         "state_cache_hits",
+		Help:         "The number of state reads served from the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+
+	stateCacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "couchdb",
+		Subsystem:    "",
+		Name:         "state_cache_misses",
+		Help:         "The number of state reads that could not be served from the state cache.",
+		LabelNames:   []string{"channel", "namespace"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+	}
+)
+
+type stats struct {
+	stateCacheHits   metrics.Counter
+	stateCacheMisses metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		stateCacheHits:   metricsProvider.NewCounter(stateCacheHitsOpts),
+		stateCacheMisses: metricsProvider.NewCounter(stateCacheMissesOpts),
+	}
+}
+
+func (s *stats) updateStateCacheStats(channel, namespace string, hit bool) {
+	if hit {
+		s.stateCacheHits.With("channel", channel, "namespace", namespace).Add(1)
+		return
+	}
+	s.stateCacheMisses.With("channel", channel, "namespace", namespace).Add(1)
+}
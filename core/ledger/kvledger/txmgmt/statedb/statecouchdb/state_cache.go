@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"sync"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// stateCache is a bounded in-memory cache of the committed state of a channel, maintained per namespace.
+// A key that is known to be absent from the state is cached with a nil value so that the repeated
+// reads of a non-existing key are served from the cache as well.
+// The cache is write-through, i.e., it is updated with the writes of every batch applied to CouchDB.
+// As a value read from CouchDB may race with the commit of a batch that updates the same key, such a
+// value is cached only if no batch has been applied in the meantime (tracked via `updateSeq`)
+type stateCache struct {
+	maxEntriesPerNs int
+	namespaces      map[string]map[string]*statedb.VersionedValue
+	updateSeq       uint64
+	rwMutex         sync.RWMutex
+}
+
+func newStateCache(maxEntriesPerNs int) *stateCache {
+	return &stateCache{
+		maxEntriesPerNs: maxEntriesPerNs,
+		namespaces:      make(map[string]map[string]*statedb.VersionedValue),
+	}
+}
+
+func (c *stateCache) isEnabled() bool {
+	return c.maxEntriesPerNs > 0
+}
+
+// getState returns the cached value of the key and whether the key is present in the cache.
+// A nil value along with a true flag indicates that the key does not exist in the state.
+// In addition, the current update sequence is returned which is expected to be passed to
+// `setState` if the value is to be loaded from CouchDB
+func (c *stateCache) getState(namespace, key string) (*statedb.VersionedValue, bool, uint64) {
+	c.rwMutex.RLock()
+	defer c.rwMutex.RUnlock()
+
+	versionedValue, ok := c.namespaces[namespace][key]
+	return versionedValue, ok, c.updateSeq
+}
+
+// setState caches the value of the key loaded from CouchDB, evicting a random entry of the namespace if the
+// namespace is full. The value is not cached if a batch has been applied since the given update sequence
+func (c *stateCache) setState(updateSeq uint64, namespace, key string, value *statedb.VersionedValue) {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+
+	if updateSeq != c.updateSeq {
+		return
+	}
+	c.setStateWithoutLock(namespace, key, value)
+}
+
+// invalidate removes the keys present in the batch from the cache. This is invoked before the batch is
+// applied to CouchDB so that a partially applied batch does not leave stale values in the cache
+func (c *stateCache) invalidate(updates *statedb.UpdateBatch) {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+
+	c.updateSeq++
+	for _, namespace := range updates.GetUpdatedNamespaces() {
+		nsCache, ok := c.namespaces[namespace]
+		if !ok {
+			continue
+		}
+		for key := range updates.GetUpdates(namespace) {
+			delete(nsCache, key)
+		}
+	}
+}
+
+// update caches the values (and the deletes as nil values) present in the batch. This is invoked after
+// the batch is successfully applied to CouchDB
+func (c *stateCache) update(updates *statedb.UpdateBatch) {
+	c.rwMutex.Lock()
+	defer c.rwMutex.Unlock()
+
+	c.updateSeq++
+	for _, namespace := range updates.GetUpdatedNamespaces() {
+		for key, versionedValue := range updates.GetUpdates(namespace) {
+			if versionedValue.Value == nil {
+				versionedValue = nil
+			}
+			c.setStateWithoutLock(namespace, key, versionedValue)
+		}
+	}
+}
+
+func (c *stateCache) setStateWithoutLock(namespace, key string, value *statedb.VersionedValue) {
+	nsCache, ok := c.namespaces[namespace]
+	if !ok {
+		nsCache = make(map[string]*statedb.VersionedValue)
+		c.namespaces[namespace] = nsCache
+	}
+	if _, ok := nsCache[key]; !ok && len(nsCache) >= c.maxEntriesPerNs {
+		evictARandomEntry(nsCache)
+	}
+	nsCache[key] = value
+}
+
+func evictARandomEntry(nsCache map[string]*statedb.VersionedValue) {
+	for key := range nsCache {
+		delete(nsCache, key)
+		return
+	}
+}
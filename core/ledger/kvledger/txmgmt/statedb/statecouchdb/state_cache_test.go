@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+func TestStateCache(t *testing.T) {
+	assert.False(t, newStateCache(0).isEnabled())
+
+	cache := newStateCache(3)
+	assert.True(t, cache.isEnabled())
+	value1 := &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+
+	_, found, seq := cache.getState("ns1", "key1")
+	assert.False(t, found)
+	cache.setState(seq, "ns1", "key1", value1)
+	cache.setState(seq, "ns1", "key2", nil)
+
+	value, found, _ := cache.getState("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, value1, value)
+	// a key known to be absent is cached as nil
+	value, found, _ = cache.getState("ns1", "key2")
+	assert.True(t, found)
+	assert.Nil(t, value)
+	_, found, _ = cache.getState("ns2", "key1")
+	assert.False(t, found)
+
+	// write-through: puts and deletes of a batch are reflected in the cache
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("new-value1"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key3", version.NewHeight(2, 2))
+	batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(2, 3))
+	cache.invalidate(batch)
+	_, found, _ = cache.getState("ns1", "key1")
+	assert.False(t, found)
+	cache.update(batch)
+
+	value, found, _ = cache.getState("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("new-value1"), Version: version.NewHeight(2, 1)}, value)
+	value, found, _ = cache.getState("ns1", "key3")
+	assert.True(t, found)
+	assert.Nil(t, value)
+	value, found, _ = cache.getState("ns2", "key1")
+	assert.True(t, found)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(2, 3)}, value)
+
+	// a value loaded before a batch got applied is not cached
+	cache.setState(seq, "ns2", "key2", value1)
+	_, found, _ = cache.getState("ns2", "key2")
+	assert.False(t, found)
+}
+
+func TestStateCacheEviction(t *testing.T) {
+	cache := newStateCache(10)
+	_, _, seq := cache.getState("ns1", "key")
+	for i := 0; i < 20; i++ {
+		cache.setState(seq, "ns1", "key"+strconv.Itoa(i), nil)
+		cache.setState(seq, "ns2", "key"+strconv.Itoa(i), nil)
+	}
+	assert.Len(t, cache.namespaces["ns1"], 10)
+	assert.Len(t, cache.namespaces["ns2"], 10)
+
+	// updating a cached key does not evict any entry
+	for key := range cache.namespaces["ns1"] {
+		cache.setState(seq, "ns1", key, &statedb.VersionedValue{Value: []byte("value")})
+	}
+	assert.Len(t, cache.namespaces["ns1"], 10)
+}
@@ -29,6 +29,19 @@ var logger = flogging.MustGetLogger("statecouchdb")
 // currently defaulted to 0 and is not used
 const querySkip = 0
 
+// StateDatabaseName is the value of `ledger.state.stateDatabase` that selects this state database
+const StateDatabaseName = "CouchDB"
+
+func init() {
+	statedb.RegisterVersionedDBProviderFactory(StateDatabaseName, func(metricsProvider metrics.Provider) (statedb.VersionedDBProvider, error) {
+		vdbProvider, err := NewVersionedDBProvider(metricsProvider)
+		if err != nil {
+			return nil, err
+		}
+		return vdbProvider, nil
+	})
+}
+
 // LsccCacheSize denotes the number of entries allowed in the lsccStateCache
 const lsccCacheSize = 50
 
@@ -38,6 +51,7 @@ type VersionedDBProvider struct {
 	databases     map[string]*VersionedDB
 	mux           sync.Mutex
 	openCounts    uint64
+	stats         *stats
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -49,7 +63,7 @@ func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvi
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0, newStats(metricsProvider)}, nil
 }
 
 // GetDBHandle gets the handle to a named database
@@ -59,7 +73,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	vdb := provider.databases[dbName]
 	if vdb == nil {
 		var err error
-		vdb, err = newVersionedDB(provider.couchInstance, dbName)
+		vdb, err = newVersionedDB(provider.couchInstance, dbName, provider.stats)
 		if err != nil {
 			return nil, err
 		}
@@ -88,6 +102,8 @@ type VersionedDB struct {
 	verCacheLock       sync.RWMutex
 	mux                sync.RWMutex
 	lsccStateCache     *lsccStateCache
+	stateCache         *stateCache // Used as a write-through cache of the committed state
+	stats              *stats
 }
 
 type lsccStateCache struct {
@@ -140,7 +156,7 @@ func (l *lsccStateCache) evictARandomEntry() {
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string, stats *stats) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -159,6 +175,8 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 		lsccStateCache: &lsccStateCache{
 			cache: make(map[string]*statedb.VersionedValue),
 		},
+		stateCache: newStateCache(ledgerconfig.GetStateCacheSize()),
+		stats:      stats,
 	}, nil
 }
 
@@ -290,6 +308,17 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 			return value, nil
 		}
 	}
+	useStateCache := vdb.stateCache.isEnabled()
+	var stateCacheSeq uint64
+	if useStateCache {
+		var value *statedb.VersionedValue
+		var ok bool
+		value, ok, stateCacheSeq = vdb.stateCache.getState(namespace, key)
+		vdb.stats.updateStateCacheStats(vdb.chainName, namespace, ok)
+		if ok {
+			return value, nil
+		}
+	}
 
 	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
@@ -300,6 +329,9 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 		return nil, err
 	}
 	if couchDoc == nil {
+		if useStateCache {
+			vdb.stateCache.setState(stateCacheSeq, namespace, key, nil)
+		}
 		return nil, nil
 	}
 	kv, err := couchDocToKeyValue(couchDoc)
@@ -310,6 +342,9 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 	if namespace == "lscc" {
 		vdb.lsccStateCache.setState(key, kv.VersionedValue)
 	}
+	if useStateCache {
+		vdb.stateCache.setState(stateCacheSeq, namespace, key, kv.VersionedValue)
+	}
 
 	return kv.VersionedValue, nil
 }
@@ -530,6 +565,9 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 		return err
 	}
 	// stage 2 - ApplyUpdates push the changes to the DB
+	if vdb.stateCache.isEnabled() {
+		vdb.stateCache.invalidate(updates)
+	}
 	if err = executeBatches(updateBatches); err != nil {
 		return err
 	}
@@ -546,6 +584,9 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	for key, value := range lsccUpdates {
 		vdb.lsccStateCache.updateState(key, value)
 	}
+	if vdb.stateCache.isEnabled() {
+		vdb.stateCache.update(updates)
+	}
 
 	return nil
 }
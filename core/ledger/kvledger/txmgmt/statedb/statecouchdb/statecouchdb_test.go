@@ -22,6 +22,7 @@ import (
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/tradeline-tech/fabric/core/ledger/testutil"
 	"github.com/tradeline-tech/fabric/core/ledger/util/couchdb"
 	"github.com/tradeline-tech/fabric/integration/runner"
@@ -761,6 +762,59 @@ func TestLSCCStateCache(t *testing.T) {
 	assert.Equal(t, true, db.(*VersionedDB).lsccStateCache.isCacheFull())
 }
 
+func TestStateCacheWithCouchDB(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+
+	db, err := env.DBProvider.GetDBHandle("teststatecache")
+	assert.NoError(t, err)
+	db.Open()
+	defer db.Close()
+	vdb := db.(*VersionedDB)
+	assert.True(t, vdb.stateCache.isEnabled())
+
+	// ApplyUpdates populates the cache with the values and the deletes
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	valueFromCache, found, _ := vdb.stateCache.getState("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, valueFromCache)
+	valueFromDB, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, valueFromCache, valueFromDB)
+
+	batch = statedb.NewUpdateBatch()
+	batch.Delete("ns1", "key2", version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	valueFromCache, found, _ = vdb.stateCache.getState("ns1", "key2")
+	assert.True(t, found)
+	assert.Nil(t, valueFromCache)
+
+	// GetState populates the cache for the keys missing in the cache, including the non-existing keys
+	vdb.stateCache = newStateCache(ledgerconfig.GetStateCacheSize())
+	valueFromDB, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	valueFromCache, found, _ = vdb.stateCache.getState("ns1", "key1")
+	assert.True(t, found)
+	assert.Equal(t, valueFromDB, valueFromCache)
+
+	valueFromDB, err = db.GetState("ns1", "non-existing-key")
+	assert.NoError(t, err)
+	assert.Nil(t, valueFromDB)
+	_, found, _ = vdb.stateCache.getState("ns1", "non-existing-key")
+	assert.True(t, found)
+
+	vals, err := db.GetStateMultipleKeys("ns1", []string{"key1", "key2"})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]*statedb.VersionedValue{{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, nil},
+		vals,
+	)
+}
+
 func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
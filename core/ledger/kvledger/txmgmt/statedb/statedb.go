@@ -88,6 +88,21 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScannable interface provides additional functions for
+//databases capable of iterating over the entries of all the namespaces
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the entries of the db, ordered by namespace and key.
+	// The entries of the namespaces for which skipNamespace returns true are not returned
+	GetFullScanIterator(skipNamespace func(string) bool) (FullScanIterator, error)
+}
+
+// FullScanIterator iterates over all the entries of a db
+type FullScanIterator interface {
+	// Next returns the next entry, or nil when all the entries have been returned
+	Next() (*VersionedKV, error)
+	Close()
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -0,0 +1,534 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// This file implements the subset of the CouchDB Mango query syntax that is supported by the leveldb state db.
+// A query is a JSON object with a mandatory "selector" and the optional "sort", "fields", and "use_index" entries.
+// The selector supports the implicit equality on a field, the nested fields (either as nested objects or as
+// dotted field names), the condition operators $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $exists, and $regex,
+// and the combination operators $and, $or, $nor, and $not.
+// The values are compared as per the CouchDB collation order for the JSON types, i.e.,
+// null < false < true < numbers < strings < arrays < objects, except that the strings are compared bytewise
+
+const (
+	querySelector = "selector"
+	querySort     = "sort"
+	queryFields   = "fields"
+	queryUseIndex = "use_index"
+)
+
+// the entries that are meaningful for CouchDB but are ignored by the leveldb state db
+var ignoredQueryEntries = map[string]bool{
+	"limit":           true,
+	"skip":            true,
+	"bookmark":        true,
+	"execution_stats": true,
+	"r":               true,
+	"conflicts":       true,
+	"update":          true,
+	"stable":          true,
+	"stale":           true,
+}
+
+// parsedQuery is the in-memory form of a query
+type parsedQuery struct {
+	selector   matcher
+	sortFields []string
+	sortDesc   bool
+	fields     []string
+	useIndex   string
+}
+
+// matcher evaluates a (part of a) selector against a JSON document
+type matcher interface {
+	matches(doc map[string]interface{}) bool
+}
+
+type andMatcher []matcher
+
+type orMatcher []matcher
+
+type norMatcher []matcher
+
+type notMatcher struct {
+	matcher matcher
+}
+
+// fieldMatcher evaluates a condition operator against the value of a field
+type fieldMatcher struct {
+	field   string
+	path    []string
+	op      string
+	operand interface{}
+	regex   *regexp.Regexp
+}
+
+func parseQuery(query string) (*parsedQuery, error) {
+	jsonQuery, err := unmarshalJSON([]byte(query))
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid query, the query must be a JSON object")
+	}
+	jsonQueryMap, ok := jsonQuery.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid query, the query must be a JSON object")
+	}
+	parsed := &parsedQuery{}
+	for entry, value := range jsonQueryMap {
+		switch entry {
+		case querySelector:
+			selectorMap, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid query, \"selector\" must be a JSON object")
+			}
+			if parsed.selector, err = parseSelector(selectorMap, nil); err != nil {
+				return nil, err
+			}
+		case querySort:
+			if parsed.sortFields, parsed.sortDesc, err = parseSort(value); err != nil {
+				return nil, err
+			}
+		case queryFields:
+			if parsed.fields, err = parseFields(value); err != nil {
+				return nil, err
+			}
+		case queryUseIndex:
+			if parsed.useIndex, err = parseUseIndex(value); err != nil {
+				return nil, err
+			}
+		default:
+			if !ignoredQueryEntries[entry] {
+				return nil, errors.Errorf("invalid query, entry \"%s\" is not supported", entry)
+			}
+		}
+	}
+	if parsed.selector == nil {
+		return nil, errors.New("invalid query, \"selector\" is required")
+	}
+	return parsed, nil
+}
+
+// parseSelector parses a selector object. The path is the field path that the object applies to,
+// which is non-empty for the nested objects of a field
+func parseSelector(selector map[string]interface{}, path []string) (matcher, error) {
+	var matchers andMatcher
+	for _, name := range sortedKeys(selector) {
+		value := selector[name]
+		var m matcher
+		var err error
+		switch {
+		case name == "$and" || name == "$or" || name == "$nor":
+			m, err = parseCombination(name, value, path)
+		case name == "$not":
+			valueMap, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid selector, the argument of \"$not\" must be a JSON object")
+			}
+			var notMatch matcher
+			if notMatch, err = parseSelector(valueMap, path); err == nil {
+				m = &notMatcher{notMatch}
+			}
+		case strings.HasPrefix(name, "$"):
+			if len(path) == 0 {
+				return nil, errors.Errorf("invalid selector, operator \"%s\" must be applied to a field", name)
+			}
+			m, err = parseCondition(path, name, value)
+		default:
+			fieldPath := append(append([]string{}, path...), strings.Split(name, ".")...)
+			if valueMap, ok := value.(map[string]interface{}); ok {
+				m, err = parseSelector(valueMap, fieldPath)
+			} else {
+				m, err = parseCondition(fieldPath, "$eq", value)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	if len(matchers) == 1 {
+		return matchers[0], nil
+	}
+	return matchers, nil
+}
+
+func parseCombination(op string, value interface{}, path []string) (matcher, error) {
+	values, ok := value.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, errors.Errorf("invalid selector, the argument of \"%s\" must be a non-empty array", op)
+	}
+	var matchers []matcher
+	for _, v := range values {
+		valueMap, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid selector, the elements of \"%s\" must be JSON objects", op)
+		}
+		m, err := parseSelector(valueMap, path)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	switch op {
+	case "$and":
+		return andMatcher(matchers), nil
+	case "$or":
+		return orMatcher(matchers), nil
+	default:
+		return norMatcher(matchers), nil
+	}
+}
+
+func parseCondition(path []string, op string, operand interface{}) (matcher, error) {
+	m := &fieldMatcher{field: strings.Join(path, "."), path: path, op: op, operand: operand}
+	switch op {
+	case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+	case "$in", "$nin":
+		if _, ok := operand.([]interface{}); !ok {
+			return nil, errors.Errorf("invalid selector, the argument of \"%s\" must be an array", op)
+		}
+	case "$exists":
+		if _, ok := operand.(bool); !ok {
+			return nil, errors.New("invalid selector, the argument of \"$exists\" must be a boolean")
+		}
+	case "$regex":
+		pattern, ok := operand.(string)
+		if !ok {
+			return nil, errors.New("invalid selector, the argument of \"$regex\" must be a string")
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selector, bad regular expression [%s]", pattern)
+		}
+		m.regex = regex
+	default:
+		return nil, errors.Errorf("invalid selector, operator \"%s\" is not supported by leveldb", op)
+	}
+	return m, nil
+}
+
+// parseSort parses the sort entry which is an array of field names or of single-entry objects
+// mapping a field name to the direction "asc" or "desc". All the fields must use the same direction
+func parseSort(value interface{}) ([]string, bool, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, false, errors.New("invalid query, \"sort\" must be an array")
+	}
+	var fields []string
+	desc := false
+	for i, v := range values {
+		field, direction := "", "asc"
+		switch s := v.(type) {
+		case string:
+			field = s
+		case map[string]interface{}:
+			if len(s) != 1 {
+				return nil, false, errors.New("invalid query, each sort object must have a single field")
+			}
+			for f, d := range s {
+				field = f
+				if direction, ok = d.(string); !ok || (direction != "asc" && direction != "desc") {
+					return nil, false, errors.Errorf("invalid query, bad sort direction for field \"%s\"", f)
+				}
+			}
+		default:
+			return nil, false, errors.New("invalid query, sort entries must be strings or JSON objects")
+		}
+		if i > 0 && desc != (direction == "desc") {
+			return nil, false, errors.New("invalid query, sorts currently only support a single direction for all fields")
+		}
+		desc = direction == "desc"
+		fields = append(fields, field)
+	}
+	return fields, desc, nil
+}
+
+func parseFields(value interface{}) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid query, \"fields\" must be an array")
+	}
+	var fields []string
+	for _, v := range values {
+		field, ok := v.(string)
+		if !ok {
+			return nil, errors.New("invalid query, \"fields\" must be an array of strings")
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// parseUseIndex parses the use_index entry which is either "<ddoc>", or ["<ddoc>", "<index name>"].
+// Only the index name is relevant for leveldb
+func parseUseIndex(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return "", nil
+	case []interface{}:
+		if len(v) == 1 {
+			return "", nil
+		}
+		if len(v) == 2 {
+			if name, ok := v[1].(string); ok {
+				return name, nil
+			}
+		}
+	}
+	return "", errors.New("invalid query, \"use_index\" must be a string or an array of two strings")
+}
+
+func (m andMatcher) matches(doc map[string]interface{}) bool {
+	for _, sub := range m {
+		if !sub.matches(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m orMatcher) matches(doc map[string]interface{}) bool {
+	for _, sub := range m {
+		if sub.matches(doc) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m norMatcher) matches(doc map[string]interface{}) bool {
+	return !orMatcher(m).matches(doc)
+}
+
+func (m *notMatcher) matches(doc map[string]interface{}) bool {
+	return !m.matcher.matches(doc)
+}
+
+// matches evaluates the condition. As in CouchDB, a condition on a missing field is not satisfied,
+// except for {"$exists": false}
+func (m *fieldMatcher) matches(doc map[string]interface{}) bool {
+	value, found := lookupField(doc, m.path)
+	if m.op == "$exists" {
+		return found == m.operand.(bool)
+	}
+	if !found {
+		return false
+	}
+	switch m.op {
+	case "$eq":
+		return compareJSON(value, m.operand) == 0
+	case "$ne":
+		return compareJSON(value, m.operand) != 0
+	case "$gt":
+		return compareJSON(value, m.operand) > 0
+	case "$gte":
+		return compareJSON(value, m.operand) >= 0
+	case "$lt":
+		return compareJSON(value, m.operand) < 0
+	case "$lte":
+		return compareJSON(value, m.operand) <= 0
+	case "$in":
+		return containsAny(m.operand.([]interface{}), value)
+	case "$nin":
+		return !containsAny(m.operand.([]interface{}), value)
+	case "$regex":
+		s, ok := value.(string)
+		return ok && m.regex.MatchString(s)
+	}
+	return false
+}
+
+// containsAny returns true if the value (or, for an array value, any of its elements) is present in the candidates
+func containsAny(candidates []interface{}, value interface{}) bool {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, c := range candidates {
+		for _, v := range values {
+			if compareJSON(c, v) == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupField returns the value at the given path of the document. An element of a path that is an integer
+// indexes into an array
+func lookupField(doc map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, p := range path {
+		switch c := current.(type) {
+		case map[string]interface{}:
+			v, ok := c[p]
+			if !ok {
+				return nil, false
+			}
+			current = v
+		case []interface{}:
+			i, err := strconv.Atoi(p)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			current = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// typeRank returns the rank of the type of a JSON value as per the CouchDB collation order
+func typeRank(v interface{}) int {
+	switch val := v.(type) {
+	case nil:
+		return 1
+	case bool:
+		if val {
+			return 3
+		}
+		return 2
+	case json.Number:
+		return 4
+	case string:
+		return 5
+	case []interface{}:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// compareJSON compares two JSON values as per the CouchDB collation order. The strings are compared bytewise,
+// the arrays element by element, and the objects field by field in the order of the field names
+func compareJSON(a, b interface{}) int {
+	rankA, rankB := typeRank(a), typeRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	switch valA := a.(type) {
+	case json.Number:
+		fA, fB := numberToFloat(valA), numberToFloat(b.(json.Number))
+		switch {
+		case fA < fB:
+			return -1
+		case fA > fB:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(valA, b.(string))
+	case []interface{}:
+		valB := b.([]interface{})
+		for i := 0; i < len(valA) && i < len(valB); i++ {
+			if c := compareJSON(valA[i], valB[i]); c != 0 {
+				return c
+			}
+		}
+		return len(valA) - len(valB)
+	case map[string]interface{}:
+		valB := b.(map[string]interface{})
+		keysA, keysB := sortedKeys(valA), sortedKeys(valB)
+		for i := 0; i < len(keysA) && i < len(keysB); i++ {
+			if c := strings.Compare(keysA[i], keysB[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(valA[keysA[i]], valB[keysB[i]]); c != 0 {
+				return c
+			}
+		}
+		return len(keysA) - len(keysB)
+	}
+	return 0
+}
+
+func numberToFloat(n json.Number) float64 {
+	f, err := n.Float64()
+	if err != nil {
+		// json.Number holds a valid JSON number, so this can only be an out of range number
+		logger.Warningf("Number [%s] is out of the float64 range", n)
+	}
+	return f
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unmarshalJSON decodes a JSON value preserving the numbers as json.Number so that a projected value
+// retains the exact representation of the numbers
+func unmarshalJSON(jsonBytes []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+// unmarshalJSONObject decodes the value as a JSON object, returning nil if the value is not a JSON object
+func unmarshalJSONObject(value []byte) map[string]interface{} {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 || value[0] != '{' {
+		return nil
+	}
+	doc, err := unmarshalJSON(value)
+	if err != nil {
+		return nil
+	}
+	docMap, _ := doc.(map[string]interface{})
+	return docMap
+}
+
+// project returns the JSON encoding of the given fields of the document
+func project(doc map[string]interface{}, fields []string) ([]byte, error) {
+	projected := make(map[string]interface{})
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, found := lookupField(doc, path)
+		if !found {
+			continue
+		}
+		current := projected
+		for _, p := range path[:len(path)-1] {
+			next, ok := current[p].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				current[p] = next
+			}
+			current = next
+		}
+		current[path[len(path)-1]] = value
+	}
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(projected); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error while projecting the fields %s", fields))
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,555 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/core/common/ccprovider"
+)
+
+// The secondary indexes are built from the CouchDB index definitions packaged by the chaincodes
+// under META-INF/statedb/couchdb/indexes. The index definitions and the index entries are stored
+// in the same db as the state, under keys that start with 0x00 so that they never collide with
+// a state key (i.e., "<namespace>0x00<key>"):
+//
+//	index definition - 0x00 0x01 <namespace> 0x00 <index name>
+//	index entry      - 0x00 0x02 <namespace> 0x00 <index name> 0x00 <encoded field values> <key>
+//
+// A document is indexed only if it contains all the fields of the index, same as CouchDB.
+// A field value is encoded such that the bytewise order of the encoded values follows the
+// collation order used by `compareJSON` for the null, boolean, number, and string values.
+var indexDefPrefix = []byte{0x00, 0x01}
+var indexEntryPrefix = []byte{0x00, 0x02}
+
+// terminator of an encoded field value. A 0x00 byte within a value is escaped as 0x00 0xFF
+var indexValueTerminator = []byte{0x00, 0x01}
+
+const indexValueEscape = byte(0xff)
+
+// indexDefinition is the persisted form of an index
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	DDoc   string   `json:"ddoc,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// couchIndexDefinition is the format of the index definitions packaged by the chaincodes, for instance,
+// {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type couchIndexDefinition struct {
+	Index *struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	DDoc string `json:"ddoc"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetDBType returns the type of the statedb artifacts that are processed by this db, i.e., the
+// leveldb state db builds its indexes from the CouchDB index definitions
+func (vdb *versionedDB) GetDBType() string {
+	return "couchdb"
+}
+
+// ProcessIndexesForChaincodeDeploy creates indexes for a specified namespace
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		indexDef, err := parseIndexDefinition(fileEntry.FileContent)
+		if err == nil {
+			err = vdb.createIndex(namespace, indexDef)
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error creating index from file [%s] for namespace [%s]", filename, namespace))
+		}
+	}
+	return nil
+}
+
+func parseIndexDefinition(indexJSON []byte) (*indexDefinition, error) {
+	couchIndexDef := &couchIndexDefinition{}
+	if err := json.Unmarshal(indexJSON, couchIndexDef); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if couchIndexDef.Type != "" && couchIndexDef.Type != "json" {
+		return nil, errors.Errorf("index type [%s] is not supported by leveldb", couchIndexDef.Type)
+	}
+	if couchIndexDef.Index == nil || len(couchIndexDef.Index.Fields) == 0 {
+		return nil, errors.New("invalid index definition, \"fields\" are missing")
+	}
+	indexDef := &indexDefinition{Name: couchIndexDef.Name, DDoc: couchIndexDef.DDoc}
+	for _, f := range couchIndexDef.Index.Fields {
+		// a field is either a field name or a single-entry object mapping the field name to a sort direction.
+		// As the leveldb indexes can be scanned in both the directions, the sort direction is ignored
+		switch field := f.(type) {
+		case string:
+			indexDef.Fields = append(indexDef.Fields, field)
+		case map[string]interface{}:
+			if len(field) != 1 {
+				return nil, errors.New("invalid index definition, each field object must have a single field")
+			}
+			for name := range field {
+				indexDef.Fields = append(indexDef.Fields, name)
+			}
+		default:
+			return nil, errors.New("invalid index definition, fields must be strings or JSON objects")
+		}
+	}
+	if indexDef.Name == "" {
+		indexDef.Name = strings.Join(indexDef.Fields, "-")
+	}
+	if strings.IndexByte(indexDef.Name, 0x00) >= 0 {
+		return nil, errors.Errorf("invalid index name [%s]", indexDef.Name)
+	}
+	return indexDef, nil
+}
+
+// loadIndexes loads the definitions of the indexes present in the db
+func (vdb *versionedDB) loadIndexes() error {
+	itr := vdb.db.GetIterator(indexDefPrefix, successorKey(indexDefPrefix))
+	defer itr.Release()
+	for itr.Next() {
+		namespace, _ := splitCompositeKey(itr.Key()[len(indexDefPrefix):])
+		indexDef := &indexDefinition{}
+		if err := json.Unmarshal(itr.Value(), indexDef); err != nil {
+			return errors.Wrapf(err, "error while loading the index definitions of the channel [%s]", vdb.dbName)
+		}
+		vdb.addIndexDefinition(namespace, indexDef)
+	}
+	return errors.Wrapf(itr.Error(), "error while loading the index definitions of the channel [%s]", vdb.dbName)
+}
+
+func (vdb *versionedDB) addIndexDefinition(namespace string, indexDef *indexDefinition) {
+	nsIndexes, ok := vdb.indexes[namespace]
+	if !ok {
+		nsIndexes = make(map[string]*indexDefinition)
+		vdb.indexes[namespace] = nsIndexes
+	}
+	nsIndexes[indexDef.Name] = indexDef
+}
+
+// createIndex persists the index definition and builds the index entries for the existing data of the namespace.
+// An existing index with the same name but different fields is replaced. The namespace is scanned without holding
+// the indexesLock, so that the blocks keep getting committed while a large namespace is being indexed
+func (vdb *versionedDB) createIndex(namespace string, indexDef *indexDefinition) error {
+	vdb.indexCreationLock.Lock()
+	defer vdb.indexCreationLock.Unlock()
+
+	build := vdb.startIndexBuild(namespace, indexDef)
+	if build == nil {
+		logger.Debugf("Channel [%s]: index [%s] already exists for namespace [%s]", vdb.dbName, indexDef.Name, namespace)
+		return nil
+	}
+	err := build.scan()
+	return vdb.finishIndexBuild(build, err)
+}
+
+// indexBuild holds the index entries built from a snapshot of the namespace data, along with
+// the keys of the namespace that are updated after the snapshot was taken
+type indexBuild struct {
+	namespace   string
+	indexDef    *indexDefinition
+	existingDef *indexDefinition
+	dataItr     *leveldbhelper.Iterator
+	existingItr *leveldbhelper.Iterator
+	updatedKeys map[string]struct{}
+	entries     map[string][]byte
+	dbBatch     *leveldbhelper.UpdateBatch
+}
+
+// startIndexBuild takes a snapshot of the namespace data and starts tracking the keys that are updated afterwards.
+// It returns nil if an index with the same name and fields exists already
+func (vdb *versionedDB) startIndexBuild(namespace string, indexDef *indexDefinition) *indexBuild {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+
+	existingDef := vdb.indexes[namespace][indexDef.Name]
+	if existingDef != nil && equalFields(existingDef.Fields, indexDef.Fields) {
+		return nil
+	}
+	build := &indexBuild{
+		namespace:   namespace,
+		indexDef:    indexDef,
+		existingDef: existingDef,
+		updatedKeys: make(map[string]struct{}),
+		entries:     make(map[string][]byte),
+		dbBatch:     leveldbhelper.NewUpdateBatch(),
+	}
+	// a leveldb iterator reads the snapshot of the db taken when the iterator is created
+	compositeStartKey := constructCompositeKey(namespace, "")
+	compositeEndKey := constructCompositeKey(namespace, "")
+	compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	build.dataItr = vdb.db.GetIterator(compositeStartKey, compositeEndKey)
+	if existingDef != nil {
+		prefix := constructIndexEntryPrefix(namespace, existingDef.Name)
+		build.existingItr = vdb.db.GetIterator(prefix, successorKey(prefix))
+	}
+	vdb.updatedKeys[namespace] = build.updatedKeys
+	return build
+}
+
+// scan builds the index entries from the snapshot of the namespace data, and adds the deletion of the entries of
+// the replaced index, if any, to the batch
+func (b *indexBuild) scan() error {
+	defer b.dataItr.Release()
+	if b.existingItr != nil {
+		defer b.existingItr.Release()
+		for b.existingItr.Next() {
+			b.dbBatch.Delete(copyBytes(b.existingItr.Key()))
+		}
+		if err := b.existingItr.Error(); err != nil {
+			return errors.Wrap(err, "error while iterating over the index entries")
+		}
+	}
+	for b.dataItr.Next() {
+		_, key := splitCompositeKey(b.dataItr.Key())
+		vv, err := decodeValue(copyBytes(b.dataItr.Value()))
+		if err != nil {
+			return err
+		}
+		if indexEntryKey, ok := constructIndexEntryKey(b.namespace, b.indexDef, key, unmarshalJSONObject(vv.Value)); ok {
+			b.entries[key] = indexEntryKey
+		}
+	}
+	return errors.Wrap(b.dataItr.Error(), "error while iterating over the namespace data")
+}
+
+// finishIndexBuild rebuilds the index entries of the keys that were updated during the scan from their latest
+// values, and commits the index. The indexesLock is held meanwhile, so that no more keys get updated
+func (vdb *versionedDB) finishIndexBuild(b *indexBuild, scanErr error) error {
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
+
+	delete(vdb.updatedKeys, b.namespace)
+	if scanErr != nil {
+		return scanErr
+	}
+	for key := range b.updatedKeys {
+		delete(b.entries, key)
+		vv, err := vdb.GetState(b.namespace, key)
+		if err != nil {
+			return err
+		}
+		var doc map[string]interface{}
+		if vv != nil {
+			doc = unmarshalJSONObject(vv.Value)
+		}
+		// the entry maintained for the replaced index after the snapshot was taken
+		if b.existingDef != nil {
+			if indexEntryKey, ok := constructIndexEntryKey(b.namespace, b.existingDef, key, doc); ok {
+				b.dbBatch.Delete(indexEntryKey)
+			}
+		}
+		if indexEntryKey, ok := constructIndexEntryKey(b.namespace, b.indexDef, key, doc); ok {
+			b.entries[key] = indexEntryKey
+		}
+	}
+
+	indexDefBytes, err := json.Marshal(b.indexDef)
+	if err != nil {
+		return errors.Wrap(err, "error while marshalling the index definition")
+	}
+	b.dbBatch.Put(constructIndexDefKey(b.namespace, b.indexDef.Name), indexDefBytes)
+	// the entries are added after the deletions, so that an entry shared with the replaced index is retained
+	for _, indexEntryKey := range b.entries {
+		b.dbBatch.Put(indexEntryKey, []byte{})
+	}
+	if err := vdb.db.WriteBatch(b.dbBatch, true); err != nil {
+		return err
+	}
+	vdb.addIndexDefinition(b.namespace, b.indexDef)
+	logger.Infof("Channel [%s]: created index [%s] on fields %s for namespace [%s] with [%d] entries",
+		vdb.dbName, b.indexDef.Name, b.indexDef.Fields, b.namespace, len(b.entries))
+	return nil
+}
+
+// addIndexUpdates adds to the batch the changes to the index entries of the namespace caused by the update of the key.
+// The caller is expected to add the index changes of a key before adding the update of the key itself to the batch
+func (vdb *versionedDB) addIndexUpdates(namespace, key string, newValue []byte, dbBatch *leveldbhelper.UpdateBatch) error {
+	nsIndexes := vdb.indexes[namespace]
+	if len(nsIndexes) == 0 {
+		return nil
+	}
+	oldVV, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return err
+	}
+	var oldDoc, newDoc map[string]interface{}
+	if oldVV != nil {
+		oldDoc = unmarshalJSONObject(oldVV.Value)
+	}
+	if newValue != nil {
+		newDoc = unmarshalJSONObject(newValue)
+	}
+	if oldDoc == nil && newDoc == nil {
+		return nil
+	}
+	for _, indexDef := range nsIndexes {
+		// the deletion is added before the addition, so that an unchanged entry is retained
+		if indexEntryKey, ok := constructIndexEntryKey(namespace, indexDef, key, oldDoc); ok {
+			dbBatch.Delete(indexEntryKey)
+		}
+		if indexEntryKey, ok := constructIndexEntryKey(namespace, indexDef, key, newDoc); ok {
+			dbBatch.Put(indexEntryKey, []byte{})
+		}
+	}
+	return nil
+}
+
+func constructIndexDefKey(namespace, indexName string) []byte {
+	return append(append([]byte{}, indexDefPrefix...), constructCompositeKey(namespace, indexName)...)
+}
+
+func constructIndexEntryPrefix(namespace, indexName string) []byte {
+	prefix := append(append([]byte{}, indexEntryPrefix...), constructCompositeKey(namespace, indexName)...)
+	return append(prefix, compositeKeySep...)
+}
+
+func constructIndexEntryKey(namespace string, indexDef *indexDefinition, key string, doc map[string]interface{}) ([]byte, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	indexEntryKey := constructIndexEntryPrefix(namespace, indexDef.Name)
+	for _, field := range indexDef.Fields {
+		value, found := lookupField(doc, strings.Split(field, "."))
+		if !found {
+			return nil, false
+		}
+		indexEntryKey = append(indexEntryKey, encodeIndexValue(value)...)
+	}
+	return append(indexEntryKey, key...), true
+}
+
+// keyFromIndexEntryKey extracts the state key from an index entry key by skipping the encoded values of the fields
+func keyFromIndexEntryKey(indexEntryKey []byte, prefixLen, numFields int) string {
+	remaining := indexEntryKey[prefixLen:]
+	for i := 0; i < numFields; i++ {
+		end := 0
+		for !bytes.HasPrefix(remaining[end:], indexValueTerminator) {
+			if remaining[end] == 0x00 {
+				end++
+			}
+			end++
+		}
+		remaining = remaining[end+len(indexValueTerminator):]
+	}
+	return string(remaining)
+}
+
+// encodeIndexValue encodes a field value as the type rank followed by an order-preserving encoding of the value.
+// The arrays and the objects are encoded as their JSON and hence their encoding only preserves the equality
+func encodeIndexValue(value interface{}) []byte {
+	encoded := []byte{byte(typeRank(value))}
+	switch v := value.(type) {
+	case json.Number:
+		f := numberToFloat(v)
+		if f == 0 {
+			// normalizes -0
+			f = 0
+		}
+		bits := math.Float64bits(f)
+		if bits&(1<<63) != 0 {
+			bits = ^bits
+		} else {
+			bits |= 1 << 63
+		}
+		numBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(numBytes, bits)
+		encoded = append(encoded, numBytes...)
+	case string:
+		encoded = append(encoded, v...)
+	case []interface{}, map[string]interface{}:
+		jsonBytes, _ := json.Marshal(v)
+		encoded = append(encoded, jsonBytes...)
+	}
+	escaped := make([]byte, 0, len(encoded)+len(indexValueTerminator))
+	for _, b := range encoded {
+		escaped = append(escaped, b)
+		if b == 0x00 {
+			escaped = append(escaped, indexValueEscape)
+		}
+	}
+	return append(escaped, indexValueTerminator...)
+}
+
+// queryPlan captures how a query is executed, either by scanning an index or by scanning all the keys of the namespace
+type queryPlan struct {
+	indexDef         *indexDefinition
+	indexPrefixLen   int
+	startKey, endKey []byte
+}
+
+// planQuery picks the index for a query. An index is usable only if the selector requires all the fields of the
+// index to be present (as the documents that lack an index field are not indexed), except for the fields that
+// the query sorts on. A query with a sort requires an index whose leading fields are the sort fields.
+// Without a sort, an index is used only if the selector bounds the leading field of the index
+func (vdb *versionedDB) planQuery(namespace string, query *parsedQuery) (*queryPlan, error) {
+	vdb.indexesLock.RLock()
+	defer vdb.indexesLock.RUnlock()
+
+	conditions := topLevelConditions(query.selector)
+	requiredFields := make(map[string]bool)
+	for _, c := range conditions {
+		if c.op != "$exists" || c.operand.(bool) {
+			requiredFields[c.field] = true
+		}
+	}
+	usable := func(indexDef *indexDefinition) bool {
+		if len(indexDef.Fields) < len(query.sortFields) {
+			return false
+		}
+		for i, field := range indexDef.Fields {
+			if i < len(query.sortFields) {
+				if field != query.sortFields[i] {
+					return false
+				}
+				continue
+			}
+			if !requiredFields[field] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var indexNames []string
+	for name := range vdb.indexes[namespace] {
+		indexNames = append(indexNames, name)
+	}
+	sort.Strings(indexNames)
+	var selected *indexDefinition
+	selectedScore := 0
+	for _, name := range indexNames {
+		indexDef := vdb.indexes[namespace][name]
+		if !usable(indexDef) {
+			if name == query.useIndex {
+				logger.Warningf("Channel [%s]: index [%s] cannot be used for the query, ignoring \"use_index\"", vdb.dbName, name)
+			}
+			continue
+		}
+		score := boundScore(indexDef.Fields[0], conditions)
+		if name == query.useIndex {
+			selected = indexDef
+			break
+		}
+		if selected == nil || score > selectedScore || (score == selectedScore && len(indexDef.Fields) > len(selected.Fields)) {
+			selected, selectedScore = indexDef, score
+		}
+	}
+
+	if selected == nil || (len(query.sortFields) == 0 && selectedScore == 0 && selected.Name != query.useIndex) {
+		if len(query.sortFields) > 0 {
+			return nil, errors.Errorf("no index exists for the sort %s in namespace [%s]", query.sortFields, namespace)
+		}
+		startKey := constructCompositeKey(namespace, "")
+		endKey := constructCompositeKey(namespace, "")
+		endKey[len(endKey)-1] = lastKeyIndicator
+		return &queryPlan{startKey: startKey, endKey: endKey}, nil
+	}
+
+	prefix := constructIndexEntryPrefix(namespace, selected.Name)
+	plan := &queryPlan{indexDef: selected, indexPrefixLen: len(prefix), startKey: prefix, endKey: successorKey(prefix)}
+	for _, c := range conditions {
+		if c.field != selected.Fields[0] || typeRank(c.operand) > typeRank("") {
+			continue
+		}
+		encoded := append(append([]byte{}, prefix...), encodeIndexValue(c.operand)...)
+		switch c.op {
+		case "$eq":
+			plan.narrow(encoded, successorKey(encoded))
+		case "$gt":
+			plan.narrow(successorKey(encoded), nil)
+		case "$gte":
+			plan.narrow(encoded, nil)
+		case "$lt":
+			plan.narrow(nil, encoded)
+		case "$lte":
+			plan.narrow(nil, successorKey(encoded))
+		}
+	}
+	logger.Debugf("Channel [%s]: using index [%s] for the query on namespace [%s]", vdb.dbName, selected.Name, namespace)
+	return plan, nil
+}
+
+// narrow restricts the key range of the plan to the given start and end keys, a nil key leaves that end unchanged
+func (p *queryPlan) narrow(startKey, endKey []byte) {
+	if startKey != nil && bytes.Compare(startKey, p.startKey) > 0 {
+		p.startKey = startKey
+	}
+	if endKey != nil && bytes.Compare(endKey, p.endKey) < 0 {
+		p.endKey = endKey
+	}
+	if bytes.Compare(p.startKey, p.endKey) > 0 {
+		p.endKey = p.startKey
+	}
+}
+
+// boundScore rates how selective the conditions are on the leading field of an index
+func boundScore(field string, conditions []*fieldMatcher) int {
+	score := 0
+	for _, c := range conditions {
+		if c.field != field || typeRank(c.operand) > typeRank("") {
+			continue
+		}
+		switch c.op {
+		case "$eq":
+			return 2
+		case "$gt", "$gte", "$lt", "$lte":
+			score = 1
+		}
+	}
+	return score
+}
+
+// topLevelConditions returns the conditions that every matching document has to satisfy
+func topLevelConditions(m matcher) []*fieldMatcher {
+	switch matcher := m.(type) {
+	case *fieldMatcher:
+		return []*fieldMatcher{matcher}
+	case andMatcher:
+		var conditions []*fieldMatcher
+		for _, sub := range matcher {
+			conditions = append(conditions, topLevelConditions(sub)...)
+		}
+		return conditions
+	}
+	return nil
+}
+
+// successorKey returns the smallest key that is larger than all the keys that start with the given key.
+// The last byte of the keys passed to this function is never 0xff
+func successorKey(key []byte) []byte {
+	successor := append([]byte{}, key...)
+	successor[len(successor)-1]++
+	return successor
+}
+
+func equalFields(fields1, fields2 []string) bool {
+	if len(fields1) != len(fields2) {
+		return false
+	}
+	for i := range fields1 {
+		if fields1[i] != fields2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	doc := unmarshalJSONObject([]byte(`{"owner":"tom","size":10,"color":null,"tags":["a","b"],"dims":{"h":5,"w":2.5}}`))
+	assert.NotNil(t, doc)
+
+	testCases := []struct {
+		selector string
+		matches  bool
+	}{
+		{`{"owner":"tom"}`, true},
+		{`{"owner":"jerry"}`, false},
+		{`{"owner":{"$ne":"jerry"}}`, true},
+		{`{"size":{"$gt":9,"$lte":10}}`, true},
+		{`{"size":{"$gt":10}}`, false},
+		{`{"size":{"$lt":"a"}}`, true},
+		{`{"size":10.0}`, true},
+		{`{"color":null}`, true},
+		{`{"color":{"$exists":true}}`, true},
+		{`{"missing":{"$exists":false}}`, true},
+		{`{"missing":{"$ne":"tom"}}`, false},
+		{`{"dims.h":5}`, true},
+		{`{"dims":{"w":{"$gte":2}}}`, true},
+		{`{"tags.1":"b"}`, true},
+		{`{"tags":["a","b"]}`, true},
+		{`{"tags":{"$in":["b","c"]}}`, true},
+		{`{"owner":{"$in":["jerry","tom"]}}`, true},
+		{`{"owner":{"$nin":["jerry","tom"]}}`, false},
+		{`{"owner":{"$regex":"^t"}}`, true},
+		{`{"$or":[{"owner":"jerry"},{"size":10}]}`, true},
+		{`{"$and":[{"owner":"tom"},{"size":9}]}`, false},
+		{`{"$nor":[{"owner":"jerry"},{"size":9}]}`, true},
+		{`{"$not":{"owner":"tom"}}`, false},
+		{`{"size":{"$not":{"$lt":5}}}`, true},
+	}
+	for _, testCase := range testCases {
+		query, err := parseQuery(`{"selector":` + testCase.selector + `}`)
+		assert.NoError(t, err, testCase.selector)
+		assert.Equal(t, testCase.matches, query.selector.matches(doc), testCase.selector)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	query, err := parseQuery(`{"selector":{"owner":"tom"},"sort":[{"size":"desc"},{"color":"desc"}],"fields":["owner"],"use_index":["_design/indexOwnerDoc","indexOwner"],"limit":5}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"size", "color"}, query.sortFields)
+	assert.True(t, query.sortDesc)
+	assert.Equal(t, []string{"owner"}, query.fields)
+	assert.Equal(t, "indexOwner", query.useIndex)
+
+	errorCases := map[string]string{
+		`this is not a json`:                                        "invalid query, the query must be a JSON object",
+		`["selector"]`:                                              "invalid query, the query must be a JSON object",
+		`{"fields":["owner"]}`:                                      "invalid query, \"selector\" is required",
+		`{"selector":{"owner":"tom"},"unknown":1}`:                  "invalid query, entry \"unknown\" is not supported",
+		`{"selector":{"owner":{"$elemMatch":{"$eq":"a"}}}}`:         "invalid selector, operator \"$elemMatch\" is not supported by leveldb",
+		`{"selector":{"$gt":5}}`:                                    "invalid selector, operator \"$gt\" must be applied to a field",
+		`{"selector":{"$or":{"owner":"tom"}}}`:                      "invalid selector, the argument of \"$or\" must be a non-empty array",
+		`{"selector":{"owner":{"$in":"tom"}}}`:                      "invalid selector, the argument of \"$in\" must be an array",
+		`{"selector":{"owner":"tom"},"sort":["size",{"a":"desc"}]}`: "invalid query, sorts currently only support a single direction for all fields",
+	}
+	for query, expectedErr := range errorCases {
+		_, err := parseQuery(query)
+		assert.Error(t, err, query)
+		assert.Contains(t, err.Error(), expectedErr, query)
+	}
+}
+
+func TestProject(t *testing.T) {
+	doc := unmarshalJSONObject([]byte(`{"owner":"<tom>","size":1000007,"dims":{"h":5,"w":2.5}}`))
+	projected, err := project(doc, []string{"owner", "size", "dims.w", "missing"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"dims":{"w":2.5},"owner":"<tom>","size":1000007}`, string(projected))
+	assert.Nil(t, unmarshalJSONObject([]byte(`["not","an","object"]`)))
+	assert.Nil(t, unmarshalJSONObject([]byte(`{"bad json"`)))
+}
+
+func TestIndexValueEncodingOrder(t *testing.T) {
+	// values in the ascending collation order
+	values := []string{`null`, `false`, `true`, `-1e10`, `-2.5`, `-0`, `1`, `1.5`, `1000007`, `""`, `"a"`, `"a\u0000"`, `"a\u0000b"`, `"ab"`, `"b"`}
+	for i := 1; i < len(values); i++ {
+		previous, err := unmarshalJSON([]byte(values[i-1]))
+		assert.NoError(t, err)
+		current, err := unmarshalJSON([]byte(values[i]))
+		assert.NoError(t, err)
+		assert.True(t, compareJSON(previous, current) < 0, "%s < %s", values[i-1], values[i])
+		assert.True(t, bytes.Compare(encodeIndexValue(previous), encodeIndexValue(current)) < 0, "%s < %s", values[i-1], values[i])
+	}
+	minusZero, _ := unmarshalJSON([]byte(`-0`))
+	zero, _ := unmarshalJSON([]byte(`0.0`))
+	assert.Equal(t, encodeIndexValue(zero), encodeIndexValue(minusZero))
+}
+
+func TestIndexEntryKey(t *testing.T) {
+	indexDef := &indexDefinition{Name: "indexOwner", Fields: []string{"owner", "dims.h"}}
+	doc := unmarshalJSONObject([]byte(`{"owner":"a\u0000b","dims":{"h":5}}`))
+	indexEntryKey, ok := constructIndexEntryKey("ns1", indexDef, "key\x00with\x00nils", doc)
+	assert.True(t, ok)
+	prefix := constructIndexEntryPrefix("ns1", "indexOwner")
+	assert.True(t, bytes.HasPrefix(indexEntryKey, prefix))
+	assert.Equal(t, "key\x00with\x00nils", keyFromIndexEntryKey(indexEntryKey, len(prefix), 2))
+
+	_, ok = constructIndexEntryKey("ns1", indexDef, "key1", unmarshalJSONObject([]byte(`{"owner":"tom"}`)))
+	assert.False(t, ok)
+	_, ok = constructIndexEntryKey("ns1", indexDef, "key1", nil)
+	assert.False(t, ok)
+}
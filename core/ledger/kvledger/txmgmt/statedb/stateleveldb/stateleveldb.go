@@ -7,11 +7,14 @@ package stateleveldb
 
 import (
 	"bytes"
+	"encoding/base64"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/tradeline-tech/fabric/common/flogging"
 	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/common/metrics"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
@@ -23,22 +26,49 @@ var compositeKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 var savePointKey = []byte{0x00}
 
+// StateDatabaseName is the value of `ledger.state.stateDatabase` that selects this state database
+const StateDatabaseName = "goleveldb"
+
+func init() {
+	statedb.RegisterVersionedDBProviderFactory(StateDatabaseName, func(metrics.Provider) (statedb.VersionedDBProvider, error) {
+		return NewVersionedDBProvider(), nil
+	})
+	statedb.RegisterDataPath(StateDatabaseName, ledgerconfig.GetStateLevelDBPath)
+}
+
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	databases  map[string]*versionedDB
+	mux        sync.Mutex
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
 func NewVersionedDBProvider() *VersionedDBProvider {
-	dbPath := ledgerconfig.GetStateLevelDBPath()
+	return NewVersionedDBProviderWithPath(ledgerconfig.GetStateLevelDBPath())
+}
+
+// NewVersionedDBProviderWithPath instantiates VersionedDBProvider for the LevelDB at dbPath
+func NewVersionedDBProviderWithPath(dbPath string) *VersionedDBProvider {
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: make(map[string]*versionedDB)}
 }
 
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+
+	vdb, ok := provider.databases[dbName]
+	if !ok {
+		var err error
+		if vdb, err = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName); err != nil {
+			return nil, err
+		}
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
 }
 
 // Close closes the underlying db
@@ -50,11 +80,26 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	// indexes maps a namespace to the definitions of its indexes by name
+	indexes map[string]map[string]*indexDefinition
+	// updatedKeys maps a namespace for which an index is being built to the keys updated since the build started
+	updatedKeys       map[string]map[string]struct{}
+	indexesLock       sync.RWMutex
+	indexCreationLock sync.Mutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) (*versionedDB, error) {
+	vdb := &versionedDB{
+		db:          db,
+		dbName:      dbName,
+		indexes:     make(map[string]map[string]*indexDefinition),
+		updatedKeys: make(map[string]map[string]struct{}),
+	}
+	if err := vdb.loadIndexes(); err != nil {
+		return nil, err
+	}
+	return vdb, nil
 }
 
 // Open implements method in VersionedDB interface
@@ -125,6 +170,7 @@ func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey str
 }
 
 const optionLimit = "limit"
+const optionBookmark = "bookmark"
 
 // GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
 func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
@@ -156,24 +202,96 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
 }
 
 // ExecuteQueryWithMetadata implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+	requestedLimit := int32(0)
+	bookmark := ""
+	// if metadata is provided, validate and apply options
+	if metadata != nil {
+		err := validateQueryMetadata(metadata)
+		if err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	parsedQuery, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := vdb.planQuery(namespace, parsedQuery)
+	if err != nil {
+		return nil, err
+	}
+	// resume the scan after the last key returned for the previous page
+	if bookmark != "" {
+		lastKey, err := base64.RawURLEncoding.DecodeString(bookmark)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bookmark [%s]", bookmark)
+		}
+		if parsedQuery.sortDesc {
+			plan.narrow(nil, lastKey)
+		} else {
+			plan.narrow(append(lastKey, 0x00), nil)
+		}
+	}
+	dbItr := vdb.db.GetIterator(plan.startKey, plan.endKey)
+	return newQueryScanner(vdb, namespace, parsedQuery, plan, dbItr, requestedLimit, bookmark), nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			//Verify the bookmark is a string
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			//Verify the limit is an integer
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return errors.New("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return errors.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
+	// the lock keeps the index definitions unchanged while the batch is being prepared and committed, and
+	// records the updated keys of a namespace that is being indexed, see createIndex
+	vdb.indexesLock.Lock()
+	defer vdb.indexesLock.Unlock()
 	for _, ns := range namespaces {
 		updates := batch.GetUpdates(ns)
+		updatedKeys := vdb.updatedKeys[ns]
 		for k, vv := range updates {
+			if updatedKeys != nil {
+				updatedKeys[k] = struct{}{}
+			}
 			compositeKey := constructCompositeKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
 
+			if err := vdb.addIndexUpdates(ns, k, vv.Value, dbBatch); err != nil {
+				return err
+			}
+
 			if vv.Value == nil {
 				dbBatch.Delete(compositeKey)
 			} else {
@@ -215,6 +333,11 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, error) {
+	return &fullDBScanner{vdb.db.GetIterator(nil, nil), skipNamespace}, nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -278,3 +401,134 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+// queryScanner iterates over the results of a query, either by scanning an index or all the keys of the namespace
+type queryScanner struct {
+	vdb                  *versionedDB
+	namespace            string
+	query                *parsedQuery
+	plan                 *queryPlan
+	dbItr                *leveldbhelper.Iterator
+	requestedLimit       int32
+	totalRecordsReturned int32
+	started              bool
+	lastKey              []byte
+	bookmark             string
+}
+
+func newQueryScanner(vdb *versionedDB, namespace string, query *parsedQuery, plan *queryPlan,
+	dbItr *leveldbhelper.Iterator, requestedLimit int32, bookmark string) *queryScanner {
+	return &queryScanner{
+		vdb:            vdb,
+		namespace:      namespace,
+		query:          query,
+		plan:           plan,
+		dbItr:          dbItr,
+		requestedLimit: requestedLimit,
+		bookmark:       bookmark,
+	}
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	for scanner.advance() {
+		dbKey := copyBytes(scanner.dbItr.Key())
+		key, vv, err := scanner.currentEntry(dbKey)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			continue
+		}
+		doc := unmarshalJSONObject(vv.Value)
+		if doc == nil || !scanner.query.selector.matches(doc) {
+			continue
+		}
+		if len(scanner.query.fields) > 0 {
+			if vv.Value, err = project(doc, scanner.query.fields); err != nil {
+				return nil, err
+			}
+		}
+		scanner.lastKey = dbKey
+		scanner.totalRecordsReturned++
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv}, nil
+	}
+	return nil, errors.Wrap(scanner.dbItr.Error(), "error while executing the query")
+}
+
+func (scanner *queryScanner) advance() bool {
+	if !scanner.query.sortDesc {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
+
+// currentEntry returns the key and the value for the current entry of the iterator. For an index scan, the value is
+// loaded from the state and a nil value is returned if the key got deleted after the iterator was created
+func (scanner *queryScanner) currentEntry(dbKey []byte) (string, *statedb.VersionedValue, error) {
+	if scanner.plan.indexDef == nil {
+		_, key := splitCompositeKey(dbKey)
+		vv, err := decodeValue(copyBytes(scanner.dbItr.Value()))
+		return key, vv, err
+	}
+	key := keyFromIndexEntryKey(dbKey, scanner.plan.indexPrefixLen, len(scanner.plan.indexDef.Fields))
+	vv, err := scanner.vdb.GetState(scanner.namespace, key)
+	return key, vv, err
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// GetBookmarkAndClose returns a bookmark that encodes the position of the last returned result. If no result
+// has been returned, the bookmark passed to the query is returned
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	if scanner.lastKey == nil {
+		return scanner.bookmark
+	}
+	return base64.RawURLEncoding.EncodeToString(scanner.lastKey)
+}
+
+// fullDBScanner iterates over all the entries of a versionedDB, skipping the savepoint and the indexes
+type fullDBScanner struct {
+	dbItr         *leveldbhelper.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (*statedb.VersionedKV, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if dbKey[0] == savePointKey[0] {
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skipNamespace(ns) {
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "error while iterating over the state database")
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
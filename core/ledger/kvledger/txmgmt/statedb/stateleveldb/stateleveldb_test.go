@@ -17,11 +17,14 @@ limitations under the License.
 package stateleveldb
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/core/common/ccprovider"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
@@ -69,27 +72,222 @@ func testCompositeKey(t *testing.T, dbName string, ns string, key string) {
 	assert.Equal(t, key, key1)
 }
 
-// TestQueryOnLevelDB tests queries on levelDB.
-func TestQueryOnLevelDB(t *testing.T) {
+func TestQuery(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
-	db, err := env.DBProvider.GetDBHandle("testquery")
+	commontests.TestQuery(t, env.DBProvider)
+}
+
+func TestQueryWithIndex(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquerywithindex")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":3,"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"asset_name":"marble2","color":"red","size":1,"owner":"fred"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"asset_name":"marble3","color":"blue","size":2,"owner":"fred"}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte(`{"asset_name":"marble4","color":"blue","owner":"fred"}`), version.NewHeight(1, 4))
+	batch.Put("ns1", "key5", []byte(`not a json value`), version.NewHeight(1, 5))
+	batch.Put("ns2", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":3,"owner":"fred"}`), version.NewHeight(1, 6))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 6)))
+
+	// a sort requires an index
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"fred"}, "sort": [{"size": "desc"}]}`)
+	assert.EqualError(t, err, "no index exists for the sort [size] in namespace [ns1]")
+
+	indexCapable, ok := db.(statedb.IndexCapable)
+	assert.True(t, ok)
+	assert.Equal(t, "couchdb", indexCapable.GetDBType())
+	dbArtifactsTarBytes := testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: "META-INF/statedb/couchdb/indexes/indexSizeSortName.json", Body: `{"index":{"fields":[{"size":"desc"}]},"ddoc":"indexSizeSortName","name":"indexSizeSortName","type":"json"}`},
+			{Name: "META-INF/statedb/couchdb/indexes/indexOwner.json", Body: `{"index":{"fields":["owner","color"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`},
+		},
+	)
+	fileEntries, err := ccprovider.ExtractFileEntries(dbArtifactsTarBytes, "couchdb")
+	assert.NoError(t, err)
+	assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", fileEntries["META-INF/statedb/couchdb/indexes"]))
+
+	// the documents without the field "size" are not returned for a sort on "size"
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"fred"}, "sort": [{"size": "desc"}]}`)
+	assert.NoError(t, err)
+	testQueryItr(t, itr, []string{"key3", "key2"})
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"size":{"$gte":2}}, "sort": ["size"]}`)
+	assert.NoError(t, err)
+	testQueryItr(t, itr, []string{"key3", "key1"})
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"fred","color":"blue"}}`)
+	assert.NoError(t, err)
+	testQueryItr(t, itr, []string{"key3", "key4"})
+
+	// the index entries follow the updates and the deletes of the documents
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1","color":"blue","size":0,"owner":"fred"}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key5", []byte(`{"asset_name":"marble5","color":"green","size":5,"owner":"fred"}`), version.NewHeight(2, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"fred"}, "sort": [{"size": "desc"}]}`)
+	assert.NoError(t, err)
+	testQueryItr(t, itr, []string{"key5", "key3", "key1"})
+
+	// the index is created only for the given namespace
+	_, err = db.ExecuteQuery("ns2", `{"selector":{"owner":"fred"}, "sort": [{"size": "desc"}]}`)
+	assert.Error(t, err)
+
+	// the index definitions are loaded when the db is reopened
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testquerywithindex")
+	assert.NoError(t, err)
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"fred"}, "sort": [{"size": "desc"}]}`)
+	assert.NoError(t, err)
+	testQueryItr(t, itr, []string{"key5", "key3", "key1"})
+
+	// an erroneous index definition is reported
+	dbArtifactsTarBytes = testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: "META-INF/statedb/couchdb/indexes/badSyntax.json", Body: `{"index":{"fields": This is a bad json}`},
+		},
+	)
+	fileEntries, err = ccprovider.ExtractFileEntries(dbArtifactsTarBytes, "couchdb")
+	assert.NoError(t, err)
+	err = db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", fileEntries["META-INF/statedb/couchdb/indexes"])
+	assert.Contains(t, err.Error(), "error creating index from file [META-INF/statedb/couchdb/indexes/badSyntax.json] for namespace [ns1]")
+
+	// the full scan iterator skips the index entries
+	fullScanItr, err := db.(statedb.FullScannable).GetFullScanIterator(func(string) bool { return false })
+	assert.NoError(t, err)
+	defer fullScanItr.Close()
+	numEntries := 0
+	for {
+		kv, err := fullScanItr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		numEntries++
+	}
+	assert.Equal(t, 5, numEntries)
+}
+
+func TestIndexBuildWithConcurrentUpdates(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexbuildwithconcurrentupdates")
+	assert.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"size":1,"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"size":2,"owner":"fred"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"size":3,"owner":"fred"}`), version.NewHeight(1, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+	assert.NoError(t, vdb.createIndex("ns1", &indexDefinition{Name: "indexSize", Fields: []string{"size"}}))
+
+	// the index is replaced while the namespace gets updated in between the snapshot and the catch up
+	build := vdb.startIndexBuild("ns1", &indexDefinition{Name: "indexSize", Fields: []string{"size", "owner"}})
+	assert.NotNil(t, build)
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"size":5,"owner":"tom"}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key4", []byte(`{"size":0,"owner":"fred"}`), version.NewHeight(2, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+	assert.NoError(t, build.scan())
+	assert.NoError(t, vdb.finishIndexBuild(build, nil))
+	assert.Empty(t, vdb.updatedKeys)
+
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":{"$exists":true}}, "sort": ["size"]}`)
 	assert.NoError(t, err)
-	db.Open()
-	defer db.Close()
+	testQueryItr(t, itr, []string{"key4", "key3", "key1"})
+
+	// no entry of the replaced index or of an outdated value is left behind
+	prefix := constructIndexEntryPrefix("ns1", "indexSize")
+	entryItr := vdb.db.GetIterator(prefix, successorKey(prefix))
+	defer entryItr.Release()
+	numEntries := 0
+	for entryItr.Next() {
+		numEntries++
+	}
+	assert.Equal(t, 3, numEntries)
+
+	// an existing index is not rebuilt
+	assert.Nil(t, vdb.startIndexBuild("ns1", &indexDefinition{Name: "indexSize", Fields: []string{"size", "owner"}}))
+}
+
+func TestPaginatedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery")
+	assert.NoError(t, err)
+
 	batch := statedb.NewUpdateBatch()
-	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
+	for i := 1; i <= 10; i++ {
+		value := fmt.Sprintf(`{"asset_name":"marble%d","size":%d,"owner":"%s"}`, i, 100-i, []string{"fred", "tom"}[i%2])
+		batch.Put("ns1", fmt.Sprintf("key%02d", i), []byte(value), version.NewHeight(1, uint64(i)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 10)))
 
-	savePoint := version.NewHeight(2, 22)
-	db.ApplyUpdates(batch, savePoint)
+	// without an index
+	keys, bookmark := executePaginatedQuery(t, db, `{"selector":{"owner":"fred"}}`, "", 2)
+	assert.Equal(t, []string{"key02", "key04"}, keys)
+	keys, bookmark = executePaginatedQuery(t, db, `{"selector":{"owner":"fred"}}`, bookmark, 2)
+	assert.Equal(t, []string{"key06", "key08"}, keys)
+	keys, bookmark = executePaginatedQuery(t, db, `{"selector":{"owner":"fred"}}`, bookmark, 2)
+	assert.Equal(t, []string{"key10"}, keys)
+	keys, _ = executePaginatedQuery(t, db, `{"selector":{"owner":"fred"}}`, bookmark, 2)
+	assert.Empty(t, keys)
 
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
-	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
-	assert.Nil(t, itr)
+	// with an index, in the descending order of the sort
+	dbArtifactsTarBytes := testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: "META-INF/statedb/couchdb/indexes/indexSize.json", Body: `{"index":{"fields":["size"]},"name":"indexSize","type":"json"}`},
+		},
+	)
+	fileEntries, err := ccprovider.ExtractFileEntries(dbArtifactsTarBytes, "couchdb")
+	assert.NoError(t, err)
+	assert.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy("ns1", fileEntries["META-INF/statedb/couchdb/indexes"]))
+	query := `{"selector":{"size":{"$lt":98}}, "sort":[{"size":"desc"}]}`
+	keys, bookmark = executePaginatedQuery(t, db, query, "", 3)
+	assert.Equal(t, []string{"key03", "key04", "key05"}, keys)
+	keys, bookmark = executePaginatedQuery(t, db, query, bookmark, 3)
+	assert.Equal(t, []string{"key06", "key07", "key08"}, keys)
+	keys, _ = executePaginatedQuery(t, db, query, bookmark, 3)
+	assert.Equal(t, []string{"key09", "key10"}, keys)
+
+	_, err = db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"limit": 10})
+	assert.EqualError(t, err, `Invalid entry, "limit" must be an int32`)
+	_, err = db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"bookmark": "!@#"})
+	assert.Error(t, err)
+}
+
+func executePaginatedQuery(t *testing.T, db statedb.VersionedDB, query, bookmark string, limit int32) ([]string, string) {
+	itr, err := db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"limit": limit, "bookmark": bookmark})
+	assert.NoError(t, err)
+	var keys []string
+	for {
+		queryResult, err := itr.Next()
+		assert.NoError(t, err)
+		if queryResult == nil {
+			break
+		}
+		keys = append(keys, queryResult.(*statedb.VersionedKV).Key)
+	}
+	return keys, itr.GetBookmarkAndClose()
+}
+
+func testQueryItr(t *testing.T, itr statedb.ResultsIterator, expectedKeys []string) {
+	defer itr.Close()
+	var keys []string
+	for {
+		queryResult, err := itr.Next()
+		assert.NoError(t, err)
+		if queryResult == nil {
+			break
+		}
+		keys = append(keys, queryResult.(*statedb.VersionedKV).Key)
+	}
+	assert.Equal(t, expectedKeys, keys)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -136,3 +334,44 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscaniterator")
+	assert.NoError(t, err)
+	otherDB, err := env.DBProvider.GetDBHandle("testfullscaniterator_other")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns2", "key1", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns3", "key1", []byte("value3"), version.NewHeight(1, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "key2", []byte("value4"), version.NewHeight(1, 1))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 1)))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(func(ns string) bool { return ns == "ns3" })
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		kv, err := itr.Next()
+		assert.NoError(t, err)
+		if kv == nil {
+			break
+		}
+		results = append(results, kv)
+	}
+	assert.Equal(t, []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+		},
+	}, results)
+}
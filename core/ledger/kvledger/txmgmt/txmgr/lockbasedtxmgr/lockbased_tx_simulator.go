@@ -89,6 +89,18 @@ func (s *lockBasedTxSimulator) DeletePrivateData(ns, coll, key string) error {
 	return s.SetPrivateData(ns, coll, key, nil)
 }
 
+// PurgePrivateData implements method in interface `ledger.TxSimulator`
+func (s *lockBasedTxSimulator) PurgePrivateData(ns, coll, key string) error {
+	if err := s.helper.validateCollName(ns, coll); err != nil {
+		return err
+	}
+	if err := s.checkWritePrecondition(key, nil); err != nil {
+		return err
+	}
+	s.rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ns, coll, key)
+	return nil
+}
+
 // SetPrivateDataMultipleKeys implements method in interface `ledger.TxSimulator`
 func (s *lockBasedTxSimulator) SetPrivateDataMultipleKeys(ns, coll string, kvs map[string][]byte) error {
 	for k, v := range kvs {
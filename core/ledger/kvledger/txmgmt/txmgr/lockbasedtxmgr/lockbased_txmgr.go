@@ -207,6 +207,48 @@ func (txmgr *LockBasedTxMgr) RemoveStaleAndCommitPvtDataOfOldBlocks(blocksPvtDat
 	return nil
 }
 
+// PurgePvtDataOfOldBlocks implements method in interface `txmgmt.TxMgr`
+// The pvt data written by the passed blocksPvtData is removed from the stateDB if the
+// committed version of the pvt data is still lower than the block `maxBlockNumToRetain`.
+// The hashes of the pvt data are not removed so that the public state stays verifiable.
+func (txmgr *LockBasedTxMgr) PurgePvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData, maxBlockNumToRetain uint64) error {
+	// similar to RemoveStaleAndCommitPvtDataOfOldBlocks, the lock on oldBlockCommit ensures that
+	// the committed versions read here are not changed by a regular block commit in between
+	logger.Debug("Waiting for purge mgr to finish the background job of computing expirying keys for the block")
+	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
+	txmgr.oldBlockCommit.Lock()
+	defer txmgr.oldBlockCommit.Unlock()
+	logger.Debug("lock acquired on oldBlockCommit for purging pvtData of old blocks from state database")
+
+	uniquePvtData, err := constructUniquePvtData(blocksPvtData)
+	if len(uniquePvtData) == 0 || err != nil {
+		return err
+	}
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	for hashedCompositeKey, pvtWrite := range uniquePvtData {
+		ns := hashedCompositeKey.Namespace
+		coll := hashedCompositeKey.CollectionName
+		committedPvtData, err := txmgr.db.GetPrivateData(ns, coll, pvtWrite.Key)
+		if err != nil {
+			return err
+		}
+		if committedPvtData == nil || committedPvtData.Version.BlockNum >= maxBlockNumToRetain {
+			// either already deleted or overwritten by a block that is retained
+			continue
+		}
+		batch.PvtUpdates.Delete(ns, coll, pvtWrite.Key, committedPvtData.Version)
+	}
+	if batch.PvtUpdates.IsEmpty() {
+		return nil
+	}
+
+	logger.Debugf("Purging pvtData of old blocks below block [%d] from state database", maxBlockNumToRetain)
+	txmgr.commitRWLock.Lock()
+	defer txmgr.commitRWLock.Unlock()
+	return txmgr.db.ApplyPrivacyAwareUpdates(batch, nil)
+}
+
 type uniquePvtDataMap map[privacyenabledstate.HashedCompositeKey]*privacyenabledstate.PvtKVWrite
 
 func constructUniquePvtData(blocksPvtData map[uint64][]*ledger.TxPvtData) (uniquePvtDataMap, error) {
@@ -1329,6 +1329,55 @@ func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	assert.True(t, testPvtValueEqual(t, txMgr, "ns", "coll", "pvtkey2", nil))
 }
 
+func TestPurgePvtDataOfOldBlocks(t *testing.T) {
+	ledgerid := "TestPurgePvtDataOfOldBlocks"
+	testEnv := testEnvs[0]
+	testEnv.init(t, ledgerid, nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+	db := testEnv.getVDB()
+
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	for _, kv := range []struct {
+		key, value string
+		ver        *version.Height
+	}{
+		{"key1", "value1", version.NewHeight(1, 1)},
+		{"key2", "value2", version.NewHeight(1, 2)},
+		{"key3", "new-value3", version.NewHeight(3, 1)}, // written in block 1 and overwritten in block 3
+	} {
+		updateBatch.PvtUpdates.Put("ns1", "coll1", kv.key, []byte(kv.value), kv.ver)
+		updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash(kv.key), util.ComputeStringHash(kv.value), kv.ver)
+	}
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(3, 1)))
+
+	blocksPvtData := map[uint64][]*ledger.TxPvtData{
+		1: {
+			producePvtdata(t, 1, []string{"ns1:coll1"}, []string{"key1"}, [][]byte{[]byte("value1")}),
+			producePvtdata(t, 2, []string{"ns1:coll1", "ns1:coll1"}, []string{"key2", "key3"}, [][]byte{[]byte("value2"), []byte("value3")}),
+			producePvtdata(t, 3, []string{"ns1:coll1"}, []string{"key4"}, [][]byte{[]byte("value4")}),
+		},
+	}
+	assert.NoError(t, txMgr.PurgePvtDataOfOldBlocks(blocksPvtData, 2))
+
+	for _, key := range []string{"key1", "key2"} {
+		vv, err := db.GetPrivateData("ns1", "coll1", key)
+		assert.NoError(t, err)
+		assert.Nil(t, vv)
+		// the hashes are retained
+		vv, err = db.GetValueHash("ns1", "coll1", util.ComputeStringHash(key))
+		assert.NoError(t, err)
+		assert.NotNil(t, vv)
+	}
+	vv, err := db.GetPrivateData("ns1", "coll1", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("new-value3"), vv.Value)
+
+	savepoint, err := txMgr.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(3, 1), savepoint)
+}
+
 func testPvtKeyExist(t *testing.T, txMgr txmgr.TxMgr, ns, coll, key string) bool {
 	simulator, _ := txMgr.NewTxSimulator("tx-tmp")
 	defer simulator.Done()
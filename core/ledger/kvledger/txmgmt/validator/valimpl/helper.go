@@ -144,8 +144,16 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
 				continue
 			}
+			err = processEndorserTx(chdr.TxId, respPayload, txRWSet, txMgr)
+			if _, ok := err.(*customtx.InvalidTxError); ok {
+				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
 		} else {
-			rwsetProto, err := processNonEndorserTx(env, chdr.TxId, txType, txMgr, !doMVCCValidation)
+			rwsetProto, err := processNonEndorserTx(env, chdr.TxId, txType, txMgr, !doMVCCValidation, block.Header.Number, uint64(txIndex))
 			if _, ok := err.(*customtx.InvalidTxError); ok {
 				txsFilter.SetFlag(txIndex, peer.TxValidationCode_INVALID_OTHER_REASON)
 				continue
@@ -174,7 +182,8 @@ func preprocessProtoBlock(txMgr txmgr.TxMgr,
 	return b, txsStatInfo, nil
 }
 
-func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, txmgr txmgr.TxMgr, synchingState bool) (*rwset.TxReadWriteSet, error) {
+func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.HeaderType, txmgr txmgr.TxMgr, synchingState bool,
+	blockNum uint64, txNum uint64) (*rwset.TxReadWriteSet, error) {
 	logger.Debugf("Performing custom processing for transaction [txid=%s], [txType=%s]", txid, txType)
 	processor := customtx.GetProcessor(txType)
 	logger.Debugf("Processor for custom tx processing:%#v", processor)
@@ -189,7 +198,12 @@ func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.Hea
 		return nil, err
 	}
 	defer sim.Done()
-	if err = processor.GenerateSimulationResults(txEnv, sim, synchingState); err != nil {
+	if positionAwareProcessor, ok := processor.(customtx.PositionAwareProcessor); ok {
+		err = positionAwareProcessor.GenerateSimulationResultsAt(txEnv, sim, synchingState, blockNum, txNum)
+	} else {
+		err = processor.GenerateSimulationResults(txEnv, sim, synchingState)
+	}
+	if err != nil {
 		return nil, err
 	}
 	if simRes, err = sim.GetTxSimulationResults(); err != nil {
@@ -198,6 +212,42 @@ func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.Hea
 	return simRes.PubSimulationResults, nil
 }
 
+// processEndorserTx adds to txRWSet the ledger updates that the custom processors derive from the endorser transaction
+func processEndorserTx(txid string, chaincodeAction *peer.ChaincodeAction, txRWSet *rwsetutil.TxRwSet, txmgr txmgr.TxMgr) error {
+	for _, processor := range customtx.GetEndorserTxProcessors() {
+		logger.Debugf("Performing custom processing for endorser transaction [txid=%s]", txid)
+		rwsetProto, err := generateEndorserTxSimulationResults(processor, txid, chaincodeAction, txmgr)
+		if err != nil {
+			return err
+		}
+		if rwsetProto == nil {
+			continue
+		}
+		processorRWSet, err := rwsetutil.TxRwSetFromProtoMsg(rwsetProto)
+		if err != nil {
+			return err
+		}
+		txRWSet.NsRwSets = append(txRWSet.NsRwSets, processorRWSet.NsRwSets...)
+	}
+	return nil
+}
+
+func generateEndorserTxSimulationResults(processor customtx.EndorserTxProcessor, txid string, chaincodeAction *peer.ChaincodeAction, txmgr txmgr.TxMgr) (*rwset.TxReadWriteSet, error) {
+	sim, err := txmgr.NewTxSimulator(txid)
+	if err != nil {
+		return nil, err
+	}
+	defer sim.Done()
+	if err = processor.GenerateEndorserTxSimulationResults(txid, chaincodeAction, sim); err != nil {
+		return nil, err
+	}
+	simRes, err := sim.GetTxSimulationResults()
+	if err != nil {
+		return nil, err
+	}
+	return simRes.PubSimulationResults, nil
+}
+
 func validateWriteset(txRWSet *rwsetutil.TxRwSet, validateKVFunc func(key string, value []byte) error) error {
 	for _, nsRwSet := range txRWSet.NsRwSets {
 		pubWriteset := nsRwSet.KvRwSet
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package valimpl
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/ledger/testutil"
 	"github.com/tradeline-tech/fabric/common/util"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
@@ -514,3 +516,122 @@ func testutilGenerateTxSimulationResultsAsBytes(
 	assert.NoError(t, err)
 	return pub, pvt
 }
+
+func TestProcessNonEndorserTxWithPosition(t *testing.T) {
+	processor := &positionRecordingProcessor{}
+	customtx.InitializeTestEnv(customtx.Processors{common.HeaderType_TOKEN_TRANSACTION: processor})
+	defer customtx.InitializeTestEnv(nil)
+
+	_, err := processNonEndorserTx(&common.Envelope{}, "txid", common.HeaderType_TOKEN_TRANSACTION, &testTxMgr{}, false, 7, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{7, 3}, processor.position)
+}
+
+// positionRecordingProcessor is a customtx.PositionAwareProcessor that records the position of the processed transaction
+type positionRecordingProcessor struct {
+	position []uint64
+}
+
+func (p *positionRecordingProcessor) GenerateSimulationResults(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	return errors.New("the position of the transaction was not passed")
+}
+
+func (p *positionRecordingProcessor) GenerateSimulationResultsAt(txEnvelop *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool, blockNum uint64, txNum uint64) error {
+	p.position = []uint64{blockNum, txNum}
+	return nil
+}
+
+type testTxMgr struct {
+	txmgr.TxMgr
+}
+
+func (*testTxMgr) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
+	return &testTxSimulator{}, nil
+}
+
+type testTxSimulator struct {
+	ledger.TxSimulator
+}
+
+func (*testTxSimulator) Done() {}
+
+func (*testTxSimulator) GetTxSimulationResults() (*ledger.TxSimulationResults, error) {
+	return &ledger.TxSimulationResults{}, nil
+}
+
+func TestProcessEndorserTx(t *testing.T) {
+	customtx.InitializeTestEnv(customtx.Processors{common.HeaderType_TOKEN_TRANSACTION: &expectationRecordingProcessor{}})
+	defer customtx.InitializeTestEnv(nil)
+
+	tx1SimulationResults := testutilSampleTxSimulationResults(t, "key1")
+	tx1Results, err := tx1SimulationResults.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	tx2Results, err := testutilSampleTxSimulationResults(t, "key2").GetPubSimulationBytes()
+	assert.NoError(t, err)
+	block := testutil.ConstructBlockWithTxid(t, 10, testutil.ConstructRandomBytes(t, 32), [][]byte{tx1Results, tx2Results}, []string{"tx1", "invalid-tx"}, false)
+	alwaysValidKVFunc := func(key string, value []byte) error {
+		return nil
+	}
+
+	preprocessedBlock, _, err := preprocessProtoBlock(&builderTxMgr{}, alwaysValidKVFunc, block, false)
+	assert.NoError(t, err)
+
+	// the updates of the processor are added to the rwset of tx1
+	expectedRWSet, err := rwsetutil.TxRwSetFromProtoMsg(tx1SimulationResults.PubSimulationResults)
+	assert.NoError(t, err)
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToWriteSet("tms", "expectation-tx1", []byte("recorded"))
+	expectedRWSet.NsRwSets = append(expectedRWSet.NsRwSets, builder.GetTxReadWriteSet().NsRwSets...)
+	assert.Len(t, preprocessedBlock.Txs, 1)
+	assert.Equal(t, "tx1", preprocessedBlock.Txs[0].ID)
+	assert.Equal(t, expectedRWSet, preprocessedBlock.Txs[0].RWSet)
+
+	// the transaction rejected by the processor is marked invalid
+	txsFilter := lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.Equal(t, peer.TxValidationCode_INVALID_OTHER_REASON, txsFilter.Flag(1))
+
+	// other errors of the processor are returned
+	block = testutil.ConstructBlockWithTxid(t, 11, testutil.ConstructRandomBytes(t, 32), [][]byte{tx1Results}, []string{"failing-tx"}, false)
+	_, _, err = preprocessProtoBlock(&builderTxMgr{}, alwaysValidKVFunc, block, false)
+	assert.EqualError(t, err, "failed processing failing-tx")
+}
+
+// expectationRecordingProcessor is a customtx.EndorserTxProcessor that records a key for each endorser transaction
+type expectationRecordingProcessor struct {
+	customtx.Processor
+}
+
+func (*expectationRecordingProcessor) GenerateEndorserTxSimulationResults(txID string, chaincodeAction *peer.ChaincodeAction, simulator ledger.TxSimulator) error {
+	switch txID {
+	case "invalid-tx":
+		return &customtx.InvalidTxError{Msg: "no way"}
+	case "failing-tx":
+		return fmt.Errorf("failed processing %s", txID)
+	}
+	return simulator.SetState("tms", "expectation-"+txID, []byte("recorded"))
+}
+
+// builderTxMgr creates simulators that collect the writes of the transactions
+type builderTxMgr struct {
+	txmgr.TxMgr
+}
+
+func (*builderTxMgr) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
+	return &builderTxSimulator{builder: rwsetutil.NewRWSetBuilder()}, nil
+}
+
+type builderTxSimulator struct {
+	ledger.TxSimulator
+	builder *rwsetutil.RWSetBuilder
+}
+
+func (*builderTxSimulator) Done() {}
+
+func (s *builderTxSimulator) SetState(namespace string, key string, value []byte) error {
+	s.builder.AddToWriteSet(namespace, key, value)
+	return nil
+}
+
+func (s *builderTxSimulator) GetTxSimulationResults() (*ledger.TxSimulationResults, error) {
+	return s.builder.GetTxSimulationResults()
+}
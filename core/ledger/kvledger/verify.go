@@ -0,0 +1,703 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/ledger/blkstorage"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
+	"github.com/tradeline-tech/fabric/common/util"
+	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerstorage"
+	"github.com/tradeline-tech/fabric/core/ledger/pvtdatapolicy"
+	lutil "github.com/tradeline-tech/fabric/core/ledger/util"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+// The checks performed by VerifyLedger, as reported in a Divergence
+const (
+	VerificationCheckBlockNumber = "block_number"
+	VerificationCheckHashChain   = "hash_chain"
+	VerificationCheckDataHash    = "data_hash"
+	VerificationCheckBlockIndex  = "block_index"
+	VerificationCheckPvtData     = "private_data"
+	VerificationCheckState       = "state"
+	VerificationCheckHistory     = "history"
+)
+
+// VerificationReport is the outcome of the verification of a ledger, meant to be serialized to JSON.
+// StateChecked and HistoryChecked report whether the state database and the history database have been
+// compared with the databases rebuilt from the blocks, which requires all the blocks from the genesis block
+type VerificationReport struct {
+	ChannelName      string      `json:"channel_name"`
+	Height           uint64      `json:"height"`
+	FirstBlockNumber uint64      `json:"first_block_number"`
+	StateChecked     bool        `json:"state_checked"`
+	HistoryChecked   bool        `json:"history_checked"`
+	Consistent       bool        `json:"consistent"`
+	Divergence       *Divergence `json:"divergence,omitempty"`
+}
+
+// Divergence describes the first inconsistency found in a ledger. The hashes, the values,
+// and the keys of the history database are hex encoded, the versions are formatted as blockNum:txNum
+type Divergence struct {
+	Check           string  `json:"check"`
+	BlockNumber     *uint64 `json:"block_number,omitempty"`
+	TxNumber        *uint64 `json:"tx_number,omitempty"`
+	TxID            string  `json:"tx_id,omitempty"`
+	Namespace       string  `json:"namespace,omitempty"`
+	Collection      string  `json:"collection,omitempty"`
+	Key             string  `json:"key,omitempty"`
+	Expected        string  `json:"expected,omitempty"`
+	Actual          string  `json:"actual,omitempty"`
+	ExpectedVersion string  `json:"expected_version,omitempty"`
+	ActualVersion   string  `json:"actual_version,omitempty"`
+	Description     string  `json:"description"`
+}
+
+// VerifyLedger checks the integrity of the ledger ledgerID and returns a report of the first inconsistency found.
+// The blocks are checked for the sequence of their numbers, their hash chain, and their data hashes, and are
+// looked up through the block index. The private data is checked against the hashes present in the blocks.
+// If all the blocks from the genesis block are available, the state database and the history database are
+// rebuilt from the blocks in a temporary directory and compared with the databases of the ledger.
+// customTxProcessors and ccInfoProvider have to be the ones used by the peer for committing the blocks.
+// This function is expected to be invoked while the peer is stopped
+func VerifyLedger(ledgerID string, customTxProcessors customtx.Processors,
+	ccInfoProvider ledger.DeployedChaincodeInfoProvider) (*VerificationReport, error) {
+
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+	customtx.Initialize(customTxProcessors)
+
+	ledgerStoreProvider := ledgerstorage.NewProvider(&disabled.Provider{})
+	defer ledgerStoreProvider.Close()
+	blockStore, err := ledgerStoreProvider.Open(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStore.Shutdown()
+
+	bookkeepingProvider := bookkeeping.NewProvider()
+	defer bookkeepingProvider.Close()
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeepingProvider, &disabled.Provider{}, &noopHealthCheckRegistry{})
+	if err != nil {
+		return nil, err
+	}
+	defer vdbProvider.Close()
+	vdb, err := vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var historyDB historydb.HistoryDB
+	if ledgerconfig.IsHistoryDBEnabled() {
+		historyDBProvider := historyleveldb.NewHistoryDBProvider()
+		defer historyDBProvider.Close()
+		if historyDB, err = historyDBProvider.GetDBHandle(ledgerID); err != nil {
+			return nil, err
+		}
+	}
+
+	tempDir, err := ioutil.TempDir(ledgerconfig.GetRootPath(), "verify")
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating a temporary directory for rebuilding the databases")
+	}
+	defer os.RemoveAll(tempDir)
+
+	return verifyLedger(ledgerID, blockStore, vdb, historyDB, tempDir, ccInfoProvider)
+}
+
+func verifyLedger(ledgerID string, blockStore *ledgerstorage.Store, vdb privacyenabledstate.DB, historyDB historydb.HistoryDB,
+	tempDir string, ccInfoProvider ledger.DeployedChaincodeInfoProvider) (*VerificationReport, error) {
+
+	report := &VerificationReport{ChannelName: ledgerID}
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	report.Height = bcInfo.Height
+	if bcInfo.Height == 0 {
+		report.Consistent = true
+		return report, nil
+	}
+	if report.FirstBlockNumber, err = firstAvailableBlockNum(blockStore); err != nil {
+		return nil, err
+	}
+
+	// the databases can only be rebuilt from the genesis block
+	var rebuilt *rebuiltDBs
+	if report.FirstBlockNumber == 0 {
+		if rebuilt, err = newRebuiltDBs(ledgerID, tempDir, historyDB != nil, ccInfoProvider); err != nil {
+			return nil, err
+		}
+		defer rebuilt.close()
+	}
+
+	var prevBlockHash []byte
+	for blockNum := report.FirstBlockNumber; blockNum < bcInfo.Height; blockNum++ {
+		blockAndPvtData, err := blockStore.GetPvtDataAndBlockByNum(blockNum, nil)
+		if err != nil {
+			return nil, err
+		}
+		block := blockAndPvtData.Block
+		if report.Divergence = checkBlockHashes(blockNum, block, prevBlockHash); report.Divergence != nil {
+			return report, nil
+		}
+		if report.Divergence, err = checkBlockIndex(blockStore, block); err != nil || report.Divergence != nil {
+			return report, err
+		}
+		if report.Divergence, err = checkPvtData(blockAndPvtData); err != nil || report.Divergence != nil {
+			return report, err
+		}
+		if rebuilt != nil {
+			if err := rebuilt.commit(blockAndPvtData); err != nil {
+				return nil, err
+			}
+		}
+		prevBlockHash = block.Header.Hash()
+	}
+	if !bytes.Equal(prevBlockHash, bcInfo.CurrentBlockHash) {
+		report.Divergence = &Divergence{
+			Check:       VerificationCheckHashChain,
+			BlockNumber: uint64Ptr(bcInfo.Height - 1),
+			Expected:    hex.EncodeToString(prevBlockHash),
+			Actual:      hex.EncodeToString(bcInfo.CurrentBlockHash),
+			Description: "the hash of the last block does not match the current block hash recorded by the block store",
+		}
+		return report, nil
+	}
+	if rebuilt == nil {
+		report.Consistent = true
+		return report, nil
+	}
+
+	report.StateChecked = true
+	if report.Divergence, err = compareStateDBs(rebuilt.vdb, vdb); err != nil || report.Divergence != nil {
+		return report, err
+	}
+	if historyDB != nil {
+		report.HistoryChecked = true
+		if report.Divergence, err = compareHistoryDBs(rebuilt.historyDB, historyDB); err != nil || report.Divergence != nil {
+			return report, err
+		}
+	}
+	report.Consistent = true
+	return report, nil
+}
+
+// firstAvailableBlockNum returns the number of the lowest block retained by the block store,
+// which is not zero for a pruned block store or a block store bootstrapped from a snapshot
+func firstAvailableBlockNum(blockStore *ledgerstorage.Store) (uint64, error) {
+	itr, err := blockStore.RetrieveBlocks(0)
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+	if _, err := itr.Next(); err != nil {
+		if prunedErr, ok := err.(*ledger.PrunedErr); ok {
+			return prunedErr.FirstBlockNum, nil
+		}
+		return 0, err
+	}
+	return 0, nil
+}
+
+func checkBlockHashes(blockNum uint64, block *common.Block, prevBlockHash []byte) *Divergence {
+	if block.Header.Number != blockNum {
+		return &Divergence{
+			Check:       VerificationCheckBlockNumber,
+			BlockNumber: uint64Ptr(blockNum),
+			Expected:    fmt.Sprintf("%d", blockNum),
+			Actual:      fmt.Sprintf("%d", block.Header.Number),
+			Description: "the number in the block header does not match the position of the block",
+		}
+	}
+	if prevBlockHash != nil && !bytes.Equal(block.Header.PreviousHash, prevBlockHash) {
+		return &Divergence{
+			Check:       VerificationCheckHashChain,
+			BlockNumber: uint64Ptr(blockNum),
+			Expected:    hex.EncodeToString(prevBlockHash),
+			Actual:      hex.EncodeToString(block.Header.PreviousHash),
+			Description: "the previous hash in the block header does not match the hash of the previous block",
+		}
+	}
+	if dataHash := block.Data.Hash(); !bytes.Equal(block.Header.DataHash, dataHash) {
+		return &Divergence{
+			Check:       VerificationCheckDataHash,
+			BlockNumber: uint64Ptr(blockNum),
+			Expected:    hex.EncodeToString(dataHash),
+			Actual:      hex.EncodeToString(block.Header.DataHash),
+			Description: "the data hash in the block header does not match the hash of the block data",
+		}
+	}
+	return nil
+}
+
+// checkBlockIndex checks that the block and its transactions are retrieved through the entries of the block index.
+// The lookups by transaction ID are only checked for the first transaction with a given ID, as the index
+// is not updated for duplicate transactions
+func checkBlockIndex(blockStore *ledgerstorage.Store, block *common.Block) (*Divergence, error) {
+	blockNum := block.Header.Number
+	newDivergence := func(txNum *uint64, txID, description string) *Divergence {
+		return &Divergence{
+			Check:       VerificationCheckBlockIndex,
+			BlockNumber: uint64Ptr(blockNum),
+			TxNumber:    txNum,
+			TxID:        txID,
+			Description: description,
+		}
+	}
+
+	blockByHash, err := blockStore.RetrieveBlockByHash(block.Header.Hash())
+	if divergent, err := isDivergentIndexLookup(err); err != nil || divergent {
+		return newDivergence(nil, "", "the block is not found by its hash"), err
+	}
+	if blockByHash != nil && blockByHash.Header.Number != blockNum {
+		return newDivergence(nil, "", fmt.Sprintf("the block hash is indexed for block [%d]", blockByHash.Header.Number)), nil
+	}
+
+	txsFilter := lutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	txIDsInBlock := map[string]bool{}
+	for i, envBytes := range block.Data.Data {
+		txNum := uint64Ptr(uint64(i))
+		env, err := utils.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			// a malformed transaction is stored as is, and not indexed by transaction ID
+			continue
+		}
+		envByTxNum, err := blockStore.RetrieveTxByBlockNumTranNum(blockNum, uint64(i))
+		if divergent, err := isDivergentIndexLookup(err); err != nil || divergent {
+			return newDivergence(txNum, "", "the transaction is not found by its block and transaction numbers"), err
+		}
+		if envByTxNum != nil && !proto.Equal(envByTxNum, env) {
+			return newDivergence(txNum, "", "a different transaction is found by the block and transaction numbers"), nil
+		}
+
+		txID, err := utils.GetOrComputeTxIDFromEnvelope(envBytes)
+		if err != nil || txID == "" || txIDsInBlock[txID] {
+			continue
+		}
+		txIDsInBlock[txID] = true
+		blockByTxID, err := blockStore.RetrieveBlockByTxID(txID)
+		if _, ok := err.(*ledger.PrunedErr); ok {
+			// the transaction ID is first used in a pruned block
+			continue
+		}
+		if divergent, err := isDivergentIndexLookup(err); err != nil || divergent {
+			return newDivergence(txNum, txID, "the block is not found by the transaction ID"), err
+		}
+		if blockByTxID != nil {
+			if blockByTxID.Header.Number < blockNum {
+				// duplicate of a transaction of a previous block
+				continue
+			}
+			if blockByTxID.Header.Number != blockNum {
+				return newDivergence(txNum, txID, fmt.Sprintf("the transaction ID is indexed for block [%d]", blockByTxID.Header.Number)), nil
+			}
+		}
+		envByTxID, err := blockStore.RetrieveTxByID(txID)
+		if divergent, err := isDivergentIndexLookup(err); err != nil || divergent {
+			return newDivergence(txNum, txID, "the transaction is not found by its ID"), err
+		}
+		if envByTxID != nil && !proto.Equal(envByTxID, env) {
+			return newDivergence(txNum, txID, "a different transaction is found by the transaction ID"), nil
+		}
+		validationCode, err := blockStore.RetrieveTxValidationCodeByTxID(txID)
+		if divergent, err := isDivergentIndexLookup(err); err != nil || divergent {
+			return newDivergence(txNum, txID, "the validation code is not found by the transaction ID"), err
+		}
+		if err == nil && validationCode != txsFilter.Flag(i) {
+			divergence := newDivergence(txNum, txID, "the indexed validation code does not match the validation code in the block metadata")
+			divergence.Expected, divergence.Actual = txsFilter.Flag(i).String(), validationCode.String()
+			return divergence, nil
+		}
+	}
+	return nil, nil
+}
+
+// isDivergentIndexLookup returns true if err denotes a missing index entry, and returns err if
+// it denotes a failure of the lookup. The attributes that are not indexed are not checked
+func isDivergentIndexLookup(err error) (bool, error) {
+	if err == nil || err == blkstorage.ErrAttrNotIndexed {
+		return false, nil
+	}
+	if _, ok := err.(ledger.NotFoundInIndexErr); ok {
+		return true, nil
+	}
+	return false, err
+}
+
+// checkPvtData checks the private data of a block against the hashes present in the block. As the private data
+// store removes the writes of the purged keys, a write set whose hash does not match is accepted if each of its
+// remaining writes matches the corresponding hashed write
+func checkPvtData(blockAndPvtData *ledger.BlockAndPvtData) (*Divergence, error) {
+	block := blockAndPvtData.Block
+	blockNum := block.Header.Number
+	var txNums []uint64
+	for txNum := range blockAndPvtData.PvtData {
+		txNums = append(txNums, txNum)
+	}
+	sort.Slice(txNums, func(i, j int) bool { return txNums[i] < txNums[j] })
+
+	for _, txNum := range txNums {
+		newDivergence := func(ns, coll, description string) *Divergence {
+			return &Divergence{
+				Check:       VerificationCheckPvtData,
+				BlockNumber: uint64Ptr(blockNum),
+				TxNumber:    uint64Ptr(txNum),
+				Namespace:   ns,
+				Collection:  coll,
+				Description: description,
+			}
+		}
+		if txNum >= uint64(len(block.Data.Data)) {
+			return newDivergence("", "", "the private data belongs to a transaction that is not present in the block"), nil
+		}
+		txRWSet, err := txRWSetFromEnvelope(block.Data.Data[txNum])
+		if err != nil {
+			return newDivergence("", "", fmt.Sprintf("the read-write set of the transaction cannot be retrieved: %s", err)), nil
+		}
+		for _, nsPvtRwset := range blockAndPvtData.PvtData[txNum].WriteSet.NsPvtRwset {
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				ns, coll := nsPvtRwset.Namespace, collPvtRwset.CollectionName
+				collHashedRwSet := getCollHashedRwSet(txRWSet, ns, coll)
+				if collHashedRwSet == nil {
+					return newDivergence(ns, coll, "the private data belongs to a collection that is not written by the transaction"), nil
+				}
+				pvtRwSetHash := util.ComputeSHA256(collPvtRwset.Rwset)
+				if bytes.Equal(pvtRwSetHash, collHashedRwSet.PvtRwSetHash) {
+					continue
+				}
+				if divergence := checkTrimmedPvtRwSet(collPvtRwset, collHashedRwSet.HashedRwSet); divergence != nil {
+					divergence.BlockNumber, divergence.TxNumber = uint64Ptr(blockNum), uint64Ptr(txNum)
+					divergence.Namespace, divergence.Collection = ns, coll
+					if divergence.Key == "" {
+						divergence.Expected = hex.EncodeToString(collHashedRwSet.PvtRwSetHash)
+						divergence.Actual = hex.EncodeToString(pvtRwSetHash)
+					}
+					return divergence, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+func checkTrimmedPvtRwSet(collPvtRwset *rwset.CollectionPvtReadWriteSet, hashedRwSet *kvrwset.HashedRWSet) *Divergence {
+	hashMismatch := &Divergence{
+		Check:       VerificationCheckPvtData,
+		Description: "the hash of the private data does not match the hash in the transaction",
+	}
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtRwset.Rwset, kvRWSet); err != nil {
+		return hashMismatch
+	}
+	if len(kvRWSet.Writes) >= len(hashedRwSet.HashedWrites) && len(kvRWSet.MetadataWrites) >= len(hashedRwSet.MetadataWrites) {
+		// no write has been removed
+		return hashMismatch
+	}
+
+	hashedWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, hashedWrite := range hashedRwSet.HashedWrites {
+		hashedWrites[string(hashedWrite.KeyHash)] = hashedWrite
+	}
+	for _, kvWrite := range kvRWSet.Writes {
+		keyHash := lutil.ComputeStringHash(kvWrite.Key)
+		hashedWrite, ok := hashedWrites[string(keyHash)]
+		if !ok || hashedWrite.IsDelete != kvWrite.IsDelete ||
+			(!kvWrite.IsDelete && !bytes.Equal(hashedWrite.ValueHash, lutil.ComputeHash(kvWrite.Value))) {
+			hashMismatch.Key = hex.EncodeToString(keyHash)
+			hashMismatch.Description = "the private write of the key does not match the hashed write in the transaction"
+			return hashMismatch
+		}
+	}
+	hashedMetadataWrites := map[string]bool{}
+	for _, hashedMetadataWrite := range hashedRwSet.MetadataWrites {
+		hashedMetadataWrites[string(hashedMetadataWrite.KeyHash)] = true
+	}
+	for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+		if keyHash := lutil.ComputeStringHash(kvMetadataWrite.Key); !hashedMetadataWrites[string(keyHash)] {
+			hashMismatch.Key = hex.EncodeToString(keyHash)
+			hashMismatch.Description = "the private metadata write of the key is not present in the transaction"
+			return hashMismatch
+		}
+	}
+	return nil
+}
+
+func txRWSetFromEnvelope(envBytes []byte) (*rwsetutil.TxRwSet, error) {
+	env, err := utils.UnmarshalEnvelope(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	responsePayload, err := utils.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(responsePayload.Results); err != nil {
+		return nil, err
+	}
+	return txRWSet, nil
+}
+
+func getCollHashedRwSet(txRWSet *rwsetutil.TxRwSet, ns, coll string) *rwsetutil.CollHashedRwSet {
+	for _, nsRwSet := range txRWSet.NsRwSets {
+		if nsRwSet.NameSpace != ns {
+			continue
+		}
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName == coll {
+				return collHashedRwSet
+			}
+		}
+	}
+	return nil
+}
+
+func compareStateDBs(expectedDB, db privacyenabledstate.DB) (*Divergence, error) {
+	expectedSavepoint, err := expectedDB.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil || expectedSavepoint.Compare(savepoint) != 0 {
+		return &Divergence{
+			Check:           VerificationCheckState,
+			ExpectedVersion: formatVersion(expectedSavepoint),
+			ActualVersion:   formatVersion(savepoint),
+			Description:     "the savepoint of the state database does not match the last block",
+		}, nil
+	}
+
+	stateDivergence, err := privacyenabledstate.CompareState(expectedDB, db)
+	if err != nil || stateDivergence == nil {
+		return nil, err
+	}
+	divergence := &Divergence{
+		Check:      VerificationCheckState,
+		Namespace:  stateDivergence.Namespace,
+		Collection: stateDivergence.Collection,
+		Key:        stateDivergence.Key,
+	}
+	switch {
+	case stateDivergence.Actual == nil:
+		divergence.Description = "the entry rebuilt from the blocks is missing from the state database"
+	case stateDivergence.Expected == nil:
+		divergence.Description = "the entry of the state database is not present in the state rebuilt from the blocks"
+	case !bytes.Equal(stateDivergence.Expected.Metadata, stateDivergence.Actual.Metadata):
+		divergence.Description = "the metadata of the entry differs from the metadata rebuilt from the blocks"
+	default:
+		divergence.Description = "the entry of the state database differs from the entry rebuilt from the blocks"
+	}
+	if vv := stateDivergence.Expected; vv != nil {
+		divergence.Expected, divergence.ExpectedVersion = hex.EncodeToString(vv.Value), formatVersion(vv.Version)
+	}
+	if vv := stateDivergence.Actual; vv != nil {
+		divergence.Actual, divergence.ActualVersion = hex.EncodeToString(vv.Value), formatVersion(vv.Version)
+	}
+	return divergence, nil
+}
+
+// compareHistoryDBs compares the entries of the history databases, including their savepoints, in key order
+func compareHistoryDBs(expectedDB, db historydb.HistoryDB) (*Divergence, error) {
+	expectedScannable, ok := expectedDB.(historydb.FullScannable)
+	if !ok {
+		return nil, errors.New("the rebuilt history database does not support full scans")
+	}
+	scannable, ok := db.(historydb.FullScannable)
+	if !ok {
+		return nil, errors.New("the history database does not support full scans")
+	}
+	expectedItr, err := expectedScannable.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer expectedItr.Close()
+	itr, err := scannable.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	expectedKey, expectedValue, err := expectedItr.Next()
+	if err != nil {
+		return nil, err
+	}
+	key, value, err := itr.Next()
+	if err != nil {
+		return nil, err
+	}
+	for expectedKey != nil || key != nil {
+		switch {
+		case key == nil || (expectedKey != nil && bytes.Compare(expectedKey, key) < 0):
+			return &Divergence{
+				Check:       VerificationCheckHistory,
+				Key:         hex.EncodeToString(expectedKey),
+				Expected:    hex.EncodeToString(expectedValue),
+				Description: "the entry rebuilt from the blocks is missing from the history database",
+			}, nil
+		case expectedKey == nil || bytes.Compare(expectedKey, key) > 0:
+			return &Divergence{
+				Check:       VerificationCheckHistory,
+				Key:         hex.EncodeToString(key),
+				Actual:      hex.EncodeToString(value),
+				Description: "the entry of the history database is not present in the history rebuilt from the blocks",
+			}, nil
+		case !bytes.Equal(expectedValue, value):
+			return &Divergence{
+				Check:       VerificationCheckHistory,
+				Key:         hex.EncodeToString(key),
+				Expected:    hex.EncodeToString(expectedValue),
+				Actual:      hex.EncodeToString(value),
+				Description: "the entry of the history database differs from the entry rebuilt from the blocks",
+			}, nil
+		}
+		if expectedKey, expectedValue, err = expectedItr.Next(); err != nil {
+			return nil, err
+		}
+		if key, value, err = itr.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// rebuiltDBs holds a state database and a history database rebuilt from the blocks of a ledger
+type rebuiltDBs struct {
+	bookkeepingProvider bookkeeping.Provider
+	vdbProvider         privacyenabledstate.DBProvider
+	vdb                 privacyenabledstate.DB
+	txmgr               *lockbasedtxmgr.LockBasedTxMgr
+	historyDBProvider   historydb.HistoryDBProvider
+	historyDB           historydb.HistoryDB
+}
+
+// newRebuiltDBs creates empty LevelDB databases in the directory dir. The blocks committed to these
+// databases are processed the same way the peer processes the blocks during the recovery of its databases
+func newRebuiltDBs(ledgerID string, dir string, withHistoryDB bool, ccInfoProvider ledger.DeployedChaincodeInfoProvider) (*rebuiltDBs, error) {
+	r := &rebuiltDBs{}
+	r.bookkeepingProvider = bookkeeping.NewProviderWithPath(filepath.Join(dir, "bookkeeper"))
+	r.vdbProvider = privacyenabledstate.NewCommonStorageDBProviderWithVersionedDBProvider(
+		stateleveldb.NewVersionedDBProviderWithPath(filepath.Join(dir, "stateLeveldb")), r.bookkeepingProvider)
+	var err error
+	if r.vdb, err = r.vdbProvider.GetDBHandle(ledgerID); err != nil {
+		r.close()
+		return nil, err
+	}
+	collInfoRetriever := &rebuiltCollectionInfoRetriever{infoProvider: ccInfoProvider}
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(collInfoRetriever)
+	if r.txmgr, err = lockbasedtxmgr.NewLockBasedTxMgr(ledgerID, r.vdb, nil, btlPolicy, r.bookkeepingProvider, ccInfoProvider); err != nil {
+		r.close()
+		return nil, err
+	}
+	collInfoRetriever.txmgr = r.txmgr
+	if withHistoryDB {
+		r.historyDBProvider = historyleveldb.NewHistoryDBProviderWithPath(filepath.Join(dir, "historyLeveldb"))
+		if r.historyDB, err = r.historyDBProvider.GetDBHandle(ledgerID); err != nil {
+			r.close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *rebuiltDBs) commit(blockAndPvtData *ledger.BlockAndPvtData) error {
+	if err := r.txmgr.CommitLostBlock(blockAndPvtData); err != nil {
+		return err
+	}
+	if r.historyDB != nil {
+		return r.historyDB.CommitLostBlock(blockAndPvtData)
+	}
+	return nil
+}
+
+func (r *rebuiltDBs) close() {
+	if r.txmgr != nil {
+		r.txmgr.Shutdown()
+	}
+	if r.historyDBProvider != nil {
+		r.historyDBProvider.Close()
+	}
+	r.vdbProvider.Close()
+	r.bookkeepingProvider.Close()
+}
+
+// rebuiltCollectionInfoRetriever retrieves the collection configs from the rebuilt state database
+type rebuiltCollectionInfoRetriever struct {
+	txmgr        *lockbasedtxmgr.LockBasedTxMgr
+	infoProvider ledger.DeployedChaincodeInfoProvider
+}
+
+func (r *rebuiltCollectionInfoRetriever) CollectionInfo(chaincodeName, collectionName string) (*common.StaticCollectionConfig, error) {
+	qe, err := r.txmgr.NewQueryExecutor(util.GenerateUUID())
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	return r.infoProvider.CollectionInfo(chaincodeName, collectionName, qe)
+}
+
+// noopHealthCheckRegistry is used for opening the state database outside of a running peer
+type noopHealthCheckRegistry struct{}
+
+func (r *noopHealthCheckRegistry) RegisterChecker(string, healthz.HealthChecker) error {
+	return nil
+}
+
+func formatVersion(height *version.Height) string {
+	if height == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", height.BlockNum, height.TxNum)
+}
+
+func uint64Ptr(n uint64) *uint64 {
+	return &n
+}
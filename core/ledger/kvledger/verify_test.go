@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/common/ledger/testutil"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/common/metrics/disabled"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
+	"github.com/tradeline-tech/fabric/core/ledger/mock"
+	lutil "github.com/tradeline-tech/fabric/core/ledger/util"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset/kvrwset"
+)
+
+func TestVerifyLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Reset()
+
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		commitSimulatedBlock(t, ledger, bg, fmt.Sprintf("key%d", i+1), fmt.Sprintf("value%d", i+1))
+	}
+	ledger.Close()
+	provider.Close()
+
+	_, err = VerifyLedger("nonExistingLedger", nil, &mock.DeployedChaincodeInfoProvider{})
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+
+	report, err := VerifyLedger("testLedger", nil, &mock.DeployedChaincodeInfoProvider{})
+	require.NoError(t, err)
+	assert.Equal(t, &VerificationReport{
+		ChannelName:    "testLedger",
+		Height:         4,
+		StateChecked:   true,
+		HistoryChecked: true,
+		Consistent:     true,
+	}, report)
+
+	// an unexpected entry in the history database
+	historyDBProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetHistoryLevelDBPath()})
+	require.NoError(t, historyDBProvider.GetDBHandle("testLedger").Put([]byte("ns1\x00unexpected"), []byte{}, true))
+	historyDBProvider.Close()
+	report, err = VerifyLedger("testLedger", nil, &mock.DeployedChaincodeInfoProvider{})
+	require.NoError(t, err)
+	assert.False(t, report.Consistent)
+	assert.Equal(t, &Divergence{
+		Check:       VerificationCheckHistory,
+		Key:         hex.EncodeToString([]byte("ns1\x00unexpected")),
+		Description: "the entry of the history database is not present in the history rebuilt from the blocks",
+	}, report.Divergence)
+
+	// a tampered value in the state database, which is checked before the history database
+	bookkeepingProvider := bookkeeping.NewProvider()
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeepingProvider, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+	require.NoError(t, err)
+	vdb, err := vdbProvider.GetDBHandle("testLedger")
+	require.NoError(t, err)
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key2", []byte("tamperedValue"), version.NewHeight(2, 0))
+	require.NoError(t, vdb.ApplyPrivacyAwareUpdates(batch, version.NewHeight(3, 0)))
+	vdbProvider.Close()
+	bookkeepingProvider.Close()
+	report, err = VerifyLedger("testLedger", nil, &mock.DeployedChaincodeInfoProvider{})
+	require.NoError(t, err)
+	assert.True(t, report.StateChecked)
+	assert.False(t, report.HistoryChecked)
+	assert.Equal(t, &Divergence{
+		Check:           VerificationCheckState,
+		Namespace:       "ns1",
+		Key:             "key2",
+		Expected:        hex.EncodeToString([]byte("value2")),
+		Actual:          hex.EncodeToString([]byte("tamperedValue")),
+		ExpectedVersion: "2:0",
+		ActualVersion:   "2:0",
+		Description:     "the entry of the state database differs from the entry rebuilt from the blocks",
+	}, report.Divergence)
+}
+
+func TestCheckBlockHashes(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	assert.Nil(t, checkBlockHashes(0, blocks[0], nil))
+	assert.Nil(t, checkBlockHashes(1, blocks[1], blocks[0].Header.Hash()))
+
+	divergence := checkBlockHashes(2, blocks[1], blocks[1].Header.Hash())
+	require.NotNil(t, divergence)
+	assert.Equal(t, VerificationCheckBlockNumber, divergence.Check)
+
+	divergence = checkBlockHashes(2, blocks[2], blocks[0].Header.Hash())
+	require.NotNil(t, divergence)
+	assert.Equal(t, VerificationCheckHashChain, divergence.Check)
+	assert.Equal(t, hex.EncodeToString(blocks[0].Header.Hash()), divergence.Expected)
+
+	tamperedBlock := proto.Clone(blocks[2]).(*common.Block)
+	tamperedBlock.Data.Data = tamperedBlock.Data.Data[1:]
+	divergence = checkBlockHashes(2, tamperedBlock, blocks[1].Header.Hash())
+	require.NotNil(t, divergence)
+	assert.Equal(t, VerificationCheckDataHash, divergence.Check)
+	assert.Equal(t, uint64(2), *divergence.BlockNumber)
+}
+
+func TestCheckTrimmedPvtRwSet(t *testing.T) {
+	hashedRwSet := &kvrwset.HashedRWSet{
+		HashedWrites: []*kvrwset.KVWriteHash{
+			{KeyHash: lutil.ComputeStringHash("key1"), ValueHash: lutil.ComputeHash([]byte("value1"))},
+			{KeyHash: lutil.ComputeStringHash("key2"), ValueHash: lutil.ComputeHash([]byte("value2"))},
+		},
+	}
+	collPvtRwSet := func(writes ...*kvrwset.KVWrite) *rwset.CollectionPvtReadWriteSet {
+		rwsetBytes, err := proto.Marshal(&kvrwset.KVRWSet{Writes: writes})
+		require.NoError(t, err)
+		return &rwset.CollectionPvtReadWriteSet{CollectionName: "coll1", Rwset: rwsetBytes}
+	}
+
+	// the write of a purged key has been removed
+	assert.Nil(t, checkTrimmedPvtRwSet(collPvtRwSet(&kvrwset.KVWrite{Key: "key1", Value: []byte("value1")}), hashedRwSet))
+
+	divergence := checkTrimmedPvtRwSet(collPvtRwSet(&kvrwset.KVWrite{Key: "key1", Value: []byte("otherValue")}), hashedRwSet)
+	require.NotNil(t, divergence)
+	assert.Equal(t, hex.EncodeToString(lutil.ComputeStringHash("key1")), divergence.Key)
+	assert.Equal(t, "the private write of the key does not match the hashed write in the transaction", divergence.Description)
+
+	// no write has been removed
+	divergence = checkTrimmedPvtRwSet(collPvtRwSet(
+		&kvrwset.KVWrite{Key: "key1", Value: []byte("value1")},
+		&kvrwset.KVWrite{Key: "key2", Value: []byte("value2")},
+	), hashedRwSet)
+	require.NotNil(t, divergence)
+	assert.Equal(t, "the hash of the private data does not match the hash in the transaction", divergence.Description)
+}
@@ -36,6 +36,12 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from the snapshot in the directory snapshotDir. The ledger starts
+	// at the height of the snapshot and the next block to be committed is the block following the last block
+	// of the snapshot. The channel name recorded in the snapshot is treated as a ledger id.
+	// The snapshot is imported only if the hash of its metadata matches metadataHash, as printed by the peer
+	// that exported the snapshot
+	CreateFromSnapshot(snapshotDir string, metadataHash []byte) (PeerLedger, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -185,6 +191,23 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithMetadata retrieves the history of values for a key.
+	// metadata is a map of additional query parameters. The supported parameters are
+	// "startBlock" and "endBlock" (uint64) to bound the history by block number (both inclusive; an endBlock
+	// of zero denotes no bound), "startTime" and "endTime" (*timestamp.Timestamp) to bound the history by
+	// transaction timestamp (both inclusive), "newestFirst" (bool) to return the most recent modification first,
+	// and "limit" (int32) and "bookmark" (string) for pagination.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyWithMetadata(namespace string, key string, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetHistoryForPrivateDataHash retrieves the history of the hashed writes to a private data key, identified
+	// by the hash of the key, in a collection. The returned ResultsIterator contains results of type *KeyModification
+	// which is defined in protos/ledger/queryresult, with the value field holding the hash of the written value.
+	GetHistoryForPrivateDataHash(namespace, collection string, keyhash []byte) (commonledger.ResultsIterator, error)
+	// GetHistoryForPartialCompositeKey retrieves the history of values for all the composite keys that start with
+	// the given partial composite key, ordered by key and then by height.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult,
+	// with the key field holding the modified composite key.
+	GetHistoryForPartialCompositeKey(namespace string, partialCompositeKey string) (commonledger.ResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -209,6 +232,9 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData deletes the given tuple <namespace, collection, key> from private data and, on commit,
+	// removes the historical values of the key from the private data stores while retaining the hashes on the ledger
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -258,10 +284,13 @@ type TxMissingPvtDataMap map[uint64][]*MissingPvtData
 
 // BlockAndPvtData encapsulates the block and a map that contains the tuples <seqInBlock, *TxPvtData>
 // The map is expected to contain the entries only for the transactions that has associated pvt data
+// KeyPurges lists the private data keys purged by the transactions of the block. The purges of the transactions
+// that are found invalid while committing the block are disregarded
 type BlockAndPvtData struct {
 	Block          *common.Block
 	PvtData        TxPvtDataMap
 	MissingPvtData TxMissingPvtDataMap
+	KeyPurges      []*PvtdataKeyPurge
 }
 
 // BlockPvtData contains the private data for a block
@@ -447,6 +476,17 @@ func (NotFoundInIndexErr) Error() string {
 	return "Entry not found in index"
 }
 
+// PrunedErr is returned when the requested block or transaction is in a block
+// that has been pruned from the block store
+type PrunedErr struct {
+	// FirstBlockNum is the lowest block number retained by the block store
+	FirstBlockNum uint64
+}
+
+func (e *PrunedErr) Error() string {
+	return fmt.Sprintf("the requested data has been pruned; the lowest block retained is [%d]", e.FirstBlockNum)
+}
+
 // CollConfigNotDefinedError is returned whenever an operation
 // is requested on a collection whose config has not been defined
 type CollConfigNotDefinedError struct {
@@ -476,6 +516,16 @@ type PvtdataHashMismatch struct {
 	ExpectedHash          []byte
 }
 
+// PvtdataKeyPurge is used when a transaction purges a private data key.
+// The key is identified by its hash, as present in the block. The values of
+// the key committed before the purging transaction are to be removed from the
+// private data stores of the peer
+type PvtdataKeyPurge struct {
+	BlockNum, TxNum       uint64
+	Namespace, Collection string
+	KeyHash               []byte
+}
+
 // DeployedChaincodeInfoProvider is a dependency that is used by ledger to build collection config history
 // LSCC module is expected to provide an implementation fo this dependencys
 type DeployedChaincodeInfoProvider interface {
@@ -23,25 +23,41 @@ func IsCouchDBEnabled() bool {
 	return false
 }
 
+// GetStateDatabase returns the name of the configured state database, which defaults to goleveldb
+func GetStateDatabase() string {
+	stateDatabase := viper.GetString(confStateDatabase)
+	if stateDatabase == "" {
+		return defaultStateDatabase
+	}
+	return stateDatabase
+}
+
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateBulkleveldb = "stateBulkLeveldb"
 const confHistoryLeveldb = "historyLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
 const fileLockPath = "fileLock"
+const confStateDatabase = "ledger.state.stateDatabase"
+const defaultStateDatabase = "goleveldb"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confStateCacheSize = "ledger.state.couchDBConfig.cacheSize"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
+var confMaxBlockfileSize = &conf{"ledger.blockchain.maxBlockfileSize", 64 * 1024 * 1024}
+var confPruningRetainLastBlocks = &conf{"ledger.pruning.retainLastBlocks", 0}
+var confPruningInterval = &conf{"ledger.pruning.interval", 1000}
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -60,6 +76,11 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateBulkLevelDBPath returns the filesystem path that is used to maintain the state bulk level dbs
+func GetStateBulkLevelDBPath() string {
+	return filepath.Join(GetRootPath(), confStateBulkleveldb)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -90,9 +111,34 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
-// GetMaxBlockfileSize returns maximum size of the block file
+// GetMaxBlockfileSize returns maximum size of the block file, which is also the granularity
+// at which blocks are pruned
 func GetMaxBlockfileSize() int {
-	return 64 * 1024 * 1024
+	maxBlockfileSize := viper.GetInt(confMaxBlockfileSize.Name)
+	if maxBlockfileSize <= 0 {
+		maxBlockfileSize = confMaxBlockfileSize.DefaultVal
+	}
+	return maxBlockfileSize
+}
+
+// GetPruningRetainLastBlocks returns the number of most recent blocks that the block store of each
+// channel retains when it is pruned. Zero, the default, disables pruning
+func GetPruningRetainLastBlocks() uint64 {
+	retainLastBlocks := viper.GetInt(confPruningRetainLastBlocks.Name)
+	if retainLastBlocks <= 0 {
+		retainLastBlocks = confPruningRetainLastBlocks.DefaultVal
+	}
+	return uint64(retainLastBlocks)
+}
+
+// GetPruningInterval returns the interval in the terms of number of blocks
+// when the pruning of the block store would be performed
+func GetPruningInterval() uint64 {
+	pruningInterval := viper.GetInt(confPruningInterval.Name)
+	if pruningInterval <= 0 {
+		pruningInterval = confPruningInterval.DefaultVal
+	}
+	return uint64(pruningInterval)
 }
 
 // GetTotalQueryLimit exposes the totalLimit variable
@@ -193,6 +239,17 @@ func GetWarmIndexesAfterNBlocks() int {
 	return warmAfterNBlocks
 }
 
+//GetStateCacheSize exposes the cacheSize variable, i.e., the maximum number of entries
+//cached per namespace of a channel on top of CouchDB. A value of 0 disables the cache
+func GetStateCacheSize() int {
+	cacheSize := viper.GetInt(confStateCacheSize)
+	// if cacheSize was unset, default to 10000
+	if !viper.IsSet(confStateCacheSize) {
+		cacheSize = 10000
+	}
+	return cacheSize
+}
+
 type conf struct {
 	Name       string
 	DefaultVal int
@@ -47,11 +47,22 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	assert.True(t, updatedValue) //test config returns true
 }
 
+func TestGetStateDatabase(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, "goleveldb", GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "")
+	assert.Equal(t, "goleveldb", GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	assert.Equal(t, "CouchDB", GetStateDatabase())
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	assert.Equal(t, "/var/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateBulkLeveldb", GetStateBulkLevelDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -66,6 +77,7 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateBulkLeveldb", GetStateBulkLevelDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -247,8 +259,43 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	assert.Equal(t, 10, updatedValue)
 }
 
+func TestGetStateCacheSizeDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 10000, defaultValue)
+}
+
+func TestGetStateCacheSizeUnset(t *testing.T) {
+	viper.Reset()
+	defaultValue := GetStateCacheSize()
+	assert.Equal(t, 10000, defaultValue)
+}
+
+func TestGetStateCacheSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+	updatedValue := GetStateCacheSize()
+	assert.Equal(t, 0, updatedValue)
+}
+
 func TestGetMaxBlockfileSize(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1024)
+	assert.Equal(t, 1024, GetMaxBlockfileSize())
+}
+
+func TestGetPruningConfig(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, uint64(0), GetPruningRetainLastBlocks())
+	assert.Equal(t, uint64(1000), GetPruningInterval())
+	viper.Set("ledger.pruning.retainLastBlocks", 5000)
+	viper.Set("ledger.pruning.interval", 10)
+	assert.Equal(t, uint64(5000), GetPruningRetainLastBlocks())
+	assert.Equal(t, uint64(10), GetPruningInterval())
 }
 
 func setUpCoreYAMLConfig() {
@@ -110,6 +110,31 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot in the directory snapshotDir, as exported by
+// a peer that has joined the channel. The ledger continues from the block following the last block of the snapshot.
+// metadataHash is the hash of the snapshot metadata printed by the exporting peer
+func CreateLedgerFromSnapshot(snapshotDir string, metadataHash []byte) (ledger.PeerLedger, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	id, err := kvledger.SnapshotLedgerID(snapshotDir, metadataHash)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Creating ledger [%s] from snapshot [%s]", id, snapshotDir)
+	l, err := ledgerProvider.CreateFromSnapshot(snapshotDir, metadataHash)
+	if err != nil {
+		return nil, err
+	}
+	l = wrapLedger(id, l)
+	openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot", id)
+	return l, nil
+}
+
 // OpenLedger returns a ledger for the given id
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
@@ -15,6 +15,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/tradeline-tech/fabric/common/metrics"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	"github.com/tradeline-tech/fabric/core/ledger/pvtdatapolicy"
 	"github.com/tradeline-tech/fabric/core/ledger/pvtdatastorage"
@@ -93,6 +94,12 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// BootstrapFromSnapshot prepares a new block store whose lowest block is lastBlock from the snapshot in the
+// directory snapshotDir. The store is then opened with Open; the pvt data store starts empty at the height of lastBlock
+func (p *Provider) BootstrapFromSnapshot(ledgerid string, snapshotDir string, lastBlock *common.Block, lastConfigBlock *common.Block) error {
+	return p.blkStoreProvider.BootstrapFromSnapshot(ledgerid, snapshotDir, lastBlock, lastConfigBlock)
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -134,7 +141,8 @@ func (s *Store) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
 		// transaction to become valid, we store the pvtdata of invalid transactions
 		// too in the pvtdataStore as we do for the publicdata in the case of blockStore.
 		pvtData, missingPvtData := constructPvtDataAndMissingData(blockAndPvtdata)
-		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, pvtData, missingPvtData); err != nil {
+		purges := rwsetutil.PvtdataKeyPurgesOfValidTxs(blockAndPvtdata.Block, blockAndPvtdata.KeyPurges)
+		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, pvtData, missingPvtData, purges); err != nil {
 			return err
 		}
 		writtenToPvtStore = true
@@ -268,6 +276,16 @@ func (s *Store) ResetLastUpdatedOldBlocksList() error {
 	return s.pvtdataStore.ResetLastUpdatedOldBlocksList()
 }
 
+// PurgePvtDataBelow invokes the function `PurgeBelow` on underlying pvtdata store
+func (s *Store) PurgePvtDataBelow(minBlockNumToRetain uint64) error {
+	return s.pvtdataStore.PurgeBelow(minBlockNumToRetain)
+}
+
+// PrivateDataMinBlockNum invokes the function `MinRetainedBlockNum` on underlying pvtdata store
+func (s *Store) PrivateDataMinBlockNum() (uint64, error) {
+	return s.pvtdataStore.MinRetainedBlockNum()
+}
+
 // IsPvtStoreAheadOfBlockStore returns true when the pvtStore height is
 // greater than the blockstore height. Otherwise, it returns false.
 func (s *Store) IsPvtStoreAheadOfBlockStore() bool {
@@ -9,11 +9,14 @@ package pvtdatastorage
 import (
 	"math"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/willf/bitset"
 
 	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/ledger/pvtdatapolicy"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset/kvrwset"
 )
 
 func prepareStoreEntries(blockNum uint64, pvtData []*ledger.TxPvtData, btlPolicy pvtdatapolicy.BTLPolicy,
@@ -214,3 +217,92 @@ func (a *txPvtdataAssembler) getTxPvtdata() *ledger.TxPvtData {
 	a.done()
 	return &ledger.TxPvtData{SeqInBlock: a.txNum, WriteSet: a.txWset}
 }
+
+// prepareHashedIndexKeys returns the hashed index keys for the keys written in the given data entry
+func prepareHashedIndexKeys(dataEntry *dataEntry) []*hashedIndexKey {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(dataEntry.value.Rwset, kvRWSet); err != nil {
+		logger.Warningf("Not indexing the keys of the pvtData of block [%d], tx [%d], namespace [%s], collection [%s] as it could not be parsed: %s",
+			dataEntry.key.blkNum, dataEntry.key.txNum, dataEntry.key.ns, dataEntry.key.coll, err)
+		return nil
+	}
+	keys := make(map[string]struct{})
+	for _, kvWrite := range kvRWSet.Writes {
+		keys[kvWrite.Key] = struct{}{}
+	}
+	for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+		keys[kvMetadataWrite.Key] = struct{}{}
+	}
+	var hashedIndexKeys []*hashedIndexKey
+	for key := range keys {
+		hashedIndexKeys = append(hashedIndexKeys,
+			&hashedIndexKey{dataEntry.key.nsCollBlk, dataEntry.key.txNum, util.ComputeStringHash(key)},
+		)
+	}
+	return hashedIndexKeys
+}
+
+// removeKeyHashes returns a copy of the collection pvt data without the writes and the metadata writes of the keys
+// whose hashes are present in `keyHashes`. The input is returned as is if it does not contain any of these keys and
+// nil is returned if no write is left
+func removeKeyHashes(collPvtData *rwset.CollectionPvtReadWriteSet, keyHashes map[string]struct{}) (*rwset.CollectionPvtReadWriteSet, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtData.Rwset, kvRWSet); err != nil {
+		return nil, err
+	}
+	isPurged := func(key string) bool {
+		_, ok := keyHashes[string(util.ComputeStringHash(key))]
+		return ok
+	}
+
+	trimmedKVRWSet := &kvrwset.KVRWSet{Reads: kvRWSet.Reads, RangeQueriesInfo: kvRWSet.RangeQueriesInfo}
+	for _, kvWrite := range kvRWSet.Writes {
+		if !isPurged(kvWrite.Key) {
+			trimmedKVRWSet.Writes = append(trimmedKVRWSet.Writes, kvWrite)
+		}
+	}
+	for _, kvMetadataWrite := range kvRWSet.MetadataWrites {
+		if !isPurged(kvMetadataWrite.Key) {
+			trimmedKVRWSet.MetadataWrites = append(trimmedKVRWSet.MetadataWrites, kvMetadataWrite)
+		}
+	}
+	if len(trimmedKVRWSet.Writes) == len(kvRWSet.Writes) &&
+		len(trimmedKVRWSet.MetadataWrites) == len(kvRWSet.MetadataWrites) {
+		return collPvtData, nil
+	}
+	if len(trimmedKVRWSet.Writes) == 0 && len(trimmedKVRWSet.MetadataWrites) == 0 {
+		return nil, nil
+	}
+	rwsetBytes, err := proto.Marshal(trimmedKVRWSet)
+	if err != nil {
+		return nil, err
+	}
+	return &rwset.CollectionPvtReadWriteSet{CollectionName: collPvtData.CollectionName, Rwset: rwsetBytes}, nil
+}
+
+// removeKeysPurgedInSameBlock removes, from the data entries of a block, the keys that are purged by
+// a later transaction in the same block
+func removeKeysPurgedInSameBlock(dataEntries []*dataEntry, purges []*ledger.PvtdataKeyPurge) ([]*dataEntry, error) {
+	var remainingEntries []*dataEntry
+	for _, entry := range dataEntries {
+		keyHashes := make(map[string]struct{})
+		for _, purge := range purges {
+			if purge.Namespace == entry.key.ns && purge.Collection == entry.key.coll &&
+				purge.BlockNum == entry.key.blkNum && entry.key.txNum < purge.TxNum {
+				keyHashes[string(purge.KeyHash)] = struct{}{}
+			}
+		}
+		if len(keyHashes) == 0 {
+			remainingEntries = append(remainingEntries, entry)
+			continue
+		}
+		collPvtData, err := removeKeyHashes(entry.value, keyHashes)
+		if err != nil {
+			return nil, err
+		}
+		if collPvtData != nil {
+			remainingEntries = append(remainingEntries, &dataEntry{key: entry.key, value: collPvtData})
+		}
+	}
+	return remainingEntries, nil
+}
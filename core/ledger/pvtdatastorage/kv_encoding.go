@@ -28,6 +28,10 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	minRetainedBlkKey              = []byte{8}
+	hashedIndexKeyPrefix           = []byte{9}
+	purgeMarkerKeyPrefix           = []byte{10}
+	hashedIndexVersionKey          = []byte{11}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -39,6 +43,12 @@ func getDataKeysForRangeScanByBlockNum(blockNum uint64) (startKey, endKey []byte
 	return
 }
 
+func getDataKeysForRangeScanBelowBlockNum(blockNum uint64) (startKey, endKey []byte) {
+	startKey = append(pvtDataKeyPrefix, version.NewHeight(0, 0).ToBytes()...)
+	endKey = append(pvtDataKeyPrefix, version.NewHeight(blockNum, 0).ToBytes()...)
+	return
+}
+
 func getExpiryKeysForRangeScan(minBlkNum, maxBlkNum uint64) (startKey, endKey []byte) {
 	startKey = append(expiryKeyPrefix, version.NewHeight(minBlkNum, 0).ToBytes()...)
 	endKey = append(expiryKeyPrefix, version.NewHeight(maxBlkNum+1, 0).ToBytes()...)
@@ -54,6 +64,15 @@ func decodeLastCommittedBlockVal(blockNumBytes []byte) uint64 {
 	return s
 }
 
+func encodeMinRetainedBlockVal(blockNum uint64) []byte {
+	return proto.EncodeVarint(blockNum)
+}
+
+func decodeMinRetainedBlockVal(blockNumBytes []byte) uint64 {
+	s, _ := proto.DecodeVarint(blockNumBytes)
+	return s
+}
+
 func encodeDataKey(key *dataKey) []byte {
 	dataKeyBytes := append(pvtDataKeyPrefix, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
 	dataKeyBytes = append(dataKeyBytes, []byte(key.ns)...)
@@ -184,6 +203,20 @@ func createRangeScanKeysForEligibleMissingDataEntries(blkNum uint64) (startKey,
 	return startKey, endKey
 }
 
+func createRangeScanKeysForEligibleMissingDataEntriesBelow(blkNum uint64) (startKey, endKey []byte) {
+	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
+	endKey = ineligibleMissingDataKeyPrefix
+	return startKey, endKey
+}
+
+func createRangeScanKeysForAllIneligibleMissingData() (startKey, endKey []byte) {
+	return ineligibleMissingDataKeyPrefix, collElgKeyPrefix
+}
+
+func createRangeScanKeysForAllExpiryEntries() (startKey, endKey []byte) {
+	return expiryKeyPrefix, eligibleMissingDataKeyPrefix
+}
+
 func createRangeScanKeysForIneligibleMissingData(maxBlkNum uint64, ns, coll string) (startKey, endKey []byte) {
 	startKey = encodeMissingDataKey(
 		&missingDataKey{
@@ -216,3 +249,82 @@ func eligibleMissingdatakeyRange(blkNum uint64) (startKey, endKey []byte) {
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum-1)...)
 	return
 }
+
+func encodeHashedIndexKey(key *hashedIndexKey) []byte {
+	keyBytes := hashedIndexKeyPrefixFor(key.ns, key.coll, key.keyHash)
+	return append(keyBytes, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
+}
+
+func hashedIndexKeyPrefixFor(ns, coll string, keyHash []byte) []byte {
+	keyBytes := append(hashedIndexKeyPrefix, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	// the key hash is length prefixed, as it may contain nil bytes
+	keyBytes = append(keyBytes, proto.EncodeVarint(uint64(len(keyHash)))...)
+	return append(keyBytes, keyHash...)
+}
+
+func decodeHashedIndexKey(keyBytes []byte) (*hashedIndexKey, error) {
+	splittedKey := bytes.SplitN(keyBytes[1:], []byte{nilByte}, 3)
+	if len(splittedKey) != 3 {
+		return nil, errors.Errorf("unexpected format of hashed index key [%#v]", keyBytes)
+	}
+	remainingBytes := splittedKey[2]
+	keyHashLen, n := proto.DecodeVarint(remainingBytes)
+	if n == 0 || uint64(len(remainingBytes)) < uint64(n)+keyHashLen {
+		return nil, errors.Errorf("unexpected format of hashed index key [%#v]", keyBytes)
+	}
+	keyHash := remainingBytes[n : uint64(n)+keyHashLen]
+	height, _, err := version.NewHeightFromBytes(remainingBytes[uint64(n)+keyHashLen:])
+	if err != nil {
+		return nil, err
+	}
+	return &hashedIndexKey{
+		nsCollBlk: nsCollBlk{ns: string(splittedKey[0]), coll: string(splittedKey[1]), blkNum: height.BlockNum},
+		txNum:     height.TxNum,
+		keyHash:   keyHash,
+	}, nil
+}
+
+// getHashedIndexKeysForRangeScanBelowHeight returns the range that covers the hashed index entries of
+// the given key hash that were committed before the given height
+func getHashedIndexKeysForRangeScanBelowHeight(ns, coll string, keyHash []byte, blkNum, txNum uint64) (startKey, endKey []byte) {
+	startKey = hashedIndexKeyPrefixFor(ns, coll, keyHash)
+	endKey = append(hashedIndexKeyPrefixFor(ns, coll, keyHash), version.NewHeight(blkNum, txNum).ToBytes()...)
+	return
+}
+
+func createRangeScanKeysForAllHashedIndexEntries() (startKey, endKey []byte) {
+	return hashedIndexKeyPrefix, purgeMarkerKeyPrefix
+}
+
+func createRangeScanKeysForAllDataEntries() (startKey, endKey []byte) {
+	return pvtDataKeyPrefix, expiryKeyPrefix
+}
+
+func encodeHashedIndexVersionVal(indexVersion uint64) []byte {
+	return proto.EncodeVarint(indexVersion)
+}
+
+func decodeHashedIndexVersionVal(indexVersionBytes []byte) uint64 {
+	v, _ := proto.DecodeVarint(indexVersionBytes)
+	return v
+}
+
+func encodePurgeMarkerKey(ns, coll string, keyHash []byte) []byte {
+	keyBytes := append(purgeMarkerKeyPrefix, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	return append(keyBytes, keyHash...)
+}
+
+func encodePurgeMarkerValue(blkNum, txNum uint64) []byte {
+	return version.NewHeight(blkNum, txNum).ToBytes()
+}
+
+func decodePurgeMarkerValue(valueBytes []byte) (*version.Height, error) {
+	height, _, err := version.NewHeightFromBytes(valueBytes)
+	return height, err
+}
@@ -49,8 +49,12 @@ type Store interface {
 	// is expected to call `Commit` function. Return from this should ensure
 	// that enough preparation is done such that `Commit` function invoked afterwards can commit the
 	// data and the store is capable of surviving a crash between this function call and the next
-	// invoke to the `Commit`
-	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error
+	// invoke to the `Commit`.
+	// The parameter `purges` lists the private data keys purged by the valid transactions of the block. The values
+	// of these keys committed before the purging transactions are removed from the store and, thereafter, are not
+	// accepted via the function `CommitPvtDataOfOldBlocks`
+	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap,
+		purges []*ledger.PvtdataKeyPurge) error
 	// Commit commits the pvt data passed in the previous invoke to the `Prepare` function
 	Commit() error
 	// ProcessCollsEligibilityEnabled notifies the store when the peer becomes eligible to recieve data for an
@@ -69,6 +73,13 @@ type Store interface {
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
 	ResetLastUpdatedOldBlocksList() error
+	// PurgeBelow permanently removes the pvt data and the missing pvt data information of the blocks
+	// below `minBlockNumToRetain`, irrespective of the BTL policy. Afterwards, the pvt data of these blocks
+	// is neither returned by this store nor accepted via the function `CommitPvtDataOfOldBlocks`
+	PurgeBelow(minBlockNumToRetain uint64) error
+	// MinRetainedBlockNum returns the lowest block number for which the pvt data is retained.
+	// This is 0 if the function `PurgeBelow` has never been invoked
+	MinRetainedBlockNum() (uint64, error)
 	// IsEmpty returns true if the store does not have any block committed yet
 	IsEmpty() (bool, error)
 	// LastCommittedBlockHeight returns the height of the last committed block
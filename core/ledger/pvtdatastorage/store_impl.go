@@ -19,6 +19,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/flogging"
 	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	"github.com/tradeline-tech/fabric/core/ledger/pvtdatapolicy"
 	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
@@ -26,6 +27,14 @@ import (
 
 var logger = flogging.MustGetLogger("pvtdatastorage")
 
+// maxPurgeBatchSize is the maximum number of deletes written in a single db batch by `PurgeBelow`
+const maxPurgeBatchSize = 5000
+
+// hashedIndexVersion is the version of the hashed index of the private data keys, which the key purges rely on.
+// It is recorded in the store once the index covers all the data entries, including the ones committed
+// before the store maintained the index
+const hashedIndexVersion = 1
+
 type provider struct {
 	dbProvider *leveldbhelper.Provider
 }
@@ -37,6 +46,7 @@ type store struct {
 
 	isEmpty            bool
 	lastCommittedBlock uint64
+	minRetainedBlock   uint64
 	batchPending       bool
 	purgerLock         sync.Mutex
 	collElgProcSync    *collElgProcSync
@@ -79,6 +89,12 @@ type dataKey struct {
 	txNum uint64
 }
 
+type hashedIndexKey struct {
+	nsCollBlk
+	txNum   uint64
+	keyHash []byte
+}
+
 type missingDataKey struct {
 	nsCollBlk
 	isEligible bool
@@ -125,6 +141,9 @@ func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	if err := s.initState(); err != nil {
 		return nil, err
 	}
+	if err := s.buildHashedIndex(); err != nil {
+		return nil, err
+	}
 	s.launchCollElgProc()
 	logger.Debugf("Pvtdata store opened. Initial state: isEmpty [%t], lastCommittedBlock [%d], batchPending [%t]",
 		s.isEmpty, s.lastCommittedBlock, s.batchPending)
@@ -148,6 +167,9 @@ func (s *store) initState() error {
 	if s.batchPending, err = s.hasPendingCommit(); err != nil {
 		return err
 	}
+	if s.minRetainedBlock, err = s.getMinRetainedBlockNum(); err != nil {
+		return err
+	}
 	if blist, err = s.getLastUpdatedOldBlocksList(); err != nil {
 		return err
 	}
@@ -163,7 +185,8 @@ func (s *store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 }
 
 // Prepare implements the function in the interface `Store`
-func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error {
+func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap,
+	purges []*ledger.PvtdataKeyPurge) error {
 	if s.batchPending {
 		return &ErrIllegalCall{`A pending batch exists as as result of last invoke to "Prepare" call.
 			 Invoke "Commit" on the pending batch before invoking "Prepare" function`}
@@ -182,12 +205,22 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvt
 		return err
 	}
 
+	if len(purges) > 0 {
+		// the purger lock prevents a concurrent removal of the expired entries that are rewritten by the purges
+		s.purgerLock.Lock()
+		defer s.purgerLock.Unlock()
+		if storeEntries.dataEntries, err = removeKeysPurgedInSameBlock(storeEntries.dataEntries, purges); err != nil {
+			return err
+		}
+	}
+
 	for _, dataEntry := range storeEntries.dataEntries {
 		keyBytes = encodeDataKey(dataEntry.key)
 		if valBytes, err = encodeDataValue(dataEntry.value); err != nil {
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		addHashedIndexEntriesToBatch(batch, dataEntry)
 	}
 
 	for _, expiryEntry := range storeEntries.expiryEntries {
@@ -206,6 +239,10 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvt
 		batch.Put(keyBytes, valBytes)
 	}
 
+	if err := s.addKeyPurgesToBatch(batch, purges); err != nil {
+		return err
+	}
+
 	batch.Put(pendingCommitKey, emptyValue)
 	if err := s.db.WriteBatch(batch, true); err != nil {
 		return err
@@ -251,8 +288,20 @@ func (s *store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 		stateDB may not be in sync with the pvtStore`}
 	}
 
+	// the pvt data of the blocks purged via `PurgeBelow` is not accepted any longer
+	minRetainedBlock := atomic.LoadUint64(&s.minRetainedBlock)
+	for blkNum := range blocksPvtData {
+		if blkNum < minRetainedBlock {
+			logger.Debugf("Ignoring the pvtData of block [%d] as the pvtData below block [%d] has been purged", blkNum, minRetainedBlock)
+			delete(blocksPvtData, blkNum)
+		}
+	}
+
 	// (1) construct dataEntries for all pvtData
-	dataEntries := constructDataEntriesFromBlocksPvtData(blocksPvtData)
+	dataEntries, err := s.removePurgedKeys(constructDataEntriesFromBlocksPvtData(blocksPvtData))
+	if err != nil {
+		return err
+	}
 
 	// (2) construct update entries (i.e., dataEntries, expiryEntries, missingDataEntries) from the above created data entries
 	logger.Debugf("Constructing pvtdatastore entries for pvtData of [%d] old blocks", len(blocksPvtData))
@@ -435,11 +484,13 @@ func addNewDataEntriesToUpdateBatch(batch *leveldbhelper.UpdateBatch, entries *e
 	var keyBytes, valBytes []byte
 	var err error
 	for dataKey, pvtData := range entries.dataEntries {
+		dataKey := dataKey
 		keyBytes = encodeDataKey(&dataKey)
 		if valBytes, err = encodeDataValue(pvtData); err != nil {
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		addHashedIndexEntriesToBatch(batch, &dataEntry{key: &dataKey, value: pvtData})
 	}
 	return nil
 }
@@ -583,6 +634,10 @@ func (s *store) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFil
 	if blockNum > lastCommittedBlock {
 		return nil, &ErrOutOfRange{fmt.Sprintf("Last committed block=%d, block requested=%d", lastCommittedBlock, blockNum)}
 	}
+	if blockNum < atomic.LoadUint64(&s.minRetainedBlock) {
+		logger.Debugf("The private data of block [%d] has been purged", blockNum)
+		return nil, nil
+	}
 	startKey, endKey := getDataKeysForRangeScanByBlockNum(blockNum)
 	logger.Debugf("Querying private data storage for write sets using startKey=%#v, endKey=%#v", startKey, endKey)
 	itr := s.db.GetIterator(startKey, endKey)
@@ -670,6 +725,7 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	// construct the MissingPvtDataInfo. As a result, lastCommittedBlock can get
 	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
 	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	minRetainedBlock := atomic.LoadUint64(&s.minRetainedBlock)
 
 	startKey, endKey := createRangeScanKeysForEligibleMissingDataEntries(lastCommittedBlock)
 	dbItr := s.db.GetIterator(startKey, endKey)
@@ -679,6 +735,12 @@ func (s *store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 		missingDataKeyBytes := dbItr.Key()
 		missingDataKey := decodeMissingDataKey(missingDataKeyBytes)
 
+		if missingDataKey.blkNum < minRetainedBlock {
+			// the entries are in the descending order of block numbers and the
+			// remaining entries belong to the purged blocks
+			break
+		}
+
 		if isMaxBlockLimitReached && (missingDataKey.blkNum != lastProcessedBlock) {
 			// esnures that exactly maxBlock number
 			// of blocks' entries are processed
@@ -776,6 +838,9 @@ func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		batch.Delete(encodeExpiryKey(expiryEntry.key))
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 		for _, dataKey := range dataKeys {
+			if err := s.addHashedIndexEntriesDeletionToBatch(batch, dataKey); err != nil {
+				return err
+			}
 			batch.Delete(encodeDataKey(dataKey))
 		}
 		for _, missingDataKey := range missingDataKeys {
@@ -810,6 +875,315 @@ func (s *store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEnt
 	return expiryEntries, nil
 }
 
+// PurgeBelow implements the function in the interface `Store`.
+// The minimum retained block number is persisted before removing any entry. Hence, if the peer crashes
+// in between, the leftover entries are not exposed and get removed by the next invocation of this function
+// as the entries are always removed starting from block 0
+func (s *store) PurgeBelow(minBlockNumToRetain uint64) error {
+	lastCommittedBlockHeight, err := s.LastCommittedBlockHeight()
+	if err != nil {
+		return err
+	}
+	if minBlockNumToRetain > lastCommittedBlockHeight {
+		return &ErrIllegalArgs{fmt.Sprintf("Cannot purge the private data below block [%d] as the last committed block height is [%d]",
+			minBlockNumToRetain, lastCommittedBlockHeight)}
+	}
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	if minBlockNumToRetain < atomic.LoadUint64(&s.minRetainedBlock) {
+		return &ErrIllegalArgs{fmt.Sprintf("The private data below block [%d] has already been purged",
+			atomic.LoadUint64(&s.minRetainedBlock))}
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(minRetainedBlkKey, encodeMinRetainedBlockVal(minBlockNumToRetain))
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&s.minRetainedBlock, minBlockNumToRetain)
+	if minBlockNumToRetain == 0 {
+		return nil
+	}
+
+	numEntriesPurged, err := s.deleteEntriesBelow(minBlockNumToRetain)
+	if err != nil {
+		return err
+	}
+	logger.Infof("[%s] - [%d] Entries purged from private data storage below block number [%d]", s.ledgerid, numEntriesPurged, minBlockNumToRetain)
+	return nil
+}
+
+// deleteEntriesBelow removes the pvt data, the expiry, and the missing data entries of the blocks below the given block number
+func (s *store) deleteEntriesBelow(blkNum uint64) (int, error) {
+	batch := leveldbhelper.NewUpdateBatch()
+	numEntriesDeleted := 0
+	deleteKey := func(key []byte) error {
+		batch.Delete(append([]byte(nil), key...))
+		numEntriesDeleted++
+		if batch.Len() < maxPurgeBatchSize {
+			return nil
+		}
+		if err := s.db.WriteBatch(batch, false); err != nil {
+			return err
+		}
+		batch = leveldbhelper.NewUpdateBatch()
+		return nil
+	}
+
+	startKey, endKey := getDataKeysForRangeScanBelowBlockNum(blkNum)
+	if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+		return deleteKey(key)
+	}); err != nil {
+		return 0, err
+	}
+
+	startKey, endKey = createRangeScanKeysForAllHashedIndexEntries()
+	if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+		hashedIndexKey, err := decodeHashedIndexKey(key)
+		if err != nil {
+			return err
+		}
+		if hashedIndexKey.blkNum >= blkNum {
+			return nil
+		}
+		return deleteKey(key)
+	}); err != nil {
+		return 0, err
+	}
+
+	startKey, endKey = createRangeScanKeysForAllExpiryEntries()
+	if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+		expiryKey, err := decodeExpiryKey(key)
+		if err != nil {
+			return err
+		}
+		if expiryKey.committingBlk >= blkNum {
+			return nil
+		}
+		return deleteKey(key)
+	}); err != nil {
+		return 0, err
+	}
+
+	startKey, endKey = createRangeScanKeysForEligibleMissingDataEntriesBelow(blkNum)
+	if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+		return deleteKey(key)
+	}); err != nil {
+		return 0, err
+	}
+
+	startKey, endKey = createRangeScanKeysForAllIneligibleMissingData()
+	if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+		if decodeMissingDataKey(key).blkNum >= blkNum {
+			return nil
+		}
+		return deleteKey(key)
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return 0, err
+	}
+	return numEntriesDeleted, nil
+}
+
+// addKeyPurgesToBatch adds to the batch the removal of the values of the purged keys that were committed
+// before the purging transactions along with the purge markers, which are used for rejecting these values if
+// received later via the function `CommitPvtDataOfOldBlocks`
+func (s *store) addKeyPurgesToBatch(batch *leveldbhelper.UpdateBatch, purges []*ledger.PvtdataKeyPurge) error {
+	updatedDataEntries := make(map[dataKey]*rwset.CollectionPvtReadWriteSet)
+	for _, purge := range purges {
+		var hashedIndexKeys []*hashedIndexKey
+		startKey, endKey := getHashedIndexKeysForRangeScanBelowHeight(purge.Namespace, purge.Collection, purge.KeyHash, purge.BlockNum, purge.TxNum)
+		if err := s.forEachKey(startKey, endKey, func(key []byte) error {
+			hashedIndexKey, err := decodeHashedIndexKey(key)
+			if err != nil {
+				return err
+			}
+			hashedIndexKeys = append(hashedIndexKeys, hashedIndexKey)
+			batch.Delete(append([]byte(nil), key...))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, hashedIndexKey := range hashedIndexKeys {
+			dataKey := dataKey{hashedIndexKey.nsCollBlk, hashedIndexKey.txNum}
+			collPvtData, ok := updatedDataEntries[dataKey]
+			if !ok {
+				var err error
+				if collPvtData, err = s.getCollPvtData(&dataKey); err != nil {
+					return err
+				}
+			}
+			if collPvtData == nil {
+				// already expired or purged
+				continue
+			}
+			trimmedCollPvtData, err := removeKeyHashes(collPvtData, map[string]struct{}{string(purge.KeyHash): {}})
+			if err != nil {
+				return err
+			}
+			updatedDataEntries[dataKey] = trimmedCollPvtData
+		}
+		batch.Put(encodePurgeMarkerKey(purge.Namespace, purge.Collection, purge.KeyHash),
+			encodePurgeMarkerValue(purge.BlockNum, purge.TxNum))
+	}
+
+	for dataKey, collPvtData := range updatedDataEntries {
+		dataKey := dataKey
+		if collPvtData == nil {
+			batch.Delete(encodeDataKey(&dataKey))
+			continue
+		}
+		valBytes, err := encodeDataValue(collPvtData)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeDataKey(&dataKey), valBytes)
+	}
+	logger.Debugf("[%s] - Purged [%d] keys from [%d] private data entries", s.ledgerid, len(purges), len(updatedDataEntries))
+	return nil
+}
+
+// removePurgedKeys removes from the data entries the values of the keys that were purged by a later transaction
+func (s *store) removePurgedKeys(dataEntries []*dataEntry) ([]*dataEntry, error) {
+	var remainingEntries []*dataEntry
+	for _, entry := range dataEntries {
+		hashedIndexKeys := prepareHashedIndexKeys(entry)
+		keyHashes := make(map[string]struct{})
+		for _, hashedIndexKey := range hashedIndexKeys {
+			purgeHeight, err := s.getPurgeMarker(entry.key.ns, entry.key.coll, hashedIndexKey.keyHash)
+			if err != nil {
+				return nil, err
+			}
+			if purgeHeight != nil && purgeHeight.Compare(version.NewHeight(entry.key.blkNum, entry.key.txNum)) > 0 {
+				keyHashes[string(hashedIndexKey.keyHash)] = struct{}{}
+			}
+		}
+		if len(keyHashes) == 0 {
+			remainingEntries = append(remainingEntries, entry)
+			continue
+		}
+		logger.Debugf("Ignoring the values of [%d] purged keys in the pvtData of block [%d], tx [%d], namespace [%s], collection [%s]",
+			len(keyHashes), entry.key.blkNum, entry.key.txNum, entry.key.ns, entry.key.coll)
+		collPvtData, err := removeKeyHashes(entry.value, keyHashes)
+		if err != nil {
+			return nil, err
+		}
+		if collPvtData != nil {
+			remainingEntries = append(remainingEntries, &dataEntry{key: entry.key, value: collPvtData})
+		}
+	}
+	return remainingEntries, nil
+}
+
+func (s *store) addHashedIndexEntriesDeletionToBatch(batch *leveldbhelper.UpdateBatch, dataKey *dataKey) error {
+	collPvtData, err := s.getCollPvtData(dataKey)
+	if err != nil || collPvtData == nil {
+		return err
+	}
+	for _, hashedIndexKey := range prepareHashedIndexKeys(&dataEntry{key: dataKey, value: collPvtData}) {
+		batch.Delete(encodeHashedIndexKey(hashedIndexKey))
+	}
+	return nil
+}
+
+// buildHashedIndex adds to the hashed index the keys of the data entries committed before the store
+// maintained the index, so that the keys purged later are removed from these entries as well.
+// The index is built once, when the store is opened; afterwards it is maintained as the data entries are committed
+func (s *store) buildHashedIndex() error {
+	v, err := s.db.Get(hashedIndexVersionKey)
+	if err != nil {
+		return err
+	}
+	if v != nil && decodeHashedIndexVersionVal(v) >= hashedIndexVersion {
+		return nil
+	}
+
+	logger.Infof("[%s] Building the hashed index of the private data keys", s.ledgerid)
+	startKey, endKey := createRangeScanKeysForAllDataEntries()
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	numEntries := 0
+	for itr.Next() {
+		dataKeyBytes := itr.Key()
+		v11Fmt, err := v11Format(dataKeyBytes)
+		if err != nil {
+			return err
+		}
+		if v11Fmt {
+			// a v1.1 entry holds the whole private write set of a transaction rather than the keys of a collection
+			continue
+		}
+		dataKey, err := decodeDatakey(dataKeyBytes)
+		if err != nil {
+			return err
+		}
+		collPvtData, err := decodeDataValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		addHashedIndexEntriesToBatch(batch, &dataEntry{key: dataKey, value: collPvtData})
+		numEntries++
+		if batch.Len() >= maxPurgeBatchSize {
+			if err := s.db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	batch.Put(hashedIndexVersionKey, encodeHashedIndexVersionVal(hashedIndexVersion))
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Infof("[%s] Indexed the keys of [%d] private data entries", s.ledgerid, numEntries)
+	return nil
+}
+
+func addHashedIndexEntriesToBatch(batch *leveldbhelper.UpdateBatch, dataEntry *dataEntry) {
+	for _, hashedIndexKey := range prepareHashedIndexKeys(dataEntry) {
+		batch.Put(encodeHashedIndexKey(hashedIndexKey), emptyValue)
+	}
+}
+
+func (s *store) getCollPvtData(dataKey *dataKey) (*rwset.CollectionPvtReadWriteSet, error) {
+	v, err := s.db.Get(encodeDataKey(dataKey))
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return decodeDataValue(v)
+}
+
+func (s *store) getPurgeMarker(ns, coll string, keyHash []byte) (*version.Height, error) {
+	v, err := s.db.Get(encodePurgeMarkerKey(ns, coll, keyHash))
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return decodePurgeMarkerValue(v)
+}
+
+func (s *store) forEachKey(startKey, endKey []byte, f func(key []byte) error) error {
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		if err := f(itr.Key()); err != nil {
+			return err
+		}
+	}
+	return itr.Error()
+}
+
+// MinRetainedBlockNum implements the function in the interface `Store`
+func (s *store) MinRetainedBlockNum() (uint64, error) {
+	return atomic.LoadUint64(&s.minRetainedBlock), nil
+}
+
 func (s *store) launchCollElgProc() {
 	maxBatchSize := ledgerconfig.GetPvtdataStoreCollElgProcMaxDbBatchSize()
 	batchesInterval := ledgerconfig.GetPvtdataStoreCollElgProcDbBatchesInterval()
@@ -932,6 +1306,15 @@ func (s *store) getLastCommittedBlockNum() (bool, uint64, error) {
 	return false, decodeLastCommittedBlockVal(v), nil
 }
 
+func (s *store) getMinRetainedBlockNum() (uint64, error) {
+	var v []byte
+	var err error
+	if v, err = s.db.Get(minRetainedBlkKey); v == nil || err != nil {
+		return 0, err
+	}
+	return decodeMinRetainedBlockVal(v), nil
+}
+
 type collElgProcSync struct {
 	notification, procComplete chan bool
 }
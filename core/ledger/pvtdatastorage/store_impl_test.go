@@ -18,10 +18,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
 	"github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	btltestutil "github.com/tradeline-tech/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/tradeline-tech/fabric/core/ledger/util"
 )
 
 func TestMain(m *testing.M) {
@@ -84,11 +86,11 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data with block 1 - commit
-	assert.NoError(store.Prepare(1, testData, blk1MissingData))
+	assert.NoError(store.Prepare(1, testData, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data retrieval for block 0 should return nil
@@ -126,7 +128,7 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	assert.Nil(retrievedData)
 
 	// pvt data with block 2 - commit
-	assert.NoError(store.Prepare(2, testData, blk2MissingData))
+	assert.NoError(store.Prepare(2, testData, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// retrieve the stored missing entries using GetMissingPvtDataInfoForMostRecentBlocks
@@ -208,15 +210,15 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// COMMIT BLOCK 0 WITH NO DATA
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// COMMIT BLOCK 1 WITH PVTDATA AND MISSINGDATA
-	assert.NoError(store.Prepare(1, testData, blk1MissingData))
+	assert.NoError(store.Prepare(1, testData, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// COMMIT BLOCK 2 WITH PVTDATA AND MISSINGDATA
-	assert.NoError(store.Prepare(2, nil, blk2MissingData))
+	assert.NoError(store.Prepare(2, nil, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// CHECK MISSINGDATA ENTRIES ARE CORRECTLY STORED
@@ -319,7 +321,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	assert.Nil(blksPvtData)
 
 	// COMMIT BLOCK 3 WITH NO PVTDATA
-	assert.NoError(store.Prepare(3, nil, nil))
+	assert.NoError(store.Prepare(3, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// IN BLOCK 1, NS-1:COLL-2 AND NS-2:COLL-2 SHOULD HAVE EXPIRED BUT NOT PURGED
@@ -351,7 +353,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	assert.NoError(err)
 
 	// COMMIT BLOCK 4 WITH NO PVTDATA
-	assert.NoError(store.Prepare(4, nil, nil))
+	assert.NoError(store.Prepare(4, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	testWaitForPurgerRoutineToFinish(store)
@@ -413,7 +415,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	blk2MissingData.Add(1, "ns-1", "coll-2", true)
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 1
@@ -421,7 +423,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 2
@@ -429,7 +431,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData))
+	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
@@ -454,7 +456,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 3 with no pvtdata
-	assert.NoError(store.Prepare(3, nil, nil))
+	assert.NoError(store.Prepare(3, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
@@ -478,7 +480,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 4 with no pvtdata
-	assert.NoError(store.Prepare(4, nil, nil))
+	assert.NoError(store.Prepare(4, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
@@ -530,7 +532,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Prepare(0, nil, nil, nil))
 	assert.NoError(s.Commit())
 
 	// construct missing data for block 1
@@ -547,11 +549,11 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(s.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(s.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(s.Commit())
 
 	// write pvt data for block 2
-	assert.NoError(s.Prepare(2, nil, nil))
+	assert.NoError(s.Prepare(2, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	ns1Coll1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}
@@ -576,7 +578,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 3
-	assert.NoError(s.Prepare(3, nil, nil))
+	assert.NoError(s.Prepare(3, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
@@ -590,7 +592,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 4
-	assert.NoError(s.Prepare(4, nil, nil))
+	assert.NoError(s.Prepare(4, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4)
 	// but ns-2:coll-2 should exist because it expires at block 5
@@ -605,7 +607,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 5
-	assert.NoError(s.Prepare(5, nil, nil))
+	assert.NoError(s.Prepare(5, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
@@ -613,7 +615,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, ns2Coll2))
 
 	// write pvt data for block 6
-	assert.NoError(s.Prepare(6, nil, nil))
+	assert.NoError(s.Prepare(6, nil, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
@@ -624,6 +626,229 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: 1}, txNum: 2}))
 }
 
+func TestStorePurgeBelow(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 10,
+			{"ns-2", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeBelow", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	minRetainedBlock, err := s.MinRetainedBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(0), minRetainedBlock)
+
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+	for blkNum := uint64(1); blkNum <= 3; blkNum++ {
+		missingData := make(ledger.TxMissingPvtDataMap)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		missingData.Add(4, "ns-2", "coll-1", false)
+		testData := []*ledger.TxPvtData{
+			produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2"}),
+		}
+		assert.NoError(s.Prepare(blkNum, testData, missingData, nil))
+		assert.NoError(s.Commit())
+	}
+
+	// cannot purge beyond the last committed block height
+	err = s.PurgeBelow(5)
+	assert.IsType(&ErrIllegalArgs{}, err)
+	assert.NoError(s.PurgeBelow(3))
+
+	for blkNum := uint64(1); blkNum <= 3; blkNum++ {
+		expectedToExist := blkNum >= 3
+		assert.Equal(expectedToExist, testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: blkNum}, txNum: 2}))
+		assert.Equal(expectedToExist, testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: blkNum}, txNum: 2}))
+		assert.Equal(expectedToExist, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: blkNum}, isEligible: true}))
+		assert.Equal(expectedToExist, testMissingDataKeyExists(t, s, &missingDataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-1", blkNum: blkNum}, isEligible: false}))
+	}
+	expiryEntries, err := s.(*store).retrieveExpiryEntries(0, 100)
+	assert.NoError(err)
+	assert.Len(expiryEntries, 1)
+	assert.Equal(uint64(3), expiryEntries[0].key.committingBlk)
+
+	// the pvt data and the missing data info of the purged blocks is not returned
+	pvtData, err := s.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Nil(pvtData)
+	pvtData, err = s.GetPvtDataByBlockNum(3, nil)
+	assert.NoError(err)
+	assert.Len(pvtData, 1)
+	missingPvtDataInfo, err := s.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	expectedMissingPvtDataInfo.Add(3, 1, "ns-1", "coll-1")
+	assert.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
+
+	// the pvt data of the purged blocks is not accepted any longer
+	assert.NoError(s.CommitPvtDataOfOldBlocks(map[uint64][]*ledger.TxPvtData{
+		1: {produceSamplePvtdata(t, 1, []string{"ns-1:coll-1"})},
+	}))
+	assert.NoError(s.ResetLastUpdatedOldBlocksList())
+	assert.False(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 1}))
+
+	// the purge cannot be undone
+	err = s.PurgeBelow(2)
+	assert.IsType(&ErrIllegalArgs{}, err)
+	assert.NoError(s.PurgeBelow(3))
+
+	env.CloseAndReopen()
+	s = env.TestStore
+	minRetainedBlock, err = s.MinRetainedBlockNum()
+	assert.NoError(err)
+	assert.Equal(uint64(3), minRetainedBlock)
+}
+
+func TestStorePurgeKeys(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeKeys", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+	key1Hash := util.ComputeStringHash("key1")
+
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(3, "ns-1", "coll-1", true)
+	assert.NoError(s.Prepare(1,
+		[]*ledger.TxPvtData{
+			produceSamplePvtdataWithKeys(t, 1, "ns-1", "coll-1", "key1", "value1-1", "key2", "value2-1"),
+			produceSamplePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1", "value1-2"),
+		},
+		blk1MissingData, nil,
+	))
+	assert.NoError(s.Commit())
+
+	// the key1 is purged by tx 1 in block 2
+	purgeTxPvtData := produceSamplePvtdataWithKeys(t, 1, "ns-1", "coll-1")
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSetForPurge("ns-1", "coll-1", "key1")
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(err)
+	purgeTxPvtData.WriteSet = simRes.PvtSimulationResults
+	assert.NoError(s.Prepare(2,
+		[]*ledger.TxPvtData{
+			produceSamplePvtdataWithKeys(t, 0, "ns-1", "coll-1", "key1", "value1-3"),
+			purgeTxPvtData,
+			produceSamplePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1", "value1-4"),
+		},
+		nil,
+		[]*ledger.PvtdataKeyPurge{
+			{BlockNum: 2, TxNum: 1, Namespace: "ns-1", Collection: "coll-1", KeyHash: key1Hash},
+		},
+	))
+	assert.NoError(s.Commit())
+
+	// the values of key1 committed before the purging transaction are removed
+	assert.Equal(
+		map[uint64]map[string]string{1: {"key2": "value2-1"}},
+		testRetrieveKVs(t, s, 1),
+	)
+	assert.Equal(
+		map[uint64]map[string]string{1: {"key1": ""}, 2: {"key1": "value1-4"}},
+		testRetrieveKVs(t, s, 2),
+	)
+	assert.False(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}))
+	startKey, endKey := getHashedIndexKeysForRangeScanBelowHeight("ns-1", "coll-1", key1Hash, 2, 1)
+	assert.Equal(0, testCountKeys(t, s, startKey, endKey))
+
+	// the values of key1 committed before the purging transaction are not accepted via reconciliation
+	assert.NoError(s.CommitPvtDataOfOldBlocks(map[uint64][]*ledger.TxPvtData{
+		1: {produceSamplePvtdataWithKeys(t, 3, "ns-1", "coll-1", "key1", "value1-5", "key3", "value3-1")},
+	}))
+	assert.NoError(s.ResetLastUpdatedOldBlocksList())
+	assert.Equal(
+		map[uint64]map[string]string{1: {"key2": "value2-1"}, 3: {"key3": "value3-1"}},
+		testRetrieveKVs(t, s, 1),
+	)
+
+	// the hashed index entries are removed along with the data entries of the blocks purged via PurgeBelow
+	startKey, endKey = createRangeScanKeysForAllHashedIndexEntries()
+	assert.Equal(4, testCountKeys(t, s, startKey, endKey))
+	assert.NoError(s.PurgeBelow(2))
+	assert.Equal(2, testCountKeys(t, s, startKey, endKey))
+}
+
+func TestStorePurgeKeysCommittedBeforeHashedIndex(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestStorePurgeKeysCommittedBeforeHashedIndex", btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+	key1Hash := util.ComputeStringHash("key1")
+
+	assert.NoError(s.Prepare(0, nil, nil, nil))
+	assert.NoError(s.Commit())
+	assert.NoError(s.Prepare(1,
+		[]*ledger.TxPvtData{
+			produceSamplePvtdataWithKeys(t, 1, "ns-1", "coll-1", "key1", "value1-1", "key2", "value2-1"),
+			produceSamplePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1", "value1-2"),
+		},
+		nil, nil,
+	))
+	assert.NoError(s.Commit())
+
+	// turn the store into one written before the hashed index was maintained
+	startKey, endKey := createRangeScanKeysForAllHashedIndexEntries()
+	batch := leveldbhelper.NewUpdateBatch()
+	assert.NoError(s.(*store).forEachKey(startKey, endKey, func(key []byte) error {
+		batch.Delete(append([]byte(nil), key...))
+		return nil
+	}))
+	batch.Delete(hashedIndexVersionKey)
+	assert.NoError(s.(*store).db.WriteBatch(batch, true))
+	assert.Equal(0, testCountKeys(t, s, startKey, endKey))
+
+	// the hashed index is built when the store is opened
+	env.CloseAndReopen()
+	s = env.TestStore
+	assert.Equal(3, testCountKeys(t, s, startKey, endKey))
+
+	purgeTxPvtData := produceSamplePvtdataWithKeys(t, 0, "ns-1", "coll-1")
+	builder := rwsetutil.NewRWSetBuilder()
+	builder.AddToPvtAndHashedWriteSetForPurge("ns-1", "coll-1", "key1")
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(err)
+	purgeTxPvtData.WriteSet = simRes.PvtSimulationResults
+	assert.NoError(s.Prepare(2,
+		[]*ledger.TxPvtData{purgeTxPvtData},
+		nil,
+		[]*ledger.PvtdataKeyPurge{
+			{BlockNum: 2, TxNum: 0, Namespace: "ns-1", Collection: "coll-1", KeyHash: key1Hash},
+		},
+	))
+	assert.NoError(s.Commit())
+
+	// the values of key1 committed before the hashed index existed are removed
+	assert.Equal(
+		map[uint64]map[string]string{1: {"key2": "value2-1"}},
+		testRetrieveKVs(t, s, 1),
+	)
+
+	// the hashed index is not built again once the store records its version
+	env.CloseAndReopen()
+	s = env.TestStore
+	v, err := s.(*store).db.Get(hashedIndexVersionKey)
+	assert.NoError(err)
+	assert.Equal(uint64(hashedIndexVersion), decodeHashedIndexVersionVal(v))
+}
+
 func TestStoreState(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -638,14 +863,14 @@ func TestStoreState(t *testing.T) {
 	testData := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
 	}
-	_, ok := store.Prepare(1, testData, nil).(*ErrIllegalArgs)
+	_, ok := store.Prepare(1, testData, nil, nil).(*ErrIllegalArgs)
 	assert.True(ok)
 
-	assert.Nil(store.Prepare(0, testData, nil))
+	assert.Nil(store.Prepare(0, testData, nil, nil))
 	assert.NoError(store.Commit())
 
-	assert.Nil(store.Prepare(1, testData, nil))
-	_, ok = store.Prepare(2, testData, nil).(*ErrIllegalCall)
+	assert.Nil(store.Prepare(1, testData, nil, nil))
+	_, ok = store.Prepare(2, testData, nil, nil).(*ErrIllegalCall)
 	assert.True(ok)
 }
 
@@ -702,7 +927,7 @@ func testCollElgEnabled(t *testing.T) {
 	// Initial state: eligible for {ns-1:coll-1 and ns-2:coll-1 }
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Prepare(0, nil, nil, nil))
 	assert.NoError(store.Commit())
 
 	// construct and commit block 1
@@ -714,7 +939,7 @@ func testCollElgEnabled(t *testing.T) {
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
 	}
-	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData))
+	assert.NoError(store.Prepare(1, testDataForBlk1, blk1MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// construct and commit block 2
@@ -725,7 +950,7 @@ func testCollElgEnabled(t *testing.T) {
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
 	}
-	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData))
+	assert.NoError(store.Prepare(2, testDataForBlk2, blk2MissingData, nil))
 	assert.NoError(store.Commit())
 
 	// Retrieve and verify missing data reported
@@ -814,6 +1039,47 @@ func testutilWaitForCollElgProcToFinish(s Store) {
 	s.(*store).collElgProcSync.waitForDone()
 }
 
+func produceSamplePvtdataWithKeys(t *testing.T, txNum uint64, ns, coll string, keyValues ...string) *ledger.TxPvtData {
+	builder := rwsetutil.NewRWSetBuilder()
+	for i := 0; i < len(keyValues); i += 2 {
+		builder.AddToPvtAndHashedWriteSet(ns, coll, keyValues[i], []byte(keyValues[i+1]))
+	}
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
+}
+
+// testRetrieveKVs returns the keys and values present in the pvt data of a block, by transaction number
+func testRetrieveKVs(t *testing.T, s Store, blkNum uint64) map[uint64]map[string]string {
+	pvtData, err := s.GetPvtDataByBlockNum(blkNum, nil)
+	assert.NoError(t, err)
+	kvs := make(map[uint64]map[string]string)
+	for _, txPvtData := range pvtData {
+		txPvtRwSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(txPvtData.WriteSet)
+		assert.NoError(t, err)
+		for _, nsPvtRwSet := range txPvtRwSet.NsPvtRwSet {
+			for _, collPvtRwSet := range nsPvtRwSet.CollPvtRwSets {
+				for _, kvWrite := range collPvtRwSet.KvRwSet.Writes {
+					if kvs[txPvtData.SeqInBlock] == nil {
+						kvs[txPvtData.SeqInBlock] = make(map[string]string)
+					}
+					kvs[txPvtData.SeqInBlock][kvWrite.Key] = string(kvWrite.Value)
+				}
+			}
+		}
+	}
+	return kvs
+}
+
+func testCountKeys(t *testing.T, s Store, startKey, endKey []byte) int {
+	count := 0
+	assert.NoError(t, s.(*store).forEachKey(startKey, endKey, func(key []byte) error {
+		count++
+		return nil
+	}))
+	return count
+}
+
 func produceSamplePvtdata(t *testing.T, txNum uint64, nsColls []string) *ledger.TxPvtData {
 	builder := rwsetutil.NewRWSetBuilder()
 	for _, nsColl := range nsColls {
@@ -81,6 +81,10 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 10000)
+	viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+	viper.Set("ledger.pruning.retainLastBlocks", 0)
+	viper.Set("ledger.pruning.interval", 1000)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
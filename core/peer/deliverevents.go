@@ -21,6 +21,7 @@ import (
 	"github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/token"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
 
@@ -91,6 +92,35 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 	return fbrs.Send(response)
 }
 
+// tokenBlockResponseSender structure used to send token block responses
+type tokenBlockResponseSender struct {
+	peer.Deliver_DeliverTokensServer
+}
+
+// SendStatusResponse generates status reply proto message
+func (tbrs *tokenBlockResponseSender) SendStatusResponse(status common.Status) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return tbrs.Send(response)
+}
+
+// IsFiltered is a marker method which indicates that this response sender
+// sends filtered blocks.
+func (tbrs *tokenBlockResponseSender) IsFiltered() bool {
+	return true
+}
+
+// SendBlockResponse generates deliver response with token block message
+func (tbrs *tokenBlockResponseSender) SendBlockResponse(block *common.Block) error {
+	// Generates token block response
+	b := blockEvent(*block)
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_TokenBlock{TokenBlock: b.toTokenBlock()},
+	}
+	return tbrs.Send(response)
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -113,6 +143,22 @@ func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
 
+// DeliverTokens sends a stream of the token actions committed in
+// each block to a client
+func (s *server) DeliverTokens(srv peer.Deliver_DeliverTokensServer) error {
+	logger.Debugf("Starting new DeliverTokens handler")
+	defer dumpStacktraceOnPanic()
+	// getting policy checker based on resources.Event_TokenBlock resource name
+	deliverServer := &deliver.Server{
+		Receiver:      srv,
+		PolicyChecker: s.policyCheckerProvider(resources.Event_TokenBlock),
+		ResponseSender: &tokenBlockResponseSender{
+			Deliver_DeliverTokensServer: srv,
+		},
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
 // Deliver sends a stream of blocks to a client after commitment
 func (s *server) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	logger.Debugf("Starting new Deliver handler")
@@ -263,6 +309,120 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 	}, nil
 }
 
+// toTokenBlock extracts the token transactions of the block. A transaction
+// that cannot be decoded does not prevent the others from being delivered:
+// it is skipped, or reported with an unknown action type if it is known to
+// be a token transaction.
+func (block *blockEvent) toTokenBlock() *peer.TokenBlock {
+	tokenBlock := &peer.TokenBlock{
+		Number: block.Header.Number,
+	}
+
+	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.Data.Data {
+		if ebytes == nil {
+			logger.Debugf("got nil data bytes for tx index %d, "+
+				"block num %d", txIndex, block.Header.Number)
+			continue
+		}
+
+		env, err := utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Errorf("error getting tx from block, %s", err)
+			continue
+		}
+
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			logger.Warningf("could not extract payload from envelope of tx index %d, block num %d: %s",
+				txIndex, block.Header.Number, err)
+			continue
+		}
+
+		if payload.Header == nil {
+			logger.Debugf("transaction payload header is nil, %d, block num %d",
+				txIndex, block.Header.Number)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			logger.Warningf("could not extract channel header of tx index %d, block num %d: %s",
+				txIndex, block.Header.Number, err)
+			continue
+		}
+
+		tokenBlock.ChannelId = chdr.ChannelId
+
+		if common.HeaderType(chdr.Type) != common.HeaderType_TOKEN_TRANSACTION {
+			continue
+		}
+
+		var event *peer.TokenTransactionEvent
+		tokenTx := &token.TokenTransaction{}
+		if err := proto.Unmarshal(payload.Data, tokenTx); err != nil {
+			logger.Warningf("error unmarshaling token transaction [%s] of block num %d: %s",
+				chdr.TxId, block.Header.Number, err)
+			event = &peer.TokenTransactionEvent{Txid: chdr.TxId, ActionType: peer.TokenActionType_UNKNOWN_TOKEN_ACTION}
+		} else {
+			event = toTokenTransactionEvent(chdr.TxId, tokenTx)
+		}
+		event.TxValidationCode = txsFltr.Flag(txIndex)
+		tokenBlock.TokenTransactions = append(tokenBlock.TokenTransactions, event)
+	}
+
+	return tokenBlock
+}
+
+// toTokenTransactionEvent decodes the inputs and outputs of a plain token
+// transaction; the ID of each output is derived from its position in the
+// transaction, as done by the plain TMS when committing it.
+func toTokenTransactionEvent(txID string, tokenTx *token.TokenTransaction) *peer.TokenTransactionEvent {
+	event := &peer.TokenTransactionEvent{Txid: txID}
+
+	var outputs []*token.PlainOutput
+	switch action := tokenTx.GetPlainAction().GetData().(type) {
+	case *token.PlainTokenAction_PlainImport:
+		event.ActionType = peer.TokenActionType_TOKEN_IMPORT
+		outputs = action.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		event.ActionType = peer.TokenActionType_TOKEN_TRANSFER
+		event.Inputs = action.PlainTransfer.GetInputs()
+		outputs = action.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		event.ActionType = peer.TokenActionType_TOKEN_REDEEM
+		event.Inputs = action.PlainRedeem.GetInputs()
+		outputs = action.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		event.ActionType = peer.TokenActionType_TOKEN_APPROVE
+		event.Inputs = action.PlainApprove.GetInputs()
+		if action.PlainApprove.GetOutput() != nil {
+			outputs = []*token.PlainOutput{action.PlainApprove.GetOutput()}
+		}
+	case *token.PlainTokenAction_PlainTransfer_From:
+		event.ActionType = peer.TokenActionType_TOKEN_TRANSFER_FROM
+		event.Inputs = action.PlainTransfer_From.GetInputs()
+		outputs = action.PlainTransfer_From.GetOutputs()
+	case *token.PlainTokenAction_PlainSwap:
+		event.ActionType = peer.TokenActionType_TOKEN_SWAP
+		event.Inputs = action.PlainSwap.GetInputs()
+		outputs = action.PlainSwap.GetOutputs()
+	case *token.PlainTokenAction_PlainTypeRegistration:
+		event.ActionType = peer.TokenActionType_TOKEN_TYPE_REGISTRATION
+	default:
+		event.ActionType = peer.TokenActionType_UNKNOWN_TOKEN_ACTION
+	}
+
+	for i, output := range outputs {
+		event.Outputs = append(event.Outputs, &peer.TokenOutputEvent{
+			Id:       &token.InputId{TxId: txID, Index: uint32(i)},
+			Owner:    output.GetOwner(),
+			Type:     output.GetType(),
+			Quantity: output.GetQuantity(),
+		})
+	}
+	return event
+}
+
 func dumpStacktraceOnPanic() {
 	func() {
 		if r := recover(); r != nil {
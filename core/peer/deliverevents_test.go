@@ -27,6 +27,7 @@ import (
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/orderer"
 	"github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/token"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
 
@@ -393,6 +394,187 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 		})
 	}
 }
+func TestTokenBlockResponseSenderIsFiltered(t *testing.T) {
+	var tbrs interface{} = &tokenBlockResponseSender{}
+	filtered, ok := tbrs.(deliver.Filtered)
+	assert.True(t, ok, "should be filtered")
+	assert.True(t, filtered.IsFiltered(), "should return true from IsFiltered")
+}
+
+func TestToTokenBlock(t *testing.T) {
+	endorsement, err := createEndorsement("testChainID", "endorserID", nil)
+	assert.NoError(t, err)
+	transfer, err := createTokenTransaction("testChainID", "transferID", &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainTransfer{
+			PlainTransfer: &token.PlainTransfer{
+				Inputs: []*token.InputId{{TxId: "importID", Index: 0}},
+				Outputs: []*token.PlainOutput{
+					{Owner: []byte("Bob"), Type: "TOK1", Quantity: 10},
+					{Owner: []byte("Alice"), Type: "TOK1", Quantity: 90},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	redeem, err := createTokenTransaction("testChainID", "redeemID", &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainRedeem{
+			PlainRedeem: &token.PlainTransfer{
+				Inputs:  []*token.InputId{{TxId: "transferID", Index: 0}},
+				Outputs: []*token.PlainOutput{{Type: "TOK1", Quantity: 10}},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	block, err := createTestBlock([]*common.Envelope{
+		{Payload: utils.MarshalOrPanic(endorsement)},
+		{Payload: utils.MarshalOrPanic(transfer)},
+		{Payload: utils.MarshalOrPanic(redeem)},
+	})
+	assert.NoError(t, err)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][2] = byte(peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	b := blockEvent(*block)
+	tokenBlock := b.toTokenBlock()
+	assert.True(t, proto.Equal(&peer.TokenBlock{
+		ChannelId: "testChainID",
+		TokenTransactions: []*peer.TokenTransactionEvent{
+			{
+				Txid:             "transferID",
+				TxValidationCode: peer.TxValidationCode_VALID,
+				ActionType:       peer.TokenActionType_TOKEN_TRANSFER,
+				Inputs:           []*token.InputId{{TxId: "importID", Index: 0}},
+				Outputs: []*peer.TokenOutputEvent{
+					{Id: &token.InputId{TxId: "transferID", Index: 0}, Owner: []byte("Bob"), Type: "TOK1", Quantity: 10},
+					{Id: &token.InputId{TxId: "transferID", Index: 1}, Owner: []byte("Alice"), Type: "TOK1", Quantity: 90},
+				},
+			},
+			{
+				Txid:             "redeemID",
+				TxValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+				ActionType:       peer.TokenActionType_TOKEN_REDEEM,
+				Inputs:           []*token.InputId{{TxId: "transferID", Index: 0}},
+				Outputs: []*peer.TokenOutputEvent{
+					{Id: &token.InputId{TxId: "redeemID", Index: 0}, Type: "TOK1", Quantity: 10},
+				},
+			},
+		},
+	}, tokenBlock), "unexpected token block %s", tokenBlock)
+
+	t.Run("malformed token transaction", func(t *testing.T) {
+		garbage, err := createTokenTransaction("testChainID", "garbageID", &token.PlainTokenAction{})
+		assert.NoError(t, err)
+		garbage.Data = []byte("garbage")
+		block, err := createTestBlock([]*common.Envelope{
+			{Payload: utils.MarshalOrPanic(transfer)},
+			{Payload: []byte("not a payload")},
+			{Payload: utils.MarshalOrPanic(garbage)},
+			{Payload: utils.MarshalOrPanic(redeem)},
+		})
+		assert.NoError(t, err)
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][2] = byte(peer.TxValidationCode_BAD_PAYLOAD)
+
+		b := blockEvent(*block)
+		tokenBlock := b.toTokenBlock()
+		assert.Len(t, tokenBlock.TokenTransactions, 3)
+		assert.Equal(t, "transferID", tokenBlock.TokenTransactions[0].Txid)
+		assert.Equal(t, peer.TokenActionType_TOKEN_TRANSFER, tokenBlock.TokenTransactions[0].ActionType)
+		assert.True(t, proto.Equal(&peer.TokenTransactionEvent{
+			Txid:             "garbageID",
+			TxValidationCode: peer.TxValidationCode_BAD_PAYLOAD,
+			ActionType:       peer.TokenActionType_UNKNOWN_TOKEN_ACTION,
+		}, tokenBlock.TokenTransactions[1]), "unexpected token transaction %s", tokenBlock.TokenTransactions[1])
+		assert.Equal(t, "redeemID", tokenBlock.TokenTransactions[2].Txid)
+		assert.Equal(t, peer.TokenActionType_TOKEN_REDEEM, tokenBlock.TokenTransactions[2].ActionType)
+	})
+}
+
+func TestEventsServer_DeliverTokens(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	config := testConfig{
+		channelID:  "testChainID",
+		txID:       "importID",
+		Assertions: assert.New(t),
+	}
+	importTx, err := createTokenTransaction(config.channelID, config.txID, &token.PlainTokenAction{
+		Data: &token.PlainTokenAction_PlainImport{
+			PlainImport: &token.PlainImport{
+				Outputs: []*token.PlainOutput{{Owner: []byte("Alice"), Type: "TOK1", Quantity: 100}},
+			},
+		},
+	})
+	config.NoError(err)
+	block, err := createTestBlock([]*common.Envelope{{Payload: utils.MarshalOrPanic(importTx)}})
+	config.NoError(err)
+
+	iter := &mockIterator{}
+	iter.On("Next").Return(block, common.Status_SUCCESS)
+	reader := &mockReader{}
+	reader.On("Iterator", mock.Anything).Return(iter, uint64(1))
+	reader.On("Height").Return(uint64(1))
+	chain := &mockChainSupport{}
+	chain.On("Sequence").Return(uint64(0))
+	chain.On("Reader").Return(reader)
+	chainManager := &mockChainManager{}
+	chainManager.On("GetChain", config.channelID).Return(chain, true)
+
+	seekPayload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: config.channelID,
+				Timestamp: util.CreateUtcTimestamp(),
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{}),
+		},
+		Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+			Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+			Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		}),
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	p := &peer2.Peer{}
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+	deliverServer.On("Recv").Return(&common.Envelope{Payload: utils.MarshalOrPanic(seekPayload)}, nil).Run(func(_ mock.Arguments) {
+		deliverServer.Mock = mock.Mock{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+		deliverServer.On("Recv").Return(&common.Envelope{}, io.EOF)
+		deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			defer wg.Done()
+			response := args.Get(0).(*peer.DeliverResponse)
+			switch response.Type.(type) {
+			case *peer.DeliverResponse_Status:
+				config.Equal(common.Status_SUCCESS, response.GetStatus())
+			case *peer.DeliverResponse_TokenBlock:
+				tokenBlock := response.GetTokenBlock()
+				config.Equal(config.channelID, tokenBlock.ChannelId)
+				config.Len(tokenBlock.TokenTransactions, 1)
+				tx := tokenBlock.TokenTransactions[0]
+				config.Equal(config.txID, tx.Txid)
+				config.Equal(peer.TokenActionType_TOKEN_IMPORT, tx.ActionType)
+				config.Len(tx.Outputs, 1)
+				config.Equal([]byte("Alice"), tx.Outputs[0].Owner)
+			default:
+				config.FailNow("Unexpected response type")
+			}
+		}).Return(nil)
+	})
+
+	var resourceName string
+	policyCheckerProvider := func(name string) deliver.PolicyCheckerFunc {
+		resourceName = name
+		return defaultPolicyCheckerProvider(name)
+	}
+	server := NewDeliverEventsServer(false, policyCheckerProvider, chainManager, &disabled.Provider{})
+	err = server.DeliverTokens(deliverServer)
+	wg.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, "event/TokenBlock", resourceName)
+}
+
 func createDefaultSupportMamangerMock(config testConfig, chaincodeActionPayload *peer.ChaincodeActionPayload) *mockChainManager {
 	chainManager := &mockChainManager{}
 	iter := &mockIterator{}
@@ -524,3 +706,24 @@ func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(data))
 	return block, nil
 }
+
+func createTokenTransaction(channelID string, txID string, action *token.PlainTokenAction) (*common.Payload, error) {
+	tokenTxBytes, err := proto.Marshal(&token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{PlainAction: action},
+	})
+	if err != nil {
+		return nil, err
+	}
+	chdrBytes, err := proto.Marshal(&common.ChannelHeader{
+		ChannelId: channelID,
+		TxId:      txID,
+		Type:      int32(common.HeaderType_TOKEN_TRANSACTION),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &common.Payload{
+		Header: &common.Header{ChannelHeader: chdrBytes},
+		Data:   tokenTxBytes,
+	}, nil
+}
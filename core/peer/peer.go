@@ -55,7 +55,8 @@ var peerServers []*comm.GRPCServer
 var configTxProcessor = newConfigTxProcessor()
 var tokenTxProcessor = &transaction.Processor{
 	TMSManager: &manager.Manager{
-		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{}}}
+		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+		CapabilityChecker:           &tokenCapabilityChecker{}}}
 var ConfigTxProcessors = customtx.Processors{
 	common.HeaderType_CONFIG:            configTxProcessor,
 	common.HeaderType_TOKEN_TRANSACTION: tokenTxProcessor,
@@ -524,6 +525,25 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot in the directory snapshotDir and
+// returns the chain ID. The snapshot is imported only if the hash of its metadata matches metadataHash.
+// The chain is initialized with the last config block of the snapshot
+func CreateChainFromSnapshot(snapshotDir string, metadataHash []byte, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) (string, error) {
+	l, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotDir, metadataHash)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil {
+		return "", err
+	}
+	cid, err := utils.GetChainIDFromBlock(cb)
+	if err != nil {
+		return "", err
+	}
+	return cid, createChain(cid, l, cb, ccp, sccp, pluginMapper)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -557,6 +577,22 @@ func GetChannelConfig(cid string) channelconfig.Resources {
 	return nil
 }
 
+// tokenCapabilityChecker implements the manager.CapabilityChecker interface
+// by reading the application capabilities of the channel configuration
+type tokenCapabilityChecker struct{}
+
+func (*tokenCapabilityChecker) ConfidentialFabToken(cid string) (bool, error) {
+	cc := GetChannelConfig(cid)
+	if cc == nil {
+		return false, errors.Errorf("channel %s not found", cid)
+	}
+	ac, ok := cc.ApplicationConfig()
+	if !ok {
+		return false, errors.Errorf("no application config found for channel %s", cid)
+	}
+	return ac.Capabilities().ConfidentialFabToken(), nil
+}
+
 // GetPolicyManager returns the policy manager of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetPolicyManager(cid string) policies.Manager {
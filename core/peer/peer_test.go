@@ -28,6 +28,7 @@ import (
 	deliverclient "github.com/tradeline-tech/fabric/core/deliverservice"
 	"github.com/tradeline-tech/fabric/core/deliverservice/blocksprovider"
 	validation "github.com/tradeline-tech/fabric/core/handlers/validation/api"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgermgmt"
 	ledgermocks "github.com/tradeline-tech/fabric/core/ledger/mock"
 	"github.com/tradeline-tech/fabric/core/mocks/ccprovider"
 	fakeconfig "github.com/tradeline-tech/fabric/core/peer/mocks"
@@ -208,8 +209,12 @@ func TestGetLocalIP(t *testing.T) {
 }
 
 func TestDeliverSupportManager(t *testing.T) {
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+
 	// reset chains for testing
 	MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
 
 	manager := &DeliverChainManager{}
 	chainSupport := manager.GetChain("fake")
@@ -218,6 +223,11 @@ func TestDeliverSupportManager(t *testing.T) {
 	MockCreateChain("testchain")
 	chainSupport = manager.GetChain("testchain")
 	assert.NotNil(t, chainSupport, "chain support should not be nil")
+
+	// cleanup the chain referenes to enable execution with -count n
+	chains.Lock()
+	chains.list = map[string]*chain{}
+	chains.Unlock()
 }
 
 func TestGossipChannelConfigOrdererAddressesByOrgs(t *testing.T) {
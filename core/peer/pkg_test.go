@@ -28,6 +28,7 @@ import (
 	configtxtest "github.com/tradeline-tech/fabric/common/configtx/test"
 	"github.com/tradeline-tech/fabric/core/comm"
 	testpb "github.com/tradeline-tech/fabric/core/comm/testdata/grpc"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgermgmt"
 	"github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/core/peer"
 	"github.com/tradeline-tech/fabric/msp"
@@ -171,17 +172,19 @@ func TestUpdateRootsFromConfigBlock(t *testing.T) {
 	channel3Block, err := createConfigBlock("channel3", org2IntermediateMSPConf, ordererOrgMSPConf, "Org2IntermediateMSP", "OrdererOrgMSP")
 	require.NoError(t, err)
 
-	createChannel := func(cid string, block *cb.Block) {
-		testDir, err := ioutil.TempDir("", "peer-pkg")
-		require.NoError(t, err)
-		defer os.RemoveAll(testDir)
+	testDir, err := ioutil.TempDir("", "peer-pkg")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	viper.Set("peer.fileSystemPath", testDir)
+	peer.MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
 
+	createChannel := func(cid string, block *cb.Block) {
 		viper.Set("peer.tls.enabled", true)
 		viper.Set("peer.tls.cert.file", filepath.Join("testdata", "Org1-server1-cert.pem"))
 		viper.Set("peer.tls.key.file", filepath.Join("testdata", "Org1-server1-key.pem"))
 		viper.Set("peer.tls.rootcert.file", filepath.Join("testdata", "Org1-cert.pem"))
-		viper.Set("peer.fileSystemPath", testDir)
-		err = peer.Default.CreateChainFromBlock(block, nil, nil)
+		err := peer.Default.CreateChainFromBlock(block, nil, nil)
 		if err != nil {
 			t.Fatalf("Failed to create config block (%s)", err)
 		}
@@ -372,6 +372,56 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	}
 }
 
+func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+
+	peer.MockInitialize()
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+	identityDeserializer := &policymocks.MockIdentityDeserializer{
+		Identity: []byte("Alice"),
+		Msg:      []byte("msg1"),
+	}
+	e.policyChecker = policy.NewPolicyChecker(
+		&policymocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+	sProp, _ := utils.MockSignedEndorserProposalOrPanic("", &pb.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	identityDeserializer.Msg = sProp.ProposalBytes
+	sProp.Signature = sProp.ProposalBytes
+
+	res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte(JoinChainBySnapshot), []byte("/snapshot")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Incorrect number of arguments, 2", res.Message)
+
+	res = stub.MockInvokeWithSignedProposal("2", [][]byte{[]byte(JoinChainBySnapshot), nil, []byte("hash")}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot join the channel, no snapshot directory provided", res.Message)
+
+	res = stub.MockInvokeWithSignedProposal("3", [][]byte{[]byte(JoinChainBySnapshot), []byte("/snapshot"), nil}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot join the channel, no snapshot metadata hash provided", res.Message)
+
+	// the snapshot is looked up on the peer only once the caller is an admin of the peer
+	args := [][]byte{[]byte(JoinChainBySnapshot), []byte("/tmp/hyperledgertest/nonExistingSnapshot"), []byte("hash")}
+	sProp.Signature = nil
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "access denied for [JoinChainBySnapshot][/tmp/hyperledgertest/nonExistingSnapshot]")
+	sProp.Signature = sProp.ProposalBytes
+
+	res = stub.MockInvokeWithSignedProposal("5", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "cannot create ledger from snapshot")
+	assert.Contains(t, res.Message, "error while opening the snapshot file")
+}
+
 func TestGetConfigTree(t *testing.T) {
 	aclProvider := &mock.ACLProvider{}
 	configMgr := &mock.ConfigManager{}
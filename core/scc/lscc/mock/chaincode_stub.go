@@ -138,6 +138,38 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithOptionsStub        func(string, *shim.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error)
+	getHistoryForKeyWithOptionsMutex       sync.RWMutex
+	getHistoryForKeyWithOptionsArgsForCall []struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+	}
+	getHistoryForKeyWithOptionsReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getHistoryForKeyWithOptionsReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	GetHistoryForKeyWithPaginationStub        func(string, *shim.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}
+	getHistoryForKeyWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -210,6 +242,24 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataQueryResultWithPaginationStub        func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getPrivateDataQueryResultWithPaginationMutex       sync.RWMutex
+	getPrivateDataQueryResultWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}
+	getPrivateDataQueryResultWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataQueryResultWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataValidationParameterStub        func(string, string) ([]byte, error)
 	getPrivateDataValidationParameterMutex       sync.RWMutex
 	getPrivateDataValidationParameterArgsForCall []struct {
@@ -413,6 +463,18 @@ type ChaincodeStub struct {
 	invokeChaincodeReturnsOnCall map[int]struct {
 		result1 peer.Response
 	}
+	PurgePrivateDataStub        func(string, string) error
+	purgePrivateDataMutex       sync.RWMutex
+	purgePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	purgePrivateDataReturns struct {
+		result1 error
+	}
+	purgePrivateDataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PutPrivateDataStub        func(string, string, []byte) error
 	putPrivateDataMutex       sync.RWMutex
 	putPrivateDataArgsForCall []struct {
@@ -1123,6 +1185,139 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptions(arg1 string, arg2 *shim.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithOptionsReturnsOnCall[len(fake.getHistoryForKeyWithOptionsArgsForCall)]
+	fake.getHistoryForKeyWithOptionsArgsForCall = append(fake.getHistoryForKeyWithOptionsArgsForCall, struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+	}{arg1, arg2})
+	fake.recordInvocation("GetHistoryForKeyWithOptions", []interface{}{arg1, arg2})
+	fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	if fake.GetHistoryForKeyWithOptionsStub != nil {
+		return fake.GetHistoryForKeyWithOptionsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyWithOptionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsCallCount() int {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithOptionsArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsCalls(stub func(string, *shim.HistoryQueryOptions) (shim.HistoryQueryIteratorInterface, error)) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsArgsForCall(i int) (string, *shim.HistoryQueryOptions) {
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	fake.getHistoryForKeyWithOptionsReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithOptionsReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyWithOptionsMutex.Lock()
+	defer fake.getHistoryForKeyWithOptionsMutex.Unlock()
+	fake.GetHistoryForKeyWithOptionsStub = nil
+	if fake.getHistoryForKeyWithOptionsReturnsOnCall == nil {
+		fake.getHistoryForKeyWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyWithOptionsReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPagination(arg1 string, arg2 *shim.HistoryQueryOptions, arg3 int32, arg4 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithPaginationReturnsOnCall[len(fake.getHistoryForKeyWithPaginationArgsForCall)]
+	fake.getHistoryForKeyWithPaginationArgsForCall = append(fake.getHistoryForKeyWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyWithPaginationStub != nil {
+		return fake.GetHistoryForKeyWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCallCount() int {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCalls(stub func(string, *shim.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationArgsForCall(i int) (string, *shim.HistoryQueryOptions, int32, string) {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	fake.getHistoryForKeyWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	if fake.getHistoryForKeyWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1450,6 +1645,75 @@ func (fake *ChaincodeStub) GetPrivateDataQueryResultReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)]
+	fake.getPrivateDataQueryResultWithPaginationArgsForCall = append(fake.getPrivateDataQueryResultWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetPrivateDataQueryResultWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataQueryResultWithPaginationStub != nil {
+		return fake.GetPrivateDataQueryResultWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getPrivateDataQueryResultWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationCallCount() int {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationCalls(stub func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationArgsForCall(i int) (string, string, int32, string) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	argsForCall := fake.getPrivateDataQueryResultWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	fake.getPrivateDataQueryResultWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	if fake.getPrivateDataQueryResultWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataQueryResultWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataValidationParameter(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataValidationParameterMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataValidationParameterReturnsOnCall[len(fake.getPrivateDataValidationParameterArgsForCall)]
@@ -2383,6 +2647,67 @@ func (fake *ChaincodeStub) InvokeChaincodeReturnsOnCall(i int, result1 peer.Resp
 	}{result1}
 }
 
+func (fake *ChaincodeStub) PurgePrivateData(arg1 string, arg2 string) error {
+	fake.purgePrivateDataMutex.Lock()
+	ret, specificReturn := fake.purgePrivateDataReturnsOnCall[len(fake.purgePrivateDataArgsForCall)]
+	fake.purgePrivateDataArgsForCall = append(fake.purgePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("PurgePrivateData", []interface{}{arg1, arg2})
+	fake.purgePrivateDataMutex.Unlock()
+	if fake.PurgePrivateDataStub != nil {
+		return fake.PurgePrivateDataStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePrivateDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataCallCount() int {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return len(fake.purgePrivateDataArgsForCall)
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataCalls(stub func(string, string) error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = stub
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataArgsForCall(i int) (string, string) {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	argsForCall := fake.purgePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataReturns(result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	fake.purgePrivateDataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) PurgePrivateDataReturnsOnCall(i int, result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	if fake.purgePrivateDataReturnsOnCall == nil {
+		fake.purgePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgePrivateDataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChaincodeStub) PutPrivateData(arg1 string, arg2 string, arg3 []byte) error {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -2806,6 +3131,10 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithOptionsMutex.RLock()
+	defer fake.getHistoryForKeyWithOptionsMutex.RUnlock()
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
@@ -2816,6 +3145,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getPrivateDataHashMutex.RUnlock()
 	fake.getPrivateDataQueryResultMutex.RLock()
 	defer fake.getPrivateDataQueryResultMutex.RUnlock()
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
 	fake.getPrivateDataValidationParameterMutex.RLock()
 	defer fake.getPrivateDataValidationParameterMutex.RUnlock()
 	fake.getQueryResultMutex.RLock()
@@ -2846,6 +3177,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getTxTimestampMutex.RUnlock()
 	fake.invokeChaincodeMutex.RLock()
 	defer fake.invokeChaincodeMutex.RUnlock()
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
 	fake.putPrivateDataMutex.RLock()
 	defer fake.putPrivateDataMutex.RUnlock()
 	fake.putStateMutex.RLock()
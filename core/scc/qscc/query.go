@@ -10,10 +10,14 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/tradeline-tech/fabric/common/flogging"
+	commonledger "github.com/tradeline-tech/fabric/common/ledger"
 	"github.com/tradeline-tech/fabric/core/aclmgmt"
 	"github.com/tradeline-tech/fabric/core/chaincode/shim"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/ledgerconfig"
 	"github.com/tradeline-tech/fabric/core/peer"
 	pb "github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/utils"
@@ -40,6 +44,8 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetHistoryForPrivateDataHash returns the history of a hashed private data key
+// - GetHistoryForPartialCompositeKey returns the history of the composite keys sharing a prefix
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -53,6 +59,9 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetHistoryForPrivateDataHash     string = "GetHistoryForPrivateDataHash"
+	GetHistoryForPartialCompositeKey string = "GetHistoryForPartialCompositeKey"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +80,8 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetHistoryForPrivateDataHash: Return the history of the private data key hash in args[4] of the collection in args[3] of the chaincode in args[2]
+// # GetHistoryForPartialCompositeKey: Return the history of the composite keys starting with args[3] of the chaincode in args[2]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -115,6 +126,16 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetHistoryForPrivateDataHash:
+		if len(args) < 5 {
+			return shim.Error(fmt.Sprintf("missing 4th and 5th arguments for %s", fname))
+		}
+		return getHistoryForPrivateDataHash(targetLedger, args[2], args[3], args[4])
+	case GetHistoryForPartialCompositeKey:
+		if len(args) < 4 {
+			return shim.Error(fmt.Sprintf("missing 4th argument for %s", fname))
+		}
+		return getHistoryForPartialCompositeKey(targetLedger, args[2], args[3])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -126,6 +147,9 @@ func getTransactionByID(vledger ledger.PeerLedger, tid []byte) pb.Response {
 	}
 
 	processedTran, err := vledger.GetTransactionByID(string(tid))
+	if prunedErr, ok := errors.Cause(err).(*ledger.PrunedErr); ok {
+		return shim.Error(fmt.Sprintf("Transaction with id %s is in a pruned block, the lowest block retained is %d", string(tid), prunedErr.FirstBlockNum))
+	}
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Failed to get transaction with id %s, error %s", string(tid), err))
 	}
@@ -147,6 +171,9 @@ func getBlockByNumber(vledger ledger.PeerLedger, number []byte) pb.Response {
 		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
 	}
 	block, err := vledger.GetBlockByNumber(bnum)
+	if prunedErr, ok := errors.Cause(err).(*ledger.PrunedErr); ok {
+		return shim.Error(fmt.Sprintf("Block number %d has been pruned, the lowest block retained is %d", bnum, prunedErr.FirstBlockNum))
+	}
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Failed to get block number %d, error %s", bnum, err))
 	}
@@ -200,6 +227,9 @@ func getChainInfo(vledger ledger.PeerLedger) pb.Response {
 func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	txID := string(rawTxID)
 	block, err := vledger.GetBlockByTxID(txID)
+	if prunedErr, ok := errors.Cause(err).(*ledger.PrunedErr); ok {
+		return shim.Error(fmt.Sprintf("Block for txID %s has been pruned, the lowest block retained is %d", txID, prunedErr.FirstBlockNum))
+	}
 
 	if err != nil {
 		return shim.Error(fmt.Sprintf("Failed to get block for txID %s, error %s", txID, err))
@@ -214,6 +244,70 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getHistoryForPrivateDataHash(vledger ledger.PeerLedger, namespace, collection, keyHash []byte) pb.Response {
+	if len(namespace) == 0 || len(collection) == 0 || len(keyHash) == 0 {
+		return shim.Error("Chaincode name, collection name and key hash must not be empty.")
+	}
+	historyQueryExecutor, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+	itr, err := historyQueryExecutor.GetHistoryForPrivateDataHash(string(namespace), string(collection), keyHash)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for key hash %x, error %s", keyHash, err))
+	}
+	return buildHistoryQueryResponse(itr)
+}
+
+func getHistoryForPartialCompositeKey(vledger ledger.PeerLedger, namespace, partialCompositeKey []byte) pb.Response {
+	if len(namespace) == 0 {
+		return shim.Error("Chaincode name must not be empty.")
+	}
+	historyQueryExecutor, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+	itr, err := historyQueryExecutor.GetHistoryForPartialCompositeKey(string(namespace), string(partialCompositeKey))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for partial composite key %q, error %s", partialCompositeKey, err))
+	}
+	return buildHistoryQueryResponse(itr)
+}
+
+// buildHistoryQueryResponse collects the history records, up to the total query limit
+// of the peer, into a QueryResponse
+func buildHistoryQueryResponse(itr commonledger.ResultsIterator) pb.Response {
+	defer itr.Close()
+
+	totalQueryLimit := ledgerconfig.GetTotalQueryLimit()
+	response := &pb.QueryResponse{}
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to retrieve history record, error %s", err))
+		}
+		if result == nil {
+			break
+		}
+		if len(response.Results) >= totalQueryLimit {
+			response.HasMore = true
+			break
+		}
+		resultBytes, err := utils.Marshal(result.(proto.Message))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		response.Results = append(response.Results, &pb.QueryResultBytes{ResultBytes: resultBytes})
+	}
+
+	bytes, err := utils.Marshal(response)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
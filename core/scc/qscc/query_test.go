@@ -21,11 +21,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	commonledger "github.com/tradeline-tech/fabric/common/ledger"
 	"github.com/tradeline-tech/fabric/common/ledger/testutil"
 	"github.com/tradeline-tech/fabric/common/util"
 	"github.com/tradeline-tech/fabric/core/aclmgmt/mocks"
@@ -34,6 +36,7 @@ import (
 	ledger2 "github.com/tradeline-tech/fabric/core/ledger"
 	"github.com/tradeline-tech/fabric/core/peer"
 	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 	peer2 "github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
@@ -75,7 +78,7 @@ func TestQueryGetChainInfo(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	args := [][]byte{[]byte(GetChainInfo), []byte(chainid)}
@@ -99,7 +102,7 @@ func TestQueryGetTransactionByID(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	args := [][]byte{[]byte(GetTransactionByID), []byte(chainid), []byte("1")}
@@ -124,7 +127,7 @@ func TestQueryGetBlockByNumber(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	// block number 0 (genesis block) would already be present in the ledger
@@ -151,7 +154,7 @@ func TestQueryGetBlockByHash(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	args := [][]byte{[]byte(GetBlockByHash), []byte(chainid), []byte("0")}
@@ -171,7 +174,7 @@ func TestQueryGetBlockByTxID(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	args := [][]byte{[]byte(GetBlockByTxID), []byte(chainid), []byte("")}
@@ -187,7 +190,7 @@ func TestFailingAccessControl(t *testing.T) {
 
 	_, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 	e := &LedgerQuerier{
 		aclProvider: mockAclProvider,
@@ -262,7 +265,7 @@ func TestQueryNonexistentFunction(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	args := [][]byte{[]byte("GetBlocks"), []byte(chainid), []byte("arg1")}
@@ -280,7 +283,7 @@ func TestQueryGeneratedBlock(t *testing.T) {
 
 	stub, err := setupTestLedger(chainid, path)
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	block1 := addBlockForTesting(t, chainid)
@@ -310,7 +313,7 @@ func TestQueryGeneratedBlock(t *testing.T) {
 				}
 				chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 				if err != nil {
-					t.Fatalf(err.Error())
+					t.Fatal(err)
 				}
 				if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
 					args = [][]byte{[]byte(GetBlockByTxID), []byte(chainid), []byte(chdr.TxId)}
@@ -329,6 +332,109 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryPrunedBlock(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+	// every block is stored in a block file of its own, so that any block can be pruned
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 64*1024*1024)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block1 := addBlockForTesting(t, chainid)
+
+	// block 2 is a config block, from which the blocks are retained
+	ledger := peer.GetLedger(chainid)
+	genesisBlock, err := ledger.GetBlockByNumber(0)
+	require.NoError(t, err)
+	block2 := proto.Clone(genesisBlock).(*common.Block)
+	block2.Header.Number = 2
+	block2.Header.PreviousHash = block1.Header.Hash()
+	block2.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: 2}),
+	})
+	require.NoError(t, ledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block2}, &ledger2.CommitOptions{}))
+	require.NoError(t, ledger.Prune(&commonledger.RetainLastBlocksPolicy{NumBlocks: 1}))
+
+	args := [][]byte{[]byte(GetBlockByNumber), []byte(chainid), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetBlockByNumber, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Block number 1 has been pruned, the lowest block retained is 2", res.Message)
+
+	args = [][]byte{[]byte(GetBlockByNumber), []byte(chainid), []byte("2")}
+	prop = resetProvider(resources.Qscc_GetBlockByNumber, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetBlockByNumber should have succeeded for block number 2")
+}
+
+func TestQueryHistory(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ledger := peer.GetLedger(chainid)
+	txid := util.GenerateUUID()
+	simulator, _ := ledger.NewTxSimulator(txid)
+	simulator.SetState("ns1", "\x00asset\x00a1\x00", []byte("value1"))
+	simulator.SetState("ns1", "\x00asset\x00a2\x00", []byte("value2"))
+	simulator.SetState("ns1", "\x00owner\x00a1\x00", []byte("value3"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	block1 := testutil.ConstructBlock(t, 1, bcInfo.CurrentBlockHash, [][]byte{pubSimResBytes}, false)
+	assert.NoError(t, ledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block1}, &ledger2.CommitOptions{}))
+	ledger.Close()
+
+	args := [][]byte{[]byte(GetHistoryForPartialCompositeKey), []byte(chainid), []byte("ns1"), []byte("\x00asset\x00")}
+	prop := resetProvider(resources.Qscc_GetHistoryForPartialCompositeKey, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetHistoryForPartialCompositeKey failed with err: %s", res.Message)
+	queryResponse := &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, queryResponse))
+	assert.Len(t, queryResponse.Results, 2)
+	assert.False(t, queryResponse.HasMore)
+	keyModification := &queryresult.KeyModification{}
+	assert.NoError(t, proto.Unmarshal(queryResponse.Results[0].ResultBytes, keyModification))
+	assert.Equal(t, "\x00asset\x00a1\x00", keyModification.Key)
+	assert.Equal(t, []byte("value1"), keyModification.Value)
+
+	args = [][]byte{[]byte(GetHistoryForPartialCompositeKey), []byte(chainid), []byte("ns1"), []byte("asset")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForPartialCompositeKey should have failed for a key that is not a composite key")
+
+	args = [][]byte{[]byte(GetHistoryForPartialCompositeKey), []byte(chainid), []byte("ns1")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForPartialCompositeKey should have failed due to incorrect number of arguments")
+
+	args = [][]byte{[]byte(GetHistoryForPrivateDataHash), []byte(chainid), []byte("ns1"), []byte("coll1"), []byte("keyhash")}
+	prop = resetProvider(resources.Qscc_GetHistoryForPrivateDataHash, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetHistoryForPrivateDataHash failed with err: %s", res.Message)
+	queryResponse = &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, queryResponse))
+	assert.Empty(t, queryResponse.Results)
+
+	args = [][]byte{[]byte(GetHistoryForPrivateDataHash), []byte(chainid), []byte("ns1"), []byte("coll1"), nil}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForPrivateDataHash should have failed for an empty key hash")
+
+	args = [][]byte{[]byte(GetHistoryForPrivateDataHash), []byte(chainid), []byte("ns1"), []byte("coll1")}
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForPrivateDataHash should have failed due to incorrect number of arguments")
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
@@ -79,6 +79,10 @@ type Store interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+	// PurgeKeys removes the writes of the given private data keys from the private write sets
+	// that were persisted at a block height not greater than the block number of the corresponding
+	// purge, i.e., from the write sets simulated before the purging transaction got committed
+	PurgeKeys(purges []*ledger.PvtdataKeyPurge) error
 	// GetMinTransientBlkHt returns the lowest block height remaining in transient store
 	GetMinTransientBlkHt() (uint64, error)
 	Shutdown()
@@ -344,6 +348,44 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return s.db.WriteBatch(dbBatch, true)
 }
 
+// PurgeKeys removes the writes of the given private data keys from the private write sets
+// that were persisted at a block height not greater than the block number of the corresponding
+// purge. PurgeKeys() is expected to be called by coordinator after committing a block that
+// contains private data purges to ledger.
+func (s *store) PurgeKeys(purges []*ledger.PvtdataKeyPurge) error {
+	if len(purges) == 0 {
+		return nil
+	}
+	logger.Debugf("Purging [%d] private data keys from transient store", len(purges))
+
+	startKey := []byte{prwsetPrefix, compositeKeySep}
+	endKey := []byte{prwsetPrefix, byte(0xff)}
+	iter := s.db.GetIterator(startKey, endKey)
+	defer iter.Release()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for iter.Next() {
+		dbKey := iter.Key()
+		dbVal := iter.Value()
+		_, blockHeight, err := splitCompositeKeyOfPvtRWSet(dbKey)
+		if err != nil {
+			return err
+		}
+		keyHashes := keyHashesToPurgeAt(purges, blockHeight)
+		if len(keyHashes) == 0 {
+			continue
+		}
+		updatedVal, err := removeKeysFromPvtRWSetBytes(dbVal, keyHashes)
+		if err != nil {
+			return err
+		}
+		if updatedVal != nil {
+			dbBatch.Put(append([]byte(nil), dbKey...), updatedVal)
+		}
+	}
+	return s.db.WriteBatch(dbBatch, true)
+}
+
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
 func (s *store) GetMinTransientBlkHt() (uint64, error) {
 	// Current approach performs a range query on purgeIndex with startKey
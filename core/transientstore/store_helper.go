@@ -11,11 +11,16 @@ import (
 	"errors"
 	"path/filepath"
 
+	"github.com/golang/protobuf/proto"
+
 	"github.com/tradeline-tech/fabric/common/ledger/util"
 	"github.com/tradeline-tech/fabric/core/config"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	ledgerutil "github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/tradeline-tech/fabric/protos/transientstore"
 )
 
 var (
@@ -243,3 +248,105 @@ func trimPvtCollectionConfigs(configs map[string]*common.CollectionConfigPackage
 	}
 	return result, nil
 }
+
+// purgedKeyHashes maintains the hashes of the keys to be purged, grouped by namespace and collection
+type purgedKeyHashes map[string]map[string]map[string]struct{}
+
+func (p purgedKeyHashes) add(ns, coll string, keyHash []byte) {
+	colls, ok := p[ns]
+	if !ok {
+		colls = make(map[string]map[string]struct{})
+		p[ns] = colls
+	}
+	keyHashes, ok := colls[coll]
+	if !ok {
+		keyHashes = make(map[string]struct{})
+		colls[coll] = keyHashes
+	}
+	keyHashes[string(keyHash)] = struct{}{}
+}
+
+func (p purgedKeyHashes) has(ns, coll, key string) bool {
+	_, ok := p[ns][coll][string(ledgerutil.ComputeStringHash(key))]
+	return ok
+}
+
+// keyHashesToPurgeAt returns the key hashes of the purges that are to be applied on a private
+// write set received at the given block height. A write set received at a block height not greater
+// than the block number of the purge was simulated before the purging transaction got committed
+func keyHashesToPurgeAt(purges []*ledger.PvtdataKeyPurge, blockHeight uint64) purgedKeyHashes {
+	keyHashes := purgedKeyHashes{}
+	for _, purge := range purges {
+		if blockHeight <= purge.BlockNum {
+			keyHashes.add(purge.Namespace, purge.Collection, purge.KeyHash)
+		}
+	}
+	return keyHashes
+}
+
+// removeKeysFromPvtRWSetBytes removes the writes of the purged keys from the serialized private
+// write set as stored in the transient store (either in the old format, i.e., TxPvtReadWriteSet, or
+// in the new format, i.e., nilByte followed by TxPvtReadWriteSetWithConfigInfo). A nil value is
+// returned if none of the writes is removed
+func removeKeysFromPvtRWSetBytes(dbVal []byte, keyHashes purgedKeyHashes) ([]byte, error) {
+	if len(dbVal) > 0 && dbVal[0] == nilByte {
+		txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+			return nil, err
+		}
+		removed, err := removeKeysFromPvtRWSet(txPvtRWSetWithConfig.PvtRwset, keyHashes)
+		if err != nil || !removed {
+			return nil, err
+		}
+		updatedBytes, err := proto.Marshal(txPvtRWSetWithConfig)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{nilByte}, updatedBytes...), nil
+	}
+
+	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+	if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+		return nil, err
+	}
+	removed, err := removeKeysFromPvtRWSet(txPvtRWSet, keyHashes)
+	if err != nil || !removed {
+		return nil, err
+	}
+	return proto.Marshal(txPvtRWSet)
+}
+
+// removeKeysFromPvtRWSet removes, in place, the writes of the purged keys from the private write set
+// and returns whether any of the writes is removed
+func removeKeysFromPvtRWSet(txPvtRWSet *rwset.TxPvtReadWriteSet, keyHashes purgedKeyHashes) (bool, error) {
+	removed := false
+	for _, ns := range txPvtRWSet.GetNsPvtRwset() {
+		for _, coll := range ns.CollectionPvtRwset {
+			if _, ok := keyHashes[ns.Namespace][coll.CollectionName]; !ok {
+				continue
+			}
+			kvRWSet := &kvrwset.KVRWSet{}
+			if err := proto.Unmarshal(coll.Rwset, kvRWSet); err != nil {
+				return false, err
+			}
+			var retainedWrites []*kvrwset.KVWrite
+			for _, write := range kvRWSet.Writes {
+				if keyHashes.has(ns.Namespace, coll.CollectionName, write.Key) {
+					continue
+				}
+				retainedWrites = append(retainedWrites, write)
+			}
+			if len(retainedWrites) == len(kvRWSet.Writes) {
+				continue
+			}
+			kvRWSet.Writes = retainedWrites
+			rwsetBytes, err := proto.Marshal(kvRWSet)
+			if err != nil {
+				return false, err
+			}
+			coll.Rwset = rwsetBytes
+			removed = true
+		}
+	}
+	return removed, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/tradeline-tech/fabric/core/ledger/util"
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/ledger/rwset"
+	"github.com/tradeline-tech/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/tradeline-tech/fabric/protos/transientstore"
 )
 
@@ -458,6 +459,62 @@ func TestTransientStorePurgeByHeight(t *testing.T) {
 	env.Cleanup()
 }
 
+func TestTransientStorePurgeKeys(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	assert := assert.New(t)
+
+	// txid-1 and txid-2 are simulated before the purge (block 6) is committed, whereas txid-3 after it
+	assert.NoError(env.TestStore.Persist("txid-1", 5, samplePvtDataWithKeys(t, "key1", "key2")))
+	assert.NoError(env.TestStore.PersistWithConfig("txid-2", 6,
+		&transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: samplePvtDataWithKeys(t, "key1", "key2")}))
+	assert.NoError(env.TestStore.PersistWithConfig("txid-3", 7,
+		&transientstore.TxPvtReadWriteSetWithConfigInfo{PvtRwset: samplePvtDataWithKeys(t, "key1", "key2")}))
+
+	assert.NoError(env.TestStore.PurgeKeys(nil))
+	assert.NoError(env.TestStore.PurgeKeys(
+		[]*ledger.PvtdataKeyPurge{
+			{BlockNum: 6, TxNum: 0, Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key1")},
+		},
+	))
+
+	retrieveKeys := func(txid string) map[string][]string {
+		iter, err := env.TestStore.GetTxPvtRWSetByTxid(txid, nil)
+		assert.NoError(err)
+		defer iter.Close()
+		result, err := iter.NextWithConfig()
+		assert.NoError(err)
+		keys := map[string][]string{}
+		for _, ns := range result.PvtSimulationResultsWithConfig.PvtRwset.NsPvtRwset {
+			for _, coll := range ns.CollectionPvtRwset {
+				kvRWSet := &kvrwset.KVRWSet{}
+				assert.NoError(proto.Unmarshal(coll.Rwset, kvRWSet))
+				nsColl := ns.Namespace + "/" + coll.CollectionName
+				keys[nsColl] = []string{}
+				for _, write := range kvRWSet.Writes {
+					keys[nsColl] = append(keys[nsColl], write.Key)
+				}
+			}
+		}
+		return keys
+	}
+
+	purgedKeys := map[string][]string{
+		"ns-1/coll-1": {"key2"},
+		"ns-1/coll-2": {"key1", "key2"},
+		"ns-2/coll-1": {"key1", "key2"},
+	}
+	assert.Equal(purgedKeys, retrieveKeys("txid-1"))
+	assert.Equal(purgedKeys, retrieveKeys("txid-2"))
+	assert.Equal(
+		map[string][]string{
+			"ns-1/coll-1": {"key1", "key2"},
+			"ns-1/coll-2": {"key1", "key2"},
+			"ns-2/coll-1": {"key1", "key2"},
+		},
+		retrieveKeys("txid-3"),
+	)
+}
+
 func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	env := NewTestStoreEnv(t)
 	store := env.TestStore
@@ -568,6 +625,33 @@ func samplePvtData(t *testing.T) *rwset.TxPvtReadWriteSet {
 	return pvtWriteSet
 }
 
+func samplePvtDataWithKeys(t *testing.T, keys ...string) *rwset.TxPvtReadWriteSet {
+	kvRWSet := &kvrwset.KVRWSet{}
+	for _, key := range keys {
+		kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("value-" + key)})
+	}
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	assert.NoError(t, err)
+
+	pvtWriteSet := &rwset.TxPvtReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
+	pvtWriteSet.NsPvtRwset = []*rwset.NsPvtReadWriteSet{
+		{
+			Namespace: "ns-1",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+				{CollectionName: "coll-1", Rwset: rwsetBytes},
+				{CollectionName: "coll-2", Rwset: rwsetBytes},
+			},
+		},
+		{
+			Namespace: "ns-2",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+				{CollectionName: "coll-1", Rwset: rwsetBytes},
+			},
+		},
+	}
+	return pvtWriteSet
+}
+
 func samplePvtDataWithConfigInfo(t *testing.T) *transientstore.TxPvtReadWriteSetWithConfigInfo {
 	pvtWriteSet := samplePvtData(t)
 	pvtRWSetWithConfigInfo := &transientstore.TxPvtReadWriteSetWithConfigInfo{
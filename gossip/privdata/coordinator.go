@@ -65,6 +65,10 @@ type TransientStore interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+
+	// PurgeKeys removes the writes of the given private data keys from the private write sets
+	// that were simulated before the purging transactions got committed
+	PurgeKeys(purges []*ledger.PvtdataKeyPurge) error
 }
 
 // Coordinator orchestrates the flow of the new
@@ -280,6 +284,14 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		blockAndPvtData.MissingPvtData.Add(missingRWS.seqInBlock, missingRWS.namespace, missingRWS.collection, false)
 	}
 
+	if c.Support.CapabilityProvider.Capabilities().PvtDataPurge() {
+		// Without the capability, a purge is committed as a regular delete of the private data key
+		blockAndPvtData.KeyPurges, err = rwsetutil.PvtdataKeyPurgesOfBlock(block)
+		if err != nil {
+			return errors.WithMessage(err, "failed retrieving private data key purges")
+		}
+	}
+
 	// commit block and private data
 	commitStart := time.Now()
 	err = c.CommitWithPvtData(blockAndPvtData, &ledger.CommitOptions{})
@@ -297,6 +309,14 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		}
 	}
 
+	// Remove the private data keys purged by the valid transactions in the block from
+	// the write sets of the in-flight transactions
+	if purges := rwsetutil.PvtdataKeyPurgesOfValidTxs(block, blockAndPvtData.KeyPurges); len(purges) > 0 {
+		if err := c.PurgeKeys(purges); err != nil {
+			logger.Error("Failed purging private data keys from transient store at block", block.Header.Number, ":", err)
+		}
+	}
+
 	seq := block.Header.Number
 	if seq%c.transientBlockRetention == 0 && seq > c.transientBlockRetention {
 		err := c.PurgeByHeight(seq - c.transientBlockRetention)
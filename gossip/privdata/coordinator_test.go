@@ -137,6 +137,10 @@ func (store *mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error
 	return store.Called(maxBlockNumToRetain).Error(0)
 }
 
+func (store *mockTransientStore) PurgeKeys(purges []*ledger.PvtdataKeyPurge) error {
+	return store.Called(purges).Error(0)
+}
+
 func (store *mockTransientStore) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (transientstore.RWSetScanner, error) {
 	store.lastReqTxID = txid
 	store.lastReqFilter = filter
@@ -677,6 +681,7 @@ func TestCoordinatorStoreInvalidBlock(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -749,6 +754,7 @@ func TestCoordinatorStoreInvalidBlock(t *testing.T) {
 	appCapability = &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(false)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator = NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -808,6 +814,7 @@ func TestCoordinatorStoreInvalidBlock(t *testing.T) {
 	appCapability = &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator = NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -970,6 +977,7 @@ func TestCoordinatorToFilterOutPvtRWSetsWithWrongHash(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1081,6 +1089,7 @@ func TestCoordinatorStoreBlock(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1333,6 +1342,7 @@ func TestCoordinatorStoreBlockWhenPvtDataExistInLedger(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    nil,
 		Committer:          committer,
@@ -1430,6 +1440,7 @@ func TestProceedWithoutPrivateData(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1492,6 +1503,7 @@ func TestProceedWithInEligiblePrivateData(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1505,6 +1517,88 @@ func TestProceedWithInEligiblePrivateData(t *testing.T) {
 	assertCommitHappened()
 }
 
+func TestCoordinatorStoreBlockWithPvtDataKeyPurges(t *testing.T) {
+	mspID := "Org1MSP"
+	peerSelfSignedData := common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	hash := util2.ComputeSHA256([]byte("rws-pre-image"))
+	expectedPurges := []*ledger.PvtdataKeyPurge{
+		{BlockNum: 1, TxNum: 0, Namespace: "ns3", Collection: "c2", KeyHash: []byte("Key-4-hash")},
+	}
+
+	for _, testCase := range []struct {
+		name            string
+		pvtDataPurge    bool
+		mvccInvalidTx   bool
+		committedPurges []*ledger.PvtdataKeyPurge
+		transientPurges []*ledger.PvtdataKeyPurge
+	}{
+		{
+			name:            "capability enabled",
+			pvtDataPurge:    true,
+			committedPurges: expectedPurges,
+			transientPurges: expectedPurges,
+		},
+		{
+			name:            "capability enabled and purging transaction invalidated by the ledger",
+			pvtDataPurge:    true,
+			mvccInvalidTx:   true,
+			committedPurges: expectedPurges,
+		},
+		{
+			name:         "capability disabled",
+			pvtDataPurge: false,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var committedPurges []*ledger.PvtdataKeyPurge
+			committer := &mocks.Committer{}
+			committer.On("DoesPvtDataInfoExistInLedger", mock.Anything).Return(false, nil)
+			committer.On("CommitWithPvtData", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				blockAndPrivateData := args.Get(0).(*ledger.BlockAndPvtData)
+				committedPurges = blockAndPrivateData.KeyPurges
+				if testCase.mvccInvalidTx {
+					blockAndPrivateData.Block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][0] = uint8(peer.TxValidationCode_MVCC_READ_CONFLICT)
+				}
+			}).Return(nil)
+
+			store := &mockTransientStore{t: t}
+			if testCase.transientPurges != nil {
+				store.On("PurgeKeys", testCase.transientPurges).Return(nil)
+			}
+
+			capabilityProvider := &capabilitymock.CapabilityProvider{}
+			appCapability := &capabilitymock.AppCapabilities{}
+			capabilityProvider.On("Capabilities").Return(appCapability)
+			appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+			appCapability.On("PvtDataPurge").Return(testCase.pvtDataPurge)
+			coordinator := NewCoordinator(mspID, Support{
+				CollectionStore:    createcollectionStore(peerSelfSignedData).thatAcceptsNone(),
+				Committer:          committer,
+				Fetcher:            nil,
+				TransientStore:     store,
+				Validator:          &validatorMock{},
+				CapabilityProvider: capabilityProvider,
+			}, peerSelfSignedData, metrics, testConfig)
+
+			bf := &blockFactory{
+				channelID: "test",
+			}
+			err := coordinator.StoreBlock(bf.AddPurgingTxn("tx1", "ns3", hash, "c2").create(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.committedPurges, committedPurges)
+			store.AssertExpectations(t)
+			if testCase.transientPurges == nil {
+				store.AssertNotCalled(t, "PurgeKeys", mock.Anything)
+			}
+		})
+	}
+}
+
 func TestCoordinatorGetBlocks(t *testing.T) {
 	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
 	mspID := "Org1MSP"
@@ -1523,6 +1617,7 @@ func TestCoordinatorGetBlocks(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1610,6 +1705,7 @@ func TestPurgeByHeight(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1647,6 +1743,7 @@ func TestCoordinatorStorePvtData(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1730,6 +1827,7 @@ func TestIgnoreReadOnlyColRWSets(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -1780,6 +1878,7 @@ func TestCoordinatorMetrics(t *testing.T) {
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coordinator := NewCoordinator(mspID, Support{
 		CollectionStore:    cs,
 		Committer:          committer,
@@ -37,6 +37,20 @@ func (_m *AppCapabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// ConfidentialFabToken provides a mock function with given fields:
+func (_m *AppCapabilities) ConfidentialFabToken() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FabToken provides a mock function with given fields:
 func (_m *AppCapabilities) FabToken() bool {
 	ret := _m.Called()
@@ -107,6 +121,20 @@ func (_m *AppCapabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// PvtDataPurge provides a mock function with given fields:
+func (_m *AppCapabilities) PvtDataPurge() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // StorePvtDataOfInvalidTx provides a mock function with given fields:
 func (_m *AppCapabilities) StorePvtDataOfInvalidTx() bool {
 	ret := _m.Called()
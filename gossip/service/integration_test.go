@@ -31,6 +31,10 @@ func (*transientStoreMock) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*transientStoreMock) PurgeKeys(purges []*ledger.PvtdataKeyPurge) error {
+	return nil
+}
+
 func (*transientStoreMock) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -195,6 +195,10 @@ func (*mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	return nil
 }
 
+func (*mockTransientStore) PurgeKeys(purges []*ledger.PvtdataKeyPurge) error {
+	return nil
+}
+
 func (*mockTransientStore) Persist(txid string, blockHeight uint64, privateSimulationResults *rwset.TxPvtReadWriteSet) error {
 	panic("implement me")
 }
@@ -429,6 +433,7 @@ func newPeerNodeWithGossipWithValidatorWithMetrics(id int, committer committer.C
 	appCapability := &capabilitymock.AppCapabilities{}
 	capabilityProvider.On("Capabilities").Return(appCapability)
 	appCapability.On("StorePvtDataOfInvalidTx").Return(true)
+	appCapability.On("PvtDataPurge").Return(true)
 	coord := privdata.NewCoordinator(mspID, privdata.Support{
 		Validator:          v,
 		TransientStore:     &mockTransientStore{},
@@ -103,11 +103,13 @@ func RunTokenTransactionSubmit(n *nwo.Network, orderer *nwo.Orderer, peer *nwo.P
 
 	ordererCfg := tokenclient.ConnectionConfig{
 		Address:         ordererAddr,
+		TlsEnabled:      true,
 		TlsRootCertFile: ordererTlsRootCertFile,
 	}
 
 	commitPeerCfg := tokenclient.ConnectionConfig{
 		Address:         peerAddr,
+		TlsEnabled:      true,
 		TlsRootCertFile: peerTlsRootCertFile,
 	}
 
@@ -115,7 +117,6 @@ func RunTokenTransactionSubmit(n *nwo.Network, orderer *nwo.Orderer, peer *nwo.P
 		ChannelId:     "testchannel",
 		MspDir:        mspDir,
 		MspId:         mspId,
-		TlsEnabled:    true,
 		OrdererCfg:    ordererCfg,
 		CommitPeerCfg: commitPeerCfg,
 	}
@@ -60,6 +60,8 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// RateLimiter limits the rate of the messages of the clients, no limit applies when nil
+	RateLimiter RateLimiter
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -167,6 +169,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if resp := bh.admit(chdr.ChannelId, msg, addr); resp != nil {
+			return resp
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -188,6 +194,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if resp := bh.admit(chdr.ChannelId, msg, addr); resp != nil {
+			return resp
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -206,6 +216,20 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+// admit applies the rate limits to a message which passed validation, so that the
+// limits are charged to the authenticated creator of the message, and returns the
+// response rejecting the message if it exceeds them.
+func (bh *Handler) admit(channelID string, msg *cb.Envelope, addr string) *ab.BroadcastResponse {
+	if bh.RateLimiter == nil {
+		return nil
+	}
+	if err := bh.RateLimiter.Admit(channelID, msg); err != nil {
+		logger.Warningf("[channel: %s] Rejecting broadcast of message from %s because of error: %s", channelID, addr, err)
+		return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+	}
+	return nil
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
 	switch errors.Cause(err) {
@@ -215,6 +239,8 @@ func ClassifyError(err error) cb.Status {
 		return cb.Status_FORBIDDEN
 	case msgprocessor.ErrMaintenanceMode:
 		return cb.Status_SERVICE_UNAVAILABLE
+	case ErrRateLimited:
+		return cb.Status_TOO_MANY_REQUESTS
 	default:
 		return cb.Status_BAD_REQUEST
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/orderer/common/broadcast"
 	"github.com/tradeline-tech/fabric/orderer/common/broadcast/mock"
@@ -276,6 +277,57 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when a rate limiter is set", func() {
+			var fakeRateLimiter *mock.RateLimiter
+
+			BeforeEach(func() {
+				fakeRateLimiter = &mock.RateLimiter{}
+				handler.RateLimiter = fakeRateLimiter
+			})
+
+			It("admits the validated message before enqueuing it", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeRateLimiter.AdmitCallCount()).To(Equal(1))
+				channelID, msg := fakeRateLimiter.AdmitArgsForCall(0)
+				Expect(channelID).To(Equal("fake-channel"))
+				Expect(msg).To(Equal(fakeMsg))
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+			})
+
+			Context("when the message exceeds a rate limit", func() {
+				BeforeEach(func() {
+					fakeRateLimiter.AdmitReturns(errors.Wrap(broadcast.ErrRateLimited, "client limit of 1 messages per second"))
+				})
+
+				It("rejects the message with a too many requests status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.WaitReadyCallCount()).To(Equal(0))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_TOO_MANY_REQUESTS, Info: "client limit of 1 messages per second: rate limit exceeded"},
+					)).To(BeTrue())
+				})
+			})
+
+			Context("when the message fails validation", func() {
+				BeforeEach(func() {
+					fakeSupport.ProcessNormalMsgReturns(0, msgprocessor.ErrPermissionDenied)
+				})
+
+				It("does not charge the rate limits", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeRateLimiter.AdmitCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the message is a config message", func() {
 			var (
 				fakeConfig *cb.Envelope
@@ -312,6 +364,26 @@ var _ = Describe("Broadcast", func() {
 				Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
 			})
 
+			Context("when the config message exceeds a rate limit", func() {
+				BeforeEach(func() {
+					fakeRateLimiter := &mock.RateLimiter{}
+					fakeRateLimiter.AdmitReturns(broadcast.ErrRateLimited)
+					handler.RateLimiter = fakeRateLimiter
+				})
+
+				It("rejects the message with a too many requests status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.ConfigureCallCount()).To(Equal(0))
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_TOO_MANY_REQUESTS, Info: "rate limit exceeded"},
+					)).To(BeTrue())
+				})
+			})
+
 			Context("when the consenter is not ready for the request", func() {
 				BeforeEach(func() {
 					fakeSupport.WaitReadyReturns(fmt.Errorf("not-ready"))
@@ -30,12 +30,28 @@ var (
 		LabelNames:   []string{"channel", "type", "status"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{type}.%{status}",
 	}
+	rateLimitedCount = metrics.CounterOpts{
+		Namespace:    "broadcast",
+		Name:         "rate_limited_count",
+		Help:         "The number of transactions rejected by a rate limit.",
+		LabelNames:   []string{"channel", "org", "limit"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}.%{limit}",
+	}
+	admittedCount = metrics.CounterOpts{
+		Namespace:    "broadcast",
+		Name:         "admitted_count",
+		Help:         "The number of transactions admitted by the rate limits.",
+		LabelNames:   []string{"channel", "org"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}",
+	}
 )
 
 type Metrics struct {
 	ValidateDuration metrics.Histogram
 	EnqueueDuration  metrics.Histogram
 	ProcessedCount   metrics.Counter
+	RateLimitedCount metrics.Counter
+	AdmittedCount    metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -43,5 +59,7 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		ValidateDuration: p.NewHistogram(validateDuration),
 		EnqueueDuration:  p.NewHistogram(enqueueDuration),
 		ProcessedCount:   p.NewCounter(processedCount),
+		RateLimitedCount: p.NewCounter(rateLimitedCount),
+		AdmittedCount:    p.NewCounter(admittedCount),
 	}
 }
@@ -31,8 +31,10 @@ var _ = Describe("Metrics", func() {
 		Expect(metrics.ValidateDuration).To(Equal(&mock.MetricsHistogram{}))
 		Expect(metrics.EnqueueDuration).To(Equal(&mock.MetricsHistogram{}))
 		Expect(metrics.ProcessedCount).To(Equal(&mock.MetricsCounter{}))
+		Expect(metrics.RateLimitedCount).To(Equal(&mock.MetricsCounter{}))
+		Expect(metrics.AdmittedCount).To(Equal(&mock.MetricsCounter{}))
 
 		Expect(fakeProvider.NewHistogramCallCount()).To(Equal(2))
-		Expect(fakeProvider.NewCounterCallCount()).To(Equal(1))
+		Expect(fakeProvider.NewCounterCallCount()).To(Equal(3))
 	})
 })
@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"math"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+// ErrRateLimited is returned when a message exceeds a rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// bucketSweepInterval is the interval at which the idle buckets of the clients are dropped.
+const bucketSweepInterval = time.Minute
+
+//go:generate counterfeiter -o mock/rate_limiter.go --fake-name RateLimiter . RateLimiter
+
+// RateLimiter limits the rate of the messages clients broadcast
+type RateLimiter interface {
+	// Admit consumes a token of the channel, of the org and of the client that created the message, or
+	// returns an error wrapping ErrRateLimited if any of them is out of tokens
+	Admit(channelID string, msg *cb.Envelope) error
+}
+
+// tokenBucket is a token bucket that holds up to burst tokens, and is refilled at rate tokens per second.
+type tokenBucket struct {
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(limit localconfig.RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst == 0 {
+		burst = math.Max(1, math.Ceil(limit.Rate))
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, lastFill: now}
+}
+
+func (tb *tokenBucket) fill(now time.Time) {
+	if elapsed := now.Sub(tb.lastFill).Seconds(); elapsed > 0 {
+		tb.tokens = math.Min(tb.burst, tb.tokens+elapsed*tb.rate)
+	}
+	tb.lastFill = now
+}
+
+// full returns whether the bucket is full, in which case dropping it does not lose any state.
+func (tb *tokenBucket) full(now time.Time) bool {
+	tb.fill(now)
+	return tb.tokens >= tb.burst
+}
+
+// channelBuckets are the token buckets of a channel, of its orgs and of its clients.
+type channelBuckets struct {
+	limits  localconfig.RateLimits
+	channel *tokenBucket
+	orgs    map[string]*tokenBucket
+	clients map[[sha256.Size]byte]*tokenBucket
+}
+
+func (b *channelBuckets) orgLimit(mspID string) localconfig.RateLimit {
+	if limit, exists := b.limits.Orgs[mspID]; exists {
+		return limit
+	}
+	return b.limits.Org
+}
+
+// TokenBucketRateLimiter limits the rate of the messages broadcast on each channel, by the clients of each
+// org and by each client certificate, with token buckets.
+type TokenBucketRateLimiter struct {
+	config  localconfig.RateLimiting
+	metrics *Metrics
+	clock   clock.Clock
+
+	mutex     sync.Mutex
+	channels  map[string]*channelBuckets
+	lastSweep time.Time
+}
+
+// NewTokenBucketRateLimiter creates a TokenBucketRateLimiter with the given limits, whose
+// buckets are refilled as the clock advances.
+func NewTokenBucketRateLimiter(config localconfig.RateLimiting, metrics *Metrics, clock clock.Clock) *TokenBucketRateLimiter {
+	return &TokenBucketRateLimiter{
+		config:   config,
+		metrics:  metrics,
+		clock:    clock,
+		channels: map[string]*channelBuckets{},
+	}
+}
+
+// Admit consumes a token of the channel, of the org and of the client that created the message, or
+// returns an error wrapping ErrRateLimited if any of them is out of tokens. No token is consumed
+// when the message is rejected.
+func (rl *TokenBucketRateLimiter) Admit(channelID string, msg *cb.Envelope) error {
+	mspID, client := creatorOf(msg)
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.clock.Now()
+	rl.sweep(now)
+	buckets := rl.channelBuckets(channelID, now)
+
+	var limited []*tokenBucket
+	var names []string
+	if buckets.limits.Channel.Rate > 0 {
+		limited = append(limited, buckets.channel)
+		names = append(names, "channel")
+	}
+	if limit := buckets.orgLimit(mspID); limit.Rate > 0 {
+		bucket, exists := buckets.orgs[mspID]
+		if !exists {
+			bucket = newTokenBucket(limit, now)
+			buckets.orgs[mspID] = bucket
+		}
+		limited = append(limited, bucket)
+		names = append(names, "org")
+	}
+	if buckets.limits.Client.Rate > 0 {
+		bucket, exists := buckets.clients[client]
+		if !exists {
+			bucket = newTokenBucket(buckets.limits.Client, now)
+			buckets.clients[client] = bucket
+		}
+		limited = append(limited, bucket)
+		names = append(names, "client")
+	}
+
+	for i, bucket := range limited {
+		bucket.fill(now)
+		if bucket.tokens < 1 {
+			rl.metrics.RateLimitedCount.With("channel", channelID, "org", mspID, "limit", names[i]).Add(1)
+			return errors.Wrapf(ErrRateLimited, "%s limit of %v messages per second", names[i], bucket.rate)
+		}
+	}
+	for _, bucket := range limited {
+		bucket.tokens--
+	}
+	rl.metrics.AdmittedCount.With("channel", channelID, "org", mspID).Add(1)
+
+	return nil
+}
+
+func (rl *TokenBucketRateLimiter) channelBuckets(channelID string, now time.Time) *channelBuckets {
+	if buckets, exists := rl.channels[channelID]; exists {
+		return buckets
+	}
+
+	limits, exists := rl.config.Channels[channelID]
+	if !exists {
+		limits = rl.config.Defaults
+	}
+	buckets := &channelBuckets{
+		limits:  limits,
+		channel: newTokenBucket(limits.Channel, now),
+		orgs:    map[string]*tokenBucket{},
+		clients: map[[sha256.Size]byte]*tokenBucket{},
+	}
+	rl.channels[channelID] = buckets
+	return buckets
+}
+
+// sweep drops the full buckets of the orgs and of the clients, so that the buckets of
+// clients that stopped broadcasting do not accumulate.
+func (rl *TokenBucketRateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < bucketSweepInterval {
+		return
+	}
+	rl.lastSweep = now
+
+	for _, buckets := range rl.channels {
+		for mspID, bucket := range buckets.orgs {
+			if bucket.full(now) {
+				delete(buckets.orgs, mspID)
+			}
+		}
+		for client, bucket := range buckets.clients {
+			if bucket.full(now) {
+				delete(buckets.clients, client)
+			}
+		}
+	}
+}
+
+// creatorOf returns the MSP ID and the hash of the identity of the creator of the message. The
+// message is expected to have been validated, and a malformed message is attributed to an
+// unknown creator.
+func creatorOf(msg *cb.Envelope) (string, [sha256.Size]byte) {
+	unknown := sha256.Sum256(nil)
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil || payload.Header == nil {
+		return "unknown", unknown
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "unknown", unknown
+	}
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, identity); err != nil {
+		return "unknown", unknown
+	}
+	return identity.Mspid, sha256.Sum256(shdr.Creator)
+}
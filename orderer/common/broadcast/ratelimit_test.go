@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/orderer/common/broadcast"
+	"github.com/tradeline-tech/fabric/orderer/common/broadcast/mock"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func envelopeFrom(mspID, cert string) *cb.Envelope {
+	creator := utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)})
+	payload := &cb.Payload{
+		Header: utils.MakePayloadHeader(
+			utils.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, "fake-channel", 0),
+			utils.MakeSignatureHeader(creator, nil),
+		),
+	}
+	return &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+}
+
+var _ = Describe("TokenBucketRateLimiter", func() {
+	var (
+		config                  localconfig.RateLimiting
+		fakeClock               *fakeclock.FakeClock
+		fakeRateLimitedCounter  *mock.MetricsCounter
+		fakeAdmittedCounter     *mock.MetricsCounter
+		rateLimiter             *broadcast.TokenBucketRateLimiter
+		org1Client1             *cb.Envelope
+		org1Client2             *cb.Envelope
+		org2Client1             *cb.Envelope
+		admitN                  func(n int, channelID string, msg *cb.Envelope) int
+		expectRateLimitedLabels func(labels ...string)
+	)
+
+	BeforeEach(func() {
+		config = localconfig.RateLimiting{Enabled: true}
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		fakeRateLimitedCounter = &mock.MetricsCounter{}
+		fakeRateLimitedCounter.WithReturns(fakeRateLimitedCounter)
+		fakeAdmittedCounter = &mock.MetricsCounter{}
+		fakeAdmittedCounter.WithReturns(fakeAdmittedCounter)
+
+		org1Client1 = envelopeFrom("Org1MSP", "client1")
+		org1Client2 = envelopeFrom("Org1MSP", "client2")
+		org2Client1 = envelopeFrom("Org2MSP", "client1")
+
+		admitN = func(n int, channelID string, msg *cb.Envelope) int {
+			admitted := 0
+			for i := 0; i < n; i++ {
+				if err := rateLimiter.Admit(channelID, msg); err == nil {
+					admitted++
+				} else {
+					Expect(errors.Cause(err)).To(Equal(broadcast.ErrRateLimited))
+				}
+			}
+			return admitted
+		}
+		expectRateLimitedLabels = func(labels ...string) {
+			Expect(fakeRateLimitedCounter.WithCallCount()).NotTo(BeZero())
+			Expect(fakeRateLimitedCounter.WithArgsForCall(fakeRateLimitedCounter.WithCallCount() - 1)).To(Equal(labels))
+		}
+	})
+
+	JustBeforeEach(func() {
+		metrics := &broadcast.Metrics{
+			RateLimitedCount: fakeRateLimitedCounter,
+			AdmittedCount:    fakeAdmittedCounter,
+		}
+		rateLimiter = broadcast.NewTokenBucketRateLimiter(config, metrics, fakeClock)
+	})
+
+	It("admits every message without limits", func() {
+		Expect(admitN(100, "fake-channel", org1Client1)).To(Equal(100))
+		Expect(fakeRateLimitedCounter.AddCallCount()).To(Equal(0))
+		Expect(fakeAdmittedCounter.AddCallCount()).To(Equal(100))
+		Expect(fakeAdmittedCounter.WithArgsForCall(0)).To(Equal([]string{"channel", "fake-channel", "org", "Org1MSP"}))
+	})
+
+	Context("when the client rate is limited", func() {
+		BeforeEach(func() {
+			config.Defaults.Client = localconfig.RateLimit{Rate: 2, Burst: 4}
+		})
+
+		It("admits a burst of messages of each client, then refills at the rate", func() {
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(4))
+			Expect(admitN(10, "fake-channel", org1Client2)).To(Equal(4))
+			Expect(admitN(10, "other-channel", org1Client1)).To(Equal(4))
+			expectRateLimitedLabels("channel", "other-channel", "org", "Org1MSP", "limit", "client")
+
+			err := rateLimiter.Admit("fake-channel", org1Client1)
+			Expect(err).To(MatchError("client limit of 2 messages per second: rate limit exceeded"))
+
+			fakeClock.Increment(time.Second)
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(2))
+
+			fakeClock.Increment(time.Hour)
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(4))
+		})
+
+		It("does not lose the state of the clients when dropping idle buckets", func() {
+			Expect(admitN(4, "fake-channel", org1Client1)).To(Equal(4))
+			Expect(admitN(2, "fake-channel", org1Client2)).To(Equal(2))
+
+			fakeClock.Increment(time.Minute)
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(4))
+			Expect(admitN(10, "fake-channel", org1Client2)).To(Equal(4))
+		})
+	})
+
+	Context("when the burst is not set", func() {
+		BeforeEach(func() {
+			config.Defaults.Client = localconfig.RateLimit{Rate: 0.5}
+		})
+
+		It("admits bursts of one second worth of messages, and at least one", func() {
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(1))
+			fakeClock.Increment(time.Second)
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(0))
+			fakeClock.Increment(time.Second)
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(1))
+		})
+	})
+
+	Context("when the org rate is limited", func() {
+		BeforeEach(func() {
+			config.Defaults.Org = localconfig.RateLimit{Rate: 1, Burst: 3}
+			config.Defaults.Orgs = map[string]localconfig.RateLimit{
+				"Org2MSP": {Rate: 1, Burst: 1},
+			}
+		})
+
+		It("limits the messages of all the clients of the org", func() {
+			Expect(admitN(2, "fake-channel", org1Client1)).To(Equal(2))
+			Expect(admitN(2, "fake-channel", org1Client2)).To(Equal(1))
+			expectRateLimitedLabels("channel", "fake-channel", "org", "Org1MSP", "limit", "org")
+		})
+
+		It("applies the limits of specific orgs", func() {
+			Expect(admitN(3, "fake-channel", org2Client1)).To(Equal(1))
+			expectRateLimitedLabels("channel", "fake-channel", "org", "Org2MSP", "limit", "org")
+		})
+	})
+
+	Context("when the channel rate is limited", func() {
+		BeforeEach(func() {
+			config.Defaults.Channel = localconfig.RateLimit{Rate: 10, Burst: 5}
+			config.Defaults.Client = localconfig.RateLimit{Rate: 10, Burst: 3}
+		})
+
+		It("limits the messages of all the clients on the channel", func() {
+			Expect(admitN(3, "fake-channel", org1Client1)).To(Equal(3))
+			Expect(admitN(3, "fake-channel", org2Client1)).To(Equal(2))
+			expectRateLimitedLabels("channel", "fake-channel", "org", "Org2MSP", "limit", "channel")
+		})
+
+		It("does not consume the tokens of a rejected message", func() {
+			Expect(admitN(5, "fake-channel", org1Client1)).To(Equal(3))
+			Expect(admitN(5, "fake-channel", org2Client1)).To(Equal(2))
+		})
+	})
+
+	Context("when a channel has limits of its own", func() {
+		BeforeEach(func() {
+			config.Defaults.Client = localconfig.RateLimit{Rate: 1, Burst: 1}
+			config.Channels = map[string]localconfig.RateLimits{
+				"busy-channel": {Client: localconfig.RateLimit{Rate: 1, Burst: 5}},
+				"open-channel": {},
+			}
+		})
+
+		It("applies them instead of the defaults", func() {
+			Expect(admitN(10, "fake-channel", org1Client1)).To(Equal(1))
+			Expect(admitN(10, "busy-channel", org1Client1)).To(Equal(5))
+			Expect(admitN(10, "open-channel", org1Client1)).To(Equal(10))
+		})
+	})
+
+	Context("when the creator of the message cannot be read", func() {
+		BeforeEach(func() {
+			config.Defaults.Org = localconfig.RateLimit{Rate: 1, Burst: 1}
+		})
+
+		It("charges an unknown org", func() {
+			Expect(admitN(2, "fake-channel", &cb.Envelope{Payload: []byte("garbage")})).To(Equal(1))
+			expectRateLimitedLabels("channel", "fake-channel", "org", "unknown", "limit", "org")
+			Expect(admitN(1, "fake-channel", org1Client1)).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package channelparticipation implements the channel participation API of the orderer,
+// which lets an orderer that runs without a system channel join, list and remove channels.
+package channelparticipation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/channelconfig"
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+)
+
+const (
+	// URLBaseV1 is the path of the channel participation API, which serves the channels.
+	URLBaseV1 = "/participation/v1/"
+	// URLBaseV1Channels is the path of the channels, the path of a channel is URLBaseV1Channels/<channel-name>.
+	URLBaseV1Channels = URLBaseV1 + "channels"
+	// FormDataConfigBlockKey is the key of the multipart form part that carries the config block to join.
+	FormDataConfigBlockKey = "config-block"
+
+	channelNameKey        = "channelName"
+	urlWithChannelNameKey = URLBaseV1Channels + "/{" + channelNameKey + "}"
+)
+
+//go:generate counterfeiter -o mock/channel_management.go -fake-name ChannelManagement . ChannelManagement
+
+// ChannelManagement manages the channels served by the orderer.
+type ChannelManagement interface {
+	// ChannelList returns the channels served or onboarded by the orderer.
+	ChannelList() types.ChannelList
+	// ChannelInfo returns the status of a channel served or onboarded by the orderer.
+	ChannelInfo(channelID string) (types.ChannelInfo, error)
+	// JoinChannel makes the orderer serve the channel of the given config block.
+	JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error)
+	// RemoveChannel makes the orderer stop serving the channel and removes its ledger.
+	RemoveChannel(channelID string) error
+}
+
+var logger = flogging.MustGetLogger("orderer.common.channelparticipation")
+
+// HTTPHandler serves the channel participation API.
+type HTTPHandler struct {
+	config    localconfig.ChannelParticipation
+	registrar ChannelManagement
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that serves the channels of the registrar.
+func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelManagement) *HTTPHandler {
+	handler := &HTTPHandler{
+		config:    config,
+		registrar: registrar,
+		router:    mux.NewRouter(),
+	}
+
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveListAll).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveJoin).Methods(http.MethodPost)
+	handler.router.HandleFunc(urlWithChannelNameKey, handler.serveListOne).Methods(http.MethodGet)
+	handler.router.HandleFunc(urlWithChannelNameKey, handler.serveRemove).Methods(http.MethodDelete)
+	handler.router.NotFoundHandler = http.HandlerFunc(handler.serveNotFound)
+
+	return handler
+}
+
+// ServeHTTP serves the channel participation API.
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(resp, req)
+}
+
+// List all channels
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponseJSON(resp, http.StatusOK, h.registrar.ChannelList())
+}
+
+// List a single channel
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelNameKey]
+	info, err := h.registrar.ChannelInfo(channelID)
+	if err != nil {
+		h.sendResponseError(resp, err)
+		return
+	}
+	h.sendResponseJSON(resp, http.StatusOK, info)
+}
+
+// Join a channel with the config block carried by the multipart form
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	req.Body = http.MaxBytesReader(resp, req.Body, int64(h.config.MaxRequestBodySize))
+	if err := req.ParseMultipartForm(int64(h.config.MaxRequestBodySize)); err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read the multipart form"))
+		return
+	}
+
+	file, _, err := req.FormFile(FormDataConfigBlockKey)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrapf(err, "form does not carry part %s", FormDataConfigBlockKey))
+		return
+	}
+	defer file.Close()
+
+	blockBytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read the config block"))
+		return
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal the config block"))
+		return
+	}
+
+	channelID, err := ValidateJoinBlock(block)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.WithMessage(err, "invalid join block"))
+		return
+	}
+
+	info, err := h.registrar.JoinChannel(channelID, block)
+	if err != nil {
+		h.sendResponseError(resp, err)
+		return
+	}
+
+	resp.Header().Set("Location", info.URL)
+	h.sendResponseJSON(resp, http.StatusCreated, info)
+}
+
+// Remove a channel
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelNameKey]
+	if err := h.registrar.RemoveChannel(channelID); err != nil {
+		h.sendResponseError(resp, err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) serveNotFound(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponseJSONError(resp, http.StatusNotFound, errors.Errorf("path %s is not served", req.URL.Path))
+}
+
+// sendResponseError maps the errors of the registrar to the status of the response.
+func (h *HTTPHandler) sendResponseError(resp http.ResponseWriter, err error) {
+	switch errors.Cause(err) {
+	case types.ErrSystemChannelExists:
+		resp.Header().Set("Allow", http.MethodGet)
+		h.sendResponseJSONError(resp, http.StatusMethodNotAllowed, err)
+	case types.ErrChannelAlreadyExists, types.ErrChannelOnboarding:
+		h.sendResponseJSONError(resp, http.StatusConflict, err)
+	case types.ErrChannelNotExist:
+		h.sendResponseJSONError(resp, http.StatusNotFound, err)
+	default:
+		h.sendResponseJSONError(resp, http.StatusInternalServerError, err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseJSON(resp http.ResponseWriter, code int, content interface{}) {
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		logger.Errorf("Failed encoding the response: %v", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if _, err := resp.Write(encoded); err != nil {
+		logger.Debugf("Failed writing the response: %v", err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseJSONError(resp http.ResponseWriter, code int, err error) {
+	logger.Debugf("Responding with status %d: %v", code, err)
+	h.sendResponseJSON(resp, code, &types.ErrorResponse{Error: err.Error()})
+}
+
+// ValidateJoinBlock checks that the block is a config block of an application channel and
+// returns the ID of the channel.
+func ValidateJoinBlock(block *cb.Block) (string, error) {
+	if block.Header == nil || block.Data == nil || len(block.Data.Data) != 1 {
+		return "", errors.New("block is not a config block: it must carry exactly one transaction")
+	}
+
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(block.Data.Data[0], env); err != nil {
+		return "", errors.Wrap(err, "block does not carry an envelope")
+	}
+	payload := &cb.Payload{}
+	if err := proto.Unmarshal(env.Payload, payload); err != nil {
+		return "", errors.Wrap(err, "envelope does not carry a payload")
+	}
+	if payload.Header == nil {
+		return "", errors.New("payload is missing its header")
+	}
+	chdr := &cb.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, chdr); err != nil {
+		return "", errors.Wrap(err, "payload does not carry a channel header")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return "", errors.Errorf("block is not a config block: its transaction is of type %s", cb.HeaderType(chdr.Type))
+	}
+	if strings.TrimSpace(chdr.ChannelId) == "" {
+		return "", errors.New("config block does not name a channel")
+	}
+
+	configEnv := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return "", errors.Wrap(err, "payload does not carry a config envelope")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return "", errors.New("config envelope is missing the channel group")
+	}
+	if _, ok := configEnv.Config.ChannelGroup.Groups[channelconfig.ConsortiumsGroupKey]; ok {
+		return "", errors.Errorf("config block of channel %s is a system channel block, which cannot be joined", chdr.ChannelId)
+	}
+
+	return chdr.ChannelId, nil
+}
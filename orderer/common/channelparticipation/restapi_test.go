@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/orderer/common/channelparticipation"
+	"github.com/tradeline-tech/fabric/orderer/common/channelparticipation/mock"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func newHandler() (*channelparticipation.HTTPHandler, *mock.ChannelManagement) {
+	fakeManager := &mock.ChannelManagement{}
+	config := localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 1024 * 1024}
+	return channelparticipation.NewHTTPHandler(config, fakeManager), fakeManager
+}
+
+func configBlock(channelID string, groups ...string) *cb.Block {
+	channelGroup := cb.NewConfigGroup()
+	for _, group := range groups {
+		channelGroup.Groups[group] = cb.NewConfigGroup()
+	}
+	configEnv := &cb.ConfigEnvelope{Config: &cb.Config{ChannelGroup: channelGroup}}
+	payload := &cb.Payload{
+		Header: utils.MakePayloadHeader(
+			utils.MakeChannelHeader(cb.HeaderType_CONFIG, 0, channelID, 0),
+			utils.MakeSignatureHeader(nil, nil),
+		),
+		Data: utils.MarshalOrPanic(configEnv),
+	}
+	env := &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+	block := cb.NewBlock(3, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return block
+}
+
+func joinRequest(t *testing.T, blockBytes []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(channelparticipation.FormDataConfigBlockKey, "config.block")
+	require.NoError(t, err)
+	_, err = part.Write(blockBytes)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func decodeError(t *testing.T, resp *httptest.ResponseRecorder) string {
+	errResp := &types.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	return errResp.Error
+}
+
+func TestHTTPHandlerListAll(t *testing.T) {
+	handler, fakeManager := newHandler()
+	list := types.ChannelList{
+		Channels: []types.ChannelInfoShort{
+			{Name: "app1", URL: channelparticipation.URLBaseV1Channels + "/app1"},
+			{Name: "app2", URL: channelparticipation.URLBaseV1Channels + "/app2"},
+		},
+	}
+	fakeManager.ChannelListReturns(list)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+	received := types.ChannelList{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &received))
+	assert.Equal(t, list, received)
+}
+
+func TestHTTPHandlerListOne(t *testing.T) {
+	handler, fakeManager := newHandler()
+
+	t.Run("existing channel", func(t *testing.T) {
+		info := types.ChannelInfo{Name: "app1", URL: channelparticipation.URLBaseV1Channels + "/app1", Status: types.StatusActive, Height: 10}
+		fakeManager.ChannelInfoReturns(info, nil)
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app1", nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "app1", fakeManager.ChannelInfoArgsForCall(0))
+
+		received := types.ChannelInfo{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &received))
+		assert.Equal(t, info, received)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		fakeManager.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1Channels+"/app3", nil))
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, "channel does not exist", decodeError(t, resp))
+	})
+}
+
+func TestHTTPHandlerJoin(t *testing.T) {
+	block := configBlock("app1", "Application", "Orderer")
+	blockBytes := utils.MarshalOrPanic(block)
+
+	t.Run("joined", func(t *testing.T) {
+		handler, fakeManager := newHandler()
+		info := types.ChannelInfo{Name: "app1", URL: channelparticipation.URLBaseV1Channels + "/app1", Status: types.StatusOnboarding}
+		fakeManager.JoinChannelReturns(info, nil)
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, info.URL, resp.Header().Get("Location"))
+
+		require.Equal(t, 1, fakeManager.JoinChannelCallCount())
+		channelID, joinBlock := fakeManager.JoinChannelArgsForCall(0)
+		assert.Equal(t, "app1", channelID)
+		assert.True(t, proto.Equal(block, joinBlock))
+	})
+
+	for _, testCase := range []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{"system channel exists", types.ErrSystemChannelExists, http.StatusMethodNotAllowed},
+		{"channel already exists", types.ErrChannelAlreadyExists, http.StatusConflict},
+		{"unexpected failure", errors.New("disk full"), http.StatusInternalServerError},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, fakeManager := newHandler()
+			fakeManager.JoinChannelReturns(types.ChannelInfo{}, testCase.err)
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, joinRequest(t, blockBytes))
+			assert.Equal(t, testCase.expectedCode, resp.Code)
+			assert.Equal(t, testCase.err.Error(), decodeError(t, resp))
+		})
+	}
+
+	t.Run("invalid block", func(t *testing.T) {
+		handler, fakeManager := newHandler()
+
+		for _, invalidBlock := range [][]byte{
+			[]byte("not a block"),
+			utils.MarshalOrPanic(configBlock("system", "Consortiums", "Orderer")),
+			utils.MarshalOrPanic(cb.NewBlock(3, nil)),
+		} {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, joinRequest(t, invalidBlock))
+			assert.Equal(t, http.StatusBadRequest, resp.Code)
+		}
+		assert.Equal(t, 0, fakeManager.JoinChannelCallCount())
+	})
+
+	t.Run("missing config block", func(t *testing.T) {
+		handler, _ := newHandler()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		require.NoError(t, writer.Close())
+		req := httptest.NewRequest(http.MethodPost, channelparticipation.URLBaseV1Channels, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, decodeError(t, resp), "form does not carry part config-block")
+	})
+
+	t.Run("request body too large", func(t *testing.T) {
+		fakeManager := &mock.ChannelManagement{}
+		config := localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 64}
+		handler := channelparticipation.NewHTTPHandler(config, fakeManager)
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, joinRequest(t, blockBytes))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, 0, fakeManager.JoinChannelCallCount())
+	})
+}
+
+func TestHTTPHandlerRemove(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		err          error
+		expectedCode int
+	}{
+		{"removed", nil, http.StatusNoContent},
+		{"system channel exists", types.ErrSystemChannelExists, http.StatusMethodNotAllowed},
+		{"channel onboarding", types.ErrChannelOnboarding, http.StatusConflict},
+		{"missing channel", types.ErrChannelNotExist, http.StatusNotFound},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			handler, fakeManager := newHandler()
+			fakeManager.RemoveChannelReturns(testCase.err)
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, channelparticipation.URLBaseV1Channels+"/app1", nil))
+			assert.Equal(t, testCase.expectedCode, resp.Code)
+			assert.Equal(t, "app1", fakeManager.RemoveChannelArgsForCall(0))
+			if testCase.err == types.ErrSystemChannelExists {
+				assert.Equal(t, http.MethodGet, resp.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestHTTPHandlerNotFound(t *testing.T) {
+	handler, _ := newHandler()
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, channelparticipation.URLBaseV1+"peers", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "path /participation/v1/peers is not served", decodeError(t, resp))
+}
@@ -82,6 +82,7 @@ type ChannelLister interface {
 // Replicator replicates chains
 type Replicator struct {
 	DoNotPanicIfClusterNotReachable bool
+	DoNotPanicOnBootBlockMismatch   bool
 	Filter                          ChannelPredicate
 	SystemChannel                   string
 	ChannelLister                   ChannelLister
@@ -233,7 +234,9 @@ func (r *Replicator) pullChannelBlocks(channel string, puller *BlockPuller, late
 		}
 		actualPrevHash = block.Header.Hash()
 		if channel == r.SystemChannel && block.Header.Number == r.BootBlock.Header.Number {
-			r.compareBootBlockWithSystemChannelLastConfigBlock(block)
+			if err := r.compareBootBlockWithSystemChannelLastConfigBlock(block); err != nil {
+				return err
+			}
 			r.appendBlock(block, ledger, channel)
 			// No need to pull further blocks from the system channel
 			return nil
@@ -255,17 +258,22 @@ func (r *Replicator) appendBlock(block *common.Block, ledger LedgerWriter, chann
 	r.Logger.Infof("Committed block [%d] for channel %s", block.Header.Number, channel)
 }
 
-func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block) {
+func (r *Replicator) compareBootBlockWithSystemChannelLastConfigBlock(block *common.Block) error {
 	// Overwrite the received block's data hash
 	block.Header.DataHash = block.Data.Hash()
 
 	bootBlockHash := r.BootBlock.Header.Hash()
 	retrievedBlockHash := block.Header.Hash()
 	if bytes.Equal(bootBlockHash, retrievedBlockHash) {
-		return
+		return nil
+	}
+	if r.DoNotPanicOnBootBlockMismatch {
+		return errors.Errorf("block header mismatch on boot block of channel %s, expected %s, got %s",
+			r.SystemChannel, hex.EncodeToString(bootBlockHash), hex.EncodeToString(retrievedBlockHash))
 	}
 	r.Logger.Panicf("Block header mismatch on last system channel block, expected %s, got %s",
 		hex.EncodeToString(bootBlockHash), hex.EncodeToString(retrievedBlockHash))
+	return nil
 }
 
 type channelPullHints struct {
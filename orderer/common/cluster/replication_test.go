@@ -93,15 +93,16 @@ func TestIsReplicationNeeded(t *testing.T) {
 
 func TestReplicateChainsFailures(t *testing.T) {
 	for _, testCase := range []struct {
-		name                    string
-		isProbeResponseDelayed  bool
-		latestBlockSeqInOrderer uint64
-		ledgerFactoryError      error
-		appendBlockError        error
-		expectedPanic           string
-		mutateBlocks            func([]*common.Block)
-		channelsReturns         []cluster.ChannelGenesisBlock
-		badResponse             *orderer.DeliverResponse
+		name                          string
+		isProbeResponseDelayed        bool
+		doNotPanicOnBootBlockMismatch bool
+		latestBlockSeqInOrderer       uint64
+		ledgerFactoryError            error
+		appendBlockError              error
+		expectedPanic                 string
+		mutateBlocks                  func([]*common.Block)
+		channelsReturns               []cluster.ChannelGenesisBlock
+		badResponse                   *orderer.DeliverResponse
 	}{
 		{
 			name: "no block received",
@@ -146,6 +147,17 @@ func TestReplicateChainsFailures(t *testing.T) {
 				systemChannelBlocks[21].Header.DataHash = nil
 			},
 		},
+		{
+			name: "last pulled block doesn't match the boot block - returned as an error",
+			expectedPanic: "Failed pulling system channel: block header mismatch on boot block of channel system," +
+				" expected 8ec93b2ef5ffdc302f0c0e24611be04ad2b17b099a1aeafd7cfb76a95923f146," +
+				" got e428decfc78f8e4c97b26da9c16f9d0b73f886dafa80477a0dd9bac7eb14fe7a",
+			latestBlockSeqInOrderer:       21,
+			doNotPanicOnBootBlockMismatch: true,
+			mutateBlocks: func(systemChannelBlocks []*common.Block) {
+				systemChannelBlocks[21].Header.DataHash = nil
+			},
+		},
 		{
 			name:                    "failure in creating ledger",
 			latestBlockSeqInOrderer: 21,
@@ -232,12 +244,13 @@ func TestReplicateChainsFailures(t *testing.T) {
 				AmIPartOfChannel: func(configBlock *common.Block) error {
 					return cluster.ErrNotInChannel
 				},
-				Logger:        flogging.MustGetLogger("test"),
-				BootBlock:     systemChannelBlocks[21],
-				SystemChannel: "system",
-				LedgerFactory: lf,
-				Puller:        bp,
-				ChannelLister: cl,
+				Logger:                        flogging.MustGetLogger("test"),
+				BootBlock:                     systemChannelBlocks[21],
+				SystemChannel:                 "system",
+				LedgerFactory:                 lf,
+				Puller:                        bp,
+				ChannelLister:                 cl,
+				DoNotPanicOnBootBlockMismatch: testCase.doNotPanicOnBootBlockMismatch,
 			}
 
 			if len(testCase.channelsReturns) > 0 {
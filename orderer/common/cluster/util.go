@@ -453,6 +453,7 @@ type VerifierFactory interface {
 
 // VerificationRegistry registers verifiers and retrieves them.
 type VerificationRegistry struct {
+	lock               sync.RWMutex
 	LoadVerifier       func(chain string) BlockVerifier
 	Logger             *flogging.FabricLogger
 	VerifierFactory    VerifierFactory
@@ -461,6 +462,9 @@ type VerificationRegistry struct {
 
 // RegisterVerifier adds a verifier into the registry if applicable.
 func (vr *VerificationRegistry) RegisterVerifier(chain string) {
+	vr.lock.Lock()
+	defer vr.lock.Unlock()
+
 	if _, exists := vr.VerifiersByChannel[chain]; exists {
 		vr.Logger.Debugf("No need to register verifier for chain %s", chain)
 		return
@@ -476,8 +480,19 @@ func (vr *VerificationRegistry) RegisterVerifier(chain string) {
 	vr.Logger.Infof("Registered verifier for chain %s", chain)
 }
 
+// SetVerifier sets the verifier of the given channel, replacing the verifier registered for it, if any.
+func (vr *VerificationRegistry) SetVerifier(channel string, verifier BlockVerifier) {
+	vr.lock.Lock()
+	defer vr.lock.Unlock()
+
+	vr.VerifiersByChannel[channel] = verifier
+}
+
 // RetrieveVerifier returns a BlockVerifier for the given channel, or nil if not found.
 func (vr *VerificationRegistry) RetrieveVerifier(channel string) BlockVerifier {
+	vr.lock.RLock()
+	defer vr.lock.RUnlock()
+
 	verifier, exists := vr.VerifiersByChannel[channel]
 	if exists {
 		return verifier
@@ -511,7 +526,7 @@ func (vr *VerificationRegistry) BlockCommitted(block *common.Block, channel stri
 		return
 	}
 
-	vr.VerifiersByChannel[channel] = verifier
+	vr.SetVerifier(channel, verifier)
 
 	vr.Logger.Debugf("Committed config block [%d] for channel %s", block.Header.Number, channel)
 }
@@ -964,6 +964,22 @@ func TestVerificationRegistryRegisterVerifier(t *testing.T) {
 	assert.Equal(t, 1, loadCount)
 }
 
+func TestVerificationRegistrySetVerifier(t *testing.T) {
+	t.Parallel()
+
+	verifier := &mocks.BlockVerifier{}
+	registry := &cluster.VerificationRegistry{
+		Logger:             flogging.MustGetLogger("test"),
+		VerifiersByChannel: make(map[string]cluster.BlockVerifier),
+	}
+
+	registry.SetVerifier("mychannel", &cluster.NoopBlockVerifier{})
+	assert.Equal(t, &cluster.NoopBlockVerifier{}, registry.RetrieveVerifier("mychannel"))
+
+	registry.SetVerifier("mychannel", verifier)
+	assert.Equal(t, verifier, registry.RetrieveVerifier("mychannel"))
+}
+
 func TestVerificationRegistry(t *testing.T) {
 	t.Parallel()
 	blockBytes, err := ioutil.ReadFile("testdata/mychannel.block")
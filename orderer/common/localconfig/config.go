@@ -28,14 +28,16 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	Debug                Debug
+	Consensus            interface{}
+	Operations           Operations
+	Metrics              Metrics
+	ChannelParticipation ChannelParticipation
+	ConsensusMigration   ConsensusMigration
 }
 
 // General contains config which should be common among all orderer types.
@@ -56,6 +58,7 @@ type General struct {
 	LocalMSPID        string
 	BCCSP             *bccsp.FactoryOpts
 	Authentication    Authentication
+	RateLimiting      RateLimiting
 }
 
 type Cluster struct {
@@ -109,6 +112,35 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// RateLimiting contains configuration parameters for the token-bucket rate
+// limits applied to the messages clients broadcast.
+type RateLimiting struct {
+	Enabled bool
+	// Defaults are the limits of the channels without limits of their own.
+	Defaults RateLimits
+	// Channels are the limits of specific channels, keyed by channel name.
+	Channels map[string]RateLimits
+}
+
+// RateLimits contains the rate limits of a channel.
+type RateLimits struct {
+	// Channel limits all the messages broadcast on the channel.
+	Channel RateLimit
+	// Org limits the messages broadcast by the clients of each MSP.
+	Org RateLimit
+	// Client limits the messages broadcast by each client certificate.
+	Client RateLimit
+	// Orgs are the limits of specific MSPs, keyed by MSP ID, which replace Org.
+	Orgs map[string]RateLimit
+}
+
+// RateLimit is a token-bucket rate limit. A zero Rate means no limit, and a
+// zero Burst allows bursts of one second worth of messages.
+type RateLimit struct {
+	Rate  float64
+	Burst uint32
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -196,6 +228,22 @@ type Operations struct {
 	TLS           TLS
 }
 
+// ChannelParticipation provides the channel participation API configuration for the orderer.
+// The API is hosted by the operations server, and allows to join and remove channels
+// when the orderer has no system channel.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
+// ConsensusMigration provides the consensus-type migration assistant configuration for the orderer.
+// The assistant is hosted by the operations server, and reports the readiness of the channels for
+// the migration to Raft and computes the config updates of each step of the migration.
+type ConsensusMigration struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
 // Operations confiures the metrics provider for the orderer.
 type Metrics struct {
 	Provider string
@@ -291,6 +339,14 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
+	ConsensusMigration: ConsensusMigration{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -401,6 +457,14 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.ChannelParticipation.Enabled && c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+
+		case c.ConsensusMigration.Enabled && c.ConsensusMigration.MaxRequestBodySize == 0:
+			logger.Infof("ConsensusMigration.MaxRequestBodySize unset, setting to %v", Defaults.ConsensusMigration.MaxRequestBodySize)
+			c.ConsensusMigration.MaxRequestBodySize = Defaults.ConsensusMigration.MaxRequestBodySize
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
@@ -163,6 +163,37 @@ func TestClusterDefaults(t *testing.T) {
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
 }
 
+func TestChannelParticipationDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf, _ := Load()
+	assert.Equal(t, Defaults.ChannelParticipation, conf.ChannelParticipation)
+
+	uconf := &TopLevel{ChannelParticipation: ChannelParticipation{Enabled: true}}
+	uconf.completeInitialization("/dummy/path")
+	assert.Equal(t, Defaults.ChannelParticipation.MaxRequestBodySize, uconf.ChannelParticipation.MaxRequestBodySize)
+}
+
+func TestConsensusMigrationDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf, _ := Load()
+	assert.Equal(t, Defaults.ConsensusMigration, conf.ConsensusMigration)
+
+	uconf := &TopLevel{ConsensusMigration: ConsensusMigration{Enabled: true}}
+	uconf.completeInitialization("/dummy/path")
+	assert.Equal(t, Defaults.ConsensusMigration.MaxRequestBodySize, uconf.ConsensusMigration.MaxRequestBodySize)
+}
+
+func TestRateLimitingDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf, _ := Load()
+	assert.False(t, conf.General.RateLimiting.Enabled)
+	assert.Equal(t, RateLimit{}, conf.General.RateLimiting.Defaults.Client)
+	assert.Empty(t, conf.General.RateLimiting.Channels)
+}
+
 func TestSystemChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
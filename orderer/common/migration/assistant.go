@@ -0,0 +1,480 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migration implements the consensus-type migration assistant of the orderer. The assistant
+// reports the readiness of the channels of the orderer for the migration from Kafka or Solo to Raft,
+// as well as their rollback points, and computes and validates the config updates of every step of
+// the migration, which the orderer org admins then sign and submit.
+package migration
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/channelconfig"
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/tools/configtxlator/update"
+	"github.com/tradeline-tech/fabric/orderer/common/msgprocessor"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	protoetcdraft "github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+// TargetConsensusType is the consensus type the channels are migrated to.
+const TargetConsensusType = "etcdraft"
+
+var logger = flogging.MustGetLogger("orderer.common.migration")
+
+// Phase is the phase of the migration a channel is in.
+type Phase string
+
+const (
+	// PhaseNormal denotes a channel ordered by Kafka or Solo, which has to enter maintenance mode.
+	PhaseNormal Phase = "normal"
+	// PhaseMaintenance denotes a channel ordered by Kafka or Solo in maintenance mode, whose
+	// consensus type has to be switched to Raft.
+	PhaseMaintenance Phase = "maintenance"
+	// PhaseSwitched denotes a channel in maintenance mode whose consensus type was switched to Raft.
+	// Once the orderers are restarted, the channel has to exit maintenance mode.
+	PhaseSwitched Phase = "switched"
+	// PhaseCompleted denotes a channel ordered by Raft, which is not in maintenance mode.
+	PhaseCompleted Phase = "completed"
+	// PhaseUnsupported denotes a channel whose consensus type cannot be migrated to Raft.
+	PhaseUnsupported Phase = "unsupported"
+)
+
+// sourceConsensusTypes are the consensus types channels can be migrated from.
+var sourceConsensusTypes = map[string]bool{
+	"kafka": true,
+	"solo":  true,
+}
+
+//go:generate counterfeiter -o mock/channel_source.go -fake-name ChannelSource . ChannelSource
+
+// ChannelSource provides the channels served by the orderer.
+type ChannelSource interface {
+	// ChannelList returns the channels served or onboarded by the orderer.
+	ChannelList() types.ChannelList
+	// ChannelSupport returns the support of a channel served by the orderer,
+	// and false if the orderer does not serve the channel.
+	ChannelSupport(channelID string) (ChannelSupport, bool)
+}
+
+//go:generate counterfeiter -o mock/channel_support.go -fake-name ChannelSupport . ChannelSupport
+
+// ChannelSupport provides the ledger and the config of a channel.
+type ChannelSupport interface {
+	msgprocessor.MaintenanceFilterSupport
+	// Height returns the number of blocks in the ledger of the channel.
+	Height() uint64
+	// Block returns the block of the given number, or nil if it does not exist.
+	Block(number uint64) *cb.Block
+	// ConfigProto returns the current config of the channel.
+	ConfigProto() *cb.Config
+}
+
+// RollbackPoint is the point a channel in maintenance mode can be rolled back to.
+type RollbackPoint struct {
+	// Block is the number of the config block that made the channel enter maintenance mode.
+	// The ledgers of the orderers have to be backed up at this height before the consensus
+	// type is switched.
+	Block uint64 `json:"block"`
+	// ConsensusType is the consensus type of the channel before the migration.
+	ConsensusType string `json:"consensusType"`
+}
+
+// ChannelStatus is the migration status of a channel.
+type ChannelStatus struct {
+	// Name is the channel name.
+	Name string `json:"name"`
+	// SystemChannel is true for the system channel.
+	SystemChannel bool `json:"systemChannel,omitempty"`
+	// ConsensusType is the current consensus type of the channel.
+	ConsensusType string `json:"consensusType"`
+	// ConsensusState is the current consensus state of the channel.
+	ConsensusState string `json:"consensusState"`
+	// Height is the ledger height. The heights reported by all the orderers must match before
+	// the consensus type is switched and before the orderers are restarted.
+	Height uint64 `json:"height"`
+	// LastConfigBlock is the number of the last config block of the channel.
+	LastConfigBlock uint64 `json:"lastConfigBlock"`
+	// Phase is the phase of the migration the channel is in.
+	Phase Phase `json:"phase"`
+	// Ready is true when the channel is ready for the next step of the migration.
+	Ready bool `json:"ready"`
+	// RollbackPoint is the point the channel can be rolled back to, nil unless the channel
+	// is in maintenance mode.
+	RollbackPoint *RollbackPoint `json:"rollbackPoint,omitempty"`
+	// Issues are the reasons the channel is not ready for the next step of the migration.
+	Issues []string `json:"issues,omitempty"`
+}
+
+// Status is the migration status of all the channels of the orderer.
+type Status struct {
+	// ReadyForSwitch is true when all the channels are in maintenance mode, and no block
+	// was ordered on any of them since their last config block.
+	ReadyForSwitch bool `json:"readyForSwitch"`
+	// ReadyForRestart is true when the consensus type of all the channels was switched to Raft,
+	// and the orderers can be restarted.
+	ReadyForRestart bool `json:"readyForRestart"`
+	// Channels are the statuses of the channels, the system channel first.
+	Channels []ChannelStatus `json:"channels"`
+}
+
+// ChannelUpdate carries the config updates that move a channel to the next phase of the migration,
+// and back to its rollback point.
+type ChannelUpdate struct {
+	// Name is the channel name.
+	Name string `json:"name"`
+	// Phase is the phase of the migration the channel is in.
+	Phase Phase `json:"phase"`
+	// Update is the marshaled common.ConfigUpdate that moves the channel to the next phase.
+	Update []byte `json:"update,omitempty"`
+	// Rollback is the marshaled common.ConfigUpdate that rolls the channel back to its rollback point.
+	Rollback []byte `json:"rollback,omitempty"`
+	// Error is the reason the config updates of the channel could not be computed.
+	Error string `json:"error,omitempty"`
+}
+
+// Assistant assists the migration of the channels served by the orderer to Raft.
+type Assistant struct {
+	channels ChannelSource
+}
+
+// NewAssistant creates an Assistant for the channels of the channel source.
+func NewAssistant(channels ChannelSource) *Assistant {
+	return &Assistant{channels: channels}
+}
+
+// Status returns the migration status of the channels of the orderer.
+func (a *Assistant) Status() Status {
+	status := Status{
+		ReadyForSwitch:  true,
+		ReadyForRestart: true,
+	}
+
+	channelIDs, systemChannelID := a.channelIDs()
+	for _, channelID := range channelIDs {
+		cs := a.channelStatus(channelID, channelID == systemChannelID)
+		if cs == nil {
+			continue
+		}
+		status.Channels = append(status.Channels, *cs)
+
+		inMaintenance := cs.Phase == PhaseMaintenance || cs.Phase == PhaseSwitched
+		status.ReadyForSwitch = status.ReadyForSwitch && inMaintenance && cs.Ready
+		status.ReadyForRestart = status.ReadyForRestart && cs.Phase == PhaseSwitched && cs.Ready
+	}
+
+	if len(status.Channels) == 0 {
+		status.ReadyForSwitch = false
+		status.ReadyForRestart = false
+	}
+
+	return status
+}
+
+// ComputeUpdates computes and validates the config updates of every channel of the orderer. The
+// Raft metadata is required for the channels whose consensus type has to be switched.
+func (a *Assistant) ComputeUpdates(metadata *protoetcdraft.ConfigMetadata) ([]ChannelUpdate, error) {
+	if metadata != nil {
+		if err := etcdraft.CheckConfigMetadata(metadata); err != nil {
+			return nil, errors.WithMessage(err, "invalid Raft config metadata")
+		}
+	}
+
+	var updates []ChannelUpdate
+	channelIDs, _ := a.channelIDs()
+	for _, channelID := range channelIDs {
+		support, ok := a.channels.ChannelSupport(channelID)
+		if !ok {
+			continue
+		}
+		updates = append(updates, computeUpdates(channelID, support, metadata))
+	}
+
+	return updates, nil
+}
+
+// channelIDs returns the IDs of the channels served by the orderer, the system channel first,
+// and the ID of the system channel.
+func (a *Assistant) channelIDs() (channelIDs []string, systemChannelID string) {
+	list := a.channels.ChannelList()
+
+	if list.SystemChannel != nil {
+		systemChannelID = list.SystemChannel.Name
+		channelIDs = append(channelIDs, systemChannelID)
+	}
+	for _, channel := range list.Channels {
+		channelIDs = append(channelIDs, channel.Name)
+	}
+
+	return channelIDs, systemChannelID
+}
+
+func (a *Assistant) channelStatus(channelID string, systemChannel bool) *ChannelStatus {
+	support, ok := a.channels.ChannelSupport(channelID)
+	if !ok {
+		// The channel is being onboarded
+		return nil
+	}
+
+	cs := &ChannelStatus{
+		Name:          channelID,
+		SystemChannel: systemChannel,
+		Height:        support.Height(),
+	}
+
+	consensusType, err := readConsensusType(support.ConfigProto())
+	if err != nil {
+		cs.Phase = PhaseUnsupported
+		cs.Issues = append(cs.Issues, err.Error())
+		return cs
+	}
+	cs.ConsensusType = consensusType.Type
+	cs.ConsensusState = consensusType.State.String()
+	cs.Phase = phaseOf(consensusType)
+
+	if cs.Height > 0 {
+		cs.LastConfigBlock, err = utils.GetLastConfigIndexFromBlock(support.Block(cs.Height - 1))
+		if err != nil {
+			cs.Issues = append(cs.Issues, fmt.Sprintf("failed to read the last config block: %v", err))
+			return cs
+		}
+	}
+
+	switch cs.Phase {
+	case PhaseUnsupported:
+		cs.Issues = append(cs.Issues, fmt.Sprintf("consensus type %s cannot be migrated to %s", cs.ConsensusType, TargetConsensusType))
+	case PhaseCompleted:
+	case PhaseNormal:
+		if ordererConfig, ok := support.OrdererConfig(); !ok || !ordererConfig.Capabilities().ConsensusTypeMigration() {
+			cs.Issues = append(cs.Issues, "the orderer capability of the channel does not enable consensus-type migration")
+		}
+	default:
+		if cs.Height != cs.LastConfigBlock+1 {
+			cs.Issues = append(cs.Issues, fmt.Sprintf("block %d was ordered after the last config block %d",
+				cs.Height-1, cs.LastConfigBlock))
+		}
+		cs.RollbackPoint, err = findRollbackPoint(support, cs.LastConfigBlock)
+		if err != nil {
+			cs.Issues = append(cs.Issues, fmt.Sprintf("failed to find the rollback point: %v", err))
+		}
+	}
+
+	cs.Ready = cs.Phase != PhaseUnsupported && cs.Phase != PhaseCompleted && len(cs.Issues) == 0
+
+	return cs
+}
+
+func computeUpdates(channelID string, support ChannelSupport, metadata *protoetcdraft.ConfigMetadata) ChannelUpdate {
+	cu := ChannelUpdate{Name: channelID}
+
+	config := support.ConfigProto()
+	current, err := readConsensusType(config)
+	if err != nil {
+		cu.Phase = PhaseUnsupported
+		cu.Error = err.Error()
+		return cu
+	}
+	cu.Phase = phaseOf(current)
+
+	var next, rollback *orderer.ConsensusType
+	switch cu.Phase {
+	case PhaseNormal:
+		next = &orderer.ConsensusType{Type: current.Type, Metadata: current.Metadata, State: orderer.ConsensusType_STATE_MAINTENANCE}
+	case PhaseMaintenance:
+		if metadata == nil {
+			cu.Error = "no Raft config metadata was provided to switch the consensus type"
+			return cu
+		}
+		next = &orderer.ConsensusType{Type: TargetConsensusType, Metadata: utils.MarshalOrPanic(metadata), State: orderer.ConsensusType_STATE_MAINTENANCE}
+		rollback = &orderer.ConsensusType{Type: current.Type, Metadata: current.Metadata, State: orderer.ConsensusType_STATE_NORMAL}
+	case PhaseSwitched:
+		next = &orderer.ConsensusType{Type: current.Type, Metadata: current.Metadata, State: orderer.ConsensusType_STATE_NORMAL}
+		rollback, err = rollbackConsensusType(support, current)
+		if err != nil {
+			cu.Error = err.Error()
+			return cu
+		}
+	case PhaseCompleted:
+		return cu
+	default:
+		cu.Error = fmt.Sprintf("consensus type %s cannot be migrated to %s", current.Type, TargetConsensusType)
+		return cu
+	}
+
+	if cu.Update, err = computeUpdate(channelID, support, config, next); err != nil {
+		cu.Error = errors.WithMessage(err, "invalid config update").Error()
+		return cu
+	}
+	if rollback == nil {
+		return cu
+	}
+	if cu.Rollback, err = computeUpdate(channelID, support, config, rollback); err != nil {
+		cu.Update = nil
+		cu.Error = errors.WithMessage(err, "invalid rollback config update").Error()
+	}
+
+	return cu
+}
+
+// computeUpdate computes the config update that sets the consensus type of the channel, and validates
+// it against the transition rules of consensus-type migration.
+func computeUpdate(channelID string, support ChannelSupport, config *cb.Config, consensusType *orderer.ConsensusType) ([]byte, error) {
+	nextConfig := proto.Clone(config).(*cb.Config)
+	ordererGroup, ok := nextConfig.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("config is missing the orderer group")
+	}
+	value := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	ordererGroup.Values[channelconfig.ConsensusTypeKey] = &cb.ConfigValue{
+		Version:   value.Version,
+		ModPolicy: value.ModPolicy,
+		Value:     utils.MarshalOrPanic(consensusType),
+	}
+
+	configUpdate, err := update.Compute(config, nextConfig)
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.ChannelId = channelID
+
+	configUpdateEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, channelID, nil,
+		&cb.ConfigUpdateEnvelope{ConfigUpdate: utils.MarshalOrPanic(configUpdate)}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	configEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, channelID, nil,
+		&cb.ConfigEnvelope{Config: nextConfig, LastUpdate: configUpdateEnv}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := msgprocessor.NewMaintenanceFilter(support).Apply(configEnv); err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("[channel: %s] Computed the config update to consensus type %s in %s",
+		channelID, consensusType.Type, consensusType.State)
+
+	return utils.MarshalOrPanic(configUpdate), nil
+}
+
+// findRollbackPoint walks back the config blocks of a channel in maintenance mode, starting from
+// its last config block, and returns the config block that made the channel enter maintenance mode.
+func findRollbackPoint(support ChannelSupport, lastConfigBlock uint64) (*RollbackPoint, error) {
+	var point *RollbackPoint
+	number := lastConfigBlock
+	for {
+		block := support.Block(number)
+		if block == nil {
+			return nil, errors.Errorf("block %d does not exist", number)
+		}
+		consensusType, err := consensusTypeOfBlock(block)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to read the config of block %d", number))
+		}
+		if consensusType.State != orderer.ConsensusType_STATE_MAINTENANCE {
+			break
+		}
+		point = &RollbackPoint{Block: number, ConsensusType: consensusType.Type}
+		if number == 0 {
+			break
+		}
+
+		previous := support.Block(number - 1)
+		if previous == nil {
+			return nil, errors.Errorf("block %d does not exist", number-1)
+		}
+		if number, err = utils.GetLastConfigIndexFromBlock(previous); err != nil {
+			return nil, err
+		}
+	}
+
+	if point == nil {
+		return nil, errors.Errorf("config block %d is not in maintenance mode", lastConfigBlock)
+	}
+	return point, nil
+}
+
+// rollbackConsensusType returns the consensus type of a switched channel at its rollback point,
+// in maintenance mode.
+func rollbackConsensusType(support ChannelSupport, current *orderer.ConsensusType) (*orderer.ConsensusType, error) {
+	height := support.Height()
+	if height == 0 {
+		return nil, errors.New("ledger is empty")
+	}
+	lastConfigBlock, err := utils.GetLastConfigIndexFromBlock(support.Block(height - 1))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the last config block")
+	}
+	point, err := findRollbackPoint(support, lastConfigBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find the rollback point")
+	}
+	consensusType, err := consensusTypeOfBlock(support.Block(point.Block))
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderer.ConsensusType{
+		Type:     consensusType.Type,
+		Metadata: consensusType.Metadata,
+		State:    orderer.ConsensusType_STATE_MAINTENANCE,
+	}, nil
+}
+
+func phaseOf(consensusType *orderer.ConsensusType) Phase {
+	maintenance := consensusType.State == orderer.ConsensusType_STATE_MAINTENANCE
+	switch {
+	case sourceConsensusTypes[consensusType.Type] && maintenance:
+		return PhaseMaintenance
+	case sourceConsensusTypes[consensusType.Type]:
+		return PhaseNormal
+	case consensusType.Type == TargetConsensusType && maintenance:
+		return PhaseSwitched
+	case consensusType.Type == TargetConsensusType:
+		return PhaseCompleted
+	default:
+		return PhaseUnsupported
+	}
+}
+
+func consensusTypeOfBlock(block *cb.Block) (*orderer.ConsensusType, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	if _, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG, configEnv); err != nil {
+		return nil, err
+	}
+	return readConsensusType(configEnv.Config)
+}
+
+func readConsensusType(config *cb.Config) (*orderer.ConsensusType, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("config is missing the channel group")
+	}
+	ordererGroup, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("config is missing the orderer group")
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, errors.Errorf("config is missing the %s value", channelconfig.ConsensusTypeKey)
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the consensus type")
+	}
+	return consensusType, nil
+}
@@ -0,0 +1,402 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/common/capabilities"
+	"github.com/tradeline-tech/fabric/common/channelconfig"
+	"github.com/tradeline-tech/fabric/common/crypto/tlsgen"
+	"github.com/tradeline-tech/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/tradeline-tech/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/tradeline-tech/fabric/common/tools/configtxgen/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/migration"
+	"github.com/tradeline-tech/fabric/orderer/common/migration/mock"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	"github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+var (
+	normal      = orderer.ConsensusType_STATE_NORMAL
+	maintenance = orderer.ConsensusType_STATE_MAINTENANCE
+)
+
+// channel is a ledger of config blocks, followed by normal blocks.
+type channel struct {
+	id      string
+	blocks  []*cb.Block
+	configs []*cb.Config
+}
+
+func newChannel(t *testing.T, id string, migrationCapability bool) *channel {
+	gConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	gConf.Orderer.Capabilities = map[string]bool{capabilities.OrdererV1_1: true}
+	if migrationCapability {
+		gConf.Orderer.Capabilities = map[string]bool{capabilities.OrdererV1_4_2: true}
+	}
+	gConf.Orderer.OrdererType = "kafka"
+	channelGroup, err := encoder.NewChannelGroup(gConf)
+	require.NoError(t, err)
+
+	c := &channel{id: id}
+	c.appendConfig(&cb.Config{ChannelGroup: channelGroup})
+	return c
+}
+
+func (c *channel) lastConfig() *cb.Config {
+	return c.configs[len(c.configs)-1]
+}
+
+func (c *channel) appendBlock(data []byte, lastConfig uint64) {
+	block := cb.NewBlock(uint64(len(c.blocks)), nil)
+	block.Data.Data = [][]byte{data}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: lastConfig}),
+	})
+	c.blocks = append(c.blocks, block)
+}
+
+func (c *channel) appendConfig(config *cb.Config) {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, c.id, nil, &cb.ConfigEnvelope{Config: config}, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+	c.configs = append(c.configs, config)
+	c.appendBlock(utils.MarshalOrPanic(env), uint64(len(c.blocks)))
+}
+
+func (c *channel) appendConsensusType(consensusType *orderer.ConsensusType) {
+	config := proto.Clone(c.lastConfig()).(*cb.Config)
+	value := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey]
+	value.Version++
+	value.Value = utils.MarshalOrPanic(consensusType)
+	c.appendConfig(config)
+}
+
+func (c *channel) appendNormalBlock() {
+	lastConfig, _ := utils.GetLastConfigIndexFromBlock(c.blocks[len(c.blocks)-1])
+	c.appendBlock([]byte("tx"), lastConfig)
+}
+
+func (c *channel) support(t *testing.T) *mock.ChannelSupport {
+	bundle, err := channelconfig.NewBundle(c.id, c.lastConfig())
+	require.NoError(t, err)
+
+	support := &mock.ChannelSupport{}
+	support.ChainIDReturns(c.id)
+	support.HeightReturns(uint64(len(c.blocks)))
+	support.BlockStub = func(number uint64) *cb.Block {
+		if number >= uint64(len(c.blocks)) {
+			return nil
+		}
+		return c.blocks[number]
+	}
+	support.ConfigProtoReturns(c.lastConfig())
+	support.OrdererConfigReturns(bundle.OrdererConfig())
+	return support
+}
+
+func newChannelSource(t *testing.T, systemChannel *channel, channels ...*channel) *mock.ChannelSource {
+	supports := map[string]*mock.ChannelSupport{}
+	list := types.ChannelList{}
+	if systemChannel != nil {
+		list.SystemChannel = &types.ChannelInfoShort{Name: systemChannel.id}
+		supports[systemChannel.id] = systemChannel.support(t)
+	}
+	for _, c := range channels {
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: c.id})
+		supports[c.id] = c.support(t)
+	}
+
+	source := &mock.ChannelSource{}
+	source.ChannelListReturns(list)
+	source.ChannelSupportStub = func(channelID string) (migration.ChannelSupport, bool) {
+		support, ok := supports[channelID]
+		if !ok {
+			return nil, false
+		}
+		return support, true
+	}
+	return source
+}
+
+func raftMetadata(t *testing.T) *etcdraft.ConfigMetadata {
+	tlsCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	kp, err := tlsCA.NewServerCertKeyPair("localhost")
+	require.NoError(t, err)
+
+	return &etcdraft.ConfigMetadata{
+		Consenters: []*etcdraft.Consenter{
+			{Host: "localhost", Port: 7050, ClientTlsCert: kp.Cert, ServerTlsCert: kp.Cert},
+		},
+		Options: &etcdraft.Options{
+			TickInterval:      "500ms",
+			ElectionTick:      10,
+			HeartbeatTick:     1,
+			MaxInflightBlocks: 5,
+		},
+	}
+}
+
+func kafka(state orderer.ConsensusType_State) *orderer.ConsensusType {
+	return &orderer.ConsensusType{Type: "kafka", State: state}
+}
+
+func raft(t *testing.T, state orderer.ConsensusType_State) *orderer.ConsensusType {
+	return &orderer.ConsensusType{Type: "etcdraft", Metadata: utils.MarshalOrPanic(raftMetadata(t)), State: state}
+}
+
+func readConsensusType(t *testing.T, update []byte) *orderer.ConsensusType {
+	configUpdate := &cb.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(update, configUpdate))
+	value := configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey]
+	require.NotNil(t, value)
+	consensusType := &orderer.ConsensusType{}
+	require.NoError(t, proto.Unmarshal(value.Value, consensusType))
+	return consensusType
+}
+
+func TestAssistantStatus(t *testing.T) {
+	t.Run("NoChannels", func(t *testing.T) {
+		status := migration.NewAssistant(newChannelSource(t, nil)).Status()
+		assert.False(t, status.ReadyForSwitch)
+		assert.False(t, status.ReadyForRestart)
+		assert.Empty(t, status.Channels)
+	})
+
+	t.Run("Normal", func(t *testing.T) {
+		sys := newChannel(t, "system", true)
+		app := newChannel(t, "app", false)
+		app.appendNormalBlock()
+
+		status := migration.NewAssistant(newChannelSource(t, sys, app)).Status()
+		assert.False(t, status.ReadyForSwitch)
+		assert.False(t, status.ReadyForRestart)
+		require.Len(t, status.Channels, 2)
+		assert.Equal(t, migration.ChannelStatus{
+			Name:           "system",
+			SystemChannel:  true,
+			ConsensusType:  "kafka",
+			ConsensusState: "STATE_NORMAL",
+			Height:         1,
+			Phase:          migration.PhaseNormal,
+			Ready:          true,
+		}, status.Channels[0])
+		assert.Equal(t, migration.ChannelStatus{
+			Name:           "app",
+			ConsensusType:  "kafka",
+			ConsensusState: "STATE_NORMAL",
+			Height:         2,
+			Phase:          migration.PhaseNormal,
+			Issues:         []string{"the orderer capability of the channel does not enable consensus-type migration"},
+		}, status.Channels[1])
+	})
+
+	t.Run("Maintenance", func(t *testing.T) {
+		sys := newChannel(t, "system", true)
+		sys.appendNormalBlock()
+		sys.appendConsensusType(kafka(maintenance))
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+		app.appendConsensusType(raft(t, maintenance))
+
+		status := migration.NewAssistant(newChannelSource(t, sys, app)).Status()
+		assert.True(t, status.ReadyForSwitch)
+		assert.False(t, status.ReadyForRestart)
+		require.Len(t, status.Channels, 2)
+		assert.Equal(t, migration.ChannelStatus{
+			Name:            "system",
+			SystemChannel:   true,
+			ConsensusType:   "kafka",
+			ConsensusState:  "STATE_MAINTENANCE",
+			Height:          3,
+			LastConfigBlock: 2,
+			Phase:           migration.PhaseMaintenance,
+			Ready:           true,
+			RollbackPoint:   &migration.RollbackPoint{Block: 2, ConsensusType: "kafka"},
+		}, status.Channels[0])
+		assert.Equal(t, migration.ChannelStatus{
+			Name:            "app",
+			ConsensusType:   "etcdraft",
+			ConsensusState:  "STATE_MAINTENANCE",
+			Height:          3,
+			LastConfigBlock: 2,
+			Phase:           migration.PhaseSwitched,
+			Ready:           true,
+			RollbackPoint:   &migration.RollbackPoint{Block: 1, ConsensusType: "kafka"},
+		}, status.Channels[1])
+	})
+
+	t.Run("BlocksAfterMaintenance", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+		app.appendNormalBlock()
+
+		status := migration.NewAssistant(newChannelSource(t, nil, app)).Status()
+		assert.False(t, status.ReadyForSwitch)
+		require.Len(t, status.Channels, 1)
+		assert.False(t, status.Channels[0].Ready)
+		assert.Equal(t, []string{"block 2 was ordered after the last config block 1"}, status.Channels[0].Issues)
+		assert.Equal(t, &migration.RollbackPoint{Block: 1, ConsensusType: "kafka"}, status.Channels[0].RollbackPoint)
+	})
+
+	t.Run("Switched", func(t *testing.T) {
+		sys := newChannel(t, "system", true)
+		sys.appendConsensusType(kafka(maintenance))
+		sys.appendConsensusType(raft(t, maintenance))
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+		app.appendConsensusType(raft(t, maintenance))
+
+		status := migration.NewAssistant(newChannelSource(t, sys, app)).Status()
+		assert.True(t, status.ReadyForSwitch)
+		assert.True(t, status.ReadyForRestart)
+	})
+
+	t.Run("Completed", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+		app.appendConsensusType(raft(t, maintenance))
+		app.appendConsensusType(raft(t, normal))
+
+		status := migration.NewAssistant(newChannelSource(t, nil, app)).Status()
+		assert.False(t, status.ReadyForSwitch)
+		assert.False(t, status.ReadyForRestart)
+		require.Len(t, status.Channels, 1)
+		assert.Equal(t, migration.PhaseCompleted, status.Channels[0].Phase)
+		assert.False(t, status.Channels[0].Ready)
+		assert.Nil(t, status.Channels[0].RollbackPoint)
+		assert.Empty(t, status.Channels[0].Issues)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(&orderer.ConsensusType{Type: "bft"})
+
+		status := migration.NewAssistant(newChannelSource(t, nil, app)).Status()
+		require.Len(t, status.Channels, 1)
+		assert.Equal(t, migration.PhaseUnsupported, status.Channels[0].Phase)
+		assert.Equal(t, []string{"consensus type bft cannot be migrated to etcdraft"}, status.Channels[0].Issues)
+	})
+
+	t.Run("Onboarding", func(t *testing.T) {
+		source := newChannelSource(t, nil, newChannel(t, "app", true))
+		source.ChannelListReturns(types.ChannelList{Channels: []types.ChannelInfoShort{{Name: "app"}, {Name: "joining"}}})
+
+		status := migration.NewAssistant(source).Status()
+		require.Len(t, status.Channels, 1)
+		assert.Equal(t, "app", status.Channels[0].Name)
+	})
+}
+
+func TestAssistantComputeUpdates(t *testing.T) {
+	metadata := raftMetadata(t)
+
+	t.Run("InvalidMetadata", func(t *testing.T) {
+		_, err := migration.NewAssistant(newChannelSource(t, nil)).ComputeUpdates(&etcdraft.ConfigMetadata{})
+		assert.EqualError(t, err, "invalid Raft config metadata: nil Raft config metadata options")
+	})
+
+	t.Run("Normal", func(t *testing.T) {
+		updates, err := migration.NewAssistant(newChannelSource(t, newChannel(t, "system", true))).ComputeUpdates(nil)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Equal(t, "system", updates[0].Name)
+		assert.Equal(t, migration.PhaseNormal, updates[0].Phase)
+		assert.Empty(t, updates[0].Error)
+		assert.Nil(t, updates[0].Rollback)
+
+		configUpdate := &cb.ConfigUpdate{}
+		require.NoError(t, proto.Unmarshal(updates[0].Update, configUpdate))
+		assert.Equal(t, "system", configUpdate.ChannelId)
+		assert.True(t, proto.Equal(kafka(maintenance), readConsensusType(t, updates[0].Update)))
+	})
+
+	t.Run("NormalWithoutCapability", func(t *testing.T) {
+		updates, err := migration.NewAssistant(newChannelSource(t, nil, newChannel(t, "app", false))).ComputeUpdates(nil)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Nil(t, updates[0].Update)
+		assert.Equal(t, "invalid config update: config transaction inspection failed: next config attempted "+
+			"to change ConsensusType.State to STATE_MAINTENANCE, but capability is disabled", updates[0].Error)
+	})
+
+	t.Run("Maintenance", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+
+		updates, err := migration.NewAssistant(newChannelSource(t, nil, app)).ComputeUpdates(metadata)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Empty(t, updates[0].Error)
+		assert.Equal(t, migration.PhaseMaintenance, updates[0].Phase)
+		assert.True(t, proto.Equal(&orderer.ConsensusType{
+			Type:     "etcdraft",
+			Metadata: utils.MarshalOrPanic(metadata),
+			State:    maintenance,
+		}, readConsensusType(t, updates[0].Update)))
+		assert.True(t, proto.Equal(kafka(normal), readConsensusType(t, updates[0].Rollback)))
+	})
+
+	t.Run("MaintenanceWithoutMetadata", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+
+		updates, err := migration.NewAssistant(newChannelSource(t, nil, app)).ComputeUpdates(nil)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Nil(t, updates[0].Update)
+		assert.Equal(t, "no Raft config metadata was provided to switch the consensus type", updates[0].Error)
+	})
+
+	t.Run("Switched", func(t *testing.T) {
+		app := newChannel(t, "app", true)
+		app.appendConsensusType(kafka(maintenance))
+		app.appendConsensusType(raft(t, maintenance))
+		switched := app.lastConfig()
+
+		updates, err := migration.NewAssistant(newChannelSource(t, nil, app)).ComputeUpdates(nil)
+		require.NoError(t, err)
+		require.Len(t, updates, 1)
+		assert.Empty(t, updates[0].Error)
+		assert.Equal(t, migration.PhaseSwitched, updates[0].Phase)
+
+		next := readConsensusType(t, updates[0].Update)
+		assert.Equal(t, "etcdraft", next.Type)
+		assert.Equal(t, normal, next.State)
+		value := switched.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey]
+		current := &orderer.ConsensusType{}
+		require.NoError(t, proto.Unmarshal(value.Value, current))
+		assert.Equal(t, current.Metadata, next.Metadata)
+		assert.True(t, proto.Equal(kafka(maintenance), readConsensusType(t, updates[0].Rollback)))
+	})
+
+	t.Run("CompletedAndUnsupported", func(t *testing.T) {
+		done := newChannel(t, "done", true)
+		done.appendConsensusType(kafka(maintenance))
+		done.appendConsensusType(raft(t, maintenance))
+		done.appendConsensusType(raft(t, normal))
+		other := newChannel(t, "other", true)
+		other.appendConsensusType(&orderer.ConsensusType{Type: "bft"})
+
+		updates, err := migration.NewAssistant(newChannelSource(t, nil, done, other)).ComputeUpdates(metadata)
+		require.NoError(t, err)
+		assert.Equal(t, []migration.ChannelUpdate{
+			{Name: "done", Phase: migration.PhaseCompleted},
+			{Name: "other", Phase: migration.PhaseUnsupported, Error: "consensus type bft cannot be migrated to etcdraft"},
+		}, updates)
+	})
+}
@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	protoetcdraft "github.com/tradeline-tech/fabric/protos/orderer/etcdraft"
+)
+
+const (
+	// URLBaseV1 is the path of the consensus-type migration API.
+	URLBaseV1 = "/migration/v1/"
+	// URLBaseV1Status is the path of the migration status of the channels.
+	URLBaseV1Status = URLBaseV1 + "status"
+	// URLBaseV1Updates is the path of the config updates of the channels.
+	URLBaseV1Updates = URLBaseV1 + "updates"
+	// FormDataConsensusMetadataKey is the key of the multipart form part that carries
+	// the marshaled etcdraft.ConfigMetadata of the channels.
+	FormDataConsensusMetadataKey = "consensus-metadata"
+)
+
+// HTTPHandler serves the consensus-type migration API.
+type HTTPHandler struct {
+	config    localconfig.ConsensusMigration
+	assistant *Assistant
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that assists the migration of the channels of the channel source.
+func NewHTTPHandler(config localconfig.ConsensusMigration, channels ChannelSource) *HTTPHandler {
+	handler := &HTTPHandler{
+		config:    config,
+		assistant: NewAssistant(channels),
+		router:    mux.NewRouter(),
+	}
+
+	handler.router.HandleFunc(URLBaseV1Status, handler.serveStatus).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Updates, handler.serveUpdates).Methods(http.MethodPost)
+	handler.router.NotFoundHandler = http.HandlerFunc(handler.serveNotFound)
+
+	return handler
+}
+
+// ServeHTTP serves the consensus-type migration API.
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(resp, req)
+}
+
+// Report the migration status of all channels
+func (h *HTTPHandler) serveStatus(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponseJSON(resp, http.StatusOK, h.assistant.Status())
+}
+
+// Compute the config updates of all channels, with the Raft metadata optionally carried by the multipart form
+func (h *HTTPHandler) serveUpdates(resp http.ResponseWriter, req *http.Request) {
+	req.Body = http.MaxBytesReader(resp, req.Body, int64(h.config.MaxRequestBodySize))
+	if err := req.ParseMultipartForm(int64(h.config.MaxRequestBodySize)); err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read the multipart form"))
+		return
+	}
+
+	var metadata *protoetcdraft.ConfigMetadata
+	file, _, err := req.FormFile(FormDataConsensusMetadataKey)
+	switch err {
+	case http.ErrMissingFile:
+	case nil:
+		defer file.Close()
+		metadataBytes, err := ioutil.ReadAll(file)
+		if err != nil {
+			h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read the consensus metadata"))
+			return
+		}
+		metadata = &protoetcdraft.ConfigMetadata{}
+		if err := proto.Unmarshal(metadataBytes, metadata); err != nil {
+			h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal the consensus metadata"))
+			return
+		}
+	default:
+		h.sendResponseJSONError(resp, http.StatusBadRequest, errors.Wrapf(err, "cannot read part %s", FormDataConsensusMetadataKey))
+		return
+	}
+
+	updates, err := h.assistant.ComputeUpdates(metadata)
+	if err != nil {
+		h.sendResponseJSONError(resp, http.StatusBadRequest, err)
+		return
+	}
+	h.sendResponseJSON(resp, http.StatusOK, updates)
+}
+
+func (h *HTTPHandler) serveNotFound(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponseJSONError(resp, http.StatusNotFound, errors.Errorf("path %s is not served", req.URL.Path))
+}
+
+func (h *HTTPHandler) sendResponseJSON(resp http.ResponseWriter, code int, content interface{}) {
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		logger.Errorf("Failed encoding the response: %v", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if _, err := resp.Write(encoded); err != nil {
+		logger.Debugf("Failed writing the response: %v", err)
+	}
+}
+
+func (h *HTTPHandler) sendResponseJSONError(resp http.ResponseWriter, code int, err error) {
+	logger.Debugf("Responding with status %d: %v", code, err)
+	h.sendResponseJSON(resp, code, &types.ErrorResponse{Error: err.Error()})
+}
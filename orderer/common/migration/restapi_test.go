@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/migration"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func newHandler(source migration.ChannelSource) *migration.HTTPHandler {
+	config := localconfig.ConsensusMigration{Enabled: true, MaxRequestBodySize: 1024 * 1024}
+	return migration.NewHTTPHandler(config, source)
+}
+
+func updatesRequest(t *testing.T, metadataBytes []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if metadataBytes != nil {
+		part, err := writer.CreateFormFile(migration.FormDataConsensusMetadataKey, "metadata.pb")
+		require.NoError(t, err)
+		_, err = part.Write(metadataBytes)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, migration.URLBaseV1Updates, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func decodeError(t *testing.T, resp *httptest.ResponseRecorder) string {
+	errResp := &types.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	return errResp.Error
+}
+
+func TestHTTPHandlerStatus(t *testing.T) {
+	app := newChannel(t, "app", true)
+	app.appendConsensusType(kafka(maintenance))
+	handler := newHandler(newChannelSource(t, nil, app))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, migration.URLBaseV1Status, nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+	status := migration.Status{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &status))
+	assert.True(t, status.ReadyForSwitch)
+	require.Len(t, status.Channels, 1)
+	assert.Equal(t, migration.PhaseMaintenance, status.Channels[0].Phase)
+	assert.Equal(t, &migration.RollbackPoint{Block: 1, ConsensusType: "kafka"}, status.Channels[0].RollbackPoint)
+}
+
+func TestHTTPHandlerUpdates(t *testing.T) {
+	app := newChannel(t, "app", true)
+	app.appendConsensusType(kafka(maintenance))
+	handler := newHandler(newChannelSource(t, nil, app))
+
+	t.Run("WithMetadata", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, updatesRequest(t, utils.MarshalOrPanic(raftMetadata(t))))
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var updates []migration.ChannelUpdate
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &updates))
+		require.Len(t, updates, 1)
+		assert.Empty(t, updates[0].Error)
+		assert.Equal(t, "etcdraft", readConsensusType(t, updates[0].Update).Type)
+		assert.Equal(t, "kafka", readConsensusType(t, updates[0].Rollback).Type)
+	})
+
+	t.Run("WithoutMetadata", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, updatesRequest(t, nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var updates []migration.ChannelUpdate
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &updates))
+		require.Len(t, updates, 1)
+		assert.Equal(t, "no Raft config metadata was provided to switch the consensus type", updates[0].Error)
+	})
+
+	t.Run("BadMetadata", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, updatesRequest(t, []byte{1, 2, 3}))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, decodeError(t, resp), "cannot unmarshal the consensus metadata")
+	})
+
+	t.Run("InvalidMetadata", func(t *testing.T) {
+		metadata := raftMetadata(t)
+		metadata.Consenters = nil
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, updatesRequest(t, utils.MarshalOrPanic(metadata)))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, "invalid Raft config metadata: empty consenter set", decodeError(t, resp))
+	})
+
+	t.Run("NotMultipart", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, migration.URLBaseV1Updates, nil))
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, decodeError(t, resp), "cannot read the multipart form")
+	})
+}
+
+func TestHTTPHandlerNotFound(t *testing.T) {
+	handler := newHandler(newChannelSource(t, nil))
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, migration.URLBaseV1+"unknown", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "path /migration/v1/unknown is not served", decodeError(t, resp))
+}
@@ -185,7 +185,11 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 	}
 
 	bw.addLastConfigSignature(bw.lastBlock)
-	bw.addBlockSignature(bw.lastBlock)
+	// Consenters that collect the signatures of several orderers over a block
+	// set them in the block before it is written, and such blocks are not signed again.
+	if len(bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES]) == 0 {
+		bw.addBlockSignature(bw.lastBlock)
+	}
 
 	err := bw.support.Append(bw.lastBlock)
 	if err != nil {
@@ -9,6 +9,7 @@ package multichannel
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/tradeline-tech/fabric/common/channelconfig"
@@ -95,6 +96,38 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignaturesOfConsenters(t *testing.T) {
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	block := cb.NewBlock(1, lastBlock.Header.Hash())
+	signatures := &cb.Metadata{
+		Value: []byte("value"),
+		Signatures: []*cb.MetadataSignature{
+			{SignatureHeader: []byte("header1"), Signature: []byte("signature1")},
+			{SignatureHeader: []byte("header2"), Signature: []byte("signature2")},
+		},
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+		},
+		lastBlock: block,
+	}
+	bw.commitBlock([]byte("bar"))
+
+	committedBlock := blockledger.GetBlock(l, 1)
+	md := utils.GetMetadataFromBlockOrPanic(committedBlock, cb.BlockMetadataIndex_SIGNATURES)
+	assert.True(t, proto.Equal(signatures, md), "The signatures of the consenters are kept")
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	cb "github.com/tradeline-tech/fabric/protos/common"
+)
+
+const (
+	// joinBlocksDir is the directory, relative to the ledger location, of the join blocks
+	// of the channels being onboarded.
+	joinBlocksDir = "pendingops/join"
+	// joinBlockSuffix is the suffix of the files holding the join blocks.
+	joinBlockSuffix = ".join"
+)
+
+// joinBlockStore persists the join blocks of the channels being onboarded, so that onboarding
+// survives a restart of the orderer. A store without a directory keeps nothing, as the ledgers
+// do not survive a restart either.
+type joinBlockStore struct {
+	dir string
+}
+
+func newJoinBlockStore(ledgerDir string) (*joinBlockStore, error) {
+	if ledgerDir == "" {
+		return &joinBlockStore{}, nil
+	}
+	dir := filepath.Join(ledgerDir, joinBlocksDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed to create the join blocks directory %s", dir)
+	}
+	return &joinBlockStore{dir: dir}, nil
+}
+
+// Save persists the join block of the channel, replacing any previous one.
+func (s *joinBlockStore) Save(channelID string, joinBlock *cb.Block) error {
+	if s.dir == "" {
+		return nil
+	}
+	blockBytes, err := proto.Marshal(joinBlock)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the join block of channel %s", channelID)
+	}
+	path := s.path(channelID)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, blockBytes, 0640); err != nil {
+		return errors.Wrapf(err, "failed to write the join block of channel %s", channelID)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "failed to write the join block of channel %s", channelID)
+	}
+	return nil
+}
+
+// Remove removes the join block of the channel, if any.
+func (s *joinBlockStore) Remove(channelID string) error {
+	if s.dir == "" {
+		return nil
+	}
+	if err := os.Remove(s.path(channelID)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove the join block of channel %s", channelID)
+	}
+	return nil
+}
+
+// List returns the persisted join blocks by channel.
+func (s *joinBlockStore) List() (map[string]*cb.Block, error) {
+	joinBlocks := map[string]*cb.Block{}
+	if s.dir == "" {
+		return joinBlocks, nil
+	}
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the join blocks directory %s", s.dir)
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), joinBlockSuffix) {
+			continue
+		}
+		channelID := strings.TrimSuffix(file.Name(), joinBlockSuffix)
+		blockBytes, err := ioutil.ReadFile(filepath.Join(s.dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the join block of channel %s", channelID)
+		}
+		joinBlock := &cb.Block{}
+		if err := proto.Unmarshal(blockBytes, joinBlock); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the join block of channel %s", channelID)
+		}
+		joinBlocks[channelID] = joinBlock
+	}
+	return joinBlocks, nil
+}
+
+func (s *joinBlockStore) path(channelID string) string {
+	return filepath.Join(s.dir, channelID+joinBlockSuffix)
+}
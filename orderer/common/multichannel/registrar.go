@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -24,7 +25,9 @@ import (
 	"github.com/tradeline-tech/fabric/orderer/common/blockcutter"
 	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
 	"github.com/tradeline-tech/fabric/orderer/common/msgprocessor"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
 	"github.com/tradeline-tech/fabric/orderer/consensus"
+	"github.com/tradeline-tech/fabric/orderer/consensus/inactive"
 	cb "github.com/tradeline-tech/fabric/protos/common"
 	ab "github.com/tradeline-tech/fabric/protos/orderer"
 	"github.com/tradeline-tech/fabric/protos/utils"
@@ -35,6 +38,9 @@ const (
 	epoch      = 0
 )
 
+// channelParticipationURL is the path of the channels in the channel participation API
+const channelParticipationURL = "/participation/v1/channels/"
+
 var logger = flogging.MustGetLogger("orderer.commmon.multichannel")
 
 // checkResources makes sure that the channel config is compatible with this binary and logs sanity checks
@@ -104,6 +110,23 @@ type Registrar struct {
 	systemChannel      *ChainSupport
 	templator          msgprocessor.ChannelConfigTemplator
 	callbacks          []channelconfig.BundleActor
+	channelReplicator  ChannelReplicator
+	joining            map[string]*joiningChannel
+	joinBlocks         *joinBlockStore
+}
+
+// ChannelReplicator replicates the blocks of a channel from the other orderers of the channel.
+type ChannelReplicator interface {
+	// ReplicateChannel pulls the blocks of the channel up to, and including, the join block
+	// and commits them to the ledger of the channel.
+	ReplicateChannel(channelID string, joinBlock *cb.Block) error
+}
+
+// joiningChannel is a channel joined with a config block other than its genesis block,
+// whose preceding blocks are being replicated.
+type joiningChannel struct {
+	joinBlock *cb.Block
+	err       error
 }
 
 // ConfigBlock retrieves the last configuration block from the given ledger.
@@ -133,6 +156,15 @@ func NewRegistrar(
 	signer crypto.LocalSigner,
 	metricsProvider metrics.Provider,
 	callbacks ...channelconfig.BundleActor) *Registrar {
+	var ledgerDir string
+	if config.General.LedgerType != "ram" {
+		ledgerDir = config.FileLedger.Location
+	}
+	joinBlocks, err := newJoinBlockStore(ledgerDir)
+	if err != nil {
+		logger.Panicf("Failed to open the join blocks store: %s", err)
+	}
+
 	r := &Registrar{
 		config:             config,
 		chains:             make(map[string]*ChainSupport),
@@ -140,6 +172,8 @@ func NewRegistrar(
 		signer:             signer,
 		blockcutterMetrics: blockcutter.NewMetrics(metricsProvider),
 		callbacks:          callbacks,
+		joining:            make(map[string]*joiningChannel),
+		joinBlocks:         joinBlocks,
 	}
 
 	return r
@@ -148,12 +182,26 @@ func NewRegistrar(
 func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	r.consenters = consenters
 	existingChains := r.ledgerFactory.ChainIDs()
+	pendingJoins, err := r.joinBlocks.List()
+	if err != nil {
+		logger.Panicf("Failed to read the join blocks of the channels being onboarded: %s", err)
+	}
 
 	for _, chainID := range existingChains {
 		rl, err := r.ledgerFactory.GetOrCreate(chainID)
 		if err != nil {
 			logger.Panicf("Ledger factory reported chainID %s but could not retrieve it: %s", chainID, err)
 		}
+		if joinBlock, ok := pendingJoins[chainID]; ok {
+			if rl.Height() <= joinBlock.Header.Number {
+				// Onboarding was interrupted, it is resumed below
+				continue
+			}
+			if err := r.joinBlocks.Remove(chainID); err != nil {
+				logger.Panicf("Failed to remove the join block of onboarded channel %s: %s", chainID, err)
+			}
+			delete(pendingJoins, chainID)
+		}
 		configTx := configTx(rl)
 		if configTx == nil {
 			logger.Panic("Programming error, configTx should never be nil here")
@@ -210,10 +258,50 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		if r.config.General.GenesisMethod != "none" {
+			logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		}
+		logger.Infof("Starting without a system channel, %d channels are managed through the channel participation API", len(r.chains))
+	}
+
+	for channelID, joinBlock := range pendingJoins {
+		r.resumeOnboarding(channelID, joinBlock)
 	}
 }
 
+// resumeOnboarding resumes the onboarding of a channel that was interrupted by a restart, or
+// discards it along with its partial ledger if onboarding is no longer available.
+func (r *Registrar) resumeOnboarding(channelID string, joinBlock *cb.Block) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.channelReplicator == nil {
+		logger.Warningf("Discarding the interrupted onboarding of channel %s, as onboarding is not available", channelID)
+		if r.hasLedger(channelID) {
+			if err := r.removeLedger(channelID); err != nil {
+				logger.Panicf("Failed to discard the partial ledger of channel %s: %s", channelID, err)
+			}
+		}
+		if err := r.joinBlocks.Remove(channelID); err != nil {
+			logger.Panicf("Failed to discard the join block of channel %s: %s", channelID, err)
+		}
+		return
+	}
+
+	logger.Infof("Resuming the onboarding of channel %s up to config block [%d]", channelID, joinBlock.Header.Number)
+	r.joining[channelID] = &joiningChannel{joinBlock: joinBlock}
+	go r.onboard(channelID, joinBlock)
+}
+
+// SetChannelReplicator sets the replicator used for onboarding the channels joined
+// with a config block other than their genesis block.
+func (r *Registrar) SetChannelReplicator(channelReplicator ChannelReplicator) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.channelReplicator = channelReplicator
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -231,6 +319,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 	cs := r.GetChain(chdr.ChannelId)
 	// New channel creation
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.Errorf("channel %s does not exist and there is no system channel to create it", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -313,6 +404,12 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	r.startChain(configtx)
+}
+
+// startChain creates and starts the chain of the config transaction, it must be
+// invoked with the lock held.
+func (r *Registrar) startChain(configtx *cb.Envelope) {
 	ledgerResources := r.newLedgerResources(configtx)
 	// If we have no blocks, we need to create the genesis block ourselves.
 	if ledgerResources.Height() == 0 {
@@ -353,3 +450,272 @@ func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfi
 func (r *Registrar) CreateBundle(channelID string, config *cb.Config) (channelconfig.Resources, error) {
 	return channelconfig.NewBundle(channelID, config)
 }
+
+// JoinChannel makes the orderer serve the channel of the given config block, provided that the
+// orderer runs without a system channel. If the config block is the genesis block of the channel
+// the chain is started right away, otherwise the blocks that precede the config block are first
+// replicated from the other orderers of the channel, while the channel is reported as onboarding.
+func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (types.ChannelInfo, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.systemChannelID != "" {
+		return types.ChannelInfo{}, types.ErrSystemChannelExists
+	}
+	if r.channelExists(channelID) {
+		return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+	}
+
+	bundle, err := bundleFromConfigBlock(configBlock)
+	if err != nil {
+		return types.ChannelInfo{}, errors.WithMessage(err, "failed to create the channel config of the config block")
+	}
+	if bundle.ConfigtxValidator().ChainID() != channelID {
+		return types.ChannelInfo{}, errors.Errorf("config block is of channel %s, not of channel %s",
+			bundle.ConfigtxValidator().ChainID(), channelID)
+	}
+	if err := checkResources(bundle); err != nil {
+		return types.ChannelInfo{}, err
+	}
+	oc, _ := bundle.OrdererConfig()
+	consensusType := oc.ConsensusType()
+	if _, ok := r.consenters[consensusType]; !ok {
+		return types.ChannelInfo{}, errors.Errorf("consensus type %s is not supported by this orderer", consensusType)
+	}
+
+	info := types.ChannelInfo{
+		Name: channelID,
+		URL:  channelParticipationURL + channelID,
+	}
+
+	if configBlock.Header.Number == 0 {
+		ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+		if err != nil {
+			return types.ChannelInfo{}, errors.WithMessage(err, fmt.Sprintf("failed to create the ledger of channel %s", channelID))
+		}
+		if err := ledger.Append(configBlock); err != nil {
+			return types.ChannelInfo{}, errors.WithMessage(err, fmt.Sprintf("failed to append the genesis block of channel %s", channelID))
+		}
+		logger.Infof("Joining channel %s with its genesis block", channelID)
+		r.startChain(utils.ExtractEnvelopeOrPanic(configBlock, 0))
+		info.Status = types.StatusActive
+		info.Height = ledger.Height()
+		return info, nil
+	}
+
+	if r.channelReplicator == nil {
+		return types.ChannelInfo{}, errors.Errorf("channel %s can only be joined with its genesis block, "+
+			"as onboarding is not available for consensus type %s", channelID, consensusType)
+	}
+
+	if err := r.joinBlocks.Save(channelID, configBlock); err != nil {
+		return types.ChannelInfo{}, err
+	}
+	logger.Infof("Joining channel %s with config block [%d], onboarding the preceding blocks", channelID, configBlock.Header.Number)
+	r.joining[channelID] = &joiningChannel{joinBlock: configBlock}
+	go r.onboard(channelID, configBlock)
+
+	info.Status = types.StatusOnboarding
+	return info, nil
+}
+
+// onboard replicates the blocks of the channel up to the join block and then starts the chain.
+func (r *Registrar) onboard(channelID string, joinBlock *cb.Block) {
+	err := r.channelReplicator.ReplicateChannel(channelID, joinBlock)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err != nil {
+		logger.Errorf("Failed onboarding channel %s: %v", channelID, err)
+		r.joining[channelID].err = err
+		return
+	}
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		logger.Errorf("Failed obtaining the ledger of onboarded channel %s: %v", channelID, err)
+		r.joining[channelID].err = err
+		return
+	}
+
+	delete(r.joining, channelID)
+	if err := r.joinBlocks.Remove(channelID); err != nil {
+		logger.Errorf("Failed removing the join block of onboarded channel %s: %v", channelID, err)
+	}
+	logger.Infof("Onboarded channel %s up to block [%d]", channelID, joinBlock.Header.Number)
+	r.startChain(configTx(ledger))
+}
+
+// RemoveChannel makes the orderer stop serving the channel and removes its ledger, provided
+// that the orderer runs without a system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.systemChannelID != "" {
+		return types.ErrSystemChannelExists
+	}
+
+	if jc, ok := r.joining[channelID]; ok {
+		if jc.err == nil {
+			return types.ErrChannelOnboarding
+		}
+		delete(r.joining, channelID)
+		if err := r.joinBlocks.Remove(channelID); err != nil {
+			return err
+		}
+		return r.removeLedger(channelID)
+	}
+
+	cs, ok := r.chains[channelID]
+	if !ok {
+		return types.ErrChannelNotExist
+	}
+
+	cs.Halt()
+
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+
+	consenter := r.consenters[cs.SharedConfig().ConsensusType()]
+	if remover, ok := consenter.(consensus.ChannelStorageRemover); ok {
+		if err := remover.RemoveChannelStorage(channelID); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to remove the consensus storage of channel %s", channelID))
+		}
+	}
+
+	logger.Infof("Removed channel %s", channelID)
+	return r.removeLedger(channelID)
+}
+
+func (r *Registrar) removeLedger(channelID string) error {
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to remove the ledger of channel %s", channelID))
+	}
+	return nil
+}
+
+// channelExists tells whether the orderer serves, onboards or has a ledger for the channel,
+// it must be invoked with the lock held.
+func (r *Registrar) channelExists(channelID string) bool {
+	if _, ok := r.chains[channelID]; ok {
+		return true
+	}
+	if _, ok := r.joining[channelID]; ok {
+		return true
+	}
+	return r.hasLedger(channelID)
+}
+
+// hasLedger tells whether the ledger factory holds a ledger for the channel, without creating it.
+func (r *Registrar) hasLedger(channelID string) bool {
+	for _, existing := range r.ledgerFactory.ChainIDs() {
+		if existing == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelList returns the channels served or onboarded by the orderer, sorted by name.
+func (r *Registrar) ChannelList() types.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := types.ChannelList{}
+	if r.systemChannelID != "" {
+		list.SystemChannel = &types.ChannelInfoShort{
+			Name: r.systemChannelID,
+			URL:  channelParticipationURL + r.systemChannelID,
+		}
+	}
+	for name := range r.chains {
+		if name != r.systemChannelID {
+			list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name, URL: channelParticipationURL + name})
+		}
+	}
+	for name := range r.joining {
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name, URL: channelParticipationURL + name})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+
+	return list
+}
+
+// ChannelInfo returns the status and the ledger height of a channel served or onboarded by the orderer.
+func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	info := types.ChannelInfo{
+		Name: channelID,
+		URL:  channelParticipationURL + channelID,
+	}
+
+	if jc, ok := r.joining[channelID]; ok {
+		info.Status = types.StatusOnboarding
+		if jc.err != nil {
+			info.Status = types.StatusFailed
+			info.Error = jc.err.Error()
+		}
+		// The ledger is only looked up once the replication has created it,
+		// so that querying the channel never creates a ledger.
+		if r.hasLedger(channelID) {
+			if ledger, err := r.ledgerFactory.GetOrCreate(channelID); err == nil {
+				info.Height = ledger.Height()
+			}
+		}
+		return info, nil
+	}
+
+	cs, ok := r.chains[channelID]
+	if !ok {
+		return types.ChannelInfo{}, types.ErrChannelNotExist
+	}
+
+	info.Status = types.StatusActive
+	if _, isInactive := cs.Chain.(*inactive.Chain); isInactive {
+		info.Status = types.StatusInactive
+	}
+	info.Height = cs.Height()
+
+	return info, nil
+}
+
+// bundleFromConfigBlock creates the channel config of a config block.
+func bundleFromConfigBlock(block *cb.Block) (*channelconfig.Bundle, error) {
+	if block == nil || block.Header == nil {
+		return nil, errors.New("block is missing its header")
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing channel header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block is not a config block, its transaction is of type %d", chdr.Type)
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	return channelconfig.NewBundle(chdr.ChannelId, configEnvelope.Config)
+}
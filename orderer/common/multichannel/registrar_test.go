@@ -7,11 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tradeline-tech/fabric/common/crypto"
 	"github.com/tradeline-tech/fabric/common/ledger/blockledger"
@@ -25,6 +30,7 @@ import (
 	genesisconfig "github.com/tradeline-tech/fabric/common/tools/configtxgen/localconfig"
 	"github.com/tradeline-tech/fabric/orderer/common/blockcutter"
 	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/types"
 	"github.com/tradeline-tech/fabric/orderer/consensus"
 	cb "github.com/tradeline-tech/fabric/protos/common"
 	ab "github.com/tradeline-tech/fabric/protos/orderer"
@@ -391,3 +397,311 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 		assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 	})
 }
+
+type mockChannelReplicator struct {
+	lf       blockledger.Factory
+	blocks   []*cb.Block
+	err      error
+	proceedC chan struct{}
+}
+
+func (mcr *mockChannelReplicator) ReplicateChannel(channelID string, joinBlock *cb.Block) error {
+	<-mcr.proceedC
+	if mcr.err != nil {
+		return mcr.err
+	}
+	ledger, err := mcr.lf.GetOrCreate(channelID)
+	if err != nil {
+		return err
+	}
+	for _, block := range mcr.blocks {
+		if err := ledger.Append(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitFor polls condition until it holds or a minute has elapsed
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Minute)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRegistrarWithoutSystemChannel(t *testing.T) {
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp.Consortiums = nil
+	genesisBlockApp := encoder.New(confApp).GenesisBlockForChannel("app")
+	conf := localconfig.TopLevel{}
+	conf.General.GenesisMethod = "none"
+
+	newRegistrar := func() (*Registrar, blockledger.Factory) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}})
+		return registrar, lf
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		registrar, _ := newRegistrar()
+		assert.Equal(t, "", registrar.SystemChannelID())
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+
+		_, _, _, err := registrar.BroadcastChannelSupport(makeConfigTx("app", 1))
+		assert.EqualError(t, err, "channel app does not exist and there is no system channel to create it")
+
+		_, err = registrar.ChannelInfo("app")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+		assert.Equal(t, types.ErrChannelNotExist, registrar.RemoveChannel("app"))
+	})
+
+	t.Run("Join with genesis block and remove", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+
+		info, err := registrar.JoinChannel("app", genesisBlockApp)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "app", URL: "/participation/v1/channels/app", Status: types.StatusActive, Height: 1}, info)
+		assert.NotNil(t, registrar.GetChain("app"))
+
+		_, err = registrar.JoinChannel("app", genesisBlockApp)
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "app", URL: "/participation/v1/channels/app"}},
+		}, registrar.ChannelList())
+		info, err = registrar.ChannelInfo("app")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusActive, info.Status)
+		assert.Equal(t, uint64(1), info.Height)
+
+		assert.NoError(t, registrar.RemoveChannel("app"))
+		assert.Nil(t, registrar.GetChain("app"))
+		assert.Empty(t, lf.ChainIDs())
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+		assert.Equal(t, types.ErrChannelNotExist, registrar.RemoveChannel("app"))
+
+		// the channel can be joined again once removed
+		_, err = registrar.JoinChannel("app", genesisBlockApp)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Join with invalid block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+
+		_, err := registrar.JoinChannel("other", genesisBlockApp)
+		assert.EqualError(t, err, "config block is of channel app, not of channel other")
+
+		_, err = registrar.JoinChannel("app", &cb.Block{Header: &cb.BlockHeader{}})
+		assert.EqualError(t, err, "failed to create the channel config of the config block: block data is nil")
+
+		confKafka := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+		confKafka.Consortiums = nil
+		confKafka.Orderer.OrdererType = "kafka"
+		_, err = registrar.JoinChannel("app", encoder.New(confKafka).GenesisBlockForChannel("app"))
+		assert.EqualError(t, err, "consensus type kafka is not supported by this orderer")
+
+		assert.Empty(t, lf.ChainIDs())
+	})
+
+	t.Run("Join with later config block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		joinBlock := proto.Clone(genesisBlockApp).(*cb.Block)
+		joinBlock.Header.Number = 3
+
+		_, err := registrar.JoinChannel("app", joinBlock)
+		assert.EqualError(t, err, "channel app can only be joined with its genesis block, as onboarding is not available for consensus type solo")
+
+		replicator := &mockChannelReplicator{lf: lf, blocks: []*cb.Block{genesisBlockApp}, proceedC: make(chan struct{})}
+		registrar.SetChannelReplicator(replicator)
+
+		info, err := registrar.JoinChannel("app", joinBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+		assert.Nil(t, registrar.GetChain("app"))
+
+		info, err = registrar.ChannelInfo("app")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+		assert.Equal(t, uint64(0), info.Height)
+		assert.Empty(t, lf.ChainIDs(), "querying an onboarding channel must not create its ledger")
+		assert.Equal(t, types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "app", URL: "/participation/v1/channels/app"}},
+		}, registrar.ChannelList())
+		assert.Equal(t, types.ErrChannelOnboarding, registrar.RemoveChannel("app"))
+		_, err = registrar.JoinChannel("app", joinBlock)
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+
+		close(replicator.proceedC)
+		waitFor(t, func() bool { return registrar.GetChain("app") != nil })
+		info, err = registrar.ChannelInfo("app")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusActive, info.Status)
+	})
+
+	t.Run("Onboarding fails", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+		joinBlock := proto.Clone(genesisBlockApp).(*cb.Block)
+		joinBlock.Header.Number = 3
+
+		replicator := &mockChannelReplicator{lf: lf, err: errors.New("no orderer reachable"), proceedC: make(chan struct{})}
+		close(replicator.proceedC)
+		registrar.SetChannelReplicator(replicator)
+
+		_, err := registrar.JoinChannel("app", joinBlock)
+		assert.NoError(t, err)
+		waitFor(t, func() bool {
+			info, err := registrar.ChannelInfo("app")
+			return err == nil && info.Status == types.StatusFailed
+		})
+		info, _ := registrar.ChannelInfo("app")
+		assert.Equal(t, "no orderer reachable", info.Error)
+
+		assert.NoError(t, registrar.RemoveChannel("app"))
+		assert.Empty(t, lf.ChainIDs())
+		_, err = registrar.ChannelInfo("app")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+	})
+}
+
+func TestRegistrarResumesOnboarding(t *testing.T) {
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp.Consortiums = nil
+	genesisBlockApp := encoder.New(confApp).GenesisBlockForChannel("app")
+	joinBlock := proto.Clone(genesisBlockApp).(*cb.Block)
+	joinBlock.Header.Number = 3
+	consenters := map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}}
+
+	newConf := func(t *testing.T) (localconfig.TopLevel, func()) {
+		dir, err := ioutil.TempDir("", "registrar")
+		require.NoError(t, err)
+		conf := localconfig.TopLevel{}
+		conf.General.GenesisMethod = "none"
+		conf.General.LedgerType = "file"
+		conf.FileLedger.Location = dir
+		return conf, func() { os.RemoveAll(dir) }
+	}
+	joinBlockPath := func(conf localconfig.TopLevel) string {
+		return filepath.Join(conf.FileLedger.Location, "pendingops", "join", "app.join")
+	}
+
+	// joinAndRestart joins the channel with the join block and returns a new registrar over the
+	// same ledgers, as if the orderer was restarted while the channel was onboarding.
+	joinAndRestart := func(t *testing.T, conf localconfig.TopLevel, replicator ChannelReplicator) (*Registrar, blockledger.Factory) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		registrar.SetChannelReplicator(&mockChannelReplicator{lf: lf, proceedC: make(chan struct{})})
+		_, err := registrar.JoinChannel("app", joinBlock)
+		require.NoError(t, err)
+		assert.FileExists(t, joinBlockPath(conf))
+
+		// the blocks replicated before the restart
+		ledger, err := lf.GetOrCreate("app")
+		require.NoError(t, err)
+		require.NoError(t, ledger.Append(genesisBlockApp))
+
+		registrar = NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		if replicator != nil {
+			registrar.SetChannelReplicator(replicator)
+		}
+		registrar.Initialize(consenters)
+		return registrar, lf
+	}
+
+	t.Run("Onboarding resumes", func(t *testing.T) {
+		conf, cleanup := newConf(t)
+		defer cleanup()
+
+		replicator := &mockChannelReplicator{proceedC: make(chan struct{})}
+		registrar, lf := joinAndRestart(t, conf, replicator)
+		replicator.lf = lf
+
+		assert.Nil(t, registrar.GetChain("app"))
+		info, err := registrar.ChannelInfo("app")
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+
+		close(replicator.proceedC)
+		waitFor(t, func() bool { return registrar.GetChain("app") != nil })
+		_, err = os.Stat(joinBlockPath(conf))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Onboarding is discarded without a replicator", func(t *testing.T) {
+		conf, cleanup := newConf(t)
+		defer cleanup()
+
+		registrar, lf := joinAndRestart(t, conf, nil)
+		assert.Empty(t, lf.ChainIDs())
+		_, err := registrar.ChannelInfo("app")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+		_, err = os.Stat(joinBlockPath(conf))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Onboarded channel with a leftover join block", func(t *testing.T) {
+		conf, cleanup := newConf(t)
+		defer cleanup()
+
+		lf, _ := newRAMLedgerAndFactory(10, "app", genesisBlockApp)
+		store, err := newJoinBlockStore(conf.FileLedger.Location)
+		require.NoError(t, err)
+		require.NoError(t, store.Save("app", genesisBlockApp))
+
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		assert.NotNil(t, registrar.GetChain("app"))
+		_, err = os.Stat(joinBlockPath(conf))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Removing a failed onboarding removes its join block", func(t *testing.T) {
+		conf, cleanup := newConf(t)
+		defer cleanup()
+
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		replicator := &mockChannelReplicator{lf: lf, err: errors.New("no orderer reachable"), proceedC: make(chan struct{})}
+		close(replicator.proceedC)
+		registrar.SetChannelReplicator(replicator)
+
+		_, err := registrar.JoinChannel("app", joinBlock)
+		require.NoError(t, err)
+		waitFor(t, func() bool {
+			info, err := registrar.ChannelInfo("app")
+			return err == nil && info.Status == types.StatusFailed
+		})
+		assert.FileExists(t, joinBlockPath(conf))
+		assert.NoError(t, registrar.RemoveChannel("app"))
+		_, err = os.Stat(joinBlockPath(conf))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestRegistrarWithSystemChannel(t *testing.T) {
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+
+	lf, _ := newRAMLedgerAndFactory(10, genesisconfig.TestChainID, genesisBlockSys)
+	registrar := NewRegistrar(localconfig.TopLevel{}, lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}})
+
+	assert.Equal(t, types.ChannelList{
+		SystemChannel: &types.ChannelInfoShort{Name: genesisconfig.TestChainID, URL: "/participation/v1/channels/" + genesisconfig.TestChainID},
+	}, registrar.ChannelList())
+	info, err := registrar.ChannelInfo(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.StatusActive, info.Status)
+
+	_, err = registrar.JoinChannel("app", genesisBlockSys)
+	assert.Equal(t, types.ErrSystemChannelExists, err)
+	assert.Equal(t, types.ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+}
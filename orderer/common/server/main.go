@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -44,11 +45,14 @@ import (
 	"github.com/tradeline-tech/fabric/msp"
 	mspmgmt "github.com/tradeline-tech/fabric/msp/mgmt"
 	"github.com/tradeline-tech/fabric/orderer/common/bootstrap/file"
+	"github.com/tradeline-tech/fabric/orderer/common/channelparticipation"
 	"github.com/tradeline-tech/fabric/orderer/common/cluster"
 	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
 	"github.com/tradeline-tech/fabric/orderer/common/metadata"
+	"github.com/tradeline-tech/fabric/orderer/common/migration"
 	"github.com/tradeline-tech/fabric/orderer/common/multichannel"
 	"github.com/tradeline-tech/fabric/orderer/consensus"
+	"github.com/tradeline-tech/fabric/orderer/consensus/bft"
 	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft"
 	"github.com/tradeline-tech/fabric/orderer/consensus/kafka"
 	"github.com/tradeline-tech/fabric/orderer/consensus/solo"
@@ -67,7 +71,7 @@ var (
 	version   = app.Command("version", "Show version information")
 	benchmark = app.Command("benchmark", "Run orderer in benchmark mode")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "bft": {}}
 )
 
 // Main is the entry point of orderer process
@@ -94,9 +98,18 @@ func Main() {
 
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
+	if err := checkChannelParticipationTLS(conf); err != nil {
+		logger.Panicf("Invalid channel participation configuration: %v", err)
+	}
+	if err := checkConsensusMigrationTLS(conf); err != nil {
+		logger.Panicf("Invalid consensus-type migration configuration: %v", err)
+	}
+
 	bootstrapBlock := extractBootstrapBlock(conf)
-	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
-		logger.Panicf("Failed validating bootstrap block: %v", err)
+	if bootstrapBlock != nil {
+		if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
+			logger.Panicf("Failed validating bootstrap block: %v", err)
+		}
 	}
 
 	opsSystem := newOperationsSystem(conf.Operations, conf.Metrics)
@@ -131,10 +144,15 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	var clusterDialer *cluster.PredicateDialer
 
 	var reuseGrpcListener bool
-	typ := consensusType(bootstrapBlock)
 	var serversToUpdate []*comm.GRPCServer
 
-	clusterType := isClusterType(clusterBootBlock)
+	// Without a system channel the channels are joined through the channel participation API,
+	// and the orderer is set up as a cluster member so it can onboard the channels it joins.
+	typ, clusterType := "etcdraft", true
+	if bootstrapBlock != nil {
+		typ = consensusType(bootstrapBlock)
+		clusterType = isClusterType(clusterBootBlock)
+	}
 	if clusterType {
 		logger.Infof("Setting up cluster for orderer type %s", typ)
 
@@ -190,7 +208,18 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	manager := initializeMultichannelRegistrar(clusterBootBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	expiration := conf.General.Authentication.NoExpirationChecks
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration)
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration, conf.General.RateLimiting)
+
+	if conf.ChannelParticipation.Enabled {
+		opsSystem.RegisterHandler(channelparticipation.URLBaseV1, channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager))
+	} else if bootstrapBlock == nil {
+		logger.Warning("Started without a system channel and with the channel participation API disabled, " +
+			"no channel can be joined")
+	}
+
+	if conf.ConsensusMigration.Enabled {
+		opsSystem.RegisterHandler(migration.URLBaseV1, migration.NewHTTPHandler(conf.ConsensusMigration, &migrationChannels{Registrar: manager}))
+	}
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -238,6 +267,11 @@ func reuseListener(conf *localconfig.TopLevel, typ string) bool {
 
 // Extract system channel last config block
 func extractSysChanLastConfig(lf blockledger.Factory, bootstrapBlock *cb.Block) *cb.Block {
+	if bootstrapBlock == nil {
+		logger.Info("No system channel, channels are managed through the channel participation API")
+		return nil
+	}
+
 	// Are we bootstrapping?
 	chainCount := len(lf.ChainIDs())
 	if chainCount == 0 {
@@ -297,15 +331,16 @@ func createReplicator(
 		logger:        logger,
 	}
 
-	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-
 	// System channel is not verified because we trust the bootstrap block
 	// and use backward hash chain verification.
 	verifiersByChannel := vl.loadVerifiers()
-	verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	if bootstrapBlock != nil {
+		systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+		verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	}
 
 	vr := &cluster.VerificationRegistry{
 		LoadVerifier:       vl.loadVerifier,
@@ -326,6 +361,11 @@ func createReplicator(
 		conf:              conf,
 		lf:                ledgerFactory,
 		signer:            signer,
+		// Channels joined through the channel participation API are not verified either,
+		// because we trust the join block and use backward hash chain verification.
+		trustChain: func(chain string) {
+			vr.SetVerifier(chain, &cluster.NoopBlockVerifier{})
+		},
 	}
 }
 
@@ -565,6 +605,8 @@ func extractBootstrapBlock(conf *localconfig.TopLevel) *cb.Block {
 		bootstrapBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		bootstrapBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		// There is no system channel, the channels are joined through the channel participation API
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -587,6 +629,32 @@ func initializeBootstrapChannel(genesisBlock *cb.Block, lf blockledger.Factory)
 	}
 }
 
+// checkChannelParticipationTLS returns an error if the channel participation API is enabled
+// without mutual TLS on the operations server, as the API would then be open to anyone.
+func checkChannelParticipationTLS(conf *localconfig.TopLevel) error {
+	if !conf.ChannelParticipation.Enabled {
+		return nil
+	}
+	if !conf.Operations.TLS.Enabled || !conf.Operations.TLS.ClientAuthRequired {
+		return errors.New("the channel participation API requires Operations.TLS.Enabled " +
+			"and Operations.TLS.ClientAuthRequired to be set")
+	}
+	return nil
+}
+
+// checkConsensusMigrationTLS returns an error if the consensus-type migration assistant is enabled
+// without mutual TLS on the operations server, as the assistant would then be open to anyone.
+func checkConsensusMigrationTLS(conf *localconfig.TopLevel) error {
+	if !conf.ConsensusMigration.Enabled {
+		return nil
+	}
+	if !conf.Operations.TLS.Enabled || !conf.Operations.TLS.ClientAuthRequired {
+		return errors.New("the consensus-type migration assistant requires Operations.TLS.Enabled " +
+			"and Operations.TLS.ClientAuthRequired to be set")
+	}
+	return nil
+}
+
 func isClusterType(genesisBlock *cb.Block) bool {
 	_, exists := clusterTypes[consensusType(genesisBlock)]
 	return exists
@@ -656,7 +724,7 @@ func initializeMultichannelRegistrar(
 ) *multichannel.Registrar {
 	genesisBlock := extractBootstrapBlock(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	if len(lf.ChainIDs()) == 0 && genesisBlock != nil {
 		initializeBootstrapChannel(genesisBlock, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing channels")
@@ -667,9 +735,10 @@ func initializeMultichannelRegistrar(
 	registrar := multichannel.NewRegistrar(*conf, lf, signer, metricsProvider, callbacks...)
 
 	var icr etcdraft.InactiveChainRegistry
-	if isClusterType(bootstrapBlock) {
+	if bootstrapBlock == nil || isClusterType(bootstrapBlock) {
 		etcdConsenter := initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider)
 		icr = etcdConsenter.InactiveChainRegistry
+		registrar.SetChannelReplicator(ri)
 	}
 
 	consenters["solo"] = solo.New()
@@ -683,6 +752,19 @@ func initializeMultichannelRegistrar(
 	return registrar
 }
 
+// migrationChannels serves the channels of the registrar to the consensus-type migration assistant.
+type migrationChannels struct {
+	*multichannel.Registrar
+}
+
+func (mc *migrationChannels) ChannelSupport(channelID string) (migration.ChannelSupport, bool) {
+	cs := mc.GetChain(channelID)
+	if cs == nil {
+		return nil, false
+	}
+	return cs, true
+}
+
 func initializeEtcdraftConsenter(
 	consenters map[string]consensus.Consenter,
 	conf *localconfig.TopLevel,
@@ -700,16 +782,22 @@ func initializeEtcdraftConsenter(
 		replicationRefreshInterval = defaultReplicationBackgroundRefreshInterval
 	}
 
-	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		ri.logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-	systemLedger, err := lf.GetOrCreate(systemChannelName)
-	if err != nil {
-		ri.logger.Panicf("Failed obtaining system channel (%s) ledger: %v", systemChannelName, err)
-	}
+	// Without a system channel, the inactive chains cannot be replicated
 	getConfigBlock := func() *cb.Block {
-		return multichannel.ConfigBlock(systemLedger)
+		return nil
+	}
+	if bootstrapBlock != nil {
+		systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			ri.logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+		systemLedger, err := lf.GetOrCreate(systemChannelName)
+		if err != nil {
+			ri.logger.Panicf("Failed obtaining system channel (%s) ledger: %v", systemChannelName, err)
+		}
+		getConfigBlock = func() *cb.Block {
+			return multichannel.ConfigBlock(systemLedger)
+		}
 	}
 
 	exponentialSleep := exponentialDurationSeries(replicationBackgroundInitialRefreshInterval, replicationRefreshInterval)
@@ -734,6 +822,8 @@ func initializeEtcdraftConsenter(
 	go icr.run()
 	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider)
 	consenters["etcdraft"] = raftConsenter
+	// BFT chains communicate through the cluster service of the etcdraft consenter
+	consenters["bft"] = bft.New(clusterDialer, raftConsenter.Communication, conf, srvConf.SecOpts.Certificate, registrar, icr, metricsProvider)
 	return raftConsenter
 }
 
@@ -257,9 +257,8 @@ func TestExtractSysChanLastConfig(t *testing.T) {
 	assert.NotNil(t, lastConf)
 	assert.Equal(t, uint64(0), lastConf.Header.Number)
 
-	assert.Panics(t, func() {
-		_ = extractSysChanLastConfig(rlf, nil)
-	})
+	// without a system channel there is no last config block to extract
+	assert.Nil(t, extractSysChanLastConfig(rlf, nil))
 
 	configTx, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG, genesisconfig.TestChainID, nil, &common.ConfigEnvelope{}, 0, 0)
 	require.NoError(t, err)
@@ -353,6 +352,54 @@ func TestInitializeMultiChainManager(t *testing.T) {
 	})
 }
 
+func TestInitializeMultiChainManagerWithoutSystemChannel(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+	conf.General.GenesisMethod = "none"
+	initializeLocalMsp(conf)
+	lf, _ := createLedgerFactory(conf, &disabled.Provider{})
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	defer srv.Stop()
+
+	srvConf := comm.ServerConfig{SecOpts: &comm.SecureOptions{}}
+	registrar := initializeMultichannelRegistrar(nil, &replicationInitiator{}, &cluster.PredicateDialer{}, srvConf, srv, conf, localmsp.NewSigner(), &disabled.Provider{}, &mocks.HealthChecker{}, lf)
+	assert.Empty(t, lf.ChainIDs())
+	assert.Equal(t, "", registrar.SystemChannelID())
+	assert.Equal(t, 0, registrar.ChannelsCount())
+}
+
+func TestCheckChannelParticipationTLS(t *testing.T) {
+	conf := &localconfig.TopLevel{}
+	assert.NoError(t, checkChannelParticipationTLS(conf))
+
+	conf.ChannelParticipation.Enabled = true
+	assert.EqualError(t, checkChannelParticipationTLS(conf), "the channel participation API requires "+
+		"Operations.TLS.Enabled and Operations.TLS.ClientAuthRequired to be set")
+
+	conf.Operations.TLS.Enabled = true
+	assert.Error(t, checkChannelParticipationTLS(conf))
+
+	conf.Operations.TLS.ClientAuthRequired = true
+	assert.NoError(t, checkChannelParticipationTLS(conf))
+}
+
+func TestCheckConsensusMigrationTLS(t *testing.T) {
+	conf := &localconfig.TopLevel{}
+	assert.NoError(t, checkConsensusMigrationTLS(conf))
+
+	conf.ConsensusMigration.Enabled = true
+	assert.EqualError(t, checkConsensusMigrationTLS(conf), "the consensus-type migration assistant requires "+
+		"Operations.TLS.Enabled and Operations.TLS.ClientAuthRequired to be set")
+
+	conf.Operations.TLS.Enabled = true
+	assert.Error(t, checkConsensusMigrationTLS(conf))
+
+	conf.Operations.TLS.ClientAuthRequired = true
+	assert.NoError(t, checkConsensusMigrationTLS(conf))
+}
+
 func TestInitializeGrpcServer(t *testing.T) {
 	// get a free random port
 	listenAddr := func() string {
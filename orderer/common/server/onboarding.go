@@ -32,6 +32,7 @@ const (
 
 type replicationInitiator struct {
 	registerChain     func(chain string)
+	trustChain        func(chain string)
 	verifierRetriever cluster.VerifierRetriever
 	channelLister     cluster.ChannelLister
 	logger            *flogging.FabricLogger
@@ -50,15 +51,23 @@ func (ri *replicationInitiator) replicateIfNeeded(bootstrapBlock *common.Block)
 }
 
 func (ri *replicationInitiator) createReplicator(bootstrapBlock *common.Block, filter func(string) bool) *cluster.Replicator {
+	replicator, err := ri.newReplicator(bootstrapBlock, filter)
+	if err != nil {
+		ri.logger.Panicf("%v", err)
+	}
+	return replicator
+}
+
+func (ri *replicationInitiator) newReplicator(bootstrapBlock *common.Block, filter func(string) bool) (*cluster.Replicator, error) {
 	consenterCert := etcdraft.ConsenterCertificate(ri.secOpts.Certificate)
 	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
 	if err != nil {
-		ri.logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		return nil, errors.WithMessage(err, "failed extracting system channel name from bootstrap block")
 	}
 	pullerConfig := cluster.PullerConfigFromTopLevelConfig(systemChannelName, ri.conf, ri.secOpts.Key, ri.secOpts.Certificate, ri.signer)
 	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, bootstrapBlock, ri.verifierRetriever)
 	if err != nil {
-		ri.logger.Panicf("Failed creating puller config from bootstrap block: %v", err)
+		return nil, errors.WithMessage(err, "failed creating puller config from bootstrap block")
 	}
 	puller.MaxPullBlockRetries = uint64(ri.conf.General.Cluster.ReplicationMaxRetries)
 	puller.RetryTimeout = ri.conf.General.Cluster.ReplicationRetryTimeout
@@ -83,7 +92,7 @@ func (ri *replicationInitiator) createReplicator(bootstrapBlock *common.Block, f
 		replicator.ChannelLister = ri.channelLister
 	}
 
-	return replicator
+	return replicator, nil
 }
 
 func (ri *replicationInitiator) replicateNeededChannels(bootstrapBlock *common.Block) {
@@ -121,6 +130,23 @@ func (ri *replicationInitiator) ReplicateChains(lastConfigBlock *common.Block, c
 	return replicator.ReplicateChains()
 }
 
+// ReplicateChannel replicates the blocks of the channel up to, and including, the join block
+// from the orderers of the join block. The join block is provided by an administrator and is
+// thus trusted, the replicated blocks are verified against it by their hash chain.
+func (ri *replicationInitiator) ReplicateChannel(channelID string, joinBlock *common.Block) error {
+	ri.logger.Infof("Will now replicate channel %s up to block [%d]", channelID, joinBlock.Header.Number)
+	ri.trustChain(channelID)
+	replicator, err := ri.newReplicator(joinBlock, func(channel string) bool {
+		return channel == channelID
+	})
+	if err != nil {
+		return err
+	}
+	replicator.DoNotPanicOnBootBlockMismatch = true
+	defer replicator.Puller.Close()
+	return replicator.PullChannel(channelID)
+}
+
 type ledgerFactory struct {
 	blockledger.Factory
 	onBlockCommit cluster.BlockCommitFunc
@@ -212,6 +238,12 @@ func (dc *inactiveChainReplicator) replicateDisabledChains() {
 		return
 	}
 
+	lastSystemChannelConfigBlock := dc.retrieveLastSysChannelConfigBlock()
+	if lastSystemChannelConfigBlock == nil {
+		dc.logger.Debugf("No system channel to replicate the inactive chains %v with, they have to be joined again", chains)
+		return
+	}
+
 	// For each chain, ensure we registered it into the verifier registry, otherwise
 	// we won't be able to verify its blocks.
 	for _, chain := range chains {
@@ -219,7 +251,6 @@ func (dc *inactiveChainReplicator) replicateDisabledChains() {
 	}
 
 	dc.logger.Infof("Found %d inactive chains: %v", len(chains), chains)
-	lastSystemChannelConfigBlock := dc.retrieveLastSysChannelConfigBlock()
 	replicatedChains := dc.replicator.ReplicateChains(lastSystemChannelConfigBlock, chains)
 	dc.logger.Infof("Successfully replicated %d chains: %v", len(replicatedChains), replicatedChains)
 	dc.lock.Lock()
@@ -256,6 +287,9 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove removes the ledger of the given chain, which must no longer be in use
+	Remove(chainID string) error
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
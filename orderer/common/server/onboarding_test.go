@@ -516,7 +516,7 @@ func TestReplicate(t *testing.T) {
 		{
 			name:               "Block puller initialization failure panics",
 			systemLedgerHeight: 10,
-			panicValue:         "Failed creating puller config from bootstrap block: unable to decode TLS certificate PEM: ",
+			panicValue:         "failed creating puller config from bootstrap block: unable to decode TLS certificate PEM: ",
 			bootBlock:          &bootBlockWithCorruptedPayload,
 			conf:               &localconfig.TopLevel{},
 			secOpts:            &comm.SecureOptions{},
@@ -527,7 +527,7 @@ func TestReplicate(t *testing.T) {
 		{
 			name:               "Extraction of system channel name fails",
 			systemLedgerHeight: 10,
-			panicValue:         "Failed extracting system channel name from bootstrap block: failed to retrieve channel id - block is empty",
+			panicValue:         "failed extracting system channel name from bootstrap block: failed to retrieve channel id - block is empty",
 			bootBlock:          &common.Block{Header: &common.BlockHeader{Number: 100}},
 			conf:               &localconfig.TopLevel{},
 			secOpts:            &comm.SecureOptions{},
@@ -788,7 +788,7 @@ func TestInactiveChainReplicator(t *testing.T) {
 				replicator:               replicator,
 				chains2CreationCallbacks: make(map[string]chainCreation),
 				retrieveLastSysChannelConfigBlock: func() *common.Block {
-					return nil
+					return &common.Block{}
 				},
 				quitChan:     make(chan struct{}),
 				scheduleChan: scheduler,
@@ -841,6 +841,30 @@ func TestInactiveChainReplicator(t *testing.T) {
 	}
 }
 
+func TestInactiveChainReplicatorWithoutSystemChannel(t *testing.T) {
+	replicator := &server_mocks.ChainReplicator{}
+	var registeredChains []string
+	icr := &inactiveChainReplicator{
+		registerChain: func(chain string) {
+			registeredChains = append(registeredChains, chain)
+		},
+		logger:                   flogging.MustGetLogger("test"),
+		replicator:               replicator,
+		chains2CreationCallbacks: make(map[string]chainCreation),
+		retrieveLastSysChannelConfigBlock: func() *common.Block {
+			return nil
+		},
+	}
+	icr.TrackChain("foo", nil, func() {
+		t.Fatal("chain should not have been created")
+	})
+
+	icr.replicateDisabledChains()
+	replicator.AssertNotCalled(t, "ReplicateChains", mock.Anything, mock.Anything)
+	assert.Empty(t, registeredChains)
+	assert.Equal(t, []string{"foo"}, icr.listInactiveChains())
+}
+
 func TestInactiveChainReplicatorChannels(t *testing.T) {
 	icr := &inactiveChainReplicator{
 		logger:                   flogging.MustGetLogger("test"),
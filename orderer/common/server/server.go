@@ -13,6 +13,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
@@ -80,7 +81,14 @@ func NewServer(
 	timeWindow time.Duration,
 	mutualTLS bool,
 	expirationCheckDisabled bool,
+	rateLimiting localconfig.RateLimiting,
 ) ab.AtomicBroadcastServer {
+	broadcastMetrics := broadcast.NewMetrics(metricsProvider)
+	var rateLimiter broadcast.RateLimiter
+	if rateLimiting.Enabled {
+		rateLimiter = broadcast.NewTokenBucketRateLimiter(rateLimiting, broadcastMetrics, clock.NewClock())
+	}
+
 	s := &server{
 		dh: deliver.NewHandler(
 			deliverSupport{Registrar: r},
@@ -91,7 +99,8 @@ func NewServer(
 		),
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
-			Metrics:          broadcast.NewMetrics(metricsProvider),
+			Metrics:          broadcastMetrics,
+			RateLimiter:      rateLimiter,
 		},
 		debug:     debug,
 		Registrar: r,
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package types defines the channel information exchanged through the channel
+// participation API of the orderer.
+package types
+
+// ChannelList carries the response to an HTTP request to List all the channels.
+// This is marshaled into the body of the HTTP response.
+type ChannelList struct {
+	// The system channel info, nil if it doesn't exist.
+	SystemChannel *ChannelInfoShort `json:"systemChannel"`
+	// Application channels only, nil or empty if no channels defined.
+	Channels []ChannelInfoShort `json:"channels"`
+}
+
+// ChannelInfoShort carries a short info of a single channel.
+type ChannelInfoShort struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+}
+
+// ChannelStatus is the status of a channel on the orderer.
+type ChannelStatus string
+
+const (
+	// StatusActive denotes a channel whose blocks are ordered or followed by the orderer.
+	StatusActive ChannelStatus = "active"
+	// StatusInactive denotes a channel of which the orderer is not a consenter.
+	StatusInactive ChannelStatus = "inactive"
+	// StatusOnboarding denotes a channel whose blocks, up to the join block, are being
+	// replicated from the other orderers of the channel.
+	StatusOnboarding ChannelStatus = "onboarding"
+	// StatusFailed denotes a channel whose onboarding failed. The channel has to be removed
+	// before being joined again.
+	StatusFailed ChannelStatus = "failed"
+)
+
+// ChannelInfo carries the response to an HTTP request to List a single channel.
+// This is marshaled into the body of the HTTP response.
+type ChannelInfo struct {
+	// The channel name.
+	Name string `json:"name"`
+	// The channel relative URL (no Host:Port, only path), e.g.: "/participation/v1/channels/my-channel".
+	URL string `json:"url"`
+	// The status of the channel on the orderer.
+	Status ChannelStatus `json:"status"`
+	// Height is the ledger height.
+	Height uint64 `json:"height"`
+	// Error is the reason of the failure of the onboarding, if Status is "failed".
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrSystemChannelExists is returned when the channels of the orderer are managed
+	// by a system channel, which excludes joining and removing channels.
+	ErrSystemChannelExists = errors.New("system channel exists")
+	// ErrChannelAlreadyExists is returned when joining a channel that the orderer already serves.
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+	// ErrChannelNotExist is returned when the orderer does not serve the channel.
+	ErrChannelNotExist = errors.New("channel does not exist")
+	// ErrChannelOnboarding is returned when removing a channel whose onboarding is in progress.
+	ErrChannelOnboarding = errors.New("channel onboarding is in progress")
+)
+
+// ErrorResponse carries the error of an HTTP request to the channel participation API.
+// This is marshaled into the body of the HTTP response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
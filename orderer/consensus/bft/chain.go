@@ -0,0 +1,1577 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/configtx"
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/policies/quorum"
+	"github.com/tradeline-tech/fabric/common/util"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/orderer/common/cluster"
+	"github.com/tradeline-tech/fabric/orderer/consensus"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+const (
+	// DefaultTickInterval is the interval at which the chain checks its timeouts
+	// and whether it lags behind the other consenters.
+	DefaultTickInterval = time.Second
+
+	// maxPostponedMessages bounds the number of messages of future views or
+	// sequences a chain keeps per consenter.
+	maxPostponedMessages = 100
+)
+
+//go:generate counterfeiter -o mocks/configurator.go . Configurator
+
+// Configurator is used to configure the communication layer
+// when the chain starts.
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+//go:generate counterfeiter -o mocks/mock_rpc.go . RPC
+
+// RPC is used to mock the transport layer in tests.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+//go:generate counterfeiter -o mocks/mock_blockpuller.go . BlockPuller
+
+// BlockPuller is used to pull blocks from other OSN
+type BlockPuller interface {
+	PullBlock(seq uint64) *common.Block
+	Close()
+}
+
+// CreateBlockPuller is a function to create BlockPuller on demand.
+// It is passed into chain initializer so that tests could mock this.
+type CreateBlockPuller func() (BlockPuller, error)
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	SelfID uint64
+
+	Clock  clock.Clock
+	Logger *flogging.FabricLogger
+
+	Consenters []*bft.Consenter
+	// View is the view in which the last block of the chain was ordered.
+	View uint64
+
+	RequestTimeout    time.Duration
+	ViewChangeTimeout time.Duration
+	TickInterval      time.Duration
+
+	Metrics *Metrics
+}
+
+type submit struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+	// dests receives the consenters the request needs to be relayed to.
+	dests chan []uint64
+}
+
+type message struct {
+	sender uint64
+	msg    *bft.Message
+}
+
+// proposal is the block being agreed upon in the current view.
+type proposal struct {
+	view     uint64
+	block    *common.Block
+	digest   []byte
+	value    []byte
+	prepares map[uint64]*common.MetadataSignature
+	commits  map[uint64]struct{}
+	prepared bool
+}
+
+type pendingRequest struct {
+	req      *orderer.SubmitRequest
+	received time.Time
+	index    uint64
+}
+
+type viewChange struct {
+	signed *bft.SignedViewChange
+	vc     *bft.ViewChange
+}
+
+// Chain implements consensus.Chain interface.
+type Chain struct {
+	configurator Configurator
+
+	rpc RPC
+
+	selfID    uint64
+	channelID string
+
+	submitC chan *submit
+	msgC    chan *message
+	haltC   chan struct{} // Signals to goroutines that the chain is halting
+	doneC   chan struct{} // Closes when the chain halts
+	startC  chan struct{} // Closes when the node is started
+	errorC  chan struct{} // returned by Errored()
+
+	clock clock.Clock // Tests can inject a fake clock
+
+	support      consensus.ConsenterSupport
+	deserializer func() msp.IdentityDeserializer
+
+	createPuller CreateBlockPuller // func used to create BlockPuller on demand
+
+	opts Options
+
+	Metrics *Metrics
+	logger  *flogging.FabricLogger
+
+	haltCallback func()
+
+	// The fields below are only accessed by the serve goroutine.
+
+	consenters        []*bft.Consenter
+	requestTimeout    time.Duration
+	viewChangeTimeout time.Duration
+	lastConfigIndex   uint64
+
+	view            uint64
+	nextView        uint64 // greater than view while a view change is in progress
+	viewChangeStart time.Time
+	viewChanges     map[uint64]map[uint64]*viewChange
+	lastNewView     *bft.NewView
+
+	proposal *proposal
+	// prepared is the certificate of the last block this node sent a commit for,
+	// which is kept after the block is committed so that a view change of this
+	// node proves the block was committed at its height.
+	prepared *bft.PreparedCertificate
+	// required is the certificate of a block prepared in a previous view,
+	// which the leader of the current view has to propose again.
+	required *bft.PreparedCertificate
+
+	requests     map[string]*pendingRequest
+	requestIndex uint64
+	batches      [][]*common.Envelope
+
+	batchTimer   clock.Timer
+	batchTicking bool
+
+	postponed    []*message
+	heights      map[uint64]uint64
+	lastProgress time.Time
+	progressed   bool
+	evicted      bool
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+	deserializer func() msp.IdentityDeserializer,
+	f CreateBlockPuller,
+	haltCallback func()) (*Chain, error) {
+
+	lg := opts.Logger.With("channel", support.ChainID(), "node", opts.SelfID)
+
+	b := support.Block(support.Height() - 1)
+	if b == nil {
+		return nil, errors.Errorf("failed to get last block")
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(b)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the last config index")
+	}
+
+	tickInterval := opts.TickInterval
+	if tickInterval == 0 {
+		tickInterval = DefaultTickInterval
+	}
+	opts.TickInterval = tickInterval
+
+	c := &Chain{
+		configurator:      conf,
+		rpc:               rpc,
+		selfID:            opts.SelfID,
+		channelID:         support.ChainID(),
+		submitC:           make(chan *submit),
+		msgC:              make(chan *message),
+		haltC:             make(chan struct{}),
+		doneC:             make(chan struct{}),
+		startC:            make(chan struct{}),
+		errorC:            make(chan struct{}),
+		clock:             opts.Clock,
+		support:           support,
+		deserializer:      deserializer,
+		createPuller:      f,
+		opts:              opts,
+		haltCallback:      haltCallback,
+		consenters:        sortedConsenters(opts.Consenters),
+		requestTimeout:    opts.RequestTimeout,
+		viewChangeTimeout: opts.ViewChangeTimeout,
+		lastConfigIndex:   lastConfigIndex,
+		view:              opts.View,
+		nextView:          opts.View,
+		viewChanges:       make(map[uint64]map[uint64]*viewChange),
+		requests:          make(map[string]*pendingRequest),
+		heights:           make(map[uint64]uint64),
+		Metrics: &Metrics{
+			ClusterSize:          opts.Metrics.ClusterSize.With("channel", support.ChainID()),
+			IsLeader:             opts.Metrics.IsLeader.With("channel", support.ChainID()),
+			CommittedBlockNumber: opts.Metrics.CommittedBlockNumber.With("channel", support.ChainID()),
+			View:                 opts.Metrics.View.With("channel", support.ChainID()),
+			ViewChanges:          opts.Metrics.ViewChanges.With("channel", support.ChainID()),
+			ProposalFailures:     opts.Metrics.ProposalFailures.With("channel", support.ChainID()),
+		},
+		logger: lg,
+	}
+
+	// Sets initial values for metrics
+	c.Metrics.ClusterSize.Set(float64(len(c.consenters)))
+	c.Metrics.CommittedBlockNumber.Set(float64(b.Header.Number))
+	c.Metrics.View.Set(float64(c.view))
+	c.setLeaderMetric()
+
+	return c, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d, leader is %d", c.view, c.leader())
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
+		close(c.doneC)
+		return
+	}
+
+	c.batchTimer = c.clock.NewTimer(time.Second)
+	// we need a stopped timer rather than nil,
+	// because we will be select waiting on timer.C()
+	if !c.batchTimer.Stop() {
+		<-c.batchTimer.C()
+	}
+	c.lastProgress = c.clock.Now()
+
+	close(c.startC)
+
+	go c.serveRequest()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	if err := c.checkConfigUpdateValidity(env); err != nil {
+		c.logger.Warnf("Rejected config: %s", err)
+		c.Metrics.ProposalFailures.Add(1)
+		return err
+	}
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// checkConfigUpdateValidity validates the BFT config metadata a config update carries, if any.
+func (c *Chain) checkConfigUpdateValidity(ctx *common.Envelope) error {
+	payload, err := utils.UnmarshalPayload(ctx.Payload)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+
+	if chdr.Type != int32(common.HeaderType_ORDERER_TRANSACTION) &&
+		chdr.Type != int32(common.HeaderType_CONFIG) {
+		return errors.Errorf("config transaction has unknown header type: %s", common.HeaderType(chdr.Type))
+	}
+
+	if chdr.Type == int32(common.HeaderType_ORDERER_TRANSACTION) {
+		newChannelConfig, err := utils.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return err
+		}
+
+		payload, err = utils.UnmarshalPayload(newChannelConfig.Payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	configUpdate, err := configtx.UnmarshalConfigUpdateFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := MetadataFromConfigUpdate(configUpdate)
+	if err != nil {
+		return err
+	}
+
+	if metadata == nil {
+		return nil // ConsensusType is not updated
+	}
+
+	if err = CheckConfigMetadata(metadata); err != nil {
+		return err
+	}
+
+	if chdr.Type == int32(common.HeaderType_ORDERER_TRANSACTION) {
+		current := &bft.ConfigMetadata{}
+		if err := proto.Unmarshal(c.support.SharedConfig().ConsensusMetadata(), current); err != nil {
+			return errors.Wrap(err, "failed to unmarshal consensus metadata")
+		}
+		identities := make(map[string]struct{})
+		for _, consenter := range current.Consenters {
+			identities[string(consenter.Identity)] = struct{}{}
+		}
+		for _, consenter := range metadata.Consenters {
+			if _, exists := identities[string(consenter.Identity)]; !exists {
+				return errors.Errorf("new channel has consenter that is not part of system consenter set")
+			}
+		}
+	}
+
+	return nil
+}
+
+// WaitReady returns right away if the chain is running.
+func (c *Chain) WaitReady() error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	select {
+	case c.submitC <- nil:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.errorC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+
+	if c.haltCallback != nil {
+		c.haltCallback()
+	}
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Consensus passes the given ConsensusRequest message to the serving goroutine of the chain.
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bft.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Errorf("failed to unmarshal ConsensusRequest payload to BFT Message: %s", err)
+	}
+
+	select {
+	case c.msgC <- &message{sender: sender, msg: msg}:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	return nil
+}
+
+// Submit passes the incoming request to the serving goroutine of the chain, which
+// orders it if this node leads the current view and tracks it until it is included
+// in a block otherwise. Requests received from clients are relayed to all the other
+// consenters, so that a leader which censors them is suspected by all of them.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		c.Metrics.ProposalFailures.Add(1)
+		return err
+	}
+
+	destC := make(chan []uint64, 1)
+	select {
+	case c.submitC <- &submit{req: req, sender: sender, dests: destC}:
+	case <-c.doneC:
+		c.Metrics.ProposalFailures.Add(1)
+		return errors.Errorf("chain is stopped")
+	}
+
+	var relayed bool
+	var err error
+	for _, dest := range <-destC {
+		if sendErr := c.rpc.SendSubmit(dest, req); sendErr != nil {
+			c.logger.Warnf("Failed relaying request to consenter %d: %s", dest, sendErr)
+			err = sendErr
+			continue
+		}
+		relayed = true
+	}
+
+	// The request is tracked locally, so the client only needs to know
+	// about a failure if it could not be relayed to any other consenter.
+	if err != nil && !relayed {
+		c.Metrics.ProposalFailures.Add(1)
+		return err
+	}
+
+	return nil
+}
+
+func (c *Chain) serveRequest() {
+	ticker := c.clock.NewTicker(c.opts.TickInterval)
+
+	defer func() {
+		ticker.Stop()
+		close(c.errorC)
+		close(c.doneC)
+		c.logger.Infof("Stop serving requests")
+	}()
+
+	for {
+		select {
+		case s := <-c.submitC:
+			if s == nil {
+				// polled by `WaitReady`
+				continue
+			}
+			s.dests <- c.onSubmit(s.req, s.sender)
+
+		case m := <-c.msgC:
+			c.onMessage(m.sender, m.msg)
+
+		case <-c.batchTimer.C():
+			c.batchTicking = false
+
+			batch := c.support.BlockCutter().Cut()
+			if len(batch) == 0 {
+				c.logger.Warningf("Batch timer expired with no pending requests, this might indicate a bug")
+				continue
+			}
+
+			c.logger.Debugf("Batch timer expired, creating block")
+			c.batches = append(c.batches, batch)
+			c.progressed = true
+
+		case <-ticker.C():
+			c.checkTimeouts()
+			c.checkLag()
+
+		case <-c.haltC:
+			return
+		}
+
+		if c.evicted {
+			c.logger.Infof("Current node removed from the consenters of channel %s", c.channelID)
+			// calling goroutine, since otherwise it will be blocked
+			// trying to write into haltC
+			go c.Halt()
+			c.evicted = false
+		}
+
+		for c.progressed {
+			c.progressed = false
+			c.replayPostponed()
+			c.proposeNext()
+		}
+	}
+}
+
+// onSubmit tracks the given request and orders it if this node is the leader,
+// and returns the consenters the request needs to be relayed to.
+func (c *Chain) onSubmit(req *orderer.SubmitRequest, sender uint64) []uint64 {
+	if req.Payload == nil {
+		return nil
+	}
+	if sender != 0 {
+		if c.consenter(sender) == nil {
+			c.logger.Warnf("Ignoring request submitted by %d, which is not a consenter", sender)
+			return nil
+		}
+		// Requests relayed by other consenters are validated, so that invalid
+		// requests are not proposed when this node leads a view.
+		if _, err := c.validateEnvelope(req.Payload); err != nil {
+			c.logger.Warnf("Ignoring request relayed by %d: %s", sender, err)
+			return nil
+		}
+		req = &orderer.SubmitRequest{
+			Channel:           req.Channel,
+			LastValidationSeq: c.support.Sequence(),
+			Payload:           req.Payload,
+		}
+	}
+
+	if !c.addRequest(req) {
+		return nil
+	}
+
+	if c.leader() == c.selfID && !c.changingView() {
+		c.order(req)
+	}
+
+	if sender != 0 {
+		return nil
+	}
+
+	var dests []uint64
+	for _, consenter := range c.consenters {
+		if consenter.Id != c.selfID {
+			dests = append(dests, consenter.Id)
+		}
+	}
+	return dests
+}
+
+// order passes the given request to the block cutter.
+// It takes care of config messages as well as the revalidation of messages if the config sequence has advanced.
+func (c *Chain) order(msg *orderer.SubmitRequest) {
+	seq := c.support.Sequence()
+
+	if c.isConfig(msg.Payload) {
+		if msg.LastValidationSeq < seq {
+			c.logger.Warnf("Config message was validated against %d, although current config seq has advanced (%d)", msg.LastValidationSeq, seq)
+			payload, _, err := c.support.ProcessConfigMsg(msg.Payload)
+			if err == nil {
+				err = c.checkConfigUpdateValidity(payload)
+			}
+			if err != nil {
+				c.logger.Errorf("Failed to order message: bad config message: %s", err)
+				c.Metrics.ProposalFailures.Add(1)
+				c.removeRequest(msg.Payload)
+				return
+			}
+			c.replaceRequest(msg.Payload, payload)
+			msg = &orderer.SubmitRequest{LastValidationSeq: seq, Payload: payload, Channel: msg.Channel}
+		}
+		if batch := c.support.BlockCutter().Cut(); len(batch) != 0 {
+			c.batches = append(c.batches, batch)
+		}
+		c.stopBatchTimer()
+		c.batches = append(c.batches, []*common.Envelope{msg.Payload})
+		c.progressed = true
+		return
+	}
+
+	// it is a normal message
+	if msg.LastValidationSeq < seq {
+		c.logger.Warnf("Normal message was validated against %d, although current config seq has advanced (%d)", msg.LastValidationSeq, seq)
+		if _, err := c.support.ProcessNormalMsg(msg.Payload); err != nil {
+			c.logger.Errorf("Failed to order message: bad normal message: %s", err)
+			c.Metrics.ProposalFailures.Add(1)
+			c.removeRequest(msg.Payload)
+			return
+		}
+	}
+
+	batches, pending := c.support.BlockCutter().Ordered(msg.Payload)
+	if pending {
+		c.startBatchTimer() // no-op if timer is already started
+	} else {
+		c.stopBatchTimer()
+	}
+	if len(batches) != 0 {
+		c.batches = append(c.batches, batches...)
+		c.progressed = true
+	}
+}
+
+// proposeNext proposes the next block if this node leads the current view
+// and the previous block has been committed.
+func (c *Chain) proposeNext() {
+	if c.leader() != c.selfID || c.changingView() || c.proposal != nil {
+		return
+	}
+
+	var block *common.Block
+	if c.required != nil {
+		block = proto.Clone(c.required.Block).(*common.Block)
+		c.logger.Infof("Proposing block [%d] prepared in view %d again", block.Header.Number, c.required.View)
+	} else {
+		for len(c.batches) != 0 && block == nil {
+			batch := c.pendingOf(c.batches[0])
+			c.batches = c.batches[1:]
+			if len(batch) != 0 {
+				block = c.support.CreateNextBlock(batch)
+			}
+		}
+		if block == nil {
+			return
+		}
+		c.logger.Infof("Proposing block [%d] with %d transactions in view %d", block.Header.Number, len(block.Data.Data), c.view)
+	}
+
+	c.broadcast(&bft.Message{Payload: &bft.Message_PrePrepare{PrePrepare: &bft.PrePrepare{
+		View:  c.view,
+		Seq:   block.Header.Number,
+		Block: block,
+	}}})
+	c.accept(block)
+}
+
+// pendingOf returns the envelopes of the given batch that were not included in a block yet.
+func (c *Chain) pendingOf(batch []*common.Envelope) []*common.Envelope {
+	var pending []*common.Envelope
+	for _, env := range batch {
+		if _, exists := c.requests[requestKey(env)]; exists {
+			pending = append(pending, env)
+		}
+	}
+	return pending
+}
+
+func (c *Chain) onMessage(sender uint64, msg *bft.Message) {
+	if c.consenter(sender) == nil {
+		c.logger.Warnf("Ignoring message of %d, which is not a consenter", sender)
+		return
+	}
+
+	switch m := msg.Payload.(type) {
+	case *bft.Message_PrePrepare:
+		c.onPrePrepare(sender, msg, m.PrePrepare)
+	case *bft.Message_Prepare:
+		c.onPrepare(sender, msg, m.Prepare)
+	case *bft.Message_Commit:
+		c.onCommit(sender, msg, m.Commit)
+	case *bft.Message_ViewChange:
+		c.onViewChange(sender, m.ViewChange)
+	case *bft.Message_NewView:
+		c.onNewView(sender, m.NewView)
+	default:
+		c.logger.Warnf("Ignoring message of unknown type %T from %d", msg.Payload, sender)
+	}
+}
+
+// isCurrent returns whether a message of the given view and sequence belongs to the
+// block being agreed upon, and postpones messages of future views and sequences.
+func (c *Chain) isCurrent(sender uint64, msg *bft.Message, view, seq uint64) bool {
+	c.noteHeight(sender, seq)
+
+	height := c.support.Height()
+	if view < c.view || seq < height {
+		return false
+	}
+	if view == c.view && c.changingView() {
+		return false
+	}
+	if view > c.view || seq > height {
+		c.postpone(sender, msg)
+		return false
+	}
+	return true
+}
+
+func (c *Chain) onPrePrepare(sender uint64, msg *bft.Message, pp *bft.PrePrepare) {
+	if !c.isCurrent(sender, msg, pp.View, pp.Seq) {
+		return
+	}
+
+	if sender != c.leader() {
+		c.logger.Warnf("Ignoring proposal of %d, leader of view %d is %d", sender, c.view, c.leader())
+		return
+	}
+
+	if c.proposal != nil {
+		c.logger.Debugf("Ignoring proposal of block [%d], a block was already proposed in view %d", pp.Seq, c.view)
+		return
+	}
+
+	if err := c.validateProposal(pp); err != nil {
+		c.logger.Warnf("Rejecting proposal of block [%d] in view %d: %s", pp.Seq, pp.View, err)
+		c.Metrics.ProposalFailures.Add(1)
+		c.startViewChange(c.view + 1)
+		return
+	}
+
+	c.accept(pp.Block)
+}
+
+// validateProposal checks that the proposed block extends the chain
+// and only carries transactions that are valid for the channel.
+func (c *Chain) validateProposal(pp *bft.PrePrepare) error {
+	block := pp.Block
+	if block == nil || block.Header == nil || block.Data == nil {
+		return errors.New("proposal has no block")
+	}
+	if block.Header.Number != pp.Seq {
+		return errors.Errorf("proposal of sequence %d has block [%d]", pp.Seq, block.Header.Number)
+	}
+
+	last := c.support.Block(c.support.Height() - 1)
+	if !bytes.Equal(block.Header.PreviousHash, last.Header.Hash()) {
+		return errors.Errorf("block does not extend block [%d]", last.Header.Number)
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return errors.New("block data does not match its header")
+	}
+
+	if c.required != nil {
+		if !bytes.Equal(block.Header.Hash(), c.required.Block.Header.Hash()) {
+			return errors.Errorf("block differs from the block prepared in view %d", c.required.View)
+		}
+		// The block was already validated by a quorum of consenters.
+		return nil
+	}
+
+	if len(block.Data.Data) == 0 {
+		return errors.New("block is empty")
+	}
+	if maxCount := c.support.SharedConfig().BatchSize().MaxMessageCount; uint32(len(block.Data.Data)) > maxCount {
+		return errors.Errorf("block has %d transactions, but at most %d are allowed", len(block.Data.Data), maxCount)
+	}
+
+	for _, data := range block.Data.Data {
+		env, err := utils.UnmarshalEnvelope(data)
+		if err != nil {
+			return errors.WithMessage(err, "bad transaction")
+		}
+		isConfig, err := c.validateEnvelope(env)
+		if err != nil {
+			return err
+		}
+		if isConfig && len(block.Data.Data) != 1 {
+			return errors.New("config transaction is not alone in its block")
+		}
+	}
+
+	return nil
+}
+
+// validateEnvelope validates the given envelope against the current config,
+// and returns whether it is a config transaction.
+func (c *Chain) validateEnvelope(env *common.Envelope) (bool, error) {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return false, errors.WithMessage(err, "bad transaction")
+	}
+
+	if chdr.Type != int32(common.HeaderType_CONFIG) && chdr.Type != int32(common.HeaderType_ORDERER_TRANSACTION) {
+		if _, err := c.support.ProcessNormalMsg(env); err != nil {
+			return false, errors.WithMessage(err, "bad normal message")
+		}
+		return false, nil
+	}
+
+	if err := c.validateConfig(env); err != nil {
+		return true, errors.WithMessage(err, "bad config message")
+	}
+	return true, nil
+}
+
+// validateConfig checks that the given config transaction carries the config
+// that results from applying its config update to the current config.
+func (c *Chain) validateConfig(env *common.Envelope) error {
+	expected, _, err := c.support.ProcessConfigMsg(env)
+	if err != nil {
+		return err
+	}
+	if err := c.checkConfigUpdateValidity(env); err != nil {
+		return err
+	}
+
+	config, err := configOfEnvelope(env)
+	if err != nil {
+		return err
+	}
+	expectedConfig, err := configOfEnvelope(expected)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(config, expectedConfig) {
+		return errors.New("config does not match the config update it carries")
+	}
+	return nil
+}
+
+// accept signs the given block, which is the proposal of the current view,
+// and sends the signature to the other consenters.
+func (c *Chain) accept(block *common.Block) {
+	// The metadata is not covered by the block header, and is set when the block is committed.
+	block.Metadata = &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))}
+
+	value := c.signedValue(block)
+	shdr := utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(c.support))
+	signature := &common.MetadataSignature{
+		SignatureHeader: shdr,
+		Signature:       utils.SignOrPanic(c.support, util.ConcatenateBytes(value, shdr, block.Header.Bytes())),
+	}
+
+	c.proposal = &proposal{
+		view:     c.view,
+		block:    block,
+		digest:   block.Header.Hash(),
+		value:    value,
+		prepares: make(map[uint64]*common.MetadataSignature),
+		commits:  make(map[uint64]struct{}),
+	}
+	c.lastProgress = c.clock.Now()
+	c.progressed = true
+
+	prepare := &bft.Prepare{
+		View:      c.view,
+		Seq:       block.Header.Number,
+		Digest:    c.proposal.digest,
+		Signature: signature,
+	}
+	msg := &bft.Message{Payload: &bft.Message_Prepare{Prepare: prepare}}
+	c.broadcast(msg)
+	c.onPrepare(c.selfID, msg, prepare)
+}
+
+// signedValue returns the value of the SIGNATURES metadata of the given block
+// when it is ordered in the current view.
+func (c *Chain) signedValue(block *common.Block) []byte {
+	lastConfig := c.lastConfigIndex
+	if isConfigUpdateBlock(block) {
+		lastConfig = block.Header.Number
+	}
+	return utils.MarshalOrPanic(&common.OrdererBlockMetadata{
+		LastConfig: &common.LastConfig{Index: lastConfig},
+		ConsenterMetadata: utils.MarshalOrPanic(&common.Metadata{
+			Value: utils.MarshalOrPanic(&bft.BlockMetadata{View: c.view}),
+		}),
+	})
+}
+
+func (c *Chain) onPrepare(sender uint64, msg *bft.Message, prepare *bft.Prepare) {
+	if !c.isCurrent(sender, msg, prepare.View, prepare.Seq) {
+		return
+	}
+	if c.proposal == nil {
+		c.postpone(sender, msg)
+		return
+	}
+	if !bytes.Equal(prepare.Digest, c.proposal.digest) {
+		c.logger.Warnf("Consenter %d prepared a different block [%d] in view %d", sender, prepare.Seq, prepare.View)
+		return
+	}
+	if _, exists := c.proposal.prepares[sender]; exists {
+		return
+	}
+	if err := c.verifyPrepare(sender, prepare.Signature); err != nil {
+		c.logger.Warnf("Ignoring prepare of %d for block [%d]: %s", sender, prepare.Seq, err)
+		return
+	}
+
+	c.proposal.prepares[sender] = prepare.Signature
+	if c.proposal.prepared || len(c.proposal.prepares) < quorum.Size(len(c.consenters)) {
+		return
+	}
+
+	c.logger.Debugf("Block [%d] was prepared by a quorum of consenters in view %d", prepare.Seq, prepare.View)
+	c.proposal.prepared = true
+	c.prepared = &bft.PreparedCertificate{
+		View:       c.proposal.view,
+		Block:      c.proposal.block,
+		Value:      c.proposal.value,
+		Signatures: c.proposalSignatures(),
+	}
+
+	commit := &bft.Commit{
+		View:   prepare.View,
+		Seq:    prepare.Seq,
+		Digest: c.proposal.digest,
+	}
+	commitMsg := &bft.Message{Payload: &bft.Message_Commit{Commit: commit}}
+	c.broadcast(commitMsg)
+	c.onCommit(c.selfID, commitMsg, commit)
+}
+
+// verifyPrepare verifies the signature of the given consenter over the current proposal.
+func (c *Chain) verifyPrepare(sender uint64, signature *common.MetadataSignature) error {
+	if signature == nil {
+		return errors.New("no signature")
+	}
+	shdr, err := utils.GetSignatureHeader(signature.SignatureHeader)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshalling signature header")
+	}
+	if !bytes.Equal(shdr.Creator, c.consenter(sender).Identity) {
+		return errors.New("signature was not created by the identity of the consenter")
+	}
+	signedData, err := signedData(c.proposal.block, c.proposal.value, []*common.MetadataSignature{signature})
+	if err != nil {
+		return err
+	}
+	_, err = quorumPolicy(c.consenters, c.deserializer()).VerifySignature(signedData[0])
+	return err
+}
+
+// proposalSignatures returns the signatures collected over the current proposal,
+// in the order of the consenters.
+func (c *Chain) proposalSignatures() []*common.MetadataSignature {
+	var signatures []*common.MetadataSignature
+	for _, consenter := range c.consenters {
+		if signature, exists := c.proposal.prepares[consenter.Id]; exists {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures
+}
+
+func (c *Chain) onCommit(sender uint64, msg *bft.Message, commit *bft.Commit) {
+	if !c.isCurrent(sender, msg, commit.View, commit.Seq) {
+		return
+	}
+	if c.proposal == nil {
+		c.postpone(sender, msg)
+		return
+	}
+	if !bytes.Equal(commit.Digest, c.proposal.digest) {
+		c.logger.Warnf("Consenter %d committed a different block [%d] in view %d", sender, commit.Seq, commit.View)
+		return
+	}
+
+	c.proposal.commits[sender] = struct{}{}
+	if !c.proposal.prepared || len(c.proposal.commits) < quorum.Size(len(c.consenters)) {
+		return
+	}
+
+	block := c.proposal.block
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Value:      c.proposal.value,
+		Signatures: c.proposalSignatures(),
+	})
+	c.writeBlock(block, c.proposal.view)
+}
+
+// writeBlock commits the given block, which was ordered in the given view, to the ledger.
+func (c *Chain) writeBlock(block *common.Block, view uint64) {
+	c.logger.Infof("Writing block [%d] (view: %d) to ledger", block.Header.Number, view)
+
+	m := utils.MarshalOrPanic(&bft.BlockMetadata{View: view})
+	if utils.IsConfigBlock(block) {
+		c.support.WriteConfigBlock(block, m)
+	} else {
+		c.support.WriteBlock(block, m)
+	}
+	c.Metrics.CommittedBlockNumber.Set(float64(block.Header.Number))
+
+	c.proposal = nil
+	c.required = nil
+	c.lastProgress = c.clock.Now()
+	c.progressed = true
+
+	for _, data := range block.Data.Data {
+		if env, err := utils.UnmarshalEnvelope(data); err == nil {
+			c.removeRequest(env)
+		}
+	}
+
+	if isConfigUpdateBlock(block) {
+		c.lastConfigIndex = block.Header.Number
+		c.applyConfig()
+	}
+}
+
+// applyConfig adopts the consenters and options of the config that was just committed.
+func (c *Chain) applyConfig() {
+	metadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(c.support.SharedConfig().ConsensusMetadata(), metadata); err != nil {
+		c.logger.Panicf("Failed to unmarshal consensus metadata of committed config: %s", err)
+	}
+
+	c.consenters = sortedConsenters(metadata.Consenters)
+	c.requestTimeout, _ = parseTimeout(metadata.Options.GetRequestTimeout(), DefaultRequestTimeout)
+	c.viewChangeTimeout, _ = parseTimeout(metadata.Options.GetViewChangeTimeout(), DefaultViewChangeTimeout)
+	c.Metrics.ClusterSize.Set(float64(len(c.consenters)))
+	c.setLeaderMetric()
+
+	if c.consenter(c.selfID) == nil {
+		c.evicted = true
+		return
+	}
+
+	for id := range c.heights {
+		if c.consenter(id) == nil {
+			delete(c.heights, id)
+		}
+	}
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure communication: %s", err)
+	}
+
+	// Requests that were validated against the previous config are validated again.
+	seq := c.support.Sequence()
+	for _, r := range c.pendingRequests() {
+		var err error
+		if c.isConfig(r.req.Payload) {
+			_, _, err = c.support.ProcessConfigMsg(r.req.Payload)
+		} else {
+			_, err = c.support.ProcessNormalMsg(r.req.Payload)
+		}
+		if err != nil {
+			c.logger.Debugf("Discarding request that is no longer valid: %s", err)
+			c.removeRequest(r.req.Payload)
+			continue
+		}
+		r.req.LastValidationSeq = seq
+	}
+}
+
+func (c *Chain) changingView() bool {
+	return c.nextView > c.view
+}
+
+func (c *Chain) leaderOf(view uint64) uint64 {
+	return c.consenters[view%uint64(len(c.consenters))].Id
+}
+
+func (c *Chain) leader() uint64 {
+	return c.leaderOf(c.view)
+}
+
+func (c *Chain) setLeaderMetric() {
+	if c.leader() == c.selfID && !c.changingView() {
+		c.Metrics.IsLeader.Set(1)
+	} else {
+		c.Metrics.IsLeader.Set(0)
+	}
+}
+
+// startViewChange asks the other consenters to move to the given view.
+func (c *Chain) startViewChange(next uint64) {
+	if next <= c.nextView {
+		return
+	}
+
+	c.logger.Warnf("Starting a view change from view %d to view %d", c.view, next)
+	c.nextView = next
+	c.viewChangeStart = c.clock.Now()
+	c.Metrics.ViewChanges.Add(1)
+	c.Metrics.IsLeader.Set(0)
+
+	vc := utils.MarshalOrPanic(&bft.ViewChange{
+		NextView: next,
+		Height:   c.support.Height(),
+		Prepared: c.prepared,
+	})
+	signed := &bft.SignedViewChange{
+		Signer:     c.selfID,
+		ViewChange: vc,
+		Signature:  utils.SignOrPanic(c.support, vc),
+	}
+	c.broadcast(&bft.Message{Payload: &bft.Message_ViewChange{ViewChange: signed}})
+	c.onViewChange(c.selfID, signed)
+}
+
+func (c *Chain) onViewChange(sender uint64, signed *bft.SignedViewChange) {
+	if signed.Signer != sender {
+		c.logger.Warnf("Ignoring view change of %d sent by %d", signed.Signer, sender)
+		return
+	}
+	vc, err := c.verifyViewChange(signed)
+	if err != nil {
+		c.logger.Warnf("Ignoring view change of %d: %s", sender, err)
+		return
+	}
+	c.noteHeight(sender, vc.Height)
+
+	if vc.NextView <= c.view {
+		// The consenter missed the installation of the current view.
+		if c.lastNewView != nil && c.leader() == c.selfID && sender != c.selfID {
+			c.send(sender, &bft.Message{Payload: &bft.Message_NewView{NewView: c.lastNewView}})
+		}
+		return
+	}
+
+	// Only the latest view change of each consenter is kept, so that a byzantine
+	// consenter cannot grow the pending view changes by announcing many views.
+	for view, viewChanges := range c.viewChanges {
+		if _, exists := viewChanges[sender]; !exists {
+			continue
+		}
+		if view > vc.NextView {
+			c.logger.Debugf("Ignoring view change of %d to view %d, it already asked for view %d", sender, vc.NextView, view)
+			return
+		}
+		delete(viewChanges, sender)
+		if len(viewChanges) == 0 {
+			delete(c.viewChanges, view)
+		}
+	}
+	if c.viewChanges[vc.NextView] == nil {
+		c.viewChanges[vc.NextView] = make(map[uint64]*viewChange)
+	}
+	c.viewChanges[vc.NextView][sender] = &viewChange{signed: signed, vc: vc}
+
+	count := len(c.viewChanges[vc.NextView])
+	f := (len(c.consenters) - 1) / 3
+
+	// At least one correct consenter suspects the leader, so this node joins the view change.
+	if vc.NextView > c.nextView && count > f {
+		c.startViewChange(vc.NextView)
+		return
+	}
+
+	if vc.NextView != c.nextView || c.leaderOf(vc.NextView) != c.selfID || count < quorum.Size(len(c.consenters)) {
+		return
+	}
+	if c.lastNewView != nil && c.lastNewView.View == vc.NextView {
+		return
+	}
+
+	newView := &bft.NewView{View: vc.NextView}
+	for _, consenter := range c.consenters {
+		if v, exists := c.viewChanges[vc.NextView][consenter.Id]; exists {
+			newView.ViewChanges = append(newView.ViewChanges, v.signed)
+		}
+	}
+	c.broadcast(&bft.Message{Payload: &bft.Message_NewView{NewView: newView}})
+	c.onNewView(c.selfID, newView)
+}
+
+// verifyViewChange verifies the signature of a view change and the certificate it carries.
+func (c *Chain) verifyViewChange(signed *bft.SignedViewChange) (*bft.ViewChange, error) {
+	consenter := c.consenter(signed.Signer)
+	if consenter == nil {
+		return nil, errors.Errorf("%d is not a consenter", signed.Signer)
+	}
+	_, err := quorumPolicy(c.consenters, c.deserializer()).VerifySignature(&common.SignedData{
+		Identity:  consenter.Identity,
+		Data:      signed.ViewChange,
+		Signature: signed.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vc := &bft.ViewChange{}
+	if err := proto.Unmarshal(signed.ViewChange, vc); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling view change")
+	}
+	if vc.Prepared != nil {
+		if err := verifyCertificate(vc.Prepared, c.consenters, c.deserializer()); err != nil {
+			return nil, errors.WithMessage(err, "invalid prepared certificate")
+		}
+		if n := vc.Prepared.Block.Header.Number; n != vc.Height && n+1 != vc.Height {
+			return nil, errors.Errorf("certificate of block [%d] at height %d", vc.Prepared.Block.Header.Number, vc.Height)
+		}
+	}
+	return vc, nil
+}
+
+func (c *Chain) onNewView(sender uint64, newView *bft.NewView) {
+	if newView.View <= c.view {
+		return
+	}
+	if sender != c.leaderOf(newView.View) {
+		c.logger.Warnf("Ignoring new view %d of %d, leader of view %d is %d", newView.View, sender, newView.View, c.leaderOf(newView.View))
+		return
+	}
+
+	height := c.support.Height()
+	signers := make(map[uint64]struct{})
+	var required *bft.PreparedCertificate
+	for _, signed := range newView.ViewChanges {
+		vc, err := c.verifyViewChange(signed)
+		if err != nil {
+			c.logger.Warnf("Ignoring new view %d of %d: %s", newView.View, sender, err)
+			return
+		}
+		if vc.NextView != newView.View {
+			c.logger.Warnf("Ignoring new view %d of %d: it has a view change to view %d", newView.View, sender, vc.NextView)
+			return
+		}
+		signers[signed.Signer] = struct{}{}
+
+		cert := vc.Prepared
+		if cert != nil && cert.Block.Header.Number == height && (required == nil || cert.View > required.View) {
+			required = cert
+		}
+	}
+	if len(signers) < quorum.Size(len(c.consenters)) {
+		c.logger.Warnf("Ignoring new view %d of %d: it has view changes of %d consenters", newView.View, sender, len(signers))
+		return
+	}
+
+	c.lastNewView = newView
+	c.installView(newView.View, required)
+}
+
+// installView moves to the given view, in which the given certificate, if any,
+// is the block that has to be proposed.
+func (c *Chain) installView(view uint64, required *bft.PreparedCertificate) {
+	c.logger.Infof("Installing view %d, leader is %d", view, c.leaderOf(view))
+
+	c.adoptView(view)
+	c.proposal = nil
+	c.required = required
+}
+
+// adoptView moves to the given view, and hands the pending requests to its leader.
+// A view change in progress is abandoned, as the given view is the view in which
+// a quorum of consenters operates.
+func (c *Chain) adoptView(view uint64) {
+	if view < c.view || (view == c.view && !c.changingView()) {
+		return
+	}
+
+	c.view = view
+	c.nextView = view
+	for v := range c.viewChanges {
+		if v <= view {
+			delete(c.viewChanges, v)
+		}
+	}
+	c.Metrics.View.Set(float64(view))
+	c.setLeaderMetric()
+
+	c.support.BlockCutter().Cut()
+	c.stopBatchTimer()
+	c.batches = nil
+	c.lastProgress = c.clock.Now()
+	c.progressed = true
+
+	// The timers of the pending requests restart, so that the new
+	// leader has the time to include them in blocks.
+	now := c.clock.Now()
+	leader := c.leader() == c.selfID
+	for _, r := range c.pendingRequests() {
+		r.received = now
+		if leader {
+			c.order(r.req)
+		}
+	}
+}
+
+// checkTimeouts starts a view change if the leader does not include the
+// pending requests in blocks or does not complete its proposal in time.
+func (c *Chain) checkTimeouts() {
+	now := c.clock.Now()
+
+	if c.changingView() {
+		if now.Sub(c.viewChangeStart) > c.viewChangeTimeout {
+			c.logger.Warnf("View change to view %d did not complete in %s", c.nextView, c.viewChangeTimeout)
+			c.startViewChange(c.nextView + 1)
+		}
+		return
+	}
+
+	if c.proposal != nil && now.Sub(c.lastProgress) > c.requestTimeout {
+		c.logger.Warnf("Block [%d] proposed in view %d was not committed in %s", c.proposal.block.Header.Number, c.view, c.requestTimeout)
+		c.startViewChange(c.view + 1)
+		return
+	}
+
+	if c.leader() == c.selfID {
+		return
+	}
+
+	for _, r := range c.requests {
+		if now.Sub(r.received) > c.requestTimeout {
+			c.logger.Warnf("A request was not included in a block in %s, suspecting leader %d", c.requestTimeout, c.leader())
+			c.startViewChange(c.view + 1)
+			return
+		}
+	}
+}
+
+// noteHeight records that the given consenter reached the given height.
+func (c *Chain) noteHeight(sender uint64, height uint64) {
+	if height > c.heights[sender] {
+		c.heights[sender] = height
+	}
+}
+
+// checkLag pulls the blocks that enough consenters committed for at least
+// one of them to be correct, if this node did not commit them.
+func (c *Chain) checkLag() {
+	height := c.support.Height()
+
+	var ahead []uint64
+	for _, h := range c.heights {
+		if h > height {
+			ahead = append(ahead, h)
+		}
+	}
+	f := (len(c.consenters) - 1) / 3
+	if len(ahead) <= f {
+		return
+	}
+	sort.Slice(ahead, func(i, j int) bool {
+		return ahead[i] > ahead[j]
+	})
+	target := ahead[f]
+
+	// Consenters that work on the next block committed the block this node works
+	// on, which is expected to be committed here shortly.
+	if target == height+1 && c.proposal != nil && c.proposal.prepared {
+		return
+	}
+
+	if err := c.catchUp(target); err != nil {
+		c.logger.Warnf("Failed catching up to height %d: %s", target, err)
+	}
+}
+
+// catchUp pulls the blocks up to the given height from the other consenters.
+func (c *Chain) catchUp(target uint64) error {
+	height := c.support.Height()
+	c.logger.Infof("Catching up from height %d to height %d", height, target)
+
+	puller, err := c.createPuller()
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block puller")
+	}
+	defer puller.Close()
+
+	for seq := height; seq < target; seq++ {
+		block := puller.PullBlock(seq)
+		if block == nil {
+			return errors.Errorf("failed pulling block [%d]", seq)
+		}
+		if err := verifyBlockQuorum(block, c.consenters, c.deserializer()); err != nil {
+			return errors.WithMessage(err, "failed verifying block")
+		}
+		metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+		if err != nil {
+			return errors.WithMessage(err, "failed reading block metadata")
+		}
+		blockMetadata, err := ReadBlockMetadata(metadata)
+		if err != nil {
+			return err
+		}
+
+		c.writeBlock(block, blockMetadata.View)
+		c.adoptView(blockMetadata.View)
+		if c.evicted {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (c *Chain) postpone(sender uint64, msg *bft.Message) {
+	var count int
+	for _, m := range c.postponed {
+		if m.sender == sender {
+			count++
+		}
+	}
+	if count >= maxPostponedMessages {
+		c.logger.Debugf("Dropping message of %d, too many of its messages are postponed", sender)
+		return
+	}
+	c.postponed = append(c.postponed, &message{sender: sender, msg: msg})
+}
+
+// replayPostponed handles the postponed messages again.
+func (c *Chain) replayPostponed() {
+	postponed := c.postponed
+	c.postponed = nil
+	for _, m := range postponed {
+		c.onMessage(m.sender, m.msg)
+	}
+}
+
+func (c *Chain) broadcast(msg *bft.Message) {
+	for _, consenter := range c.consenters {
+		if consenter.Id != c.selfID {
+			c.send(consenter.Id, msg)
+		}
+	}
+}
+
+func (c *Chain) send(dest uint64, msg *bft.Message) {
+	req := &orderer.ConsensusRequest{
+		Channel: c.channelID,
+		Payload: utils.MarshalOrPanic(msg),
+	}
+	if err := c.rpc.SendConsensus(dest, req); err != nil {
+		c.logger.Errorf("Failed to send message to %d: %s", dest, err)
+	}
+}
+
+func (c *Chain) consenter(id uint64) *bft.Consenter {
+	for _, consenter := range c.consenters {
+		if consenter.Id == id {
+			return consenter
+		}
+	}
+	return nil
+}
+
+func requestKey(env *common.Envelope) string {
+	digest := sha256.Sum256(util.ConcatenateBytes(env.Payload, env.Signature))
+	return string(digest[:])
+}
+
+// addRequest tracks the given request until it is included in a block,
+// and returns false if it is already tracked.
+func (c *Chain) addRequest(req *orderer.SubmitRequest) bool {
+	key := requestKey(req.Payload)
+	if _, exists := c.requests[key]; exists {
+		return false
+	}
+	c.requestIndex++
+	c.requests[key] = &pendingRequest{req: req, received: c.clock.Now(), index: c.requestIndex}
+	return true
+}
+
+func (c *Chain) removeRequest(env *common.Envelope) {
+	delete(c.requests, requestKey(env))
+}
+
+// replaceRequest tracks a revalidated config request in place of the original one.
+func (c *Chain) replaceRequest(original, revalidated *common.Envelope) {
+	r, exists := c.requests[requestKey(original)]
+	if !exists {
+		return
+	}
+	c.removeRequest(original)
+	r.req = &orderer.SubmitRequest{LastValidationSeq: c.support.Sequence(), Payload: revalidated, Channel: r.req.Channel}
+	c.requests[requestKey(revalidated)] = r
+}
+
+// pendingRequests returns the tracked requests in the order they were received.
+func (c *Chain) pendingRequests() []*pendingRequest {
+	var pending []*pendingRequest
+	for _, r := range c.requests {
+		pending = append(pending, r)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].index < pending[j].index
+	})
+	return pending
+}
+
+func (c *Chain) startBatchTimer() {
+	if !c.batchTicking {
+		c.batchTicking = true
+		c.batchTimer.Reset(c.support.SharedConfig().BatchTimeout())
+	}
+}
+
+func (c *Chain) stopBatchTimer() {
+	if !c.batchTimer.Stop() && c.batchTicking {
+		// we only need to drain the channel if the timer expired (not explicitly stopped)
+		<-c.batchTimer.C()
+	}
+	c.batchTicking = false
+}
+
+func (c *Chain) isConfig(env *common.Envelope) bool {
+	h, err := utils.ChannelHeader(env)
+	if err != nil {
+		c.logger.Panicf("failed to extract channel header from envelope")
+	}
+
+	return h.Type == int32(common.HeaderType_CONFIG) || h.Type == int32(common.HeaderType_ORDERER_TRANSACTION)
+}
+
+func (c *Chain) configureComm() error {
+	nodes, err := remoteNodes(c.consenters, c.selfID, c.logger)
+	if err != nil {
+		return err
+	}
+
+	c.configurator.Configure(c.channelID, nodes)
+	return nil
+}
+
+// isConfigUpdateBlock returns whether the given block updates the config of its channel.
+func isConfigUpdateBlock(block *common.Block) bool {
+	if !utils.IsConfigBlock(block) {
+		return false
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return false
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return false
+	}
+	return chdr.Type == int32(common.HeaderType_CONFIG)
+}
+
+// configOfEnvelope returns the config carried by a config transaction, or by the
+// config transaction of a new channel for orderer transactions.
+func configOfEnvelope(env *common.Envelope) (*common.Config, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("config transaction has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if chdr.Type == int32(common.HeaderType_ORDERER_TRANSACTION) {
+		inner, err := utils.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		return configOfEnvelope(inner)
+	}
+
+	configEnvelope := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling config envelope")
+	}
+	return configEnvelope.Config, nil
+}
@@ -0,0 +1,658 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/channelconfig"
+	"github.com/tradeline-tech/fabric/common/configtx"
+	"github.com/tradeline-tech/fabric/common/crypto/tlsgen"
+	"github.com/tradeline-tech/fabric/common/flogging"
+	mockconfig "github.com/tradeline-tech/fabric/common/mocks/config"
+	"github.com/tradeline-tech/fabric/common/policies/quorum"
+	"github.com/tradeline-tech/fabric/common/util"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/orderer/consensus/bft"
+	"github.com/tradeline-tech/fabric/orderer/consensus/bft/mocks"
+	consensusmocks "github.com/tradeline-tech/fabric/orderer/consensus/mocks"
+	mockblockcutter "github.com/tradeline-tech/fabric/orderer/mocks/common/blockcutter"
+	"github.com/tradeline-tech/fabric/protos/common"
+	protosmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	bftprotos "github.com/tradeline-tech/fabric/protos/orderer/bft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+const (
+	channelID           = "test-channel"
+	requestTimeout      = 10 * time.Second
+	LongEventualTimeout = 10 * time.Second
+)
+
+var _ = Describe("Chain", func() {
+	var (
+		tlsCA   tlsgen.CA
+		network *network
+	)
+
+	BeforeEach(func() {
+		var err error
+		tlsCA, err = tlsgen.NewCA()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		network.stop()
+	})
+
+	Context("with a single consenter", func() {
+		BeforeEach(func() {
+			network = createNetwork(createMetadata(1, tlsCA))
+			network.start()
+		})
+
+		It("orders envelopes into blocks signed by the consenter", func() {
+			c := network.nodes[1]
+			Expect(c.Order(env("foo"), 0)).To(Succeed())
+
+			Eventually(c.height, LongEventualTimeout).Should(Equal(uint64(2)))
+			block := c.block(1)
+			Expect(block.Data.Data).To(HaveLen(1))
+			Expect(verifyQuorum(block, 1)).To(Succeed())
+			Expect(viewOf(block)).To(Equal(uint64(0)))
+			Expect(c.fakeFields.fakeCommittedBlockNumber.SetArgsForCall(c.fakeFields.fakeCommittedBlockNumber.SetCallCount() - 1)).To(Equal(float64(1)))
+		})
+
+		It("stops serving requests when halted", func() {
+			c := network.nodes[1]
+			c.Halt()
+
+			Eventually(c.Errored()).Should(BeClosed())
+			Expect(c.Order(env("foo"), 0)).To(MatchError("chain is stopped"))
+		})
+	})
+
+	Context("with four consenters", func() {
+		BeforeEach(func() {
+			network = createNetwork(createMetadata(4, tlsCA))
+			network.start()
+		})
+
+		It("orders envelopes submitted to the leader on all consenters", func() {
+			Expect(network.nodes[1].Order(env("foo"), 0)).To(Succeed())
+
+			network.expectHeight(2, 1, 2, 3, 4)
+			block := network.nodes[1].block(1)
+			Expect(verifyQuorum(block, 4)).To(Succeed())
+			for _, id := range []uint64{2, 3, 4} {
+				Expect(network.nodes[id].block(1).Header.Hash()).To(Equal(block.Header.Hash()))
+				Expect(verifyQuorum(network.nodes[id].block(1), 4)).To(Succeed())
+			}
+		})
+
+		It("relays envelopes submitted to a follower to the leader", func() {
+			Expect(network.nodes[3].Order(env("foo"), 0)).To(Succeed())
+
+			network.expectHeight(2, 1, 2, 3, 4)
+			Expect(network.nodes[3].rpc.SendSubmitCallCount()).To(Equal(3))
+		})
+
+		It("keeps ordering when a follower is unreachable", func() {
+			network.disconnect(4)
+			Expect(network.nodes[1].Order(env("foo"), 0)).To(Succeed())
+
+			network.expectHeight(2, 1, 2, 3)
+			Consistently(network.nodes[4].height).Should(Equal(uint64(1)))
+		})
+
+		It("catches up a consenter that missed blocks", func() {
+			network.disconnect(4)
+			Expect(network.nodes[1].Order(env("foo"), 0)).To(Succeed())
+			network.expectHeight(2, 1, 2, 3)
+			Expect(network.nodes[1].Order(env("bar"), 0)).To(Succeed())
+			network.expectHeight(3, 1, 2, 3)
+
+			c4 := network.nodes[4]
+			c4.puller.PullBlockStub = func(seq uint64) *common.Block {
+				return network.nodes[1].block(seq)
+			}
+			network.connect(4)
+			Expect(network.nodes[1].Order(env("baz"), 0)).To(Succeed())
+
+			Eventually(func() uint64 {
+				c4.clock.Increment(time.Second)
+				return c4.height()
+			}, LongEventualTimeout).Should(Equal(uint64(4)))
+			for seq := uint64(1); seq < 4; seq++ {
+				Expect(c4.block(seq).Header.Hash()).To(Equal(network.nodes[1].block(seq).Header.Hash()))
+			}
+		})
+
+		It("changes the view when the leader is unreachable", func() {
+			network.disconnect(1)
+			Expect(network.nodes[2].Order(env("foo"), 0)).To(Succeed())
+
+			Eventually(func() uint64 {
+				for _, id := range []uint64{2, 3, 4} {
+					network.nodes[id].clock.Increment(requestTimeout / 2)
+				}
+				return network.nodes[2].height()
+			}, LongEventualTimeout).Should(Equal(uint64(2)))
+
+			network.expectHeight(2, 2, 3, 4)
+			for _, id := range []uint64{2, 3, 4} {
+				block := network.nodes[id].block(1)
+				Expect(verifyQuorum(block, 4)).To(Succeed())
+				Expect(viewOf(block)).To(Equal(uint64(1)))
+				Expect(network.nodes[id].fakeFields.fakeViewChanges.AddCallCount()).To(BeNumerically(">", 0))
+			}
+		})
+
+		It("changes the view when the leader proposes an invalid block", func() {
+			for _, id := range []uint64{2, 3, 4} {
+				network.nodes[id].support.ProcessNormalMsgStub = func(env *common.Envelope) (uint64, error) {
+					payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+					if string(payload.Data) == "bad" {
+						return 0, errors.New("bad transaction")
+					}
+					return 0, nil
+				}
+			}
+
+			Expect(network.nodes[1].Order(env("bad"), 0)).To(Succeed())
+			for _, id := range []uint64{2, 3, 4} {
+				Eventually(network.nodes[id].fakeFields.fakeProposalFailures.AddCallCount, LongEventualTimeout).Should(Equal(1))
+			}
+
+			Expect(network.nodes[3].Order(env("good"), 0)).To(Succeed())
+			network.expectHeight(2, 1, 2, 3, 4)
+			for _, id := range []uint64{1, 2, 3, 4} {
+				block := network.nodes[id].block(1)
+				Expect(viewOf(block)).To(Equal(uint64(1)))
+				Expect(utils.UnmarshalPayloadOrPanic(utils.UnmarshalEnvelopeOrPanic(block.Data.Data[0]).Payload).Data).To(Equal([]byte("good")))
+			}
+		})
+
+		It("counts only the latest view change of each consenter", func() {
+			viewChange := func(sender, view uint64) *orderer.ConsensusRequest {
+				vc := utils.MarshalOrPanic(&bftprotos.ViewChange{NextView: view, Height: 1})
+				signed := &bftprotos.SignedViewChange{Signer: sender, ViewChange: vc, Signature: sign(serializedIdentity(sender), vc)}
+				return &orderer.ConsensusRequest{
+					Channel: channelID,
+					Payload: utils.MarshalOrPanic(&bftprotos.Message{Payload: &bftprotos.Message_ViewChange{ViewChange: signed}}),
+				}
+			}
+
+			c2 := network.nodes[2]
+			// the view change of 4 to view 1 is superseded by the one to view 2,
+			// so a single consenter asks for view 1 and 2 does not join it.
+			for view := uint64(1); view <= 2; view++ {
+				Expect(c2.Consensus(viewChange(4, view), 4)).To(Succeed())
+			}
+			Expect(c2.Consensus(viewChange(3, 1), 3)).To(Succeed())
+			Consistently(c2.fakeFields.fakeViewChanges.AddCallCount).Should(Equal(0))
+
+			Expect(c2.Consensus(viewChange(3, 2), 3)).To(Succeed())
+			Eventually(c2.fakeFields.fakeViewChanges.AddCallCount, LongEventualTimeout).Should(Equal(1))
+		})
+
+		It("removes a consenter through a config update", func() {
+			metadata := createMetadata(4, tlsCA)
+			metadata.Consenters = metadata.Consenters[:3]
+			for _, c := range network.nodes {
+				c.support.ProcessConfigMsgStub = func(env *common.Envelope) (*common.Envelope, uint64, error) {
+					return env, 0, nil
+				}
+			}
+
+			Expect(network.nodes[1].Configure(configEnv(metadata), 0)).To(Succeed())
+
+			network.expectHeight(2, 1, 2, 3, 4)
+			Eventually(network.nodes[4].halted, LongEventualTimeout).Should(BeClosed())
+			for _, id := range []uint64{1, 2, 3} {
+				c := network.nodes[id]
+				Expect(c.configurator.ConfigureCallCount()).To(Equal(2))
+				_, nodes := c.configurator.ConfigureArgsForCall(1)
+				Expect(nodes).To(HaveLen(2))
+				Expect(c.fakeFields.fakeClusterSize.SetArgsForCall(c.fakeFields.fakeClusterSize.SetCallCount() - 1)).To(Equal(float64(3)))
+			}
+
+			Expect(network.nodes[2].Order(env("foo"), 0)).To(Succeed())
+			network.expectHeight(3, 1, 2, 3)
+		})
+
+		It("rejects config updates that switch to another consensus type", func() {
+			update := configEnv(createMetadata(4, tlsCA))
+			payload := utils.UnmarshalPayloadOrPanic(update.Payload)
+			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+			Expect(err).NotTo(HaveOccurred())
+			configUpdate, err := configtx.UnmarshalConfigUpdateFromPayload(payload)
+			Expect(err).NotTo(HaveOccurred())
+			configUpdate.WriteSet.Groups["Orderer"].Values["ConsensusType"].Value = utils.MarshalOrPanic(&orderer.ConsensusType{Type: "etcdraft"})
+			configEnvelope.LastUpdate = configUpdateEnvelope(configUpdate)
+			payload.Data = utils.MarshalOrPanic(configEnvelope)
+			update.Payload = utils.MarshalOrPanic(payload)
+
+			err = network.nodes[1].Configure(update, 0)
+			Expect(err).To(MatchError("changing the consensus type from bft to etcdraft is not supported"))
+		})
+	})
+})
+
+type node struct {
+	id uint64
+
+	support      *consensusmocks.FakeConsenterSupport
+	sharedConfig *mockconfig.Orderer
+	cutter       *mockblockcutter.Receiver
+	configurator *mocks.FakeConfigurator
+	rpc          *mocks.FakeRPC
+	puller       *mocks.FakeBlockPuller
+	clock        *fakeclock.FakeClock
+	fakeFields   *fakeMetricsFields
+	halted       chan struct{}
+
+	ledgerLock sync.RWMutex
+	ledger     []*common.Block
+
+	*bft.Chain
+}
+
+func newNode(id uint64, metadata *bftprotos.ConfigMetadata, network *network) *node {
+	n := &node{
+		id:           id,
+		support:      &consensusmocks.FakeConsenterSupport{},
+		cutter:       mockblockcutter.NewReceiver(),
+		configurator: &mocks.FakeConfigurator{},
+		rpc:          &mocks.FakeRPC{},
+		puller:       &mocks.FakeBlockPuller{},
+		clock:        fakeclock.NewFakeClock(time.Now()),
+		fakeFields:   newFakeMetricsFields(),
+		halted:       make(chan struct{}),
+		ledger:       []*common.Block{seedBlock()},
+		sharedConfig: &mockconfig.Orderer{
+			BatchTimeoutVal:      time.Hour,
+			BatchSizeVal:         &orderer.BatchSize{MaxMessageCount: 10},
+			ConsensusMetadataVal: utils.MarshalOrPanic(metadata),
+		},
+	}
+
+	// blocks are cut for every envelope
+	close(n.cutter.Block)
+	n.cutter.CutNext = true
+
+	identity := serializedIdentity(id)
+	n.support.ChainIDReturns(channelID)
+	n.support.SharedConfigStub = func() channelconfig.Orderer { return n.sharedConfig }
+	n.support.BlockCutterReturns(n.cutter)
+	n.support.NewSignatureHeaderStub = func() (*common.SignatureHeader, error) {
+		return &common.SignatureHeader{Creator: identity, Nonce: []byte{1, 2, 3}}, nil
+	}
+	n.support.SignStub = func(message []byte) ([]byte, error) {
+		return sign(identity, message), nil
+	}
+	n.support.HeightStub = n.height
+	n.support.BlockStub = n.block
+	n.support.CreateNextBlockStub = n.createNextBlock
+	n.support.WriteBlockStub = n.writeBlock
+	n.support.WriteConfigBlockStub = func(block *common.Block, encodedMetadataValue []byte) {
+		payload := utils.UnmarshalPayloadOrPanic(utils.UnmarshalEnvelopeOrPanic(block.Data.Data[0]).Payload)
+		configUpdate, err := configtx.UnmarshalConfigUpdateFromPayload(payload)
+		Expect(err).NotTo(HaveOccurred())
+		metadata, err := bft.MetadataFromConfigUpdate(configUpdate)
+		Expect(err).NotTo(HaveOccurred())
+		n.sharedConfig.ConsensusMetadataVal = utils.MarshalOrPanic(metadata)
+		n.writeBlock(block, encodedMetadataValue)
+	}
+
+	n.rpc.SendConsensusStub = func(dest uint64, msg *orderer.ConsensusRequest) error {
+		network.send(id, dest, func(c *node) { c.Consensus(msg, id) })
+		return nil
+	}
+	n.rpc.SendSubmitStub = func(dest uint64, request *orderer.SubmitRequest) error {
+		network.send(id, dest, func(c *node) { c.Submit(request, id) })
+		return nil
+	}
+
+	opts := bft.Options{
+		SelfID:            id,
+		Clock:             n.clock,
+		Logger:            flogging.MustGetLogger("test"),
+		Consenters:        metadata.Consenters,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: 2 * requestTimeout,
+		Metrics:           newFakeMetrics(n.fakeFields),
+	}
+
+	var once sync.Once
+	chain, err := bft.NewChain(
+		n.support,
+		opts,
+		n.configurator,
+		n.rpc,
+		func() msp.IdentityDeserializer { return &fakeDeserializer{} },
+		func() (bft.BlockPuller, error) { return n.puller, nil },
+		func() { once.Do(func() { close(n.halted) }) },
+	)
+	Expect(err).NotTo(HaveOccurred())
+	n.Chain = chain
+	return n
+}
+
+func (n *node) height() uint64 {
+	n.ledgerLock.RLock()
+	defer n.ledgerLock.RUnlock()
+	return uint64(len(n.ledger))
+}
+
+func (n *node) block(number uint64) *common.Block {
+	n.ledgerLock.RLock()
+	defer n.ledgerLock.RUnlock()
+	if number >= uint64(len(n.ledger)) {
+		return nil
+	}
+	return proto.Clone(n.ledger[number]).(*common.Block)
+}
+
+func (n *node) createNextBlock(envs []*common.Envelope) *common.Block {
+	last := n.block(n.height() - 1)
+	data := &common.BlockData{}
+	for _, env := range envs {
+		data.Data = append(data.Data, utils.MarshalOrPanic(env))
+	}
+	block := common.NewBlock(last.Header.Number+1, last.Header.Hash())
+	block.Data = data
+	block.Header.DataHash = data.Hash()
+	return block
+}
+
+func (n *node) writeBlock(block *common.Block, encodedMetadataValue []byte) {
+	block = proto.Clone(block).(*common.Block)
+	block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&common.Metadata{Value: encodedMetadataValue})
+
+	n.ledgerLock.Lock()
+	defer n.ledgerLock.Unlock()
+	n.ledger = append(n.ledger, block)
+}
+
+// network delivers the messages the consenters send each other
+// in order, unless the sender or the receiver is disconnected.
+type network struct {
+	sync.RWMutex
+
+	nodes     map[uint64]*node
+	connected map[uint64]bool
+	inboxes   map[uint64]chan func(*node)
+	stopped   bool
+}
+
+func createNetwork(metadata *bftprotos.ConfigMetadata) *network {
+	n := &network{
+		nodes:     make(map[uint64]*node),
+		connected: make(map[uint64]bool),
+		inboxes:   make(map[uint64]chan func(*node)),
+	}
+	for _, consenter := range metadata.Consenters {
+		n.nodes[consenter.Id] = newNode(consenter.Id, metadata, n)
+		n.connected[consenter.Id] = true
+		n.inboxes[consenter.Id] = make(chan func(*node), 10000)
+	}
+	return n
+}
+
+func (n *network) start() {
+	for id, c := range n.nodes {
+		c.Start()
+		go func(c *node, inbox chan func(*node)) {
+			for deliver := range inbox {
+				deliver(c)
+			}
+		}(c, n.inboxes[id])
+	}
+}
+
+func (n *network) stop() {
+	for _, c := range n.nodes {
+		c.Halt()
+	}
+
+	n.Lock()
+	defer n.Unlock()
+	n.stopped = true
+	for _, inbox := range n.inboxes {
+		close(inbox)
+	}
+}
+
+func (n *network) send(from, to uint64, deliver func(*node)) {
+	n.RLock()
+	defer n.RUnlock()
+	if n.stopped || !n.connected[from] || !n.connected[to] {
+		return
+	}
+	n.inboxes[to] <- deliver
+}
+
+func (n *network) connect(id uint64) {
+	n.Lock()
+	defer n.Unlock()
+	n.connected[id] = true
+}
+
+func (n *network) disconnect(id uint64) {
+	n.Lock()
+	defer n.Unlock()
+	n.connected[id] = false
+}
+
+func (n *network) expectHeight(height uint64, ids ...uint64) {
+	for _, id := range ids {
+		Eventually(n.nodes[id].height, LongEventualTimeout).Should(Equal(height))
+	}
+}
+
+type fakeDeserializer struct{}
+
+func (*fakeDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	return &fakeIdentity{serialized: serializedIdentity}, nil
+}
+
+func (*fakeDeserializer) IsWellFormed(_ *protosmsp.SerializedIdentity) error {
+	return nil
+}
+
+// fakeIdentity verifies the signatures produced by sign.
+type fakeIdentity struct {
+	msp.Identity
+	serialized []byte
+}
+
+func (id *fakeIdentity) Verify(msg []byte, sig []byte) error {
+	if !bytes.Equal(sig, sign(id.serialized, msg)) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func sign(identity, message []byte) []byte {
+	digest := sha256.Sum256(util.ConcatenateBytes(identity, message))
+	return digest[:]
+}
+
+func serializedIdentity(id uint64) []byte {
+	return utils.MarshalOrPanic(&protosmsp.SerializedIdentity{
+		Mspid:   "OrdererMSP",
+		IdBytes: []byte(fmt.Sprintf("orderer%d", id)),
+	})
+}
+
+func createMetadata(nodeCount int, tlsCA tlsgen.CA) *bftprotos.ConfigMetadata {
+	md := &bftprotos.ConfigMetadata{Options: &bftprotos.Options{
+		RequestTimeout:    requestTimeout.String(),
+		ViewChangeTimeout: (2 * requestTimeout).String(),
+	}}
+	for i := 1; i <= nodeCount; i++ {
+		md.Consenters = append(md.Consenters, &bftprotos.Consenter{
+			Id:            uint64(i),
+			Host:          "localhost",
+			Port:          uint32(7050 + i),
+			MspId:         "OrdererMSP",
+			Identity:      serializedIdentity(uint64(i)),
+			ServerTlsCert: serverTLSCert(tlsCA),
+			ClientTlsCert: clientTLSCert(tlsCA),
+		})
+	}
+	return md
+}
+
+func serverTLSCert(tlsCA tlsgen.CA) []byte {
+	cert, err := tlsCA.NewServerCertKeyPair("localhost")
+	if err != nil {
+		panic(err)
+	}
+	return cert.Cert
+}
+
+func clientTLSCert(tlsCA tlsgen.CA) []byte {
+	cert, err := tlsCA.NewClientCertKeyPair()
+	if err != nil {
+		panic(err)
+	}
+	return cert.Cert
+}
+
+func seedBlock() *common.Block {
+	block := common.NewBlock(0, nil)
+	block.Data.Data = [][]byte{[]byte("genesis")}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: 0}),
+	})
+	return block
+}
+
+func env(data string) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_MESSAGE),
+					ChannelId: channelID,
+				}),
+			},
+			Data: []byte(data),
+		}),
+	}
+}
+
+func configUpdateEnvelope(configUpdate *common.ConfigUpdate) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_CONFIG_UPDATE),
+					ChannelId: channelID,
+				}),
+			},
+			Data: utils.MarshalOrPanic(&common.ConfigUpdateEnvelope{
+				ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+			}),
+		}),
+	}
+}
+
+// configEnv returns a config transaction that sets the given BFT metadata.
+func configEnv(metadata *bftprotos.ConfigMetadata) *common.Envelope {
+	configUpdate := &common.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Values: map[string]*common.ConfigValue{
+						"ConsensusType": {Version: 0},
+					},
+				},
+			},
+		},
+		WriteSet: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Values: map[string]*common.ConfigValue{
+						"ConsensusType": {
+							Version: 1,
+							Value: utils.MarshalOrPanic(&orderer.ConsensusType{
+								Type:     "bft",
+								Metadata: utils.MarshalOrPanic(metadata),
+							}),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_CONFIG),
+					ChannelId: channelID,
+				}),
+			},
+			Data: utils.MarshalOrPanic(&common.ConfigEnvelope{
+				Config:     &common.Config{Sequence: 1},
+				LastUpdate: configUpdateEnvelope(configUpdate),
+			}),
+		}),
+	}
+}
+
+// verifyQuorum verifies the block is signed by a quorum of the given number of consenters,
+// the way peers verify the blocks of channels ordered by BFT.
+func verifyQuorum(block *common.Block, consenterCount int) error {
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return err
+	}
+	var signatureSet []*common.SignedData
+	for _, signature := range metadata.Signatures {
+		shdr, err := utils.GetSignatureHeader(signature.SignatureHeader)
+		if err != nil {
+			return err
+		}
+		signatureSet = append(signatureSet, &common.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, signature.SignatureHeader, block.Header.Bytes()),
+			Signature: signature.Signature,
+		})
+	}
+
+	var identities [][]byte
+	for i := 1; i <= consenterCount; i++ {
+		identities = append(identities, serializedIdentity(uint64(i)))
+	}
+	return quorum.NewPolicy(identities, &fakeDeserializer{}).Evaluate(signatureSet)
+}
+
+func viewOf(block *common.Block) uint64 {
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+	Expect(err).NotTo(HaveOccurred())
+	blockMetadata, err := bft.ReadBlockMetadata(metadata)
+	Expect(err).NotTo(HaveOccurred())
+	return blockMetadata.View
+}
@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/metrics"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/orderer/common/cluster"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/common/multichannel"
+	"github.com/tradeline-tech/fabric/orderer/consensus"
+	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft"
+	"github.com/tradeline-tech/fabric/orderer/consensus/inactive"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
+)
+
+// MSPManagerGetter provides the MSP manager of a channel, which
+// deserializes the identities of the consenters of the channel.
+type MSPManagerGetter interface {
+	MSPManager() msp.MSPManager
+}
+
+// Consenter implements the BFT consenter. Its chains share the cluster communication
+// of the etcdraft consenter, which dispatches their messages to them.
+type Consenter struct {
+	CreateChain           func(chainName string)
+	InactiveChainRegistry etcdraft.InactiveChainRegistry
+	Dialer                *cluster.PredicateDialer
+	Communication         cluster.Communicator
+	Logger                *flogging.FabricLogger
+	OrdererConfig         localconfig.TopLevel
+	Cert                  []byte
+	Metrics               *Metrics
+}
+
+func (c *Consenter) detectSelfID(consenters []*bft.Consenter) (*bft.Consenter, error) {
+	thisNodeCertAsDER, err := pemToDER(c.Cert, 0, "server", c.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var serverCertificates []string
+	for _, cst := range consenters {
+		serverCertificates = append(serverCertificates, string(cst.ServerTlsCert))
+
+		certAsDER, err := pemToDER(cst.ServerTlsCert, cst.Id, "server", c.Logger)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(thisNodeCertAsDER, certAsDER) {
+			return cst, nil
+		}
+	}
+
+	c.Logger.Warning("Could not find", string(c.Cert), "among", serverCertificates)
+	return nil, cluster.ErrNotInChannel
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(support.SharedConfig().ConsensusMetadata(), m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+
+	if err := CheckConfigMetadata(m); err != nil {
+		return nil, errors.WithMessage(err, "invalid BFT config metadata")
+	}
+
+	// The view is restored from the metadata of the last committed block,
+	// and a chain that was not ordered by BFT so far starts in view zero.
+	blockMetadata, err := ReadBlockMetadata(metadata)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read BFT metadata")
+	}
+
+	self, err := c.detectSelfID(m.Consenters)
+	if err != nil {
+		c.InactiveChainRegistry.TrackChain(support.ChainID(), support.Block(0), func() {
+			c.CreateChain(support.ChainID())
+		})
+		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChainID())}, nil
+	}
+
+	shdr, err := support.NewSignatureHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signature header")
+	}
+	if !bytes.Equal(shdr.Creator, self.Identity) {
+		return nil, errors.Errorf("the signing identity of this orderer is not the identity of consenter %d", self.Id)
+	}
+
+	mspManagerGetter, ok := support.(MSPManagerGetter)
+	if !ok {
+		return nil, errors.Errorf("the support of channel %s does not provide an MSP manager", support.ChainID())
+	}
+	deserializer := func() msp.IdentityDeserializer {
+		return mspManagerGetter.MSPManager()
+	}
+
+	requestTimeout, _ := parseTimeout(m.Options.GetRequestTimeout(), DefaultRequestTimeout)
+	viewChangeTimeout, _ := parseTimeout(m.Options.GetViewChangeTimeout(), DefaultViewChangeTimeout)
+
+	opts := Options{
+		SelfID:            self.Id,
+		Clock:             clock.NewClock(),
+		Logger:            c.Logger,
+		Consenters:        m.Consenters,
+		View:              blockMetadata.View,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+		Metrics:           c.Metrics,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChainID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+	return NewChain(
+		support,
+		opts,
+		c.Communication,
+		rpc,
+		deserializer,
+		func() (BlockPuller, error) {
+			return newBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster, deserializer)
+		},
+		func() {
+			c.InactiveChainRegistry.TrackChain(support.ChainID(), nil, func() { c.CreateChain(support.ChainID()) })
+		},
+	)
+}
+
+// New creates a BFT Consenter, which communicates with the other
+// consenters through the given cluster communication.
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	communication cluster.Communicator,
+	conf *localconfig.TopLevel,
+	cert []byte,
+	r *multichannel.Registrar,
+	icr etcdraft.InactiveChainRegistry,
+	metricsProvider metrics.Provider,
+) *Consenter {
+	return &Consenter{
+		CreateChain:           r.CreateChain,
+		InactiveChainRegistry: icr,
+		Dialer:                clusterDialer,
+		Communication:         communication,
+		Logger:                flogging.MustGetLogger("orderer.consensus.bft"),
+		OrdererConfig:         *conf,
+		Cert:                  cert,
+		Metrics:               NewMetrics(metricsProvider),
+	}
+}
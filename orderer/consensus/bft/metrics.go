@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import "github.com/tradeline-tech/fabric/common/metrics"
+
+var (
+	clusterSizeOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "cluster_size",
+		Help:         "Number of nodes in this channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	isLeaderOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "is_leader",
+		Help:         "The leadership status of the current node: 1 if it is the leader of the current view else 0.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	committedBlockNumberOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "committed_block_number",
+		Help:         "The block number of the latest block committed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	viewOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "view",
+		Help:         "The current view of the node.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	viewChangesOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "view_changes",
+		Help:         "The number of view changes started by the node since process start.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	proposalFailuresOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "bft",
+		Name:         "proposal_failures",
+		Help:         "The number of block proposals of the leader that were rejected by the node.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+type Metrics struct {
+	ClusterSize          metrics.Gauge
+	IsLeader             metrics.Gauge
+	CommittedBlockNumber metrics.Gauge
+	View                 metrics.Gauge
+	ViewChanges          metrics.Counter
+	ProposalFailures     metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		ClusterSize:          p.NewGauge(clusterSizeOpts),
+		IsLeader:             p.NewGauge(isLeaderOpts),
+		CommittedBlockNumber: p.NewGauge(committedBlockNumberOpts),
+		View:                 p.NewGauge(viewOpts),
+		ViewChanges:          p.NewCounter(viewChangesOpts),
+		ProposalFailures:     p.NewCounter(proposalFailuresOpts),
+	}
+}
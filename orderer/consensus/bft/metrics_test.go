@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/common/metrics/metricsfakes"
+	"github.com/tradeline-tech/fabric/orderer/consensus/bft"
+)
+
+var _ = Describe("Metrics", func() {
+	Context("NewMetrics", func() {
+		var (
+			fakeProvider *metricsfakes.Provider
+			fakeGauge    *metricsfakes.Gauge
+			fakeCounter  *metricsfakes.Counter
+		)
+
+		BeforeEach(func() {
+			fakeProvider = &metricsfakes.Provider{}
+			fakeGauge = &metricsfakes.Gauge{}
+			fakeCounter = &metricsfakes.Counter{}
+
+			fakeProvider.NewGaugeReturns(fakeGauge)
+			fakeProvider.NewCounterReturns(fakeCounter)
+		})
+
+		It("uses the provider to initialize a new Metrics object", func() {
+			metrics := bft.NewMetrics(fakeProvider)
+
+			Expect(metrics).NotTo(BeNil())
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(4))
+			Expect(fakeProvider.NewCounterCallCount()).To(Equal(2))
+
+			Expect(metrics.ClusterSize).To(Equal(fakeGauge))
+			Expect(metrics.IsLeader).To(Equal(fakeGauge))
+			Expect(metrics.CommittedBlockNumber).To(Equal(fakeGauge))
+			Expect(metrics.View).To(Equal(fakeGauge))
+			Expect(metrics.ViewChanges).To(Equal(fakeCounter))
+			Expect(metrics.ProposalFailures).To(Equal(fakeCounter))
+		})
+	})
+})
+
+type fakeMetricsFields struct {
+	fakeClusterSize          *metricsfakes.Gauge
+	fakeIsLeader             *metricsfakes.Gauge
+	fakeCommittedBlockNumber *metricsfakes.Gauge
+	fakeView                 *metricsfakes.Gauge
+	fakeViewChanges          *metricsfakes.Counter
+	fakeProposalFailures     *metricsfakes.Counter
+}
+
+func newFakeMetrics(fakeFields *fakeMetricsFields) *bft.Metrics {
+	return &bft.Metrics{
+		ClusterSize:          fakeFields.fakeClusterSize,
+		IsLeader:             fakeFields.fakeIsLeader,
+		CommittedBlockNumber: fakeFields.fakeCommittedBlockNumber,
+		View:                 fakeFields.fakeView,
+		ViewChanges:          fakeFields.fakeViewChanges,
+		ProposalFailures:     fakeFields.fakeProposalFailures,
+	}
+}
+
+func newFakeMetricsFields() *fakeMetricsFields {
+	return &fakeMetricsFields{
+		fakeClusterSize:          newFakeGauge(),
+		fakeIsLeader:             newFakeGauge(),
+		fakeCommittedBlockNumber: newFakeGauge(),
+		fakeView:                 newFakeGauge(),
+		fakeViewChanges:          newFakeCounter(),
+		fakeProposalFailures:     newFakeCounter(),
+	}
+}
+
+func newFakeGauge() *metricsfakes.Gauge {
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	return fakeGauge
+}
+
+func newFakeCounter() *metricsfakes.Counter {
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	return fakeCounter
+}
@@ -0,0 +1,358 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/policies/quorum"
+	"github.com/tradeline-tech/fabric/common/util"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/orderer/common/cluster"
+	"github.com/tradeline-tech/fabric/orderer/common/localconfig"
+	"github.com/tradeline-tech/fabric/orderer/consensus"
+	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+const (
+	// DefaultRequestTimeout is the time a request may wait to be included
+	// in a block before the leader is suspected, if not set in the channel config.
+	DefaultRequestTimeout = 10 * time.Second
+
+	// DefaultViewChangeTimeout is the time a view change may take before the
+	// next view is attempted, if not set in the channel config.
+	DefaultViewChangeTimeout = 20 * time.Second
+)
+
+// CheckConfigMetadata validates BFT config metadata
+func CheckConfigMetadata(metadata *bft.ConfigMetadata) error {
+	if metadata == nil {
+		return errors.Errorf("nil BFT config metadata")
+	}
+
+	if len(metadata.Consenters) == 0 {
+		return errors.Errorf("empty consenter set")
+	}
+
+	ids := make(map[uint64]struct{})
+	identities := make(map[string]struct{})
+	for _, consenter := range metadata.Consenters {
+		if consenter == nil {
+			return errors.Errorf("nil consenter in BFT config metadata")
+		}
+		if consenter.Id == 0 {
+			return errors.Errorf("consenter %s:%d has no ID", consenter.Host, consenter.Port)
+		}
+		if _, exists := ids[consenter.Id]; exists {
+			return errors.Errorf("duplicate consenter ID %d", consenter.Id)
+		}
+		ids[consenter.Id] = struct{}{}
+
+		if len(consenter.Identity) == 0 {
+			return errors.Errorf("consenter %d has no identity", consenter.Id)
+		}
+		if _, exists := identities[string(consenter.Identity)]; exists {
+			return errors.Errorf("consenter %d has the identity of another consenter", consenter.Id)
+		}
+		identities[string(consenter.Identity)] = struct{}{}
+
+		if err := validateCert(consenter.ServerTlsCert, "server"); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("consenter %d", consenter.Id))
+		}
+		if err := validateCert(consenter.ClientTlsCert, "client"); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("consenter %d", consenter.Id))
+		}
+	}
+
+	if _, err := parseTimeout(metadata.Options.GetRequestTimeout(), DefaultRequestTimeout); err != nil {
+		return errors.WithMessage(err, "invalid RequestTimeout")
+	}
+	if _, err := parseTimeout(metadata.Options.GetViewChangeTimeout(), DefaultViewChangeTimeout); err != nil {
+		return errors.WithMessage(err, "invalid ViewChangeTimeout")
+	}
+
+	return nil
+}
+
+func validateCert(pemData []byte, certRole string) error {
+	bl, _ := pem.Decode(pemData)
+
+	if bl == nil {
+		return errors.Errorf("%s TLS certificate is not PEM encoded: %s", certRole, string(pemData))
+	}
+
+	if _, err := x509.ParseCertificate(bl.Bytes); err != nil {
+		return errors.Errorf("%s TLS certificate has invalid ASN1 structure, %v: %s", certRole, err, string(pemData))
+	}
+	return nil
+}
+
+func parseTimeout(timeout string, defaultTimeout time.Duration) (time.Duration, error) {
+	if timeout == "" {
+		return defaultTimeout, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse %s to time duration: %s", timeout, err)
+	}
+	if d <= 0 {
+		return 0, errors.Errorf("%s is not a positive time duration", timeout)
+	}
+	return d, nil
+}
+
+// MetadataFromConfigUpdate extracts the BFT config metadata from a config update,
+// or returns nil if the update does not change the consensus type value.
+func MetadataFromConfigUpdate(update *common.ConfigUpdate) (*bft.ConfigMetadata, error) {
+	var baseVersion uint64
+	if update.ReadSet != nil && update.ReadSet.Groups != nil {
+		if ordererConfigGroup, ok := update.ReadSet.Groups["Orderer"]; ok {
+			if val, ok := ordererConfigGroup.Values["ConsensusType"]; ok {
+				baseVersion = val.Version
+			}
+		}
+	}
+
+	if update.WriteSet == nil || update.WriteSet.Groups == nil {
+		return nil, nil
+	}
+	ordererConfigGroup, ok := update.WriteSet.Groups["Orderer"]
+	if !ok {
+		return nil, nil
+	}
+	val, ok := ordererConfigGroup.Values["ConsensusType"]
+	if !ok || val.Version == baseVersion {
+		return nil, nil
+	}
+
+	consensusTypeValue := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(val.Value, consensusTypeValue); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensusType config update")
+	}
+	if consensusTypeValue.Type != bft.TypeKey {
+		return nil, errors.Errorf("changing the consensus type from %s to %s is not supported", bft.TypeKey, consensusTypeValue.Type)
+	}
+
+	updatedMetadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusTypeValue.Metadata, updatedMetadata); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal updated (new) BFT metadata configuration")
+	}
+	return updatedMetadata, nil
+}
+
+// ReadBlockMetadata reads the BFT metadata from the ORDERER metadata of a block,
+// it returns empty metadata for blocks that were not ordered by BFT.
+func ReadBlockMetadata(metadata *common.Metadata) (*bft.BlockMetadata, error) {
+	m := &bft.BlockMetadata{}
+	if metadata == nil || len(metadata.Value) == 0 {
+		return m, nil
+	}
+	if err := proto.Unmarshal(metadata.Value, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block's metadata")
+	}
+	return m, nil
+}
+
+// sortedConsenters returns the consenters sorted by their IDs, which is the order in
+// which they take turns to lead the views.
+func sortedConsenters(consenters []*bft.Consenter) []*bft.Consenter {
+	sorted := make([]*bft.Consenter, len(consenters))
+	copy(sorted, consenters)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Id < sorted[j].Id
+	})
+	return sorted
+}
+
+func quorumPolicy(consenters []*bft.Consenter, deserializer msp.IdentityDeserializer) *quorum.Policy {
+	var identities [][]byte
+	for _, consenter := range consenters {
+		identities = append(identities, consenter.Identity)
+	}
+	return quorum.NewPolicy(identities, deserializer)
+}
+
+// signedData returns the data the given signatures of a block are verified against.
+func signedData(block *common.Block, value []byte, signatures []*common.MetadataSignature) ([]*common.SignedData, error) {
+	var signatureSet []*common.SignedData
+	for _, signature := range signatures {
+		shdr, err := utils.GetSignatureHeader(signature.SignatureHeader)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed unmarshalling signature header")
+		}
+		signatureSet = append(signatureSet, &common.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(value, signature.SignatureHeader, block.Header.Bytes()),
+			Signature: signature.Signature,
+		})
+	}
+	return signatureSet, nil
+}
+
+// verifyBlockQuorum verifies that the given block is signed by a quorum of the given consenters.
+func verifyBlockQuorum(block *common.Block, consenters []*bft.Consenter, deserializer msp.IdentityDeserializer) error {
+	if block.Header == nil {
+		return errors.New("block has no header")
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshalling block signatures")
+	}
+	signatureSet, err := signedData(block, metadata.Value, metadata.Signatures)
+	if err != nil {
+		return err
+	}
+	return quorumPolicy(consenters, deserializer).Evaluate(signatureSet)
+}
+
+// viewOfSignedValue returns the view in which the given value of the SIGNATURES metadata was signed.
+func viewOfSignedValue(value []byte) (uint64, error) {
+	ordererMetadata := &common.OrdererBlockMetadata{}
+	if err := proto.Unmarshal(value, ordererMetadata); err != nil {
+		return 0, errors.Wrap(err, "failed unmarshalling orderer block metadata")
+	}
+	consenterMetadata := &common.Metadata{}
+	if err := proto.Unmarshal(ordererMetadata.ConsenterMetadata, consenterMetadata); err != nil {
+		return 0, errors.Wrap(err, "failed unmarshalling consenter metadata")
+	}
+	blockMetadata, err := ReadBlockMetadata(consenterMetadata)
+	if err != nil {
+		return 0, err
+	}
+	return blockMetadata.View, nil
+}
+
+// verifyCertificate verifies that the given certificate proves a quorum of the
+// given consenters signed its block in its view.
+func verifyCertificate(cert *bft.PreparedCertificate, consenters []*bft.Consenter, deserializer msp.IdentityDeserializer) error {
+	if cert.Block == nil || cert.Block.Header == nil || cert.Block.Data == nil {
+		return errors.New("certificate has no block")
+	}
+	if !bytes.Equal(cert.Block.Data.Hash(), cert.Block.Header.DataHash) {
+		return errors.New("certificate block data does not match its header")
+	}
+	view, err := viewOfSignedValue(cert.Value)
+	if err != nil {
+		return err
+	}
+	if view != cert.View {
+		return errors.Errorf("certificate of view %d has signatures of view %d", cert.View, view)
+	}
+	signatureSet, err := signedData(cert.Block, cert.Value, cert.Signatures)
+	if err != nil {
+		return err
+	}
+	return quorumPolicy(consenters, deserializer).Evaluate(signatureSet)
+}
+
+func pemToDER(pemBytes []byte, id uint64, certType string, logger *flogging.FabricLogger) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		logger.Errorf("Rejecting PEM block of %s TLS cert for node %d, offending PEM is: %s", certType, id, string(pemBytes))
+		return nil, errors.Errorf("invalid PEM block")
+	}
+	return bl.Bytes, nil
+}
+
+func remoteNodes(consenters []*bft.Consenter, selfID uint64, logger *flogging.FabricLogger) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, consenter := range consenters {
+		// No need to know yourself
+		if consenter.Id == selfID {
+			continue
+		}
+		serverCertAsDER, err := pemToDER(consenter.ServerTlsCert, consenter.Id, "server", logger)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		clientCertAsDER, err := pemToDER(consenter.ClientTlsCert, consenter.Id, "client", logger)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            consenter.Id,
+			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			ServerTLSCert: serverCertAsDER,
+			ClientTLSCert: clientCertAsDER,
+		})
+	}
+	return nodes, nil
+}
+
+// newBlockPuller creates a puller of the blocks of the channel of the given support,
+// which only accepts blocks that are signed by a quorum of the consenters.
+func newBlockPuller(support consensus.ConsenterSupport,
+	baseDialer *cluster.PredicateDialer,
+	clusterConfig localconfig.Cluster,
+	deserializer func() msp.IdentityDeserializer) (BlockPuller, error) {
+
+	verifyBlockSequence := func(blocks []*common.Block, _ string) error {
+		if err := cluster.VerifyBlocks(blocks, support); err != nil {
+			return err
+		}
+		metadata := &bft.ConfigMetadata{}
+		if err := proto.Unmarshal(support.SharedConfig().ConsensusMetadata(), metadata); err != nil {
+			return errors.Wrap(err, "failed to unmarshal consensus metadata")
+		}
+		for _, block := range blocks {
+			if err := verifyBlockQuorum(block, metadata.Consenters, deserializer()); err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("block [%d]", block.Header.Number))
+			}
+		}
+		return nil
+	}
+
+	stdDialer := &cluster.StandardDialer{
+		ClientConfig: baseDialer.ClientConfig.Clone(),
+	}
+	stdDialer.ClientConfig.AsyncConnect = false
+	stdDialer.ClientConfig.SecOpts.VerifyCertificate = nil
+
+	// Extract the TLS CA certs and endpoints from the configuration,
+	endpoints, err := etcdraft.EndpointconfigFromFromSupport(support)
+	if err != nil {
+		return nil, err
+	}
+
+	der, _ := pem.Decode(stdDialer.ClientConfig.SecOpts.Certificate)
+	if der == nil {
+		return nil, errors.Errorf("client certificate isn't in PEM format: %v",
+			string(stdDialer.ClientConfig.SecOpts.Certificate))
+	}
+
+	bp := &cluster.BlockPuller{
+		VerifyBlockSequence: verifyBlockSequence,
+		Logger:              flogging.MustGetLogger("orderer.common.cluster.puller"),
+		RetryTimeout:        clusterConfig.ReplicationRetryTimeout,
+		MaxTotalBufferBytes: clusterConfig.ReplicationBufferSize,
+		FetchTimeout:        clusterConfig.ReplicationPullTimeout,
+		Endpoints:           endpoints,
+		Signer:              support,
+		TLSCert:             der.Bytes,
+		Channel:             support.ChainID(),
+		Dialer:              stdDialer,
+	}
+
+	return &etcdraft.LedgerBlockPuller{
+		Height:         support.Height,
+		BlockRetriever: support,
+		BlockPuller:    bp,
+	}, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/common/crypto/tlsgen"
+	"github.com/tradeline-tech/fabric/orderer/consensus/bft"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer"
+	bftprotos "github.com/tradeline-tech/fabric/protos/orderer/bft"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+var _ = Describe("Util", func() {
+	var tlsCA tlsgen.CA
+
+	BeforeEach(func() {
+		var err error
+		tlsCA, err = tlsgen.NewCA()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CheckConfigMetadata", func() {
+		var metadata *bftprotos.ConfigMetadata
+
+		BeforeEach(func() {
+			metadata = createMetadata(4, tlsCA)
+		})
+
+		It("accepts valid metadata", func() {
+			Expect(bft.CheckConfigMetadata(metadata)).To(Succeed())
+		})
+
+		It("accepts metadata without options", func() {
+			metadata.Options = nil
+			Expect(bft.CheckConfigMetadata(metadata)).To(Succeed())
+		})
+
+		It("rejects nil metadata", func() {
+			Expect(bft.CheckConfigMetadata(nil)).To(MatchError("nil BFT config metadata"))
+		})
+
+		It("rejects an empty consenter set", func() {
+			metadata.Consenters = nil
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("empty consenter set"))
+		})
+
+		It("rejects a consenter without an ID", func() {
+			metadata.Consenters[1].Id = 0
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("consenter localhost:7052 has no ID"))
+		})
+
+		It("rejects duplicate consenter IDs", func() {
+			metadata.Consenters[1].Id = 1
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("duplicate consenter ID 1"))
+		})
+
+		It("rejects a consenter without an identity", func() {
+			metadata.Consenters[2].Identity = nil
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("consenter 3 has no identity"))
+		})
+
+		It("rejects consenters that share an identity", func() {
+			metadata.Consenters[2].Identity = metadata.Consenters[0].Identity
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("consenter 3 has the identity of another consenter"))
+		})
+
+		It("rejects a malformed TLS certificate", func() {
+			metadata.Consenters[3].ServerTlsCert = []byte("cert")
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("consenter 4: server TLS certificate is not PEM encoded: cert"))
+		})
+
+		It("rejects an invalid timeout", func() {
+			metadata.Options.ViewChangeTimeout = "-1s"
+			Expect(bft.CheckConfigMetadata(metadata)).To(MatchError("invalid ViewChangeTimeout: -1s is not a positive time duration"))
+		})
+	})
+
+	Describe("MetadataFromConfigUpdate", func() {
+		configUpdate := func(consensusType string, metadata []byte) *common.ConfigUpdate {
+			return &common.ConfigUpdate{
+				ReadSet: &common.ConfigGroup{
+					Groups: map[string]*common.ConfigGroup{
+						"Orderer": {Values: map[string]*common.ConfigValue{"ConsensusType": {Version: 0}}},
+					},
+				},
+				WriteSet: &common.ConfigGroup{
+					Groups: map[string]*common.ConfigGroup{
+						"Orderer": {Values: map[string]*common.ConfigValue{
+							"ConsensusType": {
+								Version: 1,
+								Value: utils.MarshalOrPanic(&orderer.ConsensusType{
+									Type:     consensusType,
+									Metadata: metadata,
+								}),
+							},
+						}},
+					},
+				},
+			}
+		}
+
+		It("returns the updated metadata", func() {
+			metadata := createMetadata(3, tlsCA)
+			updated, err := bft.MetadataFromConfigUpdate(configUpdate("bft", utils.MarshalOrPanic(metadata)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Consenters).To(HaveLen(3))
+			Expect(proto.Equal(updated, metadata)).To(BeTrue())
+		})
+
+		It("returns nil when the consensus type is not updated", func() {
+			update := configUpdate("bft", nil)
+			update.WriteSet.Groups["Orderer"].Values["ConsensusType"].Version = 0
+			updated, err := bft.MetadataFromConfigUpdate(update)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeNil())
+
+			updated, err = bft.MetadataFromConfigUpdate(&common.ConfigUpdate{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(BeNil())
+		})
+
+		It("rejects a change of the consensus type", func() {
+			_, err := bft.MetadataFromConfigUpdate(configUpdate("etcdraft", nil))
+			Expect(err).To(MatchError("changing the consensus type from bft to etcdraft is not supported"))
+		})
+	})
+
+	Describe("ReadBlockMetadata", func() {
+		It("returns the view of the block", func() {
+			m, err := bft.ReadBlockMetadata(&common.Metadata{
+				Value: utils.MarshalOrPanic(&bftprotos.BlockMetadata{View: 7}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.View).To(Equal(uint64(7)))
+		})
+
+		It("returns view zero for blocks not ordered by BFT", func() {
+			m, err := bft.ReadBlockMetadata(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.View).To(BeZero())
+		})
+
+		It("fails on malformed metadata", func() {
+			_, err := bft.ReadBlockMetadata(&common.Metadata{Value: []byte{1, 2, 3}})
+			Expect(err).To(MatchError(ContainSubstring("failed to unmarshal block's metadata")))
+		})
+	})
+})
@@ -114,3 +114,12 @@ type ConsenterSupport interface {
 	// unlike WriteBlock that also mutates its metadata.
 	Append(block *cb.Block) error
 }
+
+// ChannelStorageRemover is implemented by the consenters that keep state of a channel
+// outside of its ledger, such as a write-ahead log, so that the state is removed
+// along with the ledger when the orderer stops serving the channel.
+type ChannelStorageRemover interface {
+	// RemoveChannelStorage removes the state of the channel. The chain of the
+	// channel is expected to have been halted.
+	RemoveChannelStorage(channelID string) error
+}
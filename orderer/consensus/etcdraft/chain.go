@@ -162,10 +162,12 @@ type Chain struct {
 	errorC     chan struct{} // returned by Errored()
 
 	raftMetadataLock     sync.RWMutex
+	departingConsenters  map[uint64]*etcdraft.Consenter // removed consenters that are still in the Raft replica set
 	confChangeInProgress *raftpb.ConfChange
-	justElected          bool // this is true when node has just been elected
-	configInflight       bool // this is true when there is config block or ConfChange in flight
-	blockInflight        int  // number of in flight blocks
+	lastAddedNode        uint64 // node added by the last ConfChange, awaited before proposing the next one
+	justElected          bool   // this is true when node has just been elected
+	configInflight       bool   // this is true when there is config block or ConfChange in flight
+	blockInflight        int    // number of in flight blocks
 
 	clock clock.Clock // Tests can inject a fake clock
 
@@ -240,34 +242,36 @@ func NewChain(
 	}
 
 	c := &Chain{
-		configurator:     conf,
-		rpc:              rpc,
-		channelID:        support.ChainID(),
-		raftID:           opts.RaftID,
-		submitC:          make(chan *submit),
-		applyC:           make(chan apply),
-		haltC:            make(chan struct{}),
-		doneC:            make(chan struct{}),
-		startC:           make(chan struct{}),
-		snapC:            make(chan *raftpb.Snapshot),
-		errorC:           make(chan struct{}),
-		gcC:              make(chan *gc),
-		observeC:         observeC,
-		support:          support,
-		fresh:            fresh,
-		appliedIndex:     opts.BlockMetadata.RaftIndex,
-		lastBlock:        b,
-		sizeLimit:        sizeLimit,
-		lastSnapBlockNum: snapBlkNum,
-		confState:        cc,
-		createPuller:     f,
-		clock:            opts.Clock,
-		haltCallback:     haltCallback,
+		configurator:        conf,
+		rpc:                 rpc,
+		channelID:           support.ChainID(),
+		raftID:              opts.RaftID,
+		submitC:             make(chan *submit),
+		applyC:              make(chan apply),
+		haltC:               make(chan struct{}),
+		doneC:               make(chan struct{}),
+		startC:              make(chan struct{}),
+		snapC:               make(chan *raftpb.Snapshot),
+		errorC:              make(chan struct{}),
+		gcC:                 make(chan *gc),
+		observeC:            observeC,
+		support:             support,
+		fresh:               fresh,
+		appliedIndex:        opts.BlockMetadata.RaftIndex,
+		lastBlock:           b,
+		sizeLimit:           sizeLimit,
+		lastSnapBlockNum:    snapBlkNum,
+		confState:           cc,
+		createPuller:        f,
+		clock:               opts.Clock,
+		haltCallback:        haltCallback,
+		departingConsenters: map[uint64]*etcdraft.Consenter{},
 		Metrics: &Metrics{
 			ClusterSize:             opts.Metrics.ClusterSize.With("channel", support.ChainID()),
 			IsLeader:                opts.Metrics.IsLeader.With("channel", support.ChainID()),
 			CommittedBlockNumber:    opts.Metrics.CommittedBlockNumber.With("channel", support.ChainID()),
 			SnapshotBlockNumber:     opts.Metrics.SnapshotBlockNumber.With("channel", support.ChainID()),
+			PendingConfChanges:      opts.Metrics.PendingConfChanges.With("channel", support.ChainID()),
 			LeaderChanges:           opts.Metrics.LeaderChanges.With("channel", support.ChainID()),
 			ProposalFailures:        opts.Metrics.ProposalFailures.With("channel", support.ChainID()),
 			DataPersistDuration:     opts.Metrics.DataPersistDuration.With("channel", support.ChainID()),
@@ -321,6 +325,10 @@ func NewChain(
 func (c *Chain) Start() {
 	c.logger.Infof("Starting Raft node")
 
+	// The Raft replica set restored from the snapshot might still contain
+	// consenters that were removed by the last config blocks.
+	c.updateDepartingConsenters()
+
 	if err := c.configureComm(); err != nil {
 		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
 		close(c.doneC)
@@ -435,8 +443,11 @@ func (c *Chain) checkConfigUpdateValidity(ctx *common.Envelope) error {
 		return nil
 
 	case int32(common.HeaderType_CONFIG):
+		// Any number of consenters may be changed at once, the resulting Raft configuration
+		// changes are applied one at a time, as long as the kept consenters form a quorum.
 		c.raftMetadataLock.RLock()
-		_, err = ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, metadata.Consenters)
+		changes := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, metadata.Consenters)
+		err = changes.CheckQuorumOverlap(c.opts.BlockMetadata.ConsenterIds)
 		c.raftMetadataLock.RUnlock()
 
 		return err
@@ -616,14 +627,7 @@ func (c *Chain) serveRequest() {
 		// if there is unfinished ConfChange, we should resume the effort to propose it as
 		// new leader, and wait for it to be committed before start serving new requests.
 		if cc := c.getInFlightConfChange(); cc != nil {
-			// The reason `ProposeConfChange` should be called in go routine is documented in `writeConfigBlock` method.
-			go func() {
-				if err := c.Node.ProposeConfChange(context.TODO(), *cc); err != nil {
-					c.logger.Warnf("Failed to propose configuration update to Raft node: %s", err)
-				}
-			}()
-
-			c.confChangeInProgress = cc
+			c.proposeConfChange(cc)
 			c.configInflight = true
 		}
 
@@ -813,6 +817,12 @@ func (c *Chain) serveRequest() {
 					sn.Metadata.Term, sn.Metadata.Index, err)
 			}
 
+			if c.updateDepartingConsenters() {
+				if err := c.configureComm(); err != nil {
+					c.logger.Panicf("Failed to configure communication: %s", err)
+				}
+			}
+
 		case <-c.doneC:
 			cancelProp()
 
@@ -996,13 +1006,14 @@ func (c *Chain) detectConfChange(block *common.Block) *MembershipChanges {
 		c.sizeLimit = configMetadata.Options.SnapshotIntervalSize
 	}
 
-	changes, err := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, configMetadata.Consenters)
-	if err != nil {
-		c.logger.Panicf("illegal configuration change detected: %s", err)
-	}
+	changes := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, configMetadata.Consenters)
 
 	if changes.Rotated() {
-		c.logger.Infof("Config block [%d] rotates TLS certificate of node %d", block.Header.Number, changes.RotatedNode)
+		c.logger.Infof("Config block [%d] rotates TLS certificate of node(s) %v", block.Header.Number, changes.RotatedNodes)
+	}
+
+	if changes.Relocated() {
+		c.logger.Infof("Config block [%d] changes the endpoint of node(s) %v", block.Header.Number, changes.RelocatedNodes)
 	}
 
 	return changes
@@ -1047,6 +1058,7 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 			}
 
 			c.confState = *c.Node.ApplyConfChange(cc)
+			departingChanged := c.updateDepartingConsenters()
 
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
@@ -1058,7 +1070,8 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 			}
 
 			// This ConfChange was introduced by a previously committed config block,
-			// we can now unblock submitC to accept envelopes.
+			// we can now propose the next one, or unblock submitC to accept envelopes
+			// if all of them are applied.
 			if c.confChangeInProgress != nil &&
 				c.confChangeInProgress.NodeID == cc.NodeID &&
 				c.confChangeInProgress.Type == cc.Type {
@@ -1067,10 +1080,29 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 					c.logger.Panicf("Failed to configure communication: %s", err)
 				}
 
-				c.confChangeInProgress = nil
-				c.configInflight = false
-				// report the new cluster size
-				c.Metrics.ClusterSize.Set(float64(len(c.opts.BlockMetadata.ConsenterIds)))
+				// report the new cluster size and the progress of the membership update
+				c.Metrics.ClusterSize.Set(float64(len(c.confState.Nodes)))
+				pending := PendingConfChanges(c.opts.BlockMetadata, &c.confState)
+				c.Metrics.PendingConfChanges.Set(float64(pending))
+
+				if cc.Type == raftpb.ConfChangeAddNode {
+					c.lastAddedNode = cc.NodeID
+				}
+
+				if next := ConfChange(c.opts.BlockMetadata, &c.confState); next != nil {
+					c.logger.Infof("%d config change(s) left to apply, proposing the next one", pending)
+					c.proposeConfChange(next)
+				} else {
+					c.confChangeInProgress = nil
+					c.lastAddedNode = raft.None
+					c.configInflight = false
+				}
+			} else if departingChanged {
+				// ConfChanges replayed after a restart, or proposed before this node
+				// joined, change the consenters that communication is kept with.
+				if err := c.configureComm(); err != nil {
+					c.logger.Panicf("Failed to configure communication: %s", err)
+				}
 			}
 
 			if cc.Type == raftpb.ConfChangeRemoveNode && cc.NodeID == c.raftID {
@@ -1100,7 +1132,9 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 		}
 	}
 
-	if c.accDataSize >= c.sizeLimit {
+	// Snapshot data is the last applied block, hence entries that only
+	// carry Raft configuration changes defer the snapshot to the next block.
+	if c.accDataSize >= c.sizeLimit && ents[position].Type == raftpb.EntryNormal && len(ents[position].Data) != 0 {
 		b := utils.UnmarshalBlockOrPanic(ents[position].Data)
 
 		select {
@@ -1159,8 +1193,16 @@ func (c *Chain) remotePeers() ([]cluster.RemoteNode, error) {
 	c.raftMetadataLock.RLock()
 	defer c.raftMetadataLock.RUnlock()
 
-	var nodes []cluster.RemoteNode
+	consenters := make(map[uint64]*etcdraft.Consenter, len(c.opts.Consenters)+len(c.departingConsenters))
+	for raftID, consenter := range c.departingConsenters {
+		consenters[raftID] = consenter
+	}
 	for raftID, consenter := range c.opts.Consenters {
+		consenters[raftID] = consenter
+	}
+
+	var nodes []cluster.RemoteNode
+	for raftID, consenter := range consenters {
 		// No need to know yourself
 		if raftID == c.raftID {
 			continue
@@ -1183,6 +1225,66 @@ func (c *Chain) remotePeers() ([]cluster.RemoteNode, error) {
 	return nodes, nil
 }
 
+// updateDepartingConsenters recomputes the consenters that are no longer in the channel configuration
+// but are still in the Raft replica set, since communication with them is kept until they are removed.
+// Consenters that are not tracked yet, e.g. after a restart, are looked up in the preceding config blocks.
+// It returns whether the departing consenters changed.
+func (c *Chain) updateDepartingConsenters() bool {
+	c.raftMetadataLock.Lock()
+	defer c.raftMetadataLock.Unlock()
+
+	var changed bool
+	for nodeID := range c.departingConsenters {
+		if !NodeExists(nodeID, c.confState.Nodes) {
+			delete(c.departingConsenters, nodeID)
+			changed = true
+		}
+	}
+
+	missing := map[uint64]struct{}{}
+	for _, nodeID := range c.confState.Nodes {
+		_, isConsenter := c.opts.Consenters[nodeID]
+		_, isDeparting := c.departingConsenters[nodeID]
+		if !isConsenter && !isDeparting {
+			missing[nodeID] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return changed
+	}
+
+	configBlock, err := cluster.LastConfigBlock(c.lastBlock, c.support)
+	for err == nil && len(missing) > 0 && configBlock.Header.Number > 0 {
+		configBlock, err = cluster.LastConfigBlock(c.support.Block(configBlock.Header.Number-1), c.support)
+		if err != nil {
+			break
+		}
+
+		var consenters map[uint64]*etcdraft.Consenter
+		if consenters, err = ConsentersFromConfigBlock(configBlock); err != nil {
+			break
+		}
+		for nodeID := range missing {
+			if consenter, exists := consenters[nodeID]; exists {
+				c.departingConsenters[nodeID] = consenter
+				delete(missing, nodeID)
+				changed = true
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		var nodeIDs []uint64
+		for nodeID := range missing {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		c.logger.Warnf("Failed to find nodes %v of the Raft replica set in the config blocks, "+
+			"communication with them is not configured: %v", nodeIDs, err)
+	}
+
+	return changed
+}
+
 func pemToDER(pemBytes []byte, id uint64, certType string, logger *flogging.FabricLogger) ([]byte, error) {
 	bl, _ := pem.Decode(pemBytes)
 	if bl == nil {
@@ -1210,6 +1312,13 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 		c.raftMetadataLock.Lock()
 		c.opts.BlockMetadata.RaftIndex = index
 		if configMembership != nil {
+			// Removed consenters take part in consensus until they are removed
+			// from the replica set, so communication with them is kept till then.
+			for nodeID, consenter := range c.opts.Consenters {
+				if _, exists := configMembership.NewConsenters[nodeID]; !exists {
+					c.departingConsenters[nodeID] = consenter
+				}
+			}
 			c.opts.BlockMetadata = configMembership.NewBlockMetadata
 			c.opts.Consenters = configMembership.NewConsenters
 		}
@@ -1225,24 +1334,16 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 
 		// update membership
 		if configMembership.ConfChange != nil {
-			// We need to propose conf change in a go routine, because it may be blocked if raft node
-			// becomes leaderless, and we should not block `serveRequest` so it can keep consuming applyC,
-			// otherwise we have a deadlock.
-			go func() {
-				// ProposeConfChange returns error only if node being stopped.
-				// This proposal is dropped by followers because DisableProposalForwarding is enabled.
-				if err := c.Node.ProposeConfChange(context.TODO(), *configMembership.ConfChange); err != nil {
-					c.logger.Warnf("Failed to propose configuration update to Raft node: %s", err)
-				}
-			}()
+			c.proposeConfChange(configMembership.ConfChange)
 
-			c.confChangeInProgress = configMembership.ConfChange
+			pending := PendingConfChanges(c.opts.BlockMetadata, &c.confState)
+			c.Metrics.PendingConfChanges.Set(float64(pending))
 
 			switch configMembership.ConfChange.Type {
 			case raftpb.ConfChangeAddNode:
-				c.logger.Infof("Config block just committed adds node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+				c.logger.Infof("Config block just committed adds node %d (%d config change(s) in total), pause accepting transactions till config changes are applied", configMembership.ConfChange.NodeID, pending)
 			case raftpb.ConfChangeRemoveNode:
-				c.logger.Infof("Config block just committed removes node %d, pause accepting transactions till config change is applied", configMembership.ConfChange.NodeID)
+				c.logger.Infof("Config block just committed removes node %d (%d config change(s) in total), pause accepting transactions till config changes are applied", configMembership.ConfChange.NodeID, pending)
 			default:
 				c.logger.Panic("Programming error, encountered unsupported raft config change")
 			}
@@ -1250,7 +1351,7 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 			c.configInflight = true
 		} else if configMembership.Rotated() {
 			lead := atomic.LoadUint64(&c.lastKnownLeader)
-			if configMembership.RotatedNode == lead {
+			if NodeExists(lead, configMembership.RotatedNodes) {
 				c.logger.Infof("Certificate of Raft leader is being rotated, attempt leader transfer before reconfiguring communication")
 				go func() {
 					c.Node.abdicateLeader(lead)
@@ -1263,6 +1364,10 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 					c.logger.Panicf("Failed to configure communication: %s", err)
 				}
 			}
+		} else if configMembership.Relocated() {
+			if err := c.configureComm(); err != nil {
+				c.logger.Panicf("Failed to configure communication: %s", err)
+			}
 		}
 
 	case common.HeaderType_ORDERER_TRANSACTION:
@@ -1279,6 +1384,61 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 	}
 }
 
+// proposeConfChange proposes the given ConfChange and tracks it as the one in progress. The proposal
+// waits for the node added by the previous ConfChange of the membership update, if any, to catch up
+// with the leader, so that replacing consenters does not lose the quorum of the channel.
+func (c *Chain) proposeConfChange(cc *raftpb.ConfChange) {
+	lastAddedNode := c.lastAddedNode
+
+	// We need to propose conf change in a go routine, because it may be blocked if raft node
+	// becomes leaderless, and we should not block `serveRequest` so it can keep consuming applyC,
+	// otherwise we have a deadlock.
+	go func() {
+		if !c.awaitCaughtUp(lastAddedNode, cc) {
+			return
+		}
+
+		// ProposeConfChange returns error only if node being stopped.
+		// This proposal is dropped by followers because DisableProposalForwarding is enabled.
+		if err := c.Node.ProposeConfChange(context.TODO(), *cc); err != nil {
+			c.logger.Warnf("Failed to propose configuration update to Raft node: %s", err)
+		}
+	}()
+
+	c.confChangeInProgress = cc
+}
+
+// awaitCaughtUp waits until the given node, if any, has caught up with the leader before the given ConfChange
+// is proposed. It returns false if the chain halts or this node loses leadership in the meantime, in which
+// case the next leader resumes the membership update.
+func (c *Chain) awaitCaughtUp(nodeID uint64, cc *raftpb.ConfChange) bool {
+	if nodeID == raft.None {
+		return true
+	}
+
+	logged := false
+	for {
+		status := c.Node.Status()
+		if status.RaftState != raft.StateLeader {
+			c.logger.Infof("Not proposing config change %s of node %d, as this node is no longer the leader", cc.Type, cc.NodeID)
+			return false
+		}
+		if CaughtUp(status, nodeID) {
+			return true
+		}
+		if !logged {
+			c.logger.Infof("Waiting for node %d to catch up before proposing config change %s of node %d", nodeID, cc.Type, cc.NodeID)
+			logged = true
+		}
+
+		select {
+		case <-time.After(c.opts.TickInterval):
+		case <-c.doneC:
+			return false
+		}
+	}
+}
+
 // getInFlightConfChange returns ConfChange in-flight if any.
 // It returns confChangeInProgress if it is not nil. Otherwise
 // it returns ConfChange from the last committed block (might be nil).
@@ -1298,15 +1458,9 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.Node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(c.opts.BlockMetadata.ConsenterIds) {
-		// Raft configuration change could only add one node or
-		// remove one node at a time, if raft conf state size is
-		// equal to membership stored in block metadata field,
-		// that means everything is in sync and no need to propose
-		// config update.
-		return nil
-	}
-
+	// Raft configuration changes add or remove one node at a time, if the
+	// replica set matches the membership stored in block metadata field,
+	// everything is in sync and no need to propose config update.
 	return ConfChange(c.opts.BlockMetadata, confState)
 }
 
@@ -223,6 +223,7 @@ var _ = Describe("Chain", func() {
 					fakeFields.fakeIsLeader,
 					fakeFields.fakeCommittedBlockNumber,
 					fakeFields.fakeSnapshotBlockNumber,
+					fakeFields.fakePendingConfChanges,
 					fakeFields.fakeLeaderChanges,
 					fakeFields.fakeProposalFailures,
 					fakeFields.fakeDataPersistDuration,
@@ -664,9 +665,10 @@ var _ = Describe("Chain", func() {
 
 						}) // BeforeEach block
 
-						It("should fail, since consenters set change is not supported", func() {
+						It("should fail, since the kept consenters are fewer than a quorum of the new consenters", func() {
 							err := chain.Configure(configEnv, configSeq)
-							Expect(err).To(MatchError(ContainSubstring("update of more than one consenter at a time is not supported, requested changes: add 3 node(s), remove 1 node(s)")))
+							Expect(err).To(MatchError(ContainSubstring("config update keeps 1 of the current consenters, fewer than a quorum of " +
+								"the current (1) or the new (2) consenter set, requested changes: add 3 node(s), remove 1 node(s)")))
 							Expect(fakeFields.fakeConfigProposalsReceived.AddCallCount()).To(Equal(1))
 							Expect(fakeFields.fakeConfigProposalsReceived.AddArgsForCall(0)).To(Equal(float64(1)))
 							Expect(fakeFields.fakeProposalFailures.AddCallCount()).To(Equal(1))
@@ -1797,32 +1799,91 @@ var _ = Describe("Chain", func() {
 			})
 
 			Context("reconfiguration", func() {
-				It("cannot change consenter set by more than 1 node", func() {
-					metadata := &raftprotos.ConfigMetadata{Options: options}
-					for id, consenter := range consenters {
-						if id == 2 || id == 3 {
-							// remove second & third consenter
-							continue
-						}
-						metadata.Consenters = append(metadata.Consenters, consenter)
-					}
-
-					value := map[string]*common.ConfigValue{
-						"ConsensusType": {
-							Version: 1,
-							Value: marshalOrPanic(&orderer.ConsensusType{
-								Metadata: marshalOrPanic(metadata),
-							}),
-						},
-					}
+				It("rejects removing several nodes without keeping a quorum of the current consenters", func() {
+					metadata := &raftprotos.ConfigMetadata{Options: options, Consenters: []*raftprotos.Consenter{consenters[1]}}
 
-					By("creating new configuration with removed node and new one")
-					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, value))
+					By("creating new configuration with second & third consenter removed")
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(metadata)))
 					c1.cutter.CutNext = true
 
 					By("sending config transaction")
 					err := c1.Configure(configEnv, 0)
-					Expect(err).To(MatchError(ContainSubstring("update of more than one consenter at a time is not supported, requested changes: add 0 node(s), remove 2 node(s)")))
+					Expect(err).To(MatchError(ContainSubstring("config update keeps 1 of the current consenters, fewer than a quorum of " +
+						"the current (2) or the new (1) consenter set, requested changes: add 0 node(s), remove 2 node(s)")))
+					Consistently(c1.support.WriteConfigBlockCallCount).Should(Equal(0))
+				})
+
+				It("keeps communicating with a removed node that is still in the replica set after a restart", func() {
+					By("seeding the ledgers with a config block that sets the initial consenters")
+					metadata := &raftprotos.ConfigMetadata{Options: options, Consenters: []*raftprotos.Consenter{consenters[1], consenters[2], consenters[3]}}
+					seedBlock := getSeedBlock()
+					seedBlock.Data.Data = [][]byte{marshalOrPanic(newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(metadata))))}
+					network.exec(func(c *chain) {
+						c.ledgerLock.Lock()
+						c.ledger[0] = seedBlock
+						c.ledgerLock.Unlock()
+					})
+
+					By("holding the leader back from proposing the removal of the third node")
+					release := make(chan struct{})
+					writeConfigBlock := c1.support.WriteConfigBlockStub
+					c1.support.WriteConfigBlockStub = func(b *common.Block, meta []byte) {
+						writeConfigBlock(b, meta)
+						<-release
+					}
+
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, removeConsenterConfigValue(3)))
+					c1.cutter.CutNext = true
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+
+					By("restarting the second node")
+					c2.Halt()
+					Eventually(c2.Errored, LongEventualTimeout).Should(BeClosed())
+
+					_, raftmetabytes := c2.support.WriteConfigBlockArgsForCall(0)
+					raftmeta, err := etcdraft.ReadBlockMetadata(&common.Metadata{Value: raftmetabytes}, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					restarted := newChain(timeout, channelID, path.Dir(c2.opts.WALDir), 2, raftmeta,
+						map[uint64]*raftprotos.Consenter{1: consenters[1], 2: consenters[2]})
+					c2.ledgerLock.RLock()
+					for number, block := range c2.ledger {
+						restarted.ledger[number] = block
+					}
+					restarted.ledgerHeight = c2.ledgerHeight
+					restarted.lastConfigBlockNumber = c2.lastConfigBlockNumber
+					c2.ledgerLock.RUnlock()
+					close(c2.stopped)
+
+					restarted.init()
+					network.addChain(restarted)
+					restarted.start()
+
+					configuredNodes := func() []uint64 {
+						count := restarted.configurator.ConfigureCallCount()
+						if count == 0 {
+							return nil
+						}
+						var nodeIDs []uint64
+						_, nodes := restarted.configurator.ConfigureArgsForCall(count - 1)
+						for _, node := range nodes {
+							nodeIDs = append(nodeIDs, node.ID)
+						}
+						return nodeIDs
+					}
+
+					By("keeping communication with the third node until it leaves the replica set")
+					Eventually(configuredNodes, LongEventualTimeout).Should(ConsistOf(uint64(1), uint64(3)))
+
+					close(release)
+					Eventually(configuredNodes, LongEventualTimeout).Should(ConsistOf(uint64(1)))
+
+					Eventually(c3.Errored, LongEventualTimeout).Should(BeClosed())
+					close(c3.stopped)
 				})
 
 				It("rejects invalid certificates", func() {
@@ -2057,17 +2118,29 @@ var _ = Describe("Chain", func() {
 				})
 
 				When("two type B config are sent back-to-back", func() {
-					It("discards or rejects the second", func() {
+					It("applies the second after the first", func() {
 						// initial state: <1, 2, 3>
 						// first config: <1, 2, 3, 4>
-						// second config: <1, 2>
+						// second config: <1, 2, 4>
 						c1.cutter.CutNext = true
+						newConsenter := &raftprotos.Consenter{
+							Host:          "localhost",
+							Port:          7050,
+							ServerTlsCert: serverTLSCert(tlsCA),
+							ClientTlsCert: clientTLSCert(tlsCA),
+						}
 						configEnvAdd := newConfigEnv(channelID,
 							common.HeaderType_CONFIG,
-							newConfigUpdateEnv(channelID, nil, addConsenterConfigValue()))
+							newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(&raftprotos.ConfigMetadata{
+								Options:    options,
+								Consenters: []*raftprotos.Consenter{consenters[1], consenters[2], consenters[3], newConsenter},
+							})))
 						configEnvRm := newConfigEnv(channelID,
 							common.HeaderType_CONFIG,
-							newConfigUpdateEnv(channelID, nil, removeConsenterConfigValue(3)))
+							newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(&raftprotos.ConfigMetadata{
+								Options:    options,
+								Consenters: []*raftprotos.Consenter{consenters[1], consenters[2], newConsenter},
+							})))
 
 						By("Submitting two config tx back-to-back")
 						c1.support.SequenceReturnsOnCall(1, 0)
@@ -2075,19 +2148,21 @@ var _ = Describe("Chain", func() {
 						c1.support.ProcessConfigMsgReturns(configEnvRm, 1, nil)
 
 						Expect(c1.Configure(configEnvAdd, 0)).To(Succeed())
-						// If the first config tx is processed too quickly, consenter set is already altered and
-						// the second config tx would be rejected with error. Otherwise, the second config tx is
-						// going to be discarded during revalidation, instead of being explicitly rejected by
-						// `Configure`. Either way, there is only one config block being committed, which is the
-						// whole point of this test.
-						Expect(c1.Configure(configEnvRm, 0)).To(Or(
-							Succeed(),
-							MatchError("update of more than one consenter at a time is not supported, requested changes: add 0 node(s), remove 2 node(s)"),
-						))
+						// The second config tx is not ordered till the node added by the first one
+						// is part of the replica set, and then removes the third node.
+						Expect(c1.Configure(configEnvRm, 0)).To(Succeed())
 						network.exec(func(c *chain) {
-							Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
-							Consistently(c.support.WriteConfigBlockCallCount).Should(Equal(1))
-						})
+							Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(2))
+						}, 1, 2)
+
+						network.exec(func(c *chain) {
+							Eventually(func() float64 {
+								count := c.fakeFields.fakeClusterSize.SetCallCount()
+								return c.fakeFields.fakeClusterSize.SetArgsForCall(count - 1)
+							}, LongEventualTimeout).Should(Equal(float64(3)))
+						}, 1, 2)
+						Eventually(c3.Errored, LongEventualTimeout).Should(BeClosed())
+						close(c3.stopped)
 					})
 				})
 
@@ -2170,6 +2245,89 @@ var _ = Describe("Chain", func() {
 					Expect(err.Error()).To(ContainSubstring(string(duplicatedMetadata.Consenters[1].ClientTlsCert)))
 				})
 
+				It("rejects replacing a quorum of consenters at once", func() {
+					metadata := &raftprotos.ConfigMetadata{Options: options, Consenters: []*raftprotos.Consenter{consenters[1]}}
+					for i := 0; i < 2; i++ {
+						metadata.Consenters = append(metadata.Consenters, &raftprotos.Consenter{
+							Host:          "localhost",
+							Port:          uint32(8050 + i),
+							ServerTlsCert: serverTLSCert(tlsCA),
+							ClientTlsCert: clientTLSCert(tlsCA),
+						})
+					}
+
+					By("creating new configuration with second & third consenter replaced")
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(metadata)))
+					c1.cutter.CutNext = true
+
+					By("sending config transaction")
+					err := c1.Configure(configEnv, 0)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("config update keeps 1 of the current consenters, fewer than a quorum"))
+				})
+
+				It("waits for a late node to catch up before adding the next one", func() {
+					metadata := &raftprotos.ConfigMetadata{Options: options}
+					for _, consenter := range consenters {
+						metadata.Consenters = append(metadata.Consenters, consenter)
+					}
+					for i := 0; i < 2; i++ {
+						metadata.Consenters = append(metadata.Consenters, &raftprotos.Consenter{
+							Host:          "localhost",
+							Port:          uint32(8050 + i),
+							ServerTlsCert: serverTLSCert(tlsCA),
+							ClientTlsCert: clientTLSCert(tlsCA),
+						})
+					}
+
+					By("creating new configuration with two consenters added")
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, nil, updateRaftConfigValue(metadata)))
+					c1.cutter.CutNext = true
+
+					By("sending config transaction")
+					Expect(c1.Configure(configEnv, 0)).To(Succeed())
+
+					By("adding the first node only, as it has not joined the cluster yet")
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
+						Eventually(c.fakeFields.fakeClusterSize.SetCallCount, LongEventualTimeout).Should(Equal(2))
+						Expect(c.fakeFields.fakeClusterSize.SetArgsForCall(1)).To(Equal(float64(4)))
+					})
+					Consistently(func() int {
+						c1.clock.Increment(interval)
+						return c1.fakeFields.fakeClusterSize.SetCallCount()
+					}, 500*time.Millisecond).Should(Equal(2))
+
+					By("starting the first node late")
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadBlockMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					c4 := newChain(timeout, channelID, dataDir, 4, raftmeta, consenters)
+					// if we join a node to existing network, it MUST already obtained blocks
+					// till the config block that adds this node to cluster.
+					c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+					c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+					c4.init()
+
+					network.addChain(c4)
+					c4.Start()
+
+					Eventually(func() <-chan raft.SoftState {
+						c1.clock.Increment(interval)
+						return c4.observe
+					}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+
+					By("adding the second node once the first one caught up")
+					Eventually(func() int {
+						c1.clock.Increment(interval)
+						return c1.fakeFields.fakeClusterSize.SetCallCount()
+					}, LongEventualTimeout).Should(Equal(3))
+					Expect(c1.fakeFields.fakeClusterSize.SetArgsForCall(2)).To(Equal(float64(5)))
+					Eventually(c4.fakeFields.fakeClusterSize.SetCallCount, LongEventualTimeout).ShouldNot(BeZero())
+				})
+
 				It("does not reconfigure raft cluster if it's a channel creation tx", func() {
 					configEnv := newConfigEnv("another-channel",
 						common.HeaderType_CONFIG,
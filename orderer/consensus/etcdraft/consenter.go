@@ -8,6 +8,7 @@ package etcdraft
 
 import (
 	"bytes"
+	"os"
 	"path"
 	"reflect"
 	"time"
@@ -86,7 +87,8 @@ func (c *Consenter) TargetChannel(message proto.Message) string {
 }
 
 // ReceiverByChain returns the MessageReceiver for the given channelID or nil
-// if not found.
+// if not found. Chains of other consenters that share the cluster communication
+// with etcdraft chains receive their messages through it as well.
 func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	cs := c.Chains.GetChain(channelID)
 	if cs == nil {
@@ -95,10 +97,10 @@ func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	if cs.Chain == nil {
 		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
 	}
-	if etcdRaftChain, isEtcdRaftChain := cs.Chain.(*Chain); isEtcdRaftChain {
-		return etcdRaftChain
+	if receiver, isMessageReceiver := cs.Chain.(MessageReceiver); isMessageReceiver {
+		return receiver
 	}
-	c.Logger.Warningf("Chain %s is of type %v and not etcdraft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	c.Logger.Warningf("Chain %s is of type %v and does not receive cluster messages", channelID, reflect.TypeOf(cs.Chain))
 	return nil
 }
 
@@ -227,6 +229,20 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 	)
 }
 
+// RemoveChannelStorage removes the WAL and the snapshots of the channel.
+func (c *Consenter) RemoveChannelStorage(channelID string) error {
+	for _, dir := range []string{
+		path.Join(c.EtcdRaftConfig.WALDir, channelID),
+		path.Join(c.EtcdRaftConfig.SnapDir, channelID),
+	} {
+		if err := os.RemoveAll(dir); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", dir)
+		}
+	}
+	c.Logger.Infof("Removed the WAL and the snapshots of channel %s", channelID)
+	return nil
+}
+
 // ReadBlockMetadata attempts to read raft metadata from block metadata, if available.
 // otherwise, it reads raft metadata from config metadata supplied.
 func ReadBlockMetadata(blockMetadata *common.Metadata, configMetadata *etcdraft.ConfigMetadata) (*etcdraft.BlockMetadata, error) {
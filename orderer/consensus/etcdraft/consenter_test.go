@@ -27,6 +27,7 @@ import (
 	"github.com/tradeline-tech/fabric/orderer/common/cluster"
 	clustermocks "github.com/tradeline-tech/fabric/orderer/common/cluster/mocks"
 	"github.com/tradeline-tech/fabric/orderer/common/multichannel"
+	"github.com/tradeline-tech/fabric/orderer/consensus"
 	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft"
 	"github.com/tradeline-tech/fabric/orderer/consensus/etcdraft/mocks"
 	consensusmocks "github.com/tradeline-tech/fabric/orderer/consensus/mocks"
@@ -134,6 +135,17 @@ var _ = Describe("Consenter", func() {
 			chain := consenter.ReceiverByChain("notraftchain")
 			Expect(chain).To(BeNil())
 		})
+		It("calls the chain getter and returns chains of other types that receive cluster messages", func() {
+			receiver := &otherClusterChain{}
+			chainGetter.On("GetChain", "otherclusterchain").Return(&multichannel.ChainSupport{
+				Chain: receiver,
+			})
+			consenter := newConsenter(chainGetter)
+			Expect(consenter).NotTo(BeNil())
+
+			chain := consenter.ReceiverByChain("otherclusterchain")
+			Expect(chain).To(BeIdenticalTo(receiver))
+		})
 		It("calls the chain getter and panics when the chain has a bad internal state", func() {
 			consenter := newConsenter(chainGetter)
 			Expect(consenter).NotTo(BeNil())
@@ -259,6 +271,22 @@ var _ = Describe("Consenter", func() {
 		Expect(chain).To(BeNil())
 		Expect(err).To(MatchError("failed to parse TickInterval (500) to time duration"))
 	})
+
+	It("removes the WAL and the snapshots of a channel", func() {
+		consenter := newConsenter(chainGetter)
+		consenter.EtcdRaftConfig.WALDir = walDir
+		consenter.EtcdRaftConfig.SnapDir = snapDir
+		for _, dir := range []string{walDir, snapDir} {
+			Expect(os.MkdirAll(path.Join(dir, "foo"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(path.Join(dir, "bar"), 0755)).To(Succeed())
+		}
+
+		Expect(consenter.RemoveChannelStorage("foo")).To(Succeed())
+		for _, dir := range []string{walDir, snapDir} {
+			Expect(path.Join(dir, "foo")).NotTo(BeADirectory())
+			Expect(path.Join(dir, "bar")).To(BeADirectory())
+		}
+	})
 })
 
 type consenter struct {
@@ -297,3 +325,10 @@ func newConsenter(chainGetter *mocks.ChainGetter) *consenter {
 		icr:       icr,
 	}
 }
+
+// otherClusterChain is a chain of another consensus type
+// that receives its messages through the cluster communication.
+type otherClusterChain struct {
+	consensus.Chain
+	mocks.MessageReceiver
+}
@@ -41,6 +41,14 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	pendingConfChangesOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "pending_conf_changes",
+		Help:         "The number of Raft membership changes left to apply for the latest consenter set update.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 	leaderChangesOpts = metrics.CounterOpts{
 		Namespace:    "consensus",
 		Subsystem:    "etcdraft",
@@ -88,6 +96,7 @@ type Metrics struct {
 	IsLeader                metrics.Gauge
 	CommittedBlockNumber    metrics.Gauge
 	SnapshotBlockNumber     metrics.Gauge
+	PendingConfChanges      metrics.Gauge
 	LeaderChanges           metrics.Counter
 	ProposalFailures        metrics.Counter
 	DataPersistDuration     metrics.Histogram
@@ -101,6 +110,7 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		IsLeader:                p.NewGauge(isLeaderOpts),
 		CommittedBlockNumber:    p.NewGauge(committedBlockNumberOpts),
 		SnapshotBlockNumber:     p.NewGauge(snapshotBlockNumberOpts),
+		PendingConfChanges:      p.NewGauge(pendingConfChangesOpts),
 		LeaderChanges:           p.NewCounter(leaderChangesOpts),
 		ProposalFailures:        p.NewCounter(proposalFailuresOpts),
 		DataPersistDuration:     p.NewHistogram(dataPersistDurationOpts),
@@ -38,7 +38,7 @@ var _ = Describe("Metrics", func() {
 			metrics := etcdraft.NewMetrics(fakeProvider)
 
 			Expect(metrics).NotTo(BeNil())
-			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(4))
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(5))
 			Expect(fakeProvider.NewCounterCallCount()).To(Equal(4))
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
 
@@ -46,6 +46,7 @@ var _ = Describe("Metrics", func() {
 			Expect(metrics.IsLeader).To(Equal(fakeGauge))
 			Expect(metrics.CommittedBlockNumber).To(Equal(fakeGauge))
 			Expect(metrics.SnapshotBlockNumber).To(Equal(fakeGauge))
+			Expect(metrics.PendingConfChanges).To(Equal(fakeGauge))
 			Expect(metrics.LeaderChanges).To(Equal(fakeCounter))
 			Expect(metrics.ProposalFailures).To(Equal(fakeCounter))
 			Expect(metrics.DataPersistDuration).To(Equal(fakeHistogram))
@@ -61,6 +62,7 @@ func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 		IsLeader:                fakeFields.fakeIsLeader,
 		CommittedBlockNumber:    fakeFields.fakeCommittedBlockNumber,
 		SnapshotBlockNumber:     fakeFields.fakeSnapshotBlockNumber,
+		PendingConfChanges:      fakeFields.fakePendingConfChanges,
 		LeaderChanges:           fakeFields.fakeLeaderChanges,
 		ProposalFailures:        fakeFields.fakeProposalFailures,
 		DataPersistDuration:     fakeFields.fakeDataPersistDuration,
@@ -74,6 +76,7 @@ type fakeMetricsFields struct {
 	fakeIsLeader                *metricsfakes.Gauge
 	fakeCommittedBlockNumber    *metricsfakes.Gauge
 	fakeSnapshotBlockNumber     *metricsfakes.Gauge
+	fakePendingConfChanges      *metricsfakes.Gauge
 	fakeLeaderChanges           *metricsfakes.Counter
 	fakeProposalFailures        *metricsfakes.Counter
 	fakeDataPersistDuration     *metricsfakes.Histogram
@@ -87,6 +90,7 @@ func newFakeMetricsFields() *fakeMetricsFields {
 		fakeIsLeader:                newFakeGauge(),
 		fakeCommittedBlockNumber:    newFakeGauge(),
 		fakeSnapshotBlockNumber:     newFakeGauge(),
+		fakePendingConfChanges:      newFakeGauge(),
 		fakeLeaderChanges:           newFakeCounter(),
 		fakeProposalFailures:        newFakeCounter(),
 		fakeDataPersistDuration:     newFakeHistogram(),
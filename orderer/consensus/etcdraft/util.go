@@ -11,6 +11,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -39,8 +40,9 @@ type MembershipChanges struct {
 	NewConsenters    map[uint64]*etcdraft.Consenter
 	AddedNodes       []*etcdraft.Consenter
 	RemovedNodes     []*etcdraft.Consenter
-	ConfChange       *raftpb.ConfChange
-	RotatedNode      uint64
+	ConfChange       *raftpb.ConfChange // the first Raft configuration change to propose, if any
+	RotatedNodes     []uint64
+	RelocatedNodes   []uint64
 }
 
 // Stringer implements fmt.Stringer interface
@@ -53,9 +55,39 @@ func (mc *MembershipChanges) Changed() bool {
 	return len(mc.AddedNodes) > 0 || len(mc.RemovedNodes) > 0
 }
 
-// Rotated indicates whether the change was a rotation
+// Rotated indicates whether the change rotates the certificates of any node
 func (mc *MembershipChanges) Rotated() bool {
-	return len(mc.AddedNodes) == 1 && len(mc.RemovedNodes) == 1
+	return len(mc.RotatedNodes) > 0
+}
+
+// Relocated indicates whether the change moves any node to another endpoint
+func (mc *MembershipChanges) Relocated() bool {
+	return len(mc.RelocatedNodes) > 0
+}
+
+// CheckQuorumOverlap returns an error if the consenters that are kept by the changes are fewer than a
+// quorum of either the current or the new consenter set. Consenters are replaced one Raft configuration
+// change at a time, and the kept consenters carry the channel through the changes, hence replacing a
+// quorum of consenters at once could lose the quorum of the channel. Adding or removing a single
+// consenter is a single Raft configuration change and is always allowed.
+func (mc *MembershipChanges) CheckQuorumOverlap(oldConsenterIDs []uint64) error {
+	var kept int
+	for _, nodeID := range mc.NewBlockMetadata.ConsenterIds {
+		if NodeExists(nodeID, oldConsenterIDs) {
+			kept++
+		}
+	}
+	changed := len(mc.NewBlockMetadata.ConsenterIds) - kept + len(oldConsenterIDs) - kept
+	if changed <= 1 {
+		return nil
+	}
+	oldQuorum := len(oldConsenterIDs)/2 + 1
+	newQuorum := len(mc.NewBlockMetadata.ConsenterIds)/2 + 1
+	if kept < oldQuorum || kept < newQuorum {
+		return errors.Errorf("config update keeps %d of the current consenters, fewer than a quorum of "+
+			"the current (%d) or the new (%d) consenter set, requested changes: %s", kept, oldQuorum, newQuorum, mc)
+	}
+	return nil
 }
 
 // EndpointconfigFromFromSupport extracts TLS CA certificates and endpoints from the ConsenterSupport
@@ -161,8 +193,10 @@ func MembershipByCert(consenters map[uint64]*etcdraft.Consenter) map[string]uint
 }
 
 // ComputeMembershipChanges computes membership update based on information about new conseters, returns
-// two slices: a slice of added consenters and a slice of consenters to be removed
-func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter) (mc *MembershipChanges, err error) {
+// two slices: a slice of added consenters and a slice of consenters to be removed.
+// Any number of consenters may be added and removed by a single update, the resulting
+// Raft configuration changes are applied one at a time, as computed by ConfChange.
+func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters map[uint64]*etcdraft.Consenter, newConsenters []*etcdraft.Consenter) *MembershipChanges {
 	result := &MembershipChanges{
 		NewConsenters:    map[uint64]*etcdraft.Consenter{},
 		NewBlockMetadata: proto.Clone(oldMetadata).(*etcdraft.BlockMetadata),
@@ -172,59 +206,70 @@ func ComputeMembershipChanges(oldMetadata *etcdraft.BlockMetadata, oldConsenters
 
 	result.NewBlockMetadata.ConsenterIds = make([]uint64, len(newConsenters))
 
-	var addedNodeIndex int
+	var addedNodeIndexes []int
 	currentConsentersSet := MembershipByCert(oldConsenters)
 	for i, c := range newConsenters {
 		if nodeID, exists := currentConsentersSet[string(c.ClientTlsCert)]; exists {
 			result.NewBlockMetadata.ConsenterIds[i] = nodeID
 			result.NewConsenters[nodeID] = c
+			if oldConsenters[nodeID].Host != c.Host || oldConsenters[nodeID].Port != c.Port {
+				result.RelocatedNodes = append(result.RelocatedNodes, nodeID)
+			}
 			continue
 		}
-		addedNodeIndex = i
+		addedNodeIndexes = append(addedNodeIndexes, i)
 		result.AddedNodes = append(result.AddedNodes, c)
 	}
 
-	var deletedNodeID uint64
+	var removedNodeIDs []uint64
 	newConsentersSet := ConsentersToMap(newConsenters)
 	for nodeID, c := range oldConsenters {
 		if _, exists := newConsentersSet[string(c.ClientTlsCert)]; !exists {
-			result.RemovedNodes = append(result.RemovedNodes, c)
-			deletedNodeID = nodeID
+			removedNodeIDs = append(removedNodeIDs, nodeID)
 		}
 	}
+	sort.Slice(removedNodeIDs, func(i, j int) bool { return removedNodeIDs[i] < removedNodeIDs[j] })
+	for _, nodeID := range removedNodeIDs {
+		result.RemovedNodes = append(result.RemovedNodes, oldConsenters[nodeID])
+	}
 
-	switch {
-	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1:
-		// cert rotation
-		result.RotatedNode = deletedNodeID
-		result.NewBlockMetadata.ConsenterIds[addedNodeIndex] = deletedNodeID
-		result.NewConsenters[deletedNodeID] = result.AddedNodes[0]
-	case len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 0:
-		// new node
-		nodeID := result.NewBlockMetadata.NextConsenterId
-		result.NewConsenters[nodeID] = result.AddedNodes[0]
-		result.NewBlockMetadata.ConsenterIds[addedNodeIndex] = nodeID
-		result.NewBlockMetadata.NextConsenterId++
-		result.ConfChange = &raftpb.ConfChange{
-			NodeID: nodeID,
-			Type:   raftpb.ConfChangeAddNode,
+	// A removed consenter that is replaced by an added one keeps its Raft ID, and only
+	// its certificates are rotated. A single added consenter replaces a single removed one,
+	// otherwise consenters are paired by their endpoints.
+	rotated := map[int]uint64{}
+	if len(result.AddedNodes) == 1 && len(result.RemovedNodes) == 1 {
+		rotated[0] = removedNodeIDs[0]
+	} else {
+		paired := map[uint64]struct{}{}
+		for i, c := range result.AddedNodes {
+			for _, nodeID := range removedNodeIDs {
+				if _, exists := paired[nodeID]; exists {
+					continue
+				}
+				if oldConsenters[nodeID].Host == c.Host && oldConsenters[nodeID].Port == c.Port {
+					paired[nodeID] = struct{}{}
+					rotated[i] = nodeID
+					break
+				}
+			}
 		}
-	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 1:
-		// removed node
-		nodeID := deletedNodeID
-		result.ConfChange = &raftpb.ConfChange{
-			Type:   raftpb.ConfChangeRemoveNode,
-			NodeID: nodeID,
+	}
+
+	for i, c := range result.AddedNodes {
+		nodeID, isRotated := rotated[i]
+		if isRotated {
+			result.RotatedNodes = append(result.RotatedNodes, nodeID)
+		} else {
+			nodeID = result.NewBlockMetadata.NextConsenterId
+			result.NewBlockMetadata.NextConsenterId++
 		}
-		delete(result.NewConsenters, nodeID)
-	case len(result.AddedNodes) == 0 && len(result.RemovedNodes) == 0:
-		// no change
-	default:
-		// len(result.AddedNodes) > 1 || len(result.RemovedNodes) > 1 {
-		return nil, errors.Errorf("update of more than one consenter at a time is not supported, requested changes: %s", result)
+		result.NewConsenters[nodeID] = c
+		result.NewBlockMetadata.ConsenterIds[addedNodeIndexes[i]] = nodeID
 	}
 
-	return result, nil
+	result.ConfChange = ConfChange(result.NewBlockMetadata, &raftpb.ConfState{Nodes: oldMetadata.ConsenterIds})
+
+	return result
 }
 
 // MetadataHasDuplication returns an error if the metadata has duplication of consenters.
@@ -378,6 +423,68 @@ func ConsensusMetadataFromConfigBlock(block *common.Block) (*etcdraft.ConfigMeta
 	return MetadataFromConfigUpdate(configUpdate)
 }
 
+// ConsentersFromConfigBlock returns the consenters of the channel as of the given config block, mapped
+// by their Raft IDs. The consenter set is read from the channel configuration in the block, or from the
+// config update of the block if the configuration is absent. It returns nil if the block sets no consenters.
+func ConsentersFromConfigBlock(block *common.Block) (map[uint64]*etcdraft.Consenter, error) {
+	envelope, err := ConfigEnvelopeFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config payload")
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config envelope")
+	}
+
+	var configMetadata *etcdraft.ConfigMetadata
+	if configEnvelope.Config != nil && configEnvelope.Config.ChannelGroup != nil {
+		if ordererGroup, exists := configEnvelope.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]; exists {
+			if value, exists := ordererGroup.Values[channelconfig.ConsensusTypeKey]; exists {
+				if configMetadata, err = MetadataFromConfigValue(value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if configMetadata == nil && configEnvelope.LastUpdate != nil {
+		configUpdate, err := configtx.UnmarshalConfigUpdateFromPayload(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read config update")
+		}
+		if configMetadata, err = MetadataFromConfigUpdate(configUpdate); err != nil {
+			return nil, err
+		}
+	}
+	if configMetadata == nil {
+		return nil, nil
+	}
+
+	var ordererMetadata *common.Metadata
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_ORDERER) {
+		if ordererMetadata, err = utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER); err != nil {
+			return nil, errors.Wrap(err, "failed to read orderer metadata of the block")
+		}
+	}
+	blockMetadata, err := ReadBlockMetadata(ordererMetadata, configMetadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockMetadata.ConsenterIds) != len(configMetadata.Consenters) {
+		return nil, errors.Errorf("block [%d] has %d consenter IDs for %d consenters",
+			block.Header.Number, len(blockMetadata.ConsenterIds), len(configMetadata.Consenters))
+	}
+
+	consenters := map[uint64]*etcdraft.Consenter{}
+	for i, consenter := range configMetadata.Consenters {
+		consenters[blockMetadata.ConsenterIds[i]] = consenter
+	}
+	return consenters, nil
+}
+
 // CheckConfigMetadata validates Raft config metadata
 func CheckConfigMetadata(metadata *etcdraft.ConfigMetadata) error {
 	if metadata == nil {
@@ -490,33 +597,61 @@ func NodeExists(id uint64, nodes []uint64) bool {
 	return false
 }
 
-// ConfChange computes Raft configuration changes based on current Raft
-// configuration state and consenters IDs stored in RaftMetadata.
+// ConfChange computes the next Raft configuration change based on current Raft
+// configuration state and consenters IDs stored in RaftMetadata, or returns nil if
+// they already match. Raft configuration changes are applied one at a time: nodes are
+// added while the replica set is not larger than the consenter set, and removed otherwise,
+// so that the replica set never shrinks below the smaller of the two, and every change is
+// committed by a quorum of the replica set it changes.
 func ConfChange(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) *raftpb.ConfChange {
-	raftConfChange := &raftpb.ConfChange{}
-
-	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(blockMetadata.ConsenterIds) {
-		// adding new node
-		raftConfChange.Type = raftpb.ConfChangeAddNode
-		for _, consenterID := range blockMetadata.ConsenterIds {
-			if NodeExists(consenterID, confState.Nodes) {
-				continue
-			}
-			raftConfChange.NodeID = consenterID
+	added, removed := membershipDiff(blockMetadata.ConsenterIds, confState.Nodes)
+
+	switch {
+	case len(added) > 0 && (len(removed) == 0 || len(confState.Nodes) <= len(blockMetadata.ConsenterIds)):
+		return &raftpb.ConfChange{
+			Type:   raftpb.ConfChangeAddNode,
+			NodeID: added[0],
 		}
-	} else {
-		// removing node
-		raftConfChange.Type = raftpb.ConfChangeRemoveNode
-		for _, nodeID := range confState.Nodes {
-			if NodeExists(nodeID, blockMetadata.ConsenterIds) {
-				continue
-			}
-			raftConfChange.NodeID = nodeID
+	case len(removed) > 0:
+		return &raftpb.ConfChange{
+			Type:   raftpb.ConfChangeRemoveNode,
+			NodeID: removed[0],
 		}
+	default:
+		return nil
 	}
+}
 
-	return raftConfChange
+// CaughtUp returns whether the given node has replicated every committed entry, according to the
+// status of the Raft leader. A follower knows no progress, and reports no node as caught up.
+func CaughtUp(status raft.Status, nodeID uint64) bool {
+	pr, exists := status.Progress[nodeID]
+	return exists && pr.Match >= status.Commit
+}
+
+// PendingConfChanges returns the number of Raft configuration changes
+// left to apply for the replica set to match the consenter set.
+func PendingConfChanges(blockMetadata *etcdraft.BlockMetadata, confState *raftpb.ConfState) int {
+	added, removed := membershipDiff(blockMetadata.ConsenterIds, confState.Nodes)
+	return len(added) + len(removed)
+}
+
+// membershipDiff returns the sorted IDs of consenters that are not in the replica
+// set, and of nodes in the replica set that are not consenters.
+func membershipDiff(consenterIDs []uint64, nodes []uint64) (added []uint64, removed []uint64) {
+	for _, consenterID := range consenterIDs {
+		if !NodeExists(consenterID, nodes) {
+			added = append(added, consenterID)
+		}
+	}
+	for _, nodeID := range nodes {
+		if !NodeExists(nodeID, consenterIDs) {
+			removed = append(removed, nodeID)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+	sort.Slice(removed, func(i, j int) bool { return removed[i] < removed[j] })
+	return added, removed
 }
 
 // PeriodicCheck checks periodically a condition, and reports
@@ -19,6 +19,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -135,6 +136,34 @@ func TestIsConsenterOfChannel(t *testing.T) {
 	}
 }
 
+func TestConsentersFromConfigBlock(t *testing.T) {
+	_, err := ConsentersFromConfigBlock(nil)
+	assert.EqualError(t, err, "nil block")
+
+	blockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "etcdraftgenesis.block"))
+	assert.NoError(t, err)
+	block := &common.Block{}
+	assert.NoError(t, proto.Unmarshal(blockBytes, block))
+
+	// the genesis block carries no Raft metadata, hence consenters are numbered by their order
+	consenters, err := ConsentersFromConfigBlock(block)
+	assert.NoError(t, err)
+	assert.Len(t, consenters, 3)
+	for nodeID, consenter := range consenters {
+		assert.True(t, nodeID >= 1 && nodeID <= 3)
+		assert.NoError(t, ConsenterCertificate(consenter.ServerTlsCert).IsConsenterOfChannel(block))
+	}
+
+	metadata, err := proto.Marshal(&etcdraft.BlockMetadata{ConsenterIds: []uint64{4, 2, 7}, NextConsenterId: 8})
+	assert.NoError(t, err)
+	block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&common.Metadata{Value: metadata})
+	renumbered, err := ConsentersFromConfigBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, consenters[1], renumbered[4])
+	assert.Equal(t, consenters[2], renumbered[2])
+	assert.Equal(t, consenters[3], renumbered[7])
+}
+
 func TestEndpointconfigFromFromSupport(t *testing.T) {
 	blockBytes, err := ioutil.ReadFile("testdata/mychannel.block")
 	assert.NoError(t, err)
@@ -538,3 +567,208 @@ func TestLedgerBlockPuller(t *testing.T) {
 	assert.Equal(t, genesisBlock, lbp.PullBlock(0))
 	assert.Equal(t, notGenesisBlock, lbp.PullBlock(1))
 }
+
+func TestComputeMembershipChanges(t *testing.T) {
+	consenter := func(host string, port uint32, cert string) *etcdraft.Consenter {
+		return &etcdraft.Consenter{Host: host, Port: port, ClientTlsCert: []byte(cert), ServerTlsCert: []byte(cert)}
+	}
+
+	oldConsenters := map[uint64]*etcdraft.Consenter{
+		1: consenter("orderer1", 7050, "cert1"),
+		2: consenter("orderer2", 7050, "cert2"),
+		3: consenter("orderer3", 7050, "cert3"),
+	}
+	oldMetadata := &etcdraft.BlockMetadata{ConsenterIds: []uint64{1, 2, 3}, NextConsenterId: 4}
+
+	t.Run("no change", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{
+			oldConsenters[1], oldConsenters[2], oldConsenters[3],
+		})
+		assert.False(t, changes.Changed())
+		assert.False(t, changes.Rotated())
+		assert.False(t, changes.Relocated())
+		assert.Nil(t, changes.ConfChange)
+		assert.Equal(t, []uint64{1, 2, 3}, changes.NewBlockMetadata.ConsenterIds)
+	})
+
+	t.Run("single rotation", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{
+			oldConsenters[1], consenter("orderer4", 7050, "cert4"), oldConsenters[3],
+		})
+		assert.Equal(t, []uint64{2}, changes.RotatedNodes)
+		assert.Nil(t, changes.ConfChange)
+		assert.Equal(t, []uint64{1, 2, 3}, changes.NewBlockMetadata.ConsenterIds)
+		assert.Equal(t, uint64(4), changes.NewBlockMetadata.NextConsenterId)
+	})
+
+	t.Run("rotation of all nodes", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{
+			consenter("orderer3", 7050, "cert6"), consenter("orderer1", 7050, "cert4"), consenter("orderer2", 7050, "cert5"),
+		})
+		assert.Equal(t, "add 3 node(s), remove 3 node(s)", changes.String())
+		assert.Equal(t, []uint64{3, 1, 2}, changes.RotatedNodes)
+		assert.Nil(t, changes.ConfChange)
+		assert.Equal(t, []uint64{3, 1, 2}, changes.NewBlockMetadata.ConsenterIds)
+		assert.Equal(t, []byte("cert4"), changes.NewConsenters[1].ClientTlsCert)
+	})
+
+	t.Run("relocation", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{
+			oldConsenters[1], consenter("orderer2.dc2", 7050, "cert2"), consenter("orderer3.dc2", 7050, "cert3"),
+		})
+		assert.False(t, changes.Changed())
+		assert.Equal(t, []uint64{2, 3}, changes.RelocatedNodes)
+		assert.Nil(t, changes.ConfChange)
+		assert.Equal(t, "orderer2.dc2", changes.NewConsenters[2].Host)
+	})
+
+	t.Run("replacement of several nodes", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{
+			oldConsenters[1], consenter("orderer4", 7050, "cert4"), consenter("orderer5", 7050, "cert5"),
+		})
+		assert.Equal(t, "add 2 node(s), remove 2 node(s)", changes.String())
+		assert.Empty(t, changes.RotatedNodes)
+		assert.Equal(t, []uint64{1, 4, 5}, changes.NewBlockMetadata.ConsenterIds)
+		assert.Equal(t, uint64(6), changes.NewBlockMetadata.NextConsenterId)
+		assert.Equal(t, &raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: 4}, changes.ConfChange)
+		assert.Len(t, changes.NewConsenters, 3)
+	})
+
+	t.Run("removal of several nodes", func(t *testing.T) {
+		changes := ComputeMembershipChanges(oldMetadata, oldConsenters, []*etcdraft.Consenter{oldConsenters[2]})
+		assert.Equal(t, "add 0 node(s), remove 2 node(s)", changes.String())
+		assert.Equal(t, []uint64{2}, changes.NewBlockMetadata.ConsenterIds)
+		assert.Equal(t, &raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: 1}, changes.ConfChange)
+	})
+}
+
+func TestConfChange(t *testing.T) {
+	// Applies the config changes one by one and returns them, along
+	// with the size of the replica set after each of them.
+	sequence := func(consenterIDs, nodes []uint64) ([]raftpb.ConfChange, []int) {
+		metadata := &etcdraft.BlockMetadata{ConsenterIds: consenterIDs}
+		confState := &raftpb.ConfState{Nodes: nodes}
+
+		pending := PendingConfChanges(metadata, confState)
+
+		var changes []raftpb.ConfChange
+		var sizes []int
+		for cc := ConfChange(metadata, confState); cc != nil; cc = ConfChange(metadata, confState) {
+			changes = append(changes, *cc)
+			switch cc.Type {
+			case raftpb.ConfChangeAddNode:
+				confState.Nodes = append(confState.Nodes, cc.NodeID)
+			case raftpb.ConfChangeRemoveNode:
+				var remaining []uint64
+				for _, n := range confState.Nodes {
+					if n != cc.NodeID {
+						remaining = append(remaining, n)
+					}
+				}
+				confState.Nodes = remaining
+			}
+			sizes = append(sizes, len(confState.Nodes))
+			assert.Equal(t, pending-len(changes), PendingConfChanges(metadata, confState))
+		}
+		return changes, sizes
+	}
+
+	add := func(id uint64) raftpb.ConfChange {
+		return raftpb.ConfChange{Type: raftpb.ConfChangeAddNode, NodeID: id}
+	}
+	remove := func(id uint64) raftpb.ConfChange {
+		return raftpb.ConfChange{Type: raftpb.ConfChangeRemoveNode, NodeID: id}
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		changes, _ := sequence([]uint64{1, 2, 3}, []uint64{3, 2, 1})
+		assert.Empty(t, changes)
+	})
+
+	t.Run("single change", func(t *testing.T) {
+		changes, _ := sequence([]uint64{1, 2, 3, 4}, []uint64{1, 2, 3})
+		assert.Equal(t, []raftpb.ConfChange{add(4)}, changes)
+
+		changes, _ = sequence([]uint64{1, 3}, []uint64{1, 2, 3})
+		assert.Equal(t, []raftpb.ConfChange{remove(2)}, changes)
+	})
+
+	t.Run("replacement alternates additions and removals", func(t *testing.T) {
+		changes, sizes := sequence([]uint64{1, 2, 6, 7, 8}, []uint64{1, 2, 3, 4, 5})
+		assert.Equal(t, []raftpb.ConfChange{add(6), remove(3), add(7), remove(4), add(8), remove(5)}, changes)
+		assert.Equal(t, []int{6, 5, 6, 5, 6, 5}, sizes)
+	})
+
+	t.Run("shrinking removes first", func(t *testing.T) {
+		changes, sizes := sequence([]uint64{1, 6}, []uint64{1, 2, 3, 4})
+		assert.Equal(t, []raftpb.ConfChange{remove(2), remove(3), add(6), remove(4)}, changes)
+		assert.Equal(t, []int{3, 2, 3, 2}, sizes)
+	})
+
+	t.Run("growing adds first", func(t *testing.T) {
+		changes, sizes := sequence([]uint64{1, 2, 5, 6}, []uint64{1, 2, 3})
+		assert.Equal(t, []raftpb.ConfChange{add(5), add(6), remove(3)}, changes)
+		assert.Equal(t, []int{4, 5, 4}, sizes)
+	})
+}
+
+func TestCheckQuorumOverlap(t *testing.T) {
+	check := func(newConsenters, oldConsenters []uint64) error {
+		changes := &MembershipChanges{NewBlockMetadata: &etcdraft.BlockMetadata{ConsenterIds: newConsenters}}
+		return changes.CheckQuorumOverlap(oldConsenters)
+	}
+
+	t.Run("single change", func(t *testing.T) {
+		assert.NoError(t, check([]uint64{1, 2, 3, 4}, []uint64{1, 2, 3}))
+		assert.NoError(t, check([]uint64{1, 2}, []uint64{1, 2, 3}))
+		assert.NoError(t, check([]uint64{1, 2, 4}, []uint64{1, 2, 3}))
+	})
+
+	t.Run("growing from a single consenter", func(t *testing.T) {
+		assert.NoError(t, check([]uint64{1, 2}, []uint64{1}))
+		err := check([]uint64{1, 2, 3}, []uint64{1})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "config update keeps 1 of the current consenters, fewer than a quorum of "+
+			"the current (1) or the new (2) consenter set")
+	})
+
+	t.Run("keeping a quorum of only the current consenters", func(t *testing.T) {
+		assert.Error(t, check([]uint64{1, 2, 4, 5, 6}, []uint64{1, 2, 3}))
+	})
+
+	t.Run("keeping a quorum of only the new consenters", func(t *testing.T) {
+		assert.Error(t, check([]uint64{1, 2, 6}, []uint64{1, 2, 3, 4, 5}))
+	})
+
+	t.Run("replacing a minority", func(t *testing.T) {
+		assert.NoError(t, check([]uint64{1, 2, 3, 6, 7}, []uint64{1, 2, 3, 4, 5}))
+	})
+
+	t.Run("replacing a quorum", func(t *testing.T) {
+		err := check([]uint64{1, 5, 6}, []uint64{1, 2, 3})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "config update keeps 1 of the current consenters, fewer than a quorum of "+
+			"the current (2) or the new (2) consenter set")
+	})
+
+	t.Run("replacing all", func(t *testing.T) {
+		assert.Error(t, check([]uint64{4, 5, 6}, []uint64{1, 2, 3}))
+	})
+}
+
+func TestCaughtUp(t *testing.T) {
+	status := raft.Status{
+		HardState: raftpb.HardState{Commit: 10},
+		Progress: map[uint64]raft.Progress{
+			1: {Match: 10},
+			2: {Match: 12},
+			3: {Match: 4},
+		},
+	}
+
+	assert.True(t, CaughtUp(status, 1))
+	assert.True(t, CaughtUp(status, 2))
+	assert.False(t, CaughtUp(status, 3))
+	assert.False(t, CaughtUp(status, 4))
+	assert.False(t, CaughtUp(raft.Status{HardState: raftpb.HardState{Commit: 10}}, 1))
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/core/scc/cscc"
+	"github.com/tradeline-tech/fabric/peer/common"
+	pcommon "github.com/tradeline-tech/fabric/protos/common"
+	pb "github.com/tradeline-tech/fabric/protos/peer"
+	putils "github.com/tradeline-tech/fabric/protos/utils"
+)
+
+const joinBySnapshotDesc = "Joins the peer to a channel from a ledger snapshot."
+
+func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	joinBySnapshotCmd := &cobra.Command{
+		Use:   "joinbysnapshot",
+		Short: joinBySnapshotDesc,
+		Long: joinBySnapshotDesc + " The snapshot, as exported by 'peer node snapshot' from a peer of the channel, " +
+			"has to be available on the file system of the joining peer. The snapshot is imported only if the hash " +
+			"of its metadata matches the hash printed when the snapshot was exported.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return joinBySnapshot(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"snapshotpath",
+		"metadatahash",
+	}
+	attachFlags(joinBySnapshotCmd, flagList)
+
+	return joinBySnapshotCmd
+}
+
+func getJoinBySnapshotCCSpec() (*pb.ChaincodeSpec, error) {
+	if snapshotPath == common.UndefinedParamValue {
+		return nil, errors.New("Must supply the snapshot path")
+	}
+	if snapshotMetadataHash == common.UndefinedParamValue {
+		return nil, errors.New("Must supply the hash of the snapshot metadata")
+	}
+	metadataHash, err := hex.DecodeString(snapshotMetadataHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hash of the snapshot metadata")
+	}
+
+	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath), metadataHash}}
+
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+		Input:       input,
+	}
+
+	return spec, nil
+}
+
+func executeJoinBySnapshot(cf *ChannelCmdFactory) error {
+	spec, err := getJoinBySnapshotCCSpec()
+	if err != nil {
+		return err
+	}
+
+	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := putils.CreateProposalFromCIS(pcommon.HeaderType_CONFIG, "", invocation, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal for join by snapshot %s", err)
+	}
+
+	signedProp, err := putils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return fmt.Errorf("Error creating signed proposal %s", err)
+	}
+
+	proposalResp, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return ProposalFailedErr(err.Error())
+	}
+
+	if proposalResp == nil {
+		return ProposalFailedErr("nil proposal response")
+	}
+
+	if proposalResp.Response.Status != 0 && proposalResp.Response.Status != 200 {
+		return ProposalFailedErr(fmt.Sprintf("bad proposal response %d: %s", proposalResp.Response.Status, proposalResp.Response.Message))
+	}
+	logger.Info("Successfully submitted proposal to join channel from snapshot")
+	return nil
+}
+
+func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if snapshotPath == common.UndefinedParamValue {
+		return errors.New("Must supply the snapshot path")
+	}
+	if snapshotMetadataHash == common.UndefinedParamValue {
+		return errors.New("Must supply the hash of the snapshot metadata")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	return executeJoinBySnapshot(cf)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/core/scc/cscc"
+	"github.com/tradeline-tech/fabric/peer/common"
+	pb "github.com/tradeline-tech/fabric/protos/peer"
+)
+
+func TestJoinBySnapshotMissingFlags(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+
+	cmd := joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--metadatahash", "abcd"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the snapshot path")
+
+	resetFlags()
+	cmd = joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshot"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the hash of the snapshot metadata")
+}
+
+func TestJoinBySnapshot(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   mockEndorserClient,
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshot", "--metadatahash", "notHex"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid hash of the snapshot metadata")
+
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshot", "--metadatahash", "abcd"})
+	assert.NoError(t, cmd.Execute())
+
+	spec, err := getJoinBySnapshotCCSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "cscc", spec.ChaincodeId.Name)
+	assert.Equal(t, [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte("/snapshot"), {0xab, 0xcd}}, spec.Input.Args)
+}
+
+func TestJoinBySnapshotBadProposalResponse(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "snapshot metadata hash mismatch"},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/snapshot", "--metadatahash", "abcd"})
+	err = cmd.Execute()
+	assert.IsType(t, ProposalFailedErr(""), err)
+	assert.Contains(t, err.Error(), "snapshot metadata hash mismatch")
+}
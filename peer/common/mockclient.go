@@ -113,3 +113,7 @@ func (m *mockAdminClient) SetLogSpec(ctx context.Context, in *cb.Envelope, opts
 	response := &pb.LogSpecResponse{LogSpec: "info"}
 	return response, m.err
 }
+
+func (m *mockAdminClient) PurgePrivateData(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.PvtDataPurgeResponse, error) {
+	return &pb.PvtDataPurgeResponse{}, m.err
+}
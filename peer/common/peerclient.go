@@ -19,6 +19,7 @@ import (
 	"github.com/tradeline-tech/fabric/core/config"
 	"github.com/tradeline-tech/fabric/peer/common/api"
 	pb "github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/token"
 )
 
 // PeerClient represents a client for communicating with a peer
@@ -133,6 +134,15 @@ func (pc *PeerClient) Admin() (pb.AdminClient, error) {
 	return pb.NewAdminClient(conn), nil
 }
 
+// Prover returns a client for the token Prover service
+func (pc *PeerClient) Prover() (token.ProverClient, error) {
+	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("prover client failed to connect to %s", pc.address))
+	}
+	return token.NewProverClient(conn), nil
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (pc *PeerClient) Certificate() tls.Certificate {
 	return pc.commonClient.Certificate()
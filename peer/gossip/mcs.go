@@ -11,18 +11,22 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/tradeline-tech/fabric/bccsp"
 	"github.com/tradeline-tech/fabric/bccsp/factory"
+	"github.com/tradeline-tech/fabric/common/channelconfig"
 	"github.com/tradeline-tech/fabric/common/crypto"
 	"github.com/tradeline-tech/fabric/common/flogging"
 	"github.com/tradeline-tech/fabric/common/policies"
+	"github.com/tradeline-tech/fabric/common/policies/quorum"
 	"github.com/tradeline-tech/fabric/common/util"
 	"github.com/tradeline-tech/fabric/gossip/api"
 	"github.com/tradeline-tech/fabric/gossip/common"
 	"github.com/tradeline-tech/fabric/msp"
 	"github.com/tradeline-tech/fabric/msp/mgmt"
 	pcommon "github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
 
@@ -41,8 +45,13 @@ type MSPMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                crypto.LocalSigner
 	deserializer               mgmt.DeserializersManager
+	ordererConfig              OrdererConfigGetter
 }
 
+// OrdererConfigGetter returns the orderer configuration of a channel,
+// or false if the configuration of the channel is not known.
+type OrdererConfigGetter func(chainID string) (channelconfig.Orderer, bool)
+
 // NewMCS creates a new instance of MSPMessageCryptoService
 // that implements MessageCryptoService.
 // The method takes in input:
@@ -53,6 +62,14 @@ func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, loca
 	return &MSPMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer}
 }
 
+// SetOrdererConfigGetter sets the source of the orderer configuration of the channels.
+// Blocks are only valid if the orderer configuration of their channel is known, and the
+// blocks of channels that are ordered by a BFT ordering service are only valid if they
+// are signed by a quorum of its consenters.
+func (s *MSPMessageCryptoService) SetOrdererConfigGetter(ordererConfig OrdererConfigGetter) {
+	s.ordererConfig = ordererConfig
+}
+
 // ValidateIdentity validates the identity of a remote peer.
 // If the identity is invalid, revoked, expired it returns an error.
 // Else, returns nil
@@ -182,7 +199,46 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 	}
 
 	// - Evaluate policy
-	return policy.Evaluate(signatureSet)
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return err
+	}
+
+	// - Verify that a quorum of the consenters signed the block, if the ordering service is BFT
+	return s.verifyQuorum(channelID, block.Header.Number, signatureSet)
+}
+
+// verifyQuorum returns nil if the orderer of the channel is known not to be BFT, or if the given
+// signatures of the block were produced by a quorum of the consenters of the channel.
+// Blocks of channels whose orderer configuration is not known are rejected.
+func (s *MSPMessageCryptoService) verifyQuorum(channelID string, blockNum uint64, signatureSet []*pcommon.SignedData) error {
+	if s.ordererConfig == nil {
+		return fmt.Errorf("Could not acquire orderer configuration for channel [%s]", channelID)
+	}
+	ordererConfig, ok := s.ordererConfig(channelID)
+	if !ok || ordererConfig == nil {
+		return fmt.Errorf("Could not acquire orderer configuration for channel [%s]", channelID)
+	}
+	if ordererConfig.ConsensusType() != bft.TypeKey {
+		return nil
+	}
+
+	metadata := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(ordererConfig.ConsensusMetadata(), metadata); err != nil {
+		return fmt.Errorf("Failed unmarshalling consensus metadata of channel [%s]: [%s]", channelID, err)
+	}
+	deserializer, ok := s.deserializer.GetChannelDeserializers()[channelID]
+	if !ok {
+		return fmt.Errorf("Could not acquire identity deserializer for channel [%s]", channelID)
+	}
+
+	var identities [][]byte
+	for _, consenter := range metadata.Consenters {
+		identities = append(identities, consenter.Identity)
+	}
+	if err := quorum.NewPolicy(identities, deserializer).Evaluate(signatureSet); err != nil {
+		return fmt.Errorf("Block with id [%d] on channel [%s] is not signed by a quorum of consenters: [%s]", blockNum, channelID, err)
+	}
+	return nil
 }
 
 // Sign signs msg with this peer's signing key and outputs
@@ -28,8 +28,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/tradeline-tech/fabric/bccsp"
 	"github.com/tradeline-tech/fabric/bccsp/factory"
+	"github.com/tradeline-tech/fabric/common/channelconfig"
 	"github.com/tradeline-tech/fabric/common/crypto"
 	"github.com/tradeline-tech/fabric/common/localmsp"
+	mockconfig "github.com/tradeline-tech/fabric/common/mocks/config"
 	mockscrypto "github.com/tradeline-tech/fabric/common/mocks/crypto"
 	"github.com/tradeline-tech/fabric/common/policies"
 	"github.com/tradeline-tech/fabric/common/util"
@@ -39,6 +41,7 @@ import (
 	"github.com/tradeline-tech/fabric/peer/gossip/mocks"
 	"github.com/tradeline-tech/fabric/protos/common"
 	pmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
 	protospeer "github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
@@ -213,6 +216,9 @@ func TestVerifyBlock(t *testing.T) {
 			},
 		},
 	)
+	msgCryptoService.SetOrdererConfigGetter(func(chainID string) (channelconfig.Orderer, bool) {
+		return &mockconfig.Orderer{ConsensusTypeVal: "etcdraft"}, true
+	})
 
 	// - Prepare testing valid block, Alice signs it.
 	blockRaw, msg := mockBlock(t, "C", 42, aliceSigner, nil)
@@ -245,6 +251,114 @@ func TestVerifyBlock(t *testing.T) {
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, nil))
 }
 
+type identitiesDeserializer map[string]msp.Identity
+
+func (d identitiesDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	identity, exists := d[string(serializedIdentity)]
+	if !exists {
+		return nil, errors.New("Invalid Identity")
+	}
+	return identity, nil
+}
+
+func (d identitiesDeserializer) IsWellFormed(identity *pmsp.SerializedIdentity) error {
+	return nil
+}
+
+func TestVerifyBlockBFT(t *testing.T) {
+	consenters := []string{"o1", "o2", "o3", "o4"}
+	var signers []crypto.LocalSigner
+	for _, consenter := range consenters {
+		signers = append(signers, &mockscrypto.LocalSigner{Identity: []byte(consenter)})
+	}
+	metadata := &bft.ConfigMetadata{}
+	for i, consenter := range consenters {
+		metadata.Consenters = append(metadata.Consenters, &bft.Consenter{Id: uint64(i + 1), Identity: []byte(consenter)})
+	}
+
+	blockRaw, msgs := mockBlockSignedBy(t, "E", 42, signers...)
+	policy := &mocks.Policy{Deserializer: &mocks.IdentityDeserializer{Identity: []byte("o1"), Msg: msgs[0], Mock: mock.Mock{}}}
+	channelDeserializer := identitiesDeserializer{}
+	for i, consenter := range consenters {
+		channelDeserializer[consenter] = &mocks.Identity{Msg: msgs[i]}
+	}
+
+	msgCryptoService := NewMCS(
+		&mocks.ChannelPolicyManagerGetterWithManager{
+			Managers: map[string]policies.Manager{"E": &mocks.ChannelPolicyManager{Policy: policy}},
+		},
+		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
+		&mocks.DeserializersManager{
+			ChannelDeserializers: map[string]msp.IdentityDeserializer{"E": channelDeserializer},
+		},
+	)
+	// Blocks are rejected as long as the orderer configuration of their channel is not known
+	err := msgCryptoService.VerifyBlock([]byte("E"), 42, blockRaw)
+	assert.EqualError(t, err, "Could not acquire orderer configuration for channel [E]")
+	msgCryptoService.SetOrdererConfigGetter(func(chainID string) (channelconfig.Orderer, bool) {
+		return nil, false
+	})
+	err = msgCryptoService.VerifyBlock([]byte("E"), 42, blockRaw)
+	assert.EqualError(t, err, "Could not acquire orderer configuration for channel [E]")
+
+	ordererConfig := &mockconfig.Orderer{ConsensusTypeVal: "etcdraft"}
+	msgCryptoService.SetOrdererConfigGetter(func(chainID string) (channelconfig.Orderer, bool) {
+		return ordererConfig, chainID == "E"
+	})
+
+	// A block of a CFT ordering service is only checked against the block validation policy
+	block, err := utils.GetBlockFromBlockBytes(blockRaw)
+	assert.NoError(t, err)
+	signatures, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	assert.NoError(t, err)
+	signatures.Signatures = signatures.Signatures[:2]
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+	twoSignaturesRaw := utils.MarshalOrPanic(block)
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("E"), 42, twoSignaturesRaw))
+
+	ordererConfig.ConsensusTypeVal = bft.TypeKey
+	ordererConfig.ConsensusMetadataVal = utils.MarshalOrPanic(metadata)
+
+	assert.NoError(t, msgCryptoService.VerifyBlock([]byte("E"), 42, blockRaw))
+
+	err = msgCryptoService.VerifyBlock([]byte("E"), 42, twoSignaturesRaw)
+	assert.EqualError(t, err, "Block with id [42] on channel [E] is not signed by a quorum of consenters: "+
+		"[signatures of 2 out of 4 consenters were found, but a quorum of 3 is required]")
+
+	ordererConfig.ConsensusMetadataVal = []byte{1, 2, 3}
+	err = msgCryptoService.VerifyBlock([]byte("E"), 42, blockRaw)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed unmarshalling consensus metadata of channel [E]")
+}
+
+// mockBlockSignedBy returns a block signed by the given signers, along
+// with the message each of them signed.
+func mockBlockSignedBy(t *testing.T, channel string, seqNum uint64, signers ...crypto.LocalSigner) ([]byte, [][]byte) {
+	blockRaw, _ := mockBlock(t, channel, seqNum, signers[0], nil)
+	block, err := utils.GetBlockFromBlockBytes(blockRaw)
+	assert.NoError(t, err)
+
+	metadata := &common.Metadata{}
+	var msgs [][]byte
+	for _, signer := range signers {
+		shdr, err := signer.NewSignatureHeader()
+		assert.NoError(t, err, "Failed generating signature header")
+		blockSignature := &common.MetadataSignature{
+			SignatureHeader: utils.MarshalOrPanic(shdr),
+		}
+		msg := util.ConcatenateBytes(metadata.Value, blockSignature.SignatureHeader, block.Header.Bytes())
+		blockSignature.Signature, err = signer.Sign(msg)
+		assert.NoError(t, err, "Failed signing block")
+		metadata.Signatures = append(metadata.Signatures, blockSignature)
+		msgs = append(msgs, msg)
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(metadata)
+
+	blockRaw, err = proto.Marshal(block)
+	assert.NoError(t, err, "Failed marshalling block")
+	return blockRaw, msgs
+}
+
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner crypto.LocalSigner, dataHash []byte) ([]byte, []byte) {
 	block := common.NewBlock(seqNum, nil)
 
@@ -19,6 +19,7 @@ import (
 	"github.com/tradeline-tech/fabric/peer/clilogging"
 	"github.com/tradeline-tech/fabric/peer/common"
 	"github.com/tradeline-tech/fabric/peer/node"
+	"github.com/tradeline-tech/fabric/peer/token"
 	"github.com/tradeline-tech/fabric/peer/version"
 )
 
@@ -48,6 +49,7 @@ func main() {
 	mainCmd.AddCommand(chaincode.Cmd(nil))
 	mainCmd.AddCommand(clilogging.Cmd(nil))
 	mainCmd.AddCommand(channel.Cmd(nil))
+	mainCmd.AddCommand(token.Cmd(nil))
 
 	// On failure Cobra prints the usage message and error string, so we only
 	// need to exit with a non-0 status
@@ -38,6 +38,9 @@ func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(statusCmd())
 	nodeCmd.AddCommand(resetCmd())
 	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(snapshotCmd())
+	nodeCmd.AddCommand(purgePvtDataCmd())
+	nodeCmd.AddCommand(verifyCmd())
 
 	return nodeCmd
 }
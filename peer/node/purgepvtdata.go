@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/common/crypto"
+	"github.com/tradeline-tech/fabric/peer/common"
+	common2 "github.com/tradeline-tech/fabric/protos/common"
+	pb "github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/utils"
+)
+
+func purgePvtDataCmd() *cobra.Command {
+	nodePurgePvtDataCmd.ResetFlags()
+	flags := nodePurgePvtDataCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to purge the private data of.")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number below which the private data is purged.")
+
+	return nodePurgePvtDataCmd
+}
+
+var nodePurgePvtDataCmd = &cobra.Command{
+	Use:   "purgepvtdata",
+	Short: "Purges the private data of a channel.",
+	Long:  `Purges the private data of the blocks below a specified block number from the ledger of a channel, irrespective of the block-to-live of the collections. The hashes of the private data are retained. The command is executed against the admin service of the running peer; the blocks committed in the meantime wait for the purge to finish. The purged private data cannot be restored.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return purgePvtData(channelID, blockNumber)
+	},
+}
+
+func purgePvtData(channelID string, maxBlockNumToRetain uint64) error {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return err
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	localSigner := crypto.NewSignatureHeaderCreator(signer)
+	wrapEnv := func(msg proto.Message) *common2.Envelope {
+		env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "", localSigner, msg, 0, 0)
+		if err != nil {
+			logger.Panicf("Failed signing: %v", err)
+		}
+		return env
+	}
+
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_PvtDataPurgeReq{
+			PvtDataPurgeReq: &pb.PvtDataPurgeRequest{
+				ChannelId:           channelID,
+				MaxBlockNumToRetain: maxBlockNumToRetain,
+			},
+		},
+	}
+	resp, err := adminClient.PurgePrivateData(context.Background(), wrapEnv(op))
+	if err != nil {
+		return errors.WithMessage(err, "error purging the private data")
+	}
+	fmt.Printf("Private data of channel [%s] purged. Lowest block with retained private data: %d\n", channelID, resp.MinBlockNum)
+	return nil
+}
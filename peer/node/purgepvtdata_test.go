@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/core/admin"
+	"github.com/tradeline-tech/fabric/core/comm"
+	"github.com/tradeline-tech/fabric/core/peer"
+	"github.com/tradeline-tech/fabric/msp"
+	common2 "github.com/tradeline-tech/fabric/peer/common"
+	"github.com/tradeline-tech/fabric/peer/mocks"
+	pb "github.com/tradeline-tech/fabric/protos/peer"
+)
+
+type mockPvtDataPurger struct {
+	channelID           string
+	maxBlockNumToRetain uint64
+}
+
+func (p *mockPvtDataPurger) PurgePrivateData(channelID string, maxBlockNumToRetain uint64) (uint64, error) {
+	if channelID != p.channelID {
+		return 0, errors.Errorf("channel [%s] does not exist", channelID)
+	}
+	p.maxBlockNumToRetain = maxBlockNumToRetain
+	return maxBlockNumToRetain, nil
+}
+
+func TestPurgePvtDataCmd(t *testing.T) {
+	defer viper.Reset()
+
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := purgePvtDataCmd()
+		cmd.SetArgs([]string{"-b", "10"})
+		err := cmd.Execute()
+		assert.EqualError(t, err, "Must supply channel ID")
+	})
+
+	signer := &mocks.Signer{}
+	common2.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return signer, nil
+	}
+	viper.Set("peer.address", "localhost:7074")
+	peerServer, err := peer.NewPeerServer("localhost:7074", comm.ServerConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create peer server (%s)", err)
+	}
+	purger := &mockPvtDataPurger{channelID: "ch1"}
+	pb.RegisterAdminServer(peerServer.Server(), admin.NewAdminServer(&mockEvaluator{}, purger))
+	go peerServer.Start()
+	defer peerServer.Stop()
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := purgePvtDataCmd()
+		cmd.SetArgs([]string{"-c", "ch2", "-b", "10"})
+		err := cmd.Execute()
+		assert.Contains(t, err.Error(), "channel [ch2] does not exist")
+	})
+
+	t.Run("when the purge succeeds", func(t *testing.T) {
+		cmd := purgePvtDataCmd()
+		cmd.SetArgs([]string{"-c", "ch1", "-b", "10"})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, uint64(10), purger.maxBlockNumToRetain)
+	})
+}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger"
+	"github.com/tradeline-tech/fabric/peer/common"
+)
+
+var snapshotDir string
+
+func snapshotCmd() *cobra.Command {
+	nodeSnapshotCmd.ResetFlags()
+	flags := nodeSnapshotCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to export a snapshot of.")
+	flags.StringVarP(&snapshotDir, "outputDir", "o", common.UndefinedParamValue, "Directory, not yet existing, to write the snapshot to.")
+
+	return nodeSnapshotCmd
+}
+
+var nodeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Exports a snapshot of a channel.",
+	Long:  `Exports a snapshot of a channel at the current height of its block store. The snapshot contains the state database, the hashes of the private data, the config history, the IDs of the transactions, and the last block. A peer joining the channel can create its ledger from the snapshot instead of processing all the blocks from the genesis block, by means of 'peer channel joinbysnapshot'. The printed hash of the snapshot metadata has to be passed to the joining peer through a trusted channel, as the snapshot is imported only if the hash matches. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		if snapshotDir == common.UndefinedParamValue {
+			return errors.New("Must supply output directory")
+		}
+		metadataHash, err := kvledger.ExportSnapshot(channelID, snapshotDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Snapshot of channel [%s] exported to [%s]. Hash of the snapshot metadata: %x\n", channelID, snapshotDir, metadataHash)
+		return nil
+	},
+}
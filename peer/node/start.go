@@ -25,6 +25,7 @@ import (
 
 	"github.com/tradeline-tech/fabric/common/cauthdsl"
 	ccdef "github.com/tradeline-tech/fabric/common/chaincode"
+	"github.com/tradeline-tech/fabric/common/channelconfig"
 	"github.com/tradeline-tech/fabric/common/crypto"
 	"github.com/tradeline-tech/fabric/common/crypto/tlsgen"
 	"github.com/tradeline-tech/fabric/common/deliver"
@@ -94,6 +95,7 @@ import (
 	"github.com/tradeline-tech/fabric/protos/transientstore"
 	"github.com/tradeline-tech/fabric/protos/utils"
 	"github.com/tradeline-tech/fabric/token/server"
+	"github.com/tradeline-tech/fabric/token/tms/manager"
 )
 
 const (
@@ -839,7 +841,21 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, baseServer
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, ledgerPvtDataPurger{}))
+}
+
+// ledgerPvtDataPurger purges the private data of the ledgers of the channels joined by the peer
+type ledgerPvtDataPurger struct{}
+
+func (ledgerPvtDataPurger) PurgePrivateData(channelID string, maxBlockNumToRetain uint64) (uint64, error) {
+	l := peer.GetLedger(channelID)
+	if l == nil {
+		return 0, errors.Errorf("channel [%s] does not exist", channelID)
+	}
+	if err := l.PurgePrivateData(maxBlockNumToRetain); err != nil {
+		return 0, err
+	}
+	return l.PrivateDataMinBlockNum()
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
@@ -893,6 +909,13 @@ func initGossipService(policyMgr policies.ChannelPolicyManagerGetter, metricsPro
 		localmsp.NewSigner(),
 		mgmt.NewDeserializersManager(),
 	)
+	messageCryptoService.SetOrdererConfigGetter(func(cid string) (channelconfig.Orderer, bool) {
+		channelConfig := peer.GetStableChannelConfig(cid)
+		if channelConfig == nil {
+			return nil, false
+		}
+		return channelConfig.OrdererConfig()
+	})
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
 	orgLeader := viper.GetBool("peer.gossip.orgLeader")
@@ -951,14 +974,17 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 		return err
 	}
 
+	capabilityChecker := &server.TokenCapabilityChecker{
+		PeerOps: peer.Default,
+	}
 	prover := &server.Prover{
-		CapabilityChecker: &server.TokenCapabilityChecker{
-			PeerOps: peer.Default,
-		},
-		Marshaler:     responseMarshaler,
-		PolicyChecker: policyChecker,
+		CapabilityChecker: capabilityChecker,
+		Marshaler:         responseMarshaler,
+		PolicyChecker:     policyChecker,
 		TMSManager: &server.Manager{
-			LedgerManager: &server.PeerLedgerManager{},
+			LedgerManager:               &server.PeerLedgerManager{},
+			CapabilityChecker:           capabilityChecker,
+			IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
 		},
 	}
 	token.RegisterProverServer(peerServer.Server(), prover)
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/core/ledger/kvledger"
+	"github.com/tradeline-tech/fabric/core/peer"
+	"github.com/tradeline-tech/fabric/core/scc/lscc"
+	"github.com/tradeline-tech/fabric/peer/common"
+)
+
+func verifyCmd() *cobra.Command {
+	nodeVerifyCmd.ResetFlags()
+	flags := nodeVerifyCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to verify.")
+	return nodeVerifyCmd
+}
+
+var nodeVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies the integrity of the ledger of a channel.",
+	Long:  `Verifies the integrity of the ledger of a channel. The command checks the hash chain and the data hashes of the blocks, the block index, and the private data against the hashes in the blocks. When all the blocks from the genesis block are available, the command also rebuilds the state database and the history database from the blocks and compares them with the databases of the peer. A JSON report that describes the first inconsistency found is printed. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		report, err := kvledger.VerifyLedger(channelID, peer.ConfigTxProcessors, &lscc.DeployedCCInfoProvider{})
+		if err != nil {
+			return err
+		}
+		reportBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "error marshaling the verification report")
+		}
+		fmt.Println(string(reportBytes))
+		if !report.Consistent {
+			return errors.Errorf("the ledger of channel [%s] is not consistent", channelID)
+		}
+		return nil
+	},
+}
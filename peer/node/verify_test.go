@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "verify")
+	require.NoError(t, err)
+	os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer os.RemoveAll(testPath)
+
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := verifyCmd()
+		args := []string{}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := verifyCmd()
+		args := []string{"-c", "ch1"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		expectedErr := "ledgerID [ch1] does not exist"
+		assert.Equal(t, expectedErr, err.Error())
+	})
+}
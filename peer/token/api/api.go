@@ -0,0 +1,24 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	pcommon "github.com/tradeline-tech/fabric/protos/common"
+)
+
+//go:generate counterfeiter -o ../mock/tx_submitter.go -fake-name TxSubmitter . TxSubmitter
+
+// TxSubmitter wraps a token transaction into a fabric transaction
+// and submits it to the ordering service
+type TxSubmitter interface {
+	// CreateTxEnvelope creates a signed envelope carrying the given token transaction bytes
+	CreateTxEnvelope(txBytes []byte) (string, *pcommon.Envelope, error)
+
+	// SubmitTransaction sends the envelope to the orderer and, if waitTimeInSeconds
+	// is greater than 0, waits for the transaction to be committed by the peer
+	SubmitTransaction(txEnvelope *pcommon.Envelope, waitTimeInSeconds int) (bool, string, error)
+}
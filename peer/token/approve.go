@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+func approveCmd(cf *TokenCmdFactory) *cobra.Command {
+	approveCmd := &cobra.Command{
+		Use:   "approve",
+		Short: "Approve the delegation of tokens.",
+		Long:  "Allow one or more recipients to spend the given quantities out of the given tokens. Requires '-c', '-i' and '-s'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return approve(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(approveCmd, flagList)
+
+	return approveCmd
+}
+
+func approve(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	ids, err := parseTokenIDs(tokenIDs)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseShares(shares, cf.Signer)
+	if err != nil {
+		return err
+	}
+	var allowanceShares []*token.AllowanceRecipientShare
+	for _, s := range parsed {
+		allowanceShares = append(allowanceShares, &token.AllowanceRecipientShare{Recipient: s.recipient, Quantity: s.quantity})
+	}
+
+	resp, err := cf.Prover.RequestApprove(ids, allowanceShares, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to request approve")
+	}
+
+	return submit(cf, resp)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/tradeline-tech/fabric/core/config"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/peer/common"
+	"github.com/tradeline-tech/fabric/peer/token/api"
+	pmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+	tk "github.com/tradeline-tech/fabric/token"
+	"github.com/tradeline-tech/fabric/token/client"
+)
+
+// TokenCmdFactory holds the clients used by TokenCmd
+type TokenCmdFactory struct {
+	Signer      tk.SigningIdentity
+	Prover      client.Prover
+	TxSubmitter api.TxSubmitter
+}
+
+// signingIdentity adapts an msp.SigningIdentity to a token SigningIdentity
+type signingIdentity struct {
+	msp.SigningIdentity
+}
+
+func (s *signingIdentity) GetPublicVersion() tk.Identity {
+	return s.SigningIdentity.GetPublicVersion()
+}
+
+// InitCmdFactory init the TokenCmdFactory with the default prover peer client
+// and, when submitterRequired is true, with a submitter for the orderer and the
+// commit peer configured in the peer CLI environment
+func InitCmdFactory(submitterRequired bool) (*TokenCmdFactory, error) {
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	peerClient, err := common.NewPeerClientFromEnv()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting peer client")
+	}
+	proverClient, err := peerClient.Prover()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting prover client")
+	}
+
+	cf := &TokenCmdFactory{
+		Signer: &signingIdentity{SigningIdentity: signer},
+		Prover: &client.ProverPeer{
+			ChannelID:        channelID,
+			ProverClient:     proverClient,
+			RandomnessReader: rand.Reader,
+			Time:             time.Now,
+		},
+	}
+	if !submitterRequired {
+		return cf, nil
+	}
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error serializing default signer")
+	}
+	clientConfig := &client.ClientConfig{
+		ChannelId:  channelID,
+		OrdererCfg: connectionConfigFromEnv("orderer"),
+		// commit events are received from the same peer that serves the prover requests
+		CommitPeerCfg: connectionConfigFromEnv("peer"),
+	}
+	if err := client.ValidateClientConfig(clientConfig); err != nil {
+		return nil, err
+	}
+	ordererClient, err := client.NewOrdererClient(clientConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting orderer client")
+	}
+	deliverClient, err := client.NewDeliverClient(clientConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting deliver client")
+	}
+	cf.TxSubmitter = &client.TxSubmitter{
+		Config:        clientConfig,
+		Signer:        signer,
+		Creator:       creator,
+		OrdererClient: ordererClient,
+		DeliverClient: deliverClient,
+	}
+
+	return cf, nil
+}
+
+func connectionConfigFromEnv(prefix string) client.ConnectionConfig {
+	cfg := client.ConnectionConfig{
+		Address:            viper.GetString(prefix + ".address"),
+		TlsEnabled:         viper.GetBool(prefix + ".tls.enabled"),
+		ServerNameOverride: viper.GetString(prefix + ".tls.serverhostoverride"),
+	}
+	if viper.GetString(prefix+".tls.rootcert.file") != "" {
+		cfg.TlsRootCertFile = config.GetPath(prefix + ".tls.rootcert.file")
+	}
+	return cfg
+}
+
+// checkChannelID makes sure that the channel flag was set
+func checkChannelID() error {
+	if channelID == common.UndefinedParamValue || channelID == "" {
+		return errors.New("must supply channel ID")
+	}
+	return nil
+}
+
+// parseTokenIDs decodes the comma separated list of hex encoded token identifiers
+func parseTokenIDs(ids string) ([][]byte, error) {
+	if ids == common.UndefinedParamValue || ids == "" {
+		return nil, errors.New("must supply token IDs")
+	}
+
+	var tokenIDs [][]byte
+	for _, id := range strings.Split(ids, ",") {
+		tokenID, err := hex.DecodeString(strings.TrimSpace(id))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token ID '%s'", id)
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+	return tokenIDs, nil
+}
+
+// parseRecipient returns the serialized identity described by the given
+// <mspID>:<path to PEM certificate> string; an empty string refers to the
+// identity of the caller
+func parseRecipient(recipient string, signer tk.SigningIdentity) ([]byte, error) {
+	if recipient == "" {
+		return signer.Serialize()
+	}
+
+	parts := strings.SplitN(recipient, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid recipient '%s', expected <mspID>:<path to PEM certificate>", recipient)
+	}
+	cert, err := ioutil.ReadFile(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading certificate of recipient '%s'", recipient)
+	}
+
+	return proto.Marshal(&pmsp.SerializedIdentity{Mspid: parts[0], IdBytes: cert})
+}
+
+type share struct {
+	recipient []byte
+	quantity  uint64
+}
+
+// parseShares parses the comma separated list of <recipient>=<quantity> shares
+func parseShares(s string, signer tk.SigningIdentity) ([]share, error) {
+	if s == common.UndefinedParamValue || s == "" {
+		return nil, errors.New("must supply shares")
+	}
+
+	var result []share
+	for _, entry := range strings.Split(s, ",") {
+		idx := strings.LastIndex(entry, "=")
+		if idx < 0 {
+			return nil, errors.Errorf("invalid share '%s', expected <recipient>=<quantity>", entry)
+		}
+		recipient, err := parseRecipient(strings.TrimSpace(entry[:idx]), signer)
+		if err != nil {
+			return nil, err
+		}
+		q, err := strconv.ParseUint(strings.TrimSpace(entry[idx+1:]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity in share '%s'", entry)
+		}
+		result = append(result, share{recipient: recipient, quantity: q})
+	}
+	return result, nil
+}
+
+// commandResponse unmarshals the response returned by the prover peer and
+// surfaces the error it carries, if any
+func commandResponse(raw []byte) (*token.CommandResponse, error) {
+	cr := &token.CommandResponse{}
+	if err := proto.Unmarshal(raw, cr); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal prover response")
+	}
+	if e, ok := cr.Payload.(*token.CommandResponse_Err); ok {
+		return nil, errors.Errorf("prover returned error: %s", e.Err.GetMessage())
+	}
+	return cr, nil
+}
+
+// submit extracts the token transaction from the prover response and
+// submits it, waiting for its commit if requested
+func submit(cf *TokenCmdFactory, raw []byte) error {
+	cr, err := commandResponse(raw)
+	if err != nil {
+		return err
+	}
+	tx, ok := cr.Payload.(*token.CommandResponse_TokenTransaction)
+	if !ok {
+		return errors.Errorf("unexpected prover response payload type %T", cr.Payload)
+	}
+	txBytes, err := proto.Marshal(tx.TokenTransaction)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal token transaction")
+	}
+
+	txID, env, err := cf.TxSubmitter.CreateTxEnvelope(txBytes)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create transaction envelope")
+	}
+
+	waitTime := 0
+	if waitForEvent {
+		waitTime = int(waitForEventTimeout.Seconds())
+	}
+	committed, _, err := cf.TxSubmitter.SubmitTransaction(env, waitTime)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to submit transaction %s", txID))
+	}
+	if waitForEvent && !committed {
+		return errors.Errorf("transaction %s was not committed within %s", txID, waitForEventTimeout)
+	}
+
+	logger.Infof("Token transaction %s submitted successfully", txID)
+	if committed {
+		logger.Infof("Token transaction %s committed", txID)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/peer/common"
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+func issueCmd(cf *TokenCmdFactory) *cobra.Command {
+	issueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue new tokens.",
+		Long:  "Issue new tokens of the given type and quantity to a recipient. Requires '-c', '-t' and '-q'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return issue(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"type",
+		"quantity",
+		"recipient",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(issueCmd, flagList)
+
+	return issueCmd
+}
+
+func issue(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if tokenType == common.UndefinedParamValue || tokenType == "" {
+		return errors.New("must supply token type")
+	}
+	if quantity == 0 {
+		return errors.New("must supply a quantity greater than 0")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	owner, err := parseRecipient(recipient, cf.Signer)
+	if err != nil {
+		return err
+	}
+	tokensToIssue := []*token.TokenToIssue{{Recipient: owner, Type: tokenType, Quantity: quantity}}
+
+	resp, err := cf.Prover.RequestImport(tokensToIssue, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to request import")
+	}
+
+	return submit(cf, resp)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+func listCmd(cf *TokenCmdFactory) *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List unspent tokens.",
+		Long:  "List the unspent tokens owned by the caller. Requires '-c'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+	}
+	attachFlags(listCmd, flagList)
+
+	return listCmd
+}
+
+func list(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := cf.Prover.ListTokens(cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to list tokens")
+	}
+	cr, err := commandResponse(resp)
+	if err != nil {
+		return err
+	}
+	unspent, ok := cr.Payload.(*token.CommandResponse_UnspentTokens)
+	if !ok {
+		return errors.Errorf("unexpected prover response payload type %T", cr.Payload)
+	}
+
+	for _, t := range unspent.UnspentTokens.GetTokens() {
+		fmt.Printf("%s %s %d\n", hex.EncodeToString(t.Id), t.Type, t.Quantity)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func redeemCmd(cf *TokenCmdFactory) *cobra.Command {
+	redeemCmd := &cobra.Command{
+		Use:   "redeem",
+		Short: "Redeem tokens.",
+		Long:  "Redeem the given quantity out of the given tokens. Requires '-c', '-i' and '-q'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return redeem(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"quantity",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(redeemCmd, flagList)
+
+	return redeemCmd
+}
+
+func redeem(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	ids, err := parseTokenIDs(tokenIDs)
+	if err != nil {
+		return err
+	}
+	if quantity == 0 {
+		return errors.New("must supply a quantity greater than 0")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := cf.Prover.RequestRedeem(ids, quantity, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to request redeem")
+	}
+
+	return submit(cf, resp)
+}
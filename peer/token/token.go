@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/peer/common"
+)
+
+const (
+	tokenFuncName = "token"
+	tokenCmdDes   = "Operate fabtokens: issue|transfer|redeem|list|approve|transferFrom."
+)
+
+var logger = flogging.MustGetLogger("cli.token")
+
+var (
+	channelID           string
+	tokenIDs            string
+	tokenType           string
+	quantity            uint64
+	recipient           string
+	shares              string
+	waitForEvent        bool
+	waitForEventTimeout time.Duration
+)
+
+// Cmd returns the cobra command for Token
+func Cmd(cf *TokenCmdFactory) *cobra.Command {
+	common.AddOrdererFlags(tokenCmd)
+
+	tokenCmd.AddCommand(issueCmd(cf))
+	tokenCmd.AddCommand(transferCmd(cf))
+	tokenCmd.AddCommand(redeemCmd(cf))
+	tokenCmd.AddCommand(listCmd(cf))
+	tokenCmd.AddCommand(approveCmd(cf))
+	tokenCmd.AddCommand(transferFromCmd(cf))
+
+	return tokenCmd
+}
+
+var tokenCmd = &cobra.Command{
+	Use:   tokenFuncName,
+	Short: fmt.Sprint(tokenCmdDes),
+	Long:  fmt.Sprint(tokenCmdDes),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.InitCmd(cmd, args)
+		common.SetOrdererEnv(cmd, args)
+	},
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "The channel on which the token transaction is submitted")
+	flags.StringVarP(&tokenIDs, "tokenIDs", "i", common.UndefinedParamValue, "Comma separated list of hex encoded token identifiers")
+	flags.StringVarP(&tokenType, "type", "t", common.UndefinedParamValue, "The type of the tokens to issue")
+	flags.Uint64VarP(&quantity, "quantity", "q", 0, "The number of token units")
+	flags.StringVarP(&recipient, "recipient", "r", "", "The recipient identity in the form <mspID>:<path to PEM certificate>; defaults to the identity of the caller")
+	flags.StringVarP(&shares, "shares", "s", common.UndefinedParamValue, "Comma separated list of shares in the form <mspID>:<path to PEM certificate>=<quantity>")
+	flags.BoolVar(&waitForEvent, "waitForEvent", false, "Whether to wait for the event from the peer before returning, which indicates that the token transaction was committed")
+	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second, "Time to wait for the event from the peer which indicates that the token transaction was committed")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
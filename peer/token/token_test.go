@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/core/config/configtest"
+	"github.com/tradeline-tech/fabric/peer/token/mock"
+	cb "github.com/tradeline-tech/fabric/protos/common"
+	pmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+	tkmock "github.com/tradeline-tech/fabric/token/client/mock"
+)
+
+func newMockCF(t *testing.T) (*TokenCmdFactory, *tkmock.Prover, *mock.TxSubmitter) {
+	signer := &tkmock.SigningIdentity{}
+	signer.SerializeReturns([]byte("creator"), nil)
+
+	txResponse, err := proto.Marshal(&token.CommandResponse{
+		Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: &token.TokenTransaction{}},
+	})
+	assert.NoError(t, err)
+
+	prover := &tkmock.Prover{}
+	prover.RequestImportReturns(txResponse, nil)
+	prover.RequestTransferReturns(txResponse, nil)
+	prover.RequestRedeemReturns(txResponse, nil)
+	prover.RequestApproveReturns(txResponse, nil)
+	prover.RequestTransferFromReturns(txResponse, nil)
+
+	submitter := &mock.TxSubmitter{}
+	submitter.CreateTxEnvelopeReturns("txid", &cb.Envelope{}, nil)
+	submitter.SubmitTransactionReturns(true, "txid", nil)
+
+	return &TokenCmdFactory{Signer: signer, Prover: prover, TxSubmitter: submitter}, prover, submitter
+}
+
+func TestIssue(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+
+	cmd := issueCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-t", "USD", "-q", "100"})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, 1, prover.RequestImportCallCount())
+	tokensToIssue, _ := prover.RequestImportArgsForCall(0)
+	assert.Equal(t, []*token.TokenToIssue{{Recipient: []byte("creator"), Type: "USD", Quantity: 100}}, tokensToIssue)
+	assert.Equal(t, 1, submitter.CreateTxEnvelopeCallCount())
+	_, waitTime := submitter.SubmitTransactionArgsForCall(0)
+	assert.Equal(t, 0, waitTime)
+}
+
+func TestOrdererFlags(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	defer viper.Reset()
+	resetFlags()
+	cf, _, _ := newMockCF(t)
+
+	cmd := Cmd(cf)
+	cmd.SetArgs([]string{"issue", "-c", "mychannel", "-t", "USD", "-q", "100",
+		"--orderer", "orderer.example.com:7050", "--tls", "--cafile", "root.crt"})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, "orderer.example.com:7050", viper.GetString("orderer.address"))
+	assert.Equal(t, true, viper.GetBool("orderer.tls.enabled"))
+	assert.Equal(t, "root.crt", viper.GetString("orderer.tls.rootcert.file"))
+}
+
+func TestConnectionConfigFromEnv(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("orderer.address", "orderer.example.com:7050")
+	viper.Set("orderer.tls.enabled", true)
+	viper.Set("orderer.tls.rootcert.file", "/orderer/root.crt")
+	viper.Set("peer.address", "peer0.example.com:7051")
+	viper.Set("peer.tls.enabled", false)
+
+	// the orderer and the commit peer connections are secured independently
+	ordererCfg := connectionConfigFromEnv("orderer")
+	assert.Equal(t, "orderer.example.com:7050", ordererCfg.Address)
+	assert.True(t, ordererCfg.TlsEnabled)
+	assert.Equal(t, "/orderer/root.crt", ordererCfg.TlsRootCertFile)
+	peerCfg := connectionConfigFromEnv("peer")
+	assert.Equal(t, "peer0.example.com:7051", peerCfg.Address)
+	assert.False(t, peerCfg.TlsEnabled)
+	assert.Empty(t, peerCfg.TlsRootCertFile)
+}
+
+func TestIssueMissingParams(t *testing.T) {
+	resetFlags()
+	cf, _, _ := newMockCF(t)
+
+	cmd := issueCmd(cf)
+	cmd.SetArgs([]string{"-t", "USD", "-q", "100"})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	resetFlags()
+	cmd = issueCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-q", "100"})
+	assert.EqualError(t, cmd.Execute(), "must supply token type")
+
+	resetFlags()
+	cmd = issueCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-t", "USD"})
+	assert.EqualError(t, cmd.Execute(), "must supply a quantity greater than 0")
+}
+
+func TestIssueProverError(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+	errResponse, err := proto.Marshal(&token.CommandResponse{
+		Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "no way"}},
+	})
+	assert.NoError(t, err)
+	prover.RequestImportReturns(errResponse, nil)
+
+	cmd := issueCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-t", "USD", "-q", "100"})
+	assert.EqualError(t, cmd.Execute(), "prover returned error: no way")
+	assert.Equal(t, 0, submitter.CreateTxEnvelopeCallCount())
+
+	resetFlags()
+	prover.RequestImportReturns(nil, errors.New("connection refused"))
+	cmd = issueCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-t", "USD", "-q", "100"})
+	assert.EqualError(t, cmd.Execute(), "failed to request import: connection refused")
+}
+
+func TestTransfer(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+
+	dir, err := ioutil.TempDir("", "peer-token")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certPath := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(certPath, []byte("certificate"), 0644))
+	recipient, err := proto.Marshal(&pmsp.SerializedIdentity{Mspid: "Org2MSP", IdBytes: []byte("certificate")})
+	assert.NoError(t, err)
+
+	cmd := transferCmd(cf)
+	cmd.SetArgs([]string{
+		"-c", "mychannel",
+		"-i", hex.EncodeToString([]byte("id1")) + "," + hex.EncodeToString([]byte("id2")),
+		"-s", "Org2MSP:" + certPath + "=70,=30",
+		"--waitForEvent",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Equal(t, 1, prover.RequestTransferCallCount())
+	ids, transferShares, _ := prover.RequestTransferArgsForCall(0)
+	assert.Equal(t, [][]byte{[]byte("id1"), []byte("id2")}, ids)
+	assert.Equal(t, []*token.RecipientTransferShare{
+		{Recipient: recipient, Quantity: 70},
+		{Recipient: []byte("creator"), Quantity: 30},
+	}, transferShares)
+	_, waitTime := submitter.SubmitTransactionArgsForCall(0)
+	assert.Equal(t, 30, waitTime)
+}
+
+func TestTransferInvalidParams(t *testing.T) {
+	resetFlags()
+	cf, _, _ := newMockCF(t)
+
+	cmd := transferCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "zz", "-s", "=10"})
+	assert.Contains(t, cmd.Execute().Error(), "invalid token ID 'zz'")
+
+	resetFlags()
+	cmd = transferCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-s", "10"})
+	assert.EqualError(t, cmd.Execute(), "invalid share '10', expected <recipient>=<quantity>")
+
+	resetFlags()
+	cmd = transferCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-s", "Org2MSP=10"})
+	assert.EqualError(t, cmd.Execute(), "invalid recipient 'Org2MSP', expected <mspID>:<path to PEM certificate>")
+}
+
+func TestTransferNotCommitted(t *testing.T) {
+	resetFlags()
+	cf, _, submitter := newMockCF(t)
+	submitter.SubmitTransactionReturns(false, "txid", nil)
+
+	cmd := transferCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-s", "=10", "--waitForEvent", "--waitForEventTimeout", "5s"})
+	assert.EqualError(t, cmd.Execute(), "transaction txid was not committed within 5s")
+}
+
+func TestRedeem(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+
+	cmd := redeemCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-q", "50"})
+	assert.NoError(t, cmd.Execute())
+
+	ids, q, _ := prover.RequestRedeemArgsForCall(0)
+	assert.Equal(t, [][]byte{{0x0a}}, ids)
+	assert.Equal(t, uint64(50), q)
+	assert.Equal(t, 1, submitter.SubmitTransactionCallCount())
+}
+
+func TestApprove(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+
+	cmd := approveCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-s", "=50"})
+	assert.NoError(t, cmd.Execute())
+
+	_, allowanceShares, _ := prover.RequestApproveArgsForCall(0)
+	assert.Equal(t, []*token.AllowanceRecipientShare{{Recipient: []byte("creator"), Quantity: 50}}, allowanceShares)
+	assert.Equal(t, 1, submitter.SubmitTransactionCallCount())
+}
+
+func TestTransferFrom(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+
+	cmd := transferFromCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-i", "0a", "-s", "=50"})
+	assert.NoError(t, cmd.Execute())
+
+	ids, transferShares, _ := prover.RequestTransferFromArgsForCall(0)
+	assert.Equal(t, [][]byte{{0x0a}}, ids)
+	assert.Equal(t, []*token.RecipientTransferShare{{Recipient: []byte("creator"), Quantity: 50}}, transferShares)
+	assert.Equal(t, 1, submitter.SubmitTransactionCallCount())
+}
+
+func TestList(t *testing.T) {
+	resetFlags()
+	cf, prover, submitter := newMockCF(t)
+	unspent, err := proto.Marshal(&token.CommandResponse{
+		Payload: &token.CommandResponse_UnspentTokens{UnspentTokens: &token.UnspentTokens{
+			Tokens: []*token.TokenOutput{{Id: []byte("id1"), Type: "USD", Quantity: 10}},
+		}},
+	})
+	assert.NoError(t, err)
+	prover.ListTokensReturns(unspent, nil)
+
+	cmd := listCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, 1, prover.ListTokensCallCount())
+	assert.Equal(t, 0, submitter.CreateTxEnvelopeCallCount())
+
+	resetFlags()
+	prover.ListTokensReturns([]byte("garbage"), nil)
+	cmd = listCmd(cf)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.Contains(t, cmd.Execute().Error(), "failed to unmarshal prover response")
+}
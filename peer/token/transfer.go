@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+func transferCmd(cf *TokenCmdFactory) *cobra.Command {
+	transferCmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Transfer tokens.",
+		Long:  "Transfer the given tokens to one or more recipients. Requires '-c', '-i' and '-s'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transfer(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(transferCmd, flagList)
+
+	return transferCmd
+}
+
+func transfer(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	ids, err := parseTokenIDs(tokenIDs)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseShares(shares, cf.Signer)
+	if err != nil {
+		return err
+	}
+	var transferShares []*token.RecipientTransferShare
+	for _, s := range parsed {
+		transferShares = append(transferShares, &token.RecipientTransferShare{Recipient: s.recipient, Quantity: s.quantity})
+	}
+
+	resp, err := cf.Prover.RequestTransfer(ids, transferShares, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to request transfer")
+	}
+
+	return submit(cf, resp)
+}
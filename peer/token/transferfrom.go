@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+func transferFromCmd(cf *TokenCmdFactory) *cobra.Command {
+	transferFromCmd := &cobra.Command{
+		Use:   "transferFrom",
+		Short: "Transfer delegated tokens.",
+		Long:  "Transfer tokens delegated to the caller by an approve transaction to one or more recipients. Requires '-c', '-i' and '-s'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transferFrom(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(transferFromCmd, flagList)
+
+	return transferFromCmd
+}
+
+func transferFrom(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	ids, err := parseTokenIDs(tokenIDs)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseShares(shares, cf.Signer)
+	if err != nil {
+		return err
+	}
+	var transferShares []*token.RecipientTransferShare
+	for _, s := range parsed {
+		transferShares = append(transferShares, &token.RecipientTransferShare{Recipient: s.recipient, Quantity: s.quantity})
+	}
+
+	resp, err := cf.Prover.RequestTransferFrom(ids, transferShares, cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to request transferFrom")
+	}
+
+	return submit(cf, resp)
+}
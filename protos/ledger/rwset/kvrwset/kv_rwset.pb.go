@@ -34,7 +34,7 @@ func (m *KVRWSet) Reset()         { *m = KVRWSet{} }
 func (m *KVRWSet) String() string { return proto.CompactTextString(m) }
 func (*KVRWSet) ProtoMessage()    {}
 func (*KVRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{0}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{0}
 }
 func (m *KVRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRWSet.Unmarshal(m, b)
@@ -96,7 +96,7 @@ func (m *HashedRWSet) Reset()         { *m = HashedRWSet{} }
 func (m *HashedRWSet) String() string { return proto.CompactTextString(m) }
 func (*HashedRWSet) ProtoMessage()    {}
 func (*HashedRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{1}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{1}
 }
 func (m *HashedRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HashedRWSet.Unmarshal(m, b)
@@ -151,7 +151,7 @@ func (m *KVRead) Reset()         { *m = KVRead{} }
 func (m *KVRead) String() string { return proto.CompactTextString(m) }
 func (*KVRead) ProtoMessage()    {}
 func (*KVRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{2}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{2}
 }
 func (m *KVRead) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRead.Unmarshal(m, b)
@@ -199,7 +199,7 @@ func (m *KVWrite) Reset()         { *m = KVWrite{} }
 func (m *KVWrite) String() string { return proto.CompactTextString(m) }
 func (*KVWrite) ProtoMessage()    {}
 func (*KVWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{3}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{3}
 }
 func (m *KVWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWrite.Unmarshal(m, b)
@@ -253,7 +253,7 @@ func (m *KVMetadataWrite) Reset()         { *m = KVMetadataWrite{} }
 func (m *KVMetadataWrite) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWrite) ProtoMessage()    {}
 func (*KVMetadataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{4}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{4}
 }
 func (m *KVMetadataWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWrite.Unmarshal(m, b)
@@ -302,7 +302,7 @@ func (m *KVReadHash) Reset()         { *m = KVReadHash{} }
 func (m *KVReadHash) String() string { return proto.CompactTextString(m) }
 func (*KVReadHash) ProtoMessage()    {}
 func (*KVReadHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{5}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{5}
 }
 func (m *KVReadHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVReadHash.Unmarshal(m, b)
@@ -341,6 +341,7 @@ type KVWriteHash struct {
 	KeyHash              []byte   `protobuf:"bytes,1,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
 	IsDelete             bool     `protobuf:"varint,2,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
 	ValueHash            []byte   `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	IsPurge              bool     `protobuf:"varint,4,opt,name=is_purge,json=isPurge,proto3" json:"is_purge,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -350,7 +351,7 @@ func (m *KVWriteHash) Reset()         { *m = KVWriteHash{} }
 func (m *KVWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVWriteHash) ProtoMessage()    {}
 func (*KVWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{6}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{6}
 }
 func (m *KVWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWriteHash.Unmarshal(m, b)
@@ -391,6 +392,13 @@ func (m *KVWriteHash) GetValueHash() []byte {
 	return nil
 }
 
+func (m *KVWriteHash) GetIsPurge() bool {
+	if m != nil {
+		return m.IsPurge
+	}
+	return false
+}
+
 // KVMetadataWriteHash captures all the upserts to the metadata associated with a key hash
 type KVMetadataWriteHash struct {
 	KeyHash              []byte             `protobuf:"bytes,1,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
@@ -404,7 +412,7 @@ func (m *KVMetadataWriteHash) Reset()         { *m = KVMetadataWriteHash{} }
 func (m *KVMetadataWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWriteHash) ProtoMessage()    {}
 func (*KVMetadataWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{7}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{7}
 }
 func (m *KVMetadataWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWriteHash.Unmarshal(m, b)
@@ -451,7 +459,7 @@ func (m *KVMetadataEntry) Reset()         { *m = KVMetadataEntry{} }
 func (m *KVMetadataEntry) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataEntry) ProtoMessage()    {}
 func (*KVMetadataEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{8}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{8}
 }
 func (m *KVMetadataEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataEntry.Unmarshal(m, b)
@@ -501,7 +509,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{9}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{9}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Version.Unmarshal(m, b)
@@ -558,7 +566,7 @@ func (m *RangeQueryInfo) Reset()         { *m = RangeQueryInfo{} }
 func (m *RangeQueryInfo) String() string { return proto.CompactTextString(m) }
 func (*RangeQueryInfo) ProtoMessage()    {}
 func (*RangeQueryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{10}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{10}
 }
 func (m *RangeQueryInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RangeQueryInfo.Unmarshal(m, b)
@@ -722,7 +730,7 @@ func (m *QueryReads) Reset()         { *m = QueryReads{} }
 func (m *QueryReads) String() string { return proto.CompactTextString(m) }
 func (*QueryReads) ProtoMessage()    {}
 func (*QueryReads) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{11}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{11}
 }
 func (m *QueryReads) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReads.Unmarshal(m, b)
@@ -767,7 +775,7 @@ func (m *QueryReadsMerkleSummary) Reset()         { *m = QueryReadsMerkleSummary
 func (m *QueryReadsMerkleSummary) String() string { return proto.CompactTextString(m) }
 func (*QueryReadsMerkleSummary) ProtoMessage()    {}
 func (*QueryReadsMerkleSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{12}
+	return fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4, []int{12}
 }
 func (m *QueryReadsMerkleSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReadsMerkleSummary.Unmarshal(m, b)
@@ -825,56 +833,57 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_b744a14a894993b5)
-}
-
-var fileDescriptor_kv_rwset_b744a14a894993b5 = []byte{
-	// 740 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdf, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0x7f, 0xcb, 0x63, 0x3b, 0xf1, 0x6d, 0x72, 0x44, 0xc7, 0xdd, 0x81, 0x51, 0x38, 0x30,
-	0x79, 0xb0, 0xc1, 0x07, 0xc7, 0x85, 0xe3, 0x1e, 0x5a, 0xe2, 0x92, 0x92, 0x26, 0xd0, 0x0d, 0x24,
-	0xd0, 0x17, 0xb1, 0x8e, 0x26, 0xb6, 0xb0, 0x25, 0xa5, 0xab, 0x95, 0x6d, 0x3d, 0x95, 0xfe, 0x75,
-	0xfd, 0x47, 0xfa, 0x87, 0x94, 0x9d, 0x95, 0x63, 0xc5, 0x75, 0x0c, 0xed, 0x93, 0xb5, 0xf3, 0xcd,
-	0x37, 0x3b, 0xdf, 0x37, 0xde, 0x5d, 0x38, 0x99, 0xa1, 0x37, 0x46, 0xd9, 0x97, 0x8b, 0x18, 0x55,
-	0x7f, 0x3a, 0x5f, 0xfd, 0xba, 0xf4, 0xd1, 0x7b, 0x94, 0x91, 0x8a, 0x58, 0x2d, 0x8b, 0x3b, 0x5f,
-	0x0b, 0x50, 0xbb, 0xbc, 0xe5, 0x77, 0x37, 0xa8, 0xd8, 0x5f, 0x50, 0x91, 0x28, 0xbc, 0xd8, 0x2e,
-	0x74, 0x4a, 0xdd, 0xc6, 0xe0, 0xa0, 0x97, 0x25, 0xf5, 0x2e, 0x6f, 0x39, 0x0a, 0x8f, 0x1b, 0x94,
-	0x0d, 0x81, 0x49, 0x11, 0x8e, 0xd1, 0xfd, 0x98, 0xa0, 0xf4, 0x31, 0x76, 0xfd, 0xf0, 0x21, 0xb2,
-	0x8b, 0xc4, 0x39, 0x7e, 0xe2, 0x70, 0x9d, 0xf2, 0x3e, 0x41, 0x99, 0xbe, 0x0d, 0x1f, 0x22, 0xde,
-	0x96, 0xab, 0xb5, 0x8f, 0xb1, 0x8e, 0xb0, 0x2e, 0x54, 0x17, 0xd2, 0x57, 0x18, 0xdb, 0x25, 0xa2,
-	0xb6, 0x73, 0xdb, 0xdd, 0x69, 0x80, 0x67, 0x38, 0x7b, 0x05, 0x07, 0x01, 0x2a, 0xe1, 0x09, 0x25,
-	0xdc, 0x8c, 0x52, 0x26, 0x8a, 0x9d, 0xa3, 0x5c, 0x65, 0x19, 0x86, 0xba, 0x1f, 0xe4, 0x97, 0xb1,
-	0xf3, 0xa5, 0x00, 0x8d, 0x0b, 0x11, 0x4f, 0xd0, 0x33, 0x52, 0xff, 0x81, 0xe6, 0x84, 0x96, 0x6e,
-	0x5e, 0xf1, 0xe1, 0x86, 0x62, 0xcd, 0xe0, 0x0d, 0x93, 0xc8, 0x49, 0xfb, 0x19, 0xb4, 0x32, 0x5e,
-	0xd6, 0x88, 0x91, 0x7d, 0xb4, 0xd9, 0x3b, 0x31, 0xb3, 0x2d, 0x4c, 0x0b, 0x6c, 0xf8, 0xbd, 0x0a,
-	0x23, 0xfc, 0x8f, 0x97, 0x54, 0x50, 0x91, 0x4d, 0x25, 0x6f, 0xa0, 0x6a, 0x9a, 0x63, 0x6d, 0x28,
-	0x4d, 0x31, 0xb5, 0x0b, 0x9d, 0x42, 0xb7, 0xce, 0xf5, 0x27, 0x3b, 0x85, 0xda, 0x1c, 0x65, 0xec,
-	0x47, 0xa1, 0x5d, 0xec, 0x14, 0x9e, 0x79, 0x7a, 0x6b, 0xe2, 0x7c, 0x95, 0xe0, 0x5c, 0xeb, 0xb9,
-	0x53, 0xcd, 0x2d, 0x85, 0x7e, 0x87, 0xba, 0x1f, 0xbb, 0x1e, 0xce, 0x50, 0x21, 0x95, 0xb2, 0xb8,
-	0xe5, 0xc7, 0xe7, 0xb4, 0x66, 0x47, 0x50, 0x99, 0x8b, 0x59, 0x82, 0x76, 0xa9, 0x53, 0xe8, 0x36,
-	0xb9, 0x59, 0x38, 0x77, 0x70, 0xb0, 0xd1, 0xfe, 0x96, 0xba, 0x03, 0xa8, 0x61, 0xa8, 0xf4, 0x5f,
-	0x20, 0x33, 0x6e, 0xdb, 0x04, 0x87, 0xa1, 0x92, 0x29, 0x5f, 0x25, 0x3a, 0x37, 0x00, 0xeb, 0x69,
-	0xb0, 0xdf, 0xc0, 0x9a, 0x62, 0xea, 0x6a, 0x67, 0xa9, 0x70, 0x93, 0xd7, 0xa6, 0x98, 0x12, 0xf4,
-	0x23, 0xea, 0x3d, 0x68, 0xe4, 0x26, 0xb5, 0xab, 0xea, 0x4e, 0x2b, 0xfe, 0x04, 0x20, 0xf5, 0x86,
-	0x69, 0xfc, 0xa8, 0x53, 0x44, 0x73, 0x1d, 0x0f, 0x0e, 0xb7, 0x8c, 0x74, 0xd7, 0x6e, 0x3f, 0x63,
-	0xd0, 0x7f, 0x79, 0xe7, 0x09, 0x63, 0x0c, 0xca, 0xa1, 0x08, 0x30, 0xb3, 0x9e, 0xbe, 0xd7, 0x63,
-	0x2b, 0xe6, 0xc7, 0xf6, 0x3f, 0xd4, 0x32, 0x73, 0xb4, 0xd2, 0xd1, 0x2c, 0xba, 0x9f, 0xba, 0x61,
-	0x12, 0x10, 0xb3, 0xcc, 0x2d, 0x0a, 0x5c, 0x27, 0x01, 0xfb, 0x15, 0xaa, 0x6a, 0x49, 0x48, 0x91,
-	0x90, 0x8a, 0x5a, 0x5e, 0x27, 0x81, 0xf3, 0xb9, 0x08, 0xfb, 0xcf, 0x4f, 0xba, 0x2e, 0x13, 0x2b,
-	0x21, 0x95, 0xbb, 0x9e, 0xbd, 0x45, 0x81, 0x4b, 0x4c, 0xd9, 0xb1, 0xd6, 0xe7, 0x11, 0x54, 0x24,
-	0xa8, 0x8a, 0xa1, 0xa7, 0x81, 0x13, 0x68, 0xf9, 0x4a, 0xba, 0xb8, 0x9c, 0x88, 0x24, 0x56, 0xe8,
-	0x91, 0x99, 0x16, 0x6f, 0xfa, 0x4a, 0x0e, 0x57, 0x31, 0x36, 0x80, 0xba, 0x14, 0x8b, 0xec, 0xc8,
-	0x96, 0x69, 0xc6, 0xeb, 0x23, 0x4b, 0x1d, 0xd0, 0x29, 0xbd, 0xd8, 0xe3, 0x96, 0x14, 0x0b, 0x73,
-	0x62, 0x39, 0x1c, 0x52, 0xbe, 0x1b, 0xa0, 0x9c, 0xce, 0xcc, 0xa4, 0x30, 0xb6, 0x2b, 0xc4, 0xee,
-	0x6c, 0x61, 0x5f, 0x51, 0xde, 0x4d, 0x12, 0x04, 0x42, 0xa6, 0x17, 0x7b, 0xfc, 0x17, 0xb9, 0x8e,
-	0xd2, 0x15, 0x12, 0xbf, 0x6e, 0x02, 0x98, 0x9a, 0xfa, 0xe6, 0x73, 0xfe, 0x05, 0x58, 0xb3, 0xd9,
-	0x29, 0x58, 0xfa, 0xae, 0xdd, 0x75, 0x8f, 0xd6, 0xa6, 0x73, 0xca, 0x75, 0x3e, 0xc1, 0xf1, 0x0b,
-	0xfb, 0xea, 0x7f, 0x56, 0x20, 0x96, 0xae, 0x87, 0x63, 0x89, 0x66, 0x8e, 0x2d, 0x5e, 0x0f, 0xc4,
-	0xf2, 0x9c, 0x02, 0xda, 0x64, 0x0d, 0xcf, 0x70, 0x8e, 0x33, 0x72, 0xb2, 0xc5, 0xad, 0x40, 0x2c,
-	0xdf, 0xe9, 0x35, 0xeb, 0x42, 0xfb, 0x09, 0x5c, 0xe9, 0xd5, 0x57, 0x4d, 0x93, 0xef, 0xaf, 0x72,
-	0x32, 0x21, 0x11, 0x0c, 0x22, 0x39, 0xee, 0x4d, 0xd2, 0x47, 0x94, 0xe6, 0xd9, 0xe8, 0x3d, 0x88,
-	0x91, 0xf4, 0xef, 0xcd, 0x33, 0x11, 0xf7, 0xb2, 0xa0, 0x69, 0x3f, 0x93, 0xf1, 0xe1, 0x6c, 0xec,
-	0xab, 0x49, 0x32, 0xea, 0xdd, 0x47, 0x41, 0x3f, 0x47, 0xed, 0x1b, 0x6a, 0xdf, 0x50, 0xfb, 0xdb,
-	0x9e, 0xa1, 0x51, 0x95, 0xc0, 0xbf, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xe1, 0xb5, 0x07,
-	0xa5, 0x06, 0x00, 0x00,
+	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4)
+}
+
+var fileDescriptor_kv_rwset_b2ec7ab6e0d3eea4 = []byte{
+	// 761 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5f, 0x6b, 0xe3, 0x46,
+	0x10, 0x3f, 0x39, 0x8e, 0x25, 0x4f, 0x9c, 0xc4, 0xdd, 0x5c, 0x89, 0x4a, 0x5b, 0x30, 0x3a, 0x0a,
+	0xe6, 0xa0, 0x36, 0xb8, 0x50, 0x5a, 0xda, 0x3e, 0xb4, 0x9c, 0x4b, 0x4a, 0x7a, 0xa1, 0xdd, 0x40,
+	0x02, 0x7d, 0x11, 0x6b, 0x6b, 0x62, 0x0b, 0xeb, 0x4f, 0xba, 0xbb, 0xb2, 0xad, 0xa7, 0xa3, 0x9f,
+	0xae, 0x5f, 0xa4, 0x1f, 0xa4, 0xec, 0xac, 0x14, 0x3b, 0xae, 0xcf, 0xd0, 0x7b, 0xf2, 0xce, 0xfc,
+	0xe6, 0x37, 0x9a, 0xdf, 0x8c, 0x77, 0x16, 0x5e, 0x25, 0x18, 0xcd, 0x50, 0x0e, 0xe5, 0x4a, 0xa1,
+	0x1e, 0x2e, 0x96, 0xf5, 0x6f, 0x48, 0x87, 0xc1, 0xa3, 0xcc, 0x75, 0xce, 0xdc, 0xca, 0x1f, 0xfc,
+	0xe3, 0x80, 0x7b, 0x7d, 0xc7, 0xef, 0x6f, 0x51, 0xb3, 0x2f, 0xe0, 0x58, 0xa2, 0x88, 0x94, 0xef,
+	0xf4, 0x8e, 0xfa, 0x27, 0xa3, 0xf3, 0x41, 0x15, 0x34, 0xb8, 0xbe, 0xe3, 0x28, 0x22, 0x6e, 0x51,
+	0x36, 0x06, 0x26, 0x45, 0x36, 0xc3, 0xf0, 0xcf, 0x02, 0x65, 0x8c, 0x2a, 0x8c, 0xb3, 0x87, 0xdc,
+	0x6f, 0x10, 0xe7, 0xf2, 0x89, 0xc3, 0x4d, 0xc8, 0xef, 0x05, 0xca, 0xf2, 0x97, 0xec, 0x21, 0xe7,
+	0x5d, 0x59, 0xdb, 0x31, 0x2a, 0xe3, 0x61, 0x7d, 0x68, 0xad, 0x64, 0xac, 0x51, 0xf9, 0x47, 0x44,
+	0xed, 0x6e, 0x7d, 0xee, 0xde, 0x00, 0xbc, 0xc2, 0xd9, 0x8f, 0x70, 0x9e, 0xa2, 0x16, 0x91, 0xd0,
+	0x22, 0xac, 0x28, 0x4d, 0xa2, 0xf8, 0x5b, 0x94, 0xb7, 0x55, 0x84, 0xa5, 0x9e, 0xa5, 0xdb, 0xa6,
+	0x0a, 0xfe, 0x76, 0xe0, 0xe4, 0x4a, 0xa8, 0x39, 0x46, 0x56, 0xea, 0xd7, 0xd0, 0x99, 0x93, 0x19,
+	0x6e, 0x2b, 0xbe, 0xd8, 0x51, 0x6c, 0x18, 0xfc, 0xc4, 0x06, 0x72, 0xd2, 0xfe, 0x2d, 0x9c, 0x56,
+	0xbc, 0xaa, 0x10, 0x2b, 0xfb, 0xe5, 0x6e, 0xed, 0xc4, 0xac, 0x3e, 0x61, 0x4b, 0x60, 0xe3, 0xff,
+	0xaa, 0xb0, 0xc2, 0x3f, 0x7b, 0x9f, 0x0a, 0x4a, 0xb2, 0xab, 0xe4, 0x67, 0x68, 0xd9, 0xe2, 0x58,
+	0x17, 0x8e, 0x16, 0x58, 0xfa, 0x4e, 0xcf, 0xe9, 0xb7, 0xb9, 0x39, 0xb2, 0xd7, 0xe0, 0x2e, 0x51,
+	0xaa, 0x38, 0xcf, 0xfc, 0x46, 0xcf, 0x79, 0xd6, 0xd3, 0x3b, 0xeb, 0xe7, 0x75, 0x40, 0x70, 0x63,
+	0xe6, 0x4e, 0x39, 0xf7, 0x24, 0xfa, 0x14, 0xda, 0xb1, 0x0a, 0x23, 0x4c, 0x50, 0x23, 0xa5, 0xf2,
+	0xb8, 0x17, 0xab, 0x37, 0x64, 0xb3, 0x97, 0x70, 0xbc, 0x14, 0x49, 0x81, 0xfe, 0x51, 0xcf, 0xe9,
+	0x77, 0xb8, 0x35, 0x82, 0x7b, 0x38, 0xdf, 0x29, 0x7f, 0x4f, 0xde, 0x11, 0xb8, 0x98, 0x69, 0x19,
+	0x3f, 0x35, 0x6e, 0xdf, 0x04, 0xc7, 0x99, 0x96, 0x25, 0xaf, 0x03, 0x83, 0x5b, 0x80, 0xcd, 0x34,
+	0xd8, 0x27, 0xe0, 0x2d, 0xb0, 0x0c, 0x4d, 0x67, 0x29, 0x71, 0x87, 0xbb, 0x0b, 0x2c, 0x09, 0xfa,
+	0x3f, 0xea, 0xdf, 0xc1, 0xc9, 0xd6, 0xa4, 0x0e, 0x65, 0x3d, 0xd8, 0x8a, 0xcf, 0x01, 0x48, 0xbd,
+	0x65, 0xda, 0x7e, 0xb4, 0xc9, 0x53, 0xa7, 0x8d, 0x55, 0xf8, 0x58, 0xc8, 0x19, 0xfa, 0x4d, 0xa2,
+	0xba, 0xb1, 0xfa, 0xcd, 0x98, 0x41, 0x04, 0x17, 0x7b, 0xa6, 0x7d, 0xa8, 0x90, 0x0f, 0xe9, 0xdd,
+	0x77, 0x70, 0xbe, 0x83, 0x31, 0x06, 0xcd, 0x4c, 0xa4, 0x58, 0x4d, 0x85, 0xce, 0x9b, 0x89, 0x36,
+	0xb6, 0x27, 0xfa, 0x03, 0xb8, 0x55, 0xdf, 0x4c, 0x13, 0x26, 0x49, 0x3e, 0x5d, 0x84, 0x59, 0x91,
+	0x12, 0xb3, 0xc9, 0x3d, 0x72, 0xdc, 0x14, 0x29, 0xfb, 0x18, 0x5a, 0x7a, 0x4d, 0x48, 0x83, 0x90,
+	0x63, 0xbd, 0xbe, 0x29, 0xd2, 0xe0, 0xaf, 0x06, 0x9c, 0x3d, 0x5f, 0x02, 0x26, 0x8d, 0xd2, 0x42,
+	0xea, 0x70, 0xf3, 0xb7, 0xf0, 0xc8, 0x71, 0x8d, 0x25, 0xbb, 0x34, 0xfa, 0x22, 0x82, 0x1a, 0x04,
+	0xb5, 0x30, 0x8b, 0x0c, 0xf0, 0x0a, 0x4e, 0x63, 0x2d, 0x43, 0x5c, 0xcf, 0x45, 0xa1, 0x34, 0x46,
+	0xd4, 0x67, 0x8f, 0x77, 0x62, 0x2d, 0xc7, 0xb5, 0x8f, 0x8d, 0xa0, 0x2d, 0xc5, 0xaa, 0xba, 0xcd,
+	0xcd, 0x9e, 0xf3, 0xec, 0x36, 0x53, 0x05, 0x74, 0x81, 0xaf, 0x5e, 0x70, 0x4f, 0x8a, 0x15, 0x9d,
+	0x19, 0x87, 0x0b, 0x8a, 0x0f, 0x53, 0x94, 0x8b, 0xc4, 0x0e, 0x11, 0x95, 0x7f, 0x4c, 0xec, 0xde,
+	0x1e, 0xf6, 0x5b, 0x8a, 0xbb, 0x2d, 0xd2, 0x54, 0xc8, 0xf2, 0xea, 0x05, 0xff, 0x48, 0x6e, 0xbc,
+	0xb4, 0x5d, 0xd4, 0x4f, 0x1d, 0x00, 0x9b, 0xd3, 0x2c, 0xc5, 0xe0, 0x1b, 0x80, 0x0d, 0x9b, 0xbd,
+	0x06, 0xcf, 0xac, 0xe1, 0x43, 0x2b, 0xd6, 0x5d, 0x2c, 0x29, 0x36, 0x78, 0x07, 0x97, 0xef, 0xf9,
+	0xae, 0xf9, 0xd3, 0xa5, 0x62, 0x1d, 0x46, 0x38, 0x93, 0x68, 0xe7, 0x78, 0xca, 0xdb, 0xa9, 0x58,
+	0xbf, 0x21, 0x87, 0x69, 0xb2, 0x81, 0x13, 0x5c, 0x62, 0x42, 0x9d, 0x3c, 0xe5, 0x5e, 0x2a, 0xd6,
+	0xbf, 0x1a, 0x9b, 0xf5, 0xa1, 0xfb, 0x04, 0xd6, 0x7a, 0xcd, 0x16, 0xea, 0xf0, 0xb3, 0x3a, 0xa6,
+	0x12, 0x22, 0x61, 0x94, 0xcb, 0xd9, 0x60, 0x5e, 0x3e, 0xa2, 0xb4, 0x2f, 0xca, 0xe0, 0x41, 0x4c,
+	0x64, 0x3c, 0xb5, 0x2f, 0x88, 0x1a, 0x54, 0x4e, 0x5b, 0x7e, 0x25, 0xe3, 0x8f, 0xef, 0x67, 0xb1,
+	0x9e, 0x17, 0x93, 0xc1, 0x34, 0x4f, 0x87, 0x5a, 0x8a, 0x08, 0x93, 0x38, 0xc3, 0x2f, 0x35, 0x4e,
+	0xe7, 0x43, 0xcb, 0x1e, 0x5a, 0xf6, 0x70, 0xdf, 0x23, 0x35, 0x69, 0x11, 0xf8, 0xd5, 0xbf, 0x03,
+	0x00, 0x0f, 0xaf, 0x57, 0x86, 0xc3, 0x06, 0x00, 0x00,
 }
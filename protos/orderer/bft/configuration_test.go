@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/orderer/bft"
+)
+
+func TestMarshal(t *testing.T) {
+	md := &bft.ConfigMetadata{
+		Consenters: []*bft.Consenter{
+			{
+				Id:            1,
+				Host:          "node-1.example.com",
+				Port:          7050,
+				MspId:         "OrdererOrg1",
+				Identity:      []byte("testdata/tls-server-1.pem"),
+				ClientTlsCert: []byte("testdata/tls-client-1.pem"),
+				ServerTlsCert: []byte("testdata/tls-server-1.pem"),
+			},
+			{
+				Id:            2,
+				Host:          "node-2.example.com",
+				Port:          7050,
+				MspId:         "OrdererOrg2",
+				Identity:      []byte("testdata/tls-server-2.pem"),
+				ClientTlsCert: []byte("testdata/tls-client-2.pem"),
+				ServerTlsCert: []byte("testdata/tls-server-2.pem"),
+			},
+		},
+	}
+	packed, err := bft.Marshal(md)
+	require.NoError(t, err, "marshalling should succeed")
+	require.Equal(t, []byte("testdata/tls-server-1.pem"), md.Consenters[0].Identity, "the input should not be mutated")
+
+	unpacked := &bft.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(packed, unpacked), "unmarshalling should succeed")
+
+	for i, c := range unpacked.Consenters {
+		cert, err := ioutil.ReadFile(string(md.Consenters[i].Identity))
+		require.NoError(t, err)
+		require.Equal(t, cert, c.ServerTlsCert)
+
+		identity := &msp.SerializedIdentity{}
+		require.NoError(t, proto.Unmarshal(c.Identity, identity))
+		require.Equal(t, md.Consenters[i].MspId, identity.Mspid)
+		require.Equal(t, cert, identity.IdBytes)
+	}
+
+	md.Consenters[1].Identity = []byte("testdata/missing.pem")
+	_, err = bft.Marshal(md)
+	require.EqualError(t, err, "cannot load identity for consenter node-2.example.com:7050: open testdata/missing.pem: no such file or directory")
+}
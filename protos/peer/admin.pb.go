@@ -57,7 +57,7 @@ func (x ServerStatus_StatusCode) String() string {
 	return proto.EnumName(ServerStatus_StatusCode_name, int32(x))
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0, 0}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{0, 0}
 }
 
 type ServerStatus struct {
@@ -71,7 +71,7 @@ func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
 func (m *ServerStatus) String() string { return proto.CompactTextString(m) }
 func (*ServerStatus) ProtoMessage()    {}
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{0}
 }
 func (m *ServerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerStatus.Unmarshal(m, b)
@@ -110,7 +110,7 @@ func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
 func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
 func (*LogLevelRequest) ProtoMessage()    {}
 func (*LogLevelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{1}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{1}
 }
 func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelRequest.Unmarshal(m, b)
@@ -156,7 +156,7 @@ func (m *LogLevelResponse) Reset()         { *m = LogLevelResponse{} }
 func (m *LogLevelResponse) String() string { return proto.CompactTextString(m) }
 func (*LogLevelResponse) ProtoMessage()    {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{2}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{2}
 }
 func (m *LogLevelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelResponse.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *LogSpecRequest) Reset()         { *m = LogSpecRequest{} }
 func (m *LogSpecRequest) String() string { return proto.CompactTextString(m) }
 func (*LogSpecRequest) ProtoMessage()    {}
 func (*LogSpecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{3}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{3}
 }
 func (m *LogSpecRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecRequest.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *LogSpecResponse) Reset()         { *m = LogSpecResponse{} }
 func (m *LogSpecResponse) String() string { return proto.CompactTextString(m) }
 func (*LogSpecResponse) ProtoMessage()    {}
 func (*LogSpecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{4}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{4}
 }
 func (m *LogSpecResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecResponse.Unmarshal(m, b)
@@ -274,10 +274,97 @@ func (m *LogSpecResponse) GetError() string {
 	return ""
 }
 
+// PvtDataPurgeRequest requests to purge the private data of the blocks
+// below the block max_block_num_to_retain from the ledger of a channel
+type PvtDataPurgeRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	MaxBlockNumToRetain  uint64   `protobuf:"varint,2,opt,name=max_block_num_to_retain,json=maxBlockNumToRetain,proto3" json:"max_block_num_to_retain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataPurgeRequest) Reset()         { *m = PvtDataPurgeRequest{} }
+func (m *PvtDataPurgeRequest) String() string { return proto.CompactTextString(m) }
+func (*PvtDataPurgeRequest) ProtoMessage()    {}
+func (*PvtDataPurgeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{5}
+}
+func (m *PvtDataPurgeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataPurgeRequest.Unmarshal(m, b)
+}
+func (m *PvtDataPurgeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataPurgeRequest.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataPurgeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataPurgeRequest.Merge(dst, src)
+}
+func (m *PvtDataPurgeRequest) XXX_Size() int {
+	return xxx_messageInfo_PvtDataPurgeRequest.Size(m)
+}
+func (m *PvtDataPurgeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataPurgeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataPurgeRequest proto.InternalMessageInfo
+
+func (m *PvtDataPurgeRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *PvtDataPurgeRequest) GetMaxBlockNumToRetain() uint64 {
+	if m != nil {
+		return m.MaxBlockNumToRetain
+	}
+	return 0
+}
+
+type PvtDataPurgeResponse struct {
+	MinBlockNum          uint64   `protobuf:"varint,1,opt,name=min_block_num,json=minBlockNum,proto3" json:"min_block_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PvtDataPurgeResponse) Reset()         { *m = PvtDataPurgeResponse{} }
+func (m *PvtDataPurgeResponse) String() string { return proto.CompactTextString(m) }
+func (*PvtDataPurgeResponse) ProtoMessage()    {}
+func (*PvtDataPurgeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{6}
+}
+func (m *PvtDataPurgeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PvtDataPurgeResponse.Unmarshal(m, b)
+}
+func (m *PvtDataPurgeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PvtDataPurgeResponse.Marshal(b, m, deterministic)
+}
+func (dst *PvtDataPurgeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PvtDataPurgeResponse.Merge(dst, src)
+}
+func (m *PvtDataPurgeResponse) XXX_Size() int {
+	return xxx_messageInfo_PvtDataPurgeResponse.Size(m)
+}
+func (m *PvtDataPurgeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PvtDataPurgeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PvtDataPurgeResponse proto.InternalMessageInfo
+
+func (m *PvtDataPurgeResponse) GetMinBlockNum() uint64 {
+	if m != nil {
+		return m.MinBlockNum
+	}
+	return 0
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
 	//	*AdminOperation_LogSpecReq
+	//	*AdminOperation_PvtDataPurgeReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -288,7 +375,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+	return fileDescriptor_admin_ba549566bfd8e0d4, []int{7}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -320,10 +407,16 @@ type AdminOperation_LogSpecReq struct {
 	LogSpecReq *LogSpecRequest `protobuf:"bytes,2,opt,name=logSpecReq,proto3,oneof"`
 }
 
+type AdminOperation_PvtDataPurgeReq struct {
+	PvtDataPurgeReq *PvtDataPurgeRequest `protobuf:"bytes,3,opt,name=pvtDataPurgeReq,proto3,oneof"`
+}
+
 func (*AdminOperation_LogReq) isAdminOperation_Content() {}
 
 func (*AdminOperation_LogSpecReq) isAdminOperation_Content() {}
 
+func (*AdminOperation_PvtDataPurgeReq) isAdminOperation_Content() {}
+
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
 		return m.Content
@@ -345,11 +438,19 @@ func (m *AdminOperation) GetLogSpecReq() *LogSpecRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetPvtDataPurgeReq() *PvtDataPurgeRequest {
+	if x, ok := m.GetContent().(*AdminOperation_PvtDataPurgeReq); ok {
+		return x.PvtDataPurgeReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
 		(*AdminOperation_LogSpecReq)(nil),
+		(*AdminOperation_PvtDataPurgeReq)(nil),
 	}
 }
 
@@ -367,6 +468,11 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogSpecReq); err != nil {
 			return err
 		}
+	case *AdminOperation_PvtDataPurgeReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PvtDataPurgeReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -393,6 +499,14 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogSpecReq{msg}
 		return true, err
+	case 3: // content.pvtDataPurgeReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PvtDataPurgeRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_PvtDataPurgeReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -412,6 +526,11 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_PvtDataPurgeReq:
+		s := proto.Size(x.PvtDataPurgeReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,6 +544,8 @@ func init() {
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*LogSpecRequest)(nil), "protos.LogSpecRequest")
 	proto.RegisterType((*LogSpecResponse)(nil), "protos.LogSpecResponse")
+	proto.RegisterType((*PvtDataPurgeRequest)(nil), "protos.PvtDataPurgeRequest")
+	proto.RegisterType((*PvtDataPurgeResponse)(nil), "protos.PvtDataPurgeResponse")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
@@ -448,6 +569,7 @@ type AdminClient interface {
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
 	SetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
+	PurgePrivateData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataPurgeResponse, error)
 }
 
 type adminClient struct {
@@ -521,6 +643,15 @@ func (c *adminClient) SetLogSpec(ctx context.Context, in *common.Envelope, opts
 	return out, nil
 }
 
+func (c *adminClient) PurgePrivateData(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*PvtDataPurgeResponse, error) {
+	out := new(PvtDataPurgeResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/PurgePrivateData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 type AdminServer interface {
 	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
@@ -530,6 +661,7 @@ type AdminServer interface {
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
 	GetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
 	SetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
+	PurgePrivateData(context.Context, *common.Envelope) (*PvtDataPurgeResponse, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -662,6 +794,24 @@ func _Admin_SetLogSpec_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_PurgePrivateData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PurgePrivateData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/PurgePrivateData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PurgePrivateData(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -694,48 +844,60 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogSpec",
 			Handler:    _Admin_SetLogSpec_Handler,
 		},
+		{
+			MethodName: "PurgePrivateData",
+			Handler:    _Admin_PurgePrivateData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
-func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_b2904393863b6bc5) }
-
-var fileDescriptor_admin_b2904393863b6bc5 = []byte{
-	// 555 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x37, 0xda, 0x92, 0xd3, 0xd1, 0x05, 0x33, 0x6d, 0xa5, 0x13, 0x02, 0xe5, 0x0a, 0x84,
-	0x94, 0x88, 0x22, 0x34, 0x76, 0xc1, 0x45, 0x4b, 0x43, 0x87, 0xe8, 0xd2, 0x2a, 0x59, 0x85, 0x40,
-	0x42, 0x55, 0x9a, 0x9e, 0x79, 0x15, 0x6e, 0x9c, 0x39, 0x6e, 0xa5, 0xbd, 0x01, 0xcf, 0xc1, 0x23,
-	0xf0, 0x84, 0x28, 0x76, 0xc2, 0x2a, 0x56, 0x2e, 0xd8, 0xae, 0xec, 0x73, 0xfc, 0x7d, 0xdf, 0xf9,
-	0xb3, 0x0d, 0x66, 0x82, 0x28, 0x9c, 0x70, 0xb6, 0x98, 0xc7, 0x76, 0x22, 0xb8, 0xe4, 0xa4, 0xaa,
-	0x96, 0xb4, 0x75, 0x48, 0x39, 0xa7, 0x0c, 0x1d, 0x65, 0x4e, 0x97, 0xe7, 0x0e, 0x2e, 0x12, 0x79,
-	0xa5, 0x41, 0xad, 0x47, 0x11, 0x5f, 0x2c, 0x78, 0xec, 0xe8, 0x45, 0x3b, 0xad, 0x9f, 0x65, 0xd8,
-	0x09, 0x50, 0xac, 0x50, 0x04, 0x32, 0x94, 0xcb, 0x94, 0x1c, 0x41, 0x35, 0x55, 0xbb, 0x66, 0xf9,
-	0x59, 0xf9, 0x79, 0xa3, 0xfd, 0x54, 0x03, 0x53, 0x7b, 0x1d, 0x65, 0xeb, 0xe5, 0x3d, 0x9f, 0xa1,
-	0x9f, 0xc3, 0xad, 0x2f, 0x00, 0xd7, 0x5e, 0xf2, 0x00, 0x8c, 0xb1, 0xd7, 0x73, 0x3f, 0x7c, 0xf4,
-	0xdc, 0x9e, 0x59, 0x22, 0x75, 0xa8, 0x05, 0x67, 0x1d, 0xff, 0xcc, 0xed, 0x99, 0x65, 0x6d, 0x0c,
-	0x47, 0x23, 0xb7, 0x67, 0x6e, 0x11, 0x80, 0xea, 0xa8, 0x33, 0x0e, 0xdc, 0x9e, 0xb9, 0x4d, 0x0c,
-	0xa8, 0xb8, 0xbe, 0x3f, 0xf4, 0xcd, 0x7b, 0x19, 0x66, 0xec, 0x7d, 0xf2, 0x86, 0x9f, 0x3d, 0xb3,
-	0x62, 0x9d, 0xc2, 0xee, 0x80, 0xd3, 0x01, 0xae, 0x90, 0xf9, 0x78, 0xb9, 0xc4, 0x54, 0x92, 0x27,
-	0x00, 0x8c, 0xd3, 0xc9, 0x82, 0xcf, 0x96, 0x0c, 0x55, 0xaa, 0x86, 0x6f, 0x30, 0x4e, 0x4f, 0x95,
-	0x83, 0x1c, 0x42, 0x66, 0x4c, 0x58, 0x46, 0x69, 0x6e, 0xa9, 0xd3, 0xfb, 0x2c, 0x97, 0xb0, 0x3c,
-	0x30, 0xaf, 0xe5, 0xd2, 0x84, 0xc7, 0x29, 0xde, 0x49, 0xef, 0x25, 0x34, 0x06, 0x9c, 0x06, 0x09,
-	0x46, 0x45, 0x76, 0x8f, 0x21, 0x3b, 0x9d, 0xa4, 0x09, 0x46, 0xb9, 0x56, 0x8d, 0x69, 0x84, 0xd5,
-	0x55, 0xb5, 0x68, 0x70, 0x1e, 0xfb, 0xdf, 0x68, 0xb2, 0x07, 0x15, 0x14, 0x82, 0x8b, 0x3c, 0xa6,
-	0x36, 0xac, 0x1f, 0x65, 0x68, 0x74, 0xb2, 0xf1, 0x0f, 0x13, 0x14, 0xa1, 0x9c, 0xf3, 0x98, 0xbc,
-	0x82, 0x2a, 0xe3, 0xd4, 0xc7, 0x4b, 0xa5, 0x50, 0x6f, 0x1f, 0x14, 0x63, 0xfb, 0xab, 0x71, 0x27,
-	0x25, 0x3f, 0x07, 0x92, 0xb7, 0xaa, 0xe4, 0x3c, 0x6d, 0x15, 0xa0, 0xde, 0xde, 0x5f, 0xa3, 0xad,
-	0x15, 0x74, 0x52, 0xf2, 0xd7, 0xb0, 0x5d, 0x03, 0x6a, 0x11, 0x8f, 0x25, 0xc6, 0xb2, 0xfd, 0x6b,
-	0x1b, 0x2a, 0x2a, 0x15, 0xf2, 0x06, 0x8c, 0x3e, 0xca, 0xfc, 0x16, 0x99, 0x76, 0x7e, 0xcb, 0xdc,
-	0x78, 0x85, 0x8c, 0x27, 0xd8, 0xda, 0xdb, 0x74, 0x8f, 0xac, 0x12, 0x39, 0x82, 0x7a, 0x20, 0x43,
-	0x21, 0xb5, 0xfb, 0x3f, 0x88, 0x1d, 0x78, 0xd8, 0x47, 0xa9, 0xe7, 0x53, 0x14, 0xb9, 0x81, 0xde,
-	0xbc, 0xd9, 0x08, 0xdd, 0x76, 0x2d, 0x11, 0xdc, 0x51, 0xe2, 0x1d, 0xec, 0xfa, 0xb8, 0x42, 0x21,
-	0x8b, 0xb3, 0x4d, 0xb5, 0xef, 0xdb, 0xfa, 0x5d, 0xda, 0xc5, 0xbb, 0xb4, 0xdd, 0xec, 0x5d, 0x5a,
-	0x25, 0x72, 0x0c, 0xd0, 0x47, 0x99, 0x37, 0x7b, 0x03, 0xf3, 0xe0, 0xc6, 0x3c, 0xfe, 0x44, 0x3e,
-	0x06, 0x08, 0x6e, 0x47, 0xed, 0x7e, 0x03, 0x8b, 0x0b, 0x6a, 0x5f, 0x5c, 0x25, 0x28, 0x18, 0xce,
-	0x28, 0x0a, 0xfb, 0x3c, 0x9c, 0x8a, 0x79, 0x54, 0x50, 0xb2, 0x0f, 0xa6, 0xbb, 0xa3, 0xe6, 0x3a,
-	0x0a, 0xa3, 0xef, 0x21, 0xc5, 0xaf, 0x2f, 0xe8, 0x5c, 0x5e, 0x2c, 0xa7, 0x59, 0x18, 0x67, 0x8d,
-	0xe8, 0x68, 0xa2, 0xfe, 0x71, 0x52, 0x27, 0x23, 0x4e, 0xf5, 0x6f, 0xf4, 0xfa, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x77, 0x16, 0x8f, 0xa8, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_ba549566bfd8e0d4) }
+
+var fileDescriptor_admin_ba549566bfd8e0d4 = []byte{
+	// 687 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x52, 0xd3, 0x40,
+	0x14, 0x6e, 0x81, 0x16, 0x7b, 0xca, 0x4f, 0x5c, 0x18, 0xa8, 0xa0, 0xa3, 0x93, 0x2b, 0x67, 0xd4,
+	0x74, 0x44, 0x1d, 0xc4, 0x19, 0x2f, 0x5a, 0x1b, 0x0b, 0x23, 0xb4, 0x9d, 0x04, 0xc6, 0xd1, 0x9b,
+	0xb8, 0x4d, 0x0f, 0x69, 0x24, 0xc9, 0x86, 0xcd, 0xa6, 0x03, 0xaf, 0xe3, 0x1b, 0xf9, 0x36, 0x5e,
+	0x3a, 0xd9, 0x4d, 0xa4, 0x40, 0xbd, 0x50, 0xae, 0x92, 0x3d, 0xe7, 0xfb, 0xbe, 0xb3, 0xe7, 0x67,
+	0x0f, 0x68, 0x31, 0x22, 0x6f, 0xd2, 0x51, 0xe8, 0x47, 0x46, 0xcc, 0x99, 0x60, 0xa4, 0x2a, 0x3f,
+	0xc9, 0xd6, 0xb6, 0xc7, 0x98, 0x17, 0x60, 0x53, 0x1e, 0x87, 0xe9, 0x69, 0x13, 0xc3, 0x58, 0x5c,
+	0x2a, 0xd0, 0xd6, 0x9a, 0xcb, 0xc2, 0x90, 0x45, 0x4d, 0xf5, 0x51, 0x46, 0xfd, 0x47, 0x19, 0x96,
+	0x6c, 0xe4, 0x13, 0xe4, 0xb6, 0xa0, 0x22, 0x4d, 0xc8, 0x2e, 0x54, 0x13, 0xf9, 0xd7, 0x28, 0x3f,
+	0x29, 0x3f, 0x5d, 0xd9, 0x79, 0xac, 0x80, 0x89, 0x31, 0x8d, 0x32, 0xd4, 0xe7, 0x03, 0x1b, 0xa1,
+	0x95, 0xc3, 0xf5, 0x2f, 0x00, 0x57, 0x56, 0xb2, 0x0c, 0xb5, 0x93, 0x5e, 0xc7, 0xfc, 0x78, 0xd0,
+	0x33, 0x3b, 0x5a, 0x89, 0xd4, 0x61, 0xd1, 0x3e, 0x6e, 0x59, 0xc7, 0x66, 0x47, 0x2b, 0xab, 0x43,
+	0x7f, 0x30, 0x30, 0x3b, 0xda, 0x1c, 0x01, 0xa8, 0x0e, 0x5a, 0x27, 0xb6, 0xd9, 0xd1, 0xe6, 0x49,
+	0x0d, 0x2a, 0xa6, 0x65, 0xf5, 0x2d, 0x6d, 0x21, 0xc3, 0x9c, 0xf4, 0x3e, 0xf5, 0xfa, 0x9f, 0x7b,
+	0x5a, 0x45, 0x3f, 0x82, 0xd5, 0x43, 0xe6, 0x1d, 0xe2, 0x04, 0x03, 0x0b, 0xcf, 0x53, 0x4c, 0x04,
+	0x79, 0x04, 0x10, 0x30, 0xcf, 0x09, 0xd9, 0x28, 0x0d, 0x50, 0x5e, 0xb5, 0x66, 0xd5, 0x02, 0xe6,
+	0x1d, 0x49, 0x03, 0xd9, 0x86, 0xec, 0xe0, 0x04, 0x19, 0xa5, 0x31, 0x27, 0xbd, 0xf7, 0x82, 0x5c,
+	0x42, 0xef, 0x81, 0x76, 0x25, 0x97, 0xc4, 0x2c, 0x4a, 0xf0, 0x4e, 0x7a, 0xcf, 0x60, 0xe5, 0x90,
+	0x79, 0x76, 0x8c, 0x6e, 0x71, 0xbb, 0x07, 0x90, 0x79, 0x9d, 0x24, 0x46, 0x37, 0xd7, 0x5a, 0x0c,
+	0x14, 0x42, 0x6f, 0xcb, 0x5c, 0x14, 0x38, 0x8f, 0xfd, 0x77, 0x34, 0x59, 0x87, 0x0a, 0x72, 0xce,
+	0x78, 0x1e, 0x53, 0x1d, 0xf4, 0xef, 0xb0, 0x36, 0x98, 0x88, 0x0e, 0x15, 0x74, 0x90, 0x72, 0x0f,
+	0xa7, 0x6a, 0xe2, 0x8e, 0x69, 0x14, 0x61, 0xe0, 0xf8, 0xa3, 0x22, 0x87, 0xdc, 0x72, 0x30, 0x22,
+	0xaf, 0x61, 0x33, 0xa4, 0x17, 0xce, 0x30, 0x60, 0xee, 0x99, 0x13, 0xa5, 0xa1, 0x23, 0x98, 0xc3,
+	0x51, 0x50, 0x3f, 0x92, 0xea, 0x0b, 0xd6, 0x5a, 0x48, 0x2f, 0xda, 0x99, 0xb7, 0x97, 0x86, 0xc7,
+	0xcc, 0x92, 0x2e, 0xfd, 0x1d, 0xac, 0x5f, 0x8f, 0x95, 0x5f, 0x5a, 0x87, 0xe5, 0xd0, 0x8f, 0xae,
+	0xd4, 0x64, 0xbc, 0x05, 0xab, 0x1e, 0xfa, 0x51, 0xa1, 0xa1, 0xff, 0x2c, 0xc3, 0x4a, 0x2b, 0x1b,
+	0xd3, 0x7e, 0x8c, 0x9c, 0x0a, 0x9f, 0x45, 0xe4, 0x25, 0x54, 0x03, 0xe6, 0x59, 0x78, 0x2e, 0xf1,
+	0xf5, 0x9d, 0xcd, 0x62, 0xbc, 0x6e, 0x34, 0x78, 0xbf, 0x64, 0xe5, 0x40, 0xf2, 0x16, 0x20, 0x2f,
+	0x47, 0x46, 0x9b, 0x93, 0xb4, 0x8d, 0x29, 0xda, 0x54, 0xe1, 0xf7, 0x4b, 0xd6, 0x14, 0x96, 0x74,
+	0x61, 0x35, 0xbe, 0x5e, 0xa7, 0xc6, 0xbc, 0xa4, 0x6f, 0x17, 0xf4, 0x19, 0x65, 0xdc, 0x2f, 0x59,
+	0x37, 0x59, 0xed, 0x1a, 0x2c, 0xba, 0x2c, 0x12, 0x18, 0x89, 0x9d, 0x5f, 0xf3, 0x50, 0x91, 0x39,
+	0x91, 0x37, 0x50, 0xeb, 0xa2, 0xc8, 0x9f, 0x8d, 0x66, 0xe4, 0xcf, 0xca, 0x8c, 0x26, 0x18, 0xb0,
+	0x18, 0xb7, 0xd6, 0x67, 0x3d, 0x1c, 0xbd, 0x44, 0x76, 0xa1, 0x6e, 0x0b, 0xca, 0x85, 0x32, 0xff,
+	0x03, 0xb1, 0x05, 0xf7, 0xbb, 0x28, 0xd4, 0x40, 0x16, 0xd5, 0x9a, 0x41, 0x6f, 0xdc, 0xae, 0xa8,
+	0x6a, 0x99, 0x92, 0xb0, 0xef, 0x28, 0xf1, 0x1e, 0x56, 0x2d, 0x9c, 0x20, 0x17, 0x85, 0x6f, 0x56,
+	0xee, 0x1b, 0x86, 0x5a, 0x44, 0x46, 0xb1, 0x88, 0x0c, 0x33, 0x5b, 0x44, 0x7a, 0x89, 0xec, 0x01,
+	0x74, 0x51, 0xe4, 0x5d, 0x9b, 0xc1, 0xdc, 0xbc, 0xd5, 0xd8, 0x3f, 0x91, 0xf7, 0x00, 0xec, 0xff,
+	0xa4, 0x76, 0x40, 0x93, 0xbd, 0x1c, 0x70, 0x7f, 0x42, 0x05, 0x66, 0xbd, 0x9d, 0x21, 0xf0, 0x70,
+	0xf6, 0x54, 0x14, 0x2a, 0xed, 0x6f, 0xa0, 0x33, 0xee, 0x19, 0xe3, 0xcb, 0x18, 0x79, 0x80, 0x23,
+	0x0f, 0xb9, 0x71, 0x4a, 0x87, 0xdc, 0x77, 0x0b, 0x5e, 0x8c, 0xc8, 0xdb, 0x4b, 0x72, 0x3a, 0x06,
+	0xd4, 0x3d, 0xa3, 0x1e, 0x7e, 0x7d, 0xee, 0xf9, 0x62, 0x9c, 0x0e, 0xb3, 0x58, 0x4d, 0xc1, 0xe9,
+	0x08, 0x03, 0x3f, 0xc2, 0x17, 0x02, 0xdd, 0x71, 0x53, 0x71, 0xd5, 0xae, 0x4e, 0x9a, 0x19, 0x77,
+	0xa8, 0xf6, 0xf8, 0xab, 0xdf, 0x03, 0x00, 0x26, 0x90, 0xe7, 0x70, 0xe2, 0x05, 0x00, 0x00,
 }
@@ -49,6 +49,7 @@ const (
 	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
 	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
 	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_PURGE_PRIVATE_DATA    ChaincodeMessage_Type = 23
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -74,6 +75,7 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "PURGE_PRIVATE_DATA",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":             0,
@@ -98,13 +100,14 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"GET_STATE_METADATA":    20,
 	"PUT_STATE_METADATA":    21,
 	"GET_PRIVATE_DATA_HASH": 22,
+	"PURGE_PRIVATE_DATA":    23,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -128,7 +131,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -212,7 +215,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +261,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +312,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +366,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +423,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -454,6 +457,56 @@ func (m *DelState) GetCollection() string {
 	return ""
 }
 
+// PurgePrivateState is the payload of a ChaincodeMessage. It contains a key
+// which needs to be recorded in the transaction's private write set as a purge
+// operation. On commit, the current and the historical values of the key are
+// removed from the private data of the collection while the hashes are retained.
+type PurgePrivateState struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PurgePrivateState) Reset()         { *m = PurgePrivateState{} }
+func (m *PurgePrivateState) String() string { return proto.CompactTextString(m) }
+func (*PurgePrivateState) ProtoMessage()    {}
+func (*PurgePrivateState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{6}
+}
+func (m *PurgePrivateState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PurgePrivateState.Unmarshal(m, b)
+}
+func (m *PurgePrivateState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PurgePrivateState.Marshal(b, m, deterministic)
+}
+func (dst *PurgePrivateState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgePrivateState.Merge(dst, src)
+}
+func (m *PurgePrivateState) XXX_Size() int {
+	return xxx_messageInfo_PurgePrivateState.Size(m)
+}
+func (m *PurgePrivateState) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgePrivateState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PurgePrivateState proto.InternalMessageInfo
+
+func (m *PurgePrivateState) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *PurgePrivateState) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
 // GetStateByRange is the payload of a ChaincodeMessage. It contains a start key and
 // a end key required to execute range query. If the collection is specified,
 // the range query needs to be executed on the private data. The metadata hold
@@ -472,7 +525,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{7}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +590,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{8}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -593,7 +646,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{9}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -628,9 +681,11 @@ func (m *QueryMetadata) GetBookmark() string {
 }
 
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
-// for which the historical values need to be retrieved.
+// for which the historical values need to be retrieved and an optional
+// HistoryQueryMetadata.
 type GetHistoryForKey struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -640,7 +695,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{10}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +722,103 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// HistoryQueryMetadata is the metadata of a GetHistoryForKey. It bounds the
+// history by block number and by transaction timestamp (both inclusive), orders
+// it newest first, and contains a pageSize and a bookmark for pagination.
+// A zero end_block and unset timestamps denote no bound.
+type HistoryQueryMetadata struct {
+	StartBlock           uint64               `protobuf:"varint,1,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock             uint64               `protobuf:"varint,2,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	NewestFirst          bool                 `protobuf:"varint,5,opt,name=newest_first,json=newestFirst,proto3" json:"newest_first,omitempty"`
+	PageSize             int32                `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Bookmark             string               `protobuf:"bytes,7,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HistoryQueryMetadata) Reset()         { *m = HistoryQueryMetadata{} }
+func (m *HistoryQueryMetadata) String() string { return proto.CompactTextString(m) }
+func (*HistoryQueryMetadata) ProtoMessage()    {}
+func (*HistoryQueryMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{11}
+}
+func (m *HistoryQueryMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryQueryMetadata.Unmarshal(m, b)
+}
+func (m *HistoryQueryMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryQueryMetadata.Marshal(b, m, deterministic)
+}
+func (dst *HistoryQueryMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryQueryMetadata.Merge(dst, src)
+}
+func (m *HistoryQueryMetadata) XXX_Size() int {
+	return xxx_messageInfo_HistoryQueryMetadata.Size(m)
+}
+func (m *HistoryQueryMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryQueryMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryQueryMetadata proto.InternalMessageInfo
+
+func (m *HistoryQueryMetadata) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryMetadata) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *HistoryQueryMetadata) GetNewestFirst() bool {
+	if m != nil {
+		return m.NewestFirst
+	}
+	return false
+}
+
+func (m *HistoryQueryMetadata) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *HistoryQueryMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +830,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{12}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +868,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{13}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +907,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{14}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +953,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{15}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +1015,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{16}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +1061,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{17}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +1106,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_5b1265277a129bff, []int{18}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -988,10 +1140,12 @@ func init() {
 	proto.RegisterType((*PutState)(nil), "protos.PutState")
 	proto.RegisterType((*PutStateMetadata)(nil), "protos.PutStateMetadata")
 	proto.RegisterType((*DelState)(nil), "protos.DelState")
+	proto.RegisterType((*PurgePrivateState)(nil), "protos.PurgePrivateState")
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*HistoryQueryMetadata)(nil), "protos.HistoryQueryMetadata")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1107,74 +1261,82 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_5b1265277a129bff)
+}
+
+var fileDescriptor_chaincode_shim_5b1265277a129bff = []byte{
+	// 1165 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5d, 0x73, 0xda, 0x46,
+	0x17, 0x0e, 0x1f, 0x36, 0xe2, 0x60, 0xe3, 0xcd, 0xfa, 0x23, 0x84, 0x77, 0xf2, 0xc6, 0xe1, 0x8a,
+	0x8b, 0x16, 0x1a, 0xda, 0xce, 0xb4, 0x33, 0x9d, 0x49, 0x31, 0xac, 0x31, 0x63, 0x1b, 0xc8, 0x4a,
+	0xce, 0xc4, 0xbd, 0xd1, 0x08, 0xe9, 0x18, 0x34, 0x16, 0x92, 0x2a, 0x2d, 0x49, 0xe8, 0x5d, 0x6f,
+	0xfb, 0x2f, 0x7a, 0xdb, 0x1f, 0xd7, 0xdf, 0xd0, 0x59, 0x7d, 0x60, 0xc0, 0x75, 0x3c, 0xcd, 0x15,
+	0x3c, 0xe7, 0x3c, 0xe7, 0xd9, 0xa3, 0x47, 0x67, 0x57, 0x0b, 0xcf, 0x7d, 0xc4, 0xa0, 0x69, 0x4e,
+	0x0d, 0xdb, 0x35, 0x3d, 0x0b, 0xf5, 0x70, 0x6a, 0xcf, 0x1a, 0x7e, 0xe0, 0x09, 0x8f, 0x6e, 0x47,
+	0x3f, 0x61, 0xb5, 0xba, 0x41, 0xc1, 0x0f, 0xe8, 0x8a, 0x98, 0x53, 0xdd, 0x8f, 0x72, 0x7e, 0xe0,
+	0xf9, 0x5e, 0x68, 0x38, 0x49, 0xf0, 0xe5, 0xc4, 0xf3, 0x26, 0x0e, 0x36, 0x23, 0x34, 0x9e, 0xdf,
+	0x34, 0x85, 0x3d, 0xc3, 0x50, 0x18, 0x33, 0x3f, 0x26, 0xd4, 0xfe, 0xde, 0x02, 0xd2, 0x49, 0xf5,
+	0x2e, 0x31, 0x0c, 0x8d, 0x09, 0xd2, 0xd7, 0x90, 0x17, 0x0b, 0x1f, 0x2b, 0x99, 0xe3, 0x4c, 0xbd,
+	0xdc, 0x7a, 0x11, 0x53, 0xc3, 0xc6, 0x26, 0xaf, 0xa1, 0x2d, 0x7c, 0xe4, 0x11, 0x95, 0xfe, 0x00,
+	0xc5, 0xa5, 0x74, 0x25, 0x7b, 0x9c, 0xa9, 0x97, 0x5a, 0xd5, 0x46, 0xbc, 0x78, 0x23, 0x5d, 0xbc,
+	0xa1, 0xa5, 0x0c, 0x7e, 0x47, 0xa6, 0x15, 0x28, 0xf8, 0xc6, 0xc2, 0xf1, 0x0c, 0xab, 0x92, 0x3b,
+	0xce, 0xd4, 0x77, 0x78, 0x0a, 0x29, 0x85, 0xbc, 0xf8, 0x64, 0x5b, 0x95, 0xfc, 0x71, 0xa6, 0x5e,
+	0xe4, 0xd1, 0x7f, 0xda, 0x02, 0x25, 0x7d, 0xc4, 0xca, 0x56, 0xb4, 0xcc, 0x51, 0xda, 0x9e, 0x6a,
+	0x4f, 0x5c, 0xb4, 0x46, 0x49, 0x96, 0x2f, 0x79, 0xf4, 0x0d, 0xec, 0x6d, 0x58, 0x56, 0xd9, 0x5e,
+	0x2f, 0x5d, 0x3e, 0x19, 0x93, 0x59, 0x5e, 0x36, 0xd7, 0x30, 0x7d, 0x01, 0x60, 0x4e, 0x0d, 0xd7,
+	0x45, 0x47, 0xb7, 0xad, 0x4a, 0x21, 0x6a, 0xa7, 0x98, 0x44, 0xfa, 0x56, 0xed, 0xaf, 0x1c, 0xe4,
+	0xa5, 0x15, 0x74, 0x17, 0x8a, 0x57, 0x83, 0x2e, 0x3b, 0xed, 0x0f, 0x58, 0x97, 0x3c, 0xa1, 0x3b,
+	0xa0, 0x70, 0xd6, 0xeb, 0xab, 0x1a, 0xe3, 0x24, 0x43, 0xcb, 0x00, 0x29, 0x62, 0x5d, 0x92, 0xa5,
+	0x0a, 0xe4, 0xfb, 0x83, 0xbe, 0x46, 0x72, 0xb4, 0x08, 0x5b, 0x9c, 0xb5, 0xbb, 0xd7, 0x24, 0x4f,
+	0xf7, 0xa0, 0xa4, 0xf1, 0xf6, 0x40, 0x6d, 0x77, 0xb4, 0xfe, 0x70, 0x40, 0xb6, 0xa4, 0x64, 0x67,
+	0x78, 0x39, 0xba, 0x60, 0x1a, 0xeb, 0x92, 0x6d, 0x49, 0x65, 0x9c, 0x0f, 0x39, 0x29, 0xc8, 0x4c,
+	0x8f, 0x69, 0xba, 0xaa, 0xb5, 0x35, 0x46, 0x14, 0x09, 0x47, 0x57, 0x29, 0x2c, 0x4a, 0xd8, 0x65,
+	0x17, 0x09, 0x04, 0x7a, 0x00, 0xa4, 0x3f, 0x78, 0x37, 0x3c, 0x67, 0x7a, 0xe7, 0xac, 0xdd, 0x1f,
+	0x74, 0x86, 0x5d, 0x46, 0x4a, 0x71, 0x83, 0xea, 0x68, 0x38, 0x50, 0x19, 0xd9, 0xa5, 0x47, 0x40,
+	0x97, 0x82, 0xfa, 0xc9, 0xb5, 0xce, 0xdb, 0x83, 0x1e, 0x23, 0x65, 0x59, 0x2b, 0xe3, 0x6f, 0xaf,
+	0x18, 0xbf, 0xd6, 0x39, 0x53, 0xaf, 0x2e, 0x34, 0xb2, 0x27, 0xa3, 0x71, 0x24, 0xe6, 0x0f, 0xd8,
+	0x7b, 0x8d, 0x10, 0x7a, 0x08, 0x4f, 0x57, 0xa3, 0x9d, 0x8b, 0xa1, 0xca, 0xc8, 0x53, 0xd9, 0xcd,
+	0x39, 0x63, 0xa3, 0xf6, 0x45, 0xff, 0x1d, 0x23, 0x94, 0x3e, 0x83, 0x7d, 0xa9, 0x78, 0xd6, 0x57,
+	0xb5, 0x21, 0xbf, 0xd6, 0x4f, 0x87, 0x5c, 0x3f, 0x67, 0xd7, 0x64, 0x7f, 0xbd, 0x85, 0x4b, 0xa6,
+	0xb5, 0xbb, 0x6d, 0xad, 0x4d, 0x0e, 0x64, 0x7c, 0x74, 0x75, 0x2f, 0x7e, 0x48, 0x9f, 0xc3, 0xa1,
+	0xe4, 0x8f, 0x78, 0xff, 0x9d, 0xcc, 0xc8, 0xa8, 0x7e, 0xd6, 0x56, 0xcf, 0xc8, 0x51, 0x5c, 0xc2,
+	0x7b, 0x6c, 0x2d, 0x49, 0x9e, 0xd5, 0x7e, 0x02, 0xa5, 0x87, 0x42, 0x15, 0x86, 0x40, 0x4a, 0x20,
+	0x77, 0x8b, 0x8b, 0x68, 0xcc, 0x8b, 0x5c, 0xfe, 0xa5, 0xff, 0x07, 0x30, 0x3d, 0xc7, 0x41, 0x53,
+	0xd8, 0x9e, 0x1b, 0xcd, 0x71, 0x91, 0xaf, 0x44, 0x6a, 0x5d, 0x20, 0x69, 0xf5, 0x25, 0x0a, 0xc3,
+	0x32, 0x84, 0xf1, 0x05, 0x2a, 0x1c, 0x94, 0xd1, 0xfc, 0xc1, 0x1e, 0x0e, 0x60, 0xeb, 0x83, 0xe1,
+	0xcc, 0x31, 0x2a, 0xdc, 0xe1, 0x31, 0xd8, 0xd0, 0xcc, 0xdd, 0xd3, 0xfc, 0x08, 0x64, 0x34, 0xff,
+	0x8f, 0x9d, 0xdd, 0x53, 0xa1, 0xaf, 0x41, 0x99, 0x25, 0xd5, 0xd1, 0xb6, 0x2b, 0xb5, 0x0e, 0x97,
+	0xdb, 0x6b, 0x55, 0x9a, 0x2f, 0x69, 0xd2, 0xd0, 0x2e, 0x3a, 0x5f, 0x6a, 0x28, 0x83, 0xa7, 0xa3,
+	0x79, 0x30, 0xc1, 0x51, 0x60, 0x7f, 0x30, 0x04, 0x7e, 0xa9, 0xcc, 0xef, 0x19, 0xd8, 0x4b, 0x5f,
+	0xcc, 0xc9, 0x82, 0x1b, 0xee, 0x04, 0x69, 0x15, 0x94, 0x50, 0x18, 0x81, 0x38, 0x5f, 0x4a, 0x2d,
+	0x31, 0x3d, 0x82, 0x6d, 0x74, 0x2d, 0x99, 0x89, 0xb5, 0x12, 0xf4, 0xa8, 0x3f, 0xd5, 0x0d, 0x7f,
+	0x76, 0x56, 0x8c, 0x18, 0x43, 0xb9, 0x87, 0xe2, 0xed, 0x1c, 0x83, 0x05, 0xc7, 0x70, 0xee, 0x08,
+	0xf9, 0x26, 0x7f, 0x95, 0x30, 0x59, 0x3e, 0x06, 0x8f, 0x3d, 0xcb, 0xda, 0x1a, 0xb9, 0x8d, 0x35,
+	0x7a, 0xb0, 0x1b, 0x2d, 0xb0, 0x7c, 0xc5, 0x55, 0x50, 0x7c, 0x63, 0x82, 0xaa, 0xfd, 0x5b, 0x7c,
+	0x5c, 0x6f, 0xf1, 0x25, 0x96, 0xb9, 0xb1, 0xe7, 0xdd, 0xce, 0x8c, 0xe0, 0x36, 0x59, 0x66, 0x89,
+	0x6b, 0x3f, 0x47, 0x83, 0x7c, 0x66, 0x87, 0xc2, 0x0b, 0x16, 0xa7, 0x5e, 0x20, 0x1f, 0xfe, 0xbe,
+	0xed, 0xab, 0xad, 0x64, 0x37, 0x5a, 0xf9, 0x33, 0x0b, 0x07, 0x49, 0xfd, 0x7a, 0x4b, 0x2f, 0xa1,
+	0x14, 0xf9, 0xac, 0x8f, 0x1d, 0xcf, 0xbc, 0x8d, 0xe4, 0xf2, 0x1c, 0xa2, 0xd0, 0x89, 0x8c, 0xd0,
+	0xff, 0x41, 0x11, 0x5d, 0x2b, 0x49, 0x67, 0xa3, 0xb4, 0x82, 0xae, 0x15, 0x27, 0x7f, 0x84, 0x98,
+	0xaa, 0xcb, 0x2f, 0x44, 0x25, 0xf7, 0xf8, 0x97, 0x24, 0x62, 0x4b, 0x4c, 0xbf, 0x07, 0x29, 0x13,
+	0x17, 0xe6, 0x1f, 0x2d, 0x2c, 0xa0, 0x6b, 0x45, 0x65, 0xaf, 0x60, 0xc7, 0xc5, 0x8f, 0x18, 0x0a,
+	0xfd, 0xc6, 0x0e, 0x42, 0x11, 0x7d, 0x56, 0x14, 0x5e, 0x8a, 0x63, 0xa7, 0x32, 0xb4, 0xe6, 0xf2,
+	0xf6, 0x67, 0x5c, 0x2e, 0x6c, 0xb8, 0x7c, 0x0c, 0xe5, 0xc8, 0x9b, 0x68, 0x2e, 0x07, 0xf8, 0x49,
+	0xd0, 0x32, 0x64, 0x6d, 0x2b, 0xb1, 0x38, 0x6b, 0x5b, 0xb5, 0x57, 0xb0, 0x77, 0xc7, 0xe8, 0x38,
+	0x5e, 0x88, 0xf7, 0x28, 0xdf, 0x01, 0x59, 0x19, 0xaa, 0x93, 0x85, 0xc0, 0x90, 0x1e, 0x43, 0x29,
+	0xb8, 0x83, 0x11, 0x79, 0x87, 0xaf, 0x86, 0x6a, 0x7f, 0x64, 0x92, 0x51, 0xe1, 0x18, 0xfa, 0x9e,
+	0x1b, 0x22, 0x6d, 0x41, 0x21, 0x26, 0x48, 0x7e, 0xae, 0x5e, 0x6a, 0x55, 0xd2, 0xad, 0xbd, 0x29,
+	0xcf, 0x53, 0x22, 0x7d, 0x0e, 0xca, 0xd4, 0x08, 0xf5, 0x99, 0x17, 0xc4, 0xc7, 0x91, 0xc2, 0x0b,
+	0x53, 0x23, 0xbc, 0xf4, 0x82, 0xb4, 0xcd, 0x5c, 0xda, 0xe6, 0x67, 0xb7, 0xc6, 0x04, 0x0e, 0xd7,
+	0x7a, 0x59, 0xce, 0x4a, 0x0b, 0x0e, 0x6f, 0x50, 0x98, 0x53, 0xb4, 0xf4, 0x00, 0x4d, 0x2f, 0xb0,
+	0x42, 0xdd, 0xf4, 0xe6, 0xae, 0x48, 0x66, 0x79, 0x3f, 0x49, 0xf2, 0x38, 0xd7, 0x91, 0xa9, 0xcf,
+	0x8e, 0xf5, 0x1b, 0xd8, 0x5d, 0x3f, 0x02, 0x2b, 0x50, 0x90, 0x5d, 0xdc, 0xcd, 0x75, 0x0a, 0xff,
+	0xfd, 0x98, 0xad, 0x9d, 0xc2, 0xfe, 0xfa, 0x41, 0x17, 0xef, 0xe4, 0x26, 0x14, 0xd0, 0x15, 0x81,
+	0x8d, 0xa9, 0x77, 0x0f, 0x1c, 0x8b, 0x29, 0xab, 0xf5, 0x7e, 0xe5, 0x5a, 0xa5, 0xce, 0x7d, 0xdf,
+	0x0b, 0x04, 0xed, 0x82, 0xc2, 0x71, 0x62, 0x87, 0x02, 0x03, 0x5a, 0x79, 0xe8, 0x52, 0x55, 0x7d,
+	0x30, 0x53, 0x7b, 0x52, 0xcf, 0x7c, 0x93, 0x39, 0xe1, 0x50, 0xf3, 0x82, 0x49, 0x63, 0xba, 0xf0,
+	0x31, 0x70, 0xd0, 0x9a, 0x60, 0xd0, 0xb8, 0x31, 0xc6, 0x81, 0x6d, 0xa6, 0x75, 0xf2, 0x1e, 0xf8,
+	0xcb, 0x57, 0x13, 0x5b, 0x4c, 0xe7, 0xe3, 0x86, 0xe9, 0xcd, 0x9a, 0x22, 0x30, 0x2c, 0x74, 0x6c,
+	0x17, 0xbf, 0x16, 0x68, 0x4e, 0x9b, 0x31, 0x3b, 0xbe, 0x12, 0x86, 0x4d, 0xc9, 0x1e, 0xc7, 0xf7,
+	0xcb, 0x6f, 0xff, 0x19, 0x00, 0x2d, 0xcf, 0x1f, 0x64, 0x83, 0x0a, 0x00, 0x00,
 }
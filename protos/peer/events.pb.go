@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/tradeline-tech/fabric/protos/common"
+import token "github.com/tradeline-tech/fabric/protos/token"
 
 import (
 	context "golang.org/x/net/context"
@@ -25,6 +26,48 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// TokenActionType identifies the kind of action carried by a token transaction
+type TokenActionType int32
+
+const (
+	TokenActionType_UNKNOWN_TOKEN_ACTION    TokenActionType = 0
+	TokenActionType_TOKEN_IMPORT            TokenActionType = 1
+	TokenActionType_TOKEN_TRANSFER          TokenActionType = 2
+	TokenActionType_TOKEN_REDEEM            TokenActionType = 3
+	TokenActionType_TOKEN_APPROVE           TokenActionType = 4
+	TokenActionType_TOKEN_TRANSFER_FROM     TokenActionType = 5
+	TokenActionType_TOKEN_SWAP              TokenActionType = 6
+	TokenActionType_TOKEN_TYPE_REGISTRATION TokenActionType = 7
+)
+
+var TokenActionType_name = map[int32]string{
+	0: "UNKNOWN_TOKEN_ACTION",
+	1: "TOKEN_IMPORT",
+	2: "TOKEN_TRANSFER",
+	3: "TOKEN_REDEEM",
+	4: "TOKEN_APPROVE",
+	5: "TOKEN_TRANSFER_FROM",
+	6: "TOKEN_SWAP",
+	7: "TOKEN_TYPE_REGISTRATION",
+}
+var TokenActionType_value = map[string]int32{
+	"UNKNOWN_TOKEN_ACTION":    0,
+	"TOKEN_IMPORT":            1,
+	"TOKEN_TRANSFER":          2,
+	"TOKEN_REDEEM":            3,
+	"TOKEN_APPROVE":           4,
+	"TOKEN_TRANSFER_FROM":     5,
+	"TOKEN_SWAP":              6,
+	"TOKEN_TYPE_REGISTRATION": 7,
+}
+
+func (x TokenActionType) String() string {
+	return proto.EnumName(TokenActionType_name, int32(x))
+}
+func (TokenActionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_events_59297a2deedeb059, []int{0}
+}
+
 // FilteredBlock is a minimal set of information about a block
 type FilteredBlock struct {
 	ChannelId            string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
@@ -39,7 +82,7 @@ func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_59297a2deedeb059, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -98,7 +141,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_59297a2deedeb059, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -231,7 +274,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_59297a2deedeb059, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +314,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_59297a2deedeb059, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -298,12 +341,206 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// TokenBlock is the set of token actions committed in a block
+type TokenBlock struct {
+	ChannelId            string                   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Number               uint64                   `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	TokenTransactions    []*TokenTransactionEvent `protobuf:"bytes,3,rep,name=token_transactions,json=tokenTransactions,proto3" json:"token_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TokenBlock) Reset()         { *m = TokenBlock{} }
+func (m *TokenBlock) String() string { return proto.CompactTextString(m) }
+func (*TokenBlock) ProtoMessage()    {}
+func (*TokenBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_59297a2deedeb059, []int{4}
+}
+func (m *TokenBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenBlock.Unmarshal(m, b)
+}
+func (m *TokenBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenBlock.Marshal(b, m, deterministic)
+}
+func (dst *TokenBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBlock.Merge(dst, src)
+}
+func (m *TokenBlock) XXX_Size() int {
+	return xxx_messageInfo_TokenBlock.Size(m)
+}
+func (m *TokenBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBlock proto.InternalMessageInfo
+
+func (m *TokenBlock) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *TokenBlock) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *TokenBlock) GetTokenTransactions() []*TokenTransactionEvent {
+	if m != nil {
+		return m.TokenTransactions
+	}
+	return nil
+}
+
+// TokenTransactionEvent describes the effects of a token transaction
+// within a block
+type TokenTransactionEvent struct {
+	Txid             string           `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	TxValidationCode TxValidationCode `protobuf:"varint,2,opt,name=tx_validation_code,json=txValidationCode,proto3,enum=protos.TxValidationCode" json:"tx_validation_code,omitempty"`
+	ActionType       TokenActionType  `protobuf:"varint,3,opt,name=action_type,json=actionType,proto3,enum=protos.TokenActionType" json:"action_type,omitempty"`
+	// inputs are the outputs spent by the transaction
+	Inputs []*token.InputId `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// outputs are the outputs created by the transaction; a redeemed
+	// output has no owner
+	Outputs              []*TokenOutputEvent `protobuf:"bytes,5,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *TokenTransactionEvent) Reset()         { *m = TokenTransactionEvent{} }
+func (m *TokenTransactionEvent) String() string { return proto.CompactTextString(m) }
+func (*TokenTransactionEvent) ProtoMessage()    {}
+func (*TokenTransactionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_59297a2deedeb059, []int{5}
+}
+func (m *TokenTransactionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTransactionEvent.Unmarshal(m, b)
+}
+func (m *TokenTransactionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTransactionEvent.Marshal(b, m, deterministic)
+}
+func (dst *TokenTransactionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTransactionEvent.Merge(dst, src)
+}
+func (m *TokenTransactionEvent) XXX_Size() int {
+	return xxx_messageInfo_TokenTransactionEvent.Size(m)
+}
+func (m *TokenTransactionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTransactionEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTransactionEvent proto.InternalMessageInfo
+
+func (m *TokenTransactionEvent) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *TokenTransactionEvent) GetTxValidationCode() TxValidationCode {
+	if m != nil {
+		return m.TxValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *TokenTransactionEvent) GetActionType() TokenActionType {
+	if m != nil {
+		return m.ActionType
+	}
+	return TokenActionType_UNKNOWN_TOKEN_ACTION
+}
+
+func (m *TokenTransactionEvent) GetInputs() []*token.InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *TokenTransactionEvent) GetOutputs() []*TokenOutputEvent {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// TokenOutputEvent describes an output created by a token transaction
+type TokenOutputEvent struct {
+	Id                   *token.InputId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner                []byte         `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Type                 string         `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Quantity             uint64         `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *TokenOutputEvent) Reset()         { *m = TokenOutputEvent{} }
+func (m *TokenOutputEvent) String() string { return proto.CompactTextString(m) }
+func (*TokenOutputEvent) ProtoMessage()    {}
+func (*TokenOutputEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_59297a2deedeb059, []int{6}
+}
+func (m *TokenOutputEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenOutputEvent.Unmarshal(m, b)
+}
+func (m *TokenOutputEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenOutputEvent.Marshal(b, m, deterministic)
+}
+func (dst *TokenOutputEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenOutputEvent.Merge(dst, src)
+}
+func (m *TokenOutputEvent) XXX_Size() int {
+	return xxx_messageInfo_TokenOutputEvent.Size(m)
+}
+func (m *TokenOutputEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenOutputEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenOutputEvent proto.InternalMessageInfo
+
+func (m *TokenOutputEvent) GetId() *token.InputId {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *TokenOutputEvent) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *TokenOutputEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenOutputEvent) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_TokenBlock
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -314,7 +551,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_59297a2deedeb059, []int{7}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -350,12 +587,18 @@ type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,proto3,oneof"`
 }
 
+type DeliverResponse_TokenBlock struct {
+	TokenBlock *TokenBlock `protobuf:"bytes,4,opt,name=token_block,json=tokenBlock,proto3,oneof"`
+}
+
 func (*DeliverResponse_Status) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_Block) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
 
+func (*DeliverResponse_TokenBlock) isDeliverResponse_Type() {}
+
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
 		return m.Type
@@ -384,12 +627,20 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetTokenBlock() *TokenBlock {
+	if x, ok := m.GetType().(*DeliverResponse_TokenBlock); ok {
+		return x.TokenBlock
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_TokenBlock)(nil),
 	}
 }
 
@@ -410,6 +661,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
 			return err
 		}
+	case *DeliverResponse_TokenBlock:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenBlock); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -443,6 +699,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_FilteredBlock{msg}
 		return true, err
+	case 4: // Type.token_block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenBlock)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_TokenBlock{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -465,6 +729,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_TokenBlock:
+		s := proto.Size(x.TokenBlock)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -477,7 +746,11 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*TokenBlock)(nil), "protos.TokenBlock")
+	proto.RegisterType((*TokenTransactionEvent)(nil), "protos.TokenTransactionEvent")
+	proto.RegisterType((*TokenOutputEvent)(nil), "protos.TokenOutputEvent")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
+	proto.RegisterEnum("protos.TokenActionType", TokenActionType_name, TokenActionType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -500,6 +773,10 @@ type DeliverClient interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of **token** block replies is received
+	DeliverTokens(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverTokensClient, error)
 }
 
 type deliverClient struct {
@@ -572,6 +849,37 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverTokens(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverTokensClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Deliver_serviceDesc.Streams[2], "/protos.Deliver/DeliverTokens", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverTokensClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverTokensClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverTokensClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverTokensClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverTokensClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DeliverServer is the server API for Deliver service.
 type DeliverServer interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
@@ -582,6 +890,10 @@ type DeliverServer interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of **token** block replies is received
+	DeliverTokens(Deliver_DeliverTokensServer) error
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -640,6 +952,32 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverTokens_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverTokens(&deliverDeliverTokensServer{stream})
+}
+
+type Deliver_DeliverTokensServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverTokensServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverTokensServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverTokensServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
@@ -657,47 +995,73 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverTokens",
+			Handler:       _Deliver_DeliverTokens_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_59297a2deedeb059) }
+
+var fileDescriptor_events_59297a2deedeb059 = []byte{
+	// 876 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x6e, 0xe2, 0x46,
+	0x14, 0xc6, 0x40, 0xc8, 0xe6, 0xb0, 0x10, 0xe7, 0xe4, 0x07, 0xc4, 0x6a, 0xb5, 0x91, 0xa5, 0x56,
+	0xa8, 0x6a, 0xa1, 0xa2, 0xaa, 0x54, 0xf5, 0xa2, 0x2d, 0x24, 0x4e, 0x83, 0x76, 0x03, 0x68, 0xe2,
+	0xdd, 0x55, 0xf7, 0xc6, 0x1a, 0xec, 0x01, 0xdc, 0x18, 0xdb, 0xb5, 0x87, 0x34, 0xb9, 0xee, 0x13,
+	0xf4, 0xae, 0x0f, 0xd3, 0x37, 0xe8, 0x53, 0xf4, 0x2d, 0x7a, 0x59, 0x79, 0xc6, 0x36, 0xe0, 0x64,
+	0x2b, 0x45, 0xbd, 0xc2, 0xe7, 0x9c, 0xef, 0xfc, 0x7f, 0x87, 0x81, 0x83, 0x80, 0xb1, 0xb0, 0xcb,
+	0x6e, 0x99, 0xc7, 0xa3, 0x4e, 0x10, 0xfa, 0xdc, 0xc7, 0x8a, 0xf8, 0x89, 0x5a, 0x87, 0x96, 0xbf,
+	0x5c, 0xfa, 0x5e, 0x57, 0xfe, 0x48, 0x63, 0xeb, 0xd5, 0xdc, 0xf7, 0xe7, 0x2e, 0xeb, 0x0a, 0x69,
+	0xba, 0x9a, 0x75, 0xb9, 0xb3, 0x64, 0x11, 0xa7, 0xcb, 0x20, 0x01, 0xb4, 0x44, 0x40, 0x6b, 0x41,
+	0x1d, 0xcf, 0xf2, 0x6d, 0x66, 0x8a, 0xd0, 0x89, 0xed, 0x44, 0xd8, 0x78, 0x48, 0xbd, 0x88, 0x5a,
+	0xdc, 0xc9, 0x82, 0x36, 0xb8, 0x7f, 0xc3, 0xbc, 0x87, 0x06, 0xed, 0x0f, 0x05, 0x6a, 0x17, 0x8e,
+	0xcb, 0x59, 0xc8, 0xec, 0x81, 0xeb, 0x5b, 0x37, 0xf8, 0x12, 0xc0, 0x5a, 0x50, 0xcf, 0x63, 0xae,
+	0xe9, 0xd8, 0x4d, 0xe5, 0x54, 0x69, 0xef, 0x91, 0xbd, 0x44, 0x33, 0xb4, 0xf1, 0x04, 0x2a, 0xde,
+	0x6a, 0x39, 0x65, 0x61, 0xb3, 0x78, 0xaa, 0xb4, 0xcb, 0x24, 0x91, 0x70, 0x02, 0xc7, 0xb3, 0x24,
+	0x8e, 0xb9, 0x91, 0x26, 0x6a, 0x96, 0x4f, 0x4b, 0xed, 0x6a, 0xef, 0x85, 0xcc, 0x17, 0x75, 0xd2,
+	0x64, 0xc6, 0x1a, 0x43, 0x8e, 0x66, 0x0f, 0x95, 0x91, 0xf6, 0x8f, 0x02, 0x87, 0x8f, 0xa0, 0x11,
+	0xa1, 0xcc, 0xef, 0xb2, 0xd2, 0xc4, 0x37, 0x7e, 0x0a, 0x65, 0x7e, 0x1f, 0x30, 0x51, 0x53, 0xbd,
+	0x87, 0x9d, 0x64, 0xa2, 0x97, 0x8c, 0xda, 0x2c, 0x34, 0xee, 0x03, 0x46, 0x84, 0x1d, 0x2f, 0x00,
+	0xf9, 0x9d, 0x79, 0x4b, 0x5d, 0xc7, 0xa6, 0x71, 0x30, 0x33, 0x9e, 0x60, 0xb3, 0x24, 0xbc, 0x9a,
+	0x69, 0x89, 0xc6, 0xdd, 0xbb, 0x0c, 0x70, 0xe6, 0xdb, 0x8c, 0xa8, 0x3c, 0xa7, 0xc1, 0xb7, 0x70,
+	0xb8, 0xd1, 0xa4, 0xb9, 0xee, 0x55, 0x69, 0x57, 0x7b, 0xda, 0x7f, 0xf4, 0xda, 0x97, 0xc8, 0xcb,
+	0x02, 0x41, 0xfe, 0x40, 0x3b, 0xa8, 0x40, 0xf9, 0x9c, 0x72, 0xaa, 0xfd, 0x0c, 0xad, 0x8f, 0xfb,
+	0xe2, 0x1b, 0x38, 0x58, 0x6f, 0x3f, 0x4d, 0xad, 0x88, 0x31, 0xbf, 0xca, 0xa7, 0x3e, 0x4b, 0x81,
+	0xd2, 0x99, 0xa8, 0xd6, 0xb6, 0x22, 0xd2, 0x3e, 0x40, 0xe3, 0x23, 0x60, 0xfc, 0x1e, 0xf6, 0x73,
+	0x34, 0x13, 0x43, 0xaf, 0xf6, 0x4e, 0xd2, 0x34, 0x99, 0x87, 0x1e, 0x5b, 0x49, 0xdd, 0xda, 0x92,
+	0xb5, 0xdf, 0x15, 0x00, 0x23, 0x66, 0xde, 0xff, 0xa2, 0xd6, 0x1b, 0x40, 0x41, 0xdf, 0x6d, 0x5e,
+	0x95, 0x44, 0xc3, 0x2f, 0xb3, 0xa5, 0xc5, 0x88, 0x8d, 0x61, 0xc9, 0x82, 0x0e, 0x78, 0x4e, 0x1d,
+	0x69, 0xbf, 0x15, 0xe1, 0xf8, 0x51, 0xf0, 0xa3, 0xc4, 0x7a, 0x9c, 0x30, 0xc5, 0x27, 0x13, 0xe6,
+	0x1b, 0xa8, 0x26, 0x5c, 0x11, 0x3c, 0x95, 0x8c, 0x6b, 0x6c, 0x15, 0x2f, 0x87, 0x2e, 0xc8, 0x0a,
+	0x34, 0xfb, 0xc6, 0x53, 0xa8, 0x38, 0x5e, 0xb0, 0xe2, 0xe9, 0x25, 0x3d, 0xeb, 0x0c, 0x63, 0x71,
+	0x68, 0x93, 0x44, 0x8f, 0x3d, 0xd8, 0xf5, 0x57, 0x5c, 0x40, 0x76, 0x04, 0xa4, 0xb9, 0x15, 0x77,
+	0x2c, 0x6c, 0x72, 0x1e, 0x29, 0x50, 0x0b, 0x41, 0xcd, 0x1b, 0xb1, 0x09, 0xc5, 0xa4, 0xfb, 0xcd,
+	0x2c, 0x45, 0xc7, 0xc6, 0x23, 0xd8, 0xf1, 0x7f, 0xf5, 0x92, 0xc5, 0x3c, 0x27, 0x52, 0x10, 0xf3,
+	0x4a, 0x9b, 0xd9, 0x4b, 0x0e, 0xac, 0x05, 0xcf, 0x7e, 0x59, 0x51, 0x8f, 0x3b, 0xfc, 0x5e, 0x5c,
+	0x43, 0x99, 0x64, 0xb2, 0xf6, 0xb7, 0x02, 0xfb, 0xe7, 0xcc, 0x75, 0x6e, 0x59, 0x48, 0x58, 0x14,
+	0xf8, 0x5e, 0xc4, 0xb0, 0x0d, 0x95, 0x88, 0x53, 0xbe, 0x8a, 0x44, 0xde, 0x7a, 0xaf, 0x9e, 0x9e,
+	0xee, 0xb5, 0xd0, 0x5e, 0x16, 0x48, 0x62, 0xc7, 0x4f, 0x60, 0x67, 0x1a, 0xb3, 0x48, 0xd4, 0x50,
+	0xed, 0xd5, 0x52, 0xa0, 0xa0, 0xd6, 0x65, 0x81, 0x48, 0x2b, 0x7e, 0x07, 0xf5, 0xec, 0x7f, 0x48,
+	0xe2, 0x4b, 0x02, 0x7f, 0x9c, 0xbf, 0x8c, 0xd4, 0xaf, 0x36, 0xdb, 0x54, 0xe0, 0xd7, 0x50, 0x95,
+	0x64, 0x93, 0xce, 0xf2, 0xa2, 0x71, 0x6b, 0xa0, 0xa9, 0x27, 0xf0, 0x4c, 0x8a, 0x2f, 0x37, 0xde,
+	0xd6, 0x67, 0x7f, 0x2a, 0xb0, 0x9f, 0xdb, 0x26, 0x36, 0xe1, 0xe8, 0xed, 0xe8, 0xf5, 0x68, 0xfc,
+	0x7e, 0x64, 0x1a, 0xe3, 0xd7, 0xfa, 0xc8, 0xec, 0x9f, 0x19, 0xc3, 0xf1, 0x48, 0x2d, 0xa0, 0x0a,
+	0xcf, 0xa5, 0x66, 0x78, 0x35, 0x19, 0x13, 0x43, 0x55, 0x10, 0xa1, 0x2e, 0x35, 0x06, 0xe9, 0x8f,
+	0xae, 0x2f, 0x74, 0xa2, 0x16, 0xd7, 0x28, 0xa2, 0x9f, 0xeb, 0xfa, 0x95, 0x5a, 0xc2, 0x03, 0xa8,
+	0x25, 0x91, 0x26, 0x13, 0x32, 0x7e, 0xa7, 0xab, 0x65, 0x6c, 0xc0, 0xe1, 0xb6, 0xa3, 0x79, 0x41,
+	0xc6, 0x57, 0xea, 0x0e, 0xd6, 0x01, 0xa4, 0xe1, 0xfa, 0x7d, 0x7f, 0xa2, 0x56, 0xf0, 0x05, 0x34,
+	0x12, 0xe0, 0x4f, 0x13, 0xdd, 0x24, 0xfa, 0x8f, 0xc3, 0x6b, 0x83, 0xf4, 0x45, 0x41, 0xbb, 0xbd,
+	0xbf, 0x14, 0xd8, 0x4d, 0x56, 0x84, 0xdf, 0xae, 0x3f, 0xd5, 0x74, 0xd8, 0xba, 0x77, 0xcb, 0x5c,
+	0x3f, 0x60, 0xad, 0x8c, 0xba, 0xb9, 0x85, 0x6a, 0x85, 0xb6, 0xf2, 0xa5, 0x82, 0x83, 0x6c, 0xd3,
+	0xe9, 0xb8, 0x9f, 0x1e, 0xe3, 0x07, 0xa8, 0x25, 0x06, 0x31, 0xd0, 0xe8, 0xc9, 0x11, 0x06, 0x14,
+	0x34, 0x3f, 0x9c, 0x77, 0x16, 0xf7, 0x01, 0x0b, 0x5d, 0x66, 0xcf, 0x59, 0xd8, 0x99, 0xd1, 0x69,
+	0xe8, 0x58, 0xa9, 0x5b, 0xfc, 0x5a, 0x0e, 0x6a, 0x82, 0xfd, 0xd1, 0x84, 0x5a, 0x37, 0x74, 0xce,
+	0x3e, 0x7c, 0x3e, 0x77, 0xf8, 0x62, 0x35, 0x8d, 0x73, 0xc5, 0xef, 0xa5, 0xcd, 0x5c, 0xc7, 0x63,
+	0x5f, 0x70, 0x66, 0x2d, 0xba, 0xd2, 0x59, 0xbe, 0xcc, 0x51, 0x37, 0x76, 0x9e, 0xca, 0xa7, 0xfc,
+	0xab, 0x7f, 0x07, 0x00, 0xf7, 0x4a, 0xe5, 0x10, 0xe6, 0x07, 0x00, 0x00,
 }
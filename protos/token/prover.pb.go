@@ -41,7 +41,7 @@ func (m *TokenToIssue) Reset()         { *m = TokenToIssue{} }
 func (m *TokenToIssue) String() string { return proto.CompactTextString(m) }
 func (*TokenToIssue) ProtoMessage()    {}
 func (*TokenToIssue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{0}
+	return fileDescriptor_prover_9df671fddf803263, []int{0}
 }
 func (m *TokenToIssue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenToIssue.Unmarshal(m, b)
@@ -97,7 +97,7 @@ func (m *RecipientTransferShare) Reset()         { *m = RecipientTransferShare{}
 func (m *RecipientTransferShare) String() string { return proto.CompactTextString(m) }
 func (*RecipientTransferShare) ProtoMessage()    {}
 func (*RecipientTransferShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{1}
+	return fileDescriptor_prover_9df671fddf803263, []int{1}
 }
 func (m *RecipientTransferShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RecipientTransferShare.Unmarshal(m, b)
@@ -148,7 +148,7 @@ func (m *TokenOutput) Reset()         { *m = TokenOutput{} }
 func (m *TokenOutput) String() string { return proto.CompactTextString(m) }
 func (*TokenOutput) ProtoMessage()    {}
 func (*TokenOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{2}
+	return fileDescriptor_prover_9df671fddf803263, []int{2}
 }
 func (m *TokenOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOutput.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *UnspentTokens) Reset()         { *m = UnspentTokens{} }
 func (m *UnspentTokens) String() string { return proto.CompactTextString(m) }
 func (*UnspentTokens) ProtoMessage()    {}
 func (*UnspentTokens) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{3}
+	return fileDescriptor_prover_9df671fddf803263, []int{3}
 }
 func (m *UnspentTokens) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnspentTokens.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{4}
+	return fileDescriptor_prover_9df671fddf803263, []int{4}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -267,6 +267,566 @@ func (m *ListRequest) GetCredential() []byte {
 	return nil
 }
 
+// TokenHistoryRequest is used to request a page of the token transactions
+// that involve the requestor, either as owner of an output or of a spent input
+type TokenHistoryRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// PageSize is the maximum number of transactions to return.
+	// If zero, the prover applies its default page size
+	PageSize uint32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Bookmark is returned with a page of results and, when set,
+	// makes the request start right after the last transaction of that page
+	Bookmark             string   `protobuf:"bytes,3,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenHistoryRequest) Reset()         { *m = TokenHistoryRequest{} }
+func (m *TokenHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*TokenHistoryRequest) ProtoMessage()    {}
+func (*TokenHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{5}
+}
+func (m *TokenHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenHistoryRequest.Unmarshal(m, b)
+}
+func (m *TokenHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *TokenHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenHistoryRequest.Merge(dst, src)
+}
+func (m *TokenHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_TokenHistoryRequest.Size(m)
+}
+func (m *TokenHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenHistoryRequest proto.InternalMessageInfo
+
+func (m *TokenHistoryRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *TokenHistoryRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *TokenHistoryRequest) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+// TokenTransactionRecord couples a committed token transaction with its transaction ID
+type TokenTransactionRecord struct {
+	TxId                 string            `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	TokenTransaction     *TokenTransaction `protobuf:"bytes,2,opt,name=token_transaction,json=tokenTransaction,proto3" json:"token_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TokenTransactionRecord) Reset()         { *m = TokenTransactionRecord{} }
+func (m *TokenTransactionRecord) String() string { return proto.CompactTextString(m) }
+func (*TokenTransactionRecord) ProtoMessage()    {}
+func (*TokenTransactionRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{6}
+}
+func (m *TokenTransactionRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTransactionRecord.Unmarshal(m, b)
+}
+func (m *TokenTransactionRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTransactionRecord.Marshal(b, m, deterministic)
+}
+func (dst *TokenTransactionRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTransactionRecord.Merge(dst, src)
+}
+func (m *TokenTransactionRecord) XXX_Size() int {
+	return xxx_messageInfo_TokenTransactionRecord.Size(m)
+}
+func (m *TokenTransactionRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTransactionRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTransactionRecord proto.InternalMessageInfo
+
+func (m *TokenTransactionRecord) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TokenTransactionRecord) GetTokenTransaction() *TokenTransaction {
+	if m != nil {
+		return m.TokenTransaction
+	}
+	return nil
+}
+
+// TokenHistory is used to hold the output of tokenHistoryRequest
+type TokenHistory struct {
+	Transactions []*TokenTransactionRecord `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Bookmark is set when more transactions are available, and must be
+	// passed in the next TokenHistoryRequest to retrieve them
+	Bookmark             string   `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenHistory) Reset()         { *m = TokenHistory{} }
+func (m *TokenHistory) String() string { return proto.CompactTextString(m) }
+func (*TokenHistory) ProtoMessage()    {}
+func (*TokenHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{7}
+}
+func (m *TokenHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenHistory.Unmarshal(m, b)
+}
+func (m *TokenHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenHistory.Marshal(b, m, deterministic)
+}
+func (dst *TokenHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenHistory.Merge(dst, src)
+}
+func (m *TokenHistory) XXX_Size() int {
+	return xxx_messageInfo_TokenHistory.Size(m)
+}
+func (m *TokenHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenHistory proto.InternalMessageInfo
+
+func (m *TokenHistory) GetTransactions() []*TokenTransactionRecord {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *TokenHistory) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+// BalanceRequest is used to request the balance of unspent tokens per token type
+type BalanceRequest struct {
+	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceRequest) Reset()         { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()    {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{8}
+}
+func (m *BalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceRequest.Unmarshal(m, b)
+}
+func (m *BalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceRequest.Marshal(b, m, deterministic)
+}
+func (dst *BalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceRequest.Merge(dst, src)
+}
+func (m *BalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_BalanceRequest.Size(m)
+}
+func (m *BalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceRequest proto.InternalMessageInfo
+
+func (m *BalanceRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+// TokenBalance is the sum of the unspent tokens of a given type
+type TokenBalance struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Quantity             uint64   `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenBalance) Reset()         { *m = TokenBalance{} }
+func (m *TokenBalance) String() string { return proto.CompactTextString(m) }
+func (*TokenBalance) ProtoMessage()    {}
+func (*TokenBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{9}
+}
+func (m *TokenBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenBalance.Unmarshal(m, b)
+}
+func (m *TokenBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenBalance.Marshal(b, m, deterministic)
+}
+func (dst *TokenBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBalance.Merge(dst, src)
+}
+func (m *TokenBalance) XXX_Size() int {
+	return xxx_messageInfo_TokenBalance.Size(m)
+}
+func (m *TokenBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBalance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBalance proto.InternalMessageInfo
+
+func (m *TokenBalance) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenBalance) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+// TokenBalances is used to hold the output of balanceRequest
+type TokenBalances struct {
+	Balances             []*TokenBalance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TokenBalances) Reset()         { *m = TokenBalances{} }
+func (m *TokenBalances) String() string { return proto.CompactTextString(m) }
+func (*TokenBalances) ProtoMessage()    {}
+func (*TokenBalances) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{10}
+}
+func (m *TokenBalances) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenBalances.Unmarshal(m, b)
+}
+func (m *TokenBalances) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenBalances.Marshal(b, m, deterministic)
+}
+func (dst *TokenBalances) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBalances.Merge(dst, src)
+}
+func (m *TokenBalances) XXX_Size() int {
+	return xxx_messageInfo_TokenBalances.Size(m)
+}
+func (m *TokenBalances) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBalances.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBalances proto.InternalMessageInfo
+
+func (m *TokenBalances) GetBalances() []*TokenBalance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+// SpendStatusRequest is used to request whether an output has been spent
+type SpendStatusRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// InputId identifies the output to check
+	InputId              *InputId `protobuf:"bytes,2,opt,name=input_id,json=inputId,proto3" json:"input_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpendStatusRequest) Reset()         { *m = SpendStatusRequest{} }
+func (m *SpendStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SpendStatusRequest) ProtoMessage()    {}
+func (*SpendStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{11}
+}
+func (m *SpendStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpendStatusRequest.Unmarshal(m, b)
+}
+func (m *SpendStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpendStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *SpendStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpendStatusRequest.Merge(dst, src)
+}
+func (m *SpendStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SpendStatusRequest.Size(m)
+}
+func (m *SpendStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpendStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpendStatusRequest proto.InternalMessageInfo
+
+func (m *SpendStatusRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *SpendStatusRequest) GetInputId() *InputId {
+	if m != nil {
+		return m.InputId
+	}
+	return nil
+}
+
+// SpendStatus is used to hold the output of spendStatusRequest
+type SpendStatus struct {
+	InputId *InputId `protobuf:"bytes,1,opt,name=input_id,json=inputId,proto3" json:"input_id,omitempty"`
+	// Spent is true if the output has been used as input of a committed transaction
+	Spent bool `protobuf:"varint,2,opt,name=spent,proto3" json:"spent,omitempty"`
+	// Delegated is true if the output is a delegated output created by an approve
+	Delegated            bool     `protobuf:"varint,3,opt,name=delegated,proto3" json:"delegated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpendStatus) Reset()         { *m = SpendStatus{} }
+func (m *SpendStatus) String() string { return proto.CompactTextString(m) }
+func (*SpendStatus) ProtoMessage()    {}
+func (*SpendStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{12}
+}
+func (m *SpendStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpendStatus.Unmarshal(m, b)
+}
+func (m *SpendStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpendStatus.Marshal(b, m, deterministic)
+}
+func (dst *SpendStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpendStatus.Merge(dst, src)
+}
+func (m *SpendStatus) XXX_Size() int {
+	return xxx_messageInfo_SpendStatus.Size(m)
+}
+func (m *SpendStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpendStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpendStatus proto.InternalMessageInfo
+
+func (m *SpendStatus) GetInputId() *InputId {
+	if m != nil {
+		return m.InputId
+	}
+	return nil
+}
+
+func (m *SpendStatus) GetSpent() bool {
+	if m != nil {
+		return m.Spent
+	}
+	return false
+}
+
+func (m *SpendStatus) GetDelegated() bool {
+	if m != nil {
+		return m.Delegated
+	}
+	return false
+}
+
+// RegisterTokenTypeRequest is used to request the registration of a token type
+type RegisterTokenTypeRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Definition is the definition of the token type to register
+	Definition           *TokenTypeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RegisterTokenTypeRequest) Reset()         { *m = RegisterTokenTypeRequest{} }
+func (m *RegisterTokenTypeRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterTokenTypeRequest) ProtoMessage()    {}
+func (*RegisterTokenTypeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{13}
+}
+func (m *RegisterTokenTypeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterTokenTypeRequest.Unmarshal(m, b)
+}
+func (m *RegisterTokenTypeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterTokenTypeRequest.Marshal(b, m, deterministic)
+}
+func (dst *RegisterTokenTypeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterTokenTypeRequest.Merge(dst, src)
+}
+func (m *RegisterTokenTypeRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterTokenTypeRequest.Size(m)
+}
+func (m *RegisterTokenTypeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterTokenTypeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterTokenTypeRequest proto.InternalMessageInfo
+
+func (m *RegisterTokenTypeRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *RegisterTokenTypeRequest) GetDefinition() *TokenTypeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// ListTokenTypesRequest is used to request the token types in the registry
+type ListTokenTypesRequest struct {
+	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListTokenTypesRequest) Reset()         { *m = ListTokenTypesRequest{} }
+func (m *ListTokenTypesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTokenTypesRequest) ProtoMessage()    {}
+func (*ListTokenTypesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{14}
+}
+func (m *ListTokenTypesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTokenTypesRequest.Unmarshal(m, b)
+}
+func (m *ListTokenTypesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTokenTypesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListTokenTypesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTokenTypesRequest.Merge(dst, src)
+}
+func (m *ListTokenTypesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTokenTypesRequest.Size(m)
+}
+func (m *ListTokenTypesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTokenTypesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListTokenTypesRequest proto.InternalMessageInfo
+
+func (m *ListTokenTypesRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+// TokenTypeInfo couples the definition of a registered token type with
+// the quantity imported since its registration
+type TokenTypeInfo struct {
+	Definition           *TokenTypeDefinition `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	Supply               uint64               `protobuf:"varint,2,opt,name=supply,proto3" json:"supply,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TokenTypeInfo) Reset()         { *m = TokenTypeInfo{} }
+func (m *TokenTypeInfo) String() string { return proto.CompactTextString(m) }
+func (*TokenTypeInfo) ProtoMessage()    {}
+func (*TokenTypeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{15}
+}
+func (m *TokenTypeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypeInfo.Unmarshal(m, b)
+}
+func (m *TokenTypeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypeInfo.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypeInfo.Merge(dst, src)
+}
+func (m *TokenTypeInfo) XXX_Size() int {
+	return xxx_messageInfo_TokenTypeInfo.Size(m)
+}
+func (m *TokenTypeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypeInfo proto.InternalMessageInfo
+
+func (m *TokenTypeInfo) GetDefinition() *TokenTypeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+func (m *TokenTypeInfo) GetSupply() uint64 {
+	if m != nil {
+		return m.Supply
+	}
+	return 0
+}
+
+// TokenTypes is used to hold the output of listTokenTypesRequest
+type TokenTypes struct {
+	Types                []*TokenTypeInfo `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *TokenTypes) Reset()         { *m = TokenTypes{} }
+func (m *TokenTypes) String() string { return proto.CompactTextString(m) }
+func (*TokenTypes) ProtoMessage()    {}
+func (*TokenTypes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{16}
+}
+func (m *TokenTypes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypes.Unmarshal(m, b)
+}
+func (m *TokenTypes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypes.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypes.Merge(dst, src)
+}
+func (m *TokenTypes) XXX_Size() int {
+	return xxx_messageInfo_TokenTypes.Size(m)
+}
+func (m *TokenTypes) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypes proto.InternalMessageInfo
+
+func (m *TokenTypes) GetTypes() []*TokenTypeInfo {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
 // ImportRequest is used to request creation of imports
 type ImportRequest struct {
 	// Credential contains information about the party who is requesting the operation
@@ -283,7 +843,7 @@ func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
 func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()    {}
 func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{5}
+	return fileDescriptor_prover_9df671fddf803263, []int{17}
 }
 func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
@@ -319,19 +879,22 @@ func (m *ImportRequest) GetTokensToIssue() []*TokenToIssue {
 
 // RequestTransfer is used to request creation of transfers
 type TransferRequest struct {
-	Credential           []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	TokenIds             [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
-	Shares               []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Credential []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	TokenIds   [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	Shares     []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
+	// Openings are the openings of the tokens identified by token_ids, in the same order.
+	// They are only used by token management systems that hide quantities
+	Openings             []*TokenOpening `protobuf:"bytes,4,rep,name=openings,proto3" json:"openings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
 func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferRequest) ProtoMessage()    {}
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{6}
+	return fileDescriptor_prover_9df671fddf803263, []int{18}
 }
 func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
@@ -372,6 +935,100 @@ func (m *TransferRequest) GetShares() []*RecipientTransferShare {
 	return nil
 }
 
+func (m *TransferRequest) GetOpenings() []*TokenOpening {
+	if m != nil {
+		return m.Openings
+	}
+	return nil
+}
+
+// SwapRequest is used to request an atomic swap of tokens of different types
+// between the requestor and a counterparty
+type SwapRequest struct {
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// token_ids identifies the tokens of the requestor that are given in the swap
+	TokenIds [][]byte `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	// quantity is the quantity of the tokens of the requestor that goes to the counterparty;
+	// the remaining quantity, if any, is transferred back to the requestor
+	Quantity uint64 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// counterparty is the owner of the tokens received in exchange
+	Counterparty []byte `protobuf:"bytes,4,opt,name=counterparty,proto3" json:"counterparty,omitempty"`
+	// counterparty_token_ids identifies the tokens of the counterparty that are received in the swap
+	CounterpartyTokenIds [][]byte `protobuf:"bytes,5,rep,name=counterparty_token_ids,json=counterpartyTokenIds,proto3" json:"counterparty_token_ids,omitempty"`
+	// counterparty_quantity is the quantity of the tokens of the counterparty that goes to the requestor;
+	// the remaining quantity, if any, is transferred back to the counterparty
+	CounterpartyQuantity uint64   `protobuf:"varint,6,opt,name=counterparty_quantity,json=counterpartyQuantity,proto3" json:"counterparty_quantity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SwapRequest) Reset()         { *m = SwapRequest{} }
+func (m *SwapRequest) String() string { return proto.CompactTextString(m) }
+func (*SwapRequest) ProtoMessage()    {}
+func (*SwapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_9df671fddf803263, []int{19}
+}
+func (m *SwapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SwapRequest.Unmarshal(m, b)
+}
+func (m *SwapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SwapRequest.Marshal(b, m, deterministic)
+}
+func (dst *SwapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapRequest.Merge(dst, src)
+}
+func (m *SwapRequest) XXX_Size() int {
+	return xxx_messageInfo_SwapRequest.Size(m)
+}
+func (m *SwapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapRequest proto.InternalMessageInfo
+
+func (m *SwapRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *SwapRequest) GetTokenIds() [][]byte {
+	if m != nil {
+		return m.TokenIds
+	}
+	return nil
+}
+
+func (m *SwapRequest) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *SwapRequest) GetCounterparty() []byte {
+	if m != nil {
+		return m.Counterparty
+	}
+	return nil
+}
+
+func (m *SwapRequest) GetCounterpartyTokenIds() [][]byte {
+	if m != nil {
+		return m.CounterpartyTokenIds
+	}
+	return nil
+}
+
+func (m *SwapRequest) GetCounterpartyQuantity() uint64 {
+	if m != nil {
+		return m.CounterpartyQuantity
+	}
+	return 0
+}
+
 // RedeemRequest is used to request token redemption
 type RedeemRequest struct {
 	// Credential contains information for the party who is requesting the operation
@@ -380,17 +1037,20 @@ type RedeemRequest struct {
 	// token_ids specifies the ids for the tokens that will be redeemed
 	TokenIds [][]byte `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
 	// quantity refers to the number of units of a given token needs to be redeemed.
-	QuantityToRedeem     uint64   `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	QuantityToRedeem uint64 `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
+	// Openings are the openings of the tokens identified by token_ids, in the same order.
+	// They are only used by token management systems that hide quantities
+	Openings             []*TokenOpening `protobuf:"bytes,4,rep,name=openings,proto3" json:"openings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
 func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
 func (*RedeemRequest) ProtoMessage()    {}
 func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{7}
+	return fileDescriptor_prover_9df671fddf803263, []int{20}
 }
 func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
@@ -431,6 +1091,13 @@ func (m *RedeemRequest) GetQuantityToRedeem() uint64 {
 	return 0
 }
 
+func (m *RedeemRequest) GetOpenings() []*TokenOpening {
+	if m != nil {
+		return m.Openings
+	}
+	return nil
+}
+
 // ALlowance defines how many and what tokens a recipient can transfer on behalf of their actual owner
 type AllowanceRecipientShare struct {
 	// Recipient refers to the entity allowed to spend the specified quantity from the tokens identified by token IDs
@@ -446,7 +1113,7 @@ func (m *AllowanceRecipientShare) Reset()         { *m = AllowanceRecipientShare
 func (m *AllowanceRecipientShare) String() string { return proto.CompactTextString(m) }
 func (*AllowanceRecipientShare) ProtoMessage()    {}
 func (*AllowanceRecipientShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{8}
+	return fileDescriptor_prover_9df671fddf803263, []int{21}
 }
 func (m *AllowanceRecipientShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllowanceRecipientShare.Unmarshal(m, b)
@@ -497,7 +1164,7 @@ func (m *ApproveRequest) Reset()         { *m = ApproveRequest{} }
 func (m *ApproveRequest) String() string { return proto.CompactTextString(m) }
 func (*ApproveRequest) ProtoMessage()    {}
 func (*ApproveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{9}
+	return fileDescriptor_prover_9df671fddf803263, []int{22}
 }
 func (m *ApproveRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApproveRequest.Unmarshal(m, b)
@@ -556,7 +1223,7 @@ func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
 func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpectationRequest) ProtoMessage()    {}
 func (*ExpectationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{10}
+	return fileDescriptor_prover_9df671fddf803263, []int{23}
 }
 func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
@@ -619,7 +1286,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{11}
+	return fileDescriptor_prover_9df671fddf803263, []int{24}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -681,6 +1348,12 @@ type Command struct {
 	//	*Command_ApproveRequest
 	//	*Command_TransferFromRequest
 	//	*Command_ExpectationRequest
+	//	*Command_TokenHistoryRequest
+	//	*Command_BalanceRequest
+	//	*Command_SpendStatusRequest
+	//	*Command_RegisterTokenTypeRequest
+	//	*Command_ListTokenTypesRequest
+	//	*Command_SwapRequest
 	Payload              isCommand_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -691,7 +1364,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{12}
+	return fileDescriptor_prover_9df671fddf803263, []int{25}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -750,6 +1423,30 @@ type Command_ExpectationRequest struct {
 	ExpectationRequest *ExpectationRequest `protobuf:"bytes,8,opt,name=expectation_request,json=expectationRequest,proto3,oneof"`
 }
 
+type Command_TokenHistoryRequest struct {
+	TokenHistoryRequest *TokenHistoryRequest `protobuf:"bytes,9,opt,name=token_history_request,json=tokenHistoryRequest,proto3,oneof"`
+}
+
+type Command_BalanceRequest struct {
+	BalanceRequest *BalanceRequest `protobuf:"bytes,10,opt,name=balance_request,json=balanceRequest,proto3,oneof"`
+}
+
+type Command_SpendStatusRequest struct {
+	SpendStatusRequest *SpendStatusRequest `protobuf:"bytes,11,opt,name=spend_status_request,json=spendStatusRequest,proto3,oneof"`
+}
+
+type Command_RegisterTokenTypeRequest struct {
+	RegisterTokenTypeRequest *RegisterTokenTypeRequest `protobuf:"bytes,12,opt,name=register_token_type_request,json=registerTokenTypeRequest,proto3,oneof"`
+}
+
+type Command_ListTokenTypesRequest struct {
+	ListTokenTypesRequest *ListTokenTypesRequest `protobuf:"bytes,13,opt,name=list_token_types_request,json=listTokenTypesRequest,proto3,oneof"`
+}
+
+type Command_SwapRequest struct {
+	SwapRequest *SwapRequest `protobuf:"bytes,14,opt,name=swap_request,json=swapRequest,proto3,oneof"`
+}
+
 func (*Command_ImportRequest) isCommand_Payload() {}
 
 func (*Command_TransferRequest) isCommand_Payload() {}
@@ -764,6 +1461,18 @@ func (*Command_TransferFromRequest) isCommand_Payload() {}
 
 func (*Command_ExpectationRequest) isCommand_Payload() {}
 
+func (*Command_TokenHistoryRequest) isCommand_Payload() {}
+
+func (*Command_BalanceRequest) isCommand_Payload() {}
+
+func (*Command_SpendStatusRequest) isCommand_Payload() {}
+
+func (*Command_RegisterTokenTypeRequest) isCommand_Payload() {}
+
+func (*Command_ListTokenTypesRequest) isCommand_Payload() {}
+
+func (*Command_SwapRequest) isCommand_Payload() {}
+
 func (m *Command) GetPayload() isCommand_Payload {
 	if m != nil {
 		return m.Payload
@@ -820,6 +1529,48 @@ func (m *Command) GetExpectationRequest() *ExpectationRequest {
 	return nil
 }
 
+func (m *Command) GetTokenHistoryRequest() *TokenHistoryRequest {
+	if x, ok := m.GetPayload().(*Command_TokenHistoryRequest); ok {
+		return x.TokenHistoryRequest
+	}
+	return nil
+}
+
+func (m *Command) GetBalanceRequest() *BalanceRequest {
+	if x, ok := m.GetPayload().(*Command_BalanceRequest); ok {
+		return x.BalanceRequest
+	}
+	return nil
+}
+
+func (m *Command) GetSpendStatusRequest() *SpendStatusRequest {
+	if x, ok := m.GetPayload().(*Command_SpendStatusRequest); ok {
+		return x.SpendStatusRequest
+	}
+	return nil
+}
+
+func (m *Command) GetRegisterTokenTypeRequest() *RegisterTokenTypeRequest {
+	if x, ok := m.GetPayload().(*Command_RegisterTokenTypeRequest); ok {
+		return x.RegisterTokenTypeRequest
+	}
+	return nil
+}
+
+func (m *Command) GetListTokenTypesRequest() *ListTokenTypesRequest {
+	if x, ok := m.GetPayload().(*Command_ListTokenTypesRequest); ok {
+		return x.ListTokenTypesRequest
+	}
+	return nil
+}
+
+func (m *Command) GetSwapRequest() *SwapRequest {
+	if x, ok := m.GetPayload().(*Command_SwapRequest); ok {
+		return x.SwapRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
@@ -830,6 +1581,12 @@ func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Command_ApproveRequest)(nil),
 		(*Command_TransferFromRequest)(nil),
 		(*Command_ExpectationRequest)(nil),
+		(*Command_TokenHistoryRequest)(nil),
+		(*Command_BalanceRequest)(nil),
+		(*Command_SpendStatusRequest)(nil),
+		(*Command_RegisterTokenTypeRequest)(nil),
+		(*Command_ListTokenTypesRequest)(nil),
+		(*Command_SwapRequest)(nil),
 	}
 }
 
@@ -872,6 +1629,36 @@ func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ExpectationRequest); err != nil {
 			return err
 		}
+	case *Command_TokenHistoryRequest:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenHistoryRequest); err != nil {
+			return err
+		}
+	case *Command_BalanceRequest:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BalanceRequest); err != nil {
+			return err
+		}
+	case *Command_SpendStatusRequest:
+		b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SpendStatusRequest); err != nil {
+			return err
+		}
+	case *Command_RegisterTokenTypeRequest:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RegisterTokenTypeRequest); err != nil {
+			return err
+		}
+	case *Command_ListTokenTypesRequest:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListTokenTypesRequest); err != nil {
+			return err
+		}
+	case *Command_SwapRequest:
+		b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SwapRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Command.Payload has unexpected type %T", x)
@@ -938,6 +1725,54 @@ func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Payload = &Command_ExpectationRequest{msg}
 		return true, err
+	case 9: // payload.token_history_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenHistoryRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_TokenHistoryRequest{msg}
+		return true, err
+	case 10: // payload.balance_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BalanceRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_BalanceRequest{msg}
+		return true, err
+	case 11: // payload.spend_status_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SpendStatusRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_SpendStatusRequest{msg}
+		return true, err
+	case 12: // payload.register_token_type_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RegisterTokenTypeRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_RegisterTokenTypeRequest{msg}
+		return true, err
+	case 13: // payload.list_token_types_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ListTokenTypesRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_ListTokenTypesRequest{msg}
+		return true, err
+	case 14: // payload.swap_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SwapRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_SwapRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -982,6 +1817,36 @@ func _Command_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Command_TokenHistoryRequest:
+		s := proto.Size(x.TokenHistoryRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_BalanceRequest:
+		s := proto.Size(x.BalanceRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_SpendStatusRequest:
+		s := proto.Size(x.SpendStatusRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_RegisterTokenTypeRequest:
+		s := proto.Size(x.RegisterTokenTypeRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_ListTokenTypesRequest:
+		s := proto.Size(x.ListTokenTypesRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_SwapRequest:
+		s := proto.Size(x.SwapRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1004,7 +1869,7 @@ func (m *SignedCommand) Reset()         { *m = SignedCommand{} }
 func (m *SignedCommand) String() string { return proto.CompactTextString(m) }
 func (*SignedCommand) ProtoMessage()    {}
 func (*SignedCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{13}
+	return fileDescriptor_prover_9df671fddf803263, []int{26}
 }
 func (m *SignedCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommand.Unmarshal(m, b)
@@ -1058,7 +1923,7 @@ func (m *CommandResponseHeader) Reset()         { *m = CommandResponseHeader{} }
 func (m *CommandResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*CommandResponseHeader) ProtoMessage()    {}
 func (*CommandResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{14}
+	return fileDescriptor_prover_9df671fddf803263, []int{27}
 }
 func (m *CommandResponseHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponseHeader.Unmarshal(m, b)
@@ -1114,7 +1979,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{15}
+	return fileDescriptor_prover_9df671fddf803263, []int{28}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1158,6 +2023,10 @@ type CommandResponse struct {
 	//	*CommandResponse_Err
 	//	*CommandResponse_TokenTransaction
 	//	*CommandResponse_UnspentTokens
+	//	*CommandResponse_TokenHistory
+	//	*CommandResponse_TokenBalances
+	//	*CommandResponse_SpendStatus
+	//	*CommandResponse_TokenTypes
 	Payload              isCommandResponse_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -1168,7 +2037,7 @@ func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
 func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandResponse) ProtoMessage()    {}
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{16}
+	return fileDescriptor_prover_9df671fddf803263, []int{29}
 }
 func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
@@ -1211,12 +2080,36 @@ type CommandResponse_UnspentTokens struct {
 	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,proto3,oneof"`
 }
 
+type CommandResponse_TokenHistory struct {
+	TokenHistory *TokenHistory `protobuf:"bytes,5,opt,name=token_history,json=tokenHistory,proto3,oneof"`
+}
+
+type CommandResponse_TokenBalances struct {
+	TokenBalances *TokenBalances `protobuf:"bytes,6,opt,name=token_balances,json=tokenBalances,proto3,oneof"`
+}
+
+type CommandResponse_SpendStatus struct {
+	SpendStatus *SpendStatus `protobuf:"bytes,7,opt,name=spend_status,json=spendStatus,proto3,oneof"`
+}
+
+type CommandResponse_TokenTypes struct {
+	TokenTypes *TokenTypes `protobuf:"bytes,8,opt,name=token_types,json=tokenTypes,proto3,oneof"`
+}
+
 func (*CommandResponse_Err) isCommandResponse_Payload() {}
 
 func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
 
 func (*CommandResponse_UnspentTokens) isCommandResponse_Payload() {}
 
+func (*CommandResponse_TokenHistory) isCommandResponse_Payload() {}
+
+func (*CommandResponse_TokenBalances) isCommandResponse_Payload() {}
+
+func (*CommandResponse_SpendStatus) isCommandResponse_Payload() {}
+
+func (*CommandResponse_TokenTypes) isCommandResponse_Payload() {}
+
 func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
 	if m != nil {
 		return m.Payload
@@ -1245,12 +2138,44 @@ func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
 	return nil
 }
 
+func (m *CommandResponse) GetTokenHistory() *TokenHistory {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenHistory); ok {
+		return x.TokenHistory
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetTokenBalances() *TokenBalances {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenBalances); ok {
+		return x.TokenBalances
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetSpendStatus() *SpendStatus {
+	if x, ok := m.GetPayload().(*CommandResponse_SpendStatus); ok {
+		return x.SpendStatus
+	}
+	return nil
+}
+
+func (m *CommandResponse) GetTokenTypes() *TokenTypes {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenTypes); ok {
+		return x.TokenTypes
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
 		(*CommandResponse_Err)(nil),
 		(*CommandResponse_TokenTransaction)(nil),
 		(*CommandResponse_UnspentTokens)(nil),
+		(*CommandResponse_TokenHistory)(nil),
+		(*CommandResponse_TokenBalances)(nil),
+		(*CommandResponse_SpendStatus)(nil),
+		(*CommandResponse_TokenTypes)(nil),
 	}
 }
 
@@ -1273,6 +2198,26 @@ func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
 			return err
 		}
+	case *CommandResponse_TokenHistory:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenHistory); err != nil {
+			return err
+		}
+	case *CommandResponse_TokenBalances:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenBalances); err != nil {
+			return err
+		}
+	case *CommandResponse_SpendStatus:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SpendStatus); err != nil {
+			return err
+		}
+	case *CommandResponse_TokenTypes:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenTypes); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
@@ -1307,6 +2252,38 @@ func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Payload = &CommandResponse_UnspentTokens{msg}
 		return true, err
+	case 5: // payload.token_history
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenHistory)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenHistory{msg}
+		return true, err
+	case 6: // payload.token_balances
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenBalances)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenBalances{msg}
+		return true, err
+	case 7: // payload.spend_status
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SpendStatus)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_SpendStatus{msg}
+		return true, err
+	case 8: // payload.token_types
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenTypes)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenTypes{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1331,6 +2308,26 @@ func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CommandResponse_TokenHistory:
+		s := proto.Size(x.TokenHistory)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_TokenBalances:
+		s := proto.Size(x.TokenBalances)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_SpendStatus:
+		s := proto.Size(x.SpendStatus)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommandResponse_TokenTypes:
+		s := proto.Size(x.TokenTypes)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1353,7 +2350,7 @@ func (m *SignedCommandResponse) Reset()         { *m = SignedCommandResponse{} }
 func (m *SignedCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*SignedCommandResponse) ProtoMessage()    {}
 func (*SignedCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{17}
+	return fileDescriptor_prover_9df671fddf803263, []int{30}
 }
 func (m *SignedCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommandResponse.Unmarshal(m, b)
@@ -1393,8 +2390,21 @@ func init() {
 	proto.RegisterType((*TokenOutput)(nil), "protos.TokenOutput")
 	proto.RegisterType((*UnspentTokens)(nil), "protos.UnspentTokens")
 	proto.RegisterType((*ListRequest)(nil), "protos.ListRequest")
+	proto.RegisterType((*TokenHistoryRequest)(nil), "protos.TokenHistoryRequest")
+	proto.RegisterType((*TokenTransactionRecord)(nil), "protos.TokenTransactionRecord")
+	proto.RegisterType((*TokenHistory)(nil), "protos.TokenHistory")
+	proto.RegisterType((*BalanceRequest)(nil), "protos.BalanceRequest")
+	proto.RegisterType((*TokenBalance)(nil), "protos.TokenBalance")
+	proto.RegisterType((*TokenBalances)(nil), "protos.TokenBalances")
+	proto.RegisterType((*SpendStatusRequest)(nil), "protos.SpendStatusRequest")
+	proto.RegisterType((*SpendStatus)(nil), "protos.SpendStatus")
+	proto.RegisterType((*RegisterTokenTypeRequest)(nil), "protos.RegisterTokenTypeRequest")
+	proto.RegisterType((*ListTokenTypesRequest)(nil), "protos.ListTokenTypesRequest")
+	proto.RegisterType((*TokenTypeInfo)(nil), "protos.TokenTypeInfo")
+	proto.RegisterType((*TokenTypes)(nil), "protos.TokenTypes")
 	proto.RegisterType((*ImportRequest)(nil), "protos.ImportRequest")
 	proto.RegisterType((*TransferRequest)(nil), "protos.TransferRequest")
+	proto.RegisterType((*SwapRequest)(nil), "protos.SwapRequest")
 	proto.RegisterType((*RedeemRequest)(nil), "protos.RedeemRequest")
 	proto.RegisterType((*AllowanceRecipientShare)(nil), "protos.AllowanceRecipientShare")
 	proto.RegisterType((*ApproveRequest)(nil), "protos.ApproveRequest")
@@ -1488,72 +2498,109 @@ var _Prover_serviceDesc = grpc.ServiceDesc{
 	Metadata: "token/prover.proto",
 }
 
-func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_aacdf31643d38144) }
-
-var fileDescriptor_prover_aacdf31643d38144 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xd9, 0xae, 0x13, 0x8f, 0xbf, 0xd2, 0x4d, 0xd3, 0x9c, 0x0c, 0x69, 0xd3, 0x43, 0x42,
-	0x11, 0x1f, 0xb6, 0x14, 0x04, 0xaa, 0xa0, 0xaa, 0x48, 0xa1, 0x70, 0x41, 0x54, 0xb4, 0x1b, 0xf3,
-	0x82, 0x90, 0xac, 0xcd, 0xdd, 0xc6, 0x5e, 0xe1, 0xbb, 0xbd, 0xee, 0xee, 0x01, 0xe1, 0x0f, 0xe0,
-	0x0d, 0x24, 0x1e, 0x79, 0xe0, 0xff, 0xe4, 0x11, 0xdd, 0x7e, 0x9c, 0xef, 0xdc, 0xd0, 0x06, 0x95,
-	0x27, 0x7b, 0x67, 0x66, 0x67, 0x7e, 0x3b, 0xfb, 0xfb, 0xcd, 0x1e, 0x20, 0xc5, 0x7f, 0xa0, 0xe9,
-	0x34, 0x13, 0xfc, 0x47, 0x2a, 0x26, 0x99, 0xe0, 0x8a, 0xa3, 0x8e, 0xfe, 0x91, 0xe3, 0xbb, 0x0b,
-	0xce, 0x17, 0x2b, 0x3a, 0xd5, 0xcb, 0xf3, 0xfc, 0x62, 0xaa, 0x58, 0x42, 0xa5, 0x22, 0x49, 0x66,
-	0x02, 0xc7, 0xbe, 0xd9, 0x4c, 0x7f, 0xce, 0x68, 0xa4, 0x88, 0x62, 0x3c, 0x95, 0xd6, 0xb3, 0x6f,
-	0x3c, 0x4a, 0x90, 0x54, 0x92, 0xa8, 0xf0, 0x18, 0x47, 0xf0, 0x3d, 0xf4, 0x67, 0x85, 0x6b, 0xc6,
-	0x4f, 0xa5, 0xcc, 0x29, 0x7a, 0x13, 0xba, 0x82, 0x46, 0x2c, 0x63, 0x34, 0x55, 0xbe, 0x77, 0xe8,
-	0x1d, 0xf5, 0xf1, 0xda, 0x80, 0x10, 0xb4, 0xd5, 0x65, 0x46, 0xfd, 0xe6, 0xa1, 0x77, 0xd4, 0xc5,
-	0xfa, 0x3f, 0x1a, 0xc3, 0xf6, 0xf3, 0x9c, 0xa4, 0x8a, 0xa9, 0x4b, 0xbf, 0x75, 0xe8, 0x1d, 0xb5,
-	0x71, 0xb9, 0x0e, 0x30, 0xdc, 0xc6, 0x6e, 0xf3, 0xac, 0xa8, 0x7d, 0x41, 0xc5, 0xd9, 0x92, 0x88,
-	0x57, 0xd5, 0xa9, 0xe6, 0x6c, 0x6e, 0xe4, 0x7c, 0x02, 0x3d, 0x8d, 0xf8, 0x9b, 0x5c, 0x65, 0xb9,
-	0x42, 0x43, 0x68, 0xb2, 0xd8, 0x66, 0x68, 0xb2, 0xf8, 0x3f, 0x43, 0x7c, 0x00, 0x83, 0x6f, 0x53,
-	0x99, 0x15, 0x00, 0x8b, 0xac, 0x12, 0xbd, 0x0b, 0x1d, 0xdd, 0x2c, 0xe9, 0x7b, 0x87, 0xad, 0xa3,
-	0xde, 0xf1, 0xae, 0xe9, 0x94, 0x9c, 0x54, 0xaa, 0x62, 0x1b, 0x12, 0xbc, 0x0f, 0xbd, 0xaf, 0x99,
-	0x54, 0x98, 0x3e, 0xcf, 0xa9, 0x54, 0xe8, 0x0e, 0x40, 0x24, 0x68, 0x4c, 0x53, 0xc5, 0xc8, 0xca,
-	0x82, 0xaa, 0x58, 0x82, 0x04, 0x06, 0xa7, 0x49, 0xc6, 0xc5, 0x75, 0x37, 0xa0, 0x07, 0x30, 0x32,
-	0x95, 0xe6, 0x8a, 0xcf, 0x59, 0x71, 0x43, 0x7e, 0x53, 0xa3, 0xba, 0x55, 0x43, 0x65, 0x6f, 0x0f,
-	0x0f, 0x4c, 0xb0, 0x5d, 0x06, 0xbf, 0x7a, 0x30, 0x72, 0x6d, 0xbf, 0x6e, 0xc5, 0x37, 0xa0, 0xab,
-	0x93, 0xcc, 0x59, 0x2c, 0x75, 0xad, 0x3e, 0xde, 0xd6, 0x86, 0xd3, 0x58, 0xa2, 0x8f, 0xa0, 0x23,
-	0x8b, 0xeb, 0x93, 0x7e, 0x4b, 0xa3, 0xb8, 0xe3, 0x50, 0x5c, 0x7d, 0xcb, 0xd8, 0x46, 0x07, 0xbf,
-	0xc0, 0x00, 0xd3, 0x98, 0xd2, 0xe4, 0x7f, 0x41, 0xf1, 0x1e, 0x20, 0x77, 0x7d, 0x45, 0x5b, 0x84,
-	0xce, 0x6c, 0x2f, 0x76, 0xc7, 0x79, 0x66, 0xdc, 0x54, 0x0c, 0xce, 0x60, 0xff, 0x64, 0xb5, 0xe2,
-	0x3f, 0x91, 0x34, 0xa2, 0x25, 0xcc, 0xd7, 0x25, 0xe1, 0x9f, 0x1e, 0x0c, 0x4f, 0x32, 0xad, 0xd2,
-	0xeb, 0x1e, 0xe9, 0x2b, 0xd8, 0x21, 0x0e, 0xc7, 0xdc, 0x76, 0xd1, 0xdc, 0xe5, 0x5d, 0xd7, 0xc5,
-	0x7f, 0xc1, 0x89, 0x47, 0xe5, 0x46, 0xbd, 0x96, 0xf5, 0xf6, 0xb4, 0xea, 0xed, 0x09, 0x7e, 0xf3,
-	0x00, 0x3d, 0x5e, 0x8f, 0x80, 0xeb, 0xe2, 0xfb, 0x18, 0x7a, 0x95, 0xc1, 0xa1, 0x4f, 0xdc, 0x3b,
-	0xf6, 0x6b, 0x34, 0xab, 0x66, 0xad, 0x06, 0xbf, 0x1c, 0xcf, 0x1f, 0x1e, 0x74, 0x42, 0x4a, 0x62,
-	0x2a, 0xd0, 0x7d, 0xe8, 0x96, 0x33, 0x4b, 0x43, 0xe8, 0x1d, 0x8f, 0x27, 0x66, 0xaa, 0x4d, 0xdc,
-	0x54, 0x9b, 0xcc, 0x5c, 0x04, 0x5e, 0x07, 0xa3, 0x03, 0x80, 0x68, 0x49, 0xd2, 0x94, 0xae, 0xe6,
-	0x2c, 0xb6, 0xe2, 0xee, 0x5a, 0xcb, 0x69, 0x8c, 0x6e, 0xc1, 0x8d, 0x94, 0xa7, 0x11, 0xd5, 0x2c,
-	0xe8, 0x63, 0xb3, 0x40, 0x3e, 0x6c, 0x45, 0x82, 0x12, 0xc5, 0x85, 0xdf, 0xd6, 0x76, 0xb7, 0x0c,
-	0xfe, 0x6a, 0xc3, 0xd6, 0x67, 0x3c, 0x49, 0x48, 0x1a, 0xa3, 0xb7, 0xa1, 0xb3, 0xd4, 0xf0, 0x2c,
-	0xa2, 0xa1, 0x3b, 0xb3, 0x01, 0x8d, 0xad, 0x17, 0x3d, 0x84, 0x21, 0xd3, 0xe2, 0x9d, 0x0b, 0xd3,
-	0x52, 0xdb, 0xa3, 0x3d, 0x17, 0x5f, 0x93, 0x76, 0xd8, 0xc0, 0x03, 0x56, 0xd3, 0xfa, 0xe7, 0xb0,
-	0xa3, 0xac, 0x3a, 0xca, 0x0c, 0x2d, 0x9d, 0x61, 0xbf, 0xec, 0x72, 0x5d, 0xac, 0x61, 0x03, 0x8f,
-	0xd4, 0x86, 0x7e, 0xef, 0x43, 0x7f, 0xc5, 0xe4, 0x1a, 0x43, 0x5b, 0x67, 0x28, 0x87, 0x54, 0x65,
-	0x1a, 0x85, 0x0d, 0xdc, 0x5b, 0x55, 0x86, 0xd3, 0x43, 0x18, 0x1a, 0xa9, 0x94, 0x7b, 0x6f, 0xd4,
-	0xf1, 0xd7, 0x24, 0x5a, 0xe0, 0x17, 0x35, 0xcd, 0x9e, 0xc0, 0x88, 0x18, 0xca, 0x97, 0x09, 0x3a,
-	0x3a, 0xc1, 0xed, 0x92, 0xbf, 0x35, 0x45, 0x84, 0x0d, 0x3c, 0x24, 0x75, 0x8d, 0x3c, 0x81, 0xbd,
-	0xb2, 0x05, 0x17, 0x82, 0xaf, 0x91, 0x6c, 0xbd, 0xaa, 0x0f, 0xbb, 0x6e, 0xdf, 0x17, 0x82, 0x27,
-	0xeb, 0x74, 0xbb, 0x15, 0x16, 0x96, 0xc9, 0xb6, 0x2d, 0xb1, 0x6c, 0xb2, 0x17, 0xb5, 0x10, 0x36,
-	0x30, 0xa2, 0x2f, 0x58, 0x1f, 0x75, 0x61, 0x2b, 0x23, 0x97, 0x2b, 0x4e, 0xe2, 0xe0, 0x4b, 0x18,
-	0x9c, 0xb1, 0x45, 0x4a, 0x63, 0x47, 0x92, 0x82, 0x4a, 0xe6, 0xaf, 0x95, 0x8e, 0x5b, 0x16, 0x43,
-	0x44, 0xb2, 0x45, 0x4a, 0x54, 0x2e, 0xcc, 0xab, 0xd3, 0xc7, 0x6b, 0x43, 0xf0, 0xbb, 0x07, 0x7b,
-	0x36, 0x07, 0xa6, 0x32, 0xe3, 0xa9, 0xa4, 0xaf, 0xad, 0x85, 0x7b, 0xd0, 0xb7, 0xc5, 0xe7, 0x4b,
-	0x22, 0x97, 0xb6, 0x68, 0xcf, 0xda, 0x42, 0x22, 0x97, 0x55, 0xe6, 0xb7, 0xea, 0xcc, 0xff, 0x04,
-	0x6e, 0x3c, 0x16, 0x82, 0x8b, 0x22, 0x24, 0xa1, 0x52, 0x92, 0x05, 0xd5, 0xd5, 0xbb, 0xd8, 0x2d,
-	0x0b, 0x8f, 0xed, 0x83, 0x4d, 0x5d, 0xb6, 0xe5, 0x6f, 0x0f, 0x46, 0x1b, 0xa7, 0x41, 0x1f, 0x6e,
-	0xc8, 0xe7, 0xc0, 0xf5, 0xfd, 0xca, 0x63, 0x97, 0x6a, 0xba, 0x07, 0x2d, 0x2a, 0x84, 0x95, 0xd0,
-	0xa0, 0xbc, 0xab, 0x02, 0x5a, 0xd8, 0xc0, 0x85, 0x0f, 0x7d, 0x0a, 0x37, 0xcd, 0x54, 0xa9, 0x7c,
-	0xb6, 0x58, 0xc5, 0xdc, 0xb4, 0xef, 0xde, 0xda, 0x11, 0x36, 0xf0, 0x8e, 0xda, 0xb0, 0x15, 0x94,
-	0xcf, 0xcd, 0xe3, 0x3e, 0xb7, 0x6f, 0x7a, 0xbb, 0x4e, 0xf9, 0xda, 0xd3, 0x5f, 0x50, 0x3e, 0xaf,
-	0x1a, 0xaa, 0x8c, 0x78, 0x06, 0x7b, 0x35, 0x46, 0x94, 0xe7, 0x1f, 0xc3, 0xb6, 0xb0, 0xff, 0x2d,
-	0x35, 0xca, 0xf5, 0xcb, 0xb9, 0x71, 0x8c, 0xa1, 0xf3, 0x54, 0x7f, 0xe7, 0xa1, 0x10, 0x86, 0x4f,
-	0x05, 0x8f, 0xa8, 0x94, 0x8e, 0x6f, 0x25, 0xc2, 0x5a, 0xd1, 0xf1, 0xc1, 0x95, 0x66, 0x87, 0x25,
-	0x68, 0x3c, 0x7a, 0x06, 0x6f, 0x71, 0xb1, 0x98, 0x2c, 0x2f, 0x33, 0x2a, 0x56, 0x34, 0x5e, 0x50,
-	0x31, 0xb9, 0x20, 0xe7, 0x82, 0x45, 0x6e, 0xa3, 0xee, 0xc3, 0x77, 0xef, 0x2c, 0x98, 0x5a, 0xe6,
-	0xe7, 0x93, 0x88, 0x27, 0xd3, 0x4a, 0xec, 0xd4, 0xc4, 0x9a, 0x2f, 0x4c, 0x39, 0xd5, 0xb1, 0xe7,
-	0xe6, 0xf3, 0xf3, 0x83, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x10, 0x78, 0x90, 0x5b, 0x9b, 0x0a,
-	0x00, 0x00,
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_9df671fddf803263) }
+
+var fileDescriptor_prover_9df671fddf803263 = []byte{
+	// 1601 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xef, 0x6e, 0x1b, 0xb9,
+	0x11, 0xd7, 0x5a, 0xb6, 0x2c, 0x8d, 0xfe, 0xd8, 0xa1, 0x2d, 0x67, 0xe1, 0x34, 0x89, 0xb3, 0x01,
+	0x0a, 0x17, 0x69, 0xe4, 0x20, 0x7f, 0xda, 0xb4, 0x09, 0x82, 0xda, 0x4d, 0x5a, 0xb9, 0x68, 0xda,
+	0x84, 0x72, 0x81, 0xde, 0xe1, 0x0e, 0x02, 0xad, 0xa5, 0xa5, 0x45, 0xa4, 0xdd, 0x0d, 0x49, 0x5d,
+	0xe2, 0xbc, 0xc3, 0x1d, 0x70, 0x1f, 0xef, 0x01, 0xee, 0x01, 0xee, 0xa5, 0xee, 0x0d, 0xee, 0xcb,
+	0x7d, 0x3a, 0xf0, 0xcf, 0x72, 0x97, 0x92, 0x9c, 0x28, 0x48, 0x3e, 0x69, 0x39, 0xe4, 0x0c, 0x67,
+	0x86, 0xbf, 0xf9, 0x71, 0x28, 0x40, 0x22, 0x79, 0x45, 0xe3, 0x83, 0x94, 0x25, 0xdf, 0x50, 0xd6,
+	0x49, 0x59, 0x22, 0x12, 0x54, 0x51, 0x3f, 0x7c, 0xf7, 0xfa, 0x30, 0x49, 0x86, 0x63, 0x7a, 0xa0,
+	0x86, 0xa7, 0xd3, 0xb3, 0x03, 0x11, 0x4d, 0x28, 0x17, 0x64, 0x92, 0xea, 0x85, 0xbb, 0xbe, 0x56,
+	0xa6, 0x6f, 0x53, 0x3a, 0x10, 0x44, 0x44, 0x49, 0xcc, 0xcd, 0xcc, 0x65, 0x3d, 0x23, 0x18, 0x89,
+	0x39, 0x19, 0xc8, 0x19, 0x3d, 0x11, 0x7c, 0x05, 0x8d, 0x13, 0x39, 0x75, 0x92, 0x1c, 0x73, 0x3e,
+	0xa5, 0xe8, 0x77, 0x50, 0x63, 0x74, 0x10, 0xa5, 0x11, 0x8d, 0x85, 0xef, 0xed, 0x79, 0xfb, 0x0d,
+	0x9c, 0x0b, 0x10, 0x82, 0x55, 0x71, 0x9e, 0x52, 0x7f, 0x65, 0xcf, 0xdb, 0xaf, 0x61, 0xf5, 0x8d,
+	0x76, 0xa1, 0xfa, 0x7a, 0x4a, 0x62, 0x11, 0x89, 0x73, 0xbf, 0xbc, 0xe7, 0xed, 0xaf, 0x62, 0x3b,
+	0x0e, 0x30, 0xec, 0xe0, 0x4c, 0xf9, 0x44, 0xee, 0x7d, 0x46, 0x59, 0x6f, 0x44, 0xd8, 0x87, 0xf6,
+	0x29, 0xda, 0x5c, 0x99, 0xb1, 0xf9, 0x1c, 0xea, 0xca, 0xe3, 0xff, 0x4e, 0x45, 0x3a, 0x15, 0xa8,
+	0x05, 0x2b, 0x51, 0x68, 0x2c, 0xac, 0x44, 0xe1, 0x47, 0xbb, 0xf8, 0x18, 0x9a, 0xff, 0x8b, 0x79,
+	0x2a, 0x1d, 0x94, 0x56, 0x39, 0xba, 0x05, 0x15, 0x95, 0x2c, 0xee, 0x7b, 0x7b, 0xe5, 0xfd, 0xfa,
+	0xdd, 0x2d, 0x9d, 0x29, 0xde, 0x29, 0xec, 0x8a, 0xcd, 0x92, 0xe0, 0x36, 0xd4, 0xff, 0x1d, 0x71,
+	0x81, 0xe9, 0xeb, 0x29, 0xe5, 0x02, 0x5d, 0x03, 0x18, 0x30, 0x1a, 0xd2, 0x58, 0x44, 0x64, 0x6c,
+	0x9c, 0x2a, 0x48, 0x82, 0x18, 0xb6, 0x94, 0x95, 0x6e, 0xc4, 0x45, 0xc2, 0xce, 0x97, 0x54, 0x43,
+	0x57, 0xa0, 0x96, 0x92, 0x21, 0xed, 0xf3, 0xe8, 0x9d, 0x0e, 0xac, 0x89, 0xab, 0x52, 0xd0, 0x8b,
+	0xde, 0xa9, 0xe0, 0x4e, 0x93, 0xe4, 0xd5, 0x84, 0xb0, 0x57, 0x2a, 0xb8, 0x1a, 0xb6, 0xe3, 0x60,
+	0x02, 0x3b, 0xfa, 0x74, 0xf3, 0x73, 0xc7, 0x74, 0x90, 0xb0, 0x10, 0x6d, 0xc1, 0x9a, 0x78, 0xdb,
+	0x37, 0x99, 0x93, 0x79, 0x7a, 0x7b, 0x1c, 0xa2, 0x27, 0x70, 0x49, 0xc5, 0xd5, 0x2f, 0xe0, 0x44,
+	0xed, 0x57, 0xbf, 0x7b, 0xa9, 0x33, 0x67, 0x68, 0x53, 0xcc, 0x48, 0x82, 0x18, 0x1a, 0xc5, 0xf0,
+	0xd0, 0x11, 0x34, 0x0a, 0x96, 0xb2, 0x84, 0x5e, 0x73, 0x12, 0x3a, 0xe7, 0x1a, 0x76, 0x74, 0x9c,
+	0xf0, 0x56, 0x66, 0xc2, 0xbb, 0x03, 0xad, 0x23, 0x32, 0x26, 0xf1, 0x80, 0x2e, 0x7b, 0x00, 0x4f,
+	0x8c, 0x87, 0x46, 0xcd, 0xa2, 0xc5, 0xbb, 0x00, 0x2d, 0xb3, 0xe0, 0x3b, 0x84, 0x66, 0x51, 0x9f,
+	0xa3, 0x3b, 0x50, 0x3d, 0x35, 0xdf, 0x26, 0xbc, 0x6d, 0x27, 0xbc, 0xcc, 0x3f, 0xbb, 0x2a, 0xf8,
+	0x02, 0x50, 0x2f, 0xa5, 0x71, 0xd8, 0x13, 0x44, 0x4c, 0xf9, 0xb2, 0x10, 0xb8, 0x09, 0xd5, 0x28,
+	0x4e, 0xa7, 0x42, 0x1e, 0x99, 0x3e, 0x91, 0x6a, 0xe7, 0x58, 0x0a, 0x8e, 0x43, 0xbc, 0x1e, 0xe9,
+	0x8f, 0xe0, 0x0c, 0xea, 0x05, 0xd3, 0x8e, 0x8e, 0x77, 0x81, 0x0e, 0xda, 0x86, 0x35, 0x85, 0x7e,
+	0x65, 0xb5, 0x8a, 0xf5, 0x40, 0x96, 0x67, 0x48, 0xc7, 0x74, 0x48, 0x04, 0x0d, 0x15, 0xaa, 0xaa,
+	0x38, 0x17, 0x04, 0x29, 0xf8, 0x98, 0x0e, 0x23, 0x2e, 0x28, 0xd3, 0x67, 0x78, 0x9e, 0x2e, 0x7b,
+	0x02, 0xe8, 0x3e, 0x40, 0x48, 0xcf, 0xa2, 0x38, 0x2a, 0x80, 0x6b, 0xbb, 0x63, 0xcd, 0x3c, 0xb5,
+	0x73, 0xb8, 0xb0, 0x2e, 0xf8, 0x33, 0xb4, 0x65, 0x9d, 0xd9, 0x65, 0xcb, 0xe6, 0x2d, 0xf8, 0x1a,
+	0x9a, 0x56, 0xe9, 0x38, 0x3e, 0x4b, 0x66, 0xf6, 0xf7, 0x96, 0xdb, 0x1f, 0xed, 0x40, 0x85, 0x4f,
+	0xd3, 0x74, 0x9c, 0x21, 0xc2, 0x8c, 0x82, 0xbf, 0x00, 0xe4, 0x3e, 0xa1, 0x5b, 0xb0, 0x26, 0x11,
+	0x94, 0x21, 0xa1, 0xed, 0x02, 0xdd, 0x78, 0x80, 0xf5, 0x9a, 0x60, 0x02, 0xcd, 0xe3, 0x49, 0x9a,
+	0xb0, 0x65, 0xc9, 0x03, 0x3d, 0x86, 0x0d, 0xcd, 0x3a, 0x7d, 0x91, 0xf4, 0x23, 0xc9, 0xd6, 0xfe,
+	0xca, 0x02, 0xc4, 0x19, 0x26, 0xc7, 0x4d, 0xbd, 0xd8, 0x0c, 0x83, 0x9f, 0x3c, 0xd8, 0xc8, 0x28,
+	0xf8, 0x23, 0x78, 0x47, 0xf3, 0x41, 0x14, 0x72, 0xb5, 0x57, 0x03, 0x57, 0x95, 0xe0, 0x38, 0xe4,
+	0xe8, 0x4f, 0x50, 0xe1, 0x92, 0xca, 0xb9, 0x5f, 0x76, 0xcb, 0x7a, 0x31, 0xe3, 0x63, 0xb3, 0x1a,
+	0xfd, 0x01, 0xaa, 0x49, 0x4a, 0xe3, 0x28, 0x1e, 0x72, 0x7f, 0x55, 0x69, 0x36, 0x0d, 0xb5, 0x6a,
+	0x29, 0xb6, 0xd3, 0xc1, 0x2f, 0x1e, 0xd4, 0x7b, 0x6f, 0x48, 0xfa, 0x59, 0xfc, 0x7d, 0xcf, 0x25,
+	0x80, 0x02, 0x68, 0x0c, 0x92, 0x69, 0x2c, 0x28, 0x4b, 0x09, 0x13, 0xe7, 0xfe, 0xaa, 0x32, 0xed,
+	0xc8, 0xd0, 0x7d, 0xd8, 0x29, 0x8e, 0xfb, 0xf9, 0x4e, 0x6b, 0x6a, 0xa7, 0xed, 0xe2, 0xec, 0x49,
+	0xb6, 0xeb, 0x3d, 0x68, 0x3b, 0x5a, 0xd6, 0x85, 0x8a, 0x72, 0xc1, 0x51, 0x7a, 0x99, 0xb1, 0xcc,
+	0x8f, 0x1e, 0x34, 0x31, 0x0d, 0x29, 0x9d, 0x7c, 0x96, 0xc8, 0xff, 0x08, 0x28, 0xdb, 0x56, 0x42,
+	0x87, 0x29, 0xcb, 0x26, 0x07, 0x9b, 0xd9, 0xcc, 0x49, 0xa2, 0x77, 0xfc, 0x98, 0xf3, 0xe9, 0xc1,
+	0xe5, 0xc3, 0xf1, 0x38, 0x79, 0xa3, 0x19, 0xd8, 0x9c, 0xfa, 0xa7, 0xde, 0xef, 0x3f, 0x78, 0xd0,
+	0x3a, 0x4c, 0x55, 0x03, 0xb4, 0x6c, 0xf4, 0xff, 0x82, 0x4d, 0x92, 0xf9, 0xd1, 0x37, 0xa0, 0xd4,
+	0xa5, 0x71, 0x3d, 0x03, 0xe5, 0x05, 0x7e, 0xe2, 0x0d, 0xab, 0xd8, 0xd3, 0xf0, 0x74, 0x32, 0x59,
+	0x76, 0x33, 0x19, 0x7c, 0xeb, 0x01, 0x7a, 0x96, 0x77, 0x57, 0xcb, 0xfa, 0xf7, 0x57, 0xa8, 0x17,
+	0x7a, 0x32, 0x43, 0x7a, 0xbe, 0x53, 0xb5, 0x45, 0xab, 0xc5, 0xc5, 0xef, 0xf7, 0xe7, 0x7b, 0x0f,
+	0x2a, 0x5d, 0x4a, 0x42, 0xca, 0xd0, 0x43, 0xa8, 0xd9, 0x76, 0xd0, 0xd0, 0xda, 0x6e, 0x47, 0x37,
+	0x8c, 0x9d, 0xac, 0x61, 0xec, 0x9c, 0x64, 0x2b, 0x70, 0xbe, 0x18, 0x5d, 0x05, 0x18, 0x8c, 0x48,
+	0x1c, 0xd3, 0x71, 0x76, 0xb9, 0xd4, 0x70, 0xcd, 0x48, 0xf4, 0x05, 0x11, 0x27, 0xf1, 0x80, 0x2a,
+	0xc0, 0x34, 0xb0, 0x1e, 0x20, 0x1f, 0xd6, 0x07, 0x8c, 0x12, 0x91, 0x30, 0x53, 0x2c, 0xd9, 0x30,
+	0xf8, 0x75, 0x1d, 0xd6, 0xff, 0x9e, 0x4c, 0x26, 0x24, 0x0e, 0xd1, 0xef, 0xa1, 0x32, 0x52, 0xee,
+	0x19, 0x8f, 0x5a, 0x59, 0xcc, 0xda, 0x69, 0x6c, 0x66, 0xd1, 0x13, 0x68, 0x45, 0x8a, 0x0b, 0xfb,
+	0x4c, 0xa7, 0xd4, 0xe4, 0xc8, 0x32, 0xa8, 0xc3, 0x94, 0xdd, 0x12, 0x6e, 0x46, 0x45, 0x01, 0x7a,
+	0x0a, 0x9b, 0xc2, 0x90, 0x8d, 0xb5, 0x50, 0x56, 0x16, 0x2e, 0xdb, 0x2c, 0xbb, 0xdc, 0xd7, 0x2d,
+	0xe1, 0x0d, 0xe1, 0x8a, 0xd0, 0x43, 0x68, 0x8c, 0x23, 0x9e, 0xfb, 0xb0, 0xba, 0xe7, 0x15, 0xfb,
+	0xbf, 0x42, 0xa3, 0xd7, 0x2d, 0xe1, 0xfa, 0x38, 0x1f, 0x4a, 0xff, 0x75, 0x55, 0x59, 0xdd, 0x35,
+	0xd7, 0x7f, 0xa7, 0x9a, 0xa5, 0xff, 0xcc, 0x29, 0xef, 0x43, 0xd8, 0x20, 0x1a, 0xf2, 0xd6, 0x40,
+	0x45, 0x19, 0xd8, 0xb1, 0xf8, 0x75, 0x2a, 0xa2, 0x5b, 0xc2, 0x2d, 0xe2, 0xd6, 0xc8, 0x73, 0x68,
+	0xdb, 0x14, 0x9c, 0xb1, 0x24, 0xf7, 0x64, 0xfd, 0x43, 0x79, 0xd8, 0xca, 0xf4, 0xfe, 0xc1, 0x92,
+	0x49, 0x6e, 0x6e, 0xab, 0x80, 0x42, 0x6b, 0xac, 0x6a, 0x80, 0x65, 0x8c, 0xcd, 0xd7, 0x42, 0xb7,
+	0x84, 0x11, 0x9d, 0xaf, 0x90, 0x97, 0xd0, 0xd6, 0x28, 0x1e, 0xe9, 0xd6, 0xd0, 0x1a, 0xac, 0x29,
+	0x83, 0x57, 0x9c, 0x5a, 0x70, 0xbb, 0x63, 0xe5, 0xe1, 0xbc, 0x58, 0xe6, 0xcc, 0xf4, 0x54, 0xd6,
+	0x18, 0xb8, 0x39, 0x73, 0x7b, 0x43, 0x99, 0xb3, 0x53, 0x47, 0x82, 0xfe, 0x03, 0xdb, 0x5c, 0xf6,
+	0x4b, 0x7d, 0xae, 0x1a, 0x26, 0x6b, 0xa7, 0xee, 0x46, 0x39, 0xdf, 0xae, 0xc9, 0x28, 0xf9, 0x9c,
+	0x14, 0x11, 0xb8, 0xc2, 0x4c, 0x5f, 0x64, 0xae, 0x07, 0x79, 0xd5, 0x5b, 0xb3, 0x0d, 0x65, 0x76,
+	0x2f, 0xc7, 0xc4, 0xe2, 0x16, 0xaa, 0x5b, 0xc2, 0x3e, 0xbb, 0x60, 0x0e, 0xfd, 0x1f, 0x7c, 0x85,
+	0xd1, 0xdc, 0x7c, 0xee, 0x76, 0x53, 0xd9, 0xbf, 0x5a, 0xc4, 0xeb, 0x5c, 0xc3, 0xd4, 0x2d, 0xe1,
+	0xf6, 0x78, 0xd1, 0x84, 0x44, 0x3f, 0x7f, 0x43, 0x52, 0x6b, 0xad, 0xe5, 0xa2, 0xbf, 0x70, 0x0f,
+	0x4b, 0xf4, 0xf3, 0x7c, 0x78, 0x54, 0x83, 0xf5, 0x94, 0x9c, 0x8f, 0x13, 0x12, 0x06, 0xff, 0x84,
+	0x66, 0x2f, 0x1a, 0xc6, 0x34, 0xcc, 0x18, 0x40, 0xf2, 0x84, 0xfe, 0x34, 0xbc, 0x98, 0x0d, 0xe5,
+	0x0d, 0xc1, 0xa3, 0x61, 0x4c, 0xc4, 0x94, 0xe9, 0x47, 0x4d, 0x03, 0xe7, 0x82, 0xe0, 0x3b, 0x0f,
+	0xda, 0xc6, 0x06, 0xa6, 0x3c, 0x4d, 0x62, 0x4e, 0x3f, 0x99, 0xe8, 0x6e, 0xc8, 0x5b, 0x5e, 0x99,
+	0xec, 0x8f, 0x08, 0x1f, 0x99, 0x4d, 0xeb, 0x46, 0xd6, 0x25, 0x7c, 0x54, 0xa4, 0xb5, 0xb2, 0x4b,
+	0x6b, 0x8f, 0x60, 0xed, 0x19, 0x63, 0x09, 0x93, 0x4b, 0x26, 0x94, 0x73, 0x32, 0xcc, 0x5e, 0x0d,
+	0xd9, 0x10, 0xf9, 0x36, 0x0f, 0xc6, 0xb4, 0x4d, 0xcb, 0xcf, 0x65, 0xd8, 0x98, 0x89, 0x06, 0x3d,
+	0x98, 0xe1, 0x46, 0x7b, 0x6e, 0x0b, 0xc3, 0xb6, 0x54, 0x79, 0x03, 0xca, 0x94, 0x31, 0xc3, 0x8f,
+	0x4d, 0x5b, 0x88, 0xd2, 0xb5, 0x6e, 0x09, 0xcb, 0x39, 0xf4, 0xb7, 0x45, 0xcf, 0xb8, 0xf2, 0x05,
+	0xcf, 0xb8, 0x6e, 0x69, 0xfe, 0x21, 0x27, 0xf9, 0x6c, 0xaa, 0x1f, 0xc5, 0x7d, 0xf3, 0x16, 0x5e,
+	0x75, 0xf9, 0xcc, 0x79, 0x32, 0x4b, 0x3e, 0x9b, 0x16, 0x05, 0xe8, 0x11, 0x34, 0x9d, 0x72, 0x37,
+	0x74, 0xb8, 0xbd, 0xa8, 0xcc, 0xbb, 0x25, 0xdc, 0x28, 0xd6, 0xb7, 0xdc, 0x5c, 0x2b, 0xdb, 0x87,
+	0x55, 0xc5, 0xdd, 0xdc, 0x79, 0x81, 0xc9, 0xcd, 0x45, 0x51, 0xa0, 0x80, 0x5c, 0xa8, 0x6a, 0x7f,
+	0x7d, 0x06, 0xc8, 0x79, 0xdd, 0x2a, 0x20, 0xe7, 0x43, 0xf4, 0x00, 0xea, 0x85, 0xba, 0x32, 0x64,
+	0x87, 0xe6, 0xba, 0x78, 0xa9, 0x07, 0xc2, 0x8e, 0x8a, 0xf8, 0x7f, 0x09, 0x6d, 0x07, 0xff, 0xf6,
+	0xb4, 0x77, 0xa1, 0xca, 0xcc, 0xb7, 0x29, 0x04, 0x3b, 0x7e, 0x7f, 0x25, 0xdc, 0xc5, 0x50, 0x79,
+	0xa1, 0xfe, 0x0d, 0x42, 0x5d, 0x68, 0xbd, 0x60, 0xc9, 0x80, 0x72, 0x9e, 0x55, 0x97, 0x4d, 0x89,
+	0xb3, 0xe9, 0xee, 0xd5, 0x85, 0xe2, 0xcc, 0x97, 0xa0, 0x74, 0x74, 0x02, 0x37, 0x13, 0x36, 0xec,
+	0x8c, 0xce, 0x53, 0xca, 0xc6, 0x34, 0x1c, 0x52, 0xd6, 0x39, 0x23, 0xa7, 0x2c, 0x1a, 0x64, 0x8a,
+	0x2a, 0xba, 0x2f, 0x6f, 0x0f, 0x23, 0x31, 0x9a, 0x9e, 0x76, 0x06, 0xc9, 0x44, 0xfe, 0x75, 0x14,
+	0xd2, 0x71, 0x14, 0xd3, 0xdb, 0x82, 0x0e, 0x46, 0x07, 0x7a, 0xb9, 0xfe, 0x2b, 0x8a, 0x1f, 0xa8,
+	0xe5, 0xa7, 0xfa, 0x7f, 0xaa, 0x7b, 0xbf, 0x0d, 0x00, 0x1b, 0x6c, 0x2c, 0x7b, 0xc4, 0x12, 0x00,
+	0x00,
 }
@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+// The action types prefixed to the signed bytes, so that a signature
+// over one kind of action cannot be replayed as another.
+const (
+	transferSigningPrefix = "transfer"
+	redeemSigningPrefix   = "redeem"
+	swapSigningPrefix     = "swap"
+)
+
+// SigningBytes returns the bytes the parties of a swap sign, that is,
+// the action type followed by the serialized swap without its signatures.
+func (m *PlainSwap) SigningBytes() ([]byte, error) {
+	return signingBytes(swapSigningPrefix, &PlainSwap{Inputs: m.Inputs, Outputs: m.Outputs})
+}
+
+// SigningBytes returns the bytes the owners of the inputs of a transfer
+// sign, that is, the action type followed by the serialized transfer
+// without its signatures.
+func (m *PlainTransfer) SigningBytes() ([]byte, error) {
+	return signingBytes(transferSigningPrefix, &PlainTransfer{Inputs: m.Inputs, Outputs: m.Outputs})
+}
+
+// RedeemSigningBytes returns the bytes the owners of the inputs of a
+// redeem sign. They differ from the SigningBytes of a transfer with the
+// same inputs and outputs.
+func (m *PlainTransfer) RedeemSigningBytes() ([]byte, error) {
+	return signingBytes(redeemSigningPrefix, &PlainTransfer{Inputs: m.Inputs, Outputs: m.Outputs})
+}
+
+// signingBytes serializes msg and prefixes it with the action type and
+// a zero byte separator.
+func signingBytes(actionType string, msg proto.Message) ([]byte, error) {
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(actionType), 0), raw...), nil
+}
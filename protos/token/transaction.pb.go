@@ -18,6 +18,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type TokenOwner_Type int32
+
+const (
+	// raw is a serialized MSP identity
+	TokenOwner_MSP_IDENTIFIER TokenOwner_Type = 0
+	// raw is a serialized idemix identity, that is, a pseudonym
+	TokenOwner_IDEMIX TokenOwner_Type = 1
+	// raw is a serialized SignaturePolicyEnvelope whose principals must
+	// sign the actions spending the tokens
+	TokenOwner_MULTISIG TokenOwner_Type = 2
+)
+
+var TokenOwner_Type_name = map[int32]string{
+	0: "MSP_IDENTIFIER",
+	1: "IDEMIX",
+	2: "MULTISIG",
+}
+var TokenOwner_Type_value = map[string]int32{
+	"MSP_IDENTIFIER": 0,
+	"IDEMIX":         1,
+	"MULTISIG":       2,
+}
+
+func (x TokenOwner_Type) String() string {
+	return proto.EnumName(TokenOwner_Type_name, int32(x))
+}
+func (TokenOwner_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{8, 0}
+}
+
 // TokenTransaction governs the structure of Payload.data, when
 // the transaction's envelope header indicates a transaction of type
 // "Token"
@@ -26,17 +56,21 @@ type TokenTransaction struct {
 	//
 	// Types that are valid to be assigned to Action:
 	//	*TokenTransaction_PlainAction
-	Action               isTokenTransaction_Action `protobuf_oneof:"action"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	//	*TokenTransaction_ConfidentialAction
+	Action isTokenTransaction_Action `protobuf_oneof:"action"`
+	// chaincode_tx_id, if set, is the identifier of the committed, valid chaincode
+	// transaction whose token expectation this transaction fulfils
+	ChaincodeTxId        string   `protobuf:"bytes,3,opt,name=chaincode_tx_id,json=chaincodeTxId,proto3" json:"chaincode_tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TokenTransaction) Reset()         { *m = TokenTransaction{} }
 func (m *TokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*TokenTransaction) ProtoMessage()    {}
 func (*TokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{0}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{0}
 }
 func (m *TokenTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenTransaction.Unmarshal(m, b)
@@ -64,8 +98,14 @@ type TokenTransaction_PlainAction struct {
 	PlainAction *PlainTokenAction `protobuf:"bytes,1,opt,name=plain_action,json=plainAction,proto3,oneof"`
 }
 
+type TokenTransaction_ConfidentialAction struct {
+	ConfidentialAction *ConfidentialTokenAction `protobuf:"bytes,2,opt,name=confidential_action,json=confidentialAction,proto3,oneof"`
+}
+
 func (*TokenTransaction_PlainAction) isTokenTransaction_Action() {}
 
+func (*TokenTransaction_ConfidentialAction) isTokenTransaction_Action() {}
+
 func (m *TokenTransaction) GetAction() isTokenTransaction_Action {
 	if m != nil {
 		return m.Action
@@ -80,10 +120,25 @@ func (m *TokenTransaction) GetPlainAction() *PlainTokenAction {
 	return nil
 }
 
+func (m *TokenTransaction) GetConfidentialAction() *ConfidentialTokenAction {
+	if x, ok := m.GetAction().(*TokenTransaction_ConfidentialAction); ok {
+		return x.ConfidentialAction
+	}
+	return nil
+}
+
+func (m *TokenTransaction) GetChaincodeTxId() string {
+	if m != nil {
+		return m.ChaincodeTxId
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TokenTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TokenTransaction_OneofMarshaler, _TokenTransaction_OneofUnmarshaler, _TokenTransaction_OneofSizer, []interface{}{
 		(*TokenTransaction_PlainAction)(nil),
+		(*TokenTransaction_ConfidentialAction)(nil),
 	}
 }
 
@@ -96,6 +151,11 @@ func _TokenTransaction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainAction); err != nil {
 			return err
 		}
+	case *TokenTransaction_ConfidentialAction:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialAction); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TokenTransaction.Action has unexpected type %T", x)
@@ -114,6 +174,14 @@ func _TokenTransaction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Action = &TokenTransaction_PlainAction{msg}
 		return true, err
+	case 2: // action.confidential_action
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialTokenAction)
+		err := b.DecodeMessage(msg)
+		m.Action = &TokenTransaction_ConfidentialAction{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -128,6 +196,11 @@ func _TokenTransaction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TokenTransaction_ConfidentialAction:
+		s := proto.Size(x.ConfidentialAction)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -144,6 +217,8 @@ type PlainTokenAction struct {
 	//	*PlainTokenAction_PlainRedeem
 	//	*PlainTokenAction_PlainApprove
 	//	*PlainTokenAction_PlainTransfer_From
+	//	*PlainTokenAction_PlainTypeRegistration
+	//	*PlainTokenAction_PlainSwap
 	Data                 isPlainTokenAction_Data `protobuf_oneof:"data"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -154,7 +229,7 @@ func (m *PlainTokenAction) Reset()         { *m = PlainTokenAction{} }
 func (m *PlainTokenAction) String() string { return proto.CompactTextString(m) }
 func (*PlainTokenAction) ProtoMessage()    {}
 func (*PlainTokenAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{1}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{1}
 }
 func (m *PlainTokenAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTokenAction.Unmarshal(m, b)
@@ -198,6 +273,14 @@ type PlainTokenAction_PlainTransfer_From struct {
 	PlainTransfer_From *PlainTransferFrom `protobuf:"bytes,5,opt,name=plain_transfer_From,json=plainTransferFrom,proto3,oneof"`
 }
 
+type PlainTokenAction_PlainTypeRegistration struct {
+	PlainTypeRegistration *PlainTypeRegistration `protobuf:"bytes,6,opt,name=plain_type_registration,json=plainTypeRegistration,proto3,oneof"`
+}
+
+type PlainTokenAction_PlainSwap struct {
+	PlainSwap *PlainSwap `protobuf:"bytes,7,opt,name=plain_swap,json=plainSwap,proto3,oneof"`
+}
+
 func (*PlainTokenAction_PlainImport) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer) isPlainTokenAction_Data() {}
@@ -208,6 +291,10 @@ func (*PlainTokenAction_PlainApprove) isPlainTokenAction_Data() {}
 
 func (*PlainTokenAction_PlainTransfer_From) isPlainTokenAction_Data() {}
 
+func (*PlainTokenAction_PlainTypeRegistration) isPlainTokenAction_Data() {}
+
+func (*PlainTokenAction_PlainSwap) isPlainTokenAction_Data() {}
+
 func (m *PlainTokenAction) GetData() isPlainTokenAction_Data {
 	if m != nil {
 		return m.Data
@@ -250,6 +337,20 @@ func (m *PlainTokenAction) GetPlainTransfer_From() *PlainTransferFrom {
 	return nil
 }
 
+func (m *PlainTokenAction) GetPlainTypeRegistration() *PlainTypeRegistration {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainTypeRegistration); ok {
+		return x.PlainTypeRegistration
+	}
+	return nil
+}
+
+func (m *PlainTokenAction) GetPlainSwap() *PlainSwap {
+	if x, ok := m.GetData().(*PlainTokenAction_PlainSwap); ok {
+		return x.PlainSwap
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PlainTokenAction_OneofMarshaler, _PlainTokenAction_OneofUnmarshaler, _PlainTokenAction_OneofSizer, []interface{}{
@@ -258,6 +359,8 @@ func (*PlainTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buff
 		(*PlainTokenAction_PlainRedeem)(nil),
 		(*PlainTokenAction_PlainApprove)(nil),
 		(*PlainTokenAction_PlainTransfer_From)(nil),
+		(*PlainTokenAction_PlainTypeRegistration)(nil),
+		(*PlainTokenAction_PlainSwap)(nil),
 	}
 }
 
@@ -290,6 +393,16 @@ func _PlainTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.PlainTransfer_From); err != nil {
 			return err
 		}
+	case *PlainTokenAction_PlainTypeRegistration:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainTypeRegistration); err != nil {
+			return err
+		}
+	case *PlainTokenAction_PlainSwap:
+		b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PlainSwap); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("PlainTokenAction.Data has unexpected type %T", x)
@@ -340,6 +453,22 @@ func _PlainTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Data = &PlainTokenAction_PlainTransfer_From{msg}
 		return true, err
+	case 6: // data.plain_type_registration
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainTypeRegistration)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainTypeRegistration{msg}
+		return true, err
+	case 7: // data.plain_swap
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PlainSwap)
+		err := b.DecodeMessage(msg)
+		m.Data = &PlainTokenAction_PlainSwap{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -374,6 +503,16 @@ func _PlainTokenAction_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *PlainTokenAction_PlainTypeRegistration:
+		s := proto.Size(x.PlainTypeRegistration)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *PlainTokenAction_PlainSwap:
+		s := proto.Size(x.PlainSwap)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -394,7 +533,7 @@ func (m *PlainImport) Reset()         { *m = PlainImport{} }
 func (m *PlainImport) String() string { return proto.CompactTextString(m) }
 func (*PlainImport) ProtoMessage()    {}
 func (*PlainImport) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{2}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{2}
 }
 func (m *PlainImport) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainImport.Unmarshal(m, b)
@@ -426,17 +565,20 @@ type PlainTransfer struct {
 	// The inputs to the transfer transaction are specified by their ID
 	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
 	// A transfer transaction may contain one or more outputs
-	Outputs              []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Outputs []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The signatures of the owners of the inputs whose ownership is not
+	// proven by the creator of the transaction alone
+	Signatures           []*OwnerSignature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *PlainTransfer) Reset()         { *m = PlainTransfer{} }
 func (m *PlainTransfer) String() string { return proto.CompactTextString(m) }
 func (*PlainTransfer) ProtoMessage()    {}
 func (*PlainTransfer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{3}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{3}
 }
 func (m *PlainTransfer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransfer.Unmarshal(m, b)
@@ -470,6 +612,13 @@ func (m *PlainTransfer) GetOutputs() []*PlainOutput {
 	return nil
 }
 
+func (m *PlainTransfer) GetSignatures() []*OwnerSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
 // PlainApprove specifies an approve of one or more tokens in plaintext format
 type PlainApprove struct {
 	// The inputs to the transfer transaction are specified by their ID
@@ -487,7 +636,7 @@ func (m *PlainApprove) Reset()         { *m = PlainApprove{} }
 func (m *PlainApprove) String() string { return proto.CompactTextString(m) }
 func (*PlainApprove) ProtoMessage()    {}
 func (*PlainApprove) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{4}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{4}
 }
 func (m *PlainApprove) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainApprove.Unmarshal(m, b)
@@ -546,7 +695,7 @@ func (m *PlainTransferFrom) Reset()         { *m = PlainTransferFrom{} }
 func (m *PlainTransferFrom) String() string { return proto.CompactTextString(m) }
 func (*PlainTransferFrom) ProtoMessage()    {}
 func (*PlainTransferFrom) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{5}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{5}
 }
 func (m *PlainTransferFrom) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainTransferFrom.Unmarshal(m, b)
@@ -587,6 +736,283 @@ func (m *PlainTransferFrom) GetDelegatedOutput() *PlainDelegatedOutput {
 	return nil
 }
 
+// PlainSwap specifies an atomic exchange of plaintext tokens of different types between parties.
+// The quantity of each token type in the inputs must match the quantity of that type in the outputs.
+type PlainSwap struct {
+	// The inputs to the swap transaction are specified by their ID.
+	// They may be owned by different parties and be of different types
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// A swap transaction contains multiple outputs
+	Outputs []*PlainOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// The signatures of the owners of the inputs other than the creator of the transaction
+	Signatures           []*OwnerSignature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PlainSwap) Reset()         { *m = PlainSwap{} }
+func (m *PlainSwap) String() string { return proto.CompactTextString(m) }
+func (*PlainSwap) ProtoMessage()    {}
+func (*PlainSwap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{6}
+}
+func (m *PlainSwap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainSwap.Unmarshal(m, b)
+}
+func (m *PlainSwap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainSwap.Marshal(b, m, deterministic)
+}
+func (dst *PlainSwap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainSwap.Merge(dst, src)
+}
+func (m *PlainSwap) XXX_Size() int {
+	return xxx_messageInfo_PlainSwap.Size(m)
+}
+func (m *PlainSwap) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainSwap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainSwap proto.InternalMessageInfo
+
+func (m *PlainSwap) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *PlainSwap) GetOutputs() []*PlainOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *PlainSwap) GetSignatures() []*OwnerSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// OwnerSignature is the signature of a party whose tokens are spent by an action
+type OwnerSignature struct {
+	// The serialized identity of the signer
+	Signer []byte `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// The signature over the action, computed without any signature
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OwnerSignature) Reset()         { *m = OwnerSignature{} }
+func (m *OwnerSignature) String() string { return proto.CompactTextString(m) }
+func (*OwnerSignature) ProtoMessage()    {}
+func (*OwnerSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{7}
+}
+func (m *OwnerSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerSignature.Unmarshal(m, b)
+}
+func (m *OwnerSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerSignature.Marshal(b, m, deterministic)
+}
+func (dst *OwnerSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerSignature.Merge(dst, src)
+}
+func (m *OwnerSignature) XXX_Size() int {
+	return xxx_messageInfo_OwnerSignature.Size(m)
+}
+func (m *OwnerSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerSignature proto.InternalMessageInfo
+
+func (m *OwnerSignature) GetSigner() []byte {
+	if m != nil {
+		return m.Signer
+	}
+	return nil
+}
+
+func (m *OwnerSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TokenOwner identifies the owner of an output when the owner is not a
+// plain serialized MSP identity; the owner field of the output then holds
+// the serialized TokenOwner.
+type TokenOwner struct {
+	Type                 TokenOwner_Type `protobuf:"varint,1,opt,name=type,proto3,enum=TokenOwner_Type" json:"type,omitempty"`
+	Raw                  []byte          `protobuf:"bytes,2,opt,name=raw,proto3" json:"raw,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TokenOwner) Reset()         { *m = TokenOwner{} }
+func (m *TokenOwner) String() string { return proto.CompactTextString(m) }
+func (*TokenOwner) ProtoMessage()    {}
+func (*TokenOwner) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{8}
+}
+func (m *TokenOwner) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenOwner.Unmarshal(m, b)
+}
+func (m *TokenOwner) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenOwner.Marshal(b, m, deterministic)
+}
+func (dst *TokenOwner) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenOwner.Merge(dst, src)
+}
+func (m *TokenOwner) XXX_Size() int {
+	return xxx_messageInfo_TokenOwner.Size(m)
+}
+func (m *TokenOwner) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenOwner.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenOwner proto.InternalMessageInfo
+
+func (m *TokenOwner) GetType() TokenOwner_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TokenOwner_MSP_IDENTIFIER
+}
+
+func (m *TokenOwner) GetRaw() []byte {
+	if m != nil {
+		return m.Raw
+	}
+	return nil
+}
+
+// PlainTypeRegistration adds the definition of a token type to the token type registry of a channel
+type PlainTypeRegistration struct {
+	// The definition of the token type to register
+	Definition           *TokenTypeDefinition `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PlainTypeRegistration) Reset()         { *m = PlainTypeRegistration{} }
+func (m *PlainTypeRegistration) String() string { return proto.CompactTextString(m) }
+func (*PlainTypeRegistration) ProtoMessage()    {}
+func (*PlainTypeRegistration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{9}
+}
+func (m *PlainTypeRegistration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlainTypeRegistration.Unmarshal(m, b)
+}
+func (m *PlainTypeRegistration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlainTypeRegistration.Marshal(b, m, deterministic)
+}
+func (dst *PlainTypeRegistration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlainTypeRegistration.Merge(dst, src)
+}
+func (m *PlainTypeRegistration) XXX_Size() int {
+	return xxx_messageInfo_PlainTypeRegistration.Size(m)
+}
+func (m *PlainTypeRegistration) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlainTypeRegistration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlainTypeRegistration proto.InternalMessageInfo
+
+func (m *PlainTypeRegistration) GetDefinition() *TokenTypeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// TokenTypeDefinition carries the metadata of a token type
+type TokenTypeDefinition struct {
+	// The token type
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The human readable name of the token type
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// The number of decimal places used to display quantities.
+	// Quantities are always expressed in the smallest unit of the token type
+	Decimals uint32 `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// The maximum quantity of tokens of this type that can be imported
+	// after the registration. Zero means no cap
+	MaxSupply uint64 `protobuf:"varint,4,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	// The serialized identities allowed to import tokens of this type.
+	// If empty, any creator satisfying the issuing policy of the channel is allowed
+	Issuers              [][]byte `protobuf:"bytes,5,rep,name=issuers,proto3" json:"issuers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenTypeDefinition) Reset()         { *m = TokenTypeDefinition{} }
+func (m *TokenTypeDefinition) String() string { return proto.CompactTextString(m) }
+func (*TokenTypeDefinition) ProtoMessage()    {}
+func (*TokenTypeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{10}
+}
+func (m *TokenTypeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTypeDefinition.Unmarshal(m, b)
+}
+func (m *TokenTypeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTypeDefinition.Marshal(b, m, deterministic)
+}
+func (dst *TokenTypeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTypeDefinition.Merge(dst, src)
+}
+func (m *TokenTypeDefinition) XXX_Size() int {
+	return xxx_messageInfo_TokenTypeDefinition.Size(m)
+}
+func (m *TokenTypeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTypeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTypeDefinition proto.InternalMessageInfo
+
+func (m *TokenTypeDefinition) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenTypeDefinition) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *TokenTypeDefinition) GetDecimals() uint32 {
+	if m != nil {
+		return m.Decimals
+	}
+	return 0
+}
+
+func (m *TokenTypeDefinition) GetMaxSupply() uint64 {
+	if m != nil {
+		return m.MaxSupply
+	}
+	return 0
+}
+
+func (m *TokenTypeDefinition) GetIssuers() [][]byte {
+	if m != nil {
+		return m.Issuers
+	}
+	return nil
+}
+
 // A PlainOutput is the result of import and transfer transactions using plaintext tokens
 type PlainOutput struct {
 	// The owner is the serialization of a SerializedIdentity struct
@@ -604,7 +1030,7 @@ func (m *PlainOutput) Reset()         { *m = PlainOutput{} }
 func (m *PlainOutput) String() string { return proto.CompactTextString(m) }
 func (*PlainOutput) ProtoMessage()    {}
 func (*PlainOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{6}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{11}
 }
 func (m *PlainOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainOutput.Unmarshal(m, b)
@@ -660,7 +1086,7 @@ func (m *InputId) Reset()         { *m = InputId{} }
 func (m *InputId) String() string { return proto.CompactTextString(m) }
 func (*InputId) ProtoMessage()    {}
 func (*InputId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{7}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{12}
 }
 func (m *InputId) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InputId.Unmarshal(m, b)
@@ -714,7 +1140,7 @@ func (m *PlainDelegatedOutput) Reset()         { *m = PlainDelegatedOutput{} }
 func (m *PlainDelegatedOutput) String() string { return proto.CompactTextString(m) }
 func (*PlainDelegatedOutput) ProtoMessage()    {}
 func (*PlainDelegatedOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_a359b0c8d648a383, []int{8}
+	return fileDescriptor_transaction_8706352289fb60fb, []int{13}
 }
 func (m *PlainDelegatedOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlainDelegatedOutput.Unmarshal(m, b)
@@ -762,6 +1188,690 @@ func (m *PlainDelegatedOutput) GetQuantity() uint64 {
 	return 0
 }
 
+// ConfidentialTokenAction governs the structure of a token action whose
+// quantities are hidden behind Pedersen commitments
+type ConfidentialTokenAction struct {
+	// Types that are valid to be assigned to Data:
+	//	*ConfidentialTokenAction_ConfidentialImport
+	//	*ConfidentialTokenAction_ConfidentialTransfer
+	//	*ConfidentialTokenAction_ConfidentialRedeem
+	Data                 isConfidentialTokenAction_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ConfidentialTokenAction) Reset()         { *m = ConfidentialTokenAction{} }
+func (m *ConfidentialTokenAction) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialTokenAction) ProtoMessage()    {}
+func (*ConfidentialTokenAction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{14}
+}
+func (m *ConfidentialTokenAction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialTokenAction.Unmarshal(m, b)
+}
+func (m *ConfidentialTokenAction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialTokenAction.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialTokenAction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialTokenAction.Merge(dst, src)
+}
+func (m *ConfidentialTokenAction) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialTokenAction.Size(m)
+}
+func (m *ConfidentialTokenAction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialTokenAction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialTokenAction proto.InternalMessageInfo
+
+type isConfidentialTokenAction_Data interface {
+	isConfidentialTokenAction_Data()
+}
+
+type ConfidentialTokenAction_ConfidentialImport struct {
+	ConfidentialImport *ConfidentialImport `protobuf:"bytes,1,opt,name=confidential_import,json=confidentialImport,proto3,oneof"`
+}
+
+type ConfidentialTokenAction_ConfidentialTransfer struct {
+	ConfidentialTransfer *ConfidentialTransfer `protobuf:"bytes,2,opt,name=confidential_transfer,json=confidentialTransfer,proto3,oneof"`
+}
+
+type ConfidentialTokenAction_ConfidentialRedeem struct {
+	ConfidentialRedeem *ConfidentialRedeem `protobuf:"bytes,3,opt,name=confidential_redeem,json=confidentialRedeem,proto3,oneof"`
+}
+
+func (*ConfidentialTokenAction_ConfidentialImport) isConfidentialTokenAction_Data() {}
+
+func (*ConfidentialTokenAction_ConfidentialTransfer) isConfidentialTokenAction_Data() {}
+
+func (*ConfidentialTokenAction_ConfidentialRedeem) isConfidentialTokenAction_Data() {}
+
+func (m *ConfidentialTokenAction) GetData() isConfidentialTokenAction_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialImport() *ConfidentialImport {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialImport); ok {
+		return x.ConfidentialImport
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialTransfer() *ConfidentialTransfer {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialTransfer); ok {
+		return x.ConfidentialTransfer
+	}
+	return nil
+}
+
+func (m *ConfidentialTokenAction) GetConfidentialRedeem() *ConfidentialRedeem {
+	if x, ok := m.GetData().(*ConfidentialTokenAction_ConfidentialRedeem); ok {
+		return x.ConfidentialRedeem
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ConfidentialTokenAction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ConfidentialTokenAction_OneofMarshaler, _ConfidentialTokenAction_OneofUnmarshaler, _ConfidentialTokenAction_OneofSizer, []interface{}{
+		(*ConfidentialTokenAction_ConfidentialImport)(nil),
+		(*ConfidentialTokenAction_ConfidentialTransfer)(nil),
+		(*ConfidentialTokenAction_ConfidentialRedeem)(nil),
+	}
+}
+
+func _ConfidentialTokenAction_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ConfidentialTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ConfidentialTokenAction_ConfidentialImport:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialImport); err != nil {
+			return err
+		}
+	case *ConfidentialTokenAction_ConfidentialTransfer:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialTransfer); err != nil {
+			return err
+		}
+	case *ConfidentialTokenAction_ConfidentialRedeem:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ConfidentialRedeem); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ConfidentialTokenAction.Data has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ConfidentialTokenAction_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ConfidentialTokenAction)
+	switch tag {
+	case 1: // data.confidential_import
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialImport)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialImport{msg}
+		return true, err
+	case 2: // data.confidential_transfer
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialTransfer)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialTransfer{msg}
+		return true, err
+	case 3: // data.confidential_redeem
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfidentialRedeem)
+		err := b.DecodeMessage(msg)
+		m.Data = &ConfidentialTokenAction_ConfidentialRedeem{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ConfidentialTokenAction_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ConfidentialTokenAction)
+	// data
+	switch x := m.Data.(type) {
+	case *ConfidentialTokenAction_ConfidentialImport:
+		s := proto.Size(x.ConfidentialImport)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfidentialTokenAction_ConfidentialTransfer:
+		s := proto.Size(x.ConfidentialTransfer)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfidentialTokenAction_ConfidentialRedeem:
+		s := proto.Size(x.ConfidentialRedeem)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// ConfidentialImport specifies an import of one or more tokens with hidden quantities
+type ConfidentialImport struct {
+	// An import transaction may contain one or more outputs
+	Outputs              []*ConfidentialOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ConfidentialImport) Reset()         { *m = ConfidentialImport{} }
+func (m *ConfidentialImport) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialImport) ProtoMessage()    {}
+func (*ConfidentialImport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{15}
+}
+func (m *ConfidentialImport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialImport.Unmarshal(m, b)
+}
+func (m *ConfidentialImport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialImport.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialImport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialImport.Merge(dst, src)
+}
+func (m *ConfidentialImport) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialImport.Size(m)
+}
+func (m *ConfidentialImport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialImport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialImport proto.InternalMessageInfo
+
+func (m *ConfidentialImport) GetOutputs() []*ConfidentialOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+// ConfidentialTransfer specifies a transfer of one or more tokens with hidden quantities
+type ConfidentialTransfer struct {
+	// The inputs to the transfer transaction are specified by their ID
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// A transfer transaction may contain one or more outputs
+	Outputs []*ConfidentialOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// BalanceProof proves that the inputs and the outputs commit to the same quantity
+	BalanceProof         *SchnorrProof `protobuf:"bytes,3,opt,name=balance_proof,json=balanceProof,proto3" json:"balance_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ConfidentialTransfer) Reset()         { *m = ConfidentialTransfer{} }
+func (m *ConfidentialTransfer) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialTransfer) ProtoMessage()    {}
+func (*ConfidentialTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{16}
+}
+func (m *ConfidentialTransfer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialTransfer.Unmarshal(m, b)
+}
+func (m *ConfidentialTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialTransfer.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialTransfer.Merge(dst, src)
+}
+func (m *ConfidentialTransfer) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialTransfer.Size(m)
+}
+func (m *ConfidentialTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialTransfer proto.InternalMessageInfo
+
+func (m *ConfidentialTransfer) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ConfidentialTransfer) GetOutputs() []*ConfidentialOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ConfidentialTransfer) GetBalanceProof() *SchnorrProof {
+	if m != nil {
+		return m.BalanceProof
+	}
+	return nil
+}
+
+// ConfidentialRedeem specifies the redemption of a public quantity of tokens with hidden quantities
+type ConfidentialRedeem struct {
+	// The inputs to the redeem transaction are specified by their ID
+	Inputs []*InputId `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	// Quantity is the number of units that are redeemed
+	Quantity uint64 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The outputs returning the remaining units, if any, to the owner of the inputs
+	Outputs []*ConfidentialOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// BalanceProof proves that the inputs commit to the redeemed quantity plus the quantity of the outputs
+	BalanceProof         *SchnorrProof `protobuf:"bytes,4,opt,name=balance_proof,json=balanceProof,proto3" json:"balance_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ConfidentialRedeem) Reset()         { *m = ConfidentialRedeem{} }
+func (m *ConfidentialRedeem) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialRedeem) ProtoMessage()    {}
+func (*ConfidentialRedeem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{17}
+}
+func (m *ConfidentialRedeem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialRedeem.Unmarshal(m, b)
+}
+func (m *ConfidentialRedeem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialRedeem.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialRedeem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialRedeem.Merge(dst, src)
+}
+func (m *ConfidentialRedeem) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialRedeem.Size(m)
+}
+func (m *ConfidentialRedeem) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialRedeem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialRedeem proto.InternalMessageInfo
+
+func (m *ConfidentialRedeem) GetInputs() []*InputId {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *ConfidentialRedeem) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *ConfidentialRedeem) GetOutputs() []*ConfidentialOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *ConfidentialRedeem) GetBalanceProof() *SchnorrProof {
+	if m != nil {
+		return m.BalanceProof
+	}
+	return nil
+}
+
+// A ConfidentialOutput is the result of confidential import and transfer transactions
+type ConfidentialOutput struct {
+	// The owner is the serialized identity of the party allowed to spend the output
+	Owner []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The token type
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Commitment is the Pedersen commitment to the quantity of the output
+	Commitment []byte `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// RangeProof proves that the committed quantity is a 64-bit unsigned integer
+	RangeProof *RangeProof `protobuf:"bytes,4,opt,name=range_proof,json=rangeProof,proto3" json:"range_proof,omitempty"`
+	// Opening is the opening of the commitment, encrypted for the owner
+	Opening              *EncryptedOpening `protobuf:"bytes,5,opt,name=opening,proto3" json:"opening,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConfidentialOutput) Reset()         { *m = ConfidentialOutput{} }
+func (m *ConfidentialOutput) String() string { return proto.CompactTextString(m) }
+func (*ConfidentialOutput) ProtoMessage()    {}
+func (*ConfidentialOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{18}
+}
+func (m *ConfidentialOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfidentialOutput.Unmarshal(m, b)
+}
+func (m *ConfidentialOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfidentialOutput.Marshal(b, m, deterministic)
+}
+func (dst *ConfidentialOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfidentialOutput.Merge(dst, src)
+}
+func (m *ConfidentialOutput) XXX_Size() int {
+	return xxx_messageInfo_ConfidentialOutput.Size(m)
+}
+func (m *ConfidentialOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfidentialOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfidentialOutput proto.InternalMessageInfo
+
+func (m *ConfidentialOutput) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ConfidentialOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ConfidentialOutput) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *ConfidentialOutput) GetRangeProof() *RangeProof {
+	if m != nil {
+		return m.RangeProof
+	}
+	return nil
+}
+
+func (m *ConfidentialOutput) GetOpening() *EncryptedOpening {
+	if m != nil {
+		return m.Opening
+	}
+	return nil
+}
+
+// RangeProof proves that a Pedersen commitment hides a 64-bit unsigned integer
+// by committing to each bit of the quantity separately
+type RangeProof struct {
+	Bits                 []*BitProof `protobuf:"bytes,1,rep,name=bits,proto3" json:"bits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RangeProof) Reset()         { *m = RangeProof{} }
+func (m *RangeProof) String() string { return proto.CompactTextString(m) }
+func (*RangeProof) ProtoMessage()    {}
+func (*RangeProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{19}
+}
+func (m *RangeProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RangeProof.Unmarshal(m, b)
+}
+func (m *RangeProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RangeProof.Marshal(b, m, deterministic)
+}
+func (dst *RangeProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeProof.Merge(dst, src)
+}
+func (m *RangeProof) XXX_Size() int {
+	return xxx_messageInfo_RangeProof.Size(m)
+}
+func (m *RangeProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeProof proto.InternalMessageInfo
+
+func (m *RangeProof) GetBits() []*BitProof {
+	if m != nil {
+		return m.Bits
+	}
+	return nil
+}
+
+// BitProof proves that a Pedersen commitment hides either 0 or 1
+type BitProof struct {
+	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Challenge0           []byte   `protobuf:"bytes,2,opt,name=challenge0,proto3" json:"challenge0,omitempty"`
+	Challenge1           []byte   `protobuf:"bytes,3,opt,name=challenge1,proto3" json:"challenge1,omitempty"`
+	Response0            []byte   `protobuf:"bytes,4,opt,name=response0,proto3" json:"response0,omitempty"`
+	Response1            []byte   `protobuf:"bytes,5,opt,name=response1,proto3" json:"response1,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BitProof) Reset()         { *m = BitProof{} }
+func (m *BitProof) String() string { return proto.CompactTextString(m) }
+func (*BitProof) ProtoMessage()    {}
+func (*BitProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{20}
+}
+func (m *BitProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BitProof.Unmarshal(m, b)
+}
+func (m *BitProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BitProof.Marshal(b, m, deterministic)
+}
+func (dst *BitProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BitProof.Merge(dst, src)
+}
+func (m *BitProof) XXX_Size() int {
+	return xxx_messageInfo_BitProof.Size(m)
+}
+func (m *BitProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_BitProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BitProof proto.InternalMessageInfo
+
+func (m *BitProof) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *BitProof) GetChallenge0() []byte {
+	if m != nil {
+		return m.Challenge0
+	}
+	return nil
+}
+
+func (m *BitProof) GetChallenge1() []byte {
+	if m != nil {
+		return m.Challenge1
+	}
+	return nil
+}
+
+func (m *BitProof) GetResponse0() []byte {
+	if m != nil {
+		return m.Response0
+	}
+	return nil
+}
+
+func (m *BitProof) GetResponse1() []byte {
+	if m != nil {
+		return m.Response1
+	}
+	return nil
+}
+
+// SchnorrProof proves the knowledge of a discrete logarithm
+type SchnorrProof struct {
+	Commitment           []byte   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Response             []byte   `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchnorrProof) Reset()         { *m = SchnorrProof{} }
+func (m *SchnorrProof) String() string { return proto.CompactTextString(m) }
+func (*SchnorrProof) ProtoMessage()    {}
+func (*SchnorrProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{21}
+}
+func (m *SchnorrProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchnorrProof.Unmarshal(m, b)
+}
+func (m *SchnorrProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchnorrProof.Marshal(b, m, deterministic)
+}
+func (dst *SchnorrProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchnorrProof.Merge(dst, src)
+}
+func (m *SchnorrProof) XXX_Size() int {
+	return xxx_messageInfo_SchnorrProof.Size(m)
+}
+func (m *SchnorrProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchnorrProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchnorrProof proto.InternalMessageInfo
+
+func (m *SchnorrProof) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *SchnorrProof) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// TokenOpening carries the values committed to by a ConfidentialOutput
+type TokenOpening struct {
+	// The quantity of the output
+	Quantity uint64 `protobuf:"varint,1,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The blinding factor of the commitment
+	BlindingFactor       []byte   `protobuf:"bytes,2,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenOpening) Reset()         { *m = TokenOpening{} }
+func (m *TokenOpening) String() string { return proto.CompactTextString(m) }
+func (*TokenOpening) ProtoMessage()    {}
+func (*TokenOpening) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{22}
+}
+func (m *TokenOpening) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenOpening.Unmarshal(m, b)
+}
+func (m *TokenOpening) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenOpening.Marshal(b, m, deterministic)
+}
+func (dst *TokenOpening) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenOpening.Merge(dst, src)
+}
+func (m *TokenOpening) XXX_Size() int {
+	return xxx_messageInfo_TokenOpening.Size(m)
+}
+func (m *TokenOpening) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenOpening.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenOpening proto.InternalMessageInfo
+
+func (m *TokenOpening) GetQuantity() uint64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *TokenOpening) GetBlindingFactor() []byte {
+	if m != nil {
+		return m.BlindingFactor
+	}
+	return nil
+}
+
+// EncryptedOpening is a TokenOpening encrypted with an ephemeral ECDH key agreement
+// against the public key of the owner
+type EncryptedOpening struct {
+	EphemeralPublicKey   []byte   `protobuf:"bytes,1,opt,name=ephemeral_public_key,json=ephemeralPublicKey,proto3" json:"ephemeral_public_key,omitempty"`
+	Nonce                []byte   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Ciphertext           []byte   `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptedOpening) Reset()         { *m = EncryptedOpening{} }
+func (m *EncryptedOpening) String() string { return proto.CompactTextString(m) }
+func (*EncryptedOpening) ProtoMessage()    {}
+func (*EncryptedOpening) Descriptor() ([]byte, []int) {
+	return fileDescriptor_transaction_8706352289fb60fb, []int{23}
+}
+func (m *EncryptedOpening) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptedOpening.Unmarshal(m, b)
+}
+func (m *EncryptedOpening) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptedOpening.Marshal(b, m, deterministic)
+}
+func (dst *EncryptedOpening) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptedOpening.Merge(dst, src)
+}
+func (m *EncryptedOpening) XXX_Size() int {
+	return xxx_messageInfo_EncryptedOpening.Size(m)
+}
+func (m *EncryptedOpening) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptedOpening.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptedOpening proto.InternalMessageInfo
+
+func (m *EncryptedOpening) GetEphemeralPublicKey() []byte {
+	if m != nil {
+		return m.EphemeralPublicKey
+	}
+	return nil
+}
+
+func (m *EncryptedOpening) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *EncryptedOpening) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TokenTransaction)(nil), "TokenTransaction")
 	proto.RegisterType((*PlainTokenAction)(nil), "PlainTokenAction")
@@ -769,47 +1879,110 @@ func init() {
 	proto.RegisterType((*PlainTransfer)(nil), "PlainTransfer")
 	proto.RegisterType((*PlainApprove)(nil), "PlainApprove")
 	proto.RegisterType((*PlainTransferFrom)(nil), "PlainTransferFrom")
+	proto.RegisterType((*PlainSwap)(nil), "PlainSwap")
+	proto.RegisterType((*OwnerSignature)(nil), "OwnerSignature")
+	proto.RegisterType((*TokenOwner)(nil), "TokenOwner")
+	proto.RegisterType((*PlainTypeRegistration)(nil), "PlainTypeRegistration")
+	proto.RegisterType((*TokenTypeDefinition)(nil), "TokenTypeDefinition")
 	proto.RegisterType((*PlainOutput)(nil), "PlainOutput")
 	proto.RegisterType((*InputId)(nil), "InputId")
 	proto.RegisterType((*PlainDelegatedOutput)(nil), "PlainDelegatedOutput")
+	proto.RegisterType((*ConfidentialTokenAction)(nil), "ConfidentialTokenAction")
+	proto.RegisterType((*ConfidentialImport)(nil), "ConfidentialImport")
+	proto.RegisterType((*ConfidentialTransfer)(nil), "ConfidentialTransfer")
+	proto.RegisterType((*ConfidentialRedeem)(nil), "ConfidentialRedeem")
+	proto.RegisterType((*ConfidentialOutput)(nil), "ConfidentialOutput")
+	proto.RegisterType((*RangeProof)(nil), "RangeProof")
+	proto.RegisterType((*BitProof)(nil), "BitProof")
+	proto.RegisterType((*SchnorrProof)(nil), "SchnorrProof")
+	proto.RegisterType((*TokenOpening)(nil), "TokenOpening")
+	proto.RegisterType((*EncryptedOpening)(nil), "EncryptedOpening")
+	proto.RegisterEnum("TokenOwner_Type", TokenOwner_Type_name, TokenOwner_Type_value)
 }
 
 func init() {
-	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_a359b0c8d648a383)
-}
-
-var fileDescriptor_transaction_a359b0c8d648a383 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4f, 0x8f, 0xd2, 0x40,
-	0x14, 0xa7, 0x50, 0xba, 0xf8, 0x68, 0x57, 0x98, 0x5d, 0x63, 0xe3, 0xc1, 0x90, 0xc6, 0x18, 0x63,
-	0x0c, 0x8d, 0xee, 0xaa, 0x57, 0x97, 0x6c, 0x0c, 0x9c, 0x34, 0x23, 0x17, 0xbd, 0x90, 0xc2, 0xcc,
-	0xb2, 0x8d, 0xd0, 0x19, 0x87, 0x41, 0x21, 0xf1, 0x93, 0x78, 0xf1, 0x63, 0xf8, 0xf5, 0x4c, 0xdf,
-	0x4c, 0xd9, 0x16, 0xc5, 0x78, 0xd8, 0x5b, 0x7f, 0xbf, 0x37, 0xbf, 0x3f, 0x6f, 0xda, 0x14, 0xee,
-	0x6b, 0xf1, 0x99, 0x67, 0xb1, 0x56, 0x49, 0xb6, 0x4a, 0x66, 0x3a, 0x15, 0x59, 0x5f, 0x2a, 0xa1,
-	0x45, 0x34, 0x86, 0xce, 0x38, 0x1f, 0x8d, 0x6f, 0x26, 0xe4, 0x15, 0xf8, 0x72, 0x91, 0xa4, 0xd9,
-	0xc4, 0xe0, 0xd0, 0xe9, 0x39, 0x4f, 0xda, 0x2f, 0xba, 0xfd, 0xf7, 0x39, 0x89, 0xa7, 0x2f, 0x70,
-	0x30, 0xac, 0xd1, 0x36, 0x1e, 0x34, 0x70, 0xd0, 0x02, 0xcf, 0x28, 0xa2, 0x5f, 0x75, 0xe8, 0xec,
-	0x9f, 0x26, 0xcf, 0x0b, 0xdb, 0x74, 0x29, 0x85, 0xd2, 0xd6, 0xd6, 0x37, 0xb6, 0x23, 0xe4, 0x76,
-	0x8e, 0x06, 0x92, 0xd7, 0x70, 0x6c, 0x24, 0x58, 0xfc, 0x8a, 0xab, 0xb0, 0x8e, 0xa2, 0x63, 0xdb,
-	0xc5, 0xb2, 0xc3, 0x1a, 0x0d, 0x64, 0x99, 0x20, 0x67, 0x45, 0x96, 0xe2, 0x8c, 0xf3, 0x65, 0xd8,
-	0x38, 0x20, 0x33, 0x69, 0x14, 0x0f, 0x91, 0x73, 0x08, 0xec, 0xde, 0x52, 0x2a, 0xf1, 0x95, 0x87,
-	0x2e, 0xaa, 0x02, 0xa3, 0xba, 0x30, 0xe4, 0xb0, 0x46, 0x8d, 0xb5, 0xc5, 0xe4, 0x12, 0x4e, 0xaa,
-	0x1d, 0x27, 0x6f, 0x95, 0x58, 0x86, 0x4d, 0xd4, 0x92, 0x6a, 0x62, 0x3e, 0x19, 0xd6, 0x68, 0x57,
-	0xee, 0x93, 0x03, 0x0f, 0x5c, 0x96, 0xe8, 0x24, 0x7a, 0x09, 0xed, 0xd2, 0x7d, 0x90, 0xc7, 0x70,
-	0x24, 0xd6, 0x5a, 0xae, 0xf5, 0x2a, 0x74, 0x7a, 0x8d, 0x9b, 0xeb, 0x7a, 0x87, 0x24, 0x2d, 0x86,
-	0xd1, 0x47, 0x08, 0x2a, 0x41, 0xa4, 0x07, 0x5e, 0x9a, 0x95, 0x74, 0xad, 0xfe, 0x28, 0x87, 0x23,
-	0x46, 0x2d, 0x5f, 0xb6, 0xae, 0xff, 0xcb, 0xfa, 0x87, 0x03, 0x7e, 0xf9, 0x02, 0xfe, 0xc3, 0x7a,
-	0x00, 0x5d, 0xc6, 0x17, 0x7c, 0x9e, 0x68, 0xce, 0x26, 0xd5, 0x90, 0x7b, 0x26, 0xe4, 0xb2, 0x18,
-	0xdb, 0xb4, 0x0e, 0xab, 0x12, 0x2b, 0xf2, 0x08, 0x3c, 0xa3, 0xb4, 0xef, 0xae, 0xda, 0xce, 0xce,
-	0xa2, 0x9f, 0x0e, 0x74, 0xff, 0xb8, 0xe1, 0xdb, 0x5b, 0x9e, 0xbc, 0x81, 0xce, 0xfe, 0x26, 0xb6,
-	0xcf, 0x81, 0x45, 0xee, 0xee, 0x2d, 0x12, 0x7d, 0xb0, 0x2f, 0xd4, 0x40, 0x72, 0x0a, 0x4d, 0xf1,
-	0x2d, 0xe3, 0x0a, 0xbf, 0x7e, 0x9f, 0x1a, 0x40, 0x08, 0xb8, 0x7a, 0x2b, 0x39, 0x7e, 0xdd, 0x77,
-	0x28, 0x3e, 0x93, 0x07, 0xd0, 0xfa, 0xb2, 0x4e, 0x32, 0x9d, 0xea, 0x2d, 0x46, 0xba, 0x74, 0x87,
-	0xa3, 0x73, 0x38, 0xb2, 0x1b, 0x91, 0x13, 0x68, 0xea, 0xcd, 0x24, 0x65, 0x68, 0x98, 0x6b, 0x37,
-	0x23, 0x96, 0xa7, 0xa4, 0x19, 0xe3, 0x1b, 0x34, 0x0c, 0xa8, 0x01, 0xd1, 0x77, 0x38, 0xfd, 0x5b,
-	0xe7, 0x03, 0x9d, 0x1e, 0x02, 0x14, 0xbb, 0x70, 0x73, 0x4b, 0x3e, 0x2d, 0x31, 0xbb, 0xce, 0x8d,
-	0x03, 0x9d, 0xdd, 0x6a, 0xe7, 0xc1, 0xb3, 0x4f, 0x4f, 0xe7, 0xa9, 0xbe, 0x5e, 0x4f, 0xfb, 0x33,
-	0xb1, 0x8c, 0xaf, 0xb7, 0x92, 0xab, 0x05, 0x67, 0x73, 0xae, 0xe2, 0xab, 0x64, 0xaa, 0xd2, 0x59,
-	0x8c, 0x3f, 0xa4, 0x55, 0x8c, 0x7f, 0xaa, 0xa9, 0x87, 0xe8, 0xec, 0x77, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x10, 0xe3, 0xb3, 0x61, 0xb9, 0x04, 0x00, 0x00,
+	proto.RegisterFile("token/transaction.proto", fileDescriptor_transaction_8706352289fb60fb)
+}
+
+var fileDescriptor_transaction_8706352289fb60fb = []byte{
+	// 1252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc5, 0x57, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0x3f, 0x37, 0x69, 0xda, 0x4c, 0x9c, 0x34, 0xdd, 0xa6, 0xd7, 0x08, 0x01, 0x3a, 0x0c, 0x02,
+	0x24, 0xa8, 0x7b, 0xed, 0x1d, 0xf0, 0xca, 0xf5, 0x7a, 0x85, 0x70, 0xd7, 0xbb, 0xca, 0x09, 0x12,
+	0xe2, 0xc5, 0x72, 0xec, 0x4d, 0xb2, 0xba, 0xc4, 0x36, 0xb6, 0x43, 0x1b, 0xc4, 0x33, 0x0f, 0x7c,
+	0x04, 0x78, 0x40, 0xbc, 0xf0, 0x0d, 0x40, 0xe2, 0x23, 0xf0, 0xa9, 0x60, 0xbc, 0xbb, 0x76, 0xbc,
+	0x6e, 0x7a, 0xbd, 0x43, 0x48, 0xbc, 0x65, 0x7e, 0xf3, 0x67, 0x7f, 0xb3, 0x33, 0x3b, 0xe3, 0xc0,
+	0x5e, 0x12, 0x3c, 0xa7, 0xfe, 0x41, 0x12, 0x39, 0x7e, 0xec, 0xb8, 0x09, 0x0b, 0x7c, 0x33, 0x8c,
+	0x82, 0x24, 0x30, 0xfe, 0xd2, 0xa0, 0x3d, 0x48, 0x75, 0x83, 0xa5, 0x8a, 0x7c, 0x0c, 0x7a, 0x38,
+	0x75, 0x98, 0x6f, 0x0b, 0xb9, 0xab, 0xdd, 0xd1, 0xde, 0x6f, 0x1c, 0x6d, 0x9b, 0xe7, 0x29, 0xc8,
+	0xad, 0x1f, 0x70, 0xc5, 0xe7, 0xb7, 0xac, 0x06, 0x37, 0x14, 0x22, 0x79, 0x0c, 0x3b, 0x6e, 0xe0,
+	0x8f, 0x98, 0x47, 0xfd, 0x84, 0x39, 0xd3, 0xcc, 0x7d, 0x8d, 0xbb, 0x77, 0xcd, 0x87, 0x05, 0x9d,
+	0x1a, 0x85, 0x14, 0xdd, 0x64, 0xb0, 0x77, 0x61, 0xcb, 0x9d, 0x60, 0x6c, 0x37, 0xf0, 0xa8, 0x9d,
+	0x5c, 0xda, 0xcc, 0xeb, 0x56, 0x30, 0x50, 0xdd, 0x6a, 0xe6, 0xf0, 0xe0, 0xb2, 0xe7, 0x1d, 0x6f,
+	0x42, 0x4d, 0x9c, 0x63, 0xfc, 0x51, 0x81, 0x76, 0x99, 0x22, 0x39, 0xcc, 0x72, 0x61, 0xb3, 0x30,
+	0x88, 0x12, 0x99, 0x8b, 0x2e, 0x72, 0xe9, 0x71, 0x2c, 0x4f, 0x43, 0x88, 0xe4, 0x13, 0x68, 0x09,
+	0x17, 0x7e, 0x5d, 0x23, 0x1a, 0xc9, 0x0c, 0x5a, 0xf2, 0x02, 0x24, 0x8a, 0x6e, 0xcd, 0xb0, 0x08,
+	0x90, 0x7b, 0xd9, 0x59, 0x11, 0xf5, 0x28, 0x9d, 0x71, 0xbe, 0xab, 0xdc, 0xc4, 0x69, 0x16, 0x37,
+	0x22, 0xf7, 0xa1, 0x29, 0x2f, 0x3b, 0xc4, 0x92, 0x7c, 0x4b, 0xbb, 0x55, 0xee, 0xd5, 0x14, 0x5e,
+	0x0f, 0x04, 0x88, 0x4e, 0x22, 0xb4, 0x94, 0xc9, 0x09, 0xec, 0xa8, 0x1c, 0xed, 0xd3, 0x28, 0x98,
+	0x75, 0xd7, 0xb9, 0x2f, 0x51, 0x4f, 0x4c, 0x35, 0x18, 0x60, 0x3b, 0x2c, 0x83, 0xe4, 0x1c, 0xf6,
+	0x64, 0x94, 0x45, 0x48, 0x91, 0xf5, 0x98, 0xc5, 0x18, 0x8f, 0x17, 0xad, 0xc6, 0x23, 0xdd, 0x96,
+	0x91, 0x50, 0x6d, 0x15, 0xb4, 0x18, 0x6d, 0x37, 0x5c, 0xa5, 0x20, 0x1f, 0x00, 0x88, 0x88, 0xf1,
+	0x85, 0x13, 0x76, 0x37, 0x78, 0x10, 0x10, 0x41, 0xfa, 0x88, 0xa0, 0x63, 0x3d, 0xcc, 0x84, 0xe3,
+	0x1a, 0x54, 0x3d, 0x27, 0x71, 0x8c, 0x8f, 0xa0, 0x51, 0x28, 0x07, 0x56, 0x7e, 0x23, 0x98, 0x27,
+	0xe1, 0x3c, 0x89, 0xb1, 0x5a, 0x95, 0x65, 0xb5, 0x9e, 0x71, 0xd0, 0xca, 0x94, 0xc6, 0x8f, 0x1a,
+	0x34, 0x95, 0x44, 0xc9, 0x1d, 0xa8, 0x31, 0xbf, 0xe0, 0xb8, 0x69, 0xf6, 0x52, 0xb1, 0xe7, 0x59,
+	0x12, 0x2f, 0xc6, 0x5e, 0x7b, 0x41, 0x6c, 0x72, 0x00, 0x10, 0xb3, 0xb1, 0xef, 0x24, 0xf3, 0x88,
+	0xc6, 0x58, 0xc8, 0xd4, 0x74, 0xcb, 0x7c, 0x76, 0xe1, 0xd3, 0xa8, 0x9f, 0xe1, 0x56, 0xc1, 0xc4,
+	0xf8, 0x49, 0x03, 0xbd, 0x58, 0xb1, 0x97, 0xe0, 0x72, 0x0c, 0xdb, 0x1e, 0x9d, 0xd2, 0xb1, 0x93,
+	0x50, 0xcf, 0x56, 0x59, 0xed, 0x0a, 0x56, 0x27, 0x99, 0x5a, 0xd2, 0x6b, 0x7b, 0x2a, 0x10, 0x93,
+	0x77, 0xa0, 0x26, 0x3c, 0x65, 0xb3, 0xa9, 0xe9, 0x48, 0x9d, 0xf1, 0x8b, 0x06, 0xdb, 0x57, 0x5a,
+	0xe2, 0x3f, 0xbc, 0xad, 0x4f, 0xa1, 0x5d, 0xce, 0x44, 0xf2, 0xb9, 0x26, 0x91, 0xad, 0x52, 0x22,
+	0xc6, 0x0f, 0x1a, 0xd4, 0xf3, 0x2e, 0xf9, 0x3f, 0xeb, 0x78, 0x0a, 0x2d, 0x55, 0x4b, 0x6e, 0x43,
+	0x2d, 0xd5, 0xe3, 0x18, 0x48, 0x67, 0x87, 0x6e, 0x49, 0x89, 0xbc, 0x0e, 0xf5, 0xdc, 0x8f, 0x4f,
+	0x08, 0xdd, 0x5a, 0x02, 0xc6, 0x77, 0x00, 0x7c, 0x0c, 0xf1, 0x60, 0x58, 0xa6, 0x6a, 0xfa, 0xc4,
+	0x78, 0x84, 0xd6, 0x51, 0xdb, 0x5c, 0xaa, 0x4c, 0xfe, 0x84, 0xb8, 0x96, 0xb4, 0xa1, 0x12, 0x39,
+	0x17, 0x32, 0x56, 0xfa, 0xd3, 0xb8, 0x0f, 0xd5, 0x54, 0x4f, 0x08, 0xb4, 0xce, 0xfa, 0xe7, 0x76,
+	0xef, 0xe4, 0xd1, 0xd3, 0x41, 0xef, 0xb4, 0xf7, 0xc8, 0x6a, 0xdf, 0x22, 0x00, 0x35, 0x94, 0xcf,
+	0x7a, 0x5f, 0xb5, 0x35, 0xa2, 0xc3, 0xe6, 0xd9, 0x97, 0x4f, 0x06, 0xbd, 0x7e, 0xef, 0xb3, 0xf6,
+	0x9a, 0x71, 0x06, 0xbb, 0x2b, 0x9f, 0x2d, 0xce, 0x1a, 0xf0, 0xe8, 0x88, 0xf9, 0xac, 0x30, 0xd6,
+	0x3b, 0x82, 0x4c, 0x6a, 0x7b, 0x92, 0xeb, 0xac, 0x82, 0x9d, 0xf1, 0xab, 0x06, 0x3b, 0x2b, 0x6c,
+	0x90, 0xd4, 0x32, 0xa9, 0xba, 0x4c, 0xe1, 0x2d, 0xd0, 0x3d, 0x16, 0xe3, 0x13, 0x5f, 0xd8, 0xbe,
+	0x33, 0x13, 0xf7, 0x52, 0xb7, 0x1a, 0x12, 0x7b, 0x8a, 0x10, 0x79, 0x0d, 0x36, 0x3d, 0xea, 0xb2,
+	0x99, 0x33, 0x8d, 0x79, 0x93, 0x34, 0xad, 0x5c, 0x26, 0x6f, 0x00, 0xcc, 0x9c, 0x4b, 0x3b, 0x9e,
+	0x87, 0xe1, 0x74, 0xc1, 0x27, 0x61, 0xd5, 0xaa, 0x23, 0xd2, 0xe7, 0x00, 0xe9, 0xc2, 0x06, 0x8b,
+	0xe3, 0x39, 0x8d, 0x62, 0x9c, 0x74, 0x15, 0xbc, 0xa4, 0x4c, 0x34, 0xfa, 0x72, 0x84, 0x88, 0xfa,
+	0x93, 0x0e, 0xac, 0x07, 0x17, 0xcb, 0x92, 0x09, 0x21, 0x27, 0xbc, 0x56, 0x20, 0x8c, 0x6c, 0xbe,
+	0x99, 0x3b, 0xb8, 0x78, 0x92, 0x05, 0x67, 0x53, 0xb5, 0x72, 0x19, 0x6f, 0x7f, 0x43, 0xf6, 0x1d,
+	0xd9, 0x81, 0x75, 0xb1, 0x83, 0xb2, 0x64, 0x71, 0xf5, 0xa4, 0xa7, 0x30, 0xdf, 0xa3, 0x97, 0x3c,
+	0x60, 0xd3, 0x12, 0x82, 0xf1, 0x3d, 0x74, 0x56, 0xf5, 0xfc, 0x35, 0x9c, 0xde, 0x4c, 0x4b, 0x22,
+	0x0c, 0xa9, 0xe8, 0x65, 0xdd, 0x2a, 0x20, 0x39, 0xe7, 0xca, 0x35, 0x9c, 0xab, 0x25, 0xce, 0x7f,
+	0x6b, 0xb0, 0x77, 0xcd, 0xa2, 0x25, 0xa7, 0xa5, 0xfd, 0xac, 0xac, 0xc4, 0x1d, 0x65, 0x3f, 0xe7,
+	0x9b, 0x51, 0x59, 0xcd, 0x72, 0x40, 0x3f, 0x81, 0x5d, 0x25, 0x4e, 0x69, 0x4f, 0xee, 0xaa, 0x9b,
+	0x7e, 0xb9, 0xf7, 0x3a, 0xee, 0x0a, 0xfc, 0x0a, 0x2b, 0x65, 0x79, 0xaa, 0xac, 0xc4, 0xca, 0x2c,
+	0xb3, 0x12, 0x68, 0xbe, 0x4d, 0x1e, 0x02, 0xb9, 0x9a, 0x09, 0xd9, 0x2f, 0x2f, 0x15, 0x35, 0x72,
+	0x79, 0xb7, 0xfc, 0xac, 0x41, 0x67, 0x55, 0x16, 0x2f, 0x31, 0x9a, 0xf6, 0xcb, 0xa3, 0xe9, 0x85,
+	0x27, 0x91, 0x23, 0x68, 0x0e, 0x9d, 0xa9, 0xe3, 0xbb, 0xd4, 0xc6, 0xc5, 0x11, 0x8c, 0x64, 0xe2,
+	0x4d, 0xb3, 0xef, 0x4e, 0xfc, 0x20, 0x8a, 0xce, 0x53, 0xd0, 0xd2, 0xa5, 0x0d, 0x97, 0x8c, 0xdf,
+	0x35, 0x35, 0x47, 0xf9, 0x29, 0x71, 0x33, 0xb7, 0x62, 0xe7, 0xac, 0xa9, 0x9d, 0x53, 0xe4, 0x5d,
+	0xf9, 0x37, 0xbc, 0xab, 0x37, 0xf3, 0xfe, 0xb3, 0xc4, 0xfb, 0x95, 0x5f, 0x2b, 0xbe, 0x16, 0x37,
+	0x98, 0xcd, 0x58, 0x32, 0xc3, 0x00, 0xfc, 0xa6, 0xf0, 0xb5, 0x2c, 0x11, 0xf2, 0x21, 0x34, 0xb0,
+	0x50, 0x63, 0x95, 0x52, 0xc3, 0xb4, 0x52, 0x4c, 0x10, 0x82, 0x28, 0xff, 0x8d, 0x1f, 0x2b, 0x1b,
+	0x41, 0x48, 0x7d, 0xe6, 0x8f, 0xe5, 0x87, 0xd3, 0xb6, 0xf9, 0xc8, 0x77, 0xa3, 0x45, 0x98, 0x3e,
+	0x5a, 0xa1, 0xb0, 0x32, 0x0b, 0x03, 0xbf, 0x6c, 0x96, 0x61, 0x70, 0x50, 0x55, 0x87, 0x2c, 0xbf,
+	0xe8, 0xba, 0x79, 0xcc, 0x12, 0x11, 0x9f, 0xc3, 0xc6, 0x6f, 0x1a, 0x6c, 0x66, 0x50, 0x89, 0xb4,
+	0x76, 0x85, 0x74, 0xaa, 0x9f, 0x38, 0xd3, 0x29, 0xc5, 0xe8, 0x77, 0xe5, 0xf4, 0x2f, 0x20, 0x8a,
+	0xfe, 0x30, 0x4f, 0x3a, 0x47, 0xd2, 0x45, 0x84, 0x9b, 0x2b, 0x0c, 0xfc, 0x18, 0xdd, 0xab, 0x62,
+	0x11, 0xe5, 0x40, 0x51, 0x7b, 0xc8, 0xd3, 0x2c, 0x68, 0x0f, 0x8d, 0x2f, 0x40, 0x2f, 0xd6, 0xeb,
+	0x46, 0xae, 0xd8, 0x40, 0x99, 0xb3, 0x64, 0x9a, 0xcb, 0x38, 0x83, 0x75, 0xb1, 0xd7, 0xc4, 0x8d,
+	0x29, 0xcd, 0xa6, 0x95, 0x9a, 0xed, 0x3d, 0xd8, 0x1a, 0x4e, 0x71, 0x5e, 0xa2, 0x9d, 0x3d, 0xc2,
+	0xef, 0xf7, 0x20, 0x92, 0xe1, 0x5a, 0x19, 0x7c, 0xca, 0x51, 0xdc, 0xa3, 0xed, 0x72, 0x4d, 0xc8,
+	0x5d, 0xe8, 0xd0, 0x70, 0x42, 0x67, 0x34, 0xc2, 0x71, 0x11, 0xce, 0xd1, 0xc3, 0xb5, 0x9f, 0xd3,
+	0x85, 0xa4, 0x4b, 0x72, 0xdd, 0x39, 0x57, 0x3d, 0xa6, 0x8b, 0xb4, 0xc3, 0xfc, 0x00, 0xdb, 0x50,
+	0x1e, 0x22, 0x04, 0x9e, 0x2c, 0x43, 0xe3, 0x28, 0xa1, 0x97, 0xcb, 0x6e, 0xca, 0x91, 0xe3, 0x01,
+	0xbc, 0x1d, 0x44, 0x63, 0x73, 0x82, 0x9d, 0x17, 0x4d, 0xa9, 0x37, 0xc6, 0x6d, 0x3d, 0x72, 0x86,
+	0x11, 0x73, 0xc5, 0x9f, 0xa7, 0xd8, 0xe4, 0xff, 0xaa, 0xbe, 0xde, 0x1f, 0xb3, 0x64, 0x32, 0x1f,
+	0x9a, 0x78, 0x4d, 0xe9, 0x3f, 0x2c, 0x1c, 0xde, 0xcc, 0xa7, 0xfb, 0x09, 0x75, 0x27, 0x07, 0xc2,
+	0xfc, 0x40, 0x98, 0x1f, 0x70, 0xf3, 0x61, 0x8d, 0x4b, 0xf7, 0xfe, 0x01, 0x6c, 0x85, 0xaa, 0xf9,
+	0x94, 0x0d, 0x00, 0x00,
 }
@@ -8,6 +8,7 @@ package client
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/token"
@@ -30,6 +31,73 @@ type Prover interface {
 	// among recipients; it returns a response in bytes and an error message in the case the
 	// request fails
 	RequestTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRedeem allows the client to submit a redeem request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens to be redeemed and
+	// the quantity to redeem; it returns a response in bytes and an error message in the case the
+	// request fails
+	RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// ListTokens allows the client to submit a list request to a prover peer service;
+	// it returns a response in bytes and an error message in the case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the unspent tokens
+	// owned by the client
+	ListTokens(signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// GetTokenHistory allows the client to request a page of the token transactions it is involved in;
+	// the function takes as parameters the maximum number of transactions to return and the bookmark
+	// returned with the previous page, if any; it returns a response in bytes and an error message
+	// in the case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the token history
+	GetTokenHistory(pageSize uint32, bookmark string, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// GetBalance allows the client to request the balance of its unspent tokens per token type;
+	// it returns a response in bytes and an error message in the case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the token balances
+	GetBalance(signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// GetSpendStatus allows the client to request whether the output identified by inputID
+	// has been spent; it returns a response in bytes and an error message in the case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the spend status
+	GetSpendStatus(inputID *token.InputId, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRegisterTokenType allows the client to submit a request to register the token type
+	// described by definition in the token type registry of the channel;
+	// it returns a response in bytes and an error message in the case the request fails.
+	// The response corresponds to a serialized TokenTransaction protobuf message.
+	RequestRegisterTokenType(definition *token.TokenTypeDefinition, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// ListTokenTypes allows the client to request the token types in the registry of the channel;
+	// it returns a response in bytes and an error message in the case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the token types
+	ListTokenTypes(signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestApprove allows the client to submit an approve request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens to be delegated and
+	// the shares describing how much each recipient is allowed to spend;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestApprove(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestTransferFrom allows the client to submit a transferFrom request to a prover peer service;
+	// the function takes as parameters the identifiers of the delegated outputs that were previously
+	// approved to the client and the shares describing how they are going to be distributed
+	// among recipients; it returns a response in bytes and an error message in the case the
+	// request fails
+	RequestTransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestExpectation allows the client to submit an expectation request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens used to fulfill the expectation
+	// (empty for an import expectation) and the token expectation set by a chaincode;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestExpectation(tokenIDs [][]byte, expectation *token.TokenExpectation, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestSwap allows the client to submit a swap request to a prover peer service;
+	// the function takes as parameters the identifiers of the tokens of the client and the quantity
+	// of them to give, the counterparty, and the identifiers of the tokens of the counterparty and
+	// the quantity of them to receive; it returns a response in bytes and an error message in the
+	// case the request fails.
+	// The response corresponds to a serialized CommandResponse carrying the swap transaction
+	RequestSwap(tokenIDs [][]byte, quantity uint64, counterparty []byte, counterpartyTokenIDs [][]byte, counterpartyQuantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
 }
 
 //go:generate counterfeiter -o mock/fabric_tx_submitter.go -fake-name FabricTxSubmitter . FabricTxSubmitter
@@ -68,6 +136,23 @@ func (c *Client) Issue(tokensToIssue []*token.TokenToIssue) ([]byte, error) {
 	return tx, c.TxSubmitter.Submit(tx)
 }
 
+// RegisterTokenType is the function that the client calls to add a token type
+// to the token type registry of the channel.
+// RegisterTokenType takes as parameter the definition of the token type, which
+// cannot be changed once committed.
+func (c *Client) RegisterTokenType(definition *token.TokenTypeDefinition) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestRegisterTokenType(definition, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
 // Transfer is the function that the client calls to transfer his tokens.
 // Transfer takes as parameter an array of token.RecipientTransferShare that
 // identifies who receives the tokens and describes how the tokens are distributed.
@@ -84,6 +169,263 @@ func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferSh
 	return tx, c.TxSubmitter.Submit(tx)
 }
 
+// Redeem is the function that the client calls to redeem his tokens.
+// Redeem takes as parameter the identifiers of the tokens to be redeemed and the
+// quantity to redeem; any remaining quantity is transferred back to the client.
+func (c *Client) Redeem(tokenIDs [][]byte, quantity uint64) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestRedeem(tokenIDs, quantity, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// Approve is the function that the client calls to allow other parties to spend his tokens.
+// Approve takes as parameter the identifiers of the tokens to be delegated and an array of
+// token.AllowanceRecipientShare that describes how much each delegatee is allowed to spend.
+func (c *Client) Approve(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestApprove(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// TransferFrom is the function that the client calls to transfer tokens that
+// their owner delegated to the client via an approve transaction.
+// TransferFrom takes as parameter the identifiers of the delegated outputs and an array of
+// token.RecipientTransferShare that identifies who receives the tokens.
+// Any allowance left after the transfer remains delegated to the client.
+func (c *Client) TransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare) ([]byte, error) {
+	serializedTokenTx, err := c.Prover.RequestTransferFrom(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// FulfillExpectation is the function that the client calls to issue or transfer tokens
+// as described by the token expectation set by a chaincode.
+// FulfillExpectation takes as parameter the identifiers of the tokens to be transferred,
+// which are ignored for an import expectation, the identifier of the chaincode transaction
+// and the token expectation it set; the submitted token transaction is bound to that chaincode
+// transaction, so that the committing peers check that the chaincode transaction was committed
+// as valid in an earlier block and that the token transaction fulfils its token expectation.
+func (c *Client) FulfillExpectation(tokenIDs [][]byte, chaincodeTxID string, expectation *token.TokenExpectation) ([]byte, error) {
+	if chaincodeTxID == "" {
+		return nil, errors.New("no chaincode transaction identifier provided")
+	}
+	if expectation == nil {
+		return nil, errors.New("no token expectation provided")
+	}
+
+	serializedResponse, err := c.Prover.RequestExpectation(tokenIDs, expectation, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	response := &token.CommandResponse{}
+	err = proto.Unmarshal(serializedResponse, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling expectation response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("expectation request failed: %s", response.GetErr().GetMessage())
+	}
+	tokenTx := response.GetTokenTransaction()
+	if tokenTx == nil {
+		return nil, errors.New("expectation response does not carry a token transaction")
+	}
+	tokenTx.ChaincodeTxId = chaincodeTxID
+
+	serializedTokenTx, err := proto.Marshal(tokenTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling token transaction")
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// ProposeSwap is the function that the client calls to prepare an atomic swap of its tokens
+// with tokens of a different type owned by counterparty.
+// ProposeSwap takes as parameters the identifiers of the tokens of the client and the quantity
+// of them that goes to the counterparty, and the identifiers of the tokens of the counterparty
+// and the quantity of them that goes to the client.
+// The returned transaction must be signed by the counterparty with EndorseSwap
+// before the client submits it with SubmitSwap.
+func (c *Client) ProposeSwap(tokenIDs [][]byte, quantity uint64, counterparty []byte, counterpartyTokenIDs [][]byte, counterpartyQuantity uint64) (*token.TokenTransaction, error) {
+	serializedResponse, err := c.Prover.RequestSwap(tokenIDs, quantity, counterparty, counterpartyTokenIDs, counterpartyQuantity, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tokenTx, err := unmarshalTokenTransaction(serializedResponse, "swap")
+	if err != nil {
+		return nil, err
+	}
+	if tokenTx.GetPlainAction().GetPlainSwap() == nil {
+		return nil, errors.New("swap response does not carry a swap transaction")
+	}
+	return tokenTx, nil
+}
+
+// EndorseSwap is the function that the counterparty of a swap calls to authorize
+// the spending of its tokens; it adds the signature of the client to tokenTx.
+func (c *Client) EndorseSwap(tokenTx *token.TokenTransaction) error {
+	swap := tokenTx.GetPlainAction().GetPlainSwap()
+	if swap == nil {
+		return errors.New("the token transaction is not a swap")
+	}
+	signer, err := c.SigningIdentity.Serialize()
+	if err != nil {
+		return err
+	}
+	message, err := swap.SigningBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := c.SigningIdentity.Sign(message)
+	if err != nil {
+		return err
+	}
+	swap.Signatures = append(swap.Signatures, &token.OwnerSignature{Signer: signer, Signature: signature})
+	return nil
+}
+
+// ProposeTransfer is the function that the client calls to prepare a transfer of tokens
+// whose owners must sign it, such as tokens owned by a multisig policy that the client
+// is part of. It takes the same parameters as Transfer.
+// The returned transaction must be signed with EndorseTransfer by the signers the owners
+// of the tokens require, the client included, before it is submitted with SubmitTransfer.
+func (c *Client) ProposeTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (*token.TokenTransaction, error) {
+	serializedResponse, err := c.Prover.RequestTransfer(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tokenTx, err := unmarshalTokenTransaction(serializedResponse, "transfer")
+	if err != nil {
+		return nil, err
+	}
+	if tokenTx.GetPlainAction().GetPlainTransfer() == nil {
+		return nil, errors.New("transfer response does not carry a transfer transaction")
+	}
+	return tokenTx, nil
+}
+
+// ProposeRedeem is like ProposeTransfer for a redeem; it takes the same parameters as Redeem.
+func (c *Client) ProposeRedeem(tokenIDs [][]byte, quantity uint64) (*token.TokenTransaction, error) {
+	serializedResponse, err := c.Prover.RequestRedeem(tokenIDs, quantity, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+	tokenTx, err := unmarshalTokenTransaction(serializedResponse, "redeem")
+	if err != nil {
+		return nil, err
+	}
+	if tokenTx.GetPlainAction().GetPlainRedeem() == nil {
+		return nil, errors.New("redeem response does not carry a redeem transaction")
+	}
+	return tokenTx, nil
+}
+
+// EndorseTransfer is the function that an owner of the tokens spent by a transfer or a redeem
+// calls to authorize the spending; it adds the signature of the client to tokenTx.
+func (c *Client) EndorseTransfer(tokenTx *token.TokenTransaction) error {
+	var action *token.PlainTransfer
+	var signingBytes func() ([]byte, error)
+	switch {
+	case tokenTx.GetPlainAction().GetPlainTransfer() != nil:
+		action = tokenTx.GetPlainAction().GetPlainTransfer()
+		signingBytes = action.SigningBytes
+	case tokenTx.GetPlainAction().GetPlainRedeem() != nil:
+		action = tokenTx.GetPlainAction().GetPlainRedeem()
+		signingBytes = action.RedeemSigningBytes
+	default:
+		return errors.New("the token transaction is neither a transfer nor a redeem")
+	}
+	signer, err := c.SigningIdentity.Serialize()
+	if err != nil {
+		return err
+	}
+	message, err := signingBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := c.SigningIdentity.Sign(message)
+	if err != nil {
+		return err
+	}
+	action.Signatures = append(action.Signatures, &token.OwnerSignature{Signer: signer, Signature: signature})
+	return nil
+}
+
+// SubmitTransfer is the function that the client calls to submit a transfer or a redeem
+// once the owners of the tokens have signed it.
+func (c *Client) SubmitTransfer(tokenTx *token.TokenTransaction) ([]byte, error) {
+	if tokenTx.GetPlainAction().GetPlainTransfer() == nil && tokenTx.GetPlainAction().GetPlainRedeem() == nil {
+		return nil, errors.New("the token transaction is neither a transfer nor a redeem")
+	}
+	serializedTokenTx, err := proto.Marshal(tokenTx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling token transaction")
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// SubmitSwap is the function that the client calls to submit a swap
+// once the counterparty has signed it.
+func (c *Client) SubmitSwap(tokenTx *token.TokenTransaction) ([]byte, error) {
+	if len(tokenTx.GetPlainAction().GetPlainSwap().GetSignatures()) == 0 {
+		return nil, errors.New("the swap has not been signed by the counterparty")
+	}
+	serializedTokenTx, err := proto.Marshal(tokenTx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.createTx(serializedTokenTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, c.TxSubmitter.Submit(tx)
+}
+
+// unmarshalTokenTransaction returns the token transaction carried by the serialized response
+// of the prover to a request; requestName is used in the error messages.
+func unmarshalTokenTransaction(serializedResponse []byte, requestName string) (*token.TokenTransaction, error) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(serializedResponse, response)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling %s response", requestName)
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("%s request failed: %s", requestName, response.GetErr().GetMessage())
+	}
+	return response.GetTokenTransaction(), nil
+}
+
 // TODO to be updated later to have a proper fabric header
 // createTx is a function that creates a fabric tx form an array of bytes.
 func (c *Client) createTx(tokenTx []byte) ([]byte, error) {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -40,6 +41,11 @@ var _ = Describe("TokenClient", func() {
 		fakeProver = &mock.Prover{}
 		fakeProver.RequestImportReturns([]byte("tx-payload"), nil) // same data as payload
 		fakeProver.RequestTransferReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestTransferFromReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestRedeemReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestApproveReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestExpectationReturns([]byte("tx-payload"), nil)
+		fakeProver.RequestRegisterTokenTypeReturns([]byte("tx-payload"), nil)
 
 		fakeSigningIdentity = &mock.SigningIdentity{}
 		fakeSigningIdentity.SignReturns([]byte("tx-signature"), nil) // same signature as envelope
@@ -215,4 +221,420 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("TransferFrom", func() {
+		var (
+			tokenIDs       [][]byte
+			transferShares []*token.RecipientTransferShare
+		)
+
+		BeforeEach(func() {
+			// input data for TransferFrom
+			tokenIDs = [][]byte{[]byte("delegated-id1")}
+			transferShares = []*token.RecipientTransferShare{
+				{Recipient: []byte("Charlie"), Quantity: 10},
+			}
+		})
+
+		It("returns tx envelope without error", func() {
+			serializedTx, err := tokenClient.TransferFrom(tokenIDs, transferShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestTransferFromCallCount()).To(Equal(1))
+			ids, shares, signingIdentity := fakeProver.RequestTransferFromArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(shares).To(Equal(transferShares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			raw := fakeTxSubmitter.SubmitArgsForCall(0)
+			Expect(raw).To(Equal(envelopeBytes))
+		})
+
+		Context("when prover.RequestTransferFrom fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferFromReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TransferFrom(tokenIDs, transferShares)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeProver.RequestTransferFromCallCount()).To(Equal(1))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("FulfillExpectation", func() {
+		var (
+			tokenIDs    [][]byte
+			expectation *token.TokenExpectation
+			tokenTx     *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			// input data for FulfillExpectation
+			tokenIDs = [][]byte{[]byte("id1")}
+			expectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("Bob"), Type: "XYZ", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+			tokenTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}},
+								Outputs: expectation.GetPlainExpectation().GetTransferExpectation().GetOutputs(),
+							},
+						},
+					},
+				},
+			}
+			response := &token.CommandResponse{
+				Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTx},
+			}
+			fakeProver.RequestExpectationReturns(ProtoMarshal(response), nil)
+		})
+
+		It("submits a token transaction bound to the chaincode transaction", func() {
+			serializedTx, err := tokenClient.FulfillExpectation(tokenIDs, "cc-tx", expectation)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeProver.RequestExpectationCallCount()).To(Equal(1))
+			ids, e, signingIdentity := fakeProver.RequestExpectationArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(proto.Equal(e, expectation)).To(BeTrue())
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(serializedTx))
+
+			env := &common.Envelope{}
+			Expect(proto.Unmarshal(serializedTx, env)).To(Succeed())
+			payload := &common.Payload{}
+			Expect(proto.Unmarshal(env.Payload, payload)).To(Succeed())
+			submittedTx := &token.TokenTransaction{}
+			Expect(proto.Unmarshal(payload.Data, submittedTx)).To(Succeed())
+			Expect(submittedTx.GetChaincodeTxId()).To(Equal("cc-tx"))
+			Expect(proto.Equal(submittedTx.GetPlainAction(), tokenTx.GetPlainAction())).To(BeTrue())
+		})
+
+		Context("when no chaincode transaction identifier is provided", func() {
+			It("returns an error", func() {
+				_, err := tokenClient.FulfillExpectation(tokenIDs, "", expectation)
+				Expect(err).To(MatchError("no chaincode transaction identifier provided"))
+				Expect(fakeProver.RequestExpectationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no token expectation is provided", func() {
+			It("returns an error", func() {
+				_, err := tokenClient.FulfillExpectation(tokenIDs, "cc-tx", nil)
+				Expect(err).To(MatchError("no token expectation provided"))
+				Expect(fakeProver.RequestExpectationCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when prover.RequestExpectation fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestExpectationReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.FulfillExpectation(tokenIDs, "cc-tx", expectation)
+				Expect(err).To(MatchError("wild-banana"))
+
+				Expect(fakeProver.RequestExpectationCallCount()).To(Equal(1))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(0))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the prover returns an error response", func() {
+			BeforeEach(func() {
+				response := &token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "insufficient funds"}},
+				}
+				fakeProver.RequestExpectationReturns(ProtoMarshal(response), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.FulfillExpectation(tokenIDs, "cc-tx", expectation)
+				Expect(err).To(MatchError("expectation request failed: insufficient funds"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Redeem", func() {
+		It("returns tx envelope without error", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			serializedTx, err := tokenClient.Redeem(tokenIDs, 50)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestRedeemCallCount()).To(Equal(1))
+			ids, quantity, signingIdentity := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(quantity).To(Equal(uint64(50)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when prover.RequestRedeem fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem([][]byte{[]byte("id1")}, 50)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Approve", func() {
+		It("returns tx envelope without error", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			allowanceShares := []*token.AllowanceRecipientShare{{Recipient: []byte("Bob"), Quantity: 10}}
+			serializedTx, err := tokenClient.Approve(tokenIDs, allowanceShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestApproveCallCount()).To(Equal(1))
+			ids, shares, signingIdentity := fakeProver.RequestApproveArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(shares).To(Equal(allowanceShares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("RegisterTokenType", func() {
+		It("returns tx envelope without error", func() {
+			definition := &token.TokenTypeDefinition{Type: "TOK1", Decimals: 2, MaxSupply: 1000}
+			serializedTx, err := tokenClient.RegisterTokenType(definition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serializedTx).To(Equal(envelopeBytes))
+
+			Expect(fakeProver.RequestRegisterTokenTypeCallCount()).To(Equal(1))
+			def, signingIdentity := fakeProver.RequestRegisterTokenTypeArgsForCall(0)
+			Expect(def).To(Equal(definition))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+		})
+
+		Context("when prover.RequestRegisterTokenType fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRegisterTokenTypeReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.RegisterTokenType(&token.TokenTypeDefinition{Type: "TOK1"})
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Swap", func() {
+		var swapTx *token.TokenTransaction
+
+		BeforeEach(func() {
+			swapTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainSwap{
+							PlainSwap: &token.PlainSwap{
+								Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}, {TxId: "tx2", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("Bob"), Type: "TOK1", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+			response := &token.CommandResponse{
+				Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: swapTx},
+			}
+			fakeProver.RequestSwapReturns(ProtoMarshal(response), nil)
+			fakeSigningIdentity.SerializeReturns([]byte("Bob"), nil)
+		})
+
+		It("proposes, endorses and submits a swap", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			counterpartyTokenIDs := [][]byte{[]byte("id2")}
+			proposal, err := tokenClient.ProposeSwap(tokenIDs, 10, []byte("Bob"), counterpartyTokenIDs, 20)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ProtoMarshal(proposal)).To(Equal(ProtoMarshal(swapTx)))
+
+			Expect(fakeProver.RequestSwapCallCount()).To(Equal(1))
+			ids, quantity, counterparty, cpIDs, cpQuantity, signingIdentity := fakeProver.RequestSwapArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(quantity).To(Equal(uint64(10)))
+			Expect(counterparty).To(Equal([]byte("Bob")))
+			Expect(cpIDs).To(Equal(counterpartyTokenIDs))
+			Expect(cpQuantity).To(Equal(uint64(20)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			err = tokenClient.EndorseSwap(proposal)
+			Expect(err).NotTo(HaveOccurred())
+			signingBytes, err := swapTx.GetPlainAction().GetPlainSwap().SigningBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(signingBytes))
+			Expect(proposal.GetPlainAction().GetPlainSwap().Signatures).To(Equal([]*token.OwnerSignature{
+				{Signer: []byte("Bob"), Signature: []byte("tx-signature")},
+			}))
+
+			serializedTx, err := tokenClient.SubmitSwap(proposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(serializedTx))
+		})
+
+		Context("when the prover returns an error response", func() {
+			BeforeEach(func() {
+				response := &token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "no tokens"}},
+				}
+				fakeProver.RequestSwapReturns(ProtoMarshal(response), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.ProposeSwap([][]byte{[]byte("id1")}, 10, []byte("Bob"), [][]byte{[]byte("id2")}, 20)
+				Expect(err).To(MatchError("swap request failed: no tokens"))
+			})
+		})
+
+		Context("when the transaction is not a swap", func() {
+			It("refuses to endorse it", func() {
+				err := tokenClient.EndorseSwap(&token.TokenTransaction{})
+				Expect(err).To(MatchError("the token transaction is not a swap"))
+			})
+		})
+
+		Context("when the swap has not been signed", func() {
+			It("refuses to submit it", func() {
+				_, err := tokenClient.SubmitSwap(swapTx)
+				Expect(err).To(MatchError("the swap has not been signed by the counterparty"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Transfer signed by the owners", func() {
+		var (
+			transferTx *token.TokenTransaction
+			redeemTx   *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			transferTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("Carol"), Type: "TOK1", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+			redeemTx = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainRedeem{
+							PlainRedeem: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}},
+								Outputs: []*token.PlainOutput{{Type: "TOK1", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+			fakeProver.RequestTransferReturns(ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: transferTx},
+			}), nil)
+			fakeProver.RequestRedeemReturns(ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: redeemTx},
+			}), nil)
+			fakeSigningIdentity.SerializeReturns([]byte("Alice"), nil)
+		})
+
+		It("proposes, endorses and submits a transfer", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			shares := []*token.RecipientTransferShare{{Recipient: []byte("Carol"), Quantity: 10}}
+			proposal, err := tokenClient.ProposeTransfer(tokenIDs, shares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ProtoMarshal(proposal)).To(Equal(ProtoMarshal(transferTx)))
+			ids, requestedShares, signingIdentity := fakeProver.RequestTransferArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(requestedShares).To(Equal(shares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			err = tokenClient.EndorseTransfer(proposal)
+			Expect(err).NotTo(HaveOccurred())
+			signingBytes, err := transferTx.GetPlainAction().GetPlainTransfer().SigningBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(signingBytes))
+			Expect(proposal.GetPlainAction().GetPlainTransfer().Signatures).To(Equal([]*token.OwnerSignature{
+				{Signer: []byte("Alice"), Signature: []byte("tx-signature")},
+			}))
+
+			serializedTx, err := tokenClient.SubmitTransfer(proposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(1))
+			Expect(fakeTxSubmitter.SubmitArgsForCall(0)).To(Equal(serializedTx))
+		})
+
+		It("signs the redeem signing bytes of a redeem", func() {
+			proposal, err := tokenClient.ProposeRedeem([][]byte{[]byte("id1")}, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ProtoMarshal(proposal)).To(Equal(ProtoMarshal(redeemTx)))
+
+			err = tokenClient.EndorseTransfer(proposal)
+			Expect(err).NotTo(HaveOccurred())
+			signingBytes, err := redeemTx.GetPlainAction().GetPlainRedeem().RedeemSigningBytes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(signingBytes))
+			Expect(proposal.GetPlainAction().GetPlainRedeem().Signatures).To(Equal([]*token.OwnerSignature{
+				{Signer: []byte("Alice"), Signature: []byte("tx-signature")},
+			}))
+		})
+
+		Context("when the prover returns a different transaction", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferReturns(ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: redeemTx},
+				}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.ProposeTransfer([][]byte{[]byte("id1")}, nil)
+				Expect(err).To(MatchError("transfer response does not carry a transfer transaction"))
+			})
+		})
+
+		Context("when the transaction is neither a transfer nor a redeem", func() {
+			It("refuses to endorse or submit it", func() {
+				err := tokenClient.EndorseTransfer(&token.TokenTransaction{})
+				Expect(err).To(MatchError("the token transaction is neither a transfer nor a redeem"))
+				_, err = tokenClient.SubmitTransfer(&token.TokenTransaction{})
+				Expect(err).To(MatchError("the token transaction is neither a transfer nor a redeem"))
+				Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
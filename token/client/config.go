@@ -10,6 +10,7 @@ import "github.com/pkg/errors"
 // ConnectionConfig contains data required to establish grpc connection to a peer or orderer
 type ConnectionConfig struct {
 	Address            string
+	TlsEnabled         bool
 	TlsRootCertFile    string
 	ServerNameOverride string
 }
@@ -20,7 +21,6 @@ type ClientConfig struct {
 	ChannelId     string
 	MspDir        string
 	MspId         string
-	TlsEnabled    bool
 	OrdererCfg    ConnectionConfig
 	CommitPeerCfg ConnectionConfig
 	ProverPeerCfg ConnectionConfig
@@ -38,15 +38,15 @@ func ValidateClientConfig(config *ClientConfig) error {
 		return errors.New("missing orderer address")
 	}
 
-	if config.TlsEnabled && config.OrdererCfg.TlsRootCertFile == "" {
+	if config.OrdererCfg.TlsEnabled && config.OrdererCfg.TlsRootCertFile == "" {
 		return errors.New("missing orderer TlsRootCertFile")
 	}
 
-	if config.OrdererCfg.Address == "" {
+	if config.CommitPeerCfg.Address == "" {
 		return errors.New("missing commit peer address")
 	}
 
-	if config.TlsEnabled && config.OrdererCfg.TlsRootCertFile == "" {
+	if config.CommitPeerCfg.TlsEnabled && config.CommitPeerCfg.TlsRootCertFile == "" {
 		return errors.New("missing commit peer TlsRootCertFile")
 	}
 
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
@@ -40,6 +41,9 @@ type DeliverClient interface {
 	// NewDeliverFilterd returns a DeliverFiltered
 	NewDeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (DeliverFiltered, error)
 
+	// NewDeliverTokens returns a DeliverFiltered that receives token blocks
+	NewDeliverTokens(ctx context.Context, opts ...grpc.CallOption) (DeliverFiltered, error)
+
 	// Certificate returns tls certificate for the deliver client to commit peer
 	Certificate() *tls.Certificate
 }
@@ -53,7 +57,7 @@ type deliverClient struct {
 }
 
 func NewDeliverClient(config *ClientConfig) (DeliverClient, error) {
-	grpcClient, err := createGrpcClient(&config.CommitPeerCfg, config.TlsEnabled)
+	grpcClient, err := createGrpcClient(&config.CommitPeerCfg)
 	if err != nil {
 		err = errors.WithMessage(err, fmt.Sprintf("failed to create a GRPCClient to peer %s", config.CommitPeerCfg.Address))
 		logger.Errorf("%s", err)
@@ -95,6 +99,29 @@ func (d *deliverClient) NewDeliverFiltered(ctx context.Context, opts ...grpc.Cal
 	return df, nil
 }
 
+// NewDeliverTokens creates a DeliverFiltered client that receives token blocks
+func (d *deliverClient) NewDeliverTokens(ctx context.Context, opts ...grpc.CallOption) (DeliverFiltered, error) {
+	if d.conn != nil {
+		// close the old connection because new connection will restart its timeout
+		d.conn.Close()
+	}
+
+	// create a new connection to the peer
+	var err error
+	d.conn, err = d.grpcClient.NewConnection(d.peerAddr, d.serverNameOverride)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to commit peer %s", d.peerAddr))
+	}
+
+	// create a new DeliverTokens
+	dt, err := pb.NewDeliverClient(d.conn).DeliverTokens(ctx)
+	if err != nil {
+		rpcStatus, _ := status.FromError(err)
+		return nil, errors.Wrapf(err, "failed to new a deliver tokens, rpcStatus=%+v", rpcStatus)
+	}
+	return dt, nil
+}
+
 func (d *deliverClient) Certificate() *tls.Certificate {
 	cert := d.grpcClient.Certificate()
 	return &cert
@@ -211,6 +238,44 @@ read:
 	return event.Err
 }
 
+// DeliverReceiveTokens reads token blocks from dt and sends to eventCh the valid token
+// transactions that create at least one output for owner; if owner is nil, all the valid
+// token transactions are sent. It returns when the stream fails or completes.
+func DeliverReceiveTokens(dt DeliverFiltered, address string, owner []byte, eventCh chan<- *pb.TokenTransactionEvent) error {
+	for {
+		resp, err := dt.Recv()
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error receiving deliver response from peer %s", address))
+		}
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_TokenBlock:
+			for _, tx := range r.TokenBlock.TokenTransactions {
+				logger.Debugf("deliverReceiveTokens got token transaction [%s], status [%s]", tx.Txid, tx.TxValidationCode)
+				if tx.TxValidationCode != pb.TxValidationCode_VALID || !receivesTokens(tx, owner) {
+					continue
+				}
+				eventCh <- tx
+			}
+		case *pb.DeliverResponse_Status:
+			return errors.Errorf("deliver completed with status (%s) from peer %s", r.Status, address)
+		default:
+			return errors.Errorf("received unexpected response type (%T) from peer %s", r, address)
+		}
+	}
+}
+
+func receivesTokens(tx *pb.TokenTransactionEvent, owner []byte) bool {
+	if owner == nil {
+		return true
+	}
+	for _, output := range tx.Outputs {
+		if bytes.Equal(output.Owner, owner) {
+			return true
+		}
+	}
+	return false
+}
+
 // DeliverWaitForResponse waits for either eventChan has value (i.e., response has been received) or ctx is timed out
 // This function assumes that the eventCh is only for the specified txid
 // If an eventCh is shared by multiple transactions, a loop should be used to listen to events from multiple transactions
@@ -198,4 +198,74 @@ var _ = Describe("OrdererClient", func() {
 			})
 		})
 	})
+
+	Describe("DeliverReceiveTokens", func() {
+		var eventCh chan *pb.TokenTransactionEvent
+
+		BeforeEach(func() {
+			eventCh = make(chan *pb.TokenTransactionEvent, 10)
+			tokenBlock := &pb.TokenBlock{
+				ChannelId: channelId,
+				TokenTransactions: []*pb.TokenTransactionEvent{
+					{
+						Txid:             "tx1",
+						TxValidationCode: pb.TxValidationCode_VALID,
+						Outputs:          []*pb.TokenOutputEvent{{Owner: []byte("Alice"), Type: "TOK1", Quantity: 10}},
+					},
+					{
+						Txid:             "tx2",
+						TxValidationCode: pb.TxValidationCode_VALID,
+						Outputs:          []*pb.TokenOutputEvent{{Owner: []byte("Bob"), Type: "TOK1", Quantity: 10}},
+					},
+					{
+						Txid:             "tx3",
+						TxValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT,
+						Outputs:          []*pb.TokenOutputEvent{{Owner: []byte("Alice"), Type: "TOK1", Quantity: 10}},
+					},
+				},
+			}
+			fakeDeliverFiltered.RecvReturnsOnCall(0, &pb.DeliverResponse{Type: &pb.DeliverResponse_TokenBlock{TokenBlock: tokenBlock}}, nil)
+			fakeDeliverFiltered.RecvReturnsOnCall(1, &pb.DeliverResponse{Type: &pb.DeliverResponse_Status{Status: common.Status_SUCCESS}}, nil)
+		})
+
+		It("sends the valid transactions creating outputs for the owner", func() {
+			err := client.DeliverReceiveTokens(fakeDeliverFiltered, "dummyAddress", []byte("Alice"), eventCh)
+			Expect(err).To(MatchError("deliver completed with status (SUCCESS) from peer dummyAddress"))
+			Expect(eventCh).To(HaveLen(1))
+			Expect((<-eventCh).Txid).To(Equal("tx1"))
+		})
+
+		Context("when no owner is given", func() {
+			It("sends all the valid transactions", func() {
+				err := client.DeliverReceiveTokens(fakeDeliverFiltered, "dummyAddress", nil, eventCh)
+				Expect(err).To(HaveOccurred())
+				Expect(eventCh).To(HaveLen(2))
+				Expect((<-eventCh).Txid).To(Equal("tx1"))
+				Expect((<-eventCh).Txid).To(Equal("tx2"))
+			})
+		})
+
+		Context("when Deliver.Recv returns error", func() {
+			BeforeEach(func() {
+				fakeDeliverFiltered.RecvReturnsOnCall(0, nil, errors.New("flying-banana"))
+			})
+
+			It("returns an error", func() {
+				err := client.DeliverReceiveTokens(fakeDeliverFiltered, "dummyAddress", nil, eventCh)
+				Expect(err).To(MatchError("error receiving deliver response from peer dummyAddress: flying-banana"))
+				Expect(eventCh).To(BeEmpty())
+			})
+		})
+
+		Context("when an unexpected response is received", func() {
+			BeforeEach(func() {
+				fakeDeliverFiltered.RecvReturnsOnCall(0, deliverResp, nil)
+			})
+
+			It("returns an error", func() {
+				err := client.DeliverReceiveTokens(fakeDeliverFiltered, "dummyAddress", nil, eventCh)
+				Expect(err).To(MatchError("received unexpected response type (*peer.DeliverResponse_FilteredBlock) from peer dummyAddress"))
+			})
+		})
+	})
 })
@@ -2,20 +2,30 @@
 package mock
 
 import (
-	"context"
-	"crypto/tls"
-	"sync"
+	context "context"
+	tls "crypto/tls"
+	sync "sync"
 
-	"github.com/tradeline-tech/fabric/token/client"
-	"google.golang.org/grpc"
+	client "github.com/tradeline-tech/fabric/token/client"
+	grpc "google.golang.org/grpc"
 )
 
 type DeliverClient struct {
-	NewDeliverFilteredStub        func(ctx context.Context, opts ...grpc.CallOption) (client.DeliverFiltered, error)
+	CertificateStub        func() *tls.Certificate
+	certificateMutex       sync.RWMutex
+	certificateArgsForCall []struct {
+	}
+	certificateReturns struct {
+		result1 *tls.Certificate
+	}
+	certificateReturnsOnCall map[int]struct {
+		result1 *tls.Certificate
+	}
+	NewDeliverFilteredStub        func(context.Context, ...grpc.CallOption) (client.DeliverFiltered, error)
 	newDeliverFilteredMutex       sync.RWMutex
 	newDeliverFilteredArgsForCall []struct {
-		ctx  context.Context
-		opts []grpc.CallOption
+		arg1 context.Context
+		arg2 []grpc.CallOption
 	}
 	newDeliverFilteredReturns struct {
 		result1 client.DeliverFiltered
@@ -25,35 +35,93 @@ type DeliverClient struct {
 		result1 client.DeliverFiltered
 		result2 error
 	}
-	CertificateStub        func() *tls.Certificate
-	certificateMutex       sync.RWMutex
-	certificateArgsForCall []struct{}
-	certificateReturns     struct {
-		result1 *tls.Certificate
+	NewDeliverTokensStub        func(context.Context, ...grpc.CallOption) (client.DeliverFiltered, error)
+	newDeliverTokensMutex       sync.RWMutex
+	newDeliverTokensArgsForCall []struct {
+		arg1 context.Context
+		arg2 []grpc.CallOption
 	}
-	certificateReturnsOnCall map[int]struct {
-		result1 *tls.Certificate
+	newDeliverTokensReturns struct {
+		result1 client.DeliverFiltered
+		result2 error
+	}
+	newDeliverTokensReturnsOnCall map[int]struct {
+		result1 client.DeliverFiltered
+		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *DeliverClient) NewDeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (client.DeliverFiltered, error) {
+func (fake *DeliverClient) Certificate() *tls.Certificate {
+	fake.certificateMutex.Lock()
+	ret, specificReturn := fake.certificateReturnsOnCall[len(fake.certificateArgsForCall)]
+	fake.certificateArgsForCall = append(fake.certificateArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Certificate", []interface{}{})
+	fake.certificateMutex.Unlock()
+	if fake.CertificateStub != nil {
+		return fake.CertificateStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.certificateReturns
+	return fakeReturns.result1
+}
+
+func (fake *DeliverClient) CertificateCallCount() int {
+	fake.certificateMutex.RLock()
+	defer fake.certificateMutex.RUnlock()
+	return len(fake.certificateArgsForCall)
+}
+
+func (fake *DeliverClient) CertificateCalls(stub func() *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = stub
+}
+
+func (fake *DeliverClient) CertificateReturns(result1 *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	fake.certificateReturns = struct {
+		result1 *tls.Certificate
+	}{result1}
+}
+
+func (fake *DeliverClient) CertificateReturnsOnCall(i int, result1 *tls.Certificate) {
+	fake.certificateMutex.Lock()
+	defer fake.certificateMutex.Unlock()
+	fake.CertificateStub = nil
+	if fake.certificateReturnsOnCall == nil {
+		fake.certificateReturnsOnCall = make(map[int]struct {
+			result1 *tls.Certificate
+		})
+	}
+	fake.certificateReturnsOnCall[i] = struct {
+		result1 *tls.Certificate
+	}{result1}
+}
+
+func (fake *DeliverClient) NewDeliverFiltered(arg1 context.Context, arg2 ...grpc.CallOption) (client.DeliverFiltered, error) {
 	fake.newDeliverFilteredMutex.Lock()
 	ret, specificReturn := fake.newDeliverFilteredReturnsOnCall[len(fake.newDeliverFilteredArgsForCall)]
 	fake.newDeliverFilteredArgsForCall = append(fake.newDeliverFilteredArgsForCall, struct {
-		ctx  context.Context
-		opts []grpc.CallOption
-	}{ctx, opts})
-	fake.recordInvocation("NewDeliverFiltered", []interface{}{ctx, opts})
+		arg1 context.Context
+		arg2 []grpc.CallOption
+	}{arg1, arg2})
+	fake.recordInvocation("NewDeliverFiltered", []interface{}{arg1, arg2})
 	fake.newDeliverFilteredMutex.Unlock()
 	if fake.NewDeliverFilteredStub != nil {
-		return fake.NewDeliverFilteredStub(ctx, opts...)
+		return fake.NewDeliverFilteredStub(arg1, arg2...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.newDeliverFilteredReturns.result1, fake.newDeliverFilteredReturns.result2
+	fakeReturns := fake.newDeliverFilteredReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *DeliverClient) NewDeliverFilteredCallCount() int {
@@ -62,13 +130,22 @@ func (fake *DeliverClient) NewDeliverFilteredCallCount() int {
 	return len(fake.newDeliverFilteredArgsForCall)
 }
 
+func (fake *DeliverClient) NewDeliverFilteredCalls(stub func(context.Context, ...grpc.CallOption) (client.DeliverFiltered, error)) {
+	fake.newDeliverFilteredMutex.Lock()
+	defer fake.newDeliverFilteredMutex.Unlock()
+	fake.NewDeliverFilteredStub = stub
+}
+
 func (fake *DeliverClient) NewDeliverFilteredArgsForCall(i int) (context.Context, []grpc.CallOption) {
 	fake.newDeliverFilteredMutex.RLock()
 	defer fake.newDeliverFilteredMutex.RUnlock()
-	return fake.newDeliverFilteredArgsForCall[i].ctx, fake.newDeliverFilteredArgsForCall[i].opts
+	argsForCall := fake.newDeliverFilteredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *DeliverClient) NewDeliverFilteredReturns(result1 client.DeliverFiltered, result2 error) {
+	fake.newDeliverFilteredMutex.Lock()
+	defer fake.newDeliverFilteredMutex.Unlock()
 	fake.NewDeliverFilteredStub = nil
 	fake.newDeliverFilteredReturns = struct {
 		result1 client.DeliverFiltered
@@ -77,6 +154,8 @@ func (fake *DeliverClient) NewDeliverFilteredReturns(result1 client.DeliverFilte
 }
 
 func (fake *DeliverClient) NewDeliverFilteredReturnsOnCall(i int, result1 client.DeliverFiltered, result2 error) {
+	fake.newDeliverFilteredMutex.Lock()
+	defer fake.newDeliverFilteredMutex.Unlock()
 	fake.NewDeliverFilteredStub = nil
 	if fake.newDeliverFilteredReturnsOnCall == nil {
 		fake.newDeliverFilteredReturnsOnCall = make(map[int]struct {
@@ -90,53 +169,79 @@ func (fake *DeliverClient) NewDeliverFilteredReturnsOnCall(i int, result1 client
 	}{result1, result2}
 }
 
-func (fake *DeliverClient) Certificate() *tls.Certificate {
-	fake.certificateMutex.Lock()
-	ret, specificReturn := fake.certificateReturnsOnCall[len(fake.certificateArgsForCall)]
-	fake.certificateArgsForCall = append(fake.certificateArgsForCall, struct{}{})
-	fake.recordInvocation("Certificate", []interface{}{})
-	fake.certificateMutex.Unlock()
-	if fake.CertificateStub != nil {
-		return fake.CertificateStub()
+func (fake *DeliverClient) NewDeliverTokens(arg1 context.Context, arg2 ...grpc.CallOption) (client.DeliverFiltered, error) {
+	fake.newDeliverTokensMutex.Lock()
+	ret, specificReturn := fake.newDeliverTokensReturnsOnCall[len(fake.newDeliverTokensArgsForCall)]
+	fake.newDeliverTokensArgsForCall = append(fake.newDeliverTokensArgsForCall, struct {
+		arg1 context.Context
+		arg2 []grpc.CallOption
+	}{arg1, arg2})
+	fake.recordInvocation("NewDeliverTokens", []interface{}{arg1, arg2})
+	fake.newDeliverTokensMutex.Unlock()
+	if fake.NewDeliverTokensStub != nil {
+		return fake.NewDeliverTokensStub(arg1, arg2...)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fake.certificateReturns.result1
+	fakeReturns := fake.newDeliverTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *DeliverClient) CertificateCallCount() int {
-	fake.certificateMutex.RLock()
-	defer fake.certificateMutex.RUnlock()
-	return len(fake.certificateArgsForCall)
+func (fake *DeliverClient) NewDeliverTokensCallCount() int {
+	fake.newDeliverTokensMutex.RLock()
+	defer fake.newDeliverTokensMutex.RUnlock()
+	return len(fake.newDeliverTokensArgsForCall)
 }
 
-func (fake *DeliverClient) CertificateReturns(result1 *tls.Certificate) {
-	fake.CertificateStub = nil
-	fake.certificateReturns = struct {
-		result1 *tls.Certificate
-	}{result1}
+func (fake *DeliverClient) NewDeliverTokensCalls(stub func(context.Context, ...grpc.CallOption) (client.DeliverFiltered, error)) {
+	fake.newDeliverTokensMutex.Lock()
+	defer fake.newDeliverTokensMutex.Unlock()
+	fake.NewDeliverTokensStub = stub
 }
 
-func (fake *DeliverClient) CertificateReturnsOnCall(i int, result1 *tls.Certificate) {
-	fake.CertificateStub = nil
-	if fake.certificateReturnsOnCall == nil {
-		fake.certificateReturnsOnCall = make(map[int]struct {
-			result1 *tls.Certificate
+func (fake *DeliverClient) NewDeliverTokensArgsForCall(i int) (context.Context, []grpc.CallOption) {
+	fake.newDeliverTokensMutex.RLock()
+	defer fake.newDeliverTokensMutex.RUnlock()
+	argsForCall := fake.newDeliverTokensArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *DeliverClient) NewDeliverTokensReturns(result1 client.DeliverFiltered, result2 error) {
+	fake.newDeliverTokensMutex.Lock()
+	defer fake.newDeliverTokensMutex.Unlock()
+	fake.NewDeliverTokensStub = nil
+	fake.newDeliverTokensReturns = struct {
+		result1 client.DeliverFiltered
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeliverClient) NewDeliverTokensReturnsOnCall(i int, result1 client.DeliverFiltered, result2 error) {
+	fake.newDeliverTokensMutex.Lock()
+	defer fake.newDeliverTokensMutex.Unlock()
+	fake.NewDeliverTokensStub = nil
+	if fake.newDeliverTokensReturnsOnCall == nil {
+		fake.newDeliverTokensReturnsOnCall = make(map[int]struct {
+			result1 client.DeliverFiltered
+			result2 error
 		})
 	}
-	fake.certificateReturnsOnCall[i] = struct {
-		result1 *tls.Certificate
-	}{result1}
+	fake.newDeliverTokensReturnsOnCall[i] = struct {
+		result1 client.DeliverFiltered
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *DeliverClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.newDeliverFilteredMutex.RLock()
-	defer fake.newDeliverFilteredMutex.RUnlock()
 	fake.certificateMutex.RLock()
 	defer fake.certificateMutex.RUnlock()
+	fake.newDeliverFilteredMutex.RLock()
+	defer fake.newDeliverFilteredMutex.RUnlock()
+	fake.newDeliverTokensMutex.RLock()
+	defer fake.newDeliverTokensMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -4,17 +4,115 @@ package mock
 import (
 	sync "sync"
 
-	token "github.com/tradeline-tech/fabric/protos/token"
-	tokena "github.com/tradeline-tech/fabric/token"
+	tokena "github.com/tradeline-tech/fabric/protos/token"
+	token "github.com/tradeline-tech/fabric/token"
 	client "github.com/tradeline-tech/fabric/token/client"
 )
 
 type Prover struct {
-	RequestImportStub        func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)
+	GetBalanceStub        func(token.SigningIdentity) ([]byte, error)
+	getBalanceMutex       sync.RWMutex
+	getBalanceArgsForCall []struct {
+		arg1 token.SigningIdentity
+	}
+	getBalanceReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getBalanceReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetSpendStatusStub        func(*tokena.InputId, token.SigningIdentity) ([]byte, error)
+	getSpendStatusMutex       sync.RWMutex
+	getSpendStatusArgsForCall []struct {
+		arg1 *tokena.InputId
+		arg2 token.SigningIdentity
+	}
+	getSpendStatusReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getSpendStatusReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetTokenHistoryStub        func(uint32, string, token.SigningIdentity) ([]byte, error)
+	getTokenHistoryMutex       sync.RWMutex
+	getTokenHistoryArgsForCall []struct {
+		arg1 uint32
+		arg2 string
+		arg3 token.SigningIdentity
+	}
+	getTokenHistoryReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getTokenHistoryReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	ListTokenTypesStub        func(token.SigningIdentity) ([]byte, error)
+	listTokenTypesMutex       sync.RWMutex
+	listTokenTypesArgsForCall []struct {
+		arg1 token.SigningIdentity
+	}
+	listTokenTypesReturns struct {
+		result1 []byte
+		result2 error
+	}
+	listTokenTypesReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	ListTokensStub        func(token.SigningIdentity) ([]byte, error)
+	listTokensMutex       sync.RWMutex
+	listTokensArgsForCall []struct {
+		arg1 token.SigningIdentity
+	}
+	listTokensReturns struct {
+		result1 []byte
+		result2 error
+	}
+	listTokensReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestApproveStub        func([][]byte, []*tokena.AllowanceRecipientShare, token.SigningIdentity) ([]byte, error)
+	requestApproveMutex       sync.RWMutex
+	requestApproveArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*tokena.AllowanceRecipientShare
+		arg3 token.SigningIdentity
+	}
+	requestApproveReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestApproveReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestExpectationStub        func([][]byte, *tokena.TokenExpectation, token.SigningIdentity) ([]byte, error)
+	requestExpectationMutex       sync.RWMutex
+	requestExpectationArgsForCall []struct {
+		arg1 [][]byte
+		arg2 *tokena.TokenExpectation
+		arg3 token.SigningIdentity
+	}
+	requestExpectationReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestExpectationReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestImportStub        func([]*tokena.TokenToIssue, token.SigningIdentity) ([]byte, error)
 	requestImportMutex       sync.RWMutex
 	requestImportArgsForCall []struct {
-		arg1 []*token.TokenToIssue
-		arg2 tokena.SigningIdentity
+		arg1 []*tokena.TokenToIssue
+		arg2 token.SigningIdentity
 	}
 	requestImportReturns struct {
 		result1 []byte
@@ -24,12 +122,59 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
-	RequestTransferStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
+	RequestRedeemStub        func([][]byte, uint64, token.SigningIdentity) ([]byte, error)
+	requestRedeemMutex       sync.RWMutex
+	requestRedeemArgsForCall []struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 token.SigningIdentity
+	}
+	requestRedeemReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRedeemReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestRegisterTokenTypeStub        func(*tokena.TokenTypeDefinition, token.SigningIdentity) ([]byte, error)
+	requestRegisterTokenTypeMutex       sync.RWMutex
+	requestRegisterTokenTypeArgsForCall []struct {
+		arg1 *tokena.TokenTypeDefinition
+		arg2 token.SigningIdentity
+	}
+	requestRegisterTokenTypeReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRegisterTokenTypeReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestSwapStub        func([][]byte, uint64, []byte, [][]byte, uint64, token.SigningIdentity) ([]byte, error)
+	requestSwapMutex       sync.RWMutex
+	requestSwapArgsForCall []struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 []byte
+		arg4 [][]byte
+		arg5 uint64
+		arg6 token.SigningIdentity
+	}
+	requestSwapReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestSwapReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestTransferStub        func([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) ([]byte, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
 		arg1 [][]byte
-		arg2 []*token.RecipientTransferShare
-		arg3 tokena.SigningIdentity
+		arg2 []*tokena.RecipientTransferShare
+		arg3 token.SigningIdentity
 	}
 	requestTransferReturns struct {
 		result1 []byte
@@ -39,21 +184,499 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestTransferFromStub        func([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) ([]byte, error)
+	requestTransferFromMutex       sync.RWMutex
+	requestTransferFromArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*tokena.RecipientTransferShare
+		arg3 token.SigningIdentity
+	}
+	requestTransferFromReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestTransferFromReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Prover) RequestImport(arg1 []*token.TokenToIssue, arg2 tokena.SigningIdentity) ([]byte, error) {
-	var arg1Copy []*token.TokenToIssue
+func (fake *Prover) GetBalance(arg1 token.SigningIdentity) ([]byte, error) {
+	fake.getBalanceMutex.Lock()
+	ret, specificReturn := fake.getBalanceReturnsOnCall[len(fake.getBalanceArgsForCall)]
+	fake.getBalanceArgsForCall = append(fake.getBalanceArgsForCall, struct {
+		arg1 token.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("GetBalance", []interface{}{arg1})
+	fake.getBalanceMutex.Unlock()
+	if fake.GetBalanceStub != nil {
+		return fake.GetBalanceStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBalanceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) GetBalanceCallCount() int {
+	fake.getBalanceMutex.RLock()
+	defer fake.getBalanceMutex.RUnlock()
+	return len(fake.getBalanceArgsForCall)
+}
+
+func (fake *Prover) GetBalanceCalls(stub func(token.SigningIdentity) ([]byte, error)) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = stub
+}
+
+func (fake *Prover) GetBalanceArgsForCall(i int) token.SigningIdentity {
+	fake.getBalanceMutex.RLock()
+	defer fake.getBalanceMutex.RUnlock()
+	argsForCall := fake.getBalanceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) GetBalanceReturns(result1 []byte, result2 error) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = nil
+	fake.getBalanceReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetBalanceReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = nil
+	if fake.getBalanceReturnsOnCall == nil {
+		fake.getBalanceReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getBalanceReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetSpendStatus(arg1 *tokena.InputId, arg2 token.SigningIdentity) ([]byte, error) {
+	fake.getSpendStatusMutex.Lock()
+	ret, specificReturn := fake.getSpendStatusReturnsOnCall[len(fake.getSpendStatusArgsForCall)]
+	fake.getSpendStatusArgsForCall = append(fake.getSpendStatusArgsForCall, struct {
+		arg1 *tokena.InputId
+		arg2 token.SigningIdentity
+	}{arg1, arg2})
+	fake.recordInvocation("GetSpendStatus", []interface{}{arg1, arg2})
+	fake.getSpendStatusMutex.Unlock()
+	if fake.GetSpendStatusStub != nil {
+		return fake.GetSpendStatusStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getSpendStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) GetSpendStatusCallCount() int {
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	return len(fake.getSpendStatusArgsForCall)
+}
+
+func (fake *Prover) GetSpendStatusCalls(stub func(*tokena.InputId, token.SigningIdentity) ([]byte, error)) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = stub
+}
+
+func (fake *Prover) GetSpendStatusArgsForCall(i int) (*tokena.InputId, token.SigningIdentity) {
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	argsForCall := fake.getSpendStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Prover) GetSpendStatusReturns(result1 []byte, result2 error) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = nil
+	fake.getSpendStatusReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetSpendStatusReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = nil
+	if fake.getSpendStatusReturnsOnCall == nil {
+		fake.getSpendStatusReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getSpendStatusReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetTokenHistory(arg1 uint32, arg2 string, arg3 token.SigningIdentity) ([]byte, error) {
+	fake.getTokenHistoryMutex.Lock()
+	ret, specificReturn := fake.getTokenHistoryReturnsOnCall[len(fake.getTokenHistoryArgsForCall)]
+	fake.getTokenHistoryArgsForCall = append(fake.getTokenHistoryArgsForCall, struct {
+		arg1 uint32
+		arg2 string
+		arg3 token.SigningIdentity
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetTokenHistory", []interface{}{arg1, arg2, arg3})
+	fake.getTokenHistoryMutex.Unlock()
+	if fake.GetTokenHistoryStub != nil {
+		return fake.GetTokenHistoryStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getTokenHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) GetTokenHistoryCallCount() int {
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	return len(fake.getTokenHistoryArgsForCall)
+}
+
+func (fake *Prover) GetTokenHistoryCalls(stub func(uint32, string, token.SigningIdentity) ([]byte, error)) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = stub
+}
+
+func (fake *Prover) GetTokenHistoryArgsForCall(i int) (uint32, string, token.SigningIdentity) {
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	argsForCall := fake.getTokenHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) GetTokenHistoryReturns(result1 []byte, result2 error) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = nil
+	fake.getTokenHistoryReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) GetTokenHistoryReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = nil
+	if fake.getTokenHistoryReturnsOnCall == nil {
+		fake.getTokenHistoryReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getTokenHistoryReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokenTypes(arg1 token.SigningIdentity) ([]byte, error) {
+	fake.listTokenTypesMutex.Lock()
+	ret, specificReturn := fake.listTokenTypesReturnsOnCall[len(fake.listTokenTypesArgsForCall)]
+	fake.listTokenTypesArgsForCall = append(fake.listTokenTypesArgsForCall, struct {
+		arg1 token.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("ListTokenTypes", []interface{}{arg1})
+	fake.listTokenTypesMutex.Unlock()
+	if fake.ListTokenTypesStub != nil {
+		return fake.ListTokenTypesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTokenTypesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) ListTokenTypesCallCount() int {
+	fake.listTokenTypesMutex.RLock()
+	defer fake.listTokenTypesMutex.RUnlock()
+	return len(fake.listTokenTypesArgsForCall)
+}
+
+func (fake *Prover) ListTokenTypesCalls(stub func(token.SigningIdentity) ([]byte, error)) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = stub
+}
+
+func (fake *Prover) ListTokenTypesArgsForCall(i int) token.SigningIdentity {
+	fake.listTokenTypesMutex.RLock()
+	defer fake.listTokenTypesMutex.RUnlock()
+	argsForCall := fake.listTokenTypesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) ListTokenTypesReturns(result1 []byte, result2 error) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = nil
+	fake.listTokenTypesReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokenTypesReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = nil
+	if fake.listTokenTypesReturnsOnCall == nil {
+		fake.listTokenTypesReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.listTokenTypesReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokens(arg1 token.SigningIdentity) ([]byte, error) {
+	fake.listTokensMutex.Lock()
+	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
+	fake.listTokensArgsForCall = append(fake.listTokensArgsForCall, struct {
+		arg1 token.SigningIdentity
+	}{arg1})
+	fake.recordInvocation("ListTokens", []interface{}{arg1})
+	fake.listTokensMutex.Unlock()
+	if fake.ListTokensStub != nil {
+		return fake.ListTokensStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTokensReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) ListTokensCallCount() int {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	return len(fake.listTokensArgsForCall)
+}
+
+func (fake *Prover) ListTokensCalls(stub func(token.SigningIdentity) ([]byte, error)) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = stub
+}
+
+func (fake *Prover) ListTokensArgsForCall(i int) token.SigningIdentity {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	argsForCall := fake.listTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Prover) ListTokensReturns(result1 []byte, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	fake.listTokensReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) ListTokensReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.listTokensMutex.Lock()
+	defer fake.listTokensMutex.Unlock()
+	fake.ListTokensStub = nil
+	if fake.listTokensReturnsOnCall == nil {
+		fake.listTokensReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.listTokensReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestApprove(arg1 [][]byte, arg2 []*tokena.AllowanceRecipientShare, arg3 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*tokena.AllowanceRecipientShare
+	if arg2 != nil {
+		arg2Copy = make([]*tokena.AllowanceRecipientShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestApproveMutex.Lock()
+	ret, specificReturn := fake.requestApproveReturnsOnCall[len(fake.requestApproveArgsForCall)]
+	fake.requestApproveArgsForCall = append(fake.requestApproveArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*tokena.AllowanceRecipientShare
+		arg3 token.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestApprove", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestApproveMutex.Unlock()
+	if fake.RequestApproveStub != nil {
+		return fake.RequestApproveStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestApproveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestApproveCallCount() int {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	return len(fake.requestApproveArgsForCall)
+}
+
+func (fake *Prover) RequestApproveCalls(stub func([][]byte, []*tokena.AllowanceRecipientShare, token.SigningIdentity) ([]byte, error)) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = stub
+}
+
+func (fake *Prover) RequestApproveArgsForCall(i int) ([][]byte, []*tokena.AllowanceRecipientShare, token.SigningIdentity) {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	argsForCall := fake.requestApproveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestApproveReturns(result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	fake.requestApproveReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestApproveReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	if fake.requestApproveReturnsOnCall == nil {
+		fake.requestApproveReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestApproveReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestExpectation(arg1 [][]byte, arg2 *tokena.TokenExpectation, arg3 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
 	if arg1 != nil {
-		arg1Copy = make([]*token.TokenToIssue, len(arg1))
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestExpectationMutex.Lock()
+	ret, specificReturn := fake.requestExpectationReturnsOnCall[len(fake.requestExpectationArgsForCall)]
+	fake.requestExpectationArgsForCall = append(fake.requestExpectationArgsForCall, struct {
+		arg1 [][]byte
+		arg2 *tokena.TokenExpectation
+		arg3 token.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestExpectation", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestExpectationMutex.Unlock()
+	if fake.RequestExpectationStub != nil {
+		return fake.RequestExpectationStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestExpectationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestExpectationCallCount() int {
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
+	return len(fake.requestExpectationArgsForCall)
+}
+
+func (fake *Prover) RequestExpectationCalls(stub func([][]byte, *tokena.TokenExpectation, token.SigningIdentity) ([]byte, error)) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = stub
+}
+
+func (fake *Prover) RequestExpectationArgsForCall(i int) ([][]byte, *tokena.TokenExpectation, token.SigningIdentity) {
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
+	argsForCall := fake.requestExpectationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestExpectationReturns(result1 []byte, result2 error) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = nil
+	fake.requestExpectationReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestExpectationReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestExpectationMutex.Lock()
+	defer fake.requestExpectationMutex.Unlock()
+	fake.RequestExpectationStub = nil
+	if fake.requestExpectationReturnsOnCall == nil {
+		fake.requestExpectationReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestExpectationReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestImport(arg1 []*tokena.TokenToIssue, arg2 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy []*tokena.TokenToIssue
+	if arg1 != nil {
+		arg1Copy = make([]*tokena.TokenToIssue, len(arg1))
 		copy(arg1Copy, arg1)
 	}
 	fake.requestImportMutex.Lock()
 	ret, specificReturn := fake.requestImportReturnsOnCall[len(fake.requestImportArgsForCall)]
 	fake.requestImportArgsForCall = append(fake.requestImportArgsForCall, struct {
-		arg1 []*token.TokenToIssue
-		arg2 tokena.SigningIdentity
+		arg1 []*tokena.TokenToIssue
+		arg2 token.SigningIdentity
 	}{arg1Copy, arg2})
 	fake.recordInvocation("RequestImport", []interface{}{arg1Copy, arg2})
 	fake.requestImportMutex.Unlock()
@@ -73,13 +696,13 @@ func (fake *Prover) RequestImportCallCount() int {
 	return len(fake.requestImportArgsForCall)
 }
 
-func (fake *Prover) RequestImportCalls(stub func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)) {
+func (fake *Prover) RequestImportCalls(stub func([]*tokena.TokenToIssue, token.SigningIdentity) ([]byte, error)) {
 	fake.requestImportMutex.Lock()
 	defer fake.requestImportMutex.Unlock()
 	fake.RequestImportStub = stub
 }
 
-func (fake *Prover) RequestImportArgsForCall(i int) ([]*token.TokenToIssue, tokena.SigningIdentity) {
+func (fake *Prover) RequestImportArgsForCall(i int) ([]*tokena.TokenToIssue, token.SigningIdentity) {
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
 	argsForCall := fake.requestImportArgsForCall[i]
@@ -112,23 +735,240 @@ func (fake *Prover) RequestImportReturnsOnCall(i int, result1 []byte, result2 er
 	}{result1, result2}
 }
 
-func (fake *Prover) RequestTransfer(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+func (fake *Prover) RequestRedeem(arg1 [][]byte, arg2 uint64, arg3 token.SigningIdentity) ([]byte, error) {
 	var arg1Copy [][]byte
 	if arg1 != nil {
 		arg1Copy = make([][]byte, len(arg1))
 		copy(arg1Copy, arg1)
 	}
-	var arg2Copy []*token.RecipientTransferShare
+	fake.requestRedeemMutex.Lock()
+	ret, specificReturn := fake.requestRedeemReturnsOnCall[len(fake.requestRedeemArgsForCall)]
+	fake.requestRedeemArgsForCall = append(fake.requestRedeemArgsForCall, struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 token.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestRedeem", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestRedeemMutex.Unlock()
+	if fake.RequestRedeemStub != nil {
+		return fake.RequestRedeemStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRedeemReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRedeemCallCount() int {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	return len(fake.requestRedeemArgsForCall)
+}
+
+func (fake *Prover) RequestRedeemCalls(stub func([][]byte, uint64, token.SigningIdentity) ([]byte, error)) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = stub
+}
+
+func (fake *Prover) RequestRedeemArgsForCall(i int) ([][]byte, uint64, token.SigningIdentity) {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	argsForCall := fake.requestRedeemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestRedeemReturns(result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	fake.requestRedeemReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	if fake.requestRedeemReturnsOnCall == nil {
+		fake.requestRedeemReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRedeemReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRegisterTokenType(arg1 *tokena.TokenTypeDefinition, arg2 token.SigningIdentity) ([]byte, error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	ret, specificReturn := fake.requestRegisterTokenTypeReturnsOnCall[len(fake.requestRegisterTokenTypeArgsForCall)]
+	fake.requestRegisterTokenTypeArgsForCall = append(fake.requestRegisterTokenTypeArgsForCall, struct {
+		arg1 *tokena.TokenTypeDefinition
+		arg2 token.SigningIdentity
+	}{arg1, arg2})
+	fake.recordInvocation("RequestRegisterTokenType", []interface{}{arg1, arg2})
+	fake.requestRegisterTokenTypeMutex.Unlock()
+	if fake.RequestRegisterTokenTypeStub != nil {
+		return fake.RequestRegisterTokenTypeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRegisterTokenTypeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRegisterTokenTypeCallCount() int {
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
+	return len(fake.requestRegisterTokenTypeArgsForCall)
+}
+
+func (fake *Prover) RequestRegisterTokenTypeCalls(stub func(*tokena.TokenTypeDefinition, token.SigningIdentity) ([]byte, error)) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = stub
+}
+
+func (fake *Prover) RequestRegisterTokenTypeArgsForCall(i int) (*tokena.TokenTypeDefinition, token.SigningIdentity) {
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
+	argsForCall := fake.requestRegisterTokenTypeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Prover) RequestRegisterTokenTypeReturns(result1 []byte, result2 error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = nil
+	fake.requestRegisterTokenTypeReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRegisterTokenTypeReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = nil
+	if fake.requestRegisterTokenTypeReturnsOnCall == nil {
+		fake.requestRegisterTokenTypeReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRegisterTokenTypeReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestSwap(arg1 [][]byte, arg2 uint64, arg3 []byte, arg4 [][]byte, arg5 uint64, arg6 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	var arg4Copy [][]byte
+	if arg4 != nil {
+		arg4Copy = make([][]byte, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.requestSwapMutex.Lock()
+	ret, specificReturn := fake.requestSwapReturnsOnCall[len(fake.requestSwapArgsForCall)]
+	fake.requestSwapArgsForCall = append(fake.requestSwapArgsForCall, struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 []byte
+		arg4 [][]byte
+		arg5 uint64
+		arg6 token.SigningIdentity
+	}{arg1Copy, arg2, arg3Copy, arg4Copy, arg5, arg6})
+	fake.recordInvocation("RequestSwap", []interface{}{arg1Copy, arg2, arg3Copy, arg4Copy, arg5, arg6})
+	fake.requestSwapMutex.Unlock()
+	if fake.RequestSwapStub != nil {
+		return fake.RequestSwapStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestSwapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestSwapCallCount() int {
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
+	return len(fake.requestSwapArgsForCall)
+}
+
+func (fake *Prover) RequestSwapCalls(stub func([][]byte, uint64, []byte, [][]byte, uint64, token.SigningIdentity) ([]byte, error)) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = stub
+}
+
+func (fake *Prover) RequestSwapArgsForCall(i int) ([][]byte, uint64, []byte, [][]byte, uint64, token.SigningIdentity) {
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
+	argsForCall := fake.requestSwapArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *Prover) RequestSwapReturns(result1 []byte, result2 error) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = nil
+	fake.requestSwapReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestSwapReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = nil
+	if fake.requestSwapReturnsOnCall == nil {
+		fake.requestSwapReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestSwapReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransfer(arg1 [][]byte, arg2 []*tokena.RecipientTransferShare, arg3 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*tokena.RecipientTransferShare
 	if arg2 != nil {
-		arg2Copy = make([]*token.RecipientTransferShare, len(arg2))
+		arg2Copy = make([]*tokena.RecipientTransferShare, len(arg2))
 		copy(arg2Copy, arg2)
 	}
 	fake.requestTransferMutex.Lock()
 	ret, specificReturn := fake.requestTransferReturnsOnCall[len(fake.requestTransferArgsForCall)]
 	fake.requestTransferArgsForCall = append(fake.requestTransferArgsForCall, struct {
 		arg1 [][]byte
-		arg2 []*token.RecipientTransferShare
-		arg3 tokena.SigningIdentity
+		arg2 []*tokena.RecipientTransferShare
+		arg3 token.SigningIdentity
 	}{arg1Copy, arg2Copy, arg3})
 	fake.recordInvocation("RequestTransfer", []interface{}{arg1Copy, arg2Copy, arg3})
 	fake.requestTransferMutex.Unlock()
@@ -148,13 +988,13 @@ func (fake *Prover) RequestTransferCallCount() int {
 	return len(fake.requestTransferArgsForCall)
 }
 
-func (fake *Prover) RequestTransferCalls(stub func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)) {
+func (fake *Prover) RequestTransferCalls(stub func([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) ([]byte, error)) {
 	fake.requestTransferMutex.Lock()
 	defer fake.requestTransferMutex.Unlock()
 	fake.RequestTransferStub = stub
 }
 
-func (fake *Prover) RequestTransferArgsForCall(i int) ([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) {
+func (fake *Prover) RequestTransferArgsForCall(i int) ([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) {
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
 	argsForCall := fake.requestTransferArgsForCall[i]
@@ -187,13 +1027,110 @@ func (fake *Prover) RequestTransferReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestTransferFrom(arg1 [][]byte, arg2 []*tokena.RecipientTransferShare, arg3 token.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*tokena.RecipientTransferShare
+	if arg2 != nil {
+		arg2Copy = make([]*tokena.RecipientTransferShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestTransferFromMutex.Lock()
+	ret, specificReturn := fake.requestTransferFromReturnsOnCall[len(fake.requestTransferFromArgsForCall)]
+	fake.requestTransferFromArgsForCall = append(fake.requestTransferFromArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*tokena.RecipientTransferShare
+		arg3 token.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestTransferFrom", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestTransferFromMutex.Unlock()
+	if fake.RequestTransferFromStub != nil {
+		return fake.RequestTransferFromStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestTransferFromReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestTransferFromCallCount() int {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	return len(fake.requestTransferFromArgsForCall)
+}
+
+func (fake *Prover) RequestTransferFromCalls(stub func([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) ([]byte, error)) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = stub
+}
+
+func (fake *Prover) RequestTransferFromArgsForCall(i int) ([][]byte, []*tokena.RecipientTransferShare, token.SigningIdentity) {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	argsForCall := fake.requestTransferFromArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestTransferFromReturns(result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	fake.requestTransferFromReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransferFromReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	if fake.requestTransferFromReturnsOnCall == nil {
+		fake.requestTransferFromReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestTransferFromReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getBalanceMutex.RLock()
+	defer fake.getBalanceMutex.RUnlock()
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	fake.listTokenTypesMutex.RLock()
+	defer fake.listTokenTypesMutex.RUnlock()
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	fake.requestExpectationMutex.RLock()
+	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
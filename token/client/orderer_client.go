@@ -50,7 +50,7 @@ type ordererClient struct {
 }
 
 func NewOrdererClient(config *ClientConfig) (OrdererClient, error) {
-	grpcClient, err := createGrpcClient(&config.OrdererCfg, config.TlsEnabled)
+	grpcClient, err := createGrpcClient(&config.OrdererCfg)
 	if err != nil {
 		err = errors.WithMessage(err, fmt.Sprintf("failed to create a GRPCClient to orderer %s", config.OrdererCfg.Address))
 		logger.Errorf("%s", err)
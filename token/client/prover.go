@@ -70,6 +70,227 @@ func (prover *ProverPeer) RequestTransfer(
 	return scr.Response, nil
 }
 
+func (prover *ProverPeer) RequestRedeem(
+	tokenIDs [][]byte,
+	quantity uint64,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	rr := &token.RedeemRequest{
+		TokenIds:         tokenIDs,
+		QuantityToRedeem: quantity,
+	}
+	payload := &token.Command_RedeemRequest{RedeemRequest: rr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) ListTokens(signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_ListRequest{ListRequest: &token.ListRequest{}}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) GetTokenHistory(pageSize uint32, bookmark string, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	hr := &token.TokenHistoryRequest{
+		PageSize: pageSize,
+		Bookmark: bookmark,
+	}
+	payload := &token.Command_TokenHistoryRequest{TokenHistoryRequest: hr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) GetBalance(signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) GetSpendStatus(inputID *token.InputId, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_SpendStatusRequest{SpendStatusRequest: &token.SpendStatusRequest{InputId: inputID}}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestRegisterTokenType(definition *token.TokenTypeDefinition, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_RegisterTokenTypeRequest{RegisterTokenTypeRequest: &token.RegisterTokenTypeRequest{Definition: definition}}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) ListTokenTypes(signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_ListTokenTypesRequest{ListTokenTypesRequest: &token.ListTokenTypesRequest{}}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestApprove(
+	tokenIDs [][]byte,
+	shares []*token.AllowanceRecipientShare,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	// the owner of the delegated outputs is taken from the credential of the request
+	credential, err := signingIdentity.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	ar := &token.ApproveRequest{
+		Credential:      credential,
+		TokenIds:        tokenIDs,
+		AllowanceShares: shares,
+	}
+	payload := &token.Command_ApproveRequest{ApproveRequest: ar}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestTransferFrom(
+	tokenIDs [][]byte,
+	shares []*token.RecipientTransferShare,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	tr := &token.TransferRequest{
+		Shares:   shares,
+		TokenIds: tokenIDs,
+	}
+	payload := &token.Command_TransferFromRequest{TransferFromRequest: tr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestSwap(
+	tokenIDs [][]byte,
+	quantity uint64,
+	counterparty []byte,
+	counterpartyTokenIDs [][]byte,
+	counterpartyQuantity uint64,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	sr := &token.SwapRequest{
+		TokenIds:             tokenIDs,
+		Quantity:             quantity,
+		Counterparty:         counterparty,
+		CounterpartyTokenIds: counterpartyTokenIDs,
+		CounterpartyQuantity: counterpartyQuantity,
+	}
+	payload := &token.Command_SwapRequest{SwapRequest: sr}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
+func (prover *ProverPeer) RequestExpectation(
+	tokenIDs [][]byte,
+	expectation *token.TokenExpectation,
+	signingIdentity tk.SigningIdentity) ([]byte, error) {
+
+	er := &token.ExpectationRequest{
+		Expectation: expectation,
+		TokenIds:    tokenIDs,
+	}
+	payload := &token.Command_ExpectationRequest{ExpectationRequest: er}
+
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
 func (prover *ProverPeer) CreateSignedCommand(payload interface{}, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
 
 	command, err := commandFromPayload(payload)
@@ -123,6 +344,28 @@ func commandFromPayload(payload interface{}) (*token.Command, error) {
 		return &token.Command{Payload: t}, nil
 	case *token.Command_TransferRequest:
 		return &token.Command{Payload: t}, nil
+	case *token.Command_RedeemRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ListRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ApproveRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_TransferFromRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ExpectationRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_TokenHistoryRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_BalanceRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_SpendStatusRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_RegisterTokenTypeRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ListTokenTypesRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_SwapRequest:
+		return &token.Command{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
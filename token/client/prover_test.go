@@ -248,6 +248,359 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("RequestTransferFrom", func() {
+		var (
+			tokenIDs          [][]byte
+			transferShares    []*token.RecipientTransferShare
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			// input data for TransferFrom
+			tokenIDs = [][]byte{[]byte("delegated-id1")}
+			transferShares = []*token.RecipientTransferShare{
+				{Recipient: []byte("Charlie"), Quantity: 10},
+			}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TransferFromRequest{
+					TransferFromRequest: &token.TransferRequest{
+						TokenIds: tokenIDs,
+						Shares:   transferShares,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestTransferFrom(tokenIDs, transferShares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestTransferFrom(tokenIDs, transferShares, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestExpectation", func() {
+		var (
+			tokenIDs          [][]byte
+			expectation       *token.TokenExpectation
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			// input data for Expectation
+			tokenIDs = [][]byte{[]byte("id1")}
+			expectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("Bob"), Type: "XYZ", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ExpectationRequest{
+					ExpectationRequest: &token.ExpectationRequest{
+						TokenIds:    tokenIDs,
+						Expectation: expectation,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := prover.RequestExpectation(tokenIDs, expectation, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestExpectation(tokenIDs, expectation, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestRedeem", func() {
+		It("returns serialized token transaction", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RedeemRequest{
+					RedeemRequest: &token.RedeemRequest{
+						TokenIds:         tokenIDs,
+						QuantityToRedeem: 50,
+					},
+				},
+			}
+
+			response, err := prover.RequestRedeem(tokenIDs, 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("RequestSwap", func() {
+		It("returns the serialized command response", func() {
+			tokenIDs := [][]byte{[]byte("id1")}
+			counterpartyTokenIDs := [][]byte{[]byte("id2")}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_SwapRequest{
+					SwapRequest: &token.SwapRequest{
+						TokenIds:             tokenIDs,
+						Quantity:             10,
+						Counterparty:         []byte("Bob"),
+						CounterpartyTokenIds: counterpartyTokenIDs,
+						CounterpartyQuantity: 20,
+					},
+				},
+			}
+
+			response, err := prover.RequestSwap(tokenIDs, 10, []byte("Bob"), counterpartyTokenIDs, 20, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("ListTokens", func() {
+		It("returns the serialized command response", func() {
+			command := &token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_ListRequest{ListRequest: &token.ListRequest{}},
+			}
+
+			response, err := prover.ListTokens(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("GetTokenHistory", func() {
+		It("returns the serialized command response", func() {
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TokenHistoryRequest{
+					TokenHistoryRequest: &token.TokenHistoryRequest{PageSize: 10, Bookmark: "tx1"},
+				},
+			}
+
+			response, err := prover.GetTokenHistory(10, "tx1", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("GetBalance", func() {
+		It("returns the serialized command response", func() {
+			command := &token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}},
+			}
+
+			response, err := prover.GetBalance(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("GetSpendStatus", func() {
+		It("returns the serialized command response", func() {
+			inputID := &token.InputId{TxId: "tx1", Index: 1}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_SpendStatusRequest{
+					SpendStatusRequest: &token.SpendStatusRequest{InputId: inputID},
+				},
+			}
+
+			response, err := prover.GetSpendStatus(inputID, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+
+		Context("when the prover client fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.GetSpendStatus(&token.InputId{}, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("RequestRegisterTokenType", func() {
+		It("returns the serialized command response", func() {
+			definition := &token.TokenTypeDefinition{Type: "TOK1", DisplayName: "Token One", MaxSupply: 100}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RegisterTokenTypeRequest{
+					RegisterTokenTypeRequest: &token.RegisterTokenTypeRequest{Definition: definition},
+				},
+			}
+
+			response, err := prover.RequestRegisterTokenType(definition, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+	})
+
+	Describe("ListTokenTypes", func() {
+		It("returns the serialized command response", func() {
+			command := &token.Command{
+				Header:  commandHeader,
+				Payload: &token.Command_ListTokenTypesRequest{ListTokenTypesRequest: &token.ListTokenTypesRequest{}},
+			}
+
+			response, err := prover.ListTokenTypes(fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+
+		Context("when the prover client fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.ListTokenTypes(fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
+	Describe("RequestApprove", func() {
+		var (
+			tokenIDs [][]byte
+			shares   []*token.AllowanceRecipientShare
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+			shares = []*token.AllowanceRecipientShare{{Recipient: []byte("Bob"), Quantity: 10}}
+			fakeSigningIdentity.SerializeReturns([]byte("Alice"), nil)
+		})
+
+		It("uses the serialized signing identity as credential", func() {
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ApproveRequest{
+					ApproveRequest: &token.ApproveRequest{
+						Credential:      []byte("Alice"),
+						TokenIds:        tokenIDs,
+						AllowanceShares: shares,
+					},
+				},
+			}
+
+			response, err := prover.RequestApprove(tokenIDs, shares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(&token.SignedCommand{Command: ProtoMarshal(command), Signature: []byte("pineapple")}))
+		})
+
+		Context("when SigningIdentity serialize fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SerializeReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := prover.RequestApprove(tokenIDs, shares, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
 
 func clock() time.Time {
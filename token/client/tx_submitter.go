@@ -23,6 +23,7 @@ import (
 	mspmgmt "github.com/tradeline-tech/fabric/msp/mgmt"
 	peercommon "github.com/tradeline-tech/fabric/peer/common"
 	"github.com/tradeline-tech/fabric/protos/common"
+	pb "github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/utils"
 )
 
@@ -172,6 +173,25 @@ func (s *TxSubmitter) sendTransactionInternal(txEnvelope *common.Envelope, ctx c
 	return committed, txid, err
 }
 
+// SubscribeTokens sends to eventCh the token transactions committed from now on
+// that create outputs owned by owner, or all of them if owner is nil.
+// It blocks until ctx is done or the deliver stream fails.
+func (s *TxSubmitter) SubscribeTokens(ctx context.Context, owner []byte, eventCh chan<- *pb.TokenTransactionEvent) error {
+	deliverTokens, err := s.DeliverClient.NewDeliverTokens(ctx)
+	if err != nil {
+		return err
+	}
+	blockEnvelope, err := CreateDeliverEnvelope(s.Config.ChannelId, s.Creator, s.Signer, s.DeliverClient.Certificate())
+	if err != nil {
+		return err
+	}
+	err = DeliverSend(deliverTokens, s.Config.CommitPeerCfg.Address, blockEnvelope)
+	if err != nil {
+		return err
+	}
+	return DeliverReceiveTokens(deliverTokens, s.Config.CommitPeerCfg.Address, owner, eventCh)
+}
+
 func (s *TxSubmitter) CreateTxEnvelope(txBytes []byte) (string, *common.Envelope, error) {
 	// channelId string, creator []byte, signer SignerIdentity, cert *tls.Certificate
 	// , s.Config.ChannelId, s.Creator, s.Signer, s.OrdererClient.Certificate()
@@ -287,10 +307,10 @@ func getTransactionId(txEnvelope *common.Envelope) (string, error) {
 }
 
 // createGrpcClient returns a comm.GRPCClient based on toke client config
-func createGrpcClient(cfg *ConnectionConfig, tlsEnabled bool) (*comm.GRPCClient, error) {
+func createGrpcClient(cfg *ConnectionConfig) (*comm.GRPCClient, error) {
 	clientConfig := comm.ClientConfig{Timeout: time.Second}
 
-	if tlsEnabled {
+	if cfg.TlsEnabled {
 		if cfg.TlsRootCertFile == "" {
 			return nil, errors.New("missing TlsRootCertFile in client config")
 		}
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"context"
 	"io"
 
 	"github.com/golang/protobuf/proto"
@@ -57,7 +58,6 @@ var _ = Describe("TxSubmitter", func() {
 
 		config = &client.ClientConfig{
 			ChannelId:     channelId,
-			TlsEnabled:    false,
 			OrdererCfg:    ordererCfg,
 			CommitPeerCfg: commitPeerCfg,
 		}
@@ -252,6 +252,51 @@ var _ = Describe("TxSubmitter", func() {
 		})
 	})
 
+	Describe("SubscribeTokens", func() {
+		BeforeEach(func() {
+			fakeDeliverClient.NewDeliverTokensReturns(fakeDeliverFiltered, nil)
+			tokenBlock := &pb.TokenBlock{
+				ChannelId: channelId,
+				TokenTransactions: []*pb.TokenTransactionEvent{{
+					Txid:             expectedTxid,
+					TxValidationCode: pb.TxValidationCode_VALID,
+					Outputs:          []*pb.TokenOutputEvent{{Owner: []byte("token-owner"), Type: "PDQ", Quantity: 777}},
+				}},
+			}
+			fakeDeliverFiltered.RecvReturnsOnCall(0, &pb.DeliverResponse{Type: &pb.DeliverResponse_TokenBlock{TokenBlock: tokenBlock}}, nil)
+			fakeDeliverFiltered.RecvReturnsOnCall(1, nil, io.EOF)
+		})
+
+		It("sends the token transactions of the owner to the event channel", func() {
+			eventCh := make(chan *pb.TokenTransactionEvent, 1)
+			err := txSubmitter.SubscribeTokens(context.Background(), []byte("token-owner"), eventCh)
+			Expect(err).To(MatchError("error receiving deliver response from peer fake_address: EOF"))
+			Expect((<-eventCh).Txid).To(Equal(expectedTxid))
+
+			Expect(fakeDeliverFiltered.SendCallCount()).To(Equal(1))
+			envelope := fakeDeliverFiltered.SendArgsForCall(0)
+			payload := common.Payload{}
+			err = proto.Unmarshal(envelope.Payload, &payload)
+			Expect(err).NotTo(HaveOccurred())
+			channelHeader := common.ChannelHeader{}
+			err = proto.Unmarshal(payload.Header.ChannelHeader, &channelHeader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(channelHeader.Type).To(Equal(int32(common.HeaderType_DELIVER_SEEK_INFO)))
+			Expect(channelHeader.ChannelId).To(Equal(channelId))
+		})
+
+		Context("when DeliverClient fails to create deliver tokens", func() {
+			BeforeEach(func() {
+				fakeDeliverClient.NewDeliverTokensReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				err := txSubmitter.SubscribeTokens(context.Background(), nil, make(chan *pb.TokenTransactionEvent, 1))
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
+
 	Describe("CreateTxEnvelope", func() {
 		It("returns expected envelope", func() {
 			txid, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
@@ -402,6 +447,37 @@ var _ = Describe("Create a header", func() {
 	})
 })
 
+var _ = Describe("ValidateClientConfig", func() {
+	var config *client.ClientConfig
+
+	BeforeEach(func() {
+		config = &client.ClientConfig{
+			ChannelId:     "test-channel",
+			OrdererCfg:    client.ConnectionConfig{Address: "orderer-address"},
+			CommitPeerCfg: client.ConnectionConfig{Address: "peer-address"},
+		}
+	})
+
+	It("secures the orderer and the commit peer connections independently", func() {
+		config.OrdererCfg.TlsEnabled = true
+		Expect(client.ValidateClientConfig(config)).To(MatchError("missing orderer TlsRootCertFile"))
+
+		config.OrdererCfg.TlsRootCertFile = "orderer-root.crt"
+		Expect(client.ValidateClientConfig(config)).To(Succeed())
+
+		config.CommitPeerCfg.TlsEnabled = true
+		Expect(client.ValidateClientConfig(config)).To(MatchError("missing commit peer TlsRootCertFile"))
+
+		config.CommitPeerCfg.TlsRootCertFile = "peer-root.crt"
+		Expect(client.ValidateClientConfig(config)).To(Succeed())
+	})
+
+	It("requires the commit peer address", func() {
+		config.CommitPeerCfg.Address = ""
+		Expect(client.ValidateClientConfig(config)).To(MatchError("missing commit peer address"))
+	})
+})
+
 func createFilteredBlock(channelId string, txIDs ...string) *pb.FilteredBlock {
 	var filteredTransactions []*pb.FilteredTransaction
 	for _, txID := range txIDs {
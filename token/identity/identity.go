@@ -16,6 +16,12 @@ type IssuingValidator interface {
 	Validate(creator PublicInfo, tokenType string) error
 }
 
+// RegistrationValidator is used to establish if the creator can register token types.
+type RegistrationValidator interface {
+	// Validate returns no error if the passed creator can register the passed token type, an error otherwise.
+	Validate(creator PublicInfo, tokenType string) error
+}
+
 // PublicInfo is used to identify token owners.
 type PublicInfo interface {
 	Public() []byte
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/protos/common"
+	pmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+// UnmarshalOwner returns the TokenOwner held by the owner field of an output.
+// The owner field holds a serialized TokenOwner only for owners that are not
+// plain MSP identities; any other content is a serialized MSP identity.
+func UnmarshalOwner(owner []byte) *token.TokenOwner {
+	tokenOwner := &token.TokenOwner{}
+	err := proto.Unmarshal(owner, tokenOwner)
+	if err != nil || tokenOwner.Type == token.TokenOwner_MSP_IDENTIFIER {
+		return &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: owner}
+	}
+	return tokenOwner
+}
+
+// MarshalOwner returns the content of the owner field of an output owned by tokenOwner.
+func MarshalOwner(tokenOwner *token.TokenOwner) ([]byte, error) {
+	switch tokenOwner.GetType() {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		return tokenOwner.Raw, nil
+	case token.TokenOwner_IDEMIX, token.TokenOwner_MULTISIG:
+		if len(tokenOwner.Raw) == 0 {
+			return nil, errors.Errorf("no raw owner in token owner of type %s", tokenOwner.Type)
+		}
+		return proto.Marshal(tokenOwner)
+	default:
+		return nil, errors.Errorf("unknown token owner type %d", tokenOwner.Type)
+	}
+}
+
+// NewIdemixOwner returns the owner of the outputs owned by the passed serialized idemix identity.
+func NewIdemixOwner(serializedIdentity []byte) ([]byte, error) {
+	return MarshalOwner(&token.TokenOwner{Type: token.TokenOwner_IDEMIX, Raw: serializedIdentity})
+}
+
+// NewMultisigOwner returns the owner of the outputs that can be spent only with
+// signatures satisfying the passed policy.
+func NewMultisigOwner(policy *common.SignaturePolicyEnvelope) ([]byte, error) {
+	raw, err := proto.Marshal(policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling multisig policy")
+	}
+	return MarshalOwner(&token.TokenOwner{Type: token.TokenOwner_MULTISIG, Raw: raw})
+}
+
+// PolicyDeserializer adapts a Deserializer to the msp.IdentityDeserializer
+// used to evaluate signature policies.
+type PolicyDeserializer struct {
+	Deserializer
+}
+
+// IsWellFormed delegates to the underlying Deserializer when it supports the check.
+func (d *PolicyDeserializer) IsWellFormed(identity *pmsp.SerializedIdentity) error {
+	if wf, ok := d.Deserializer.(interface {
+		IsWellFormed(*pmsp.SerializedIdentity) error
+	}); ok {
+		return wf.IsWellFormed(identity)
+	}
+	return nil
+}
+
+var _ msp.IdentityDeserializer = &PolicyDeserializer{}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package identity_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tradeline-tech/fabric/common/cauthdsl"
+	pmsp "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+)
+
+func TestUnmarshalOwner(t *testing.T) {
+	serializedIdentity, err := proto.Marshal(&pmsp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("certificate")})
+	assert.NoError(t, err)
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	serializedPolicy, err := proto.Marshal(policy)
+	assert.NoError(t, err)
+	multisigOwner, err := identity.NewMultisigOwner(policy)
+	assert.NoError(t, err)
+	idemixOwner, err := identity.NewIdemixOwner(serializedIdentity)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		owner    []byte
+		expected *token.TokenOwner
+	}{
+		{
+			name:     "serialized identity",
+			owner:    serializedIdentity,
+			expected: &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: serializedIdentity},
+		},
+		{
+			name:     "opaque bytes",
+			owner:    []byte("Alice"),
+			expected: &token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: []byte("Alice")},
+		},
+		{
+			name:     "idemix owner",
+			owner:    idemixOwner,
+			expected: &token.TokenOwner{Type: token.TokenOwner_IDEMIX, Raw: serializedIdentity},
+		},
+		{
+			name:     "multisig owner",
+			owner:    multisigOwner,
+			expected: &token.TokenOwner{Type: token.TokenOwner_MULTISIG, Raw: serializedPolicy},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, proto.Equal(tt.expected, identity.UnmarshalOwner(tt.owner)))
+		})
+	}
+}
+
+func TestMarshalOwner(t *testing.T) {
+	owner, err := identity.MarshalOwner(&token.TokenOwner{Type: token.TokenOwner_MSP_IDENTIFIER, Raw: []byte("Alice")})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("Alice"), owner)
+
+	_, err = identity.MarshalOwner(&token.TokenOwner{Type: token.TokenOwner_MULTISIG})
+	assert.EqualError(t, err, "no raw owner in token owner of type MULTISIG")
+
+	_, err = identity.MarshalOwner(&token.TokenOwner{Type: 42})
+	assert.EqualError(t, err, "unknown token owner type 42")
+}
@@ -49,12 +49,47 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			c.Header.ChannelId,
 			signedData,
 		)
+	case *token.Command_RegisterTokenTypeRequest:
+		// Registering a token type has the same policy as import
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.IssueTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
 	case *token.Command_ListRequest:
 		return ac.ACLProvider.CheckACL(
 			ac.ACLResources.ListTokens,
 			c.Header.ChannelId,
 			signedData,
 		)
+	case *token.Command_ListTokenTypesRequest:
+		// Token type queries have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+	case *token.Command_TokenHistoryRequest:
+		// History queries have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+	case *token.Command_BalanceRequest:
+		// Balance queries have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+	case *token.Command_SpendStatusRequest:
+		// Spend status queries have the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
 	case *token.Command_TransferRequest:
 		return ac.ACLProvider.CheckACL(
 			ac.ACLResources.TransferTokens,
@@ -85,6 +120,14 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			signedData,
 		)
 
+	case *token.Command_SwapRequest:
+		// Swap has the same policy as transfer
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.TransferTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
 	case *token.Command_ExpectationRequest:
 		if c.GetExpectationRequest().GetExpectation() == nil {
 			return errors.New("ExpectationRequest has nil Expectation")
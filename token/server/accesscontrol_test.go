@@ -118,6 +118,84 @@ var _ = Describe("AccessControl", func() {
 		}))
 	})
 
+	It("validates the transfer policy for swap command", func() {
+		swapCommand := &token.Command{
+			Header: header,
+			Payload: &token.Command_SwapRequest{
+				SwapRequest: &token.SwapRequest{},
+			},
+		}
+		signedSwapCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(swapCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedSwapCommand, swapCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal(aclResources.TransferTokens))
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(signedData).To(ConsistOf(&common.SignedData{
+			Data:      signedSwapCommand.Command,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}))
+	})
+
+	It("validates the issue policy for register token type command", func() {
+		registerCommand := &token.Command{
+			Header: header,
+			Payload: &token.Command_RegisterTokenTypeRequest{
+				RegisterTokenTypeRequest: &token.RegisterTokenTypeRequest{},
+			},
+		}
+		signedRegisterCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(registerCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedRegisterCommand, registerCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal(aclResources.IssueTokens))
+		Expect(channelID).To(Equal("channel-id"))
+		Expect(signedData).To(ConsistOf(&common.SignedData{
+			Data:      signedRegisterCommand.Command,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}))
+	})
+
+	It("validates the list policy for query commands", func() {
+		aclResources.ListTokens = "mango"
+		queryCommands := []*token.Command{
+			{Header: header, Payload: &token.Command_TokenHistoryRequest{TokenHistoryRequest: &token.TokenHistoryRequest{}}},
+			{Header: header, Payload: &token.Command_BalanceRequest{BalanceRequest: &token.BalanceRequest{}}},
+			{Header: header, Payload: &token.Command_SpendStatusRequest{SpendStatusRequest: &token.SpendStatusRequest{}}},
+			{Header: header, Payload: &token.Command_ListTokenTypesRequest{ListTokenTypesRequest: &token.ListTokenTypesRequest{}}},
+		}
+		for i, queryCommand := range queryCommands {
+			signedQueryCommand := &token.SignedCommand{
+				Command:   ProtoMarshal(queryCommand),
+				Signature: []byte("signature"),
+			}
+			err := pbac.Check(signedQueryCommand, queryCommand)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(i + 1))
+			resourceName, channelID, signedData := fakeACLProvider.CheckACLArgsForCall(i)
+			Expect(resourceName).To(Equal("mango"))
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(signedData).To(ConsistOf(&common.SignedData{
+				Data:      signedQueryCommand.Command,
+				Identity:  []byte("creator"),
+				Signature: []byte("signature"),
+			}))
+		}
+	})
+
 	Context("when the policy checker returns an error", func() {
 		BeforeEach(func() {
 			fakeACLProvider.CheckACLReturns(errors.New("wild-banana"))
@@ -9,7 +9,9 @@ package server
 import (
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/token/identity"
 	"github.com/tradeline-tech/fabric/token/ledger"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 )
 
@@ -17,12 +19,26 @@ import (
 // TODO: it will be updated after lscc-baased tms configuration is available
 type Manager struct {
 	LedgerManager ledger.LedgerManager
+	// CapabilityChecker selects the confidential TMS for the channels that enable it.
+	// If nil, the plain TMS is used for all channels.
+	CapabilityChecker CapabilityChecker
+	// IdentityDeserializerManager provides the plain Transactors with the deserializer of their channel,
+	// used to evaluate multisig owners. If nil, the tokens of multisig owners cannot be spent.
+	IdentityDeserializerManager identity.DeserializerManager
 }
 
-// For now it returns a plain issuer.
+// For now it returns a plain issuer, or a confidential issuer if the channel enables
+// the confidential FabToken capability.
 // After lscc-based tms configuration is available, it will be updated
 // to return an issuer configured for the specific channel
 func (manager *Manager) GetIssuer(channel string, privateCredential, publicCredential []byte) (Issuer, error) {
+	confidentialTMS, err := manager.isConfidential(channel)
+	if err != nil {
+		return nil, err
+	}
+	if confidentialTMS {
+		return &confidential.Issuer{}, nil
+	}
 	return &plain.Issuer{}, nil
 }
 
@@ -33,5 +49,33 @@ func (manager *Manager) GetTransactor(channel string, privateCredential, publicC
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
 	}
-	return &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential}, nil
+	confidentialTMS, err := manager.isConfidential(channel)
+	if err != nil {
+		ledger.Done()
+		return nil, err
+	}
+	if confidentialTMS {
+		return &confidential.Transactor{Ledger: ledger, PublicCredential: publicCredential}, nil
+	}
+	transactor := &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential}
+	if manager.IdentityDeserializerManager != nil {
+		transactor.Deserializer, err = manager.IdentityDeserializerManager.Deserializer(channel)
+		if err != nil {
+			ledger.Done()
+			return nil, errors.WithMessage(err, "failed getting identity deserializer for channel: "+channel)
+		}
+	}
+	return transactor, nil
+}
+
+// isConfidential returns true if the passed channel uses the confidential TMS
+func (manager *Manager) isConfidential(channel string) (bool, error) {
+	if manager.CapabilityChecker == nil {
+		return false, nil
+	}
+	enabled, err := manager.CapabilityChecker.ConfidentialFabToken(channel)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed checking the confidential FabToken capability for channel: "+channel)
+	}
+	return enabled, nil
 }
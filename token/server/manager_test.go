@@ -12,8 +12,11 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
 	"github.com/tradeline-tech/fabric/token/ledger/mock"
 	"github.com/tradeline-tech/fabric/token/server"
+	mockserver "github.com/tradeline-tech/fabric/token/server/mock"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 )
 
@@ -25,6 +28,16 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(issuer).To(Equal(&plain.Issuer{}))
 		})
+
+		It("returns a confidential issuer when the channel enables it", func() {
+			fakeCapabilityChecker := &mockserver.CapabilityChecker{}
+			fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+			manager := &server.Manager{CapabilityChecker: fakeCapabilityChecker}
+			issuer, err := manager.GetIssuer("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issuer).To(Equal(&confidential.Issuer{}))
+			Expect(fakeCapabilityChecker.ConfidentialFabTokenArgsForCall(0)).To(Equal("test-channel"))
+		})
 	})
 
 	Describe("GetTransactor", func() {
@@ -45,6 +58,46 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(transactor).To(Equal(&plain.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential")}))
 		})
+		It("returns a plain transactor with the deserializer of the channel", func() {
+			fakeDeserializer := &mockid.Deserializer{}
+			fakeDeserializerManager := &mockid.DeserializerManager{}
+			fakeDeserializerManager.DeserializerReturns(fakeDeserializer, nil)
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, IdentityDeserializerManager: fakeDeserializerManager}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactor).To(Equal(&plain.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential"), Deserializer: fakeDeserializer}))
+			Expect(fakeDeserializerManager.DeserializerArgsForCall(0)).To(Equal("test-channel"))
+		})
+		It("returns an error when the deserializer of the channel is not available", func() {
+			fakeDeserializerManager := &mockid.DeserializerManager{}
+			fakeDeserializerManager.DeserializerReturns(nil, errors.New("channel not found"))
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, IdentityDeserializerManager: fakeDeserializerManager}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("failed getting identity deserializer for channel: test-channel: channel not found"))
+			Expect(transactor).To(BeNil())
+			Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+		})
+		It("returns a confidential transactor when the channel enables it", func() {
+			fakeCapabilityChecker := &mockserver.CapabilityChecker{}
+			fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, CapabilityChecker: fakeCapabilityChecker}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactor).To(Equal(&confidential.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential")}))
+		})
+		It("returns an error when the capability check fails", func() {
+			fakeCapabilityChecker := &mockserver.CapabilityChecker{}
+			fakeCapabilityChecker.ConfidentialFabTokenReturns(false, errors.New("no config"))
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, CapabilityChecker: fakeCapabilityChecker}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).To(MatchError("failed checking the confidential FabToken capability for channel: test-channel: no config"))
+			Expect(transactor).To(BeNil())
+			Expect(fakeLedgerReader.DoneCallCount()).To(Equal(1))
+		})
 		It("returns an error", func() {
 			manager := &server.Manager{LedgerManager: fakeLedgerManager}
 			fakeLedgerManager.GetLedgerReaderReturns(nil, errors.New("banana ledger"))
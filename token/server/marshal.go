@@ -95,6 +95,14 @@ func commandResponseFromPayload(payload interface{}) (*token.CommandResponse, er
 		return &token.CommandResponse{Payload: t}, nil
 	case *token.CommandResponse_UnspentTokens:
 		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenHistory:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenBalances:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_SpendStatus:
+		return &token.CommandResponse{Payload: t}, nil
+	case *token.CommandResponse_TokenTypes:
+		return &token.CommandResponse{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
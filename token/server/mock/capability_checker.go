@@ -2,16 +2,29 @@
 package mock
 
 import (
-	"sync"
+	sync "sync"
 
-	"github.com/tradeline-tech/fabric/token/server"
+	server "github.com/tradeline-tech/fabric/token/server"
 )
 
 type CapabilityChecker struct {
-	FabTokenStub        func(channelId string) (bool, error)
+	ConfidentialFabTokenStub        func(string) (bool, error)
+	confidentialFabTokenMutex       sync.RWMutex
+	confidentialFabTokenArgsForCall []struct {
+		arg1 string
+	}
+	confidentialFabTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	confidentialFabTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	FabTokenStub        func(string) (bool, error)
 	fabTokenMutex       sync.RWMutex
 	fabTokenArgsForCall []struct {
-		channelId string
+		arg1 string
 	}
 	fabTokenReturns struct {
 		result1 bool
@@ -25,21 +38,85 @@ type CapabilityChecker struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CapabilityChecker) FabToken(channelId string) (bool, error) {
+func (fake *CapabilityChecker) ConfidentialFabToken(arg1 string) (bool, error) {
+	fake.confidentialFabTokenMutex.Lock()
+	ret, specificReturn := fake.confidentialFabTokenReturnsOnCall[len(fake.confidentialFabTokenArgsForCall)]
+	fake.confidentialFabTokenArgsForCall = append(fake.confidentialFabTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ConfidentialFabToken", []interface{}{arg1})
+	fake.confidentialFabTokenMutex.Unlock()
+	if fake.ConfidentialFabTokenStub != nil {
+		return fake.ConfidentialFabTokenStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.confidentialFabTokenReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenCallCount() int {
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
+	return len(fake.confidentialFabTokenArgsForCall)
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenCalls(stub func(string) (bool, error)) {
+	fake.confidentialFabTokenMutex.Lock()
+	defer fake.confidentialFabTokenMutex.Unlock()
+	fake.ConfidentialFabTokenStub = stub
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenArgsForCall(i int) string {
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
+	argsForCall := fake.confidentialFabTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenReturns(result1 bool, result2 error) {
+	fake.confidentialFabTokenMutex.Lock()
+	defer fake.confidentialFabTokenMutex.Unlock()
+	fake.ConfidentialFabTokenStub = nil
+	fake.confidentialFabTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) ConfidentialFabTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.confidentialFabTokenMutex.Lock()
+	defer fake.confidentialFabTokenMutex.Unlock()
+	fake.ConfidentialFabTokenStub = nil
+	if fake.confidentialFabTokenReturnsOnCall == nil {
+		fake.confidentialFabTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.confidentialFabTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CapabilityChecker) FabToken(arg1 string) (bool, error) {
 	fake.fabTokenMutex.Lock()
 	ret, specificReturn := fake.fabTokenReturnsOnCall[len(fake.fabTokenArgsForCall)]
 	fake.fabTokenArgsForCall = append(fake.fabTokenArgsForCall, struct {
-		channelId string
-	}{channelId})
-	fake.recordInvocation("FabToken", []interface{}{channelId})
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("FabToken", []interface{}{arg1})
 	fake.fabTokenMutex.Unlock()
 	if fake.FabTokenStub != nil {
-		return fake.FabTokenStub(channelId)
+		return fake.FabTokenStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.fabTokenReturns.result1, fake.fabTokenReturns.result2
+	fakeReturns := fake.fabTokenReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *CapabilityChecker) FabTokenCallCount() int {
@@ -48,13 +125,22 @@ func (fake *CapabilityChecker) FabTokenCallCount() int {
 	return len(fake.fabTokenArgsForCall)
 }
 
+func (fake *CapabilityChecker) FabTokenCalls(stub func(string) (bool, error)) {
+	fake.fabTokenMutex.Lock()
+	defer fake.fabTokenMutex.Unlock()
+	fake.FabTokenStub = stub
+}
+
 func (fake *CapabilityChecker) FabTokenArgsForCall(i int) string {
 	fake.fabTokenMutex.RLock()
 	defer fake.fabTokenMutex.RUnlock()
-	return fake.fabTokenArgsForCall[i].channelId
+	argsForCall := fake.fabTokenArgsForCall[i]
+	return argsForCall.arg1
 }
 
 func (fake *CapabilityChecker) FabTokenReturns(result1 bool, result2 error) {
+	fake.fabTokenMutex.Lock()
+	defer fake.fabTokenMutex.Unlock()
 	fake.FabTokenStub = nil
 	fake.fabTokenReturns = struct {
 		result1 bool
@@ -63,6 +149,8 @@ func (fake *CapabilityChecker) FabTokenReturns(result1 bool, result2 error) {
 }
 
 func (fake *CapabilityChecker) FabTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.fabTokenMutex.Lock()
+	defer fake.fabTokenMutex.Unlock()
 	fake.FabTokenStub = nil
 	if fake.fabTokenReturnsOnCall == nil {
 		fake.fabTokenReturnsOnCall = make(map[int]struct {
@@ -79,6 +167,8 @@ func (fake *CapabilityChecker) FabTokenReturnsOnCall(i int, result1 bool, result
 func (fake *CapabilityChecker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.confidentialFabTokenMutex.RLock()
+	defer fake.confidentialFabTokenMutex.RUnlock()
 	fake.fabTokenMutex.RLock()
 	defer fake.fabTokenMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -35,6 +35,19 @@ type Issuer struct {
 		result1 *token.TokenTransaction
 		result2 error
 	}
+	RequestRegisterTokenTypeStub        func(*token.TokenTypeDefinition) (*token.TokenTransaction, error)
+	requestRegisterTokenTypeMutex       sync.RWMutex
+	requestRegisterTokenTypeArgsForCall []struct {
+		arg1 *token.TokenTypeDefinition
+	}
+	requestRegisterTokenTypeReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestRegisterTokenTypeReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -170,6 +183,69 @@ func (fake *Issuer) RequestImportReturnsOnCall(i int, result1 *token.TokenTransa
 	}{result1, result2}
 }
 
+func (fake *Issuer) RequestRegisterTokenType(arg1 *token.TokenTypeDefinition) (*token.TokenTransaction, error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	ret, specificReturn := fake.requestRegisterTokenTypeReturnsOnCall[len(fake.requestRegisterTokenTypeArgsForCall)]
+	fake.requestRegisterTokenTypeArgsForCall = append(fake.requestRegisterTokenTypeArgsForCall, struct {
+		arg1 *token.TokenTypeDefinition
+	}{arg1})
+	fake.recordInvocation("RequestRegisterTokenType", []interface{}{arg1})
+	fake.requestRegisterTokenTypeMutex.Unlock()
+	if fake.RequestRegisterTokenTypeStub != nil {
+		return fake.RequestRegisterTokenTypeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRegisterTokenTypeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Issuer) RequestRegisterTokenTypeCallCount() int {
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
+	return len(fake.requestRegisterTokenTypeArgsForCall)
+}
+
+func (fake *Issuer) RequestRegisterTokenTypeCalls(stub func(*token.TokenTypeDefinition) (*token.TokenTransaction, error)) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = stub
+}
+
+func (fake *Issuer) RequestRegisterTokenTypeArgsForCall(i int) *token.TokenTypeDefinition {
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
+	argsForCall := fake.requestRegisterTokenTypeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Issuer) RequestRegisterTokenTypeReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = nil
+	fake.requestRegisterTokenTypeReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Issuer) RequestRegisterTokenTypeReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestRegisterTokenTypeMutex.Lock()
+	defer fake.requestRegisterTokenTypeMutex.Unlock()
+	fake.RequestRegisterTokenTypeStub = nil
+	if fake.requestRegisterTokenTypeReturnsOnCall == nil {
+		fake.requestRegisterTokenTypeReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestRegisterTokenTypeReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Issuer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -177,6 +253,8 @@ func (fake *Issuer) Invocations() map[string][][]interface{} {
 	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestRegisterTokenTypeMutex.RLock()
+	defer fake.requestRegisterTokenTypeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -13,6 +13,56 @@ type Transactor struct {
 	doneMutex       sync.RWMutex
 	doneArgsForCall []struct {
 	}
+	GetBalanceStub        func() (*token.TokenBalances, error)
+	getBalanceMutex       sync.RWMutex
+	getBalanceArgsForCall []struct {
+	}
+	getBalanceReturns struct {
+		result1 *token.TokenBalances
+		result2 error
+	}
+	getBalanceReturnsOnCall map[int]struct {
+		result1 *token.TokenBalances
+		result2 error
+	}
+	GetSpendStatusStub        func(*token.InputId) (*token.SpendStatus, error)
+	getSpendStatusMutex       sync.RWMutex
+	getSpendStatusArgsForCall []struct {
+		arg1 *token.InputId
+	}
+	getSpendStatusReturns struct {
+		result1 *token.SpendStatus
+		result2 error
+	}
+	getSpendStatusReturnsOnCall map[int]struct {
+		result1 *token.SpendStatus
+		result2 error
+	}
+	GetTokenHistoryStub        func(*token.TokenHistoryRequest) (*token.TokenHistory, error)
+	getTokenHistoryMutex       sync.RWMutex
+	getTokenHistoryArgsForCall []struct {
+		arg1 *token.TokenHistoryRequest
+	}
+	getTokenHistoryReturns struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
+	getTokenHistoryReturnsOnCall map[int]struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
+	ListTokenTypesStub        func() (*token.TokenTypes, error)
+	listTokenTypesMutex       sync.RWMutex
+	listTokenTypesArgsForCall []struct {
+	}
+	listTokenTypesReturns struct {
+		result1 *token.TokenTypes
+		result2 error
+	}
+	listTokenTypesReturnsOnCall map[int]struct {
+		result1 *token.TokenTypes
+		result2 error
+	}
 	ListTokensStub        func() (*token.UnspentTokens, error)
 	listTokensMutex       sync.RWMutex
 	listTokensArgsForCall []struct {
@@ -64,6 +114,19 @@ type Transactor struct {
 		result1 *token.TokenTransaction
 		result2 error
 	}
+	RequestSwapStub        func(*token.SwapRequest) (*token.TokenTransaction, error)
+	requestSwapMutex       sync.RWMutex
+	requestSwapArgsForCall []struct {
+		arg1 *token.SwapRequest
+	}
+	requestSwapReturns struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
+	requestSwapReturnsOnCall map[int]struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}
 	RequestTransferStub        func(*token.TransferRequest) (*token.TokenTransaction, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
@@ -117,6 +180,242 @@ func (fake *Transactor) DoneCalls(stub func()) {
 	fake.DoneStub = stub
 }
 
+func (fake *Transactor) GetBalance() (*token.TokenBalances, error) {
+	fake.getBalanceMutex.Lock()
+	ret, specificReturn := fake.getBalanceReturnsOnCall[len(fake.getBalanceArgsForCall)]
+	fake.getBalanceArgsForCall = append(fake.getBalanceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetBalance", []interface{}{})
+	fake.getBalanceMutex.Unlock()
+	if fake.GetBalanceStub != nil {
+		return fake.GetBalanceStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBalanceReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) GetBalanceCallCount() int {
+	fake.getBalanceMutex.RLock()
+	defer fake.getBalanceMutex.RUnlock()
+	return len(fake.getBalanceArgsForCall)
+}
+
+func (fake *Transactor) GetBalanceCalls(stub func() (*token.TokenBalances, error)) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = stub
+}
+
+func (fake *Transactor) GetBalanceReturns(result1 *token.TokenBalances, result2 error) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = nil
+	fake.getBalanceReturns = struct {
+		result1 *token.TokenBalances
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetBalanceReturnsOnCall(i int, result1 *token.TokenBalances, result2 error) {
+	fake.getBalanceMutex.Lock()
+	defer fake.getBalanceMutex.Unlock()
+	fake.GetBalanceStub = nil
+	if fake.getBalanceReturnsOnCall == nil {
+		fake.getBalanceReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenBalances
+			result2 error
+		})
+	}
+	fake.getBalanceReturnsOnCall[i] = struct {
+		result1 *token.TokenBalances
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetSpendStatus(arg1 *token.InputId) (*token.SpendStatus, error) {
+	fake.getSpendStatusMutex.Lock()
+	ret, specificReturn := fake.getSpendStatusReturnsOnCall[len(fake.getSpendStatusArgsForCall)]
+	fake.getSpendStatusArgsForCall = append(fake.getSpendStatusArgsForCall, struct {
+		arg1 *token.InputId
+	}{arg1})
+	fake.recordInvocation("GetSpendStatus", []interface{}{arg1})
+	fake.getSpendStatusMutex.Unlock()
+	if fake.GetSpendStatusStub != nil {
+		return fake.GetSpendStatusStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getSpendStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) GetSpendStatusCallCount() int {
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	return len(fake.getSpendStatusArgsForCall)
+}
+
+func (fake *Transactor) GetSpendStatusCalls(stub func(*token.InputId) (*token.SpendStatus, error)) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = stub
+}
+
+func (fake *Transactor) GetSpendStatusArgsForCall(i int) *token.InputId {
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	argsForCall := fake.getSpendStatusArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) GetSpendStatusReturns(result1 *token.SpendStatus, result2 error) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = nil
+	fake.getSpendStatusReturns = struct {
+		result1 *token.SpendStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetSpendStatusReturnsOnCall(i int, result1 *token.SpendStatus, result2 error) {
+	fake.getSpendStatusMutex.Lock()
+	defer fake.getSpendStatusMutex.Unlock()
+	fake.GetSpendStatusStub = nil
+	if fake.getSpendStatusReturnsOnCall == nil {
+		fake.getSpendStatusReturnsOnCall = make(map[int]struct {
+			result1 *token.SpendStatus
+			result2 error
+		})
+	}
+	fake.getSpendStatusReturnsOnCall[i] = struct {
+		result1 *token.SpendStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetTokenHistory(arg1 *token.TokenHistoryRequest) (*token.TokenHistory, error) {
+	fake.getTokenHistoryMutex.Lock()
+	ret, specificReturn := fake.getTokenHistoryReturnsOnCall[len(fake.getTokenHistoryArgsForCall)]
+	fake.getTokenHistoryArgsForCall = append(fake.getTokenHistoryArgsForCall, struct {
+		arg1 *token.TokenHistoryRequest
+	}{arg1})
+	fake.recordInvocation("GetTokenHistory", []interface{}{arg1})
+	fake.getTokenHistoryMutex.Unlock()
+	if fake.GetTokenHistoryStub != nil {
+		return fake.GetTokenHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getTokenHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) GetTokenHistoryCallCount() int {
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	return len(fake.getTokenHistoryArgsForCall)
+}
+
+func (fake *Transactor) GetTokenHistoryCalls(stub func(*token.TokenHistoryRequest) (*token.TokenHistory, error)) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = stub
+}
+
+func (fake *Transactor) GetTokenHistoryArgsForCall(i int) *token.TokenHistoryRequest {
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	argsForCall := fake.getTokenHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) GetTokenHistoryReturns(result1 *token.TokenHistory, result2 error) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = nil
+	fake.getTokenHistoryReturns = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) GetTokenHistoryReturnsOnCall(i int, result1 *token.TokenHistory, result2 error) {
+	fake.getTokenHistoryMutex.Lock()
+	defer fake.getTokenHistoryMutex.Unlock()
+	fake.GetTokenHistoryStub = nil
+	if fake.getTokenHistoryReturnsOnCall == nil {
+		fake.getTokenHistoryReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenHistory
+			result2 error
+		})
+	}
+	fake.getTokenHistoryReturnsOnCall[i] = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) ListTokenTypes() (*token.TokenTypes, error) {
+	fake.listTokenTypesMutex.Lock()
+	ret, specificReturn := fake.listTokenTypesReturnsOnCall[len(fake.listTokenTypesArgsForCall)]
+	fake.listTokenTypesArgsForCall = append(fake.listTokenTypesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListTokenTypes", []interface{}{})
+	fake.listTokenTypesMutex.Unlock()
+	if fake.ListTokenTypesStub != nil {
+		return fake.ListTokenTypesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listTokenTypesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) ListTokenTypesCallCount() int {
+	fake.listTokenTypesMutex.RLock()
+	defer fake.listTokenTypesMutex.RUnlock()
+	return len(fake.listTokenTypesArgsForCall)
+}
+
+func (fake *Transactor) ListTokenTypesCalls(stub func() (*token.TokenTypes, error)) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = stub
+}
+
+func (fake *Transactor) ListTokenTypesReturns(result1 *token.TokenTypes, result2 error) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = nil
+	fake.listTokenTypesReturns = struct {
+		result1 *token.TokenTypes
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) ListTokenTypesReturnsOnCall(i int, result1 *token.TokenTypes, result2 error) {
+	fake.listTokenTypesMutex.Lock()
+	defer fake.listTokenTypesMutex.Unlock()
+	fake.ListTokenTypesStub = nil
+	if fake.listTokenTypesReturnsOnCall == nil {
+		fake.listTokenTypesReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTypes
+			result2 error
+		})
+	}
+	fake.listTokenTypesReturnsOnCall[i] = struct {
+		result1 *token.TokenTypes
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) ListTokens() (*token.UnspentTokens, error) {
 	fake.listTokensMutex.Lock()
 	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
@@ -361,6 +660,69 @@ func (fake *Transactor) RequestRedeemReturnsOnCall(i int, result1 *token.TokenTr
 	}{result1, result2}
 }
 
+func (fake *Transactor) RequestSwap(arg1 *token.SwapRequest) (*token.TokenTransaction, error) {
+	fake.requestSwapMutex.Lock()
+	ret, specificReturn := fake.requestSwapReturnsOnCall[len(fake.requestSwapArgsForCall)]
+	fake.requestSwapArgsForCall = append(fake.requestSwapArgsForCall, struct {
+		arg1 *token.SwapRequest
+	}{arg1})
+	fake.recordInvocation("RequestSwap", []interface{}{arg1})
+	fake.requestSwapMutex.Unlock()
+	if fake.RequestSwapStub != nil {
+		return fake.RequestSwapStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestSwapReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) RequestSwapCallCount() int {
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
+	return len(fake.requestSwapArgsForCall)
+}
+
+func (fake *Transactor) RequestSwapCalls(stub func(*token.SwapRequest) (*token.TokenTransaction, error)) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = stub
+}
+
+func (fake *Transactor) RequestSwapArgsForCall(i int) *token.SwapRequest {
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
+	argsForCall := fake.requestSwapArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) RequestSwapReturns(result1 *token.TokenTransaction, result2 error) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = nil
+	fake.requestSwapReturns = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) RequestSwapReturnsOnCall(i int, result1 *token.TokenTransaction, result2 error) {
+	fake.requestSwapMutex.Lock()
+	defer fake.requestSwapMutex.Unlock()
+	fake.RequestSwapStub = nil
+	if fake.requestSwapReturnsOnCall == nil {
+		fake.requestSwapReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenTransaction
+			result2 error
+		})
+	}
+	fake.requestSwapReturnsOnCall[i] = struct {
+		result1 *token.TokenTransaction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) RequestTransfer(arg1 *token.TransferRequest) (*token.TokenTransaction, error) {
 	fake.requestTransferMutex.Lock()
 	ret, specificReturn := fake.requestTransferReturnsOnCall[len(fake.requestTransferArgsForCall)]
@@ -492,6 +854,14 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.doneMutex.RLock()
 	defer fake.doneMutex.RUnlock()
+	fake.getBalanceMutex.RLock()
+	defer fake.getBalanceMutex.RUnlock()
+	fake.getSpendStatusMutex.RLock()
+	defer fake.getSpendStatusMutex.RUnlock()
+	fake.getTokenHistoryMutex.RLock()
+	defer fake.getTokenHistoryMutex.RUnlock()
+	fake.listTokenTypesMutex.RLock()
+	defer fake.listTokenTypesMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
 	fake.requestApproveMutex.RLock()
@@ -500,6 +870,8 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.requestExpectationMutex.RUnlock()
 	fake.requestRedeemMutex.RLock()
 	defer fake.requestRedeemMutex.RUnlock()
+	fake.requestSwapMutex.RLock()
+	defer fake.requestSwapMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
 	fake.requestTransferFromMutex.RLock()
@@ -95,6 +95,18 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 		payload, err = s.RequestTransferFrom(ctx, command.Header, t.TransferFromRequest)
 	case *token.Command_ExpectationRequest:
 		payload, err = s.RequestExpectation(ctx, command.Header, t.ExpectationRequest)
+	case *token.Command_TokenHistoryRequest:
+		payload, err = s.GetTokenHistory(ctx, command.Header, t.TokenHistoryRequest)
+	case *token.Command_BalanceRequest:
+		payload, err = s.GetBalance(ctx, command.Header, t.BalanceRequest)
+	case *token.Command_SpendStatusRequest:
+		payload, err = s.GetSpendStatus(ctx, command.Header, t.SpendStatusRequest)
+	case *token.Command_RegisterTokenTypeRequest:
+		payload, err = s.RequestRegisterTokenType(ctx, command.Header, t.RegisterTokenTypeRequest)
+	case *token.Command_ListTokenTypesRequest:
+		payload, err = s.ListTokenTypes(ctx, command.Header, t.ListTokenTypesRequest)
+	case *token.Command_SwapRequest:
+		payload, err = s.RequestSwap(ctx, command.Header, t.SwapRequest)
 	default:
 		err = errors.Errorf("command type not recognized: %T", t)
 	}
@@ -167,6 +179,85 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	return &token.CommandResponse_UnspentTokens{UnspentTokens: tokens}, nil
 }
 
+// GetTokenHistory returns a page of the token transactions involving the requestor
+func (s *Prover) GetTokenHistory(ctx context.Context, header *token.Header, request *token.TokenHistoryRequest) (*token.CommandResponse_TokenHistory, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	history, err := transactor.GetTokenHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenHistory{TokenHistory: history}, nil
+}
+
+// GetBalance returns the balance of the unspent tokens of the requestor, per token type
+func (s *Prover) GetBalance(ctx context.Context, header *token.Header, request *token.BalanceRequest) (*token.CommandResponse_TokenBalances, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	balances, err := transactor.GetBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenBalances{TokenBalances: balances}, nil
+}
+
+// GetSpendStatus returns whether the output identified in the request has been spent
+func (s *Prover) GetSpendStatus(ctx context.Context, header *token.Header, request *token.SpendStatusRequest) (*token.CommandResponse_SpendStatus, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	status, err := transactor.GetSpendStatus(request.InputId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_SpendStatus{SpendStatus: status}, nil
+}
+
+// RequestRegisterTokenType returns a transaction that adds a token type to the registry of the channel
+func (s *Prover) RequestRegisterTokenType(ctx context.Context, header *token.Header, request *token.RegisterTokenTypeRequest) (*token.CommandResponse_TokenTransaction, error) {
+	issuer, err := s.TMSManager.GetIssuer(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTransaction, err := issuer.RequestRegisterTokenType(request.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
+// ListTokenTypes returns the token types in the registry of the channel
+func (s *Prover) ListTokenTypes(ctx context.Context, header *token.Header, request *token.ListTokenTypesRequest) (*token.CommandResponse_TokenTypes, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	tokenTypes, err := transactor.ListTokenTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTypes{TokenTypes: tokenTypes}, nil
+}
+
 func (s *Prover) RequestApprove(ctx context.Context, header *token.Header, request *token.ApproveRequest) (*token.CommandResponse_TokenTransaction, error) {
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
 	if err != nil {
@@ -197,6 +288,23 @@ func (s *Prover) RequestTransferFrom(ctx context.Context, header *token.Header,
 	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
 }
 
+// RequestSwap gets a transactor and creates a token transaction that swaps the tokens
+// of the requestor with the tokens of the counterparty.
+func (s *Prover) RequestSwap(ctx context.Context, header *token.Header, request *token.SwapRequest) (*token.CommandResponse_TokenTransaction, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	tokenTransaction, err := transactor.RequestSwap(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTransaction}, nil
+}
+
 // RequestExpectation gets an issuer or transactor and creates a token transaction response
 // for import, transfer or redemption.
 func (s *Prover) RequestExpectation(ctx context.Context, header *token.Header, request *token.ExpectationRequest) (*token.CommandResponse_TokenTransaction, error) {
@@ -690,6 +690,74 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("RequestSwap", func() {
+		var (
+			swapRequest *token.SwapRequest
+			swap        *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			swapRequest = &token.SwapRequest{
+				Credential:           []byte("credential"),
+				TokenIds:             [][]byte{[]byte("id1")},
+				Quantity:             10,
+				Counterparty:         []byte("Bob"),
+				CounterpartyTokenIds: [][]byte{[]byte("id2")},
+				CounterpartyQuantity: 20,
+			}
+			swap = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainSwap{
+							PlainSwap: &token.PlainSwap{
+								Inputs:  []*token.InputId{{TxId: "tx1", Index: 0}, {TxId: "tx2", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("Bob"), Type: "TOK1", Quantity: 10}},
+							},
+						},
+					},
+				},
+			}
+			fakeTransactor.RequestSwapReturns(swap, nil)
+		})
+
+		It("uses a transactor to request the swap", func() {
+			resp, err := prover.RequestSwap(context.Background(), command.Header, swapRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: swap}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeTransactor.RequestSwapCallCount()).To(Equal(1))
+			Expect(fakeTransactor.RequestSwapArgsForCall(0)).To(Equal(swapRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the TMS manager fails to get a transactor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetTransactorReturns(nil, errors.New("boing boing"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestSwap(context.Background(), command.Header, swapRequest)
+				Expect(err).To(MatchError("boing boing"))
+			})
+		})
+
+		Context("when the transactor fails to create the swap", func() {
+			BeforeEach(func() {
+				fakeTransactor.RequestSwapReturns(nil, errors.New("watermelon"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestSwap(context.Background(), command.Header, swapRequest)
+				Expect(err).To(MatchError("watermelon"))
+			})
+		})
+	})
+
 	Describe("RequestExpectation import", func() {
 		It("gets an issuer", func() {
 			_, err := prover.RequestExpectation(context.Background(), command.Header, importExpectationRequest)
@@ -820,6 +888,7 @@ var _ = Describe("Prover", func() {
 		)
 
 		BeforeEach(func() {
+			manager = &server.Manager{}
 			prover = &server.Prover{
 				CapabilityChecker: fakeCapabilityChecker,
 				PolicyChecker:     fakePolicyChecker,
@@ -1024,6 +1093,287 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("GetTokenHistory", func() {
+		var (
+			historyRequest *token.TokenHistoryRequest
+			history        *token.TokenHistory
+		)
+
+		BeforeEach(func() {
+			historyRequest = &token.TokenHistoryRequest{Credential: []byte("credential"), PageSize: 10, Bookmark: "tx0"}
+			history = &token.TokenHistory{
+				Transactions: []*token.TokenTransactionRecord{{TxId: "tx1", TokenTransaction: tokenTransaction}},
+				Bookmark:     "tx1",
+			}
+			fakeTransactor.GetTokenHistoryReturns(history, nil)
+		})
+
+		It("uses a transactor to get the token history", func() {
+			resp, err := prover.GetTokenHistory(context.Background(), command.Header, historyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+
+			Expect(fakeTransactor.GetTokenHistoryCallCount()).To(Equal(1))
+			Expect(fakeTransactor.GetTokenHistoryArgsForCall(0)).To(Equal(historyRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the TMS manager fails to get a transactor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetTransactorReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetTokenHistory(context.Background(), command.Header, historyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+
+		Context("when the transactor fails to get the token history", func() {
+			BeforeEach(func() {
+				fakeTransactor.GetTokenHistoryReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetTokenHistory(context.Background(), command.Header, historyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("GetBalance", func() {
+		var balances *token.TokenBalances
+
+		BeforeEach(func() {
+			balances = &token.TokenBalances{Balances: []*token.TokenBalance{{Type: "typeaz", Quantity: 135}}}
+			fakeTransactor.GetBalanceReturns(balances, nil)
+		})
+
+		It("uses a transactor to get the balance", func() {
+			resp, err := prover.GetBalance(context.Background(), command.Header, &token.BalanceRequest{Credential: []byte("credential")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenBalances{TokenBalances: balances}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			Expect(fakeTransactor.GetBalanceCallCount()).To(Equal(1))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the transactor fails to get the balance", func() {
+			BeforeEach(func() {
+				fakeTransactor.GetBalanceReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetBalance(context.Background(), command.Header, &token.BalanceRequest{})
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("GetSpendStatus", func() {
+		var (
+			spendStatusRequest *token.SpendStatusRequest
+			spendStatus        *token.SpendStatus
+		)
+
+		BeforeEach(func() {
+			spendStatusRequest = &token.SpendStatusRequest{
+				Credential: []byte("credential"),
+				InputId:    &token.InputId{TxId: "tx1", Index: 2},
+			}
+			spendStatus = &token.SpendStatus{InputId: spendStatusRequest.InputId, Spent: true}
+			fakeTransactor.GetSpendStatusReturns(spendStatus, nil)
+		})
+
+		It("uses a transactor to get the spend status", func() {
+			resp, err := prover.GetSpendStatus(context.Background(), command.Header, spendStatusRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_SpendStatus{SpendStatus: spendStatus}))
+
+			Expect(fakeTransactor.GetSpendStatusCallCount()).To(Equal(1))
+			Expect(fakeTransactor.GetSpendStatusArgsForCall(0)).To(Equal(spendStatusRequest.InputId))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the transactor fails to get the spend status", func() {
+			BeforeEach(func() {
+				fakeTransactor.GetSpendStatusReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.GetSpendStatus(context.Background(), command.Header, spendStatusRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("RequestRegisterTokenType", func() {
+		var (
+			registerRequest *token.RegisterTokenTypeRequest
+			registration    *token.TokenTransaction
+		)
+
+		BeforeEach(func() {
+			registerRequest = &token.RegisterTokenTypeRequest{
+				Credential: []byte("credential"),
+				Definition: &token.TokenTypeDefinition{Type: "TOK1", MaxSupply: 100},
+			}
+			registration = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTypeRegistration{
+							PlainTypeRegistration: &token.PlainTypeRegistration{Definition: registerRequest.Definition},
+						},
+					},
+				},
+			}
+			fakeIssuer.RequestRegisterTokenTypeReturns(registration, nil)
+		})
+
+		It("uses an issuer to request the registration", func() {
+			resp, err := prover.RequestRegisterTokenType(context.Background(), command.Header, registerRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTransaction{TokenTransaction: registration}))
+
+			Expect(fakeTMSManager.GetIssuerCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetIssuerArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeIssuer.RequestRegisterTokenTypeCallCount()).To(Equal(1))
+			Expect(fakeIssuer.RequestRegisterTokenTypeArgsForCall(0)).To(Equal(registerRequest.Definition))
+		})
+
+		Context("when the TMS manager fails to get an issuer", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetIssuerReturns(nil, errors.New("boing boing"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestRegisterTokenType(context.Background(), command.Header, registerRequest)
+				Expect(err).To(MatchError("boing boing"))
+			})
+		})
+
+		Context("when the issuer fails to register the token type", func() {
+			BeforeEach(func() {
+				fakeIssuer.RequestRegisterTokenTypeReturns(nil, errors.New("watermelon"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.RequestRegisterTokenType(context.Background(), command.Header, registerRequest)
+				Expect(err).To(MatchError("watermelon"))
+			})
+		})
+	})
+
+	Describe("ListTokenTypes", func() {
+		var tokenTypes *token.TokenTypes
+
+		BeforeEach(func() {
+			tokenTypes = &token.TokenTypes{Types: []*token.TokenTypeInfo{
+				{Definition: &token.TokenTypeDefinition{Type: "TOK1", MaxSupply: 100}, Supply: 10},
+			}}
+			fakeTransactor.ListTokenTypesReturns(tokenTypes, nil)
+		})
+
+		It("uses a transactor to list the token types", func() {
+			resp, err := prover.ListTokenTypes(context.Background(), command.Header, &token.ListTokenTypesRequest{Credential: []byte("credential")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenTypes{TokenTypes: tokenTypes}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			Expect(fakeTransactor.ListTokenTypesCallCount()).To(Equal(1))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the transactor fails to list the token types", func() {
+			BeforeEach(func() {
+				fakeTransactor.ListTokenTypesReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.ListTokenTypes(context.Background(), command.Header, &token.ListTokenTypesRequest{})
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("Process ListTokenTypes command", func() {
+		BeforeEach(func() {
+			command = &token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_ListTokenTypesRequest{
+					ListTokenTypesRequest: &token.ListTokenTypesRequest{Credential: []byte("credential")},
+				},
+			}
+			marshaledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshaledCommand,
+				Signature: []byte("command-signature"),
+			}
+			fakeTransactor.ListTokenTypesReturns(&token.TokenTypes{}, nil)
+		})
+
+		It("returns a signed command response", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			cmd, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(cmd).To(Equal(marshaledCommand))
+			Expect(payload).To(Equal(&token.CommandResponse_TokenTypes{
+				TokenTypes: &token.TokenTypes{},
+			}))
+		})
+	})
+
+	Describe("Process GetBalance command", func() {
+		BeforeEach(func() {
+			command = &token.Command{
+				Header: &token.Header{
+					ChannelId: "channel-id",
+					Creator:   []byte("creator"),
+					Nonce:     []byte("nonce"),
+				},
+				Payload: &token.Command_BalanceRequest{
+					BalanceRequest: &token.BalanceRequest{Credential: []byte("credential")},
+				},
+			}
+			marshaledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshaledCommand,
+				Signature: []byte("command-signature"),
+			}
+			fakeTransactor.GetBalanceReturns(&token.TokenBalances{}, nil)
+		})
+
+		It("returns a signed command response", func() {
+			resp, err := prover.ProcessCommand(context.Background(), signedCommand)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(marshaledResponse))
+
+			Expect(fakeMarshaler.MarshalCommandResponseCallCount()).To(Equal(1))
+			cmd, payload := fakeMarshaler.MarshalCommandResponseArgsForCall(0)
+			Expect(cmd).To(Equal(marshaledCommand))
+			Expect(payload).To(Equal(&token.CommandResponse_TokenBalances{
+				TokenBalances: &token.TokenBalances{},
+			}))
+		})
+	})
+
 	Describe("ProcessCommand_RequestExpection for import", func() {
 		BeforeEach(func() {
 			command = &token.Command{
@@ -19,6 +19,10 @@ type Issuer interface {
 	// RequestExpectation allows indirect import based on the expectation.
 	// It creates a token transaction with the outputs as specified in the expectation.
 	RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error)
+
+	// RequestRegisterTokenType creates a transaction that adds the passed definition
+	// to the token type registry of the channel.
+	RequestRegisterTokenType(definition *token.TokenTypeDefinition) (*token.TokenTransaction, error)
 }
 
 //go:generate counterfeiter -o mock/transactor.go -fake-name Transactor . Transactor
@@ -39,6 +43,19 @@ type Transactor interface {
 	// ListTokens returns a slice of unspent tokens owned by this transactor
 	ListTokens() (*token.UnspentTokens, error)
 
+	// GetTokenHistory returns a page of the committed token transactions
+	// that involve this transactor
+	GetTokenHistory(request *token.TokenHistoryRequest) (*token.TokenHistory, error)
+
+	// GetBalance returns the sum of the unspent tokens owned by this transactor, per token type
+	GetBalance() (*token.TokenBalances, error)
+
+	// GetSpendStatus returns whether the output identified by inputID has been spent
+	GetSpendStatus(inputID *token.InputId) (*token.SpendStatus, error)
+
+	// ListTokenTypes returns the token types in the registry of the channel
+	ListTokenTypes() (*token.TokenTypes, error)
+
 	// RequestApprove creates a token transaction that includes the data necessary
 	// for approve
 	RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error)
@@ -52,6 +69,11 @@ type Transactor interface {
 	// It creates a token transaction with the outputs as specified in the expectation.
 	RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error)
 
+	// RequestSwap creates a token transaction that atomically exchanges tokens of this
+	// transactor with tokens of a different type owned by a counterparty.
+	// The counterparty must sign the transaction before it is submitted.
+	RequestSwap(request *token.SwapRequest) (*token.TokenTransaction, error)
+
 	// Done releases any resources held by this transactor
 	Done()
 }
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"crypto/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+// An Issuer that can import new tokens whose quantities are hidden behind commitments
+type Issuer struct{}
+
+// RequestImport creates an import request with the token owners, types, and quantities specified in tokensToIssue.
+// The opening of each output is encrypted for its owner, whose identity must carry an ECDSA certificate.
+func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.TokenTransaction, error) {
+	var outputs []*token.ConfidentialOutput
+	for _, tti := range tokensToIssue {
+		if tti.GetQuantity() == 0 {
+			return nil, errors.New("the quantity to issue must be greater than 0")
+		}
+		output, _, err := newOutput(tti.Recipient, tti.Type, tti.Quantity, rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialImport{
+					ConfidentialImport: &token.ConfidentialImport{
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestExpectation is not supported by the confidential TMS
+func (i *Issuer) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("expectations are not supported by the confidential TMS")
+}
+
+// RequestRegisterTokenType is not supported by the confidential TMS
+func (i *Issuer) RequestRegisterTokenType(definition *token.TokenTypeDefinition) (*token.TokenTransaction, error) {
+	return nil, errors.New("the token type registry is not supported by the confidential TMS")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential_test
+
+import (
+	"crypto/ecdsa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
+)
+
+var _ = Describe("Issuer", func() {
+	var (
+		issuer        *confidential.Issuer
+		owner         []byte
+		ownerKey      *ecdsa.PrivateKey
+		tokensToIssue []*token.TokenToIssue
+	)
+
+	BeforeEach(func() {
+		issuer = &confidential.Issuer{}
+		owner, ownerKey = newOwner("alice")
+		tokensToIssue = []*token.TokenToIssue{
+			{Recipient: owner, Type: "TOK1", Quantity: 1001},
+			{Recipient: owner, Type: "TOK2", Quantity: 1},
+		}
+	})
+
+	It("creates an import transaction with hidden quantities", func() {
+		tt, err := issuer.RequestImport(tokensToIssue)
+		Expect(err).NotTo(HaveOccurred())
+
+		outputs := tt.GetConfidentialAction().GetConfidentialImport().GetOutputs()
+		Expect(outputs).To(HaveLen(2))
+		for i, output := range outputs {
+			Expect(output.Owner).To(Equal(owner))
+			Expect(output.Type).To(Equal(tokensToIssue[i].Type))
+			Expect(output.Commitment).NotTo(BeEmpty())
+			Expect(output.RangeProof.GetBits()).To(HaveLen(64))
+
+			opening, err := confidential.OpenOutput(output, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(opening.Quantity).To(Equal(tokensToIssue[i].Quantity))
+		}
+	})
+
+	It("hides equal quantities behind different commitments", func() {
+		tokensToIssue[1].Type = "TOK1"
+		tokensToIssue[1].Quantity = 1001
+		tt, err := issuer.RequestImport(tokensToIssue)
+		Expect(err).NotTo(HaveOccurred())
+
+		outputs := tt.GetConfidentialAction().GetConfidentialImport().GetOutputs()
+		Expect(outputs[0].Commitment).NotTo(Equal(outputs[1].Commitment))
+	})
+
+	Context("when the opening is decrypted with the wrong key", func() {
+		It("returns an error", func() {
+			tt, err := issuer.RequestImport(tokensToIssue)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, otherKey := newOwner("bob")
+			_, err = confidential.OpenOutput(tt.GetConfidentialAction().GetConfidentialImport().Outputs[0], otherKey)
+			Expect(err).To(MatchError(ContainSubstring("failed decrypting opening")))
+		})
+	})
+
+	Context("when the quantity is 0", func() {
+		It("returns an error", func() {
+			tokensToIssue[1].Quantity = 0
+			_, err := issuer.RequestImport(tokensToIssue)
+			Expect(err).To(MatchError("the quantity to issue must be greater than 0"))
+		})
+	})
+
+	Context("when the recipient does not carry a certificate", func() {
+		It("returns an error", func() {
+			tokensToIssue[0].Recipient = []byte("alice")
+			_, err := issuer.RequestImport(tokensToIssue)
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling owner identity")))
+		})
+	})
+
+	Describe("RequestExpectation", func() {
+		It("is not supported", func() {
+			_, err := issuer.RequestExpectation(&token.ExpectationRequest{})
+			Expect(err).To(MatchError("expectations are not supported by the confidential TMS"))
+		})
+	})
+})
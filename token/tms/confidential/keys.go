@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/token/tms"
+)
+
+const (
+	confidentialOutput = "confidentialOutput"
+	confidentialInput  = "confidentialInput"
+	tokenTx            = "tokenTx"
+	tokenNameSpace     = tms.Namespace
+)
+
+// Create a ledger key for an individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createOutputKey(txID string, index int) (string, error) {
+	return tms.CreateCompositeKey(confidentialOutput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a spent individual output in a token transaction, as a function of
+// the transaction ID, and the index of the output
+func createSpentKey(txID string, index int) (string, error) {
+	return tms.CreateCompositeKey(confidentialInput, []string{txID, strconv.Itoa(index)})
+}
+
+// Create a ledger key for a token transaction, as a function of the transaction ID
+func createTxKey(txID string) (string, error) {
+	return tms.CreateCompositeKey(tokenTx, []string{txID})
+}
+
+// parseOutputKey returns the ID of the output identified by the passed ledger key
+func parseOutputKey(key string) (string, int, error) {
+	objectType, attributes, err := tms.SplitCompositeKey(key)
+	if err != nil {
+		return "", 0, err
+	}
+	if objectType != confidentialOutput || len(attributes) != 2 {
+		return "", 0, errors.Errorf("'%s' is not the key of a confidential output", key)
+	}
+	index, err := strconv.Atoi(attributes[1])
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "invalid output index in key '%s'", key)
+	}
+	return attributes[0], index, nil
+}
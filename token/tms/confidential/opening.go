@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/idemix"
+	"github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+// ownerPublicKey extracts the ECDSA public key of the certificate in the serialized identity owner
+func ownerPublicKey(owner []byte) (*ecdsa.PublicKey, error) {
+	sid := &msp.SerializedIdentity{}
+	err := proto.Unmarshal(owner, sid)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling owner identity")
+	}
+	block, _ := pem.Decode(sid.IdBytes)
+	if block == nil {
+		return nil, errors.New("owner identity does not contain a PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing owner certificate")
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported owner public key type %T", cert.PublicKey)
+	}
+	return publicKey, nil
+}
+
+// encryptOpening encrypts opening for the owner of the output with an ephemeral
+// ECDH key agreement on the curve of the public key of the owner and AES-GCM
+func encryptOpening(owner []byte, opening *token.TokenOpening, random io.Reader) (*token.EncryptedOpening, error) {
+	publicKey, err := ownerPublicKey(owner)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdsa.GenerateKey(publicKey.Curve, random)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed generating ephemeral key")
+	}
+	x, _ := publicKey.Curve.ScalarMult(publicKey.X, publicKey.Y, ephemeral.D.Bytes())
+	aead, err := openingCipher(x.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+	plaintext, err := proto.Marshal(opening)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling opening")
+	}
+	return &token.EncryptedOpening{
+		EphemeralPublicKey: elliptic.Marshal(publicKey.Curve, ephemeral.X, ephemeral.Y),
+		Nonce:              nonce,
+		Ciphertext:         aead.Seal(nil, nonce, plaintext, nil),
+	}, nil
+}
+
+// OpenOutput decrypts the opening of output with the private key of its owner
+// and checks that it opens the commitment of the output.
+func OpenOutput(output *token.ConfidentialOutput, privateKey *ecdsa.PrivateKey) (*token.TokenOpening, error) {
+	encrypted := output.GetOpening()
+	if encrypted == nil {
+		return nil, errors.New("output does not carry an opening")
+	}
+	ex, ey := elliptic.Unmarshal(privateKey.Curve, encrypted.GetEphemeralPublicKey())
+	if ex == nil {
+		return nil, errors.New("invalid ephemeral public key")
+	}
+	x, _ := privateKey.Curve.ScalarMult(ex, ey, privateKey.D.Bytes())
+	aead, err := openingCipher(x.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.GetNonce()) != aead.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, encrypted.GetNonce(), encrypted.GetCiphertext(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decrypting opening")
+	}
+	opening := &token.TokenOpening{}
+	err = proto.Unmarshal(plaintext, opening)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling opening")
+	}
+	_, err = checkOpening(output, opening)
+	if err != nil {
+		return nil, err
+	}
+	return opening, nil
+}
+
+// checkOpening checks that opening opens the commitment of output and returns its blinding factor
+func checkOpening(output *token.ConfidentialOutput, opening *token.TokenOpening) (*FP256BN.BIG, error) {
+	commitment, err := pointFromBytes(output.GetCommitment())
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid commitment")
+	}
+	blindingFactor, err := scalarFromBytes(opening.GetBlindingFactor())
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid blinding factor")
+	}
+	if !commit(bigFromUint64(opening.GetQuantity()), blindingFactor).Equals(commitment) {
+		return nil, errors.New("opening does not match the commitment")
+	}
+	return blindingFactor, nil
+}
+
+func openingCipher(sharedSecret []byte) (cipher.AEAD, error) {
+	key := sha256.Sum256(append([]byte("fabtoken confidential opening"), sharedSecret...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// newOutput creates an output of quantity tokens of type tokenType owned by owner,
+// and returns it together with the blinding factor of its commitment
+func newOutput(owner []byte, tokenType string, quantity uint64, random io.Reader) (*token.ConfidentialOutput, *FP256BN.BIG, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, nil, err
+	}
+	commitment, blindingFactor, rangeProof := proveRange(quantity, rng)
+	opening, err := encryptOpening(owner, &token.TokenOpening{Quantity: quantity, BlindingFactor: idemix.BigToBytes(blindingFactor)}, random)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed encrypting opening")
+	}
+	return &token.ConfidentialOutput{
+		Owner:      owner,
+		Type:       tokenType,
+		Commitment: idemix.EcpToBytes(commitment),
+		RangeProof: rangeProof,
+		Opening:    opening,
+	}, blindingFactor, nil
+}
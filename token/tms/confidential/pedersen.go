@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/idemix"
+)
+
+// Pedersen commitments Com(v, r) = v*G + r*H are computed in group G1 of FP256BN.
+// G is the standard generator of the group while H is obtained by hashing a public
+// string to the curve, so that nobody knows the discrete logarithm of H in base G.
+var (
+	genG = idemix.GenG1
+	genH = hashToG1([]byte("fabtoken confidential pedersen generator"))
+)
+
+// pointBytes is the length of a serialized (uncompressed) point of G1
+var pointBytes = 2*idemix.FieldBytes + 1
+
+func hashToG1(data []byte) *FP256BN.ECP {
+	digest := sha256.Sum256(data)
+	return FP256BN.ECP_mapit(digest[:])
+}
+
+// commit returns the Pedersen commitment to v with blinding factor r
+func commit(v, r *FP256BN.BIG) *FP256BN.ECP {
+	return add(genG.Mul(v), genH.Mul(r))
+}
+
+// bigFromUint64 returns the BIG representation of q
+func bigFromUint64(q uint64) *FP256BN.BIG {
+	b := make([]byte, idemix.FieldBytes)
+	binary.BigEndian.PutUint64(b[idemix.FieldBytes-8:], q)
+	return FP256BN.FromBytes(b)
+}
+
+// randomScalar returns a random element in 0, ..., GroupOrder-1
+func randomScalar(rng *amcl.RAND) *FP256BN.BIG {
+	return idemix.RandModOrder(rng)
+}
+
+// scalarFromBytes parses a serialized element of 0, ..., GroupOrder-1
+func scalarFromBytes(raw []byte) (*FP256BN.BIG, error) {
+	if len(raw) != idemix.FieldBytes {
+		return nil, errors.Errorf("invalid scalar length %d, expected %d", len(raw), idemix.FieldBytes)
+	}
+	s := FP256BN.FromBytes(raw)
+	s.Mod(idemix.GroupOrder)
+	if !bytes.Equal(idemix.BigToBytes(s), raw) {
+		return nil, errors.New("scalar is not reduced modulo the group order")
+	}
+	return s, nil
+}
+
+// pointFromBytes parses a serialized point of G1, rejecting the point at infinity
+func pointFromBytes(raw []byte) (*FP256BN.ECP, error) {
+	if len(raw) != pointBytes {
+		return nil, errors.Errorf("invalid point length %d, expected %d", len(raw), pointBytes)
+	}
+	p := FP256BN.ECP_fromBytes(raw)
+	if p.Is_infinity() {
+		return nil, errors.New("invalid point")
+	}
+	return p, nil
+}
+
+// add returns a+b without modifying a
+func add(a, b *FP256BN.ECP) *FP256BN.ECP {
+	c := FP256BN.NewECP()
+	c.Copy(a)
+	c.Add(b)
+	return c
+}
+
+// sub returns a-b without modifying a
+func sub(a, b *FP256BN.ECP) *FP256BN.ECP {
+	c := FP256BN.NewECP()
+	c.Copy(a)
+	c.Sub(b)
+	return c
+}
+
+// hashToScalar hashes the passed values into 0, ..., GroupOrder-1
+func hashToScalar(domain string, values ...[]byte) *FP256BN.BIG {
+	h := sha256.New()
+	h.Write([]byte(domain))
+	for _, v := range values {
+		var l [4]byte
+		binary.BigEndian.PutUint32(l[:], uint32(len(v)))
+		h.Write(l[:])
+		h.Write(v)
+	}
+	digest := h.Sum(nil)
+	s := FP256BN.FromBytes(digest)
+	s.Mod(idemix.GroupOrder)
+	return s
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/hyperledger/fabric-amcl/amcl"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/idemix"
+	"github.com/tradeline-tech/fabric/protos/token"
+)
+
+// rangeBits is the number of bits of the quantities hidden by the commitments
+const rangeBits = 64
+
+// proveRange commits to quantity and proves that the committed value is in [0, 2^64).
+// Each bit b_i of quantity is committed to as C_i = b_i*G + r_i*H, together with a
+// disjunctive proof that C_i or C_i-G is a multiple of H. The commitment to the
+// quantity is then the sum of 2^i*C_i, and its blinding factor the sum of 2^i*r_i.
+func proveRange(quantity uint64, rng *amcl.RAND) (*FP256BN.ECP, *FP256BN.BIG, *token.RangeProof) {
+	blindingFactor := FP256BN.NewBIGint(0)
+	power := FP256BN.NewBIGint(1)
+	bits := make([]*FP256BN.BIG, rangeBits)
+	bitBlindingFactors := make([]*FP256BN.BIG, rangeBits)
+	for i := 0; i < rangeBits; i++ {
+		bits[i] = FP256BN.NewBIGint(int((quantity >> uint(i)) & 1))
+		bitBlindingFactors[i] = randomScalar(rng)
+		blindingFactor = idemix.Modadd(blindingFactor, FP256BN.Modmul(power, bitBlindingFactors[i], idemix.GroupOrder), idemix.GroupOrder)
+		power = idemix.Modadd(power, power, idemix.GroupOrder)
+	}
+	commitment := commit(bigFromUint64(quantity), blindingFactor)
+	commitmentBytes := idemix.EcpToBytes(commitment)
+
+	proof := &token.RangeProof{}
+	for i := 0; i < rangeBits; i++ {
+		proof.Bits = append(proof.Bits, proveBit(commitmentBytes, i, (quantity>>uint(i))&1 == 1, bitBlindingFactors[i], rng))
+	}
+	return commitment, blindingFactor, proof
+}
+
+func proveBit(commitment []byte, index int, bit bool, blindingFactor *FP256BN.BIG, rng *amcl.RAND) *token.BitProof {
+	b := 0
+	if bit {
+		b = 1
+	}
+	c := genH.Mul(blindingFactor)
+	if bit {
+		c.Add(genG)
+	}
+	y := []*FP256BN.ECP{c, sub(c, genG)}
+
+	// simulate the proof for the branch that does not hold
+	challenges := make([]*FP256BN.BIG, 2)
+	responses := make([]*FP256BN.BIG, 2)
+	a := make([]*FP256BN.ECP, 2)
+	challenges[1-b] = randomScalar(rng)
+	responses[1-b] = randomScalar(rng)
+	a[1-b] = mulSub(responses[1-b], y[1-b], challenges[1-b])
+
+	// and compute the real proof for the branch that holds
+	k := randomScalar(rng)
+	a[b] = genH.Mul(k)
+	challenge := bitChallenge(commitment, index, c, a[0], a[1])
+	challenges[b] = idemix.Modsub(challenge, challenges[1-b], idemix.GroupOrder)
+	responses[b] = idemix.Modadd(k, FP256BN.Modmul(challenges[b], blindingFactor, idemix.GroupOrder), idemix.GroupOrder)
+
+	return &token.BitProof{
+		Commitment: idemix.EcpToBytes(c),
+		Challenge0: idemix.BigToBytes(challenges[0]),
+		Challenge1: idemix.BigToBytes(challenges[1]),
+		Response0:  idemix.BigToBytes(responses[0]),
+		Response1:  idemix.BigToBytes(responses[1]),
+	}
+}
+
+func bitChallenge(commitment []byte, index int, c, a0, a1 *FP256BN.ECP) *FP256BN.BIG {
+	return hashToScalar("fabtoken confidential range proof",
+		commitment, []byte(strconv.Itoa(index)), idemix.EcpToBytes(c), idemix.EcpToBytes(a0), idemix.EcpToBytes(a1))
+}
+
+// verifyRange checks that proof shows that commitment hides a value in [0, 2^64)
+func verifyRange(commitment *FP256BN.ECP, proof *token.RangeProof) error {
+	if len(proof.GetBits()) != rangeBits {
+		return errors.Errorf("range proof must have %d bit proofs, got %d", rangeBits, len(proof.GetBits()))
+	}
+	commitmentBytes := idemix.EcpToBytes(commitment)
+
+	// sum up 2^i*C_i from the most significant bit down, doubling at each step
+	sum := FP256BN.NewECP()
+	for i := rangeBits - 1; i >= 0; i-- {
+		c, err := verifyBit(commitmentBytes, i, proof.GetBits()[i])
+		if err != nil {
+			return errors.WithMessage(err, "invalid bit proof "+strconv.Itoa(i))
+		}
+		sum = add(sum, sum)
+		sum.Add(c)
+	}
+	if !sum.Equals(commitment) {
+		return errors.New("bit commitments do not add up to the commitment")
+	}
+	return nil
+}
+
+func verifyBit(commitment []byte, index int, proof *token.BitProof) (*FP256BN.ECP, error) {
+	c, err := pointFromBytes(proof.GetCommitment())
+	if err != nil {
+		return nil, err
+	}
+	challenges := make([]*FP256BN.BIG, 2)
+	responses := make([]*FP256BN.BIG, 2)
+	for i, raw := range [][]byte{proof.GetChallenge0(), proof.GetChallenge1()} {
+		if challenges[i], err = scalarFromBytes(raw); err != nil {
+			return nil, err
+		}
+	}
+	for i, raw := range [][]byte{proof.GetResponse0(), proof.GetResponse1()} {
+		if responses[i], err = scalarFromBytes(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	y := []*FP256BN.ECP{c, sub(c, genG)}
+	a0 := mulSub(responses[0], y[0], challenges[0])
+	a1 := mulSub(responses[1], y[1], challenges[1])
+	challenge := bitChallenge(commitment, index, c, a0, a1)
+	sum := idemix.Modadd(challenges[0], challenges[1], idemix.GroupOrder)
+	if !bytes.Equal(idemix.BigToBytes(sum), idemix.BigToBytes(challenge)) {
+		return nil, errors.New("challenge mismatch")
+	}
+	return c, nil
+}
+
+// mulSub returns s*H - c*y
+func mulSub(s *FP256BN.BIG, y *FP256BN.ECP, c *FP256BN.BIG) *FP256BN.ECP {
+	return genH.Mul2(s, y, idemix.Modsub(FP256BN.NewBIGint(0), c, idemix.GroupOrder))
+}
+
+// proveBlindingFactor proves the knowledge of x such that d = x*H.
+// The proof is bound to the passed context.
+func proveBlindingFactor(x *FP256BN.BIG, d *FP256BN.ECP, context []byte, rng *amcl.RAND) *token.SchnorrProof {
+	k := randomScalar(rng)
+	a := genH.Mul(k)
+	c := hashToScalar("fabtoken confidential balance proof", context, idemix.EcpToBytes(d), idemix.EcpToBytes(a))
+	s := idemix.Modadd(k, FP256BN.Modmul(c, x, idemix.GroupOrder), idemix.GroupOrder)
+	return &token.SchnorrProof{
+		Commitment: idemix.EcpToBytes(a),
+		Response:   idemix.BigToBytes(s),
+	}
+}
+
+// verifyBlindingFactor checks that proof shows the knowledge of x such that d = x*H
+func verifyBlindingFactor(d *FP256BN.ECP, context []byte, proof *token.SchnorrProof) error {
+	if proof == nil {
+		return errors.New("missing proof")
+	}
+	a, err := pointFromBytes(proof.GetCommitment())
+	if err != nil {
+		return err
+	}
+	s, err := scalarFromBytes(proof.GetResponse())
+	if err != nil {
+		return err
+	}
+	c := hashToScalar("fabtoken confidential balance proof", context, idemix.EcpToBytes(d), idemix.EcpToBytes(a))
+	if !genH.Mul(s).Equals(add(a, d.Mul(c))) {
+		return errors.New("proof does not verify")
+	}
+	return nil
+}
+
+// balanceContext returns the data the balance proof of a transfer or redeem is bound to
+func balanceContext(inputs []*token.InputId, outputs []*token.ConfidentialOutput, quantity uint64) []byte {
+	var buf bytes.Buffer
+	for _, input := range inputs {
+		buf.WriteString(input.GetTxId())
+		buf.WriteByte(0)
+		buf.WriteString(strconv.FormatUint(uint64(input.GetIndex()), 10))
+		buf.WriteByte(0)
+	}
+	for _, output := range outputs {
+		buf.Write(output.GetOwner())
+		buf.WriteByte(0)
+		buf.WriteString(output.GetType())
+		buf.WriteByte(0)
+		buf.Write(output.GetCommitment())
+	}
+	buf.WriteString(strconv.FormatUint(quantity, 10))
+	return buf.Bytes()
+}
@@ -0,0 +1,357 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"crypto/rand"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/idemix"
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/ledger"
+	"github.com/tradeline-tech/fabric/token/tms"
+)
+
+// A Transactor that can transfer and redeem tokens whose quantities are hidden behind commitments.
+// Since the ledger does not reveal quantities, the requests must carry the openings of the
+// tokens they spend; the owner of a token obtains its opening with OpenOutput.
+type Transactor struct {
+	PublicCredential []byte
+	Ledger           ledger.LedgerReader
+}
+
+// input is a token spent by a transaction, together with its opening
+type input struct {
+	id             *token.InputId
+	output         *token.ConfidentialOutput
+	quantity       uint64
+	blindingFactor *FP256BN.BIG
+}
+
+// RequestTransfer creates a TokenTransaction of type transfer request
+func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
+	inputs, tokenType, inputSum, err := t.getInputs(request.GetTokenIds(), request.GetOpenings())
+	if err != nil {
+		return nil, err
+	}
+	if len(request.GetShares()) == 0 {
+		return nil, errors.New("no shares in TransferRequest")
+	}
+
+	var outputSum uint64
+	var quantities []uint64
+	var owners [][]byte
+	for _, share := range request.GetShares() {
+		if share.GetQuantity() == 0 {
+			return nil, errors.New("the quantity of a share must be greater than 0")
+		}
+		if outputSum+share.GetQuantity() < outputSum {
+			return nil, errors.New("quantity overflow in shares")
+		}
+		outputSum += share.GetQuantity()
+		quantities = append(quantities, share.GetQuantity())
+		owners = append(owners, share.GetRecipient())
+	}
+	if outputSum != inputSum {
+		return nil, errors.Errorf("token sum mismatch in inputs and shares (%d vs %d)", inputSum, outputSum)
+	}
+
+	ids, outputs, proof, err := t.createOutputs(inputs, owners, tokenType, quantities, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialTransfer{
+					ConfidentialTransfer: &token.ConfidentialTransfer{
+						Inputs:       ids,
+						Outputs:      outputs,
+						BalanceProof: proof,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestRedeem creates a TokenTransaction of type redeem request.
+// The remaining quantity, if any, is returned to the creator.
+func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTransaction, error) {
+	if request.GetQuantityToRedeem() == 0 {
+		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", request.GetQuantityToRedeem())
+	}
+	inputs, tokenType, inputSum, err := t.getInputs(request.GetTokenIds(), request.GetOpenings())
+	if err != nil {
+		return nil, err
+	}
+	if inputSum < request.GetQuantityToRedeem() {
+		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be redeemed", inputSum, request.GetQuantityToRedeem())
+	}
+
+	var owners [][]byte
+	var quantities []uint64
+	if inputSum > request.GetQuantityToRedeem() {
+		owners = append(owners, t.PublicCredential)
+		quantities = append(quantities, inputSum-request.GetQuantityToRedeem())
+	}
+	ids, outputs, proof, err := t.createOutputs(inputs, owners, tokenType, quantities, request.GetQuantityToRedeem())
+	if err != nil {
+		return nil, err
+	}
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_ConfidentialAction{
+			ConfidentialAction: &token.ConfidentialTokenAction{
+				Data: &token.ConfidentialTokenAction_ConfidentialRedeem{
+					ConfidentialRedeem: &token.ConfidentialRedeem{
+						Inputs:       ids,
+						Quantity:     request.GetQuantityToRedeem(),
+						Outputs:      outputs,
+						BalanceProof: proof,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// createOutputs creates the outputs for the passed owners and quantities, and the proof that the
+// blinding factors of the inputs match those of the outputs plus the redeemed quantity
+func (t *Transactor) createOutputs(inputs []*input, owners [][]byte, tokenType string, quantities []uint64, redeemed uint64) ([]*token.InputId, []*token.ConfidentialOutput, *token.SchnorrProof, error) {
+	var ids []*token.InputId
+	inputCommitments := FP256BN.NewECP()
+	x := FP256BN.NewBIGint(0)
+	for _, in := range inputs {
+		ids = append(ids, in.id)
+		commitment, err := pointFromBytes(in.output.GetCommitment())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		inputCommitments.Add(commitment)
+		x = idemix.Modadd(x, in.blindingFactor, idemix.GroupOrder)
+	}
+
+	var outputs []*token.ConfidentialOutput
+	outputCommitments := FP256BN.NewECP()
+	for i, owner := range owners {
+		output, blindingFactor, err := newOutput(owner, tokenType, quantities[i], rand.Reader)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		commitment, err := pointFromBytes(output.GetCommitment())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		outputCommitments.Add(commitment)
+		x = idemix.Modsub(x, blindingFactor, idemix.GroupOrder)
+		outputs = append(outputs, output)
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	d := sub(sub(inputCommitments, outputCommitments), genG.Mul(bigFromUint64(redeemed)))
+	proof := proveBlindingFactor(x, d, balanceContext(ids, outputs, redeemed), rng)
+	return ids, outputs, proof, nil
+}
+
+// getInputs reads the tokens identified by tokenIDs from the ledger and checks that
+// they are owned by this transactor, unspent, of the same type, and that openings opens them.
+// It returns the inputs, their token type, and the sum of their quantities.
+func (t *Transactor) getInputs(tokenIDs [][]byte, openings []*token.TokenOpening) ([]*input, string, uint64, error) {
+	if len(tokenIDs) == 0 {
+		return nil, "", 0, errors.New("no token IDs in request")
+	}
+	if len(openings) != len(tokenIDs) {
+		return nil, "", 0, errors.Errorf("the number of openings (%d) does not match the number of token IDs (%d)", len(openings), len(tokenIDs))
+	}
+
+	var inputs []*input
+	var tokenType string
+	var sum uint64
+	for i, tokenID := range tokenIDs {
+		txID, index, err := parseOutputKey(string(tokenID))
+		if err != nil {
+			return nil, "", 0, err
+		}
+		output, err := getOutput(string(tokenID), t.Ledger)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if output == nil {
+			return nil, "", 0, errors.Errorf("input '%s' does not exist", tokenID)
+		}
+		if !bytes.Equal(output.GetOwner(), t.PublicCredential) {
+			return nil, "", 0, errors.Errorf("the requestor does not own input '%s'", tokenID)
+		}
+		if tokenType == "" {
+			tokenType = output.GetType()
+		} else if tokenType != output.GetType() {
+			return nil, "", 0, errors.Errorf("two or more token types specified in input: '%s', '%s'", tokenType, output.GetType())
+		}
+		spent, err := t.isSpent(txID, index)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if spent {
+			return nil, "", 0, errors.Errorf("input '%s' is already spent", tokenID)
+		}
+		blindingFactor, err := checkOpening(output, openings[i])
+		if err != nil {
+			return nil, "", 0, errors.WithMessage(err, "invalid opening for input '"+string(tokenID)+"'")
+		}
+		if sum+openings[i].GetQuantity() < sum {
+			return nil, "", 0, errors.New("quantity overflow in inputs")
+		}
+		sum += openings[i].GetQuantity()
+		inputs = append(inputs, &input{
+			id:             &token.InputId{TxId: txID, Index: uint32(index)},
+			output:         output,
+			quantity:       openings[i].GetQuantity(),
+			blindingFactor: blindingFactor,
+		})
+	}
+	return inputs, tokenType, sum, nil
+}
+
+// ListTokens returns the unspent tokens owned by this transactor.
+// Their quantities are hidden, so the returned quantities are always 0;
+// the owner recovers them with OpenOutput.
+func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
+	prefix, err := tms.CreatePrefix(confidentialOutput)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, tms.PrefixEnd(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	tokens := make([]*token.TokenOutput, 0)
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return &token.UnspentTokens{Tokens: tokens}, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to retrieve unspent tokens: casting error")
+		}
+		output := &token.ConfidentialOutput{}
+		err = proto.Unmarshal(result.Value, output)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve unspent tokens: unmarshaling error for key %s", result.Key)
+		}
+		if !bytes.Equal(output.GetOwner(), t.PublicCredential) {
+			continue
+		}
+		txID, index, err := parseOutputKey(result.Key)
+		if err != nil {
+			return nil, err
+		}
+		spent, err := t.isSpent(txID, index)
+		if err != nil {
+			return nil, err
+		}
+		if !spent {
+			tokens = append(tokens, &token.TokenOutput{Id: []byte(result.Key), Type: output.GetType()})
+		}
+	}
+}
+
+// GetSpendStatus returns whether the output identified by inputID has been spent.
+// This transactor must be the owner of the output.
+func (t *Transactor) GetSpendStatus(inputID *token.InputId) (*token.SpendStatus, error) {
+	if inputID == nil {
+		return nil, errors.New("no input ID in SpendStatusRequest")
+	}
+	outputID, err := createOutputKey(inputID.TxId, int(inputID.Index))
+	if err != nil {
+		return nil, err
+	}
+	output, err := getOutput(outputID, t.Ledger)
+	if err != nil {
+		return nil, err
+	}
+	if output == nil {
+		return nil, errors.Errorf("output with ID (%s, %d) does not exist", inputID.TxId, inputID.Index)
+	}
+	if !bytes.Equal(output.GetOwner(), t.PublicCredential) {
+		return nil, errors.Errorf("the requestor does not own output with ID (%s, %d)", inputID.TxId, inputID.Index)
+	}
+	spent, err := t.isSpent(inputID.TxId, int(inputID.Index))
+	if err != nil {
+		return nil, err
+	}
+	return &token.SpendStatus{InputId: inputID, Spent: spent}, nil
+}
+
+// GetTokenHistory is not supported by the confidential TMS
+func (t *Transactor) GetTokenHistory(request *token.TokenHistoryRequest) (*token.TokenHistory, error) {
+	return nil, errors.New("token history is not supported by the confidential TMS")
+}
+
+// GetBalance is not supported by the confidential TMS, since quantities are hidden
+func (t *Transactor) GetBalance() (*token.TokenBalances, error) {
+	return nil, errors.New("balances are not supported by the confidential TMS")
+}
+
+// ListTokenTypes is not supported by the confidential TMS
+func (t *Transactor) ListTokenTypes() (*token.TokenTypes, error) {
+	return nil, errors.New("the token type registry is not supported by the confidential TMS")
+}
+
+// RequestApprove is not supported by the confidential TMS
+func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("approve is not supported by the confidential TMS")
+}
+
+// RequestTransferFrom is not supported by the confidential TMS
+func (t *Transactor) RequestTransferFrom(request *token.TransferRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("transferFrom is not supported by the confidential TMS")
+}
+
+// RequestExpectation is not supported by the confidential TMS
+func (t *Transactor) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("expectations are not supported by the confidential TMS")
+}
+
+// RequestSwap is not supported by the confidential TMS
+func (t *Transactor) RequestSwap(request *token.SwapRequest) (*token.TokenTransaction, error) {
+	return nil, errors.New("swaps are not supported by the confidential TMS")
+}
+
+// Done releases any resources held by this transactor
+func (t *Transactor) Done() {
+	if t.Ledger != nil {
+		t.Ledger.Done()
+	}
+}
+
+// isSpent checks whether the output with the passed ID has been spent
+func (t *Transactor) isSpent(txID string, index int) (bool, error) {
+	spentKey, err := createSpentKey(txID, index)
+	if err != nil {
+		return false, err
+	}
+	result, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+	if err != nil {
+		return false, err
+	}
+	return result != nil, nil
+}
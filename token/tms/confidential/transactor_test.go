@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential_test
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+)
+
+var _ = Describe("Transactor", func() {
+	var (
+		memoryLedger *plain.MemoryLedger
+		alice, bob   []byte
+		aliceKey     *ecdsa.PrivateKey
+		transactor   *confidential.Transactor
+		tokenIDs     [][]byte
+		openings     []*token.TokenOpening
+	)
+
+	BeforeEach(func() {
+		memoryLedger = plain.NewMemoryLedger()
+		alice, aliceKey = newOwner("alice")
+		bob, _ = newOwner("bob")
+
+		tt, err := (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: alice, Type: "TOK1", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		verifier := &confidential.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		err = verifier.ProcessTx("import", &mockid.PublicInfo{}, tt, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		transactor = &confidential.Transactor{PublicCredential: alice, Ledger: memoryLedger}
+		tokens, err := transactor.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens.Tokens).To(HaveLen(1))
+		Expect(tokens.Tokens[0].Type).To(Equal("TOK1"))
+		Expect(tokens.Tokens[0].Quantity).To(Equal(uint64(0)))
+		tokenIDs = [][]byte{tokens.Tokens[0].Id}
+
+		value, err := memoryLedger.GetState("tms", string(tokenIDs[0]))
+		Expect(err).NotTo(HaveOccurred())
+		output := &token.ConfidentialOutput{}
+		Expect(proto.Unmarshal(value, output)).To(Succeed())
+		opening, err := confidential.OpenOutput(output, aliceKey)
+		Expect(err).NotTo(HaveOccurred())
+		openings = []*token.TokenOpening{opening}
+	})
+
+	Describe("RequestTransfer", func() {
+		It("fails when the openings are missing", func() {
+			_, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: tokenIDs,
+				Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}},
+			})
+			Expect(err).To(MatchError("the number of openings (0) does not match the number of token IDs (1)"))
+		})
+
+		It("fails when an opening does not open the input", func() {
+			openings[0].Quantity = 1000
+			_, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: tokenIDs,
+				Openings: openings,
+				Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 1000}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("opening does not match the commitment")))
+		})
+
+		It("fails when the requestor does not own the inputs", func() {
+			transactor.PublicCredential = bob
+			_, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: tokenIDs,
+				Openings: openings,
+				Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("the requestor does not own input")))
+		})
+
+		It("fails when the shares do not add up to the inputs", func() {
+			_, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: tokenIDs,
+				Openings: openings,
+				Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 99}},
+			})
+			Expect(err).To(MatchError("token sum mismatch in inputs and shares (100 vs 99)"))
+		})
+
+		It("fails when the token ID is not a confidential output", func() {
+			_, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: [][]byte{[]byte("banana")},
+				Openings: openings,
+				Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 100}},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("RequestRedeem", func() {
+		It("fails when the quantity exceeds the inputs", func() {
+			_, err := transactor.RequestRedeem(&token.RedeemRequest{
+				TokenIds:         tokenIDs,
+				Openings:         openings,
+				QuantityToRedeem: 101,
+			})
+			Expect(err).To(MatchError("total quantity [100] from TokenIds is less than quantity [101] to be redeemed"))
+		})
+
+		It("fails when the quantity is 0", func() {
+			_, err := transactor.RequestRedeem(&token.RedeemRequest{
+				TokenIds: tokenIDs,
+				Openings: openings,
+			})
+			Expect(err).To(MatchError("quantity to redeem [0] must be greater than 0"))
+		})
+	})
+
+	Describe("GetSpendStatus", func() {
+		It("returns whether the output is spent", func() {
+			status, err := transactor.GetSpendStatus(&token.InputId{TxId: "import", Index: 0})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Spent).To(BeFalse())
+		})
+
+		It("fails for outputs of other owners", func() {
+			transactor.PublicCredential = bob
+			_, err := transactor.GetSpendStatus(&token.InputId{TxId: "import", Index: 0})
+			Expect(err).To(MatchError("the requestor does not own output with ID (import, 0)"))
+		})
+	})
+
+	Describe("unsupported operations", func() {
+		It("returns errors", func() {
+			_, err := transactor.GetBalance()
+			Expect(err).To(MatchError("balances are not supported by the confidential TMS"))
+			_, err = transactor.RequestApprove(&token.ApproveRequest{})
+			Expect(err).To(MatchError("approve is not supported by the confidential TMS"))
+			_, err = transactor.RequestTransferFrom(&token.TransferRequest{})
+			Expect(err).To(MatchError("transferFrom is not supported by the confidential TMS"))
+		})
+	})
+})
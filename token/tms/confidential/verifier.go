@@ -0,0 +1,352 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
+
+	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/protos/utils"
+	"github.com/tradeline-tech/fabric/token/identity"
+	"github.com/tradeline-tech/fabric/token/ledger"
+	"github.com/tradeline-tech/fabric/token/tms"
+)
+
+var verifierLogger = flogging.MustGetLogger("token.tms.confidential.verifier")
+
+// TokenInputSpentMarker is the value stored under the spent key of an output
+var TokenInputSpentMarker = []byte{1}
+
+// A Verifier validates and commits confidential token transactions.
+type Verifier struct {
+	IssuingValidator identity.IssuingValidator
+}
+
+// ProcessTx checks that transactions are correct wrt. the most recent ledger state.
+// ProcessTx checks are ones that shall be done sequentially, since transactions within a block may introduce dependencies.
+func (v *Verifier) ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	verifierLogger.Debugf("checking transaction with txID '%s'", txID)
+	action := ttx.GetConfidentialAction()
+	if action == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("check process failed for transaction '%s': missing confidential token action", txID)}
+	}
+
+	err := v.checkAction(creator, action, txID, simulator)
+	if err != nil {
+		return err
+	}
+	err = v.checkTxDoesNotExist(txID, simulator)
+	if err != nil {
+		return err
+	}
+
+	verifierLogger.Debugf("committing transaction with txID '%s'", txID)
+	err = v.commitAction(action, txID, simulator)
+	if err != nil {
+		verifierLogger.Errorf("error committing transaction with txID '%s': %s", txID, err)
+		return err
+	}
+	return v.addTransaction(txID, ttx, simulator)
+}
+
+func (v *Verifier) checkAction(creator identity.PublicInfo, confidentialAction *token.ConfidentialTokenAction, txID string, simulator ledger.LedgerReader) error {
+	switch action := confidentialAction.Data.(type) {
+	case *token.ConfidentialTokenAction_ConfidentialImport:
+		return v.checkImportAction(creator, action.ConfidentialImport, txID, simulator)
+	case *token.ConfidentialTokenAction_ConfidentialTransfer:
+		return v.checkTransferAction(creator, action.ConfidentialTransfer, txID, simulator)
+	case *token.ConfidentialTokenAction_ConfidentialRedeem:
+		return v.checkRedeemAction(creator, action.ConfidentialRedeem, txID, simulator)
+	default:
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown confidential token action: %T", action)}
+	}
+}
+
+func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *token.ConfidentialImport, txID string, simulator ledger.LedgerReader) error {
+	outputs := importAction.GetOutputs()
+	if len(outputs) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+	}
+	tokenType, _, err := v.checkOutputs(outputs, txID, simulator)
+	if err != nil {
+		return err
+	}
+	for _, output := range outputs {
+		err := v.IssuingValidator.Validate(creator, output.GetType())
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import policy check failed: %s", err)}
+		}
+	}
+	return v.checkImportRegistry(creator, tokenType, simulator)
+}
+
+// checkImportRegistry enforces the allowed issuers of the registered token type imported by
+// a confidential import. The supply of a capped token type cannot be tracked when the imported
+// quantities are hidden, hence confidential imports of capped token types are rejected.
+// Token types that are not registered are not subject to these checks.
+func (v *Verifier) checkImportRegistry(creator identity.PublicInfo, tokenType string, simulator ledger.LedgerReader) error {
+	definition, err := tms.GetTokenTypeDefinition(tokenType, simulator)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return nil
+	}
+	if !tms.IsIssuer(creator.Public(), definition) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("creator is not an allowed issuer of token type '%s'", tokenType)}
+	}
+	if definition.GetMaxSupply() != 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type '%s' has a maximum supply and cannot be imported with hidden quantities", tokenType)}
+	}
+	return nil
+}
+
+func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.ConfidentialTransfer, txID string, simulator ledger.LedgerReader) error {
+	if len(transferAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transaction: %s", txID)}
+	}
+	outputType, outputSum, err := v.checkOutputs(transferAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	inputType, inputSum, err := v.checkInputs(creator, transferAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if outputType != inputType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for transfer with ID %s (%s vs %s)", txID, outputType, inputType)}
+	}
+
+	context := balanceContext(transferAction.GetInputs(), transferAction.GetOutputs(), 0)
+	err = verifyBlindingFactor(sub(inputSum, outputSum), context, transferAction.GetBalanceProof())
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid balance proof for transfer with ID %s: %s", txID, err)}
+	}
+	return nil
+}
+
+func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *token.ConfidentialRedeem, txID string, simulator ledger.LedgerReader) error {
+	if redeemAction.GetQuantity() == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("redeemed quantity is 0 in transaction: %s", txID)}
+	}
+	inputType, inputSum, err := v.checkInputs(creator, redeemAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	// the remaining tokens, if any, must go back to the creator
+	outputSum := FP256BN.NewECP()
+	if len(redeemAction.GetOutputs()) > 0 {
+		var outputType string
+		outputType, outputSum, err = v.checkOutputs(redeemAction.GetOutputs(), txID, simulator)
+		if err != nil {
+			return err
+		}
+		if outputType != inputType {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for redeem with ID %s (%s vs %s)", txID, outputType, inputType)}
+		}
+		for _, output := range redeemAction.GetOutputs() {
+			if !bytes.Equal(output.GetOwner(), creator.Public()) {
+				return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens in redeem with ID %s, should be the original owner", txID)}
+			}
+		}
+	}
+
+	redeemed := genG.Mul(bigFromUint64(redeemAction.GetQuantity()))
+	context := balanceContext(redeemAction.GetInputs(), redeemAction.GetOutputs(), redeemAction.GetQuantity())
+	err = verifyBlindingFactor(sub(sub(inputSum, outputSum), redeemed), context, redeemAction.GetBalanceProof())
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid balance proof for redeem with ID %s: %s", txID, err)}
+	}
+	return nil
+}
+
+// checkOutputs checks the outputs of a transaction and returns their token type
+// and the sum of their commitments
+func (v *Verifier) checkOutputs(outputs []*token.ConfidentialOutput, txID string, simulator ledger.LedgerReader) (string, *FP256BN.ECP, error) {
+	tokenType := ""
+	sum := FP256BN.NewECP()
+	for i, output := range outputs {
+		err := v.checkOutputDoesNotExist(i, txID, simulator)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(output.GetOwner()) == 0 {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has no owner in transaction: %s", i, txID)}
+		}
+		if output.GetType() == "" {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has no token type in transaction: %s", i, txID)}
+		}
+		if tokenType == "" {
+			tokenType = output.GetType()
+		} else if tokenType != output.GetType() {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types ('%s', '%s') in outputs for txID '%s'", tokenType, output.GetType(), txID)}
+		}
+		commitment, err := pointFromBytes(output.GetCommitment())
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid commitment in output %d of transaction %s: %s", i, txID, err)}
+		}
+		err = verifyRange(commitment, output.GetRangeProof())
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid range proof in output %d of transaction %s: %s", i, txID, err)}
+		}
+		sum.Add(commitment)
+	}
+	return tokenType, sum, nil
+}
+
+// checkInputs checks that the inputs of a transaction exist, are owned by the creator,
+// have not been spent and have the same type, and returns the sum of their commitments
+func (v *Verifier) checkInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, *FP256BN.ECP, error) {
+	if len(inputIDs) == 0 {
+		return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in transaction: %s", txID)}
+	}
+	tokenType := ""
+	sum := FP256BN.NewECP()
+	processedIDs := make(map[string]bool)
+	for _, id := range inputIDs {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for input: %s", err)}
+		}
+		if processedIDs[inputKey] {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transaction with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+
+		input, err := getOutput(inputKey, simulator)
+		if err != nil {
+			return "", nil, err
+		}
+		if input == nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s does not exist", inputKey)}
+		}
+		if !bytes.Equal(creator.Public(), input.GetOwner()) {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s not owned by creator", inputKey)}
+		}
+		if tokenType == "" {
+			tokenType = input.GetType()
+		} else if tokenType != input.GetType() {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in inputs for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+		}
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return "", nil, err
+		}
+		spent, err := simulator.GetState(tokenNameSpace, spentKey)
+		if err != nil {
+			return "", nil, err
+		}
+		if spent != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s has already been spent", inputKey)}
+		}
+		commitment, err := pointFromBytes(input.GetCommitment())
+		if err != nil {
+			return "", nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid commitment in input %s: %s", inputKey, err)}
+		}
+		sum.Add(commitment)
+	}
+	return tokenType, sum, nil
+}
+
+func (v *Verifier) checkOutputDoesNotExist(index int, txID string, simulator ledger.LedgerReader) error {
+	outputID, err := createOutputKey(txID, index)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+	}
+	existingOutputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return err
+	}
+	if existingOutputBytes != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("output already exists: %s", outputID)}
+	}
+	return nil
+}
+
+func (v *Verifier) checkTxDoesNotExist(txID string, simulator ledger.LedgerReader) error {
+	txKey, err := createTxKey(txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
+	}
+	existingTx, err := simulator.GetState(tokenNameSpace, txKey)
+	if err != nil {
+		return err
+	}
+	if existingTx != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transaction already exists: %s", txID)}
+	}
+	return nil
+}
+
+func (v *Verifier) commitAction(confidentialAction *token.ConfidentialTokenAction, txID string, simulator ledger.LedgerWriter) error {
+	var inputs []*token.InputId
+	var outputs []*token.ConfidentialOutput
+	switch action := confidentialAction.Data.(type) {
+	case *token.ConfidentialTokenAction_ConfidentialImport:
+		outputs = action.ConfidentialImport.GetOutputs()
+	case *token.ConfidentialTokenAction_ConfidentialTransfer:
+		inputs = action.ConfidentialTransfer.GetInputs()
+		outputs = action.ConfidentialTransfer.GetOutputs()
+	case *token.ConfidentialTokenAction_ConfidentialRedeem:
+		inputs = action.ConfidentialRedeem.GetInputs()
+		outputs = action.ConfidentialRedeem.GetOutputs()
+	}
+
+	for i, output := range outputs {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = simulator.SetState(tokenNameSpace, outputID, utils.MarshalOrPanic(output))
+		if err != nil {
+			return err
+		}
+	}
+	for _, id := range inputs {
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+		}
+		verifierLogger.Debugf("marking input '%s' as spent", spentKey)
+		err = simulator.SetState(tokenNameSpace, spentKey, TokenInputSpentMarker)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) addTransaction(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error {
+	ttxID, err := createTxKey(txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating txID: %s", err)}
+	}
+	return simulator.SetState(tokenNameSpace, ttxID, utils.MarshalOrPanic(ttx))
+}
+
+// getOutput returns the output stored under outputID, or nil if it does not exist
+func getOutput(outputID string, simulator ledger.LedgerReader) (*token.ConfidentialOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, nil
+	}
+	output := &token.ConfidentialOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
@@ -0,0 +1,351 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confidential_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/protos/token"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+)
+
+var _ = Describe("Verifier", func() {
+	var (
+		fakeIssuingValidator *mockid.IssuingValidator
+		alicePublicInfo      *mockid.PublicInfo
+		memoryLedger         *plain.MemoryLedger
+
+		alice, bob       []byte
+		aliceKey, bobKey *ecdsa.PrivateKey
+
+		verifier *confidential.Verifier
+	)
+
+	// open returns the openings of the passed unspent tokens of the owner of privateKey
+	open := func(tokenIDs [][]byte, privateKey *ecdsa.PrivateKey) []*token.TokenOpening {
+		var openings []*token.TokenOpening
+		for _, id := range tokenIDs {
+			value, err := memoryLedger.GetState("tms", string(id))
+			Expect(err).NotTo(HaveOccurred())
+			output := &token.ConfidentialOutput{}
+			Expect(proto.Unmarshal(value, output)).To(Succeed())
+			opening, err := confidential.OpenOutput(output, privateKey)
+			Expect(err).NotTo(HaveOccurred())
+			openings = append(openings, opening)
+		}
+		return openings
+	}
+
+	listTokens := func(owner []byte) [][]byte {
+		transactor := &confidential.Transactor{PublicCredential: owner, Ledger: memoryLedger}
+		tokens, err := transactor.ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		var ids [][]byte
+		for _, t := range tokens.Tokens {
+			ids = append(ids, t.Id)
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		fakeIssuingValidator = &mockid.IssuingValidator{}
+		memoryLedger = plain.NewMemoryLedger()
+		alice, aliceKey = newOwner("alice")
+		bob, bobKey = newOwner("bob")
+		alicePublicInfo = &mockid.PublicInfo{}
+		alicePublicInfo.PublicReturns(alice)
+
+		verifier = &confidential.Verifier{IssuingValidator: fakeIssuingValidator}
+
+		issuer := &confidential.Issuer{}
+		tt, err := issuer.RequestImport([]*token.TokenToIssue{
+			{Recipient: alice, Type: "TOK1", Quantity: 100},
+			{Recipient: alice, Type: "TOK1", Quantity: 50},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = verifier.ProcessTx("import", &mockid.PublicInfo{}, tt, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ProcessTx ConfidentialImport", func() {
+		It("evaluates the import policy for each output", func() {
+			Expect(fakeIssuingValidator.ValidateCallCount()).To(Equal(2))
+			_, tokenType := fakeIssuingValidator.ValidateArgsForCall(0)
+			Expect(tokenType).To(Equal("TOK1"))
+		})
+
+		It("stores the outputs", func() {
+			Expect(listTokens(alice)).To(HaveLen(2))
+			Expect(listTokens(bob)).To(BeEmpty())
+		})
+
+		Context("when the import policy check fails", func() {
+			It("returns an InvalidTxError", func() {
+				fakeIssuingValidator.ValidateReturns(errors.New("no way"))
+				tt, err := (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: alice, Type: "TOK1", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("import2", &mockid.PublicInfo{}, tt, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import policy check failed: no way"}))
+			})
+		})
+
+		Context("when the transaction already exists", func() {
+			It("returns an InvalidTxError", func() {
+				tt, err := (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: alice, Type: "TOK1", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("import", &mockid.PublicInfo{}, tt, memoryLedger)
+				Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+			})
+		})
+
+		Context("when a commitment is tampered with", func() {
+			It("rejects the range proof", func() {
+				tt, err := (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{
+					{Recipient: alice, Type: "TOK1", Quantity: 1},
+					{Recipient: alice, Type: "TOK1", Quantity: 2},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				outputs := tt.GetConfidentialAction().GetConfidentialImport().Outputs
+				outputs[0].Commitment = outputs[1].Commitment
+				err = verifier.ProcessTx("import2", &mockid.PublicInfo{}, tt, memoryLedger)
+				Expect(err).To(MatchError(ContainSubstring("invalid range proof in output 0 of transaction import2")))
+			})
+		})
+		Context("when the token type is registered", func() {
+			var (
+				definition *token.TokenTypeDefinition
+				issuerInfo *mockid.PublicInfo
+				tt         *token.TokenTransaction
+			)
+
+			BeforeEach(func() {
+				definition = &token.TokenTypeDefinition{Type: "TOK2", Issuers: [][]byte{[]byte("issuer-1")}}
+				issuerInfo = &mockid.PublicInfo{}
+				issuerInfo.PublicReturns([]byte("issuer-1"))
+
+				var err error
+				tt, err = (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: alice, Type: "TOK2", Quantity: 1}})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				definitionBytes, err := proto.Marshal(definition)
+				Expect(err).NotTo(HaveOccurred())
+				err = memoryLedger.SetState("tms", "\x00tokenType\x00TOK2\x00", definitionBytes)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accepts imports from an allowed issuer", func() {
+				err := verifier.ProcessTx("import2", issuerInfo, tt, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects imports from other creators", func() {
+				err := verifier.ProcessTx("import2", alicePublicInfo, tt, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "creator is not an allowed issuer of token type 'TOK2'"}))
+			})
+
+			Context("when the token type has a maximum supply", func() {
+				BeforeEach(func() {
+					definition.MaxSupply = 100
+				})
+
+				It("rejects imports with hidden quantities", func() {
+					err := verifier.ProcessTx("import2", issuerInfo, tt, memoryLedger)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'TOK2' has a maximum supply and cannot be imported with hidden quantities"}))
+				})
+			})
+		})
+	})
+
+	Describe("ProcessTx ConfidentialTransfer", func() {
+		var (
+			transactor *confidential.Transactor
+			tokenIDs   [][]byte
+		)
+
+		BeforeEach(func() {
+			transactor = &confidential.Transactor{PublicCredential: alice, Ledger: memoryLedger}
+			tokenIDs = listTokens(alice)
+		})
+
+		It("moves the tokens to the recipients", func() {
+			tt, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenIds: tokenIDs,
+				Openings: open(tokenIDs, aliceKey),
+				Shares: []*token.RecipientTransferShare{
+					{Recipient: bob, Quantity: 120},
+					{Recipient: alice, Quantity: 30},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("transfer", alicePublicInfo, tt, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			bobTokens := listTokens(bob)
+			Expect(bobTokens).To(HaveLen(1))
+			Expect(open(bobTokens, bobKey)[0].Quantity).To(Equal(uint64(120)))
+			aliceTokens := listTokens(alice)
+			Expect(aliceTokens).To(HaveLen(1))
+			Expect(open(aliceTokens, aliceKey)[0].Quantity).To(Equal(uint64(30)))
+		})
+
+		Context("when the inputs are spent twice", func() {
+			It("returns an InvalidTxError", func() {
+				request := &token.TransferRequest{
+					TokenIds: tokenIDs,
+					Openings: open(tokenIDs, aliceKey),
+					Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}},
+				}
+				tt1, err := transactor.RequestTransfer(request)
+				Expect(err).NotTo(HaveOccurred())
+				tt2, err := transactor.RequestTransfer(request)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = verifier.ProcessTx("transfer1", alicePublicInfo, tt1, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("transfer2", alicePublicInfo, tt2, memoryLedger)
+				Expect(err).To(MatchError(ContainSubstring("has already been spent")))
+			})
+		})
+
+		Context("when the creator does not own the inputs", func() {
+			It("returns an InvalidTxError", func() {
+				tt, err := transactor.RequestTransfer(&token.TransferRequest{
+					TokenIds: tokenIDs,
+					Openings: open(tokenIDs, aliceKey),
+					Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				bobPublicInfo := &mockid.PublicInfo{}
+				bobPublicInfo.PublicReturns(bob)
+				err = verifier.ProcessTx("transfer", bobPublicInfo, tt, memoryLedger)
+				Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+				Expect(err).To(MatchError(ContainSubstring("not owned by creator")))
+			})
+		})
+
+		Context("when an output is replaced with one of a different quantity", func() {
+			It("rejects the balance proof", func() {
+				tt, err := transactor.RequestTransfer(&token.TransferRequest{
+					TokenIds: tokenIDs,
+					Openings: open(tokenIDs, aliceKey),
+					Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				forged, err := (&confidential.Issuer{}).RequestImport([]*token.TokenToIssue{{Recipient: bob, Type: "TOK1", Quantity: 1000}})
+				Expect(err).NotTo(HaveOccurred())
+				tt.GetConfidentialAction().GetConfidentialTransfer().Outputs[0] = forged.GetConfidentialAction().GetConfidentialImport().Outputs[0]
+
+				err = verifier.ProcessTx("transfer", alicePublicInfo, tt, memoryLedger)
+				Expect(err).To(MatchError(ContainSubstring("invalid balance proof for transfer with ID transfer")))
+			})
+		})
+
+		Context("when the balance proof is missing", func() {
+			It("returns an InvalidTxError", func() {
+				tt, err := transactor.RequestTransfer(&token.TransferRequest{
+					TokenIds: tokenIDs,
+					Openings: open(tokenIDs, aliceKey),
+					Shares:   []*token.RecipientTransferShare{{Recipient: bob, Quantity: 150}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				tt.GetConfidentialAction().GetConfidentialTransfer().BalanceProof = nil
+
+				err = verifier.ProcessTx("transfer", alicePublicInfo, tt, memoryLedger)
+				Expect(err).To(MatchError(ContainSubstring("invalid balance proof for transfer with ID transfer")))
+			})
+		})
+	})
+
+	Describe("ProcessTx ConfidentialRedeem", func() {
+		var (
+			transactor *confidential.Transactor
+			tokenIDs   [][]byte
+		)
+
+		BeforeEach(func() {
+			transactor = &confidential.Transactor{PublicCredential: alice, Ledger: memoryLedger}
+			tokenIDs = listTokens(alice)
+		})
+
+		It("redeems the tokens and returns the remainder to the creator", func() {
+			tt, err := transactor.RequestRedeem(&token.RedeemRequest{
+				TokenIds:         tokenIDs,
+				Openings:         open(tokenIDs, aliceKey),
+				QuantityToRedeem: 140,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt.GetConfidentialAction().GetConfidentialRedeem().Quantity).To(Equal(uint64(140)))
+			err = verifier.ProcessTx("redeem", alicePublicInfo, tt, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			aliceTokens := listTokens(alice)
+			Expect(aliceTokens).To(HaveLen(1))
+			Expect(open(aliceTokens, aliceKey)[0].Quantity).To(Equal(uint64(10)))
+		})
+
+		It("redeems all the tokens", func() {
+			tt, err := transactor.RequestRedeem(&token.RedeemRequest{
+				TokenIds:         tokenIDs,
+				Openings:         open(tokenIDs, aliceKey),
+				QuantityToRedeem: 150,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt.GetConfidentialAction().GetConfidentialRedeem().Outputs).To(BeEmpty())
+			err = verifier.ProcessTx("redeem", alicePublicInfo, tt, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listTokens(alice)).To(BeEmpty())
+		})
+
+		Context("when the redeemed quantity is altered", func() {
+			It("rejects the balance proof", func() {
+				tt, err := transactor.RequestRedeem(&token.RedeemRequest{
+					TokenIds:         tokenIDs,
+					Openings:         open(tokenIDs, aliceKey),
+					QuantityToRedeem: 140,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				tt.GetConfidentialAction().GetConfidentialRedeem().Quantity = 100
+
+				err = verifier.ProcessTx("redeem", alicePublicInfo, tt, memoryLedger)
+				Expect(err).To(MatchError(ContainSubstring("invalid balance proof for redeem with ID redeem")))
+			})
+		})
+
+		Context("when the remainder is not owned by the creator", func() {
+			It("returns an InvalidTxError", func() {
+				tt, err := transactor.RequestRedeem(&token.RedeemRequest{
+					TokenIds:         tokenIDs,
+					Openings:         open(tokenIDs, aliceKey),
+					QuantityToRedeem: 140,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				tt.GetConfidentialAction().GetConfidentialRedeem().Outputs[0].Owner = bob
+
+				err = verifier.ProcessTx("redeem", alicePublicInfo, tt, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "wrong owner for remaining tokens in redeem with ID redeem, should be the original owner"}))
+			})
+		})
+	})
+
+	Context("when the transaction is not confidential", func() {
+		It("returns an InvalidTxError", func() {
+			tt := &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: &token.PlainTokenAction{}}}
+			err := verifier.ProcessTx("plain", alicePublicInfo, tt, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "check process failed for transaction 'plain': missing confidential token action"}))
+		})
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tms
+
+import (
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	minUnicodeRuneValue   = 0            //U+0000
+	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
+	compositeKeyNamespace = "\x00"
+
+	// Namespace is the ledger namespace holding the state of the token management systems
+	Namespace = "tms"
+	// TokenTypeKeyword is the object type of the ledger keys of the token type definitions
+	TokenTypeKeyword = "tokenType"
+)
+
+// CreateTokenTypeKey creates the ledger key for the definition of a registered token type
+func CreateTokenTypeKey(tokenType string) (string, error) {
+	return CreateCompositeKey(TokenTypeKeyword, []string{tokenType})
+}
+
+// CreatePrefix creates the prefix of the ledger keys with the passed object type
+func CreatePrefix(keyword string) (string, error) {
+	return CreateCompositeKey(keyword, nil)
+}
+
+// PrefixEnd returns the exclusive end of the range of the ledger keys starting with prefix
+func PrefixEnd(prefix string) string {
+	return prefix + string(rune(maxUnicodeRuneValue))
+}
+
+// CreateCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
+func CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	if err := validateCompositeKeyAttribute(objectType); err != nil {
+		return "", err
+	}
+	ck := compositeKeyNamespace + objectType + string(rune(minUnicodeRuneValue))
+	for _, att := range attributes {
+		if err := validateCompositeKeyAttribute(att); err != nil {
+			return "", err
+		}
+		ck += att + string(rune(minUnicodeRuneValue))
+	}
+	return ck, nil
+}
+
+func validateCompositeKeyAttribute(str string) error {
+	if !utf8.ValidString(str) {
+		return errors.Errorf("not a valid utf8 string: [%x]", str)
+	}
+	for index, runeValue := range str {
+		if runeValue == minUnicodeRuneValue || runeValue == maxUnicodeRuneValue {
+			return errors.Errorf(`input contain unicode %#U starting at position [%d]. %#U and %#U are not allowed in the input attribute of a composite key`,
+				runeValue, index, minUnicodeRuneValue, maxUnicodeRuneValue)
+		}
+	}
+	return nil
+}
+
+// SplitCompositeKey returns the object type and the attributes of a composite key
+func SplitCompositeKey(compositeKey string) (string, []string, error) {
+	componentIndex := 1
+	components := []string{}
+	for i := 1; i < len(compositeKey); i++ {
+		if compositeKey[i] == minUnicodeRuneValue {
+			components = append(components, compositeKey[componentIndex:i])
+			componentIndex = i + 1
+		}
+	}
+	if len(components) < 2 {
+		return "", nil, errors.New("invalid composite key - no components found")
+	}
+	return components[0], components[1:], nil
+}
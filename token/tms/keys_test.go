@@ -4,7 +4,7 @@ Copyright IBM Corp. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-package plain
+package tms_test
 
 import (
 	"strconv"
@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/tradeline-tech/fabric/core/chaincode/shim"
+	"github.com/tradeline-tech/fabric/token/tms"
 )
 
 var _ = Describe("Composite keys", func() {
@@ -33,7 +34,7 @@ var _ = Describe("Composite keys", func() {
 	Describe("Copied composite keys generator function", func() {
 		Context("when a composite key for an output is generated", func() {
 			It("the output is the same as from the function in the chaincode shim", func() {
-				verifierKey, err := createCompositeKey(namespace, []string{txID, strconv.Itoa(index)})
+				verifierKey, err := tms.CreateCompositeKey(namespace, []string{txID, strconv.Itoa(index)})
 				Expect(err).ToNot(HaveOccurred())
 				shimKey, err := chaincode.CreateCompositeKey(namespace, []string{txID, strconv.Itoa(index)})
 				Expect(err).ToNot(HaveOccurred())
@@ -43,7 +44,7 @@ var _ = Describe("Composite keys", func() {
 
 		Context("when a composite key for a transaction is generated", func() {
 			It("the output is the same as from the function in the chaincode shim", func() {
-				verifierKey, err := createCompositeKey(namespace, []string{txID})
+				verifierKey, err := tms.CreateCompositeKey(namespace, []string{txID})
 				Expect(err).ToNot(HaveOccurred())
 				shimKey, err := chaincode.CreateCompositeKey(namespace, []string{txID})
 				Expect(err).ToNot(HaveOccurred())
@@ -53,9 +54,9 @@ var _ = Describe("Composite keys", func() {
 
 		Context("when a minRune namespace is passed", func() {
 			It("the error string is the same as from the function in the chaincode shim", func() {
-				_, err := createCompositeKey(string(0), []string{txID})
+				_, err := tms.CreateCompositeKey(string(rune(0)), []string{txID})
 				Expect(err).To(HaveOccurred())
-				_, shimErr := chaincode.CreateCompositeKey(string(0), []string{txID})
+				_, shimErr := chaincode.CreateCompositeKey(string(rune(0)), []string{txID})
 				Expect(shimErr).To(HaveOccurred())
 				Expect(err.Error()).To(Equal(shimErr.Error()))
 			})
@@ -63,9 +64,9 @@ var _ = Describe("Composite keys", func() {
 
 		Context("when a minRune txID is passed", func() {
 			It("the error string is the same as from the function in the chaincode shim", func() {
-				_, err := createCompositeKey(namespace, []string{string(0)})
+				_, err := tms.CreateCompositeKey(namespace, []string{string(rune(0))})
 				Expect(err).To(HaveOccurred())
-				_, shimErr := chaincode.CreateCompositeKey(namespace, []string{string(0)})
+				_, shimErr := chaincode.CreateCompositeKey(namespace, []string{string(rune(0))})
 				Expect(shimErr).To(HaveOccurred())
 				Expect(err.Error()).To(Equal(shimErr.Error()))
 			})
@@ -73,7 +74,7 @@ var _ = Describe("Composite keys", func() {
 
 		Context("when a txID with the MSB set is passed", func() {
 			It("the error string is the same as from the function in the chaincode shim", func() {
-				_, err := createCompositeKey(namespace, []string{string([]byte{0x80})})
+				_, err := tms.CreateCompositeKey(namespace, []string{string([]byte{0x80})})
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError("not a valid utf8 string: [80]"))
 				_, shimErr := chaincode.CreateCompositeKey(namespace, []string{string([]byte{0x80})})
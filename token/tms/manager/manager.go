@@ -7,15 +7,25 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"fmt"
+
 	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/msp/mgmt"
 	"github.com/tradeline-tech/fabric/token/identity"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 	"github.com/tradeline-tech/fabric/token/transaction"
 )
 
 //go:generate counterfeiter -o mock/identity_deserializer_manager.go -fake-name DeserializerManager . DeserializerManager
+//go:generate counterfeiter -o mock/capability_checker.go -fake-name CapabilityChecker . CapabilityChecker
+
+// CapabilityChecker is used to check whether or not a channel processes
+// token transactions with the confidential TMS.
+type CapabilityChecker interface {
+	ConfidentialFabToken(channel string) (bool, error)
+}
 
 // FabricIdentityDeserializerManager implements an DeserializerManager
 // by routing the call to the msp/mgmt package
@@ -33,6 +43,9 @@ func (*FabricIdentityDeserializerManager) Deserializer(channel string) (identity
 // Manager is used to access TMS components.
 type Manager struct {
 	IdentityDeserializerManager identity.DeserializerManager
+	// CapabilityChecker selects the confidential TMS for the channels that enable it.
+	// If nil, the plain TMS is used for all channels.
+	CapabilityChecker CapabilityChecker
 }
 
 // GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
@@ -42,5 +55,19 @@ func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, er
 		return nil, errors.Wrapf(err, "failed getting identity deserialiser manager for channel '%s'", channel)
 	}
 
-	return &plain.Verifier{IssuingValidator: &AllIssuingValidator{Deserializer: identityDeserializerManager}}, nil
+	issuingValidator := &AllIssuingValidator{Deserializer: identityDeserializerManager}
+	if m.CapabilityChecker != nil {
+		confidentialTMS, err := m.CapabilityChecker.ConfidentialFabToken(channel)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed checking the confidential FabToken capability for channel '%s'", channel))
+		}
+		if confidentialTMS {
+			return &confidential.Verifier{IssuingValidator: issuingValidator}, nil
+		}
+	}
+	return &plain.Verifier{
+		IssuingValidator:      issuingValidator,
+		RegistrationValidator: &AdminRegistrationValidator{Deserializer: identityDeserializerManager},
+		Deserializer:          identityDeserializerManager,
+	}, nil
 }
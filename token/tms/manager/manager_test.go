@@ -12,7 +12,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/confidential"
 	"github.com/tradeline-tech/fabric/token/tms/manager"
+	mockmanager "github.com/tradeline-tech/fabric/token/tms/manager/mock"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 )
 
@@ -53,7 +55,44 @@ var _ = Describe("Manager", func() {
 				txProcessor, err := mgm.GetTxProcessor(channel)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(txProcessor).NotTo(BeNil())
-				Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+				Expect(txProcessor).To(Equal(&plain.Verifier{
+					IssuingValidator:      &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer},
+					RegistrationValidator: &manager.AdminRegistrationValidator{Deserializer: fakeIdentityDeserializer},
+					Deserializer:          fakeIdentityDeserializer,
+				}))
+			})
+		})
+
+		Describe("Get a TxProcessor for a channel with a capability checker", func() {
+			var fakeCapabilityChecker *mockmanager.CapabilityChecker
+
+			BeforeEach(func() {
+				fakeCapabilityChecker = &mockmanager.CapabilityChecker{}
+				mgm.CapabilityChecker = fakeCapabilityChecker
+			})
+
+			It("returns a plain Verifier when the confidential capability is disabled", func() {
+				txProcessor, err := mgm.GetTxProcessor(channel)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txProcessor).To(Equal(&plain.Verifier{
+					IssuingValidator:      &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer},
+					RegistrationValidator: &manager.AdminRegistrationValidator{Deserializer: fakeIdentityDeserializer},
+					Deserializer:          fakeIdentityDeserializer,
+				}))
+				Expect(fakeCapabilityChecker.ConfidentialFabTokenArgsForCall(0)).To(Equal(channel))
+			})
+
+			It("returns a confidential Verifier when the confidential capability is enabled", func() {
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(true, nil)
+				txProcessor, err := mgm.GetTxProcessor(channel)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txProcessor).To(Equal(&confidential.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+			})
+
+			It("returns an error when the capability check fails", func() {
+				fakeCapabilityChecker.ConfidentialFabTokenReturns(false, errors.New("no config"))
+				_, err := mgm.GetTxProcessor(channel)
+				Expect(err).To(MatchError("failed checking the confidential FabToken capability for channel 'ch0': no config"))
 			})
 		})
 	})
@@ -9,6 +9,8 @@ package manager
 import (
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/utils"
 	"github.com/tradeline-tech/fabric/token/identity"
 )
 
@@ -32,3 +34,30 @@ func (p *AllIssuingValidator) Validate(creator identity.PublicInfo, tokenType st
 
 	return nil
 }
+
+// AdminRegistrationValidator only allows the admins of the organizations of a channel
+// to register token types.
+type AdminRegistrationValidator struct {
+	Deserializer identity.Deserializer
+}
+
+// Validate returns no error if the passed creator can register the passed token type, an error otherwise.
+func (p *AdminRegistrationValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	identity, err := p.Deserializer.DeserializeIdentity(creator.Public())
+	if err != nil {
+		return errors.Wrapf(err, "identity [0x%x] cannot be deserialised", creator.Public())
+	}
+
+	principal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal: utils.MarshalOrPanic(&msp.MSPRole{
+			Role:          msp.MSPRole_ADMIN,
+			MspIdentifier: identity.GetMSPIdentifier(),
+		}),
+	}
+	if err := identity.SatisfiesPrincipal(principal); err != nil {
+		return errors.Wrapf(err, "identity [0x%x] is not an admin", creator.Public())
+	}
+
+	return nil
+}
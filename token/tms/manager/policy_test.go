@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	"github.com/golang/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/protos/msp"
 	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
 	"github.com/tradeline-tech/fabric/token/tms/manager"
 )
@@ -78,3 +80,66 @@ var _ = Describe("AllIssuingValidator", func() {
 
 	})
 })
+
+var _ = Describe("AdminRegistrationValidator", func() {
+	var (
+		fakeCreatorInfo          *mockid.PublicInfo
+		fakeIdentityDeserializer *mockid.Deserializer
+		fakeIdentity             *mockid.Identity
+		registrationValidator    *manager.AdminRegistrationValidator
+	)
+
+	BeforeEach(func() {
+		fakeCreatorInfo = &mockid.PublicInfo{}
+		fakeIdentityDeserializer = &mockid.Deserializer{}
+		fakeIdentity = &mockid.Identity{}
+		fakeIdentity.GetMSPIdentifierReturns("Org1MSP")
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		registrationValidator = &manager.AdminRegistrationValidator{
+			Deserializer: fakeIdentityDeserializer,
+		}
+	})
+
+	Describe("Validate", func() {
+		Context("when the creator is an admin", func() {
+			It("returns no error", func() {
+				err := registrationValidator.Validate(fakeCreatorInfo, "TOK1")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(1))
+				principal := fakeIdentity.SatisfiesPrincipalArgsForCall(0)
+				Expect(principal.PrincipalClassification).To(Equal(msp.MSPPrincipal_ROLE))
+				role := &msp.MSPRole{}
+				Expect(proto.Unmarshal(principal.Principal, role)).To(Succeed())
+				Expect(role.Role).To(Equal(msp.MSPRole_ADMIN))
+				Expect(role.MspIdentifier).To(Equal("Org1MSP"))
+			})
+		})
+
+		Context("when the creator is not an admin", func() {
+			BeforeEach(func() {
+				fakeIdentity.SatisfiesPrincipalReturns(errors.New("not an admin, no-way-man"))
+				fakeCreatorInfo.PublicReturns([]byte{1, 2, 3})
+			})
+
+			It("returns an error", func() {
+				err := registrationValidator.Validate(fakeCreatorInfo, "TOK1")
+				Expect(err).To(MatchError("identity [0x010203] is not an admin: not an admin, no-way-man"))
+			})
+		})
+
+		Context("when the creator cannot be deserialized", func() {
+			BeforeEach(func() {
+				fakeIdentityDeserializer.DeserializeIdentityReturns(nil, errors.New("Deserialize, no-way-man"))
+				fakeCreatorInfo.PublicReturns([]byte{4, 5, 6})
+			})
+
+			It("returns an error", func() {
+				err := registrationValidator.Validate(fakeCreatorInfo, "TOK1")
+				Expect(err).To(MatchError("identity [0x040506] cannot be deserialised: Deserialize, no-way-man"))
+				Expect(fakeIdentity.SatisfiesPrincipalCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+	"github.com/tradeline-tech/fabric/token/ledger"
+)
+
+// A Harness runs the prover, verifier and commit loop of the plain TMS against a ledger,
+// without a peer. It is meant for integration tests of token applications:
+// token transactions are requested from the Issuer and the Transactors of the Harness,
+// and committed to the ledger with Commit.
+type Harness struct {
+	Ledger   ledger.LedgerWriter
+	Verifier *Verifier
+}
+
+// NewHarness creates a Harness that commits to the passed ledger the transactions
+// accepted by a Verifier using the passed validator and deserializer
+func NewHarness(ledger ledger.LedgerWriter, issuingValidator identity.IssuingValidator, deserializer identity.Deserializer) *Harness {
+	return &Harness{
+		Ledger:   ledger,
+		Verifier: &Verifier{IssuingValidator: issuingValidator, Deserializer: deserializer},
+	}
+}
+
+// GetLedgerReader returns the ledger of the Harness for any channel,
+// so that the Harness can be used as the LedgerManager of a token server.
+func (h *Harness) GetLedgerReader(channel string) (ledger.LedgerReader, error) {
+	return h.Ledger, nil
+}
+
+// Issuer returns an Issuer creating the token transactions to commit with the Harness
+func (h *Harness) Issuer() *Issuer {
+	return &Issuer{}
+}
+
+// Transactor returns a Transactor for the owner with the passed public credential
+// that reads the ledger of the Harness and evaluates owners with the deserializer of its Verifier
+func (h *Harness) Transactor(publicCredential []byte) *Transactor {
+	return &Transactor{PublicCredential: publicCredential, Ledger: h.Ledger, Deserializer: h.Verifier.Deserializer}
+}
+
+// A BatchWriter is a ledger that can set several values atomically
+type BatchWriter interface {
+	// WriteBatch sets the values of the passed updates, indexed by namespace and then by key, all at once
+	WriteBatch(updates map[string]map[string][]byte) error
+}
+
+// Commit verifies the passed token transaction created by creator and commits it to the ledger.
+// A transaction that does not pass verification leaves the ledger unchanged. The writes of the
+// transaction are buffered, like by the transaction simulator of a peer, and applied at once
+// if the ledger of the Harness is a BatchWriter.
+func (h *Harness) Commit(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction) error {
+	if ttx == nil {
+		return errors.Errorf("no token transaction to commit with ID %s", txID)
+	}
+	writer := &txWriter{LedgerReader: h.Ledger, updates: make(map[string]map[string][]byte)}
+	err := h.Verifier.ProcessTx(txID, creator, ttx, writer)
+	if err != nil {
+		return err
+	}
+
+	if batchWriter, ok := h.Ledger.(BatchWriter); ok {
+		return batchWriter.WriteBatch(writer.updates)
+	}
+	for namespace, values := range writer.updates {
+		for key, value := range values {
+			err := h.Ledger.SetState(namespace, key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// A txWriter buffers the writes of a transaction. Reads return the state
+// of the ledger before the transaction.
+type txWriter struct {
+	ledger.LedgerReader
+	updates map[string]map[string][]byte
+}
+
+// SetState buffers the given value for the given namespace and key
+func (w *txWriter) SetState(namespace string, key string, value []byte) error {
+	if w.updates[namespace] == nil {
+		w.updates[namespace] = make(map[string][]byte)
+	}
+	w.updates[namespace][key] = value
+	return nil
+}
+
+var _ ledger.LedgerManager = &Harness{}
+var _ ledger.LedgerWriter = &LevelDBLedger{}
+var _ BatchWriter = &LevelDBLedger{}
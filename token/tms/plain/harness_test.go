@@ -0,0 +1,367 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/cauthdsl"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/protos/common"
+	mb "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+)
+
+var _ = Describe("Harness", func() {
+	var (
+		dbPath        string
+		levelDBLedger *plain.LevelDBLedger
+		harness       *plain.Harness
+		issuer        *mockid.PublicInfo
+		alice         *mockid.PublicInfo
+		bob           *mockid.PublicInfo
+	)
+
+	balanceOf := func(owner []byte) []*token.TokenBalance {
+		balances, err := harness.Transactor(owner).GetBalance()
+		Expect(err).NotTo(HaveOccurred())
+		return balances.Balances
+	}
+
+	BeforeEach(func() {
+		var err error
+		dbPath, err = ioutil.TempDir("", "harness")
+		Expect(err).NotTo(HaveOccurred())
+
+		levelDBLedger = plain.NewLevelDBLedger(dbPath)
+		harness = plain.NewHarness(levelDBLedger, &mockid.IssuingValidator{}, &mockid.Deserializer{})
+
+		issuer = &mockid.PublicInfo{}
+		issuer.PublicReturns([]byte("issuer"))
+		alice = &mockid.PublicInfo{}
+		alice.PublicReturns([]byte("alice"))
+		bob = &mockid.PublicInfo{}
+		bob.PublicReturns([]byte("bob"))
+
+		tt, err := harness.Issuer().RequestImport([]*token.TokenToIssue{{Recipient: []byte("alice"), Type: "TOK1", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx1", issuer, tt)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		levelDBLedger.Close()
+		os.RemoveAll(dbPath)
+	})
+
+	It("imports, transfers and redeems tokens", func() {
+		Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 100}}))
+
+		By("transferring tokens from alice to bob")
+		unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unspent.Tokens).To(HaveLen(1))
+		tt, err := harness.Transactor([]byte("alice")).RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{unspent.Tokens[0].Id},
+			Shares: []*token.RecipientTransferShare{
+				{Recipient: []byte("bob"), Quantity: 30},
+				{Recipient: []byte("alice"), Quantity: 70},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx2", alice, tt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 70}}))
+		Expect(balanceOf([]byte("bob"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 30}}))
+
+		By("redeeming part of the tokens of bob")
+		unspent, err = harness.Transactor([]byte("bob")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		tt, err = harness.Transactor([]byte("bob")).RequestRedeem(&token.RedeemRequest{
+			TokenIds:         [][]byte{unspent.Tokens[0].Id},
+			QuantityToRedeem: 10,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx3", bob, tt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanceOf([]byte("bob"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 20}}))
+
+		By("reopening the ledger")
+		levelDBLedger.Close()
+		levelDBLedger = plain.NewLevelDBLedger(dbPath)
+		harness.Ledger = levelDBLedger
+		Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 70}}))
+		Expect(balanceOf([]byte("bob"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 20}}))
+	})
+
+	Context("when a transaction does not pass verification", func() {
+		It("returns an error and leaves the ledger unchanged", func() {
+			unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+			Expect(err).NotTo(HaveOccurred())
+			tt, err := harness.Transactor([]byte("alice")).RequestTransfer(&token.TransferRequest{
+				TokenIds: [][]byte{unspent.Tokens[0].Id},
+				Shares:   []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 100}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = harness.Commit("tx2", bob, tt)
+			Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+			Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 100}}))
+			Expect(balanceOf([]byte("bob"))).To(BeEmpty())
+		})
+	})
+
+	Context("when the ledger is a batch writer", func() {
+		var recorder *batchRecorder
+
+		BeforeEach(func() {
+			recorder = &batchRecorder{LevelDBLedger: levelDBLedger}
+			harness.Ledger = recorder
+		})
+
+		It("applies the writes of a transaction in a single batch", func() {
+			unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+			Expect(err).NotTo(HaveOccurred())
+			tt, err := harness.Transactor([]byte("alice")).RequestTransfer(&token.TransferRequest{
+				TokenIds: [][]byte{unspent.Tokens[0].Id},
+				Shares: []*token.RecipientTransferShare{
+					{Recipient: []byte("bob"), Quantity: 30},
+					{Recipient: []byte("alice"), Quantity: 70},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = harness.Commit("tx2", alice, tt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.batches).To(Equal(1))
+			Expect(recorder.sets).To(Equal(0))
+			Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 70}}))
+			Expect(balanceOf([]byte("bob"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 30}}))
+		})
+
+		Context("when the batch cannot be written", func() {
+			BeforeEach(func() {
+				recorder.err = errors.New("disk full")
+			})
+
+			It("returns the error and leaves the ledger unchanged", func() {
+				unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+				Expect(err).NotTo(HaveOccurred())
+				tt, err := harness.Transactor([]byte("alice")).RequestTransfer(&token.TransferRequest{
+					TokenIds: [][]byte{unspent.Tokens[0].Id},
+					Shares:   []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 100}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = harness.Commit("tx2", alice, tt)
+				Expect(err).To(MatchError("disk full"))
+				Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 100}}))
+				Expect(balanceOf([]byte("bob"))).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the token transaction is missing", func() {
+		It("returns an error", func() {
+			err := harness.Commit("tx2", alice, nil)
+			Expect(err).To(MatchError("no token transaction to commit with ID tx2"))
+		})
+	})
+
+	It("is the ledger manager of any channel", func() {
+		reader, err := harness.GetLedgerReader("mychannel")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader).To(Equal(levelDBLedger))
+	})
+})
+
+var _ = Describe("Harness with multisig and idemix owners", func() {
+	var (
+		harness       *plain.Harness
+		issuer        *mockid.PublicInfo
+		multisigOwner []byte
+	)
+
+	// signatureOf returns the signature the fake identities accept from signer over message
+	signatureOf := func(signer string, message []byte) []byte {
+		return append([]byte(signer+"-signature:"), message...)
+	}
+
+	// endorse adds the signatures of signers to the transfer or redeem of ttx, like the client does
+	endorse := func(ttx *token.TokenTransaction, signers ...string) {
+		var action *token.PlainTransfer
+		var message []byte
+		var err error
+		if action = ttx.GetPlainAction().GetPlainRedeem(); action != nil {
+			message, err = action.RedeemSigningBytes()
+		} else {
+			action = ttx.GetPlainAction().GetPlainTransfer()
+			message, err = action.SigningBytes()
+		}
+		Expect(err).NotTo(HaveOccurred())
+		for _, signer := range signers {
+			action.Signatures = append(action.Signatures, &token.OwnerSignature{Signer: []byte(signer), Signature: signatureOf(signer, message)})
+		}
+	}
+
+	creator := func(id string) *mockid.PublicInfo {
+		publicInfo := &mockid.PublicInfo{}
+		publicInfo.PublicReturns([]byte(id))
+		return publicInfo
+	}
+
+	balanceOf := func(owner []byte) []*token.TokenBalance {
+		balances, err := harness.Transactor(owner).GetBalance()
+		Expect(err).NotTo(HaveOccurred())
+		return balances.Balances
+	}
+
+	BeforeEach(func() {
+		fakeDeserializer := &mockid.Deserializer{}
+		fakeDeserializer.DeserializeIdentityStub = func(raw []byte) (msp.Identity, error) {
+			id := &mockid.Identity{}
+			id.GetIdentifierReturns(&msp.IdentityIdentifier{Mspid: "Org1MSP", Id: string(raw)})
+			id.SatisfiesPrincipalStub = func(principal *mb.MSPPrincipal) error {
+				if !bytes.Equal(principal.Principal, raw) {
+					return errors.New("principal not satisfied")
+				}
+				return nil
+			}
+			id.VerifyStub = func(msg []byte, sig []byte) error {
+				if !bytes.Equal(sig, signatureOf(string(raw), msg)) {
+					return errors.New("bad signature")
+				}
+				return nil
+			}
+			return id, nil
+		}
+		harness = plain.NewHarness(plain.NewMemoryLedger(), &mockid.IssuingValidator{}, fakeDeserializer)
+		issuer = creator("issuer")
+
+		var err error
+		multisigOwner, err = identity.NewMultisigOwner(&common.SignaturePolicyEnvelope{
+			Rule: cauthdsl.NOutOf(2, []*common.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)}),
+			Identities: []*mb.MSPPrincipal{
+				{PrincipalClassification: mb.MSPPrincipal_IDENTITY, Principal: []byte("alice")},
+				{PrincipalClassification: mb.MSPPrincipal_IDENTITY, Principal: []byte("bob")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("takes a multisig-owned token from issue to spend", func() {
+		By("issuing tokens to alice and bob jointly")
+		tt, err := harness.Issuer().RequestImport([]*token.TokenToIssue{{Recipient: multisigOwner, Type: "TOK1", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx1", issuer, tt)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("listing the tokens to each principal of the multisig owner only")
+		unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unspent.Tokens).To(HaveLen(1))
+		Expect(balanceOf([]byte("bob"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 100}}))
+		Expect(balanceOf([]byte("carol"))).To(BeEmpty())
+
+		By("redeeming part of the tokens, with the change going back to the multisig owner")
+		tt, err = harness.Transactor([]byte("alice")).RequestRedeem(&token.RedeemRequest{TokenIds: [][]byte{unspent.Tokens[0].Id}, QuantityToRedeem: 40})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainRedeem().Outputs[1]).To(Equal(&token.PlainOutput{Owner: multisigOwner, Type: "TOK1", Quantity: 60}))
+		endorse(tt, "alice")
+		err = harness.Commit("tx2", creator("alice"), tt)
+		Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+		Expect(err.Error()).To(ContainSubstring("not owned by creator"))
+		endorse(tt, "bob")
+		err = harness.Commit("tx2", creator("alice"), tt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanceOf([]byte("alice"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 60}}))
+
+		By("transferring the rest to carol")
+		unspent, err = harness.Transactor([]byte("bob")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unspent.Tokens).To(HaveLen(1))
+		tt, err = harness.Transactor([]byte("bob")).RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{unspent.Tokens[0].Id},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("carol"), Quantity: 60}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		endorse(tt, "bob", "alice")
+		err = harness.Commit("tx3", creator("bob"), tt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanceOf([]byte("alice"))).To(BeEmpty())
+		Expect(balanceOf([]byte("bob"))).To(BeEmpty())
+		Expect(balanceOf([]byte("carol"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 60}}))
+	})
+
+	It("does not let other parties spend a multisig-owned token", func() {
+		tt, err := harness.Issuer().RequestImport([]*token.TokenToIssue{{Recipient: multisigOwner, Type: "TOK1", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx1", issuer, tt)
+		Expect(err).NotTo(HaveOccurred())
+		unspent, err := harness.Transactor([]byte("alice")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = harness.Transactor([]byte("carol")).RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{unspent.Tokens[0].Id},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("carol"), Quantity: 100}},
+		})
+		Expect(err).To(MatchError("the requestor does not own inputs"))
+	})
+
+	It("takes an idemix-owned token from issue to spend", func() {
+		idemixOwner, err := identity.NewIdemixOwner([]byte("pseudonym"))
+		Expect(err).NotTo(HaveOccurred())
+		tt, err := harness.Issuer().RequestImport([]*token.TokenToIssue{{Recipient: idemixOwner, Type: "TOK1", Quantity: 100}})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx1", issuer, tt)
+		Expect(err).NotTo(HaveOccurred())
+
+		unspent, err := harness.Transactor([]byte("pseudonym")).ListTokens()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unspent.Tokens).To(HaveLen(1))
+		tt, err = harness.Transactor([]byte("pseudonym")).RequestTransfer(&token.TransferRequest{
+			TokenIds: [][]byte{unspent.Tokens[0].Id},
+			Shares:   []*token.RecipientTransferShare{{Recipient: []byte("carol"), Quantity: 100}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = harness.Commit("tx2", creator("pseudonym"), tt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(balanceOf([]byte("pseudonym"))).To(BeEmpty())
+		Expect(balanceOf([]byte("carol"))).To(Equal([]*token.TokenBalance{{Type: "TOK1", Quantity: 100}}))
+	})
+})
+
+// batchRecorder counts the writes to a LevelDBLedger, and fails the batches with err, if set
+type batchRecorder struct {
+	*plain.LevelDBLedger
+	sets    int
+	batches int
+	err     error
+}
+
+func (r *batchRecorder) SetState(namespace string, key string, value []byte) error {
+	r.sets++
+	return r.LevelDBLedger.SetState(namespace, key, value)
+}
+
+func (r *batchRecorder) WriteBatch(updates map[string]map[string][]byte) error {
+	r.batches++
+	if r.err != nil {
+		return r.err
+	}
+	return r.LevelDBLedger.WriteBatch(updates)
+}
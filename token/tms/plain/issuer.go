@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package plain
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/tradeline-tech/fabric/protos/token"
 )
 
@@ -40,5 +42,60 @@ func (i *Issuer) RequestImport(tokensToIssue []*token.TokenToIssue) (*token.Toke
 // RequestExpectation allows indirect import based on the expectation.
 // It creates a token transaction with the outputs as specified in the expectation.
 func (i *Issuer) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
-	panic("not implemented yet")
+	if request.GetExpectation() == nil {
+		return nil, errors.New("no token expectation in ExpectationRequest")
+	}
+	if request.GetExpectation().GetPlainExpectation() == nil {
+		return nil, errors.New("no plain expectation in ExpectationRequest")
+	}
+	if request.GetExpectation().GetPlainExpectation().GetImportExpectation() == nil {
+		return nil, errors.New("no import expectation in ExpectationRequest")
+	}
+
+	outputs := request.GetExpectation().GetPlainExpectation().GetImportExpectation().GetOutputs()
+	if len(outputs) == 0 {
+		return nil, errors.New("no outputs in ExpectationRequest")
+	}
+	for _, output := range outputs {
+		if len(output.GetOwner()) == 0 {
+			return nil, errors.New("the owner of an expected output must be specified")
+		}
+		if output.GetQuantity() == 0 {
+			return nil, errors.New("the quantity of an expected output must be greater than 0")
+		}
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainImport{
+					PlainImport: &token.PlainImport{
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// RequestRegisterTokenType creates a token transaction that registers the token type described by definition.
+func (i *Issuer) RequestRegisterTokenType(definition *token.TokenTypeDefinition) (*token.TokenTransaction, error) {
+	if definition == nil {
+		return nil, errors.New("no token type definition in RegisterTokenTypeRequest")
+	}
+	if definition.GetType() == "" {
+		return nil, errors.New("the token type of a token type definition must be specified")
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTypeRegistration{
+					PlainTypeRegistration: &token.PlainTypeRegistration{
+						Definition: definition,
+					},
+				},
+			},
+		},
+	}, nil
 }
@@ -78,4 +78,114 @@ var _ = Describe("Issuer", func() {
 			}))
 		})
 	})
+
+	Describe("RequestExpectation", func() {
+		var (
+			outputs            []*token.PlainOutput
+			expectationRequest *token.ExpectationRequest
+		)
+
+		BeforeEach(func() {
+			outputs = []*token.PlainOutput{
+				{Owner: []byte("R1"), Type: "TOK1", Quantity: 1001},
+				{Owner: []byte("R2"), Type: "TOK2", Quantity: 1002},
+			}
+			expectationRequest = &token.ExpectationRequest{
+				Expectation: &token.TokenExpectation{
+					Expectation: &token.TokenExpectation_PlainExpectation{
+						PlainExpectation: &token.PlainExpectation{
+							Payload: &token.PlainExpectation_ImportExpectation{
+								ImportExpectation: &token.PlainTokenExpectation{Outputs: outputs},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("converts an import expectation to a token transaction", func() {
+			tt, err := issuer.RequestExpectation(expectationRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{Outputs: outputs},
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when the expectation is missing", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no token expectation in ExpectationRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when the expectation is a transfer expectation", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation.GetPlainExpectation().Payload = &token.PlainExpectation_TransferExpectation{
+					TransferExpectation: &token.PlainTokenExpectation{Outputs: outputs},
+				}
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no import expectation in ExpectationRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when the expectation has no outputs", func() {
+			It("returns an error", func() {
+				expectationRequest.Expectation.GetPlainExpectation().GetImportExpectation().Outputs = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("no outputs in ExpectationRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when an expected output has no owner", func() {
+			It("returns an error", func() {
+				outputs[1].Owner = nil
+				tt, err := issuer.RequestExpectation(expectationRequest)
+				Expect(err).To(MatchError("the owner of an expected output must be specified"))
+				Expect(tt).To(BeNil())
+			})
+		})
+	})
+
+	Describe("RequestRegisterTokenType", func() {
+		It("creates a token type registration transaction", func() {
+			definition := &token.TokenTypeDefinition{Type: "TOK1", DisplayName: "Token One", Decimals: 2, MaxSupply: 100}
+			tt, err := issuer.RequestRegisterTokenType(definition)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTypeRegistration{
+							PlainTypeRegistration: &token.PlainTypeRegistration{Definition: definition},
+						},
+					},
+				},
+			}))
+		})
+
+		Context("when the definition is missing", func() {
+			It("returns an error", func() {
+				tt, err := issuer.RequestRegisterTokenType(nil)
+				Expect(err).To(MatchError("no token type definition in RegisterTokenTypeRequest"))
+				Expect(tt).To(BeNil())
+			})
+		})
+
+		Context("when the token type is missing", func() {
+			It("returns an error", func() {
+				tt, err := issuer.RequestRegisterTokenType(&token.TokenTypeDefinition{DisplayName: "Token One"})
+				Expect(err).To(MatchError("the token type of a token type definition must be specified"))
+				Expect(tt).To(BeNil())
+			})
+		})
+	})
 })
@@ -7,7 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package plain
 
 import (
+	"sort"
+
 	"github.com/tradeline-tech/fabric/common/ledger"
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 )
 
 // A MemoryLedger is an in-memory ledger of transactions and unspent outputs.
@@ -38,12 +41,44 @@ func (p *MemoryLedger) SetState(namespace string, key string, value []byte) erro
 }
 
 // GetStateRangeScanIterator gets the values for a given namespace that lie in an interval determined by startKey and endKey.
-// this is a mock function.
+// startKey is included in the results and endKey is excluded; an empty endKey refers to the last available Key.
 func (p *MemoryLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (ledger.ResultsIterator, error) {
-	return nil, nil
+	var keys []string
+	for key := range p.entries {
+		if key >= startKey && (endKey == "" || key < endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	results := make([]*queryresult.KV, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, &queryresult.KV{Namespace: namespace, Key: key, Value: p.entries[key]})
+	}
+	return &memoryResultsIterator{results: results}, nil
 }
 
 // Done releases resources occupied by the MemoryLedger
 func (p *MemoryLedger) Done() {
 	// No resources to be released for MemoryLedger
 }
+
+// memoryResultsIterator iterates over a snapshot of the entries of a MemoryLedger
+type memoryResultsIterator struct {
+	results []*queryresult.KV
+}
+
+// Next returns the next KV in the snapshot, or nil when the snapshot is exhausted
+func (it *memoryResultsIterator) Next() (ledger.QueryResult, error) {
+	if len(it.results) == 0 {
+		return nil, nil
+	}
+	next := it.results[0]
+	it.results = it.results[1:]
+	return next, nil
+}
+
+// Close releases the snapshot
+func (it *memoryResultsIterator) Close() {
+	it.results = nil
+}
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 )
 
@@ -73,11 +74,47 @@ var _ = Describe("MemoryLedger", func() {
 		})
 	})
 
-	Describe("when the dummy function is invoked", func() {
-		It("returns nil", func() {
-			res, err := memoryLedger.GetStateRangeScanIterator("", "", "")
-			Expect(res).To(BeNil())
-			Expect(err).To(BeNil())
+	Describe("GetStateRangeScanIterator", func() {
+		BeforeEach(func() {
+			for _, key := range []string{"c", "a", "d", "b"} {
+				err := memoryLedger.SetState(namespace, key, []byte(key))
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns the entries in the range sorted by key", func() {
+			it, err := memoryLedger.GetStateRangeScanIterator(namespace, "b", "d")
+			Expect(err).NotTo(HaveOccurred())
+			defer it.Close()
+
+			next, err := it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(&queryresult.KV{Namespace: namespace, Key: "b", Value: []byte("b")}))
+			next, err = it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(&queryresult.KV{Namespace: namespace, Key: "c", Value: []byte("c")}))
+			next, err = it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeNil())
+		})
+
+		Context("when the range is open", func() {
+			It("returns all the entries", func() {
+				it, err := memoryLedger.GetStateRangeScanIterator(namespace, "", "")
+				Expect(err).NotTo(HaveOccurred())
+				defer it.Close()
+
+				var keys []string
+				for {
+					next, err := it.Next()
+					Expect(err).NotTo(HaveOccurred())
+					if next == nil {
+						break
+					}
+					keys = append(keys, next.(*queryresult.KV).Key)
+				}
+				Expect(keys).To(Equal([]string{"a", "b", "c", "d"}))
+			})
 		})
 	})
 })
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+
+	"github.com/tradeline-tech/fabric/common/ledger"
+	"github.com/tradeline-tech/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
+)
+
+// A LevelDBLedger is a ledger of transactions and unspent outputs persisted in a LevelDB database.
+// The keys of each namespace are prefixed with the namespace and the byte 0x00, so namespaces must
+// not contain the byte 0x00. This implementation is meant for testing token applications without a peer.
+type LevelDBLedger struct {
+	db *leveldbhelper.DB
+}
+
+// NewLevelDBLedger opens, or creates, the LevelDBLedger stored in the directory dbPath
+func NewLevelDBLedger(dbPath string) *LevelDBLedger {
+	db := leveldbhelper.CreateDB(&leveldbhelper.Conf{DBPath: dbPath})
+	db.Open()
+	return &LevelDBLedger{db: db}
+}
+
+// GetState gets the value for given namespace and Key. For a chaincode, the namespace corresponds to the chaincodeID
+func (l *LevelDBLedger) GetState(namespace string, key string) ([]byte, error) {
+	value, err := l.db.Get(levelKey(namespace, key))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting state for key [%s] in namespace [%s]", key, namespace)
+	}
+	return value, nil
+}
+
+// SetState sets the given value for the given namespace and Key. For a chaincode, the namespace corresponds to the chaincodeID
+func (l *LevelDBLedger) SetState(namespace string, key string, value []byte) error {
+	err := l.db.Put(levelKey(namespace, key), value, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed setting state for key [%s] in namespace [%s]", key, namespace)
+	}
+	return nil
+}
+
+// WriteBatch sets atomically the values of the passed updates, indexed by namespace and then by Key
+func (l *LevelDBLedger) WriteBatch(updates map[string]map[string][]byte) error {
+	batch := &leveldb.Batch{}
+	for namespace, values := range updates {
+		for key, value := range values {
+			batch.Put(levelKey(namespace, key), value)
+		}
+	}
+	return l.db.WriteBatch(batch, true)
+}
+
+// GetStateRangeScanIterator gets the values for a given namespace that lie in an interval determined by startKey and endKey.
+// startKey is included in the results and endKey is excluded; an empty endKey refers to the last available Key.
+func (l *LevelDBLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (ledger.ResultsIterator, error) {
+	end := levelKey(namespace, endKey)
+	if endKey == "" {
+		// the separator following the namespace is replaced by the next byte
+		end[len(end)-1] = 0x01
+	}
+	return &levelDBResultsIterator{
+		namespace: namespace,
+		itr:       l.db.GetIterator(levelKey(namespace, startKey), end),
+	}, nil
+}
+
+// Done releases resources occupied by a reader of the LevelDBLedger.
+// The LevelDBLedger stays open until Close is called, so that it can be shared by several readers.
+func (l *LevelDBLedger) Done() {
+	// No resources to be released for a reader of LevelDBLedger
+}
+
+// Close closes the underlying LevelDB database
+func (l *LevelDBLedger) Close() {
+	l.db.Close()
+}
+
+// levelDBResultsIterator iterates over the entries of a namespace of a LevelDBLedger
+type levelDBResultsIterator struct {
+	namespace string
+	itr       iterator.Iterator
+}
+
+// Next returns the next KV in the range, or nil when the range is exhausted
+func (it *levelDBResultsIterator) Next() (ledger.QueryResult, error) {
+	if !it.itr.Next() {
+		if err := it.itr.Error(); err != nil {
+			return nil, errors.Wrapf(err, "failed iterating over namespace [%s]", it.namespace)
+		}
+		return nil, nil
+	}
+	// the iterator reuses its buffers, so the key and the value are copied
+	value := make([]byte, len(it.itr.Value()))
+	copy(value, it.itr.Value())
+	key := string(it.itr.Key()[len(it.namespace)+1:])
+	return &queryresult.KV{Namespace: it.namespace, Key: key, Value: value}, nil
+}
+
+// Close releases the underlying LevelDB iterator
+func (it *levelDBResultsIterator) Close() {
+	it.itr.Release()
+}
+
+// levelKey returns the key of the LevelDB database under which the passed Key of the namespace is stored
+func levelKey(namespace string, key string) []byte {
+	return append([]byte(namespace+"\x00"), key...)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+)
+
+var _ = Describe("LevelDBLedger", func() {
+	var (
+		dbPath    string
+		namespace string
+
+		levelDBLedger *plain.LevelDBLedger
+	)
+
+	BeforeEach(func() {
+		var err error
+		dbPath, err = ioutil.TempDir("", "leveldbledger")
+		Expect(err).NotTo(HaveOccurred())
+
+		namespace = "ledgerNamespace"
+		levelDBLedger = plain.NewLevelDBLedger(dbPath)
+	})
+
+	AfterEach(func() {
+		levelDBLedger.Close()
+		os.RemoveAll(dbPath)
+	})
+
+	Describe("get and set", func() {
+		It("sets state", func() {
+			err := levelDBLedger.SetState(namespace, "1", []byte{1})
+			Expect(err).NotTo(HaveOccurred())
+
+			value, err := levelDBLedger.GetState(namespace, "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte{1}))
+		})
+
+		It("keeps namespaces separate", func() {
+			err := levelDBLedger.SetState(namespace, "1", []byte{1})
+			Expect(err).NotTo(HaveOccurred())
+
+			value, err := levelDBLedger.GetState("otherNamespace", "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(BeNil())
+		})
+
+		Context("when the ledger is reopened", func() {
+			It("retains the state", func() {
+				err := levelDBLedger.SetState(namespace, "1", []byte{1})
+				Expect(err).NotTo(HaveOccurred())
+
+				levelDBLedger.Close()
+				levelDBLedger = plain.NewLevelDBLedger(dbPath)
+
+				value, err := levelDBLedger.GetState(namespace, "1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal([]byte{1}))
+			})
+		})
+
+		Context("when an entry does not exist", func() {
+			It("returns nil", func() {
+				value, err := levelDBLedger.GetState(namespace, "badTxID")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(BeNil())
+			})
+		})
+	})
+
+	Describe("WriteBatch", func() {
+		It("sets the values of all the namespaces", func() {
+			err := levelDBLedger.WriteBatch(map[string]map[string][]byte{
+				namespace:        {"1": []byte{1}, "2": []byte{2}},
+				"otherNamespace": {"1": []byte{3}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, kv := range []*queryresult.KV{
+				{Namespace: namespace, Key: "1", Value: []byte{1}},
+				{Namespace: namespace, Key: "2", Value: []byte{2}},
+				{Namespace: "otherNamespace", Key: "1", Value: []byte{3}},
+			} {
+				value, err := levelDBLedger.GetState(kv.Namespace, kv.Key)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(value).To(Equal(kv.Value))
+			}
+		})
+	})
+
+	Describe("GetStateRangeScanIterator", func() {
+		BeforeEach(func() {
+			for _, key := range []string{"c", "a", "d", "b", "\x00tokenOutput\x001\x000\x00"} {
+				err := levelDBLedger.SetState(namespace, key, []byte(key))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			err := levelDBLedger.SetState("otherNamespace", "e", []byte("e"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the entries in the range sorted by key", func() {
+			it, err := levelDBLedger.GetStateRangeScanIterator(namespace, "b", "d")
+			Expect(err).NotTo(HaveOccurred())
+			defer it.Close()
+
+			next, err := it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(&queryresult.KV{Namespace: namespace, Key: "b", Value: []byte("b")}))
+			next, err = it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(&queryresult.KV{Namespace: namespace, Key: "c", Value: []byte("c")}))
+			next, err = it.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeNil())
+		})
+
+		Context("when the range is open", func() {
+			It("returns all the entries of the namespace", func() {
+				it, err := levelDBLedger.GetStateRangeScanIterator(namespace, "", "")
+				Expect(err).NotTo(HaveOccurred())
+				defer it.Close()
+
+				var keys []string
+				for {
+					next, err := it.Next()
+					Expect(err).NotTo(HaveOccurred())
+					if next == nil {
+						break
+					}
+					keys = append(keys, next.(*queryresult.KV).Key)
+				}
+				Expect(keys).To(Equal([]string{"\x00tokenOutput\x001\x000\x00", "a", "b", "c", "d"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/common/cauthdsl"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+)
+
+// ownershipProof is what the creator of a token transaction presents to spend the
+// inputs of an action: its own identity and the signatures of other owners over the action.
+type ownershipProof struct {
+	creator    identity.PublicInfo
+	message    []byte
+	signatures []*token.OwnerSignature
+	// signers is the set of the serialized identities whose signature has been verified
+	signers map[string]bool
+}
+
+// newOwnershipProof verifies the signatures over message and returns the resulting ownershipProof;
+// actionName is used in the error messages.
+func (v *Verifier) newOwnershipProof(creator identity.PublicInfo, actionName string, message []byte, signatures []*token.OwnerSignature, txID string) (*ownershipProof, error) {
+	proof := &ownershipProof{
+		creator:    creator,
+		message:    message,
+		signatures: signatures,
+		signers:    make(map[string]bool),
+	}
+	if len(signatures) == 0 {
+		return proof, nil
+	}
+	if v.Deserializer == nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("cannot verify the signatures of %s with ID %s: no deserializer", actionName, txID)}
+	}
+	for i, signature := range signatures {
+		signer, err := v.Deserializer.DeserializeIdentity(signature.GetSigner())
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("signer %d of %s with ID %s cannot be deserialized: %s", i, actionName, txID, err)}
+		}
+		if err := signer.Validate(); err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("signer %d of %s with ID %s is not valid: %s", i, actionName, txID, err)}
+		}
+		if err := signer.Verify(message, signature.GetSignature()); err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid signature %d in %s with ID %s: %s", i, actionName, txID, err)}
+		}
+		proof.signers[string(signature.GetSigner())] = true
+	}
+	return proof, nil
+}
+
+// isOwner returns true if proof proves the ownership of the outputs owned by owner.
+// An error is returned if the owner cannot be evaluated.
+func (v *Verifier) isOwner(owner []byte, proof *ownershipProof) (bool, error) {
+	tokenOwner := identity.UnmarshalOwner(owner)
+	switch tokenOwner.Type {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		return bytes.Equal(proof.creator.Public(), owner) || proof.signers[string(owner)], nil
+
+	case token.TokenOwner_IDEMIX:
+		// the pseudonym must be the creator or a signer, and be valid for its idemix MSP
+		if !bytes.Equal(proof.creator.Public(), tokenOwner.Raw) && !proof.signers[string(tokenOwner.Raw)] {
+			return false, nil
+		}
+		if v.Deserializer == nil {
+			return false, errors.New("no deserializer for idemix owners")
+		}
+		pseudonym, err := v.Deserializer.DeserializeIdentity(tokenOwner.Raw)
+		if err != nil {
+			return false, errors.WithMessage(err, "idemix owner cannot be deserialized")
+		}
+		return pseudonym.Validate() == nil, nil
+
+	case token.TokenOwner_MULTISIG:
+		if v.Deserializer == nil {
+			return false, errors.New("no deserializer for multisig owners")
+		}
+		policyProvider := cauthdsl.NewPolicyProvider(&identity.PolicyDeserializer{Deserializer: v.Deserializer})
+		policy, _, err := policyProvider.NewPolicy(tokenOwner.Raw)
+		if err != nil {
+			return false, errors.WithMessage(err, "invalid multisig owner")
+		}
+		signedData := make([]*common.SignedData, len(proof.signatures))
+		for i, signature := range proof.signatures {
+			signedData[i] = &common.SignedData{
+				Data:      proof.message,
+				Identity:  signature.GetSigner(),
+				Signature: signature.GetSignature(),
+			}
+		}
+		return policy.Evaluate(signedData) == nil, nil
+
+	default:
+		return false, errors.Errorf("unknown owner type %d", tokenOwner.Type)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tradeline-tech/fabric/common/cauthdsl"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/msp"
+	"github.com/tradeline-tech/fabric/protos/common"
+	mb "github.com/tradeline-tech/fabric/protos/msp"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
+)
+
+var _ = Describe("Verifier with owner types", func() {
+	var (
+		memoryLedger     *plain.MemoryLedger
+		fakeDeserializer *mockid.Deserializer
+		invalidIdentity  []byte
+		creator          *mockid.PublicInfo
+		multisigOwner    []byte
+		idemixOwner      []byte
+		verifier         *plain.Verifier
+	)
+
+	// signatureOf returns the signature the fake identities accept from signer over message
+	signatureOf := func(signer string, message []byte) []byte {
+		return append([]byte(signer+"-signature:"), message...)
+	}
+
+	signed := func(plainTransfer *token.PlainTransfer, message []byte, signers ...string) {
+		for _, signer := range signers {
+			plainTransfer.Signatures = append(plainTransfer.Signatures, &token.OwnerSignature{Signer: []byte(signer), Signature: signatureOf(signer, message)})
+		}
+	}
+
+	transfer := func(inputIndex uint32, outputs []*token.PlainOutput, signers ...string) *token.TokenTransaction {
+		plainTransfer := &token.PlainTransfer{
+			Inputs:  []*token.InputId{{TxId: "0", Index: inputIndex}},
+			Outputs: outputs,
+		}
+		message, err := plainTransfer.SigningBytes()
+		Expect(err).NotTo(HaveOccurred())
+		signed(plainTransfer, message, signers...)
+		return &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: plainTransfer},
+				},
+			},
+		}
+	}
+
+	redeem := func(inputIndex uint32, outputs []*token.PlainOutput, signers ...string) *token.TokenTransaction {
+		plainRedeem := &token.PlainTransfer{
+			Inputs:  []*token.InputId{{TxId: "0", Index: inputIndex}},
+			Outputs: outputs,
+		}
+		message, err := plainRedeem.RedeemSigningBytes()
+		Expect(err).NotTo(HaveOccurred())
+		signed(plainRedeem, message, signers...)
+		return &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: plainRedeem},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		invalidIdentity = nil
+		fakeDeserializer = &mockid.Deserializer{}
+		fakeDeserializer.DeserializeIdentityStub = func(raw []byte) (msp.Identity, error) {
+			id := &mockid.Identity{}
+			id.GetIdentifierReturns(&msp.IdentityIdentifier{Mspid: "Org1MSP", Id: string(raw)})
+			id.SatisfiesPrincipalStub = func(principal *mb.MSPPrincipal) error {
+				if !bytes.Equal(principal.Principal, raw) {
+					return errors.New("principal not satisfied")
+				}
+				return nil
+			}
+			id.VerifyStub = func(msg []byte, sig []byte) error {
+				if !bytes.Equal(sig, signatureOf(string(raw), msg)) {
+					return errors.New("bad signature")
+				}
+				return nil
+			}
+			if bytes.Equal(raw, invalidIdentity) {
+				id.ValidateReturns(errors.New("invalid identity"))
+			}
+			return id, nil
+		}
+
+		var err error
+		multisigOwner, err = identity.NewMultisigOwner(&common.SignaturePolicyEnvelope{
+			Rule: cauthdsl.NOutOf(2, []*common.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(1), cauthdsl.SignedBy(2)}),
+			Identities: []*mb.MSPPrincipal{
+				{PrincipalClassification: mb.MSPPrincipal_IDENTITY, Principal: []byte("alice")},
+				{PrincipalClassification: mb.MSPPrincipal_IDENTITY, Principal: []byte("bob")},
+				{PrincipalClassification: mb.MSPPrincipal_IDENTITY, Principal: []byte("carol")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		idemixOwner, err = identity.NewIdemixOwner([]byte("pseudonym"))
+		Expect(err).NotTo(HaveOccurred())
+
+		verifier = &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}, Deserializer: fakeDeserializer}
+		memoryLedger = plain.NewMemoryLedger()
+		err = verifier.ProcessTx("0", &mockid.PublicInfo{}, &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{
+							Outputs: []*token.PlainOutput{
+								{Owner: multisigOwner, Type: "TOK1", Quantity: 100},
+								{Owner: idemixOwner, Type: "TOK1", Quantity: 50},
+							},
+						},
+					},
+				},
+			},
+		}, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		creator = &mockid.PublicInfo{}
+		creator.PublicReturns([]byte("alice"))
+	})
+
+	Describe("multisig owners", func() {
+		It("accepts a transfer signed by enough owners", func() {
+			tx := transfer(0, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 100}}, "alice", "carol")
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a transfer signed by too few owners", func() {
+			tx := transfer(0, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 100}}, "alice")
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x000\x00 not owned by creator"}))
+		})
+
+		It("rejects a transfer whose signatures are not valid", func() {
+			tx := transfer(0, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 100}}, "alice", "bob")
+			tx.GetPlainAction().GetPlainTransfer().Signatures[1].Signature = []byte("forged")
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature 1 in transfer with ID 1: bad signature"}))
+		})
+
+		It("accepts a redeem returning the change to the multisig owner", func() {
+			tx := redeem(0, []*token.PlainOutput{
+				{Type: "TOK1", Quantity: 40},
+				{Owner: multisigOwner, Type: "TOK1", Quantity: 60},
+			}, "alice", "bob")
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a redeem carrying the signatures of a transfer", func() {
+			tx := transfer(0, []*token.PlainOutput{
+				{Type: "TOK1", Quantity: 40},
+				{Owner: multisigOwner, Type: "TOK1", Quantity: 60},
+			}, "alice", "bob")
+			tx.GetPlainAction().Data = &token.PlainTokenAction_PlainRedeem{PlainRedeem: tx.GetPlainAction().GetPlainTransfer()}
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature 0 in redeem with ID 1: bad signature"}))
+		})
+
+		Context("when the policy of the owner is malformed", func() {
+			BeforeEach(func() {
+				var err error
+				multisigOwner, err = identity.MarshalOwner(&token.TokenOwner{Type: token.TokenOwner_MULTISIG, Raw: []byte("garbage")})
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("2", &mockid.PublicInfo{}, &token.TokenTransaction{
+					Action: &token.TokenTransaction_PlainAction{
+						PlainAction: &token.PlainTokenAction{
+							Data: &token.PlainTokenAction_PlainImport{
+								PlainImport: &token.PlainImport{Outputs: []*token.PlainOutput{{Owner: multisigOwner, Type: "TOK1", Quantity: 1}}},
+							},
+						},
+					},
+				}, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an InvalidTxError", func() {
+				tx := transfer(0, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 1}}, "alice", "bob")
+				plainTransfer := tx.GetPlainAction().GetPlainTransfer()
+				plainTransfer.Inputs[0].TxId = "2"
+				plainTransfer.Signatures = nil
+				message, err := plainTransfer.SigningBytes()
+				Expect(err).NotTo(HaveOccurred())
+				signed(plainTransfer, message, "alice", "bob")
+				err = verifier.ProcessTx("3", creator, tx, memoryLedger)
+				Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+				Expect(err.Error()).To(ContainSubstring("cannot check the owner of transfer input with ID \x00tokenOutput\x002\x000\x00: invalid multisig owner"))
+			})
+		})
+	})
+
+	Describe("idemix owners", func() {
+		It("accepts a transfer created by the pseudonym", func() {
+			creator.PublicReturns([]byte("pseudonym"))
+			tx := transfer(1, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 50}})
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("pseudonym")))
+		})
+
+		It("accepts a transfer signed by the pseudonym", func() {
+			tx := transfer(1, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 50}}, "pseudonym")
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a transfer that the pseudonym did not authorize", func() {
+			tx := transfer(1, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 50}})
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x001\x00 not owned by creator"}))
+		})
+
+		It("rejects a transfer by a pseudonym that is not valid", func() {
+			invalidIdentity = []byte("pseudonym")
+			creator.PublicReturns([]byte("pseudonym"))
+			tx := transfer(1, []*token.PlainOutput{{Owner: []byte("dave"), Type: "TOK1", Quantity: 50}})
+			err := verifier.ProcessTx("1", creator, tx, memoryLedger)
+			Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transfer input with ID \x00tokenOutput\x000\x001\x00 not owned by creator"}))
+		})
+	})
+})
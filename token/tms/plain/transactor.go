@@ -9,21 +9,28 @@ package plain
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/protos/common"
 	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
 	"github.com/tradeline-tech/fabric/token/ledger"
+	"github.com/tradeline-tech/fabric/token/tms"
 )
 
 // A Transactor that can transfer tokens.
 type Transactor struct {
 	PublicCredential []byte
 	Ledger           ledger.LedgerReader
+	// Deserializer is used to find the multisig owners that accept the signature
+	// of the requestor; the tokens of multisig owners cannot be spent if it is nil
+	Deserializer identity.Deserializer
 }
 
 // RequestTransfer creates a TokenTransaction of type transfer request
@@ -31,7 +38,7 @@ type Transactor struct {
 func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
 	var outputs []*token.PlainOutput
 
-	inputs, tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputs, _, tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +77,7 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", request.GetQuantityToRedeem())
 	}
 
-	inputs, tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	inputs, owner, tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +96,7 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 	// add another output if there is remaining quantity after redemption
 	if quantitySum > request.QuantityToRedeem {
 		outputs = append(outputs, &token.PlainOutput{
-			Owner:    t.PublicCredential, // PublicCredential is serialized identity for the creator
+			Owner:    t.changeOwner(owner),
 			Type:     tokenType,
 			Quantity: quantitySum - request.QuantityToRedeem,
 		})
@@ -113,9 +120,17 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 }
 
 // read token data from ledger for each token ids and calculate the sum of quantities for all token ids
-// Returns InputIds, token type, sum of token quantities, and error in the case of failure
-func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, string, uint64, error) {
+// Returns InputIds, the owner of the inputs (nil if they have different owners), token type,
+// sum of token quantities, and error in the case of failure
+func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, []byte, string, uint64, error) {
+	return t.getOwnedInputsFromTokenIds(tokenIds, t.canSpend, "the requestor")
+}
+
+// getOwnedInputsFromTokenIds is like getInputsFromTokenIds, but checks that isOwner accepts the owner
+// of each token; party describes the spender in error messages
+func (t *Transactor) getOwnedInputsFromTokenIds(tokenIds [][]byte, isOwner func(owner []byte) (bool, error), party string) ([]*token.InputId, []byte, string, uint64, error) {
 	var inputs []*token.InputId
+	var owner []byte
 	var tokenType string = ""
 	var quantitySum uint64 = 0
 	for _, inKeyBytes := range tokenIds {
@@ -123,46 +138,55 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 		inKey := parseCompositeKeyBytes(inKeyBytes)
 
 		// check whether the composite key conforms to the composite key of an output
-		namespace, components, err := splitCompositeKey(inKey)
+		namespace, components, err := tms.SplitCompositeKey(inKey)
 		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error splitting input composite key: '%s'", err))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error splitting input composite key: '%s'", err))
 		}
 		if namespace != tokenOutput {
-			return nil, "", 0, errors.New(fmt.Sprintf("namespace not '%s': '%s'", tokenOutput, namespace))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("namespace not '%s': '%s'", tokenOutput, namespace))
 		}
 		if len(components) != 2 {
-			return nil, "", 0, errors.New(fmt.Sprintf("not enough components in output ID composite key; expected 2, received '%s'", components))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("not enough components in output ID composite key; expected 2, received '%s'", components))
 		}
 		txID := components[0]
 		index, err := strconv.Atoi(components[1])
 		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error parsing output index '%s': '%s'", components[1], err))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error parsing output index '%s': '%s'", components[1], err))
 		}
 
 		// make sure the output exists in the ledger
 		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
 		if err != nil {
-			return nil, "", 0, err
+			return nil, nil, "", 0, err
 		}
 		if inBytes == nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
 		}
 		input := &token.PlainOutput{}
 		err = proto.Unmarshal(inBytes, input)
 		if err != nil {
-			return nil, "", 0, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
 		}
 
 		// check the owner of the token
-		if !bytes.Equal(t.PublicCredential, input.Owner) {
-			return nil, "", 0, errors.New(fmt.Sprintf("the requestor does not own inputs"))
+		owned, err := isOwner(input.Owner)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+		if !owned {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("%s does not own inputs", party))
+		}
+		if len(inputs) == 0 {
+			owner = input.Owner
+		} else if !bytes.Equal(owner, input.Owner) {
+			owner = nil
 		}
 
 		// check the token type - only one type allowed per transfer
 		if tokenType == "" {
 			tokenType = input.Type
 		} else if tokenType != input.Type {
-			return nil, "", 0, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
 		}
 		// add input to list of inputs
 		inputs = append(inputs, &token.InputId{TxId: txID, Index: uint32(index)})
@@ -171,7 +195,145 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 		quantitySum += input.Quantity
 	}
 
-	return inputs, tokenType, quantitySum, nil
+	return inputs, owner, tokenType, quantitySum, nil
+}
+
+// RequestSwap creates a TokenTransaction of type swap request.
+// The requestor gives quantity tokens of its type to the counterparty and receives
+// counterpartyQuantity tokens of the type of the counterparty in exchange;
+// the remaining quantities, if any, are transferred back to their owners.
+// The counterparty must sign the resulting swap before it is submitted.
+func (t *Transactor) RequestSwap(request *token.SwapRequest) (*token.TokenTransaction, error) {
+	if len(request.GetTokenIds()) == 0 {
+		return nil, errors.New("no token ids in SwapRequest")
+	}
+	if len(request.GetCounterpartyTokenIds()) == 0 {
+		return nil, errors.New("no counterparty token ids in SwapRequest")
+	}
+	if len(request.GetCounterparty()) == 0 {
+		return nil, errors.New("no counterparty in SwapRequest")
+	}
+	if bytes.Equal(request.GetCounterparty(), t.PublicCredential) {
+		return nil, errors.New("the counterparty of a swap must be different from the requestor")
+	}
+	if request.GetQuantity() == 0 || request.GetCounterpartyQuantity() == 0 {
+		return nil, errors.New("the quantities to swap must be greater than 0")
+	}
+
+	inputs, owner, tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+	isCounterparty := func(owner []byte) (bool, error) {
+		return bytes.Equal(owner, request.GetCounterparty()), nil
+	}
+	counterpartyInputs, _, counterpartyTokenType, counterpartyQuantitySum, err := t.getOwnedInputsFromTokenIds(request.GetCounterpartyTokenIds(), isCounterparty, "the counterparty")
+	if err != nil {
+		return nil, err
+	}
+	if tokenType == counterpartyTokenType {
+		return nil, errors.Errorf("the tokens to swap must be of different types, got '%s' on both sides", tokenType)
+	}
+	if quantitySum < request.GetQuantity() {
+		return nil, errors.Errorf("total quantity [%d] from TokenIds is less than quantity [%d] to be swapped", quantitySum, request.GetQuantity())
+	}
+	if counterpartyQuantitySum < request.GetCounterpartyQuantity() {
+		return nil, errors.Errorf("total quantity [%d] from CounterpartyTokenIds is less than quantity [%d] to be swapped", counterpartyQuantitySum, request.GetCounterpartyQuantity())
+	}
+
+	outputs := []*token.PlainOutput{
+		{Owner: request.GetCounterparty(), Type: tokenType, Quantity: request.GetQuantity()},
+		{Owner: t.PublicCredential, Type: counterpartyTokenType, Quantity: request.GetCounterpartyQuantity()},
+	}
+	if quantitySum > request.GetQuantity() {
+		outputs = append(outputs, &token.PlainOutput{Owner: t.changeOwner(owner), Type: tokenType, Quantity: quantitySum - request.GetQuantity()})
+	}
+	if counterpartyQuantitySum > request.GetCounterpartyQuantity() {
+		outputs = append(outputs, &token.PlainOutput{Owner: request.GetCounterparty(), Type: counterpartyTokenType, Quantity: counterpartyQuantitySum - request.GetCounterpartyQuantity()})
+	}
+
+	return &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainSwap{
+					PlainSwap: &token.PlainSwap{
+						Inputs:  append(inputs, counterpartyInputs...),
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// read delegated output data from ledger for each token id and calculate the sum of quantities for all token ids
+// Returns InputIds, the owner of the delegated outputs, token type, sum of token quantities, and error in the case of failure
+func (t *Transactor) getDelegatedInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, []byte, string, uint64, error) {
+	var inputs []*token.InputId
+	var owner []byte
+	var tokenType string = ""
+	var quantitySum uint64 = 0
+	for _, inKeyBytes := range tokenIds {
+		// parse the composite key bytes into a string
+		inKey := parseCompositeKeyBytes(inKeyBytes)
+
+		// check whether the composite key conforms to the composite key of a delegated output
+		namespace, components, err := tms.SplitCompositeKey(inKey)
+		if err != nil {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error splitting input composite key: '%s'", err))
+		}
+		if namespace != tokenDelegatedOutput {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("namespace not '%s': '%s'", tokenDelegatedOutput, namespace))
+		}
+		if len(components) != 2 {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("not enough components in delegated output ID composite key; expected 2, received '%s'", components))
+		}
+		txID := components[0]
+		index, err := strconv.Atoi(components[1])
+		if err != nil {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error parsing delegated output index '%s': '%s'", components[1], err))
+		}
+
+		// make sure the delegated output exists in the ledger
+		inBytes, err := t.Ledger.GetState(tokenNameSpace, inKey)
+		if err != nil {
+			return nil, nil, "", 0, err
+		}
+		if inBytes == nil {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("input '%s' does not exist", inKey))
+		}
+		input := &token.PlainDelegatedOutput{}
+		err = proto.Unmarshal(inBytes, input)
+		if err != nil {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("error unmarshaling input bytes: '%s'", err))
+		}
+
+		// check that the requestor is a delegatee of the token
+		if !isDelegatee(t.PublicCredential, input) {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("the requestor is not a delegatee of inputs"))
+		}
+
+		// check the owner of the token - only one owner allowed per transferFrom
+		if owner == nil {
+			owner = input.Owner
+		} else if !bytes.Equal(owner, input.Owner) {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("two or more owners specified in input"))
+		}
+
+		// check the token type - only one type allowed per transferFrom
+		if tokenType == "" {
+			tokenType = input.Type
+		} else if tokenType != input.Type {
+			return nil, nil, "", 0, errors.New(fmt.Sprintf("two or more token types specified in input: '%s', '%s'", tokenType, input.Type))
+		}
+		// add input to list of inputs
+		inputs = append(inputs, &token.InputId{TxId: txID, Index: uint32(index)})
+
+		// sum up the quantity
+		quantitySum += input.Quantity
+	}
+
+	return inputs, owner, tokenType, quantitySum, nil
 }
 
 // ListTokens creates a TokenTransaction that lists the unspent tokens owned by owner.
@@ -182,7 +344,7 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 	}
 
 	tokens := make([]*token.TokenOutput, 0)
-	prefix, err := createPrefix(tokenOutput)
+	prefix, err := tms.CreatePrefix(tokenOutput)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +370,11 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 				if err != nil {
 					return nil, errors.New("failed to retrieve unspent tokens: casting error")
 				}
-				if string(output.Owner) == string(t.PublicCredential) {
+				owned, err := t.canSpend(output.Owner)
+				if err != nil {
+					return nil, err
+				}
+				if owned {
 					spent, err := t.isSpent(result.Key)
 					if err != nil {
 						return nil, err
@@ -228,6 +394,329 @@ func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
 
 }
 
+// defaultHistoryPageSize is the number of transactions returned by GetTokenHistory
+// when the request does not specify a page size
+const defaultHistoryPageSize = 100
+
+// GetTokenHistory returns a page of the committed token transactions that involve this
+// transactor, ordered by their position in the blockchain. The pages are read from the
+// token history index of the transactor, written by Verifier.IndexTx.
+func (t *Transactor) GetTokenHistory(request *token.TokenHistoryRequest) (*token.TokenHistory, error) {
+	pageSize := int(request.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultHistoryPageSize
+	}
+
+	prefix, err := createHistoryPrefix(t.PublicCredential)
+	if err != nil {
+		return nil, err
+	}
+	startKey := prefix
+	if request.GetBookmark() != "" {
+		blockNum, txNum, err := decodeHistoryBookmark(request.GetBookmark())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bookmark '%s'", request.GetBookmark())
+		}
+		bookmarkKey, err := createHistoryKey(t.PublicCredential, blockNum, txNum)
+		if err != nil {
+			return nil, err
+		}
+		// start right after the transaction identified by the bookmark
+		startKey = bookmarkKey + "\x00"
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, startKey, tms.PrefixEnd(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	history := &token.TokenHistory{}
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return history, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to retrieve token history: casting error")
+		}
+
+		txID := string(result.Value)
+		ttx, err := t.getTransaction(txID)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to retrieve token history")
+		}
+		history.Transactions = append(history.Transactions, &token.TokenTransactionRecord{TxId: txID, TokenTransaction: ttx})
+		if len(history.Transactions) == pageSize {
+			_, attributes, err := tms.SplitCompositeKey(result.Key)
+			if err != nil {
+				return nil, err
+			}
+			// the bookmark is the position of the last returned transaction
+			blockNum, txNum, err := decodeHistoryBookmark(attributes[1] + ":" + attributes[2])
+			if err != nil {
+				return nil, err
+			}
+			history.Bookmark = fmt.Sprintf("%d:%d", blockNum, txNum)
+			return history, nil
+		}
+	}
+}
+
+// decodeHistoryBookmark returns the block and transaction numbers encoded in a token history bookmark
+func decodeHistoryBookmark(bookmark string) (uint64, uint64, error) {
+	position := strings.Split(bookmark, ":")
+	if len(position) != 2 {
+		return 0, 0, errors.New("bookmark must be of the form <block number>:<transaction number>")
+	}
+	blockNum, err := strconv.ParseUint(position[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	txNum, err := strconv.ParseUint(position[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return blockNum, txNum, nil
+}
+
+// getTransaction returns the committed token transaction with ID txID
+func (t *Transactor) getTransaction(txID string) (*token.TokenTransaction, error) {
+	txKey, err := createTxKey(txID)
+	if err != nil {
+		return nil, err
+	}
+	ttxBytes, err := t.Ledger.GetState(tokenNameSpace, txKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(ttxBytes) == 0 {
+		return nil, errors.Errorf("transaction %s does not exist", txID)
+	}
+	ttx := &token.TokenTransaction{}
+	err = proto.Unmarshal(ttxBytes, ttx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unmarshaling error for transaction %s", txID)
+	}
+	return ttx, nil
+}
+
+// GetBalance returns the sum of the unspent tokens owned by this transactor, per token type.
+// Balances are sorted by token type.
+func (t *Transactor) GetBalance() (*token.TokenBalances, error) {
+	unspentTokens, err := t.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := &token.TokenBalances{}
+	byType := map[string]*token.TokenBalance{}
+	for _, tok := range unspentTokens.GetTokens() {
+		balance, ok := byType[tok.Type]
+		if !ok {
+			balance = &token.TokenBalance{Type: tok.Type}
+			byType[tok.Type] = balance
+			balances.Balances = append(balances.Balances, balance)
+		}
+		if balance.Quantity+tok.Quantity < balance.Quantity {
+			return nil, errors.Errorf("balance overflow for token type %s", tok.Type)
+		}
+		balance.Quantity += tok.Quantity
+	}
+	sort.Slice(balances.Balances, func(i, j int) bool {
+		return balances.Balances[i].Type < balances.Balances[j].Type
+	})
+	return balances, nil
+}
+
+// GetSpendStatus returns whether the output identified by inputID has been spent.
+// The output can be either a regular or a delegated output, and this transactor
+// must be its owner or, for a delegated output, one of its delegatees.
+func (t *Transactor) GetSpendStatus(inputID *token.InputId) (*token.SpendStatus, error) {
+	if inputID == nil {
+		return nil, errors.New("no input ID in SpendStatusRequest")
+	}
+
+	output, err := t.getOutput(inputID)
+	if err != nil {
+		return nil, err
+	}
+	if output != nil {
+		owned, err := t.canSpend(output.GetOwner())
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			return nil, errors.Errorf("the requestor does not own output with ID (%s, %d)", inputID.TxId, inputID.Index)
+		}
+		spentKey, err := createSpentKey(inputID.TxId, int(inputID.Index))
+		if err != nil {
+			return nil, err
+		}
+		spent, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+		if err != nil {
+			return nil, err
+		}
+		return &token.SpendStatus{InputId: inputID, Spent: spent != nil}, nil
+	}
+
+	delegatedOutput, err := t.getDelegatedOutput(inputID)
+	if err != nil {
+		return nil, err
+	}
+	if delegatedOutput == nil {
+		return nil, errors.Errorf("output with ID (%s, %d) does not exist", inputID.TxId, inputID.Index)
+	}
+	if !t.isOwnerOrDelegatee(delegatedOutput) {
+		return nil, errors.Errorf("the requestor is neither owner nor delegatee of delegated output with ID (%s, %d)", inputID.TxId, inputID.Index)
+	}
+	spentKey, err := createSpentDelegatedOutputKey(inputID.TxId, int(inputID.Index))
+	if err != nil {
+		return nil, err
+	}
+	spent, err := t.Ledger.GetState(tokenNameSpace, spentKey)
+	if err != nil {
+		return nil, err
+	}
+	return &token.SpendStatus{InputId: inputID, Spent: spent != nil, Delegated: true}, nil
+}
+
+// ListTokenTypes returns the token types in the registry of the channel, ordered by type,
+// together with the quantity imported since their registration.
+func (t *Transactor) ListTokenTypes() (*token.TokenTypes, error) {
+	prefix, err := tms.CreatePrefix(tms.TokenTypeKeyword)
+	if err != nil {
+		return nil, err
+	}
+	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, prefix, tms.PrefixEnd(prefix))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	tokenTypes := &token.TokenTypes{}
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return tokenTypes, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return nil, errors.New("failed to retrieve token types: casting error")
+		}
+
+		definition := &token.TokenTypeDefinition{}
+		err = proto.Unmarshal(result.Value, definition)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve token types: unmarshaling error for key %s", result.Key)
+		}
+		supply, err := getTokenSupply(definition.GetType(), definition, t.Ledger)
+		if err != nil {
+			return nil, err
+		}
+		tokenTypes.Types = append(tokenTypes.Types, &token.TokenTypeInfo{Definition: definition, Supply: supply})
+	}
+}
+
+// getOutput returns the output identified by inputID, or nil if it does not exist
+func (t *Transactor) getOutput(inputID *token.InputId) (*token.PlainOutput, error) {
+	outputID, err := createOutputKey(inputID.TxId, int(inputID.Index))
+	if err != nil {
+		return nil, err
+	}
+	outputBytes, err := t.Ledger.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, nil
+	}
+	output := &token.PlainOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling output with ID %s", outputID)
+	}
+	return output, nil
+}
+
+// getDelegatedOutput returns the delegated output identified by inputID, or nil if it does not exist
+func (t *Transactor) getDelegatedOutput(inputID *token.InputId) (*token.PlainDelegatedOutput, error) {
+	outputID, err := createDelegatedOutputKey(inputID.TxId, int(inputID.Index))
+	if err != nil {
+		return nil, err
+	}
+	outputBytes, err := t.Ledger.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, nil
+	}
+	output := &token.PlainDelegatedOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling delegated output with ID %s", outputID)
+	}
+	return output, nil
+}
+
+// isOwnerOrDelegatee checks whether this transactor is the owner or one of the delegatees of delegatedOutput
+// canSpend returns true if the requestor can take part in spending the outputs owned by owner,
+// that is, if it is the owner, the pseudonym of an idemix owner, or one of the principals of
+// a multisig owner. The signatures a multisig owner requires are added to the transaction
+// by its signers before it is submitted.
+func (t *Transactor) canSpend(owner []byte) (bool, error) {
+	tokenOwner := identity.UnmarshalOwner(owner)
+	switch tokenOwner.Type {
+	case token.TokenOwner_MSP_IDENTIFIER:
+		return bytes.Equal(owner, t.PublicCredential), nil
+	case token.TokenOwner_IDEMIX:
+		return bytes.Equal(tokenOwner.Raw, t.PublicCredential), nil
+	case token.TokenOwner_MULTISIG:
+		if t.Deserializer == nil {
+			return false, nil
+		}
+		policy := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(tokenOwner.Raw, policy); err != nil {
+			// a malformed multisig owner cannot be satisfied by anyone
+			return false, nil
+		}
+		requestor, err := t.Deserializer.DeserializeIdentity(t.PublicCredential)
+		if err != nil {
+			return false, errors.WithMessage(err, "failed deserializing the requestor")
+		}
+		for _, principal := range policy.GetIdentities() {
+			if requestor.SatisfiesPrincipal(principal) == nil {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, nil
+	}
+}
+
+// changeOwner returns the owner of the quantity that remains after spending inputs owned by owner:
+// the owner of the inputs if they have a single owner, the requestor otherwise
+func (t *Transactor) changeOwner(owner []byte) []byte {
+	if owner == nil {
+		return t.PublicCredential
+	}
+	return owner
+}
+
+func (t *Transactor) isOwnerOrDelegatee(delegatedOutput *token.PlainDelegatedOutput) bool {
+	return bytes.Equal(delegatedOutput.GetOwner(), t.PublicCredential) || isDelegatee(t.PublicCredential, delegatedOutput)
+}
+
 func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error) {
 	if len(request.GetTokenIds()) == 0 {
 		return nil, errors.New("no token ids in ApproveAllowanceRequest")
@@ -239,7 +728,11 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 
 	var delegatedOutputs []*token.PlainDelegatedOutput
 
-	inputs, tokenType, sumQuantity, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	// only the tokens of the requestor itself can be delegated
+	isRequestor := func(owner []byte) (bool, error) {
+		return bytes.Equal(owner, t.PublicCredential), nil
+	}
+	inputs, _, tokenType, sumQuantity, err := t.getOwnedInputsFromTokenIds(request.GetTokenIds(), isRequestor, "the requestor")
 	if err != nil {
 		return nil, err
 	}
@@ -292,14 +785,158 @@ func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.Token
 	return transaction, nil
 }
 
+// RequestTransferFrom creates a TokenTransaction of type transferFrom that spends
+// delegated outputs previously approved to the requestor by their owner.
+// If the requested shares do not exhaust the allowance, the remaining quantity
+// is kept as a new delegated output of the same owner, delegated to the requestor.
 func (t *Transactor) RequestTransferFrom(request *token.TransferRequest) (*token.TokenTransaction, error) {
-	panic("implement me!")
+	if len(request.GetTokenIds()) == 0 {
+		return nil, errors.New("no token ids in TransferFromRequest")
+	}
+	if len(request.GetShares()) == 0 {
+		return nil, errors.New("no recipient shares in TransferFromRequest")
+	}
+
+	inputs, owner, tokenType, quantitySum, err := t.getDelegatedInputsFromTokenIds(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []*token.PlainOutput
+	transferQuantity := uint64(0)
+	for _, share := range request.GetShares() {
+		if len(share.Recipient) == 0 {
+			return nil, errors.New("the recipient in transferFrom must be specified")
+		}
+		if share.Quantity <= 0 {
+			return nil, errors.Errorf("the quantity to transfer [%d] must be greater than 0", share.GetQuantity())
+		}
+		outputs = append(outputs, &token.PlainOutput{
+			Owner:    share.Recipient,
+			Type:     tokenType,
+			Quantity: share.Quantity,
+		})
+		transferQuantity = transferQuantity + share.Quantity
+	}
+	if quantitySum < transferQuantity {
+		return nil, errors.Errorf("insufficient allowance: %v < %v", quantitySum, transferQuantity)
+	}
+
+	// keep the remaining allowance delegated to the requestor
+	var delegatedOutput *token.PlainDelegatedOutput
+	if quantitySum > transferQuantity {
+		delegatedOutput = &token.PlainDelegatedOutput{
+			Owner:      owner,
+			Delegatees: [][]byte{t.PublicCredential},
+			Type:       tokenType,
+			Quantity:   quantitySum - transferQuantity,
+		}
+	}
+
+	transaction := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer_From{
+					PlainTransfer_From: &token.PlainTransferFrom{
+						Inputs:          inputs,
+						Outputs:         outputs,
+						DelegatedOutput: delegatedOutput,
+					},
+				},
+			},
+		},
+	}
+
+	return transaction, nil
 }
 
 // RequestExpectation allows indirect transfer based on the expectation.
 // It creates a token transaction based on the outputs as specified in the expectation.
+// If the inputs exceed the expected outputs, an additional output transfers the
+// remaining tokens back to the creator.
 func (t *Transactor) RequestExpectation(request *token.ExpectationRequest) (*token.TokenTransaction, error) {
-	panic("not implemented yet")
+	if len(request.GetTokenIds()) == 0 {
+		return nil, errors.New("no token ids in ExpectationRequest")
+	}
+	if request.GetExpectation() == nil {
+		return nil, errors.New("no token expectation in ExpectationRequest")
+	}
+	if request.GetExpectation().GetPlainExpectation() == nil {
+		return nil, errors.New("no plain expectation in ExpectationRequest")
+	}
+	transferExpectation := request.GetExpectation().GetPlainExpectation().GetTransferExpectation()
+	if transferExpectation == nil {
+		return nil, errors.New("no transfer expectation in ExpectationRequest")
+	}
+
+	inputs, _, inputType, inputSum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	if err != nil {
+		return nil, err
+	}
+
+	outputType, outputSum, err := parseExpectedOutputs(transferExpectation.GetOutputs())
+	if err != nil {
+		return nil, err
+	}
+	if outputType != inputType {
+		return nil, errors.Errorf("token type mismatch in inputs and outputs for expectation (%s vs %s)", outputType, inputType)
+	}
+	if inputSum < outputSum {
+		return nil, errors.Errorf("insufficient funds for expectation: %v < %v", inputSum, outputSum)
+	}
+
+	var outputs []*token.PlainOutput
+	outputs = append(outputs, transferExpectation.GetOutputs()...)
+
+	// add another output if there is remaining quantity after the expected transfer
+	if inputSum > outputSum {
+		outputs = append(outputs, &token.PlainOutput{
+			Owner:    t.PublicCredential, // PublicCredential is serialized identity for the creator
+			Type:     inputType,
+			Quantity: inputSum - outputSum,
+		})
+	}
+
+	transaction := &token.TokenTransaction{
+		Action: &token.TokenTransaction_PlainAction{
+			PlainAction: &token.PlainTokenAction{
+				Data: &token.PlainTokenAction_PlainTransfer{
+					PlainTransfer: &token.PlainTransfer{
+						Inputs:  inputs,
+						Outputs: outputs,
+					},
+				},
+			},
+		},
+	}
+
+	return transaction, nil
+}
+
+// parseExpectedOutputs checks that the expected outputs have a single token type,
+// an owner and a positive quantity.
+// Returns the token type and the sum of the quantities of the outputs.
+func parseExpectedOutputs(outputs []*token.PlainOutput) (string, uint64, error) {
+	if len(outputs) == 0 {
+		return "", 0, errors.New("no outputs in ExpectationRequest")
+	}
+	tokenType := ""
+	quantitySum := uint64(0)
+	for _, output := range outputs {
+		if len(output.GetOwner()) == 0 {
+			return "", 0, errors.New("the owner of an expected output must be specified")
+		}
+		if output.GetQuantity() == 0 {
+			return "", 0, errors.New("the quantity of an expected output must be greater than 0")
+		}
+		if tokenType == "" {
+			tokenType = output.GetType()
+		} else if tokenType != output.GetType() {
+			return "", 0, errors.Errorf("multiple token types ('%s', '%s') in expected outputs", tokenType, output.GetType())
+		}
+		quantitySum += output.GetQuantity()
+	}
+	return tokenType, quantitySum, nil
 }
 
 // Done releases any resources held by this transactor
@@ -328,31 +965,14 @@ func (t *Transactor) isSpent(outputID string) (bool, error) {
 // Create a ledger key for an individual input in a token transaction, as a function of
 // the outputID
 func createInputKey(outputID string) (string, error) {
-	att := strings.Split(outputID, string(minUnicodeRuneValue))
-	return createCompositeKey(tokenInput, att[1:])
-}
-
-// Create a prefix as a function of the string passed as argument
-func createPrefix(keyword string) (string, error) {
-	return createCompositeKey(keyword, nil)
+	_, att, err := tms.SplitCompositeKey(outputID)
+	if err != nil {
+		return "", err
+	}
+	return tms.CreateCompositeKey(tokenInput, att)
 }
 
 // GenerateKeyForTest is here only for testing purposes, to be removed later.
 func GenerateKeyForTest(txID string, index int) (string, error) {
 	return createOutputKey(txID, index)
 }
-
-func splitCompositeKey(compositeKey string) (string, []string, error) {
-	componentIndex := 1
-	components := []string{}
-	for i := 1; i < len(compositeKey); i++ {
-		if compositeKey[i] == minUnicodeRuneValue {
-			components = append(components, compositeKey[componentIndex:i])
-			componentIndex = i + 1
-		}
-	}
-	if len(components) < 2 {
-		return "", nil, errors.New("invalid composite key - no components found")
-	}
-	return components[0], components[1:], nil
-}
@@ -20,6 +20,7 @@ import (
 	"github.com/tradeline-tech/fabric/common/ledger"
 	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 	"github.com/tradeline-tech/fabric/protos/token"
+	mockid "github.com/tradeline-tech/fabric/token/identity/mock"
 	"github.com/tradeline-tech/fabric/token/ledger/mock"
 	"github.com/tradeline-tech/fabric/token/tms/plain"
 )
@@ -791,3 +792,675 @@ var _ = Describe("Transactor Approve", func() {
 	})
 
 })
+
+var _ = Describe("Transactor TransferFrom", func() {
+	var (
+		transactor          *plain.Transactor
+		fakeLedger          *mock.LedgerReader
+		transferFromRequest *token.TransferRequest
+		delegatedKey        []byte
+	)
+
+	BeforeEach(func() {
+		delegatedOutput := &token.PlainDelegatedOutput{
+			Owner:      []byte("owner"),
+			Delegatees: [][]byte{[]byte("spender")},
+			Type:       "XYZ",
+			Quantity:   100,
+		}
+		inputBytes, err := proto.Marshal(delegatedOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateReturns(inputBytes, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("spender"), Ledger: fakeLedger}
+
+		delegatedKey = []byte(string("\x00") + "tokenDelegatedOutput" + string("\x00") + "lalaland" + string("\x00") + "0" + string("\x00"))
+		transferFromRequest = &token.TransferRequest{
+			Credential: []byte("spender"),
+			TokenIds:   [][]byte{delegatedKey},
+			Shares: []*token.RecipientTransferShare{
+				{Recipient: []byte("Alice"), Quantity: 30},
+				{Recipient: []byte("Bob"), Quantity: 20},
+			},
+		}
+	})
+
+	It("creates a valid transferFrom request with the remaining allowance", func() {
+		tt, err := transactor.RequestTransferFrom(transferFromRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt).To(Equal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer_From{
+						PlainTransfer_From: &token.PlainTransferFrom{
+							Inputs: []*token.InputId{
+								{TxId: "lalaland", Index: uint32(0)},
+							},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("Alice"), Type: "XYZ", Quantity: 30},
+								{Owner: []byte("Bob"), Type: "XYZ", Quantity: 20},
+							},
+							DelegatedOutput: &token.PlainDelegatedOutput{
+								Owner:      []byte("owner"),
+								Delegatees: [][]byte{[]byte("spender")},
+								Type:       "XYZ",
+								Quantity:   50,
+							},
+						},
+					},
+				},
+			},
+		}))
+
+		Expect(fakeLedger.GetStateCallCount()).To(Equal(1))
+		ns, key := fakeLedger.GetStateArgsForCall(0)
+		Expect(ns).To(Equal("tms"))
+		Expect(key).To(Equal(string(delegatedKey)))
+	})
+
+	It("creates a valid transferFrom request without a delegated output when the allowance is exhausted", func() {
+		transferFromRequest.Shares = []*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 100}}
+		tt, err := transactor.RequestTransferFrom(transferFromRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainTransfer_From().GetDelegatedOutput()).To(BeNil())
+		Expect(tt.GetPlainAction().GetPlainTransfer_From().GetOutputs()).To(Equal([]*token.PlainOutput{
+			{Owner: []byte("Alice"), Type: "XYZ", Quantity: 100},
+		}))
+	})
+
+	When("no token ids are provided", func() {
+		It("returns an error", func() {
+			transferFromRequest.TokenIds = nil
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("no token ids in TransferFromRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("no shares are provided", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = nil
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("no recipient shares in TransferFromRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("a token id is not a delegated output", func() {
+		It("returns an error", func() {
+			key, err := plain.GenerateKeyForTest("1", 0)
+			Expect(err).NotTo(HaveOccurred())
+			transferFromRequest.TokenIds = [][]byte{[]byte(key)}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("namespace not 'tokenDelegatedOutput': 'tokenOutput'"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the requestor is not a delegatee", func() {
+		It("returns an error", func() {
+			transactor.PublicCredential = []byte("mallory")
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("the requestor is not a delegatee of inputs"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the delegated outputs have different owners", func() {
+		It("returns an error", func() {
+			otherOwner, err := proto.Marshal(&token.PlainDelegatedOutput{
+				Owner:      []byte("another-owner"),
+				Delegatees: [][]byte{[]byte("spender")},
+				Type:       "XYZ",
+				Quantity:   100,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			fakeLedger.GetStateReturnsOnCall(1, otherOwner, nil)
+			transferFromRequest.TokenIds = [][]byte{delegatedKey, []byte(string("\x00") + "tokenDelegatedOutput" + string("\x00") + "lalaland" + string("\x00") + "1" + string("\x00"))}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("two or more owners specified in input"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the allowance is insufficient", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{{Recipient: []byte("Alice"), Quantity: 101}}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("insufficient allowance: 100 < 101"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("a recipient is not specified", func() {
+		It("returns an error", func() {
+			transferFromRequest.Shares = []*token.RecipientTransferShare{{Quantity: 10}}
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError("the recipient in transferFrom must be specified"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the delegated output does not exist", func() {
+		It("returns an error", func() {
+			fakeLedger.GetStateReturns(nil, nil)
+			tt, err := transactor.RequestTransferFrom(transferFromRequest)
+			Expect(err).To(MatchError(fmt.Sprintf("input '%s' does not exist", delegatedKey)))
+			Expect(tt).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Transactor RequestExpectation", func() {
+	var (
+		transactor         *plain.Transactor
+		fakeLedger         *mock.LedgerReader
+		expectationRequest *token.ExpectationRequest
+		tokenID            []byte
+	)
+
+	BeforeEach(func() {
+		inputBytes, err := proto.Marshal(&token.PlainOutput{Owner: []byte("Alice"), Type: "XYZ", Quantity: 100})
+		Expect(err).NotTo(HaveOccurred())
+		fakeLedger = &mock.LedgerReader{}
+		fakeLedger.GetStateReturns(inputBytes, nil)
+		transactor = &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: fakeLedger}
+
+		tokenID = []byte(string("\x00") + "tokenOutput" + string("\x00") + "lalaland" + string("\x00") + "0" + string("\x00"))
+		expectationRequest = &token.ExpectationRequest{
+			Credential: []byte("Alice"),
+			TokenIds:   [][]byte{tokenID},
+			Expectation: &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("Bob"), Type: "XYZ", Quantity: 60},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("creates a transfer with the expected outputs and the remaining tokens", func() {
+		tt, err := transactor.RequestExpectation(expectationRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt).To(Equal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainTransfer{
+						PlainTransfer: &token.PlainTransfer{
+							Inputs: []*token.InputId{
+								{TxId: "lalaland", Index: uint32(0)},
+							},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("Bob"), Type: "XYZ", Quantity: 60},
+								{Owner: []byte("Alice"), Type: "XYZ", Quantity: 40},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	It("does not add a remaining output when the inputs match the expectation", func() {
+		expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Quantity = 100
+		tt, err := transactor.RequestExpectation(expectationRequest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt.GetPlainAction().GetPlainTransfer().GetOutputs()).To(Equal([]*token.PlainOutput{
+			{Owner: []byte("Bob"), Type: "XYZ", Quantity: 100},
+		}))
+	})
+
+	When("no token ids are provided", func() {
+		It("returns an error", func() {
+			expectationRequest.TokenIds = nil
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("no token ids in ExpectationRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the expectation is an import expectation", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().Payload = &token.PlainExpectation_ImportExpectation{
+				ImportExpectation: &token.PlainTokenExpectation{},
+			}
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("no transfer expectation in ExpectationRequest"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the expected type does not match the input type", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Type = "ABC"
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("token type mismatch in inputs and outputs for expectation (ABC vs XYZ)"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the expected outputs have multiple types", func() {
+		It("returns an error", func() {
+			transferExpectation := expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation()
+			transferExpectation.Outputs = append(transferExpectation.Outputs, &token.PlainOutput{Owner: []byte("Bob"), Type: "ABC", Quantity: 1})
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("multiple token types ('XYZ', 'ABC') in expected outputs"))
+			Expect(tt).To(BeNil())
+		})
+	})
+
+	When("the inputs are not sufficient", func() {
+		It("returns an error", func() {
+			expectationRequest.Expectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Quantity = 101
+			tt, err := transactor.RequestExpectation(expectationRequest)
+			Expect(err).To(MatchError("insufficient funds for expectation: 100 < 101"))
+			Expect(tt).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Transactor queries", func() {
+	var (
+		memoryLedger *plain.MemoryLedger
+		transactor   *plain.Transactor
+	)
+
+	importTx := func(outputs ...*token.PlainOutput) *token.TokenTransaction {
+		return &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{Outputs: outputs},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		memoryLedger = plain.NewMemoryLedger()
+		verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		alice := &mockid.PublicInfo{}
+		alice.PublicReturns([]byte("Alice"))
+
+		for _, tx := range []struct {
+			txID     string
+			blockNum uint64
+			txNum    uint64
+			ttx      *token.TokenTransaction
+		}{
+			{"4", 1, 5, importTx(
+				&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK2", Quantity: 5},
+				&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 3},
+				&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK2", Quantity: 7},
+			)},
+			{"1", 2, 0, importTx(
+				&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 100},
+				&token.PlainOutput{Owner: []byte("Bob"), Type: "TOK2", Quantity: 50},
+			)},
+			{"2", 2, 1, importTx(&token.PlainOutput{Owner: []byte("Bob"), Type: "TOK1", Quantity: 10})},
+			{"3", 3, 0, &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs:  []*token.InputId{{TxId: "1", Index: 0}},
+								Outputs: []*token.PlainOutput{{Owner: []byte("Charlie"), Type: "TOK1", Quantity: 100}},
+							},
+						},
+					},
+				},
+			}},
+			{"5", 10, 2, &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainApprove{
+							PlainApprove: &token.PlainApprove{
+								Inputs: []*token.InputId{{TxId: "4", Index: 2}},
+								DelegatedOutputs: []*token.PlainDelegatedOutput{
+									{Owner: []byte("Alice"), Delegatees: [][]byte{[]byte("Dave")}, Type: "TOK2", Quantity: 7},
+								},
+							},
+						},
+					},
+				},
+			}},
+		} {
+			err := verifier.ProcessTx(tx.txID, alice, tx.ttx, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.IndexTx(tx.txID, tx.ttx, memoryLedger, tx.blockNum, tx.txNum)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		transactor = &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: memoryLedger}
+	})
+
+	Describe("GetTokenHistory", func() {
+		txIDs := func(history *token.TokenHistory) []string {
+			var ids []string
+			for _, record := range history.Transactions {
+				ids = append(ids, record.TxId)
+			}
+			return ids
+		}
+
+		It("returns the transactions involving the transactor, ordered by position", func() {
+			history, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txIDs(history)).To(Equal([]string{"4", "1", "3", "5"}))
+			Expect(history.Bookmark).To(BeEmpty())
+			Expect(proto.Equal(history.Transactions[1].TokenTransaction, importTx(
+				&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 100},
+				&token.PlainOutput{Owner: []byte("Bob"), Type: "TOK2", Quantity: 50},
+			))).To(BeTrue())
+		})
+
+		It("returns the transactions involving a recipient or a delegatee", func() {
+			transactor.PublicCredential = []byte("Charlie")
+			history, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txIDs(history)).To(Equal([]string{"3"}))
+
+			transactor.PublicCredential = []byte("Dave")
+			history, err = transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txIDs(history)).To(Equal([]string{"5"}))
+		})
+
+		It("paginates the transactions", func() {
+			history, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{PageSize: 3})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txIDs(history)).To(Equal([]string{"4", "1", "3"}))
+			Expect(history.Bookmark).To(Equal("3:0"))
+
+			history, err = transactor.GetTokenHistory(&token.TokenHistoryRequest{PageSize: 3, Bookmark: history.Bookmark})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(txIDs(history)).To(Equal([]string{"5"}))
+			Expect(history.Bookmark).To(BeEmpty())
+		})
+
+		It("only returns the transactions of the transactor", func() {
+			transactor.PublicCredential = []byte("Eve")
+			history, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Transactions).To(BeEmpty())
+		})
+
+		Context("when the bookmark is invalid", func() {
+			It("returns an error", func() {
+				_, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{Bookmark: "4"})
+				Expect(err).To(MatchError("invalid bookmark '4': bookmark must be of the form <block number>:<transaction number>"))
+			})
+		})
+
+		Context("when the ledger fails", func() {
+			It("returns the error", func() {
+				fakeLedger := &mock.LedgerReader{}
+				fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("wild potato"))
+				transactor.Ledger = fakeLedger
+
+				_, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+				Expect(err).To(MatchError("wild potato"))
+			})
+		})
+	})
+
+	Describe("GetBalance", func() {
+		It("sums the unspent tokens per type", func() {
+			balances, err := transactor.GetBalance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances).To(Equal(&token.TokenBalances{Balances: []*token.TokenBalance{
+				{Type: "TOK1", Quantity: 3},
+				{Type: "TOK2", Quantity: 5},
+			}}))
+		})
+
+		Context("when the transactor owns no tokens", func() {
+			It("returns an empty balance", func() {
+				transactor.PublicCredential = []byte("Dave")
+				balances, err := transactor.GetBalance()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(balances.Balances).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetSpendStatus", func() {
+		It("returns whether an output has been spent", func() {
+			status, err := transactor.GetSpendStatus(&token.InputId{TxId: "1", Index: 0})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&token.SpendStatus{InputId: &token.InputId{TxId: "1", Index: 0}, Spent: true}))
+
+			status, err = transactor.GetSpendStatus(&token.InputId{TxId: "4", Index: 0})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&token.SpendStatus{InputId: &token.InputId{TxId: "4", Index: 0}}))
+		})
+
+		It("returns the status of a delegated output to a delegatee", func() {
+			transactor.PublicCredential = []byte("Dave")
+			status, err := transactor.GetSpendStatus(&token.InputId{TxId: "5", Index: 0})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&token.SpendStatus{InputId: &token.InputId{TxId: "5", Index: 0}, Delegated: true}))
+		})
+
+		Context("when the requestor does not own the output", func() {
+			It("returns an error", func() {
+				_, err := transactor.GetSpendStatus(&token.InputId{TxId: "1", Index: 1})
+				Expect(err).To(MatchError("the requestor does not own output with ID (1, 1)"))
+
+				transactor.PublicCredential = []byte("Bob")
+				_, err = transactor.GetSpendStatus(&token.InputId{TxId: "5", Index: 0})
+				Expect(err).To(MatchError("the requestor is neither owner nor delegatee of delegated output with ID (5, 0)"))
+			})
+		})
+
+		Context("when the output does not exist", func() {
+			It("returns an error", func() {
+				_, err := transactor.GetSpendStatus(&token.InputId{TxId: "9", Index: 0})
+				Expect(err).To(MatchError("output with ID (9, 0) does not exist"))
+			})
+		})
+
+		Context("when no input ID is passed", func() {
+			It("returns an error", func() {
+				_, err := transactor.GetSpendStatus(nil)
+				Expect(err).To(MatchError("no input ID in SpendStatusRequest"))
+			})
+		})
+	})
+
+	Describe("ListTokenTypes", func() {
+		registrationTx := func(definition *token.TokenTypeDefinition) *token.TokenTransaction {
+			return &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTypeRegistration{
+							PlainTypeRegistration: &token.PlainTypeRegistration{Definition: definition},
+						},
+					},
+				},
+			}
+		}
+
+		It("returns the registered types and their supply", func() {
+			verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}, RegistrationValidator: &mockid.RegistrationValidator{}}
+			alice := &mockid.PublicInfo{}
+			alice.PublicReturns([]byte("Alice"))
+
+			tok2 := &token.TokenTypeDefinition{Type: "TOK2", DisplayName: "Token Two"}
+			tok1 := &token.TokenTypeDefinition{Type: "TOK1", DisplayName: "Token One", Decimals: 2, MaxSupply: 1000}
+			err := verifier.ProcessTx("r1", alice, registrationTx(tok2), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("r2", alice, registrationTx(tok1), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			err = verifier.ProcessTx("6", alice, importTx(&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 20}), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			tokenTypes, err := transactor.ListTokenTypes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tokenTypes.Types).To(HaveLen(2))
+			Expect(proto.Equal(tokenTypes.Types[0].Definition, tok1)).To(BeTrue())
+			// the supply includes the tokens imported before the registration
+			Expect(tokenTypes.Types[0].Supply).To(Equal(uint64(133)))
+			Expect(proto.Equal(tokenTypes.Types[1].Definition, tok2)).To(BeTrue())
+			Expect(tokenTypes.Types[1].Supply).To(Equal(uint64(62)))
+		})
+
+		It("does not list the registration in the history", func() {
+			verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}, RegistrationValidator: &mockid.RegistrationValidator{}}
+			alice := &mockid.PublicInfo{}
+			alice.PublicReturns([]byte("Alice"))
+			err := verifier.ProcessTx("r1", alice, registrationTx(&token.TokenTypeDefinition{Type: "TOK3"}), memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			history, err := transactor.GetTokenHistory(&token.TokenHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Transactions).To(HaveLen(4))
+		})
+
+		Context("when no type is registered", func() {
+			It("returns an empty registry", func() {
+				tokenTypes, err := transactor.ListTokenTypes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tokenTypes.Types).To(BeEmpty())
+			})
+		})
+
+		Context("when the ledger fails", func() {
+			It("returns the error", func() {
+				fakeLedger := &mock.LedgerReader{}
+				fakeLedger.GetStateRangeScanIteratorReturns(nil, errors.New("wild potato"))
+				transactor.Ledger = fakeLedger
+
+				_, err := transactor.ListTokenTypes()
+				Expect(err).To(MatchError("wild potato"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Transactor RequestSwap", func() {
+	var (
+		memoryLedger *plain.MemoryLedger
+		transactor   *plain.Transactor
+		aliceTokens  [][]byte
+		bobTokens    [][]byte
+		request      *token.SwapRequest
+	)
+
+	BeforeEach(func() {
+		memoryLedger = plain.NewMemoryLedger()
+		verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}}
+		err := verifier.ProcessTx("1", &mockid.PublicInfo{}, &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainImport{
+						PlainImport: &token.PlainImport{Outputs: []*token.PlainOutput{
+							{Owner: []byte("Alice"), Type: "USD", Quantity: 100},
+							{Owner: []byte("Bob"), Type: "EUR", Quantity: 90},
+						}},
+					},
+				},
+			},
+		}, memoryLedger)
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := plain.GenerateKeyForTest("1", 0)
+		Expect(err).NotTo(HaveOccurred())
+		aliceTokens = [][]byte{[]byte(key)}
+		key, err = plain.GenerateKeyForTest("1", 1)
+		Expect(err).NotTo(HaveOccurred())
+		bobTokens = [][]byte{[]byte(key)}
+
+		transactor = &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: memoryLedger}
+		request = &token.SwapRequest{
+			TokenIds:             aliceTokens,
+			Quantity:             60,
+			Counterparty:         []byte("Bob"),
+			CounterpartyTokenIds: bobTokens,
+			CounterpartyQuantity: 90,
+		}
+	})
+
+	It("creates a swap with the exchanged tokens and the change", func() {
+		tt, err := transactor.RequestSwap(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tt).To(Equal(&token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainSwap{
+						PlainSwap: &token.PlainSwap{
+							Inputs: []*token.InputId{{TxId: "1", Index: 0}, {TxId: "1", Index: 1}},
+							Outputs: []*token.PlainOutput{
+								{Owner: []byte("Bob"), Type: "USD", Quantity: 60},
+								{Owner: []byte("Alice"), Type: "EUR", Quantity: 90},
+								{Owner: []byte("Alice"), Type: "USD", Quantity: 40},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	Context("when the counterparty does not own its tokens", func() {
+		It("returns an error", func() {
+			request.CounterpartyTokenIds = aliceTokens
+			_, err := transactor.RequestSwap(request)
+			Expect(err).To(MatchError("the counterparty does not own inputs"))
+		})
+	})
+
+	Context("when both sides have the same token type", func() {
+		It("returns an error", func() {
+			request.Counterparty = []byte("Alice")
+			_, err := transactor.RequestSwap(request)
+			Expect(err).To(MatchError("the counterparty of a swap must be different from the requestor"))
+		})
+	})
+
+	Context("when the counterparty does not have enough tokens", func() {
+		It("returns an error", func() {
+			request.CounterpartyQuantity = 91
+			_, err := transactor.RequestSwap(request)
+			Expect(err).To(MatchError("total quantity [90] from CounterpartyTokenIds is less than quantity [91] to be swapped"))
+		})
+	})
+
+	Context("when a quantity is 0", func() {
+		It("returns an error", func() {
+			request.Quantity = 0
+			_, err := transactor.RequestSwap(request)
+			Expect(err).To(MatchError("the quantities to swap must be greater than 0"))
+		})
+	})
+
+	Context("when the swap is signed by the counterparty", func() {
+		It("is accepted by the verifier", func() {
+			tt, err := transactor.RequestSwap(request)
+			Expect(err).NotTo(HaveOccurred())
+			swap := tt.GetPlainAction().GetPlainSwap()
+			swap.Signatures = []*token.OwnerSignature{{Signer: []byte("Bob"), Signature: []byte("signature")}}
+
+			fakeDeserializer := &mockid.Deserializer{}
+			fakeDeserializer.DeserializeIdentityReturns(&mockid.Identity{}, nil)
+			verifier := &plain.Verifier{IssuingValidator: &mockid.IssuingValidator{}, Deserializer: fakeDeserializer}
+			alice := &mockid.PublicInfo{}
+			alice.PublicReturns([]byte("Alice"))
+			err = verifier.ProcessTx("2", alice, tt, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+
+			balances, err := transactor.GetBalance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(balances.Balances).To(Equal([]*token.TokenBalance{{Type: "EUR", Quantity: 90}, {Type: "USD", Quantity: 40}}))
+		})
+	})
+})
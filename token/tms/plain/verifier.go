@@ -11,30 +11,33 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
-	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/common/flogging"
+	"github.com/tradeline-tech/fabric/common/util"
 	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/protos/ledger/queryresult"
 	"github.com/tradeline-tech/fabric/protos/token"
 	"github.com/tradeline-tech/fabric/protos/utils"
 	"github.com/tradeline-tech/fabric/token/identity"
 	"github.com/tradeline-tech/fabric/token/ledger"
+	"github.com/tradeline-tech/fabric/token/tms"
 )
 
 const (
-	minUnicodeRuneValue   = 0            //U+0000
-	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
-	compositeKeyNamespace = "\x00"
-	tokenOutput           = "tokenOutput"
-	tokenRedeem           = "tokenRedeem"
-	tokenTx               = "tokenTx"
-	tokenDelegatedOutput  = "tokenDelegatedOutput"
-	tokenInput            = "tokenInput"
-	tokenDelegatedInput   = "tokenDelegateInput"
-	tokenNameSpace        = "tms"
+	tokenOutput          = "tokenOutput"
+	tokenRedeem          = "tokenRedeem"
+	tokenTx              = "tokenTx"
+	tokenDelegatedOutput = "tokenDelegatedOutput"
+	tokenInput           = "tokenInput"
+	tokenDelegatedInput  = "tokenDelegateInput"
+	tokenSupply          = "tokenSupply"
+	tokenSupplyDelta     = "tokenSupplyDelta"
+	tokenHistory         = "tokenHistory"
+	tokenNameSpace       = tms.Namespace
+	maxTokenTypeDecimals = 18
 )
 
 var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
@@ -42,6 +45,12 @@ var verifierLogger = flogging.MustGetLogger("token.tms.plain.verifier")
 // A Verifier validates and commits token transactions.
 type Verifier struct {
 	IssuingValidator identity.IssuingValidator
+	// RegistrationValidator establishes who can register token types;
+	// token type registrations are rejected if it is nil
+	RegistrationValidator identity.RegistrationValidator
+	// Deserializer is used to verify the signatures of the owners of the inputs
+	// of an action and to evaluate idemix and multisig owners
+	Deserializer identity.Deserializer
 }
 
 // ProcessTx checks that transactions are correct wrt. the most recent ledger state.
@@ -91,17 +100,180 @@ func (v *Verifier) checkAction(creator identity.PublicInfo, plainAction *token.P
 		return v.checkRedeemAction(creator, action.PlainRedeem, txID, simulator)
 	case *token.PlainTokenAction_PlainApprove:
 		return v.checkApproveAction(creator, action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainTransfer_From:
+		return v.checkTransferFromAction(creator, action.PlainTransfer_From, txID, simulator)
+	case *token.PlainTokenAction_PlainTypeRegistration:
+		return v.checkTypeRegistrationAction(creator, action.PlainTypeRegistration, txID, simulator)
+	case *token.PlainTokenAction_PlainSwap:
+		return v.checkSwapAction(creator, action.PlainSwap, txID, simulator)
 	default:
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("unknown plain token action: %T", action)}
 	}
 }
 
+// ValidateExpectation checks that the token transaction ttx fulfils the passed token expectation.
+// An import expectation is fulfilled by an import whose outputs are exactly the expected ones.
+// A transfer expectation is fulfilled by a transfer whose outputs start with the expected ones;
+// at most one additional output, of the same type, may return the remaining tokens to the creator.
+func (v *Verifier) ValidateExpectation(creator identity.PublicInfo, ttx *token.TokenTransaction, expectation *token.TokenExpectation) error {
+	plainExpectation := expectation.GetPlainExpectation()
+	if plainExpectation == nil {
+		return errors.New("no plain expectation in token expectation")
+	}
+	action := ttx.GetPlainAction()
+	if action == nil {
+		return errors.New("missing token action in token transaction")
+	}
+
+	switch e := plainExpectation.GetPayload().(type) {
+	case *token.PlainExpectation_ImportExpectation:
+		importAction := action.GetPlainImport()
+		if importAction == nil {
+			return errors.Errorf("expected an import action, got %T", action.GetData())
+		}
+		expected := e.ImportExpectation.GetOutputs()
+		if len(importAction.GetOutputs()) != len(expected) {
+			return errors.Errorf("number of outputs (%d) does not match the expectation (%d)", len(importAction.GetOutputs()), len(expected))
+		}
+		return checkExpectedOutputs(importAction.GetOutputs(), expected)
+	case *token.PlainExpectation_TransferExpectation:
+		transferAction := action.GetPlainTransfer()
+		if transferAction == nil {
+			return errors.Errorf("expected a transfer action, got %T", action.GetData())
+		}
+		outputs := transferAction.GetOutputs()
+		expected := e.TransferExpectation.GetOutputs()
+		if len(outputs) != len(expected) && len(outputs) != len(expected)+1 {
+			return errors.Errorf("number of outputs (%d) does not match the expectation (%d)", len(outputs), len(expected))
+		}
+		if len(outputs) > len(expected) {
+			remaining := outputs[len(expected)]
+			if len(expected) > 0 && remaining.GetType() != expected[0].GetType() {
+				return errors.Errorf("token type mismatch in remaining output (%s vs %s)", remaining.GetType(), expected[0].GetType())
+			}
+			if !bytes.Equal(remaining.GetOwner(), creator.Public()) {
+				return errors.New("the owner of the remaining output is not the creator of the transaction")
+			}
+		}
+		return checkExpectedOutputs(outputs[:len(expected)], expected)
+	default:
+		return errors.Errorf("expectation payload type not recognized: %T", e)
+	}
+}
+
+func checkExpectedOutputs(outputs []*token.PlainOutput, expected []*token.PlainOutput) error {
+	for i, output := range outputs {
+		if !proto.Equal(output, expected[i]) {
+			return errors.Errorf("output %d does not match the expectation", i)
+		}
+	}
+	return nil
+}
+
 func (v *Verifier) checkImportAction(creator identity.PublicInfo, importAction *token.PlainImport, txID string, simulator ledger.LedgerReader) error {
 	err := v.checkImportOutputs(importAction.GetOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	return v.checkImportPolicy(creator, txID, importAction)
+	err = v.checkImportPolicy(creator, txID, importAction)
+	if err != nil {
+		return err
+	}
+	return v.checkImportRegistry(creator, txID, importAction, simulator)
+}
+
+// checkImportRegistry enforces the allowed issuers and the maximum supply of the
+// registered token types imported by importAction. Token types that are not
+// registered are not subject to these checks.
+func (v *Verifier) checkImportRegistry(creator identity.PublicInfo, txID string, importAction *token.PlainImport, simulator ledger.LedgerReader) error {
+	types, quantities, err := importedQuantities(importAction)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid import transaction %s: %s", txID, err)}
+	}
+	for _, tokenType := range types {
+		quantity := quantities[tokenType]
+		definition, err := tms.GetTokenTypeDefinition(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		if definition == nil {
+			continue
+		}
+		if !tms.IsIssuer(creator.Public(), definition) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("creator is not an allowed issuer of token type '%s'", tokenType)}
+		}
+		if definition.GetMaxSupply() == 0 {
+			continue
+		}
+		supply, err := getTokenSupply(tokenType, definition, simulator)
+		if err != nil {
+			return err
+		}
+		if supply+quantity < supply || supply+quantity > definition.GetMaxSupply() {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("import of %d tokens of type '%s' exceeds the maximum supply (%d issued of %d)", quantity, tokenType, supply, definition.GetMaxSupply())}
+		}
+	}
+	return nil
+}
+
+// importedQuantities returns the token types imported by importAction, in order of
+// appearance, and the quantity imported for each of them
+func importedQuantities(importAction *token.PlainImport) ([]string, map[string]uint64, error) {
+	var types []string
+	quantities := make(map[string]uint64)
+	for _, output := range importAction.GetOutputs() {
+		previous, ok := quantities[output.GetType()]
+		if !ok {
+			types = append(types, output.GetType())
+		}
+		sum := previous + output.GetQuantity()
+		if sum < output.GetQuantity() {
+			return nil, nil, errors.Errorf("quantity overflow for token type '%s'", output.GetType())
+		}
+		quantities[output.GetType()] = sum
+	}
+	return types, quantities, nil
+}
+
+func (v *Verifier) checkTypeRegistrationAction(creator identity.PublicInfo, registration *token.PlainTypeRegistration, txID string, simulator ledger.LedgerReader) error {
+	definition := registration.GetDefinition()
+	if definition == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no token type definition in transaction: %s", txID)}
+	}
+	if definition.GetType() == "" {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no token type in token type definition in transaction: %s", txID)}
+	}
+	if definition.GetDecimals() > maxTokenTypeDecimals {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("decimals of token type '%s' must not exceed %d, got %d", definition.GetType(), maxTokenTypeDecimals, definition.GetDecimals())}
+	}
+	for i, issuer := range definition.GetIssuers() {
+		if len(issuer) == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("issuer %d of token type '%s' is empty", i, definition.GetType())}
+		}
+	}
+	if v.RegistrationValidator == nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type registration is not enabled, transaction: %s", txID)}
+	}
+	err := v.RegistrationValidator.Validate(creator, definition.GetType())
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type registration policy check failed: %s", err)}
+	}
+	existing, err := tms.GetTokenTypeDefinition(definition.GetType(), simulator)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type '%s' is already registered", definition.GetType())}
+	}
+	// tokens of the type may have been imported before its registration
+	supply, err := getTokenSupply(definition.GetType(), nil, simulator)
+	if err != nil {
+		return err
+	}
+	if definition.GetMaxSupply() != 0 && supply > definition.GetMaxSupply() {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("maximum supply of token type '%s' is lower than its current supply (%d issued of %d)", definition.GetType(), supply, definition.GetMaxSupply())}
+	}
+	return nil
 }
 
 func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string, simulator ledger.LedgerReader) error {
@@ -122,11 +294,25 @@ func (v *Verifier) checkImportOutputs(outputs []*token.PlainOutput, txID string,
 }
 
 func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+	message, err := transferAction.SigningBytes()
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("marshaling error: %s", err)}
+	}
+	return v.checkTransfer(creator, "transfer", message, transferAction, txID, simulator)
+}
+
+// checkTransfer performs the checks shared by transfers and redeems, where
+// message is what the owners of the inputs signed for the given action.
+func (v *Verifier) checkTransfer(creator identity.PublicInfo, actionName string, message []byte, transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
 	outputType, outputSum, err := v.checkTransferOutputs(transferAction.GetOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, transferAction.GetInputs(), txID, simulator)
+	proof, err := v.newOwnershipProof(creator, actionName, message, transferAction.GetSignatures(), txID)
+	if err != nil {
+		return err
+	}
+	inputType, inputSum, err := v.checkTransferInputs(proof, transferAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -140,8 +326,12 @@ func (v *Verifier) checkTransferAction(creator identity.PublicInfo, transferActi
 }
 
 func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *token.PlainTransfer, txID string, simulator ledger.LedgerReader) error {
+	message, err := redeemAction.RedeemSigningBytes()
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("marshaling error: %s", err)}
+	}
 	// first perform the same checking as transfer
-	err := v.checkTransferAction(creator, redeemAction, txID, simulator)
+	err = v.checkTransfer(creator, "redeem", message, redeemAction, txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -158,16 +348,33 @@ func (v *Verifier) checkRedeemAction(creator identity.PublicInfo, redeemAction *
 		return &customtx.InvalidTxError{Msg: fmt.Sprintf("owner should be nil in a redeem output")}
 	}
 
-	// if output[1] presents, its owner must be same as the creator
+	// if output[1] presents, its owner must be same as the creator or the owner of the inputs
 	if len(outputs) == 2 && !bytes.Equal(creator.Public(), outputs[1].Owner) {
-		println(hex.EncodeToString(creator.Public()))
-		println(hex.EncodeToString(outputs[1].Owner))
-		return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be original owner %s, but got %s", creator.Public(), outputs[1].Owner)}
+		inputOwner, err := v.getInputOwner(redeemAction.GetInputs()[0], simulator)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(inputOwner, outputs[1].Owner) {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("wrong owner for remaining tokens, should be original owner %s, but got %s", creator.Public(), outputs[1].Owner)}
+		}
 	}
 
 	return nil
 }
 
+// getInputOwner returns the owner of the output referenced by the passed input
+func (v *Verifier) getInputOwner(id *token.InputId, simulator ledger.LedgerReader) ([]byte, error) {
+	inputKey, err := createOutputKey(id.TxId, int(id.Index))
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for transfer input: %s", err)}
+	}
+	input, err := v.getOutput(inputKey, simulator)
+	if err != nil {
+		return nil, err
+	}
+	return input.GetOwner(), nil
+}
+
 func (v *Verifier) checkOutputDoesNotExist(index int, txID string, simulator ledger.LedgerReader) error {
 	outputID, err := createOutputKey(txID, index)
 	if err != nil {
@@ -203,7 +410,7 @@ func (v *Verifier) checkTransferOutputs(outputs []*token.PlainOutput, txID strin
 	return tokenType, tokenSum, nil
 }
 
-func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
+func (v *Verifier) checkTransferInputs(proof *ownershipProof, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) (string, uint64, error) {
 	tokenType := ""
 	inputSum := uint64(0)
 	processedIDs := make(map[string]bool)
@@ -216,9 +423,12 @@ func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*
 		if err != nil {
 			return "", 0, err
 		}
-		err = v.checkInputOwner(creator, input, inputKey)
+		owned, err := v.isOwner(input.GetOwner(), proof)
 		if err != nil {
-			return "", 0, err
+			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("cannot check the owner of transfer input with ID %s: %s", inputKey, err)}
+		}
+		if !owned {
+			return "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", inputKey)}
 		}
 		if tokenType == "" {
 			tokenType = input.GetType()
@@ -245,13 +455,6 @@ func (v *Verifier) checkTransferInputs(creator identity.PublicInfo, inputIDs []*
 	return tokenType, inputSum, nil
 }
 
-func (v *Verifier) checkInputOwner(creator identity.PublicInfo, input *token.PlainOutput, inputID string) error {
-	if !bytes.Equal(creator.Public(), input.Owner) {
-		return &customtx.InvalidTxError{Msg: fmt.Sprintf("transfer input with ID %s not owned by creator", inputID)}
-	}
-	return nil
-}
-
 func (v *Verifier) checkTxDoesNotExist(txID string, simulator ledger.LedgerReader) error {
 	txKey, err := createTxKey(txID)
 	if err != nil {
@@ -298,6 +501,110 @@ func (v *Verifier) commitProcess(txID string, creator identity.PublicInfo, ttx *
 	return nil
 }
 
+// IndexTx adds the transaction txNum of block blockNum, processed by ProcessTx, to the token history of
+// the identities it involves: the owners of its outputs and of the outputs it spends, and the owners and
+// delegatees of its delegated outputs and of the delegated outputs it spends. The history entries are
+// keyed by the position of the transaction, so that indexing never conflicts with other transactions.
+func (v *Verifier) IndexTx(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter, blockNum uint64, txNum uint64) error {
+	parties, err := v.getInvolvedParties(ttx, simulator)
+	if err != nil {
+		return err
+	}
+	for _, party := range parties {
+		historyKey, err := createHistoryKey(party, blockNum, txNum)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token history key: %s", err)}
+		}
+		err = simulator.SetState(tokenNameSpace, historyKey, []byte(txID))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getInvolvedParties returns the identities involved in ttx, without duplicates
+func (v *Verifier) getInvolvedParties(ttx *token.TokenTransaction, simulator ledger.LedgerReader) ([][]byte, error) {
+	plainAction := ttx.GetPlainAction()
+	if plainAction == nil {
+		return nil, nil
+	}
+
+	var inputs []*token.InputId
+	var outputs []*token.PlainOutput
+	var delegatedOutputs []*token.PlainDelegatedOutput
+	var delegatedInputs bool
+	switch action := plainAction.Data.(type) {
+	case *token.PlainTokenAction_PlainImport:
+		outputs = action.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		inputs = action.PlainTransfer.GetInputs()
+		outputs = action.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		inputs = action.PlainRedeem.GetInputs()
+		outputs = action.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		inputs = action.PlainApprove.GetInputs()
+		if action.PlainApprove.GetOutput() != nil {
+			outputs = []*token.PlainOutput{action.PlainApprove.GetOutput()}
+		}
+		delegatedOutputs = action.PlainApprove.GetDelegatedOutputs()
+	case *token.PlainTokenAction_PlainTransfer_From:
+		inputs = action.PlainTransfer_From.GetInputs()
+		delegatedInputs = true
+		outputs = action.PlainTransfer_From.GetOutputs()
+		if action.PlainTransfer_From.GetDelegatedOutput() != nil {
+			delegatedOutputs = []*token.PlainDelegatedOutput{action.PlainTransfer_From.GetDelegatedOutput()}
+		}
+	case *token.PlainTokenAction_PlainSwap:
+		inputs = action.PlainSwap.GetInputs()
+		outputs = action.PlainSwap.GetOutputs()
+	}
+
+	var parties [][]byte
+	seen := map[string]bool{}
+	add := func(ids ...[]byte) {
+		for _, id := range ids {
+			if len(id) != 0 && !seen[string(id)] {
+				seen[string(id)] = true
+				parties = append(parties, id)
+			}
+		}
+	}
+	for _, output := range outputs {
+		add(output.GetOwner())
+	}
+	for _, delegatedOutput := range delegatedOutputs {
+		add(delegatedOutput.GetOwner())
+		add(delegatedOutput.GetDelegatees()...)
+	}
+	for _, input := range inputs {
+		if delegatedInputs {
+			outputID, err := createDelegatedOutputKey(input.TxId, int(input.Index))
+			if err != nil {
+				return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+			}
+			delegatedOutput, err := v.getDelegatedOutput(outputID, simulator)
+			if err != nil {
+				return nil, err
+			}
+			add(delegatedOutput.GetOwner())
+			add(delegatedOutput.GetDelegatees()...)
+			continue
+		}
+		outputID, err := createOutputKey(input.TxId, int(input.Index))
+		if err != nil {
+			return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		output, err := v.getOutput(outputID, simulator)
+		if err != nil {
+			return nil, err
+		}
+		add(output.GetOwner())
+	}
+	return parties, nil
+}
+
 func (v *Verifier) commitAction(plainAction *token.PlainTokenAction, txID string, simulator ledger.LedgerWriter) (err error) {
 	switch action := plainAction.Data.(type) {
 	case *token.PlainTokenAction_PlainImport:
@@ -309,6 +616,12 @@ func (v *Verifier) commitAction(plainAction *token.PlainTokenAction, txID string
 		err = v.commitTransferAction(action.PlainRedeem, txID, simulator)
 	case *token.PlainTokenAction_PlainApprove:
 		err = v.commitApproveAction(action.PlainApprove, txID, simulator)
+	case *token.PlainTokenAction_PlainTransfer_From:
+		err = v.commitTransferFromAction(action.PlainTransfer_From, txID, simulator)
+	case *token.PlainTokenAction_PlainTypeRegistration:
+		err = v.commitTypeRegistrationAction(action.PlainTypeRegistration, txID, simulator)
+	case *token.PlainTokenAction_PlainSwap:
+		err = v.commitSwapAction(action.PlainSwap, txID, simulator)
 	}
 	return
 }
@@ -325,9 +638,69 @@ func (v *Verifier) commitImportAction(importAction *token.PlainImport, txID stri
 			return err
 		}
 	}
+	return v.updateTokenSupply(importAction, txID, simulator)
+}
+
+// updateTokenSupply adds the quantities imported by importAction to the supply of their token types.
+// Only the supply of the registered token types with a maximum supply is read and updated in place,
+// as the cap requires the imports of these types to be serialized. The quantities imported of the
+// other token types, registered or not, are recorded under a key of the transaction, so that their
+// imports do not conflict with each other; they are aggregated when the supply is queried or when
+// the token type is registered with a maximum supply.
+func (v *Verifier) updateTokenSupply(importAction *token.PlainImport, txID string, simulator ledger.LedgerWriter) error {
+	types, quantities, err := importedQuantities(importAction)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: err.Error()}
+	}
+	for _, tokenType := range types {
+		quantity := quantities[tokenType]
+		definition, err := tms.GetTokenTypeDefinition(tokenType, simulator)
+		if err != nil {
+			return err
+		}
+		if definition == nil || definition.GetMaxSupply() == 0 {
+			err = addTokenSupplyDelta(tokenType, txID, quantity, simulator)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		supply, err := getTokenSupply(tokenType, definition, simulator)
+		if err != nil {
+			return err
+		}
+		if supply+quantity < supply {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("supply overflow for token type '%s'", tokenType)}
+		}
+		err = setTokenSupply(tokenType, supply+quantity, simulator)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (v *Verifier) commitTypeRegistrationAction(registration *token.PlainTypeRegistration, txID string, simulator ledger.LedgerWriter) error {
+	definition := registration.GetDefinition()
+	typeKey, err := tms.CreateTokenTypeKey(definition.GetType())
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token type key: %s", err)}
+	}
+	// the supply keeps accounting for the tokens imported before the registration; for a type with
+	// a maximum supply, their quantities are aggregated under the key that the following imports update
+	if definition.GetMaxSupply() != 0 {
+		supply, err := getTokenSupply(definition.GetType(), nil, simulator)
+		if err != nil {
+			return err
+		}
+		err = setTokenSupply(definition.GetType(), supply, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return simulator.SetState(tokenNameSpace, typeKey, utils.MarshalOrPanic(definition))
+}
+
 // commitTransferAction is called for both transfer and redeem transactions
 // Check the owner of each output to determine how to generate the key
 func (v *Verifier) commitTransferAction(transferAction *token.PlainTransfer, txID string, simulator ledger.LedgerWriter) error {
@@ -351,12 +724,117 @@ func (v *Verifier) commitTransferAction(transferAction *token.PlainTransfer, txI
 	return v.markInputsSpent(txID, transferAction.GetInputs(), simulator)
 }
 
+// checkSwapAction checks that the ownership of every input of the swap is proven by the creator
+// and the parties that signed the swap, and that the quantity of each token type is conserved
+func (v *Verifier) checkSwapAction(creator identity.PublicInfo, swapAction *token.PlainSwap, txID string, simulator ledger.LedgerReader) error {
+	if len(swapAction.GetInputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in swap with ID %s", txID)}
+	}
+	if len(swapAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in swap with ID %s", txID)}
+	}
+
+	outputSums := make(map[string]uint64)
+	for i, output := range swapAction.GetOutputs() {
+		err := v.checkOutputDoesNotExist(i, txID, simulator)
+		if err != nil {
+			return err
+		}
+		if len(output.GetOwner()) == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d has no owner in swap with ID %s", i, txID)}
+		}
+		if output.GetQuantity() == 0 {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("output %d quantity is 0 in swap with ID %s", i, txID)}
+		}
+		if outputSums[output.GetType()]+output.GetQuantity() < outputSums[output.GetType()] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("quantity overflow for token type '%s' in swap with ID %s", output.GetType(), txID)}
+		}
+		outputSums[output.GetType()] += output.GetQuantity()
+	}
+
+	message, err := swapAction.SigningBytes()
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("marshaling error: %s", err)}
+	}
+	proof, err := v.newOwnershipProof(creator, "swap", message, swapAction.GetSignatures(), txID)
+	if err != nil {
+		return err
+	}
+
+	inputSums := make(map[string]uint64)
+	processedIDs := make(map[string]bool)
+	for _, id := range swapAction.GetInputs() {
+		inputKey, err := createOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID for swap input: %s", err)}
+		}
+		if processedIDs[inputKey] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single swap with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+		input, err := v.getOutput(inputKey, simulator)
+		if err != nil {
+			return err
+		}
+		owned, err := v.isOwner(input.GetOwner(), proof)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("cannot check the owner of swap input with ID %s: %s", inputKey, err)}
+		}
+		if !owned {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("swap input with ID %s not owned by the creator or a signer of the swap", inputKey)}
+		}
+		spentKey, err := createSpentKey(id.TxId, int(id.Index))
+		if err != nil {
+			return err
+		}
+		spent, err := v.isSpent(spentKey, simulator)
+		if err != nil {
+			return err
+		}
+		if spent {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for swap has already been spent", inputKey)}
+		}
+		if inputSums[input.GetType()]+input.GetQuantity() < inputSums[input.GetType()] {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("quantity overflow for token type '%s' in swap with ID %s", input.GetType(), txID)}
+		}
+		inputSums[input.GetType()] += input.GetQuantity()
+	}
+
+	if len(inputSums) != len(outputSums) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for swap with ID %s", txID)}
+	}
+	for tokenType, inputSum := range inputSums {
+		outputSum, ok := outputSums[tokenType]
+		if !ok {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for swap with ID %s", txID)}
+		}
+		if outputSum != inputSum {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs of type '%s' for swap with ID %s (%d vs %d)", tokenType, txID, outputSum, inputSum)}
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) commitSwapAction(swapAction *token.PlainSwap, txID string, simulator ledger.LedgerWriter) error {
+	for i, output := range swapAction.GetOutputs() {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = v.addOutput(outputID, output, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return v.markInputsSpent(txID, swapAction.GetInputs(), simulator)
+}
+
 func (v *Verifier) checkApproveAction(creator identity.PublicInfo, approveAction *token.PlainApprove, txID string, simulator ledger.LedgerReader) error {
 	outputType, outputSum, err := v.checkApproveOutputs(creator, approveAction.GetOutput(), approveAction.GetDelegatedOutputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
-	inputType, inputSum, err := v.checkTransferInputs(creator, approveAction.GetInputs(), txID, simulator)
+	inputType, inputSum, err := v.checkTransferInputs(&ownershipProof{creator: creator}, approveAction.GetInputs(), txID, simulator)
 	if err != nil {
 		return err
 	}
@@ -465,6 +943,127 @@ func (v *Verifier) checkApproveOutputs(creator identity.PublicInfo, output *toke
 	return tokenType, tokenSum, nil
 }
 
+func (v *Verifier) checkTransferFromAction(creator identity.PublicInfo, transferFromAction *token.PlainTransferFrom, txID string, simulator ledger.LedgerReader) error {
+	if len(transferFromAction.GetOutputs()) == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("no outputs in transferFrom transaction: %s", txID)}
+	}
+	outputType, outputSum, err := v.checkTransferOutputs(transferFromAction.GetOutputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	owner, inputType, inputSum, err := v.checkDelegatedInputs(creator, transferFromAction.GetInputs(), txID, simulator)
+	if err != nil {
+		return err
+	}
+	if outputType != inputType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and outputs for transferFrom with ID %s (%s vs %s)", txID, outputType, inputType)}
+	}
+
+	// the remaining allowance, if any, must stay with the same owner and spender
+	delegatedOutput := transferFromAction.GetDelegatedOutput()
+	if delegatedOutput != nil {
+		err = v.checkTransferFromDelegatedOutput(creator, owner, inputType, delegatedOutput, txID, simulator)
+		if err != nil {
+			return err
+		}
+		outputSum += delegatedOutput.GetQuantity()
+	}
+	if outputSum != inputSum {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token sum mismatch in inputs and outputs for transferFrom with ID %s (%d vs %d)", txID, outputSum, inputSum)}
+	}
+	return nil
+}
+
+func (v *Verifier) checkDelegatedInputs(creator identity.PublicInfo, inputIDs []*token.InputId, txID string, simulator ledger.LedgerReader) ([]byte, string, uint64, error) {
+	if len(inputIDs) == 0 {
+		return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("no inputs in transferFrom transaction: %s", txID)}
+	}
+	var owner []byte
+	tokenType := ""
+	inputSum := uint64(0)
+	processedIDs := make(map[string]bool)
+	for _, id := range inputIDs {
+		inputKey, err := createDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating delegated output ID for transferFrom input: %s", err)}
+		}
+		input, err := v.getDelegatedOutput(inputKey, simulator)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if !isDelegatee(creator.Public(), input) {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("transferFrom input with ID %s not delegated to creator", inputKey)}
+		}
+		if owner == nil {
+			owner = input.GetOwner()
+		} else if !bytes.Equal(owner, input.GetOwner()) {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple owners in transferFrom input for txID: %s", txID)}
+		}
+		if tokenType == "" {
+			tokenType = input.GetType()
+		} else if tokenType != input.GetType() {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("multiple token types in transferFrom input for txID: %s (%s, %s)", txID, tokenType, input.GetType())}
+		}
+		if processedIDs[inputKey] {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("token input '%s' spent more than once in single transferFrom with txID '%s'", inputKey, txID)}
+		}
+		processedIDs[inputKey] = true
+		inputSum += input.GetQuantity()
+		spentKey, err := createSpentDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return nil, "", 0, err
+		}
+		spent, err := v.isSpent(spentKey, simulator)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if spent {
+			return nil, "", 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transferFrom has already been spent", inputKey)}
+		}
+	}
+	return owner, tokenType, inputSum, nil
+}
+
+func (v *Verifier) checkTransferFromDelegatedOutput(creator identity.PublicInfo, owner []byte, tokenType string, delegatedOutput *token.PlainDelegatedOutput, txID string, simulator ledger.LedgerReader) error {
+	if !bytes.Equal(delegatedOutput.GetOwner(), owner) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the owner of the delegated output for transferFrom txID '%s' is invalid", txID)}
+	}
+	if len(delegatedOutput.GetDelegatees()) != 1 || !bytes.Equal(delegatedOutput.GetDelegatees()[0], creator.Public()) {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the delegated output for transferFrom txID '%s' must be delegated to the creator only", txID)}
+	}
+	if delegatedOutput.GetType() != tokenType {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("token type mismatch in inputs and delegated output for transferFrom with ID %s (%s vs %s)", txID, delegatedOutput.GetType(), tokenType)}
+	}
+	if delegatedOutput.GetQuantity() == 0 {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("the delegated output quantity is 0 in transferFrom transaction: %s", txID)}
+	}
+	return v.checkDelegatedOutputDoesNotExist(0, txID, simulator)
+}
+
+func (v *Verifier) commitTransferFromAction(transferFromAction *token.PlainTransferFrom, txID string, simulator ledger.LedgerWriter) error {
+	for i, output := range transferFromAction.GetOutputs() {
+		outputID, err := createOutputKey(txID, i)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating output ID: %s", err)}
+		}
+		err = v.addOutput(outputID, output, simulator)
+		if err != nil {
+			return err
+		}
+	}
+	if transferFromAction.GetDelegatedOutput() != nil {
+		outputID, err := createDelegatedOutputKey(txID, 0)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating delegated output ID: %s", err)}
+		}
+		err = v.addDelegatedOutput(outputID, transferFromAction.GetDelegatedOutput(), simulator)
+		if err != nil {
+			return err
+		}
+	}
+	return v.markDelegatedInputsSpent(txID, transferFromAction.GetInputs(), simulator)
+}
+
 func (v *Verifier) addOutput(outputID string, output *token.PlainOutput, simulator ledger.LedgerWriter) error {
 	outputBytes := utils.MarshalOrPanic(output)
 
@@ -505,6 +1104,21 @@ func (v *Verifier) markInputsSpent(txID string, inputs []*token.InputId, simulat
 	return nil
 }
 
+func (v *Verifier) markDelegatedInputsSpent(txID string, inputs []*token.InputId, simulator ledger.LedgerWriter) error {
+	for _, id := range inputs {
+		inputID, err := createSpentDelegatedOutputKey(id.TxId, int(id.Index))
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating spent key: %s", err)}
+		}
+		verifierLogger.Debugf("marking delegated input '%s' as spent", inputID)
+		err = simulator.SetState(tokenNameSpace, inputID, TokenInputSpentMarker)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*token.PlainOutput, error) {
 	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
 	if err != nil {
@@ -524,6 +1138,111 @@ func (v *Verifier) getOutput(outputID string, simulator ledger.LedgerReader) (*t
 	return output, nil
 }
 
+func (v *Verifier) getDelegatedOutput(outputID string, simulator ledger.LedgerReader) (*token.PlainDelegatedOutput, error) {
+	outputBytes, err := simulator.GetState(tokenNameSpace, outputID)
+	if err != nil {
+		return nil, err
+	}
+	if len(outputBytes) == 0 {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("input with ID %s for transferFrom does not exist", outputID)}
+	}
+	output := &token.PlainDelegatedOutput{}
+	err = proto.Unmarshal(outputBytes, output)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("unmarshaling error: %s", err)}
+	}
+	return output, nil
+}
+
+// isDelegatee checks whether the passed identity is one of the delegatees of delegatedOutput.
+func isDelegatee(id []byte, delegatedOutput *token.PlainDelegatedOutput) bool {
+	for _, delegatee := range delegatedOutput.GetDelegatees() {
+		if bytes.Equal(id, delegatee) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTokenSupply returns the quantity of tokens of type tokenType imported so far, given the
+// definition of the type, or nil if the type is not registered. The supply of a registered type with
+// a maximum supply is kept under a single key; the supply of the other types is the sum of the
+// quantities recorded by each import.
+func getTokenSupply(tokenType string, definition *token.TokenTypeDefinition, simulator ledger.LedgerReader) (uint64, error) {
+	if definition == nil || definition.GetMaxSupply() == 0 {
+		return sumTokenSupplyDeltas(tokenType, simulator)
+	}
+	supplyKey, err := createTokenSupplyKey(tokenType)
+	if err != nil {
+		return 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token supply key: %s", err)}
+	}
+	supplyBytes, err := simulator.GetState(tokenNameSpace, supplyKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(supplyBytes) == 0 {
+		return 0, nil
+	}
+	supply, err := strconv.ParseUint(string(supplyBytes), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error parsing supply of token type '%s'", tokenType)
+	}
+	return supply, nil
+}
+
+func setTokenSupply(tokenType string, supply uint64, simulator ledger.LedgerWriter) error {
+	supplyKey, err := createTokenSupplyKey(tokenType)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token supply key: %s", err)}
+	}
+	return simulator.SetState(tokenNameSpace, supplyKey, []byte(strconv.FormatUint(supply, 10)))
+}
+
+// addTokenSupplyDelta records the quantity of tokens of type tokenType imported by the transaction txID
+func addTokenSupplyDelta(tokenType string, txID string, quantity uint64, simulator ledger.LedgerWriter) error {
+	deltaKey, err := createTokenSupplyDeltaKey(tokenType, txID)
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token supply delta key: %s", err)}
+	}
+	return simulator.SetState(tokenNameSpace, deltaKey, []byte(strconv.FormatUint(quantity, 10)))
+}
+
+// sumTokenSupplyDeltas returns the sum of the quantities of tokens of type tokenType recorded by the imports
+func sumTokenSupplyDeltas(tokenType string, simulator ledger.LedgerReader) (uint64, error) {
+	prefix, err := createTokenSupplyDeltaPrefix(tokenType)
+	if err != nil {
+		return 0, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token supply delta prefix: %s", err)}
+	}
+	iterator, err := simulator.GetStateRangeScanIterator(tokenNameSpace, prefix, tms.PrefixEnd(prefix))
+	if err != nil {
+		return 0, err
+	}
+	defer iterator.Close()
+
+	var supply uint64
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return 0, err
+		}
+		if next == nil {
+			return supply, nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return 0, errors.New("failed to retrieve token supply: casting error")
+		}
+		quantity, err := strconv.ParseUint(string(result.Value), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "error parsing supply delta of token type '%s'", tokenType)
+		}
+		if supply+quantity < supply {
+			return 0, errors.Errorf("supply overflow for token type '%s'", tokenType)
+		}
+		supply += quantity
+	}
+}
+
 // isSpent checks whether an output token with identifier outputID has been spent.
 func (v *Verifier) isSpent(spentKey string, simulator ledger.LedgerReader) (bool, error) {
 	verifierLogger.Debugf("checking if input with ID '%s' has been spent", spentKey)
@@ -534,52 +1253,57 @@ func (v *Verifier) isSpent(spentKey string, simulator ledger.LedgerReader) (bool
 // Create a ledger key for an individual output in a token transaction, as a function of
 // the transaction ID, and the index of the output
 func createOutputKey(txID string, index int) (string, error) {
-	return createCompositeKey(tokenOutput, []string{txID, strconv.Itoa(index)})
+	return tms.CreateCompositeKey(tokenOutput, []string{txID, strconv.Itoa(index)})
 }
 
 // Create a ledger key for a redeem output in a token transaction, as a function of
 // the transaction ID, and the index of the output
 func createRedeemKey(txID string, index int) (string, error) {
-	return createCompositeKey(tokenRedeem, []string{txID, strconv.Itoa(index)})
+	return tms.CreateCompositeKey(tokenRedeem, []string{txID, strconv.Itoa(index)})
 }
 
 // Create a ledger key for a token transaction, as a function of the transaction ID
 func createTxKey(txID string) (string, error) {
-	return createCompositeKey(tokenTx, []string{txID})
+	return tms.CreateCompositeKey(tokenTx, []string{txID})
+}
+
+// Create a ledger key for an entry of the token history of an identity, as a function of the
+// hash of the identity and of the position of the transaction in the blockchain. The block and
+// transaction numbers are zero-padded, so that the entries are sorted by position.
+func createHistoryKey(id []byte, blockNum uint64, txNum uint64) (string, error) {
+	return tms.CreateCompositeKey(tokenHistory, []string{historyOwner(id), fmt.Sprintf("%020d", blockNum), fmt.Sprintf("%020d", txNum)})
+}
+
+// Create the prefix of the ledger keys of the token history of an identity
+func createHistoryPrefix(id []byte) (string, error) {
+	return tms.CreateCompositeKey(tokenHistory, []string{historyOwner(id)})
+}
+
+// historyOwner returns the representation of an identity in the keys of its token history
+func historyOwner(id []byte) string {
+	return hex.EncodeToString(util.ComputeSHA256(id))
 }
 
 // Create a ledger key for a spent individual output in a token transaction, as a function of
 // the transaction ID, and the index of the output
 func createSpentKey(txID string, index int) (string, error) {
-	return createCompositeKey("tokenInput", []string{txID, strconv.Itoa(index)})
+	return tms.CreateCompositeKey("tokenInput", []string{txID, strconv.Itoa(index)})
 }
 
-// createCompositeKey and its related functions and consts copied from core/chaincode/shim/chaincode.go
-func createCompositeKey(objectType string, attributes []string) (string, error) {
-	if err := validateCompositeKeyAttribute(objectType); err != nil {
-		return "", err
-	}
-	ck := compositeKeyNamespace + objectType + string(minUnicodeRuneValue)
-	for _, att := range attributes {
-		if err := validateCompositeKeyAttribute(att); err != nil {
-			return "", err
-		}
-		ck += att + string(minUnicodeRuneValue)
-	}
-	return ck, nil
+// Create a ledger key for the supply of a registered token type with a maximum supply
+func createTokenSupplyKey(tokenType string) (string, error) {
+	return tms.CreateCompositeKey(tokenSupply, []string{tokenType})
 }
 
-func validateCompositeKeyAttribute(str string) error {
-	if !utf8.ValidString(str) {
-		return errors.Errorf("not a valid utf8 string: [%x]", str)
-	}
-	for index, runeValue := range str {
-		if runeValue == minUnicodeRuneValue || runeValue == maxUnicodeRuneValue {
-			return errors.Errorf(`input contain unicode %#U starting at position [%d]. %#U and %#U are not allowed in the input attribute of a composite key`,
-				runeValue, index, minUnicodeRuneValue, maxUnicodeRuneValue)
-		}
-	}
-	return nil
+// Create a ledger key for the quantity of tokens of a type imported by a transaction, as a function
+// of the token type and the transaction ID
+func createTokenSupplyDeltaKey(tokenType string, txID string) (string, error) {
+	return tms.CreateCompositeKey(tokenSupplyDelta, []string{tokenType, txID})
+}
+
+// Create the prefix of the ledger keys of the quantities of tokens of a type imported by the transactions
+func createTokenSupplyDeltaPrefix(tokenType string) (string, error) {
+	return tms.CreateCompositeKey(tokenSupplyDelta, []string{tokenType})
 }
 
 func parseCompositeKeyBytes(keyBytes []byte) string {
@@ -593,13 +1317,13 @@ func getCompositeKeyBytes(compositeKey string) []byte {
 // Create a ledger key for an individual delegated output in a token transaction, as a function of
 // the transaction ID, and the index of the output
 func createDelegatedOutputKey(txID string, index int) (string, error) {
-	return createCompositeKey(tokenDelegatedOutput, []string{txID, strconv.Itoa(index)})
+	return tms.CreateCompositeKey(tokenDelegatedOutput, []string{txID, strconv.Itoa(index)})
 }
 
 // Create a ledger key for a spent individual delegated output in a token transaction, as a function of
 // the transaction ID, and the index of the delegated output
 func createSpentDelegatedOutputKey(txID string, index int) (string, error) {
-	return createCompositeKey(tokenDelegatedInput, []string{txID, strconv.Itoa(index)})
+	return tms.CreateCompositeKey(tokenDelegatedInput, []string{txID, strconv.Itoa(index)})
 }
 
 func (v *Verifier) checkDelegatedOutputDoesNotExist(index int, txID string, simulator ledger.LedgerReader) error {
@@ -8,6 +8,8 @@ package plain_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -25,10 +27,11 @@ import (
 
 var _ = Describe("Verifier", func() {
 	var (
-		fakePublicInfo       *mockid.PublicInfo
-		fakeIssuingValidator *mockid.IssuingValidator
-		fakeLedger           *mockledger.LedgerWriter
-		memoryLedger         *plain.MemoryLedger
+		fakePublicInfo            *mockid.PublicInfo
+		fakeIssuingValidator      *mockid.IssuingValidator
+		fakeRegistrationValidator *mockid.RegistrationValidator
+		fakeLedger                *mockledger.LedgerWriter
+		memoryLedger              *plain.MemoryLedger
 
 		importTransaction *token.TokenTransaction
 		importTxID        string
@@ -39,6 +42,7 @@ var _ = Describe("Verifier", func() {
 	BeforeEach(func() {
 		fakePublicInfo = &mockid.PublicInfo{}
 		fakeIssuingValidator = &mockid.IssuingValidator{}
+		fakeRegistrationValidator = &mockid.RegistrationValidator{}
 		fakeLedger = &mockledger.LedgerWriter{}
 		fakeLedger.SetStateReturns(nil)
 
@@ -59,7 +63,8 @@ var _ = Describe("Verifier", func() {
 		}
 
 		verifier = &plain.Verifier{
-			IssuingValidator: fakeIssuingValidator,
+			IssuingValidator:      fakeIssuingValidator,
+			RegistrationValidator: fakeRegistrationValidator,
 		}
 	})
 
@@ -81,7 +86,13 @@ var _ = Describe("Verifier", func() {
 			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, fakeLedger)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeLedger.SetStateCallCount()).To(Equal(3))
+			Expect(fakeLedger.SetStateCallCount()).To(Equal(5))
+			// the supply of unregistered token types is not read, so that their imports do not conflict
+			for i := 0; i < fakeLedger.GetStateCallCount(); i++ {
+				_, k := fakeLedger.GetStateArgsForCall(i)
+				Expect(k).NotTo(HavePrefix("\x00tokenSupply"))
+			}
+			Expect(fakeLedger.GetStateRangeScanIteratorCallCount()).To(Equal(0))
 
 			outputBytes, err := proto.Marshal(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 111})
 			Expect(err).NotTo(HaveOccurred())
@@ -99,9 +110,19 @@ var _ = Describe("Verifier", func() {
 			Expect(k).To(Equal(expectedOutput))
 			Expect(td).To(Equal(outputBytes))
 
+			ns, k, td = fakeLedger.SetStateArgsForCall(2)
+			Expect(ns).To(Equal("tms"))
+			Expect(k).To(Equal(strings.Join([]string{"", "tokenSupplyDelta", "TOK1", "0", ""}, "\x00")))
+			Expect(td).To(Equal([]byte("111")))
+
+			ns, k, td = fakeLedger.SetStateArgsForCall(3)
+			Expect(ns).To(Equal("tms"))
+			Expect(k).To(Equal(strings.Join([]string{"", "tokenSupplyDelta", "TOK2", "0", ""}, "\x00")))
+			Expect(td).To(Equal([]byte("222")))
+
 			ttxBytes, err := proto.Marshal(importTransaction)
 			Expect(err).NotTo(HaveOccurred())
-			ns, k, td = fakeLedger.SetStateArgsForCall(2)
+			ns, k, td = fakeLedger.SetStateArgsForCall(4)
 			Expect(ns).To(Equal("tms"))
 			expectedOutput = strings.Join([]string{"", "tokenTx", "0", ""}, "\x00")
 			Expect(k).To(Equal(expectedOutput))
@@ -269,7 +290,7 @@ var _ = Describe("Verifier", func() {
 
 		Context("when a transaction has invalid characters in key", func() {
 			BeforeEach(func() {
-				importTxID = string(0)
+				importTxID = string(rune(0))
 			})
 
 			It("fails when creating the ledger key for the output", func() {
@@ -281,7 +302,7 @@ var _ = Describe("Verifier", func() {
 
 		Context("when a transaction has invalid characters in key", func() {
 			BeforeEach(func() {
-				importTxID = string(0)
+				importTxID = string(rune(0))
 			})
 
 			It("fails when creating the ledger key for the first output", func() {
@@ -326,7 +347,9 @@ var _ = Describe("Verifier", func() {
 			BeforeEach(func() {
 				fakeLedger.GetStateReturnsOnCall(0, nil, nil)
 				fakeLedger.GetStateReturnsOnCall(1, nil, nil)
-				fakeLedger.GetStateReturnsOnCall(2, nil, errors.New("error reading transaction"))
+				fakeLedger.GetStateReturnsOnCall(2, nil, nil)
+				fakeLedger.GetStateReturnsOnCall(3, nil, nil)
+				fakeLedger.GetStateReturnsOnCall(4, nil, errors.New("error reading transaction"))
 			})
 
 			It("returns an error", func() {
@@ -334,9 +357,10 @@ var _ = Describe("Verifier", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError("error reading transaction"))
 
-				Expect(fakeLedger.GetStateCallCount()).To(Equal(3))
+				// the output keys and the registry entries of TOK1 and TOK2 are read first
+				Expect(fakeLedger.GetStateCallCount()).To(Equal(5))
 				Expect(fakeLedger.SetStateCallCount()).To(Equal(0))
-				ns, k := fakeLedger.GetStateArgsForCall(2)
+				ns, k := fakeLedger.GetStateArgsForCall(4)
 				expectedTx := strings.Join([]string{"", "tokenTx", "0", ""}, "\x00")
 				Expect(k).To(Equal(expectedTx))
 				Expect(ns).To(Equal("tms"))
@@ -345,7 +369,7 @@ var _ = Describe("Verifier", func() {
 
 		Context("when a tx with the same txID already exists", func() {
 			BeforeEach(func() {
-				fakeLedger.GetStateReturnsOnCall(2, []byte("fake-tx"), nil)
+				fakeLedger.GetStateReturnsOnCall(4, []byte("fake-tx"), nil)
 			})
 
 			It("returns an error", func() {
@@ -422,6 +446,19 @@ var _ = Describe("Verifier", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(bytes.Equal(spentMarker, plain.TokenInputSpentMarker)).To(BeTrue())
 			})
+
+			It("indexes the transaction once for each involved party", func() {
+				err := verifier.IndexTx(transferTxID, transferTransaction, memoryLedger, 3, 1)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, owner := range []string{"owner-1", "owner-2"} {
+					ownerHash := sha256.Sum256([]byte(owner))
+					historyKey := strings.Join([]string{"", "tokenHistory", hex.EncodeToString(ownerHash[:]), "00000000000000000003", "00000000000000000001", ""}, "\x00")
+					txID, err := memoryLedger.GetState("tms", historyKey)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(txID)).To(Equal(transferTxID))
+				}
+			})
 		})
 
 		Context("when a non-existent input is referenced", func() {
@@ -869,7 +906,7 @@ var _ = Describe("Verifier", func() {
 
 			It("returns an error", func() {
 				err := verifier.ProcessTx(redeemTxID, fakePublicInfo, redeemTransaction, memoryLedger)
-				Expect(err).To(MatchError(fmt.Sprintf(fmt.Sprintf("wrong owner for remaining tokens, should be original owner owner-1, but got owner-2"))))
+				Expect(err).To(MatchError("wrong owner for remaining tokens, should be original owner owner-1, but got owner-2"))
 			})
 		})
 	})
@@ -1151,4 +1188,621 @@ var _ = Describe("Verifier", func() {
 			})
 		})
 	})
+
+	Describe("Test ProcessTx PlainTransferFrom with memory ledger", func() {
+		var (
+			approveTransaction      *token.TokenTransaction
+			transferFromTransaction *token.TokenTransaction
+			transferFromTxID        string
+		)
+
+		BeforeEach(func() {
+			approveTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainApprove{
+							PlainApprove: &token.PlainApprove{
+								Inputs: []*token.InputId{
+									{TxId: "0", Index: 0},
+								},
+								DelegatedOutputs: []*token.PlainDelegatedOutput{
+									{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("spender")}, Type: "TOK1", Quantity: 100},
+								},
+								Output: &token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11},
+							},
+						},
+					},
+				},
+			}
+			transferFromTxID = "2"
+			transferFromTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer_From{
+							PlainTransfer_From: &token.PlainTransferFrom{
+								Inputs: []*token.InputId{
+									{TxId: "1", Index: 0},
+								},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("Alice"), Type: "TOK1", Quantity: 60},
+								},
+								DelegatedOutput: &token.PlainDelegatedOutput{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("spender")}, Type: "TOK1", Quantity: 40},
+							},
+						},
+					},
+				},
+			}
+
+			memoryLedger = plain.NewMemoryLedger()
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+			err = verifier.ProcessTx("1", fakePublicInfo, approveTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			fakePublicInfo.PublicReturns([]byte("spender"))
+		})
+
+		Context("when a valid transferFrom is provided", func() {
+			It("is processed successfully", func() {
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				po, err := memoryLedger.GetState("tms", string("\x00")+"tokenOutput"+string("\x00")+"2"+string("\x00")+"0"+string("\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				output := &token.PlainOutput{}
+				err = proto.Unmarshal(po, output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal(&token.PlainOutput{Owner: []byte("Alice"), Type: "TOK1", Quantity: 60}))
+
+				do, err := memoryLedger.GetState("tms", string("\x00")+"tokenDelegatedOutput"+string("\x00")+"2"+string("\x00")+"0"+string("\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				delegatedOutput := &token.PlainDelegatedOutput{}
+				err = proto.Unmarshal(do, delegatedOutput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delegatedOutput).To(Equal(&token.PlainDelegatedOutput{Owner: []byte("owner-1"), Delegatees: [][]byte{[]byte("spender")}, Type: "TOK1", Quantity: 40}))
+
+				spentMarker, err := memoryLedger.GetState("tms", string("\x00")+"tokenDelegateInput"+string("\x00")+"1"+string("\x00")+"0"+string("\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bytes.Equal(spentMarker, plain.TokenInputSpentMarker)).To(BeTrue())
+			})
+		})
+
+		Context("when the delegated input has already been spent", func() {
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = verifier.ProcessTx("3", fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenDelegatedOutput\x001\x000\x00 for transferFrom has already been spent"}))
+			})
+		})
+
+		Context("when the creator is not a delegatee of the input", func() {
+			It("returns an InvalidTxError", func() {
+				fakePublicInfo.PublicReturns([]byte("mallory"))
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "transferFrom input with ID \x00tokenDelegatedOutput\x001\x000\x00 not delegated to creator"}))
+			})
+		})
+
+		Context("when a non-existent delegated input is referenced", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().Inputs = []*token.InputId{{TxId: "1", Index: 1}}
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenDelegatedOutput\x001\x001\x00 for transferFrom does not exist"}))
+			})
+		})
+
+		Context("when the same delegated input is spent twice within the same tx", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().Inputs = []*token.InputId{{TxId: "1", Index: 0}, {TxId: "1", Index: 0}}
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token input '\x00tokenDelegatedOutput\x001\x000\x00' spent more than once in single transferFrom with txID '2'"}))
+			})
+		})
+
+		Context("when the leftover allowance does not match", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Quantity = 50
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs for transferFrom with ID 2 (110 vs 100)"}))
+			})
+		})
+
+		Context("when the leftover allowance changes owner", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Owner = []byte("spender")
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the owner of the delegated output for transferFrom txID '2' is invalid"}))
+			})
+		})
+
+		Context("when the leftover allowance is delegated to someone else", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().DelegatedOutput.Delegatees = [][]byte{[]byte("Alice")}
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "the delegated output for transferFrom txID '2' must be delegated to the creator only"}))
+			})
+		})
+
+		Context("when the output type does not match the input type", func() {
+			It("returns an InvalidTxError", func() {
+				transferFromTransaction.GetPlainAction().GetPlainTransfer_From().Outputs[0].Type = "TOK2"
+				err := verifier.ProcessTx(transferFromTxID, fakePublicInfo, transferFromTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type mismatch in inputs and outputs for transferFrom with ID 2 (TOK2 vs TOK1)"}))
+			})
+		})
+	})
+
+	Describe("Test ProcessTx PlainTypeRegistration with memory ledger", func() {
+		var (
+			registrationTransaction *token.TokenTransaction
+			registrationTxID        string
+			definition              *token.TokenTypeDefinition
+		)
+
+		newImport := func(quantity uint64) *token.TokenTransaction {
+			return &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-1"), Type: "TOK1", Quantity: quantity},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			registrationTxID = "r1"
+			definition = &token.TokenTypeDefinition{
+				Type:        "TOK1",
+				DisplayName: "Token One",
+				Decimals:    2,
+				MaxSupply:   300,
+				Issuers:     [][]byte{[]byte("issuer-1")},
+			}
+			registrationTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTypeRegistration{
+							PlainTypeRegistration: &token.PlainTypeRegistration{Definition: definition},
+						},
+					},
+				},
+			}
+			fakePublicInfo.PublicReturns([]byte("issuer-1"))
+			memoryLedger = plain.NewMemoryLedger()
+		})
+
+		Context("when a valid registration is provided", func() {
+			BeforeEach(func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("stores the definition and evaluates the registration policy", func() {
+				raw, err := memoryLedger.GetState("tms", "\x00tokenType\x00TOK1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				stored := &token.TokenTypeDefinition{}
+				err = proto.Unmarshal(raw, stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(stored, definition)).To(BeTrue())
+
+				Expect(fakeRegistrationValidator.ValidateCallCount()).To(Equal(1))
+				creator, tt := fakeRegistrationValidator.ValidateArgsForCall(0)
+				Expect(creator).To(Equal(fakePublicInfo))
+				Expect(tt).To(Equal("TOK1"))
+				Expect(fakeIssuingValidator.ValidateCallCount()).To(Equal(0))
+			})
+
+			It("tracks the supply of imports up to the maximum", func() {
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(200), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("i2", fakePublicInfo, newImport(100), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				supply, err := memoryLedger.GetState("tms", "\x00tokenSupply\x00TOK1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supply).To(Equal([]byte("300")))
+			})
+
+			It("rejects imports exceeding the maximum supply", func() {
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(200), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("i2", fakePublicInfo, newImport(101), memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import of 101 tokens of type 'TOK1' exceeds the maximum supply (200 issued of 300)"}))
+			})
+
+			It("rejects imports from issuers that are not listed", func() {
+				fakePublicInfo.PublicReturns([]byte("issuer-2"))
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(1), memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "creator is not an allowed issuer of token type 'TOK1'"}))
+			})
+
+			It("rejects a second registration of the same type", func() {
+				err := verifier.ProcessTx("r2", fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type 'TOK1' is already registered"}))
+			})
+		})
+
+		Context("when the definition has no issuers and no maximum supply", func() {
+			BeforeEach(func() {
+				definition.Issuers = nil
+				definition.MaxSupply = 0
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accepts imports from any creator allowed by the issuing policy", func() {
+				fakePublicInfo.PublicReturns([]byte("issuer-2"))
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(1000), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the quantity imported by each transaction without updating a shared supply", func() {
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(200), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				err = verifier.ProcessTx("i2", fakePublicInfo, newImport(100), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				supply, err := memoryLedger.GetState("tms", "\x00tokenSupply\x00TOK1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supply).To(BeNil())
+				delta, err := memoryLedger.GetState("tms", "\x00tokenSupplyDelta\x00TOK1\x00i1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delta).To(Equal([]byte("200")))
+				delta, err = memoryLedger.GetState("tms", "\x00tokenSupplyDelta\x00TOK1\x00i2\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delta).To(Equal([]byte("100")))
+			})
+		})
+
+		Context("when tokens of the type are imported before its registration", func() {
+			BeforeEach(func() {
+				err := verifier.ProcessTx("i1", fakePublicInfo, newImport(200), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("accounts for them in the supply", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+				supply, err := memoryLedger.GetState("tms", "\x00tokenSupply\x00TOK1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supply).To(Equal([]byte("200")))
+
+				err = verifier.ProcessTx("i2", fakePublicInfo, newImport(101), memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "import of 101 tokens of type 'TOK1' exceeds the maximum supply (200 issued of 300)"}))
+				err = verifier.ProcessTx("i3", fakePublicInfo, newImport(100), memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				supply, err = memoryLedger.GetState("tms", "\x00tokenSupply\x00TOK1\x00")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(supply).To(Equal([]byte("300")))
+			})
+
+			It("rejects a maximum supply lower than the imported quantity", func() {
+				definition.MaxSupply = 199
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "maximum supply of token type 'TOK1' is lower than its current supply (200 issued of 199)"}))
+			})
+		})
+
+		Context("when the definition is missing", func() {
+			BeforeEach(func() {
+				registrationTransaction.GetPlainAction().GetPlainTypeRegistration().Definition = nil
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no token type definition in transaction: r1"}))
+			})
+		})
+
+		Context("when the token type is empty", func() {
+			BeforeEach(func() {
+				definition.Type = ""
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "no token type in token type definition in transaction: r1"}))
+			})
+		})
+
+		Context("when there are too many decimals", func() {
+			BeforeEach(func() {
+				definition.Decimals = 19
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "decimals of token type 'TOK1' must not exceed 18, got 19"}))
+			})
+		})
+
+		Context("when the registration policy check fails", func() {
+			BeforeEach(func() {
+				fakeRegistrationValidator.ValidateReturns(errors.New("no-way-man"))
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type registration policy check failed: no-way-man"}))
+			})
+		})
+
+		Context("when no registration policy is set", func() {
+			BeforeEach(func() {
+				verifier.RegistrationValidator = nil
+			})
+
+			It("returns an error", func() {
+				err := verifier.ProcessTx(registrationTxID, fakePublicInfo, registrationTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type registration is not enabled, transaction: r1"}))
+			})
+		})
+	})
+
+	Describe("Test ProcessTx PlainSwap with memory ledger", func() {
+		var (
+			fakeDeserializer *mockid.Deserializer
+			fakeIdentity     *mockid.Identity
+			swapTransaction  *token.TokenTransaction
+			swapTxID         string
+		)
+
+		BeforeEach(func() {
+			fakeDeserializer = &mockid.Deserializer{}
+			fakeIdentity = &mockid.Identity{}
+			fakeDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+			verifier.Deserializer = fakeDeserializer
+
+			swapTxID = "1"
+			swapTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainSwap{
+							PlainSwap: &token.PlainSwap{
+								Inputs: []*token.InputId{
+									{TxId: "0", Index: 0},
+									{TxId: "0", Index: 1},
+								},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 100},
+									{Owner: []byte("owner-1"), Type: "TOK2", Quantity: 222},
+									{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11},
+								},
+								Signatures: []*token.OwnerSignature{
+									{Signer: []byte("owner-2"), Signature: []byte("owner-2-signature")},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			memoryLedger = plain.NewMemoryLedger()
+			err := verifier.ProcessTx(importTxID, fakePublicInfo, importTransaction, memoryLedger)
+			Expect(err).NotTo(HaveOccurred())
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+		})
+
+		Context("when a valid swap is provided", func() {
+			It("is processed successfully", func() {
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				po, err := memoryLedger.GetState("tms", string("\x00")+"tokenOutput"+string("\x00")+"1"+string("\x00")+"1"+string("\x00"))
+				Expect(err).NotTo(HaveOccurred())
+				output := &token.PlainOutput{}
+				err = proto.Unmarshal(po, output)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal(&token.PlainOutput{Owner: []byte("owner-1"), Type: "TOK2", Quantity: 222}))
+
+				for _, index := range []string{"0", "1"} {
+					spentMarker, err := memoryLedger.GetState("tms", string("\x00")+"tokenInput"+string("\x00")+"0"+string("\x00")+index+string("\x00"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(bytes.Equal(spentMarker, plain.TokenInputSpentMarker)).To(BeTrue())
+				}
+			})
+
+			It("verifies the signature of the counterparty", func() {
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDeserializer.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("owner-2")))
+				Expect(fakeIdentity.ValidateCallCount()).To(Equal(1))
+				message, signature := fakeIdentity.VerifyArgsForCall(0)
+				expectedMessage, err := swapTransaction.GetPlainAction().GetPlainSwap().SigningBytes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(message).To(Equal(expectedMessage))
+				Expect(signature).To(Equal([]byte("owner-2-signature")))
+			})
+		})
+
+		Context("when the counterparty did not sign the swap", func() {
+			It("returns an InvalidTxError", func() {
+				swapTransaction.GetPlainAction().GetPlainSwap().Signatures = nil
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "swap input with ID \x00tokenOutput\x000\x001\x00 not owned by the creator or a signer of the swap"}))
+			})
+		})
+
+		Context("when the signature of the counterparty is invalid", func() {
+			It("returns an InvalidTxError", func() {
+				fakeIdentity.VerifyReturns(errors.New("bad signature"))
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid signature 0 in swap with ID 1: bad signature"}))
+			})
+		})
+
+		Context("when the signer cannot be deserialized", func() {
+			It("returns an InvalidTxError", func() {
+				fakeDeserializer.DeserializeIdentityReturns(nil, errors.New("unknown msp"))
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "signer 0 of swap with ID 1 cannot be deserialized: unknown msp"}))
+			})
+		})
+
+		Context("when the verifier has no deserializer", func() {
+			It("returns an InvalidTxError", func() {
+				verifier.Deserializer = nil
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "cannot verify the signatures of swap with ID 1: no deserializer"}))
+			})
+		})
+
+		Context("when the quantity of a token type is not conserved", func() {
+			It("returns an InvalidTxError", func() {
+				swapTransaction.GetPlainAction().GetPlainSwap().Outputs[1].Quantity = 223
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token sum mismatch in inputs and outputs of type 'TOK2' for swap with ID 1 (223 vs 222)"}))
+			})
+		})
+
+		Context("when the outputs introduce a token type that is not in the inputs", func() {
+			It("returns an InvalidTxError", func() {
+				swapTransaction.GetPlainAction().GetPlainSwap().Outputs[2].Type = "TOK3"
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token type mismatch in inputs and outputs for swap with ID 1"}))
+			})
+		})
+
+		Context("when an output has no owner", func() {
+			It("returns an InvalidTxError", func() {
+				swapTransaction.GetPlainAction().GetPlainSwap().Outputs[2].Owner = nil
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "output 2 has no owner in swap with ID 1"}))
+			})
+		})
+
+		Context("when the same input is spent twice within the same tx", func() {
+			It("returns an InvalidTxError", func() {
+				swap := swapTransaction.GetPlainAction().GetPlainSwap()
+				swap.Inputs = append(swap.Inputs, &token.InputId{TxId: "0", Index: 0})
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "token input '\x00tokenOutput\x000\x000\x00' spent more than once in single swap with txID '1'"}))
+			})
+		})
+
+		Context("when an input has already been spent", func() {
+			It("returns an InvalidTxError", func() {
+				err := verifier.ProcessTx(swapTxID, fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = verifier.ProcessTx("2", fakePublicInfo, swapTransaction, memoryLedger)
+				Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "input with ID \x00tokenOutput\x000\x000\x00 for swap has already been spent"}))
+			})
+		})
+	})
+
+	Describe("ValidateExpectation", func() {
+		var (
+			transferTransaction *token.TokenTransaction
+			transferExpectation *token.TokenExpectation
+			importExpectation   *token.TokenExpectation
+		)
+
+		BeforeEach(func() {
+			fakePublicInfo.PublicReturns([]byte("owner-1"))
+			transferTransaction = &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "0", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 100},
+									{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 11},
+								},
+							},
+						},
+					},
+				},
+			}
+			transferExpectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-2"), Type: "TOK1", Quantity: 100}},
+							},
+						},
+					},
+				},
+			}
+			importExpectation = &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_ImportExpectation{
+							ImportExpectation: &token.PlainTokenExpectation{
+								Outputs: importTransaction.GetPlainAction().GetPlainImport().GetOutputs(),
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts an import matching the expectation", func() {
+			err := verifier.ValidateExpectation(fakePublicInfo, importTransaction, importExpectation)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a transfer matching the expectation with a remaining output", func() {
+			err := verifier.ValidateExpectation(fakePublicInfo, transferTransaction, transferExpectation)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when an import has more outputs than expected", func() {
+			It("returns an error", func() {
+				importExpectation.GetPlainExpectation().GetImportExpectation().Outputs = importExpectation.GetPlainExpectation().GetImportExpectation().Outputs[:1]
+				err := verifier.ValidateExpectation(fakePublicInfo, importTransaction, importExpectation)
+				Expect(err).To(MatchError("number of outputs (2) does not match the expectation (1)"))
+			})
+		})
+
+		Context("when the action does not match the expectation type", func() {
+			It("returns an error", func() {
+				err := verifier.ValidateExpectation(fakePublicInfo, importTransaction, transferExpectation)
+				Expect(err).To(MatchError("expected a transfer action, got *token.PlainTokenAction_PlainImport"))
+			})
+		})
+
+		Context("when an expected output differs", func() {
+			It("returns an error", func() {
+				transferExpectation.GetPlainExpectation().GetTransferExpectation().Outputs[0].Quantity = 99
+				err := verifier.ValidateExpectation(fakePublicInfo, transferTransaction, transferExpectation)
+				Expect(err).To(MatchError("output 0 does not match the expectation"))
+			})
+		})
+
+		Context("when the remaining output has a different type", func() {
+			It("returns an error", func() {
+				transferTransaction.GetPlainAction().GetPlainTransfer().Outputs[1].Type = "TOK2"
+				err := verifier.ValidateExpectation(fakePublicInfo, transferTransaction, transferExpectation)
+				Expect(err).To(MatchError("token type mismatch in remaining output (TOK2 vs TOK1)"))
+			})
+		})
+
+		Context("when the remaining output is not owned by the creator", func() {
+			It("returns an error", func() {
+				transferTransaction.GetPlainAction().GetPlainTransfer().Outputs[1].Owner = []byte("owner-3")
+				err := verifier.ValidateExpectation(fakePublicInfo, transferTransaction, transferExpectation)
+				Expect(err).To(MatchError("the owner of the remaining output is not the creator of the transaction"))
+			})
+		})
+
+		Context("when the expectation is not a plain expectation", func() {
+			It("returns an error", func() {
+				err := verifier.ValidateExpectation(fakePublicInfo, transferTransaction, &token.TokenExpectation{})
+				Expect(err).To(MatchError("no plain expectation in token expectation"))
+			})
+		})
+	})
 })
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tms
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/ledger"
+)
+
+// GetTokenTypeDefinition returns the registered definition of tokenType, or nil if tokenType is not registered.
+func GetTokenTypeDefinition(tokenType string, reader ledger.LedgerReader) (*token.TokenTypeDefinition, error) {
+	typeKey, err := CreateTokenTypeKey(tokenType)
+	if err != nil {
+		return nil, &customtx.InvalidTxError{Msg: fmt.Sprintf("error creating token type key: %s", err)}
+	}
+	definitionBytes, err := reader.GetState(Namespace, typeKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(definitionBytes) == 0 {
+		return nil, nil
+	}
+	definition := &token.TokenTypeDefinition{}
+	err = proto.Unmarshal(definitionBytes, definition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling definition of token type '%s'", tokenType)
+	}
+	return definition, nil
+}
+
+// IsIssuer checks whether the passed identity is allowed to import tokens of the type of definition.
+func IsIssuer(id []byte, definition *token.TokenTypeDefinition) bool {
+	if len(definition.GetIssuers()) == 0 {
+		return true
+	}
+	for _, issuer := range definition.GetIssuers() {
+		if bytes.Equal(id, issuer) {
+			return true
+		}
+	}
+	return false
+}
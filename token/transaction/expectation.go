@@ -0,0 +1,20 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transaction
+
+import (
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+)
+
+//go:generate counterfeiter -o mock/expectation_validator.go -fake-name ExpectationValidator . ExpectationValidator
+
+// ExpectationValidator checks that a token transaction fulfils a token expectation
+type ExpectationValidator interface {
+	// ValidateExpectation returns an error if ttx, created by creator, does not fulfil expectation
+	ValidateExpectation(creator identity.PublicInfo, ttx *token.TokenTransaction, expectation *token.TokenExpectation) error
+}
@@ -9,11 +9,26 @@ package transaction
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/tradeline-tech/fabric/common/flogging"
 	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
 	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
+	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/identity"
+	"github.com/tradeline-tech/fabric/token/tms"
+)
+
+const (
+	// tokenExpectationKeyword is the object type of the ledger keys recording
+	// the token expectations set by the valid chaincode transactions
+	tokenExpectationKeyword = "tokenExpectation"
+	// tokenExpectationFulfilledKeyword is the object type of the ledger keys marking
+	// the chaincode transactions whose token expectation has been fulfilled
+	tokenExpectationFulfilledKeyword = "tokenExpectationFulfilled"
 )
 
 var logger = flogging.MustGetLogger("fabtoken-processor")
@@ -25,23 +40,127 @@ type Processor struct {
 }
 
 func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
+	_, _, _, err := p.processTx(txEnv, simulator, initializingLedger)
+	return err
+}
+
+// GenerateSimulationResultsAt implements the interface 'github.com/hyperledger/fabric/core/ledger/customtx/PositionAwareProcessor'.
+// Besides processing the token transaction, it indexes it by its position in the blockchain
+// if the TMS of the channel supports it.
+func (p *Processor) GenerateSimulationResultsAt(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool, blockNum uint64, txNum uint64) error {
+	ch, ttx, txProcessor, err := p.processTx(txEnv, simulator, initializingLedger)
+	if err != nil {
+		return err
+	}
+
+	indexer, ok := txProcessor.(TxIndexer)
+	if !ok {
+		return nil
+	}
+	err = indexer.IndexTx(ch.TxId, ttx, simulator, blockNum, txNum)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed indexing transaction for channel %s", ch.ChannelId))
+	}
+	return nil
+}
+
+// GenerateEndorserTxSimulationResults implements the interface 'github.com/hyperledger/fabric/core/ledger/customtx/EndorserTxProcessor'.
+// It records in the ledger the token expectation set by the chaincode action, if any, so that the token
+// transactions bound to the chaincode transaction txID can be checked against the state alone.
+func (p *Processor) GenerateEndorserTxSimulationResults(txID string, chaincodeAction *peer.ChaincodeAction, simulator ledger.TxSimulator) error {
+	expectation := chaincodeAction.GetTokenExpectation()
+	if expectation == nil {
+		return nil
+	}
+	expectationKey, err := tms.CreateCompositeKey(tokenExpectationKeyword, []string{txID})
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: error creating expectation key: %s", txID, err)}
+	}
+	expectationBytes, err := proto.Marshal(expectation)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling token expectation of transaction %s", txID)
+	}
+	return simulator.SetState(tms.Namespace, expectationKey, expectationBytes)
+}
+
+// processTx checks the token transaction of txEnv and generates its ledger updates with simulator
+func (p *Processor) processTx(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) (*common.ChannelHeader, *token.TokenTransaction, TMSTxProcessor, error) {
 	// Extract channel header and token transaction
 	ch, ttx, ci, err := UnmarshalTokenTransaction(txEnv.Payload)
 	if err != nil {
-		return errors.WithMessage(err, "failed unmarshalling token transaction")
+		return nil, nil, nil, errors.WithMessage(err, "failed unmarshalling token transaction")
 	}
 
 	// Get a TMSTxProcessor that corresponds to the channel
 	txProcessor, err := p.TMSManager.GetTxProcessor(ch.ChannelId)
 	if err != nil {
-		return errors.WithMessage(err, "failed getting committer")
+		return nil, nil, nil, errors.WithMessage(err, "failed getting committer")
+	}
+
+	// Check that the transaction fulfils the token expectation of the chaincode transaction it is bound to
+	if ttx.GetChaincodeTxId() != "" {
+		err = p.processExpectation(ch, ci, ttx, txProcessor, simulator, initializingLedger)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	// Extract the read dependencies and ledger updates associated to the transaction using simulator
 	err = txProcessor.ProcessTx(ch.TxId, ci, ttx, simulator)
 	if err != nil {
-		return errors.WithMessage(err, fmt.Sprintf("failed committing transaction for channel %s", ch.ChannelId))
+		return nil, nil, nil, errors.WithMessage(err, fmt.Sprintf("failed committing transaction for channel %s", ch.ChannelId))
 	}
 
-	return err
+	return ch, ttx, txProcessor, nil
+}
+
+// processExpectation checks that ttx fulfils the token expectation of the chaincode transaction it is
+// bound to, which must have been committed as valid in an earlier block, and marks that expectation
+// as fulfilled, so that no other token transaction can be bound to the same chaincode transaction.
+// The expectation is read from the state, where it is recorded when the chaincode transaction is
+// committed, so the outcome does not depend on the blocks retained by the peer.
+// When the ledger is being initialized, the block was already validated when it was first committed,
+// hence only the mark is recorded.
+func (p *Processor) processExpectation(ch *common.ChannelHeader, creator identity.PublicInfo, ttx *token.TokenTransaction, txProcessor TMSTxProcessor, simulator ledger.TxSimulator, initializingLedger bool) error {
+	ccTxID := ttx.GetChaincodeTxId()
+	validator, ok := txProcessor.(ExpectationValidator)
+	if !ok {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: token expectations are not supported on channel %s", ch.TxId, ch.ChannelId)}
+	}
+
+	fulfilledKey, err := tms.CreateCompositeKey(tokenExpectationFulfilledKeyword, []string{ccTxID})
+	if err != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: error creating expectation key: %s", ch.TxId, err)}
+	}
+	fulfilledBy, err := simulator.GetState(tms.Namespace, fulfilledKey)
+	if err != nil {
+		return err
+	}
+	if fulfilledBy != nil {
+		return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: the token expectation of chaincode transaction %s is already fulfilled by transaction %s", ch.TxId, ccTxID, fulfilledBy)}
+	}
+
+	if !initializingLedger {
+		expectationKey, err := tms.CreateCompositeKey(tokenExpectationKeyword, []string{ccTxID})
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: error creating expectation key: %s", ch.TxId, err)}
+		}
+		expectationBytes, err := simulator.GetState(tms.Namespace, expectationKey)
+		if err != nil {
+			return err
+		}
+		if expectationBytes == nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: no token expectation set by a valid chaincode transaction %s", ch.TxId, ccTxID)}
+		}
+		expectation := &token.TokenExpectation{}
+		if err := proto.Unmarshal(expectationBytes, expectation); err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: failed unmarshalling the token expectation of chaincode transaction %s: %s", ch.TxId, ccTxID, err)}
+		}
+		err = validator.ValidateExpectation(creator, ttx, expectation)
+		if err != nil {
+			return &customtx.InvalidTxError{Msg: fmt.Sprintf("invalid transaction %s: token transaction does not match the token expectation: %s", ch.TxId, err)}
+		}
+	}
+
+	return simulator.SetState(tms.Namespace, fulfilledKey, []byte(ch.TxId))
 }
@@ -11,8 +11,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 
+	"github.com/tradeline-tech/fabric/core/ledger"
+	"github.com/tradeline-tech/fabric/core/ledger/customtx"
 	"github.com/tradeline-tech/fabric/protos/common"
+	"github.com/tradeline-tech/fabric/protos/peer"
 	"github.com/tradeline-tech/fabric/protos/token"
+	"github.com/tradeline-tech/fabric/token/tms"
+	"github.com/tradeline-tech/fabric/token/tms/plain"
 	"github.com/tradeline-tech/fabric/token/transaction"
 	"github.com/tradeline-tech/fabric/token/transaction/mock"
 )
@@ -128,6 +133,299 @@ var _ = Describe("Processor", func() {
 				Expect(simulator).To(BeNil())
 			})
 		})
+
+		Context("when the token transaction is bound to a chaincode transaction", func() {
+			var (
+				expectation    *token.TokenExpectation
+				importTtx      *token.TokenTransaction
+				simulator      *fakeTxSimulator
+				expectationKey string
+				fulfilledKey   string
+			)
+
+			BeforeEach(func() {
+				expectation = &token.TokenExpectation{
+					Expectation: &token.TokenExpectation_PlainExpectation{
+						PlainExpectation: &token.PlainExpectation{
+							Payload: &token.PlainExpectation_ImportExpectation{
+								ImportExpectation: &token.PlainTokenExpectation{
+									Outputs: []*token.PlainOutput{{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 100}},
+								},
+							},
+						},
+					},
+				}
+				var err error
+				expectationKey, err = tms.CreateCompositeKey("tokenExpectation", []string{"cc-tx"})
+				Expect(err).NotTo(HaveOccurred())
+				fulfilledKey, err = tms.CreateCompositeKey("tokenExpectationFulfilled", []string{"cc-tx"})
+				Expect(err).NotTo(HaveOccurred())
+				expectationBytes, err := proto.Marshal(expectation)
+				Expect(err).NotTo(HaveOccurred())
+				simulator = &fakeTxSimulator{state: map[string][]byte{"tms/" + expectationKey: expectationBytes}}
+
+				importTtx = &token.TokenTransaction{
+					Action: &token.TokenTransaction_PlainAction{
+						PlainAction: &token.PlainTokenAction{
+							Data: &token.PlainTokenAction_PlainImport{
+								PlainImport: &token.PlainImport{
+									Outputs: []*token.PlainOutput{{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 100}},
+								},
+							},
+						},
+					},
+					ChaincodeTxId: "cc-tx",
+				}
+			})
+
+			Context("and the transaction does not fulfil the token expectation", func() {
+				BeforeEach(func() {
+					importTtx.GetPlainAction().GetPlainImport().Outputs[0].Quantity = 1000
+					fakeManager.GetTxProcessorReturns(&plain.Verifier{}, nil)
+				})
+
+				It("rejects the transaction", func() {
+					err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid transaction tx0: token transaction does not match the token expectation: output 0 does not match the expectation"}))
+					Expect(simulator.state).NotTo(HaveKey("tms/" + fulfilledKey))
+				})
+			})
+
+			Context("and the transaction fulfils the token expectation", func() {
+				var verifier *expectationVerifier
+
+				BeforeEach(func() {
+					verifier = &expectationVerifier{
+						TMSTxProcessor:       &mock.TMSTxProcessor{},
+						ExpectationValidator: &mock.ExpectationValidator{},
+					}
+					fakeManager.GetTxProcessorReturns(verifier, nil)
+				})
+
+				It("validates the expectation recorded in the state, marks it fulfilled and processes the transaction", func() {
+					err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(verifier.ValidateExpectationCallCount()).To(Equal(1))
+					_, ttx, validated := verifier.ValidateExpectationArgsForCall(0)
+					Expect(proto.Equal(ttx, importTtx)).To(BeTrue())
+					Expect(proto.Equal(validated, expectation)).To(BeTrue())
+					Expect(simulator.state).To(HaveKeyWithValue("tms/"+fulfilledKey, []byte("tx0")))
+					Expect(verifier.ProcessTxCallCount()).To(Equal(1))
+				})
+
+				Context("and the expectation is already fulfilled", func() {
+					BeforeEach(func() {
+						simulator.state["tms/"+fulfilledKey] = []byte("tx-before")
+					})
+
+					It("rejects the transaction", func() {
+						err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+						Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid transaction tx0: the token expectation of chaincode transaction cc-tx is already fulfilled by transaction tx-before"}))
+						Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("and no valid chaincode transaction recorded the expectation", func() {
+					BeforeEach(func() {
+						delete(simulator.state, "tms/"+expectationKey)
+					})
+
+					It("rejects the transaction", func() {
+						err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+						Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid transaction tx0: no token expectation set by a valid chaincode transaction cc-tx"}))
+						Expect(verifier.ValidateExpectationCallCount()).To(Equal(0))
+						Expect(verifier.ProcessTxCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("and the recorded expectation is malformed", func() {
+					BeforeEach(func() {
+						simulator.state["tms/"+expectationKey] = []byte("garbage")
+					})
+
+					It("rejects the transaction", func() {
+						err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+						Expect(err).To(BeAssignableToTypeOf(&customtx.InvalidTxError{}))
+						Expect(err.Error()).To(HavePrefix("invalid transaction tx0: failed unmarshalling the token expectation of chaincode transaction cc-tx"))
+					})
+				})
+
+				Context("and reading the state fails", func() {
+					BeforeEach(func() {
+						simulator.getStateErr = errors.New("disk on fire")
+					})
+
+					It("returns the error", func() {
+						err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+						Expect(err).To(MatchError("disk on fire"))
+					})
+				})
+
+				Context("and the ledger is being initialized", func() {
+					It("only marks the expectation fulfilled", func() {
+						err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, true)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(verifier.ValidateExpectationCallCount()).To(Equal(0))
+						Expect(simulator.state).To(HaveKeyWithValue("tms/"+fulfilledKey, []byte("tx0")))
+						Expect(verifier.ProcessTxCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("and the channel TMS does not support token expectations", func() {
+				BeforeEach(func() {
+					fakeManager.GetTxProcessorReturns(&mock.TMSTxProcessor{}, nil)
+				})
+
+				It("rejects the transaction", func() {
+					err := txProcessor.GenerateSimulationResults(createEnvelope(importTtx), simulator, false)
+					Expect(err).To(Equal(&customtx.InvalidTxError{Msg: "invalid transaction tx0: token expectations are not supported on channel wild_channel"}))
+				})
+			})
+		})
+	})
+
+	Describe("GenerateEndorserTxSimulationResults", func() {
+		var simulator *fakeTxSimulator
+
+		BeforeEach(func() {
+			simulator = &fakeTxSimulator{state: map[string][]byte{}}
+		})
+
+		It("records the token expectation of the chaincode action", func() {
+			expectation := &token.TokenExpectation{
+				Expectation: &token.TokenExpectation_PlainExpectation{
+					PlainExpectation: &token.PlainExpectation{
+						Payload: &token.PlainExpectation_TransferExpectation{
+							TransferExpectation: &token.PlainTokenExpectation{
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner-1"), Type: "TOK1", Quantity: 100}},
+							},
+						},
+					},
+				},
+			}
+			err := txProcessor.GenerateEndorserTxSimulationResults("cc-tx", &peer.ChaincodeAction{TokenExpectation: expectation}, simulator)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectationKey, err := tms.CreateCompositeKey("tokenExpectation", []string{"cc-tx"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(simulator.state).To(HaveKey("tms/" + expectationKey))
+			recorded := &token.TokenExpectation{}
+			Expect(proto.Unmarshal(simulator.state["tms/"+expectationKey], recorded)).To(Succeed())
+			Expect(proto.Equal(recorded, expectation)).To(BeTrue())
+		})
+
+		Context("when the chaincode action sets no token expectation", func() {
+			It("writes nothing", func() {
+				err := txProcessor.GenerateEndorserTxSimulationResults("cc-tx", &peer.ChaincodeAction{}, simulator)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(simulator.state).To(BeEmpty())
+			})
+		})
 	})
 
+	Describe("GenerateSimulationResultsAt", func() {
+		var verifier *indexingVerifier
+
+		BeforeEach(func() {
+			verifier = &indexingVerifier{
+				TMSTxProcessor: &mock.TMSTxProcessor{},
+				TxIndexer:      &mock.TxIndexer{},
+			}
+			fakeManager.GetTxProcessorReturns(verifier, nil)
+		})
+
+		It("processes the transaction and indexes it by its position", func() {
+			err := txProcessor.GenerateSimulationResultsAt(validEnvelope, nil, false, 12, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.ProcessTxCallCount()).To(Equal(1))
+			Expect(verifier.IndexTxCallCount()).To(Equal(1))
+			txID, ttx, simulator, blockNum, txNum := verifier.IndexTxArgsForCall(0)
+			Expect(txID).To(Equal("tx0"))
+			Expect(proto.Equal(ttx, validTtx)).To(BeTrue())
+			Expect(simulator).To(BeNil())
+			Expect(blockNum).To(Equal(uint64(12)))
+			Expect(txNum).To(Equal(uint64(3)))
+		})
+
+		Context("when the transaction is not valid", func() {
+			BeforeEach(func() {
+				verifier.ProcessTxReturns(&customtx.InvalidTxError{Msg: "no-way-man"})
+			})
+
+			It("does not index it", func() {
+				err := txProcessor.GenerateSimulationResultsAt(validEnvelope, nil, false, 12, 3)
+				Expect(err).To(MatchError("failed committing transaction for channel wild_channel: no-way-man"))
+				Expect(verifier.IndexTxCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when indexing fails", func() {
+			BeforeEach(func() {
+				verifier.IndexTxReturns(errors.New("wild potato"))
+			})
+
+			It("returns an error", func() {
+				err := txProcessor.GenerateSimulationResultsAt(validEnvelope, nil, false, 12, 3)
+				Expect(err).To(MatchError("failed indexing transaction for channel wild_channel: wild potato"))
+			})
+		})
+
+		Context("when the channel TMS does not index transactions", func() {
+			BeforeEach(func() {
+				fakeManager.GetTxProcessorReturns(&mock.TMSTxProcessor{}, nil)
+			})
+
+			It("only processes the transaction", func() {
+				err := txProcessor.GenerateSimulationResultsAt(validEnvelope, nil, false, 12, 3)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
 })
+
+type indexingVerifier struct {
+	*mock.TMSTxProcessor
+	*mock.TxIndexer
+}
+
+type expectationVerifier struct {
+	*mock.TMSTxProcessor
+	*mock.ExpectationValidator
+}
+
+// fakeTxSimulator keeps the state written by the processor in memory
+type fakeTxSimulator struct {
+	ledger.TxSimulator
+	state       map[string][]byte
+	getStateErr error
+}
+
+func (s *fakeTxSimulator) GetState(namespace string, key string) ([]byte, error) {
+	if s.getStateErr != nil {
+		return nil, s.getStateErr
+	}
+	return s.state[namespace+"/"+key], nil
+}
+
+func (s *fakeTxSimulator) SetState(namespace string, key string, value []byte) error {
+	s.state[namespace+"/"+key] = value
+	return nil
+}
+
+func createEnvelope(ttx *token.TokenTransaction) *common.Envelope {
+	ch := &common.ChannelHeader{
+		Type: int32(common.HeaderType_TOKEN_TRANSACTION), ChannelId: "wild_channel",
+		TxId: "tx0",
+	}
+	marshaledChannelHeader, err := proto.Marshal(ch)
+	Expect(err).ToNot(HaveOccurred())
+	marshaledData, err := proto.Marshal(ttx)
+	Expect(err).ToNot(HaveOccurred())
+	marshaledPayload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: marshaledChannelHeader},
+		Data:   marshaledData,
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return &common.Envelope{Payload: marshaledPayload}
+}
@@ -14,6 +14,7 @@ import (
 
 //go:generate counterfeiter -o mock/tms_tx_processor.go -fake-name TMSTxProcessor . TMSTxProcessor
 //go:generate counterfeiter -o mock/tms_manager.go -fake-name TMSManager . TMSManager
+//go:generate counterfeiter -o mock/tx_indexer.go -fake-name TxIndexer . TxIndexer
 
 // TMSTxProcessor is used to generate the read-dependencies of a token transaction
 // (read-set) along with the ledger updates triggered by that transaction
@@ -24,6 +25,13 @@ type TMSTxProcessor interface {
 	ProcessTx(txID string, creator identity.PublicInfo, ttx *token.TokenTransaction, simulator ledger.LedgerWriter) error
 }
 
+// TxIndexer is implemented by the TMSTxProcessors that index the token transactions
+// they process by their position in the blockchain
+type TxIndexer interface {
+	// IndexTx indexes ttx, processed by ProcessTx, as transaction txNum of block blockNum
+	IndexTx(txID string, ttx *token.TokenTransaction, simulator ledger.LedgerWriter, blockNum uint64, txNum uint64) error
+}
+
 type TMSManager interface {
 	// GetTxProcessor returns a TxProcessor for TMS transactions for the provided channel
 	GetTxProcessor(channel string) (TMSTxProcessor, error)